@@ -2,45 +2,144 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
-	"fmt"
 	"log"
-	"net/http"
+	"net"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/lonegunmanb/terraform-mcp-eva/pkg"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfschema"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/transport"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 func main() {
-	mode := flag.String("mode", getenv("TRANSPORT_MODE", "stdio"), "transport mode, can be `stdio` or `streamable-http`")
-	host := flag.String("host", getenv("TRANSPORT_HOST", "127.0.0.1"), "host for streamable-http server")
-	port := flag.String("port", getenv("TRANSPORT_PORT", "8080"), "port for streamable-http server")
+	defaultLogLevel := "info"
+	if strings.EqualFold(os.Getenv(gophon.DebugEnv), "true") {
+		defaultLogLevel = "debug"
+	}
+	logLevel := flag.String("log-level", getenv(logging.LevelEnv, defaultLogLevel), "minimum level of the server logs written to stderr, can be `debug`, `info`, `warn` or `error`")
+	logFormat := flag.String("log-format", getenv(logging.FormatEnv, "text"), "format of the server logs, can be `text` or `json`")
+	mode := flag.String("mode", getenv("TRANSPORT_MODE", "stdio"), "transport mode, can be `stdio`, `streamable-http` or `sse`")
+	host := flag.String("host", getenv("TRANSPORT_HOST", "127.0.0.1"), "host for the streamable-http and sse servers")
+	port := flag.String("port", getenv("TRANSPORT_PORT", "8080"), "port for the streamable-http and sse servers")
+	sessionIdleTimeout := flag.Duration("session-idle-timeout", getenvDuration(transport.SessionIdleTimeoutEnv, transport.DefaultSessionIdleTimeout), "how long a streamable-http session is kept without requests, `0` keeps sessions until their client deletes them")
+	maxSessions := flag.Int("max-sessions", getenvInt(transport.MaxSessionsEnv, 0), "maximum number of concurrent streamable-http sessions, `0` doesn't limit them")
+	authConfig := flag.String("auth-config", getenv(transport.AuthConfigEnv, ""), "JSON file with the API keys and OIDC token introspection authenticating streamable-http and sse clients")
+	apiKeys := flag.String("api-keys", getenv(transport.APIKeysEnv, ""), "comma-separated API keys allowed to call every tool over streamable-http and sse")
+	metricsAddr := flag.String("metrics-addr", getenv(telemetry.MetricsAddrEnv, ""), "listen address of the Prometheus `/metrics` endpoint, e.g. `:9090`, metrics aren't served when empty")
+	preload := flag.String("preload", getenv(tfschema.PreloadEnv, ""), "comma-separated provider schemas to load in the background at startup, e.g. `hashicorp/azurerm@latest,Azure/azapi@latest`")
+	remoteIndexes := flag.String("gophon-indexes", getenv(gophon.RemoteIndexesEnv, ""), "comma-separated gophon indexed namespaces to register, e.g. `github.com/contoso/provider/internal=contoso/provider-index@github.com/contoso/provider`")
+	enabledTools := flag.String("enabled-tools", getenv(pkg.EnabledToolsEnv, ""), "comma-separated tools to register, glob patterns like `azapi_*` are supported, all tools are registered when empty")
+	disabledTools := flag.String("disabled-tools", getenv(pkg.DisabledToolsEnv, ""), "comma-separated tools not to register, e.g. `tflint_scan,conftest_scan`")
+	toolsConfig := flag.String("tools-config", getenv(pkg.ToolsConfigEnv, ""), "JSON file with the `enabled_tools` and `disabled_tools` lists")
 	flag.Parse()
 
+	if err := logging.Init(*logLevel, *logFormat); err != nil {
+		log.Fatalf("failed to initialize logging: %v", err)
+	}
+
+	toolFilter, err := pkg.ParseToolFilter(*enabledTools, *disabledTools, *toolsConfig)
+	if err != nil {
+		log.Fatalf("failed to parse tool filter: %v", err)
+	}
+
+	indexes, err := gophon.ParseRemoteIndexes(*remoteIndexes)
+	if err != nil {
+		log.Fatalf("failed to parse gophon remote indexes: %v", err)
+	}
+	gophon.RegisterRemoteIndexes(indexes)
+
+	// exitCode is set by failures that must still shut the tracing down, os.Exit runs after the other deferred calls
+	exitCode := 0
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
+
+	shutdownTracing, err := telemetry.InitTracing(context.Background(), "0.1.0")
+	if err != nil {
+		log.Fatalf("failed to initialize tracing: %v", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("failed to shutdown tracing: %v", err)
+		}
+	}()
+
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "mcp-ever",
 		Version: "0.1.0",
 	}, nil)
-	pkg.RegisterMcpServer(server)
+	if err := pkg.RegisterMcpServer(server, toolFilter); err != nil {
+		log.Fatalf("failed to register tools: %v", err)
+	}
 
+	preloadReqs, err := tfschema.ParsePreloadList(*preload)
+	if err != nil {
+		log.Fatalf("failed to parse preload list: %v", err)
+	}
+	if len(preloadReqs) > 0 {
+		go func() {
+			if err := tfschema.WarmUp(context.Background(), preloadReqs); err != nil {
+				log.Printf("failed to preload provider schemas: %v", err)
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// metricsErr receives the error of the metrics server, which stops the MCP server when it fails
+	metricsErr := make(chan error, 1)
+	if *metricsAddr != "" {
+		go func() {
+			err := telemetry.ServeMetrics(ctx, *metricsAddr)
+			if err != nil {
+				stop()
+			}
+			metricsErr <- err
+		}()
+	} else {
+		metricsErr <- nil
+	}
 	switch *mode {
 	case "stdio":
-		if err := server.Run(context.Background(), mcp.NewStdioTransport()); err != nil {
+		if err := server.Run(ctx, mcp.NewStdioTransport()); err != nil && !errors.Is(err, context.Canceled) {
 			log.Fatal(err)
 		}
-	case "streamable-http":
-		addr := fmt.Sprintf("%s:%s", *host, *port)
-		log.Printf("MCP server serving at %s", addr)
-		handler := mcp.NewSSEHandler(func(request *http.Request) *mcp.Server {
-			return server
+	case transport.ModeStreamableHTTP, transport.ModeSSE:
+		auth, err := transport.LoadAuthConfig(*authConfig, *apiKeys, os.Getenv(transport.OIDCClientSecretEnv))
+		if err != nil {
+			log.Fatalf("failed to load auth config: %v", err)
+		}
+		err = transport.ServeHTTP(ctx, server, transport.HTTPOptions{
+			Mode:               *mode,
+			Addr:               net.JoinHostPort(*host, *port),
+			SessionIdleTimeout: *sessionIdleTimeout,
+			MaxSessions:        *maxSessions,
+			Auth:               auth,
 		})
-		if err := http.ListenAndServe(addr, handler); err != nil {
-			log.Fatalf("failed to start streamable-http server: %v", err)
+		if err != nil {
+			log.Fatalf("failed to serve %s: %v", *mode, err)
 		}
 	default:
 		log.Fatalf("unknown mode: %s", *mode)
 	}
+	stop()
+	if err := <-metricsErr; err != nil {
+		log.Printf("failed to serve metrics: %v", err)
+		exitCode = 1
+	}
 }
 
 func getenv(key, fallback string) string {
@@ -49,3 +148,27 @@ func getenv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getenvDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	return d
+}
+
+func getenvInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	return i
+}
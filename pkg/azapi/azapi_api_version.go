@@ -2,13 +2,63 @@ package azapi
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+
 	"github.com/ms-henglu/go-azure-types/types"
 )
 
+// azureSchemaLoader loads the embedded go-azure-types index once, the index is large
+var azureSchemaLoader = types.DefaultAzureSchemaLoader()
+
 func GetApiVersions(resourceType string) ([]string, error) {
-	versions := types.DefaultAzureSchemaLoader().ListApiVersions(resourceType)
+	versions := azureSchemaLoader.ListApiVersions(resourceType)
 	if len(versions) == 0 {
 		return nil, fmt.Errorf("no API versions found for resource type %s", resourceType)
 	}
 	return versions, nil
 }
+
+// ApiVersion is an api-version of a resource type with its release status
+type ApiVersion struct {
+	ApiVersion   string `json:"api_version"`
+	Preview      bool   `json:"preview,omitempty"`
+	LatestStable bool   `json:"latest_stable,omitempty"`
+}
+
+// ApiVersionList lists the api-versions of a resource type from the newest to the oldest. LatestStable is empty when
+// the resource type only has preview versions, prefer it over preview versions.
+type ApiVersionList struct {
+	ResourceType string `json:"resource_type"`
+	LatestStable string `json:"latest_stable,omitempty"`
+	// Newest is the newest api-version, which may be a preview version
+	Newest      string       `json:"newest"`
+	ApiVersions []ApiVersion `json:"api_versions"`
+}
+
+// ListApiVersions returns the api-versions of resourceType sorted from the newest to the oldest, marking the preview
+// versions and the latest stable version
+func ListApiVersions(resourceType string) (*ApiVersionList, error) {
+	versions, err := GetApiVersions(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	sorted := slices.Clone(versions)
+	sort.Slice(sorted, func(i, j int) bool {
+		return newerApiVersion(sorted[i], sorted[j])
+	})
+	list := &ApiVersionList{
+		ResourceType: resourceType,
+		Newest:       sorted[0],
+		ApiVersions:  make([]ApiVersion, 0, len(sorted)),
+	}
+	for _, v := range sorted {
+		version := ApiVersion{ApiVersion: v, Preview: isPreviewApiVersion(v)}
+		if !version.Preview && list.LatestStable == "" {
+			version.LatestStable = true
+			list.LatestStable = v
+		}
+		list.ApiVersions = append(list.ApiVersions, version)
+	}
+	return list, nil
+}
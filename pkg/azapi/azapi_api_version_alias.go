@@ -0,0 +1,73 @@
+package azapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// api-version aliases resolved against the available versions of a resource type
+const (
+	// ApiVersionLatest selects the newest stable version, or the newest preview version when there's no stable version
+	ApiVersionLatest = "latest"
+	// ApiVersionLatestStable selects the newest stable version
+	ApiVersionLatestStable = "latest-stable"
+)
+
+// ResolveApiVersion resolves the `latest` and `latest-stable` aliases to a concrete api-version of resourceType,
+// other api-versions are returned as is
+func ResolveApiVersion(resourceType, apiVersion string) (string, error) {
+	alias := strings.ToLower(strings.TrimSpace(apiVersion))
+	if alias != ApiVersionLatest && alias != ApiVersionLatestStable {
+		return apiVersion, nil
+	}
+	versions, err := GetApiVersions(resourceType)
+	if err != nil {
+		return "", err
+	}
+	stable, preview := latestApiVersions(versions)
+	switch {
+	case stable != "":
+		return stable, nil
+	case alias == ApiVersionLatest:
+		return preview, nil
+	}
+	return "", fmt.Errorf("no stable API version found for resource type %s, use %q to select the newest preview version", resourceType, ApiVersionLatest)
+}
+
+// latestApiVersions returns the newest stable and the newest preview api-version of versions, empty when there's none
+func latestApiVersions(versions []string) (stable, preview string) {
+	for _, v := range versions {
+		if isPreviewApiVersion(v) {
+			if newerApiVersion(v, preview) {
+				preview = v
+			}
+		} else if newerApiVersion(v, stable) {
+			stable = v
+		}
+	}
+	return stable, preview
+}
+
+func isPreviewApiVersion(apiVersion string) bool {
+	return strings.Contains(strings.ToLower(apiVersion), "preview")
+}
+
+// newerApiVersion compares api-versions by their date, like `2024-11-01` or `2024-11-01-preview`; on the same date
+// the version without suffix is newer
+func newerApiVersion(v, than string) bool {
+	if than == "" {
+		return true
+	}
+	date, thanDate := apiVersionDate(v), apiVersionDate(than)
+	if date != thanDate {
+		return date > thanDate
+	}
+	return len(v) < len(than) || (len(v) == len(than) && v > than)
+}
+
+func apiVersionDate(apiVersion string) string {
+	if len(apiVersion) >= len("2006-01-02") {
+		return apiVersion[:len("2006-01-02")]
+	}
+	return apiVersion
+}
@@ -0,0 +1,47 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveApiVersion(t *testing.T) {
+	resourceType := "Microsoft.Compute/virtualMachines"
+	versions, err := GetApiVersions(resourceType)
+	require.NoError(t, err)
+
+	for _, alias := range []string{"latest", "Latest-Stable"} {
+		t.Run(alias, func(t *testing.T) {
+			resolved, err := ResolveApiVersion(resourceType, alias)
+			require.NoError(t, err)
+			assert.Contains(t, versions, resolved)
+			assert.NotContains(t, resolved, "preview")
+			for _, v := range versions {
+				if !isPreviewApiVersion(v) {
+					assert.GreaterOrEqual(t, resolved, v)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveApiVersion_Concrete(t *testing.T) {
+	resolved, err := ResolveApiVersion("Microsoft.Compute/virtualMachines", "2024-11-01")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-11-01", resolved)
+}
+
+func TestResolveApiVersion_UnknownResourceType(t *testing.T) {
+	_, err := ResolveApiVersion("Microsoft.DoesNotExist/things", "latest")
+	assert.ErrorContains(t, err, "no API versions found for resource type Microsoft.DoesNotExist/things")
+}
+
+func TestNewerApiVersion(t *testing.T) {
+	assert.True(t, newerApiVersion("2024-11-01", ""))
+	assert.True(t, newerApiVersion("2024-11-01", "2024-07-01"))
+	assert.True(t, newerApiVersion("2024-11-01", "2024-11-01-preview"))
+	assert.False(t, newerApiVersion("2024-11-01-preview", "2024-11-01"))
+	assert.True(t, newerApiVersion("2025-01-01-preview", "2024-11-01"))
+}
@@ -0,0 +1,334 @@
+package azapi
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ms-henglu/go-azure-types/types"
+)
+
+// Kinds of property changes between api-versions
+const (
+	ChangeRemoved       = "removed"
+	ChangeRenamed       = "renamed"
+	ChangeRetyped       = "retyped"
+	ChangeNowRequired   = "now_required"
+	ChangeNowReadOnly   = "now_read_only"
+	ChangeValuesRemoved = "values_removed"
+	ChangeAdded         = "added"
+	ChangeValuesAdded   = "values_added"
+)
+
+// PropertyChange is a change of a body property between two api-versions, Path is the path in the older api-version
+// like `body.properties.sku.name`
+type PropertyChange struct {
+	Path     string `json:"path"`
+	Kind     string `json:"kind"`
+	Detail   string `json:"detail"`
+	Breaking bool   `json:"breaking"`
+}
+
+// ApiVersionComparison lists the property changes from FromApiVersion to ToApiVersion, breaking changes first
+type ApiVersionComparison struct {
+	ResourceType    string           `json:"resource_type"`
+	FromApiVersion  string           `json:"from_api_version"`
+	ToApiVersion    string           `json:"to_api_version"`
+	BreakingChanges int              `json:"breaking_changes"`
+	Changes         []PropertyChange `json:"changes"`
+}
+
+// propertyShape is what matters about a property to compare api-versions
+type propertyShape struct {
+	kind     string
+	required bool
+	readOnly bool
+	// values are the possible values of closed enums
+	values []string
+}
+
+// CompareApiVersions compares the body properties of resourceType between two api-versions and reports removed,
+// possibly renamed and retyped properties, properties that became required or read-only and enum values that were
+// removed, which break configurations bumping api_version, as well as added properties and enum values. When to is
+// empty, the latest stable api-version is used; when from is empty, the stable api-version before to is used.
+// Changes under a removed, renamed or retyped property aren't reported.
+func CompareApiVersions(resourceType, from, to string) (*ApiVersionComparison, error) {
+	from, to, err := comparedApiVersions(resourceType, from, to)
+	if err != nil {
+		return nil, err
+	}
+	fromShapes, err := propertyShapes(resourceType, from)
+	if err != nil {
+		return nil, err
+	}
+	toShapes, err := propertyShapes(resourceType, to)
+	if err != nil {
+		return nil, err
+	}
+	comparison := &ApiVersionComparison{
+		ResourceType:   resourceType,
+		FromApiVersion: from,
+		ToApiVersion:   to,
+		Changes:        compareShapes(fromShapes, toShapes),
+	}
+	for _, change := range comparison.Changes {
+		if change.Breaking {
+			comparison.BreakingChanges++
+		}
+	}
+	return comparison, nil
+}
+
+// compareShapes returns the changes from the fromShapes to the toShapes properties, breaking changes first
+func compareShapes(fromShapes, toShapes map[string]propertyShape) []PropertyChange {
+	var removed, added []string
+	changes := make([]PropertyChange, 0)
+	for _, path := range sortedShapePaths(fromShapes) {
+		old := fromShapes[path]
+		current, ok := toShapes[path]
+		if !ok {
+			removed = append(removed, path)
+			continue
+		}
+		switch {
+		case old.kind != current.kind:
+			changes = append(changes, PropertyChange{Path: path, Kind: ChangeRetyped, Detail: fmt.Sprintf("type changed from %s to %s", old.kind, current.kind), Breaking: !old.readOnly})
+			continue
+		case !old.readOnly && current.readOnly:
+			changes = append(changes, PropertyChange{Path: path, Kind: ChangeNowReadOnly, Detail: "property became read-only and can't be set anymore", Breaking: true})
+			continue
+		case !old.required && current.required && !current.readOnly:
+			changes = append(changes, PropertyChange{Path: path, Kind: ChangeNowRequired, Detail: "property became required", Breaking: true})
+		}
+		if len(old.values) > 0 && len(current.values) > 0 {
+			if gone := missingValues(old.values, current.values); len(gone) > 0 {
+				changes = append(changes, PropertyChange{Path: path, Kind: ChangeValuesRemoved, Detail: "possible values removed: " + strings.Join(gone, ", "), Breaking: !old.readOnly})
+			}
+			if more := missingValues(current.values, old.values); len(more) > 0 {
+				changes = append(changes, PropertyChange{Path: path, Kind: ChangeValuesAdded, Detail: "possible values added: " + strings.Join(more, ", ")})
+			}
+		}
+	}
+	for _, path := range sortedShapePaths(toShapes) {
+		if _, ok := fromShapes[path]; !ok {
+			added = append(added, path)
+		}
+	}
+
+	renamed := make(map[string]string)
+	renameTargets := make(map[string]bool)
+	for _, path := range removed {
+		if target := renameTarget(path, fromShapes[path], added, toShapes, renameTargets); target != "" {
+			renamed[path] = target
+			renameTargets[target] = true
+		}
+	}
+	for _, path := range removed {
+		shape := fromShapes[path]
+		if target, ok := renamed[path]; ok {
+			changes = append(changes, PropertyChange{Path: path, Kind: ChangeRenamed, Detail: fmt.Sprintf("possibly renamed to %s", target), Breaking: !shape.readOnly})
+			continue
+		}
+		detail := "property was removed"
+		if shape.readOnly {
+			detail = "read-only property was removed, only response_export_values are affected"
+		}
+		changes = append(changes, PropertyChange{Path: path, Kind: ChangeRemoved, Detail: detail, Breaking: !shape.readOnly})
+	}
+	for _, path := range added {
+		if renameTargets[path] || hasAncestor(path, renameTargets) {
+			continue
+		}
+		shape := toShapes[path]
+		detail := "property was added"
+		if shape.required && !shape.readOnly {
+			detail = "required property was added"
+		}
+		changes = append(changes, PropertyChange{Path: path, Kind: ChangeAdded, Detail: detail, Breaking: shape.required && !shape.readOnly})
+	}
+
+	changes = withoutNestedChanges(changes)
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Breaking != changes[j].Breaking {
+			return changes[i].Breaking
+		}
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// comparedApiVersions defaults to to the latest stable api-version, and from to the stable api-version before to
+func comparedApiVersions(resourceType, from, to string) (string, string, error) {
+	list, err := ListApiVersions(resourceType)
+	if err != nil {
+		return "", "", err
+	}
+	if to == "" {
+		to = list.LatestStable
+		if to == "" {
+			to = list.Newest
+		}
+	}
+	if from == "" {
+		for _, v := range list.ApiVersions {
+			if !v.Preview && newerApiVersion(to, v.ApiVersion) && v.ApiVersion != to {
+				from = v.ApiVersion
+				break
+			}
+		}
+		if from == "" {
+			return "", "", fmt.Errorf("%s has no stable api-version before %s to compare with", resourceType, to)
+		}
+	}
+	if from == to {
+		return "", "", fmt.Errorf("can't compare api-version %s with itself", from)
+	}
+	return from, to, nil
+}
+
+// propertyShapes returns the shapes of the body properties of resourceType@apiVersion keyed by path. The properties of
+// read-only properties can't be set and aren't compared.
+func propertyShapes(resourceType, apiVersion string) (map[string]propertyShape, error) {
+	apiType, err := getAzApiType(resourceType, apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get azapi type for resource %s api-version %s: %w", resourceType, apiVersion, err)
+	}
+	return bodyShapes(apiType.Body.Type), nil
+}
+
+// bodyShapes returns the shapes of the properties of bodyType keyed by path, see propertyShapes
+func bodyShapes(bodyType types.TypeBase) map[string]propertyShape {
+	shapes := make(map[string]propertyShape)
+	walkBodyProperties(bodyType, func(path, _ string, property types.ObjectProperty) bool {
+		if _, ok := shapes[path]; ok {
+			return true
+		}
+		shape := propertyShape{required: property.IsRequired(), readOnly: property.IsReadOnly(), kind: "any"}
+		if property.Type != nil {
+			shape.kind = typeKind(property.Type.Type)
+			if union, ok := property.Type.Type.(*types.UnionType); ok && shape.kind == "string" && !openUnion(union) {
+				shape.values = literalValues(union)
+				sort.Strings(shape.values)
+			}
+		}
+		shapes[path] = shape
+		return !shape.readOnly
+	})
+	return shapes
+}
+
+// typeKind describes a type for comparisons, like `string`, `array(object)` or `map(string)`. Enums are strings.
+func typeKind(t types.TypeBase) string {
+	switch v := t.(type) {
+	case *types.StringType, *types.StringLiteralType:
+		return "string"
+	case *types.IntegerType:
+		return "integer"
+	case *types.BooleanType:
+		return "boolean"
+	case *types.UnionType:
+		for _, element := range v.Elements {
+			if element == nil || element.Type == nil {
+				continue
+			}
+			switch element.Type.(type) {
+			case *types.StringType, *types.StringLiteralType:
+			default:
+				return "union"
+			}
+		}
+		return "string"
+	case *types.ArrayType:
+		if v.ItemType == nil {
+			return "array(any)"
+		}
+		return fmt.Sprintf("array(%s)", typeKind(v.ItemType.Type))
+	case *types.ObjectType:
+		if len(v.Properties) == 0 && v.AdditionalProperties != nil {
+			return fmt.Sprintf("map(%s)", typeKind(v.AdditionalProperties.Type))
+		}
+		return "object"
+	case *types.DiscriminatedObjectType:
+		return "object"
+	}
+	return "any"
+}
+
+// openUnion reports whether a union accepts any string besides its literal values
+func openUnion(union *types.UnionType) bool {
+	for _, element := range union.Elements {
+		if element != nil {
+			if _, ok := element.Type.(*types.StringType); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renameTarget returns the added sibling property a removed property was likely renamed to: one of the same type whose
+// name only differs in case or by at most two characters
+func renameTarget(path string, shape propertyShape, added []string, toShapes map[string]propertyShape, taken map[string]bool) string {
+	parent, name := path[:strings.LastIndex(path, ".")], lastSegment(path)
+	candidates := make(map[string]types.ObjectProperty)
+	for _, a := range added {
+		if a[:strings.LastIndex(a, ".")] != parent || toShapes[a].kind != shape.kind || taken[a] {
+			continue
+		}
+		candidates[lastSegment(a)] = types.ObjectProperty{}
+	}
+	if target := closestProperty(name, candidates); target != "" {
+		return parent + "." + target
+	}
+	return ""
+}
+
+// missingValues returns the values missing from other
+func missingValues(values, other []string) []string {
+	var missing []string
+	for _, v := range values {
+		if !slices.Contains(other, v) {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// withoutNestedChanges drops the changes under removed, renamed, retyped or added properties, they follow from them
+func withoutNestedChanges(changes []PropertyChange) []PropertyChange {
+	roots := make(map[string]bool)
+	for _, c := range changes {
+		switch c.Kind {
+		case ChangeRemoved, ChangeRenamed, ChangeRetyped, ChangeAdded:
+			roots[c.Path] = true
+		}
+	}
+	result := make([]PropertyChange, 0, len(changes))
+	for _, c := range changes {
+		if !hasAncestor(c.Path, roots) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// hasAncestor reports whether a parent path of path is in paths
+func hasAncestor(path string, paths map[string]bool) bool {
+	for strings.Contains(path, ".") {
+		path = path[:strings.LastIndex(path, ".")]
+		if paths[path] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedShapePaths(shapes map[string]propertyShape) []string {
+	paths := make([]string, 0, len(shapes))
+	for p := range shapes {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
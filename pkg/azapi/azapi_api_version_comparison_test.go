@@ -0,0 +1,76 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareApiVersions(t *testing.T) {
+	comparison, err := CompareApiVersions("Microsoft.Web/sites", "2024-04-01", "2024-11-01")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-04-01", comparison.FromApiVersion)
+	assert.Equal(t, "2024-11-01", comparison.ToApiVersion)
+	assert.Equal(t, 4, comparison.BreakingChanges)
+	assert.Equal(t, PropertyChange{
+		Path:     "body.properties.vnetBackupRestoreEnabled",
+		Kind:     ChangeRemoved,
+		Detail:   "property was removed",
+		Breaking: true,
+	}, comparison.Changes[0])
+	assert.Contains(t, comparison.Changes, PropertyChange{
+		Path:   "body.properties.outboundVnetRouting",
+		Kind:   ChangeAdded,
+		Detail: "property was added",
+	})
+}
+
+func TestCompareApiVersions_DefaultVersions(t *testing.T) {
+	list, err := ListApiVersions("Microsoft.KeyVault/vaults")
+	require.NoError(t, err)
+	comparison, err := CompareApiVersions("Microsoft.KeyVault/vaults", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, list.LatestStable, comparison.ToApiVersion)
+	assert.True(t, newerApiVersion(comparison.ToApiVersion, comparison.FromApiVersion))
+	assert.False(t, isPreviewApiVersion(comparison.FromApiVersion))
+}
+
+func TestCompareApiVersions_SameVersion(t *testing.T) {
+	_, err := CompareApiVersions("Microsoft.KeyVault/vaults", "2023-07-01", "2023-07-01")
+	assert.Error(t, err)
+}
+
+func TestCompareShapes(t *testing.T) {
+	from := map[string]propertyShape{
+		"body.properties":              {kind: "object"},
+		"body.properties.subnetId":     {kind: "string"},
+		"body.properties.size":         {kind: "integer"},
+		"body.properties.mode":         {kind: "string", values: []string{"Auto", "Manual", "Off"}},
+		"body.properties.tier":         {kind: "string"},
+		"body.properties.status":       {kind: "string", readOnly: true},
+		"body.properties.network":      {kind: "object"},
+		"body.properties.network.cidr": {kind: "string"},
+		"body.properties.enabled":      {kind: "boolean"},
+	}
+	to := map[string]propertyShape{
+		"body.properties":          {kind: "object"},
+		"body.properties.subnetID": {kind: "string"},
+		"body.properties.size":     {kind: "string"},
+		"body.properties.mode":     {kind: "string", values: []string{"Auto", "Disabled", "Manual"}},
+		"body.properties.tier":     {kind: "string", required: true},
+		"body.properties.enabled":  {kind: "boolean", readOnly: true},
+		"body.properties.zones":    {kind: "array(string)", required: true},
+	}
+	assert.Equal(t, []PropertyChange{
+		{Path: "body.properties.enabled", Kind: ChangeNowReadOnly, Detail: "property became read-only and can't be set anymore", Breaking: true},
+		{Path: "body.properties.mode", Kind: ChangeValuesRemoved, Detail: "possible values removed: Off", Breaking: true},
+		{Path: "body.properties.network", Kind: ChangeRemoved, Detail: "property was removed", Breaking: true},
+		{Path: "body.properties.size", Kind: ChangeRetyped, Detail: "type changed from integer to string", Breaking: true},
+		{Path: "body.properties.subnetId", Kind: ChangeRenamed, Detail: "possibly renamed to body.properties.subnetID", Breaking: true},
+		{Path: "body.properties.tier", Kind: ChangeNowRequired, Detail: "property became required", Breaking: true},
+		{Path: "body.properties.zones", Kind: ChangeAdded, Detail: "required property was added", Breaking: true},
+		{Path: "body.properties.mode", Kind: ChangeValuesAdded, Detail: "possible values added: Disabled"},
+		{Path: "body.properties.status", Kind: ChangeRemoved, Detail: "read-only property was removed, only response_export_values are affected"},
+	}, compareShapes(from, to))
+}
@@ -1,9 +1,11 @@
 package azapi
 
 import (
+	"strings"
+	"testing"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"testing"
 )
 
 func TestGetApiVersions(t *testing.T) {
@@ -12,3 +14,32 @@ func TestGetApiVersions(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, versions, "2024-11-01", "Expected API version 2024-11-01 to be in the list of versions for %s", resourceType)
 }
+
+func TestListApiVersions(t *testing.T) {
+	list, err := ListApiVersions("Microsoft.Compute/virtualMachines")
+	require.NoError(t, err)
+	require.NotEmpty(t, list.ApiVersions)
+	assert.Equal(t, list.ApiVersions[0].ApiVersion, list.Newest)
+
+	latestStable, err := ResolveApiVersion("Microsoft.Compute/virtualMachines", ApiVersionLatestStable)
+	require.NoError(t, err)
+	assert.Equal(t, latestStable, list.LatestStable)
+
+	latestStableCount := 0
+	for i, v := range list.ApiVersions {
+		assert.Equal(t, strings.Contains(v.ApiVersion, "preview"), v.Preview, v.ApiVersion)
+		if v.LatestStable {
+			latestStableCount++
+			assert.Equal(t, latestStable, v.ApiVersion)
+		}
+		if i > 0 {
+			assert.True(t, newerApiVersion(list.ApiVersions[i-1].ApiVersion, v.ApiVersion), "%s must be newer than %s", list.ApiVersions[i-1].ApiVersion, v.ApiVersion)
+		}
+	}
+	assert.Equal(t, 1, latestStableCount)
+}
+
+func TestListApiVersions_Unknown(t *testing.T) {
+	_, err := ListApiVersions("Microsoft.DoesNotExist/things")
+	assert.Error(t, err)
+}
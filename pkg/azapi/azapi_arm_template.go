@@ -0,0 +1,542 @@
+package azapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/ms-henglu/go-azure-types/types"
+)
+
+// armTemplateOnlyProperties are ARM template resource properties that aren't part of the resource body
+var armTemplateOnlyProperties = map[string]bool{
+	"type":       true,
+	"apiVersion": true,
+	"name":       true,
+	"dependsOn":  true,
+	"condition":  true,
+	"copy":       true,
+	"comments":   true,
+	"resources":  true,
+	"scope":      true,
+	"metadata":   true,
+	"existing":   true,
+}
+
+// armResourceGroupParentID is the parent_id of resources deployed to a resource group out of the template
+const armResourceGroupParentID = "/subscriptions/${var.subscription_id}/resourceGroups/${var.resource_group_name}"
+
+var invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// ARMTemplateConversion is the azapi_resource HCL converted from an ARM template, Warnings list what couldn't be
+// converted and must be reviewed
+type ARMTemplateConversion struct {
+	HCL      string   `json:"hcl"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// armResource is a resource of an ARM template with its full type and name, nested resources are flattened
+type armResource struct {
+	label      string
+	symbol     string
+	resType    string
+	apiVersion string
+	name       string
+	properties map[string]any
+}
+
+// ConvertARMTemplate converts the resources of an ARM template to azapi_resource blocks. template is either a whole
+// template with a `resources` array or symbolic name map, an array of resources or a single resource. Child resources
+// refer to their parents in the template through parent_id, other resources to var.subscription_id and
+// var.resource_group_name. Bodies are validated against the AzAPI types, read-only properties are removed and the
+// other problems are written as comments. ARM template expressions like `[parameters('name')]` are kept as they are and
+// reported as warnings.
+func ConvertARMTemplate(template string) (*ARMTemplateConversion, error) {
+	decoder := json.NewDecoder(strings.NewReader(template))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("template is not valid JSON: %w", err)
+	}
+	resources, err := armResources(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("template has no resources")
+	}
+	assignLabels(resources)
+
+	conversion := &ARMTemplateConversion{}
+	usesVariables := false
+	sb := &strings.Builder{}
+	for i, r := range resources {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		usesVariables = r.writeHCL(sb, resources, conversion) || usesVariables
+	}
+	if usesVariables {
+		conversion.Warnings = append(conversion.Warnings, "parent_id refers to var.subscription_id and var.resource_group_name, declare them or replace them with the IDs of the parents")
+	}
+	conversion.HCL = string(hclwrite.Format([]byte(sb.String())))
+	return conversion, nil
+}
+
+func armResources(value any) ([]*armResource, error) {
+	var resources []*armResource
+	switch v := value.(type) {
+	case []any:
+		if err := appendARMResources(&resources, v, nil); err != nil {
+			return nil, err
+		}
+	case map[string]any:
+		if _, ok := v["type"].(string); ok {
+			if err := appendARMResource(&resources, "", v, nil); err != nil {
+				return nil, err
+			}
+			break
+		}
+		if err := appendARMResources(&resources, v["resources"], nil); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("template must be an object or an array of resources")
+	}
+	return resources, nil
+}
+
+// appendARMResources appends the resources of a `resources` array, or of a symbolic name map in language version 2.0
+// templates
+func appendARMResources(resources *[]*armResource, value any, parent *armResource) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		for i, item := range v {
+			object, ok := item.(map[string]any)
+			if !ok {
+				return fmt.Errorf("resource %d is not an object", i)
+			}
+			if err := appendARMResource(resources, "", object, parent); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		symbols := make([]string, 0, len(v))
+		for symbol := range v {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+		for _, symbol := range symbols {
+			object, ok := v[symbol].(map[string]any)
+			if !ok {
+				return fmt.Errorf("resource %s is not an object", symbol)
+			}
+			if err := appendARMResource(resources, symbol, object, parent); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("resources must be an array or an object")
+	}
+	return nil
+}
+
+func appendARMResource(resources *[]*armResource, symbol string, object map[string]any, parent *armResource) error {
+	resType, _ := object["type"].(string)
+	name, _ := object["name"].(string)
+	apiVersion, _ := object["apiVersion"].(string)
+	if resType == "" || name == "" || apiVersion == "" {
+		return fmt.Errorf("resource %s must have a type, a name and an apiVersion", describeARMResource(symbol, resType, name))
+	}
+	// nested resources may use types and names relative to their parent
+	if parent != nil && !strings.Contains(resType, "/") {
+		resType = parent.resType + "/" + resType
+		name = parent.name + "/" + name
+	}
+	r := &armResource{
+		symbol:     symbol,
+		resType:    resType,
+		apiVersion: apiVersion,
+		name:       name,
+		properties: object,
+	}
+	*resources = append(*resources, r)
+	return appendARMResources(resources, object["resources"], r)
+}
+
+func describeARMResource(symbol, resType, name string) string {
+	if symbol != "" {
+		return symbol
+	}
+	return strings.TrimSpace(resType + " " + name)
+}
+
+// assignLabels names the azapi_resource blocks after the symbolic names, or the names of the resources
+func assignLabels(resources []*armResource) {
+	used := make(map[string]bool)
+	for _, r := range resources {
+		base := r.symbol
+		if base == "" {
+			base = lastPathSegment(r.name)
+			if isARMExpression(r.name) {
+				base = lastPathSegment(r.resType)
+			}
+		}
+		base = strings.Trim(invalidLabelCharacters.ReplaceAllString(strings.ToLower(base), "_"), "_")
+		if base == "" || (base[0] >= '0' && base[0] <= '9') || base[0] == '-' {
+			base = "resource_" + base
+		}
+		label := base
+		for i := 2; used[label]; i++ {
+			label = fmt.Sprintf("%s_%d", base, i)
+		}
+		used[label] = true
+		r.label = label
+	}
+}
+
+// writeHCL writes the azapi_resource block of the resource, it returns whether parent_id refers to variables
+func (r *armResource) writeHCL(sb *strings.Builder, resources []*armResource, conversion *ARMTemplateConversion) bool {
+	warn := func(format string, args ...any) {
+		conversion.Warnings = append(conversion.Warnings, fmt.Sprintf("%s: %s", r.label, fmt.Sprintf(format, args...)))
+	}
+	for _, key := range []string{"condition", "copy", "scope"} {
+		if _, ok := r.properties[key]; ok {
+			warn("%s isn't converted, it must be translated by hand", key)
+		}
+	}
+	if isARMExpression(r.name) {
+		warn("name %s is an ARM template expression", r.name)
+	}
+	parentID, usesVariables := r.parentID(resources)
+
+	bodyType, err := r.bodyType()
+	if err != nil {
+		warn("the body isn't validated: %s", err.Error())
+	}
+	body := make(map[string]any)
+	for key, value := range r.properties {
+		if armTemplateOnlyProperties[key] || rootBodyProperties[key] {
+			continue
+		}
+		body[key] = value
+	}
+	var annotations []string
+	if bodyType != nil {
+		annotations = r.validateBody(body, warn)
+	}
+	var expressions []string
+	collectARMExpressions(body, "body", &expressions)
+	for _, key := range []string{"location", "tags", "identity"} {
+		collectARMExpressions(r.properties[key], key, &expressions)
+	}
+	for _, path := range expressions {
+		warn("%s is an ARM template expression", path)
+	}
+
+	sb.WriteString(fmt.Sprintf("resource \"azapi_resource\" %q {\n", r.label))
+	sb.WriteString(fmt.Sprintf("type = %s\n", hclString(r.resType+"@"+r.apiVersion)))
+	sb.WriteString(fmt.Sprintf("name = %s\n", hclString(lastPathSegment(r.name))))
+	sb.WriteString(fmt.Sprintf("parent_id = %s\n", parentID))
+	if location, ok := r.properties["location"]; ok {
+		sb.WriteString("location = ")
+		writeHCLValue(sb, location)
+		sb.WriteString("\n")
+	}
+	if tags, ok := r.properties["tags"]; ok {
+		sb.WriteString("tags = ")
+		writeHCLValue(sb, tags)
+		sb.WriteString("\n")
+	}
+	if identity, ok := r.properties["identity"].(map[string]any); ok {
+		writeIdentityBlock(sb, identity)
+	}
+	for _, annotation := range annotations {
+		sb.WriteString("# " + annotation + "\n")
+	}
+	if len(body) > 0 {
+		sb.WriteString("body = ")
+		writeHCLValue(sb, body)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n")
+	return usesVariables
+}
+
+// parentID returns the parent_id expression of the resource, and whether it refers to variables
+func (r *armResource) parentID(resources []*armResource) (string, bool) {
+	typeSegments := strings.Split(r.resType, "/")
+	names := strings.Split(r.name, "/")
+	if strings.EqualFold(r.resType, ResourceGroupResourceType) {
+		return hclTemplate("/subscriptions/${var.subscription_id}"), true
+	}
+	if len(typeSegments) <= 2 || len(names) != len(typeSegments)-1 || isARMExpression(r.name) {
+		return hclTemplate(armResourceGroupParentID), true
+	}
+	parentType := strings.Join(typeSegments[:len(typeSegments)-1], "/")
+	parentName := strings.Join(names[:len(names)-1], "/")
+	for _, candidate := range resources {
+		if strings.EqualFold(candidate.resType, parentType) && strings.EqualFold(candidate.name, parentName) {
+			return fmt.Sprintf("azapi_resource.%s.id", candidate.label), false
+		}
+	}
+	id, err := BuildResourceID(ResourceIDComponents{
+		SubscriptionID: "${var.subscription_id}",
+		ResourceGroup:  "${var.resource_group_name}",
+		ResourceType:   parentType,
+		Name:           parentName,
+	})
+	if err != nil {
+		return hclTemplate(armResourceGroupParentID), true
+	}
+	return hclTemplate(id.ID), true
+}
+
+func (r *armResource) bodyType() (*types.ObjectType, error) {
+	if isARMExpression(r.apiVersion) {
+		return nil, fmt.Errorf("api-version %s is an ARM template expression", r.apiVersion)
+	}
+	apiType, err := getAzApiType(r.resType, r.apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("%s@%s is not known by the AzAPI types", r.resType, r.apiVersion)
+	}
+	bodyType, ok := apiType.Body.Type.(*types.ObjectType)
+	if !ok {
+		return nil, fmt.Errorf("resource body type is not an object type")
+	}
+	return bodyType, nil
+}
+
+// validateBody removes the read-only properties of the body and returns the other problems as annotations. Problems
+// of ARM template expressions are skipped, as their values are only known at deployment.
+func (r *armResource) validateBody(body map[string]any, warn func(string, ...any)) []string {
+	content, err := json.Marshal(body)
+	if err != nil {
+		warn("the body isn't validated: %s", err.Error())
+		return nil
+	}
+	validation, err := ValidateBody(r.resType, r.apiVersion, string(content))
+	if err != nil {
+		warn("the body isn't validated: %s", err.Error())
+		return nil
+	}
+	var annotations []string
+	for _, d := range validation.Diagnostics {
+		value, found := bodyValueAt(body, d.Path)
+		switch {
+		case d.Kind == DiagnosticReadOnly:
+			removeBodyValue(body, d.Path)
+			warn("%s is read-only and was removed", d.Path)
+		case found && isARMExpressionValue(value) && (d.Kind == DiagnosticTypeMismatch || d.Kind == DiagnosticInvalidValue):
+		default:
+			annotations = append(annotations, fmt.Sprintf("%s: %s", d.Path, d.Message))
+		}
+	}
+	return annotations
+}
+
+// writeIdentityBlock converts an ARM identity to the azapi_resource identity block
+func writeIdentityBlock(sb *strings.Builder, identity map[string]any) {
+	sb.WriteString("identity {\n")
+	sb.WriteString("type = ")
+	identityType := identity["type"]
+	if s, ok := identityType.(string); ok && !isARMExpression(s) {
+		// ARM accepts `SystemAssigned,UserAssigned`, azapi expects `SystemAssigned, UserAssigned`
+		parts := strings.Split(s, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		identityType = strings.Join(parts, ", ")
+	}
+	writeHCLValue(sb, identityType)
+	sb.WriteString("\n")
+	if userAssigned, ok := identity["userAssignedIdentities"].(map[string]any); ok && len(userAssigned) > 0 {
+		ids := make([]any, 0, len(userAssigned))
+		for _, id := range sortedKeys(userAssigned) {
+			ids = append(ids, id)
+		}
+		sb.WriteString("identity_ids = ")
+		writeHCLValue(sb, ids)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n")
+}
+
+// writeHCLValue writes a JSON value as an HCL expression
+func writeHCLValue(sb *strings.Builder, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			sb.WriteString("{}")
+			return
+		}
+		sb.WriteString("{\n")
+		for _, key := range sortedKeys(v) {
+			if hclsyntax.ValidIdentifier(key) {
+				sb.WriteString(key)
+			} else {
+				sb.WriteString(hclString(key))
+			}
+			sb.WriteString(" = ")
+			writeHCLValue(sb, v[key])
+			sb.WriteString("\n")
+		}
+		sb.WriteString("}")
+	case []any:
+		sb.WriteString("[")
+		for i, item := range v {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			writeHCLValue(sb, item)
+		}
+		sb.WriteString("]")
+	case string:
+		sb.WriteString(hclString(unescapeARMString(v)))
+	case json.Number:
+		sb.WriteString(v.String())
+	case nil:
+		sb.WriteString("null")
+	default:
+		literal, _ := json.Marshal(v)
+		sb.Write(literal)
+	}
+}
+
+// hclString quotes s as an HCL string literal, template sequences are escaped
+func hclString(s string) string {
+	quoted := hclTemplate(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
+
+// hclTemplate quotes s as an HCL string template, template sequences are kept
+func hclTemplate(s string) string {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// isARMExpression reports whether s is an ARM template expression like `[parameters('name')]`, strings starting with
+// `[[` are escaped literals
+func isARMExpression(s string) bool {
+	return strings.HasPrefix(s, "[") && !strings.HasPrefix(s, "[[") && strings.HasSuffix(s, "]")
+}
+
+func isARMExpressionValue(value any) bool {
+	s, ok := value.(string)
+	return ok && isARMExpression(s)
+}
+
+// unescapeARMString returns the literal value of an escaped ARM template string like `[[literal]`
+func unescapeARMString(s string) string {
+	if strings.HasPrefix(s, "[[") {
+		return s[1:]
+	}
+	return s
+}
+
+func collectARMExpressions(value any, path string, expressions *[]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			collectARMExpressions(v[key], path+"."+key, expressions)
+		}
+	case []any:
+		for i, item := range v {
+			collectARMExpressions(item, fmt.Sprintf("%s[%d]", path, i), expressions)
+		}
+	case string:
+		if isARMExpression(v) {
+			*expressions = append(*expressions, path)
+		}
+	}
+}
+
+// bodyPathSegments splits a diagnostic path like `body.properties.subnets[0].name` into object keys and array indexes,
+// the leading `body` excluded
+func bodyPathSegments(path string) []any {
+	var segments []any
+	for i, part := range strings.Split(strings.TrimPrefix(path, "body"), ".") {
+		if i == 0 {
+			continue
+		}
+		key, indexes, _ := strings.Cut(part, "[")
+		segments = append(segments, key)
+		if indexes == "" {
+			continue
+		}
+		for _, index := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			if n, err := strconv.Atoi(index); err == nil {
+				segments = append(segments, n)
+			}
+		}
+	}
+	return segments
+}
+
+func bodyValueAt(body map[string]any, path string) (any, bool) {
+	var value any = body
+	for _, segment := range bodyPathSegments(path) {
+		switch s := segment.(type) {
+		case string:
+			object, ok := value.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if value, ok = object[s]; !ok {
+				return nil, false
+			}
+		case int:
+			array, ok := value.([]any)
+			if !ok || s >= len(array) {
+				return nil, false
+			}
+			value = array[s]
+		}
+	}
+	return value, true
+}
+
+func removeBodyValue(body map[string]any, path string) {
+	segments := bodyPathSegments(path)
+	if len(segments) == 0 {
+		return
+	}
+	var parent any = body
+	for _, segment := range segments[:len(segments)-1] {
+		switch s := segment.(type) {
+		case string:
+			object, ok := parent.(map[string]any)
+			if !ok {
+				return
+			}
+			parent = object[s]
+		case int:
+			array, ok := parent.([]any)
+			if !ok || s >= len(array) {
+				return
+			}
+			parent = array[s]
+		}
+	}
+	if object, ok := parent.(map[string]any); ok {
+		if key, ok := segments[len(segments)-1].(string); ok {
+			delete(object, key)
+		}
+	}
+}
+
+func lastPathSegment(s string) string {
+	return s[strings.LastIndex(s, "/")+1:]
+}
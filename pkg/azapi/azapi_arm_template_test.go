@@ -0,0 +1,164 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertARMTemplate(t *testing.T) {
+	template := `{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "resources": [
+    {
+      "type": "Microsoft.Network/virtualNetworks",
+      "apiVersion": "2023-09-01",
+      "name": "vnet1",
+      "location": "westeurope",
+      "tags": {"env": "dev"},
+      "properties": {
+        "addressSpace": {"addressPrefixes": ["10.0.0.0/16"]},
+        "provisioningState": "Succeeded"
+      },
+      "resources": [
+        {
+          "type": "subnets",
+          "apiVersion": "2023-09-01",
+          "name": "default",
+          "dependsOn": ["vnet1"],
+          "properties": {"addressPrefix": "10.0.0.0/24"}
+        }
+      ]
+    }
+  ]
+}`
+	conversion, err := ConvertARMTemplate(template)
+	require.NoError(t, err)
+	expected := `resource "azapi_resource" "vnet1" {
+  type      = "Microsoft.Network/virtualNetworks@2023-09-01"
+  name      = "vnet1"
+  parent_id = "/subscriptions/${var.subscription_id}/resourceGroups/${var.resource_group_name}"
+  location  = "westeurope"
+  tags = {
+    env = "dev"
+  }
+  body = {
+    properties = {
+      addressSpace = {
+        addressPrefixes = ["10.0.0.0/16"]
+      }
+    }
+  }
+}
+
+resource "azapi_resource" "default" {
+  type      = "Microsoft.Network/virtualNetworks/subnets@2023-09-01"
+  name      = "default"
+  parent_id = azapi_resource.vnet1.id
+  body = {
+    properties = {
+      addressPrefix = "10.0.0.0/24"
+    }
+  }
+}
+`
+	assert.Equal(t, expected, conversion.HCL)
+	assert.Equal(t, []string{
+		"vnet1: body.properties.provisioningState is read-only and was removed",
+		"parent_id refers to var.subscription_id and var.resource_group_name, declare them or replace them with the IDs of the parents",
+	}, conversion.Warnings)
+}
+
+func TestConvertARMTemplate_Annotations(t *testing.T) {
+	resource := `{
+  "type": "Microsoft.KeyVault/vaults",
+  "apiVersion": "2023-07-01",
+  "name": "[parameters('vaultName')]",
+  "location": "[resourceGroup().location]",
+  "identity": {"type": "SystemAssigned,UserAssigned", "userAssignedIdentities": {"/subscriptions/0000/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id1": {}}},
+  "properties": {
+    "tenantId": "[subscription().tenantId]",
+    "sku": {"family": "A", "name": "basic"},
+    "enableSoftDelete": "[parameters('softDelete')]",
+    "description": "${literal}"
+  }
+}`
+	conversion, err := ConvertARMTemplate(resource)
+	require.NoError(t, err)
+	expected := `resource "azapi_resource" "vaults" {
+  type      = "Microsoft.KeyVault/vaults@2023-07-01"
+  name      = "[parameters('vaultName')]"
+  parent_id = "/subscriptions/${var.subscription_id}/resourceGroups/${var.resource_group_name}"
+  location  = "[resourceGroup().location]"
+  identity {
+    type         = "SystemAssigned, UserAssigned"
+    identity_ids = ["/subscriptions/0000/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id1"]
+  }
+  # body.properties.description: property is not declared by the schema
+  # body.properties.sku.name: value "basic" is not one of the possible values: standard, premium
+  body = {
+    properties = {
+      description      = "$${literal}"
+      enableSoftDelete = "[parameters('softDelete')]"
+      sku = {
+        family = "A"
+        name   = "basic"
+      }
+      tenantId = "[subscription().tenantId]"
+    }
+  }
+}
+`
+	assert.Equal(t, expected, conversion.HCL)
+	assert.Equal(t, []string{
+		"vaults: name [parameters('vaultName')] is an ARM template expression",
+		"vaults: body.properties.enableSoftDelete is an ARM template expression",
+		"vaults: body.properties.tenantId is an ARM template expression",
+		"vaults: location is an ARM template expression",
+		"parent_id refers to var.subscription_id and var.resource_group_name, declare them or replace them with the IDs of the parents",
+	}, conversion.Warnings)
+}
+
+func TestConvertARMTemplate_SymbolicNames(t *testing.T) {
+	template := `{
+  "languageVersion": "2.0",
+  "resources": {
+    "storage": {"type": "Microsoft.Storage/storageAccounts", "apiVersion": "2023-01-01", "name": "sa1", "kind": "StorageV2", "sku": {"name": "Standard_LRS"}, "location": "eastus"},
+    "container": {"type": "Microsoft.Storage/storageAccounts/blobServices/containers", "apiVersion": "2023-01-01", "name": "sa1/default/logs", "properties": {}}
+  }
+}`
+	conversion, err := ConvertARMTemplate(template)
+	require.NoError(t, err)
+	assert.Contains(t, conversion.HCL, `resource "azapi_resource" "container" {`)
+	assert.Contains(t, conversion.HCL, `resource "azapi_resource" "storage" {`)
+	// the blob service isn't in the template, its ID is built
+	assert.Contains(t, conversion.HCL, `parent_id = "/subscriptions/${var.subscription_id}/resourceGroups/${var.resource_group_name}/providers/Microsoft.Storage/storageAccounts/sa1/blobServices/default"`)
+	assert.Contains(t, conversion.HCL, `kind = "StorageV2"`)
+}
+
+func TestConvertARMTemplate_UnknownType(t *testing.T) {
+	conversion, err := ConvertARMTemplate(`[{"type": "Microsoft.Foo/bars", "apiVersion": "2020-01-01", "name": "bar", "copy": {"name": "c", "count": 2}, "properties": {"x": 1}}]`)
+	require.NoError(t, err)
+	assert.Contains(t, conversion.HCL, "x = 1")
+	assert.Contains(t, conversion.Warnings, "bar: copy isn't converted, it must be translated by hand")
+	assert.Contains(t, conversion.Warnings, "bar: the body isn't validated: Microsoft.Foo/bars@2020-01-01 is not known by the AzAPI types")
+}
+
+func TestConvertARMTemplate_Invalid(t *testing.T) {
+	cases := []struct {
+		desc     string
+		template string
+	}{
+		{desc: "invalid JSON", template: `{`},
+		{desc: "no resources", template: `{"resources": []}`},
+		{desc: "missing api-version", template: `{"type": "Microsoft.KeyVault/vaults", "name": "kv"}`},
+		{desc: "not an object", template: `"x"`},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			_, err := ConvertARMTemplate(c.template)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package azapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ms-henglu/go-azure-types/types"
+)
+
+// bicepTypesURL is the generated Bicep types of ARM resources, whose index.json lists the types.json defining every
+// `resource_type@api_version`. The AzAPI types embed a snapshot of them. Package-level to allow test stubbing.
+var bicepTypesURL = "https://raw.githubusercontent.com/Azure/bicep-types-az/main/generated"
+
+var bicepTypesClient = &http.Client{Timeout: 60 * time.Second}
+
+// BicepPropertyMapping maps a property of a Bicep resource declaration to the azapi_resource argument it's set by
+type BicepPropertyMapping struct {
+	Bicep string `json:"bicep"`
+	AzAPI string `json:"azapi"`
+	Note  string `json:"note,omitempty"`
+}
+
+// BicepTypeComparison puts the Bicep view of a resource type next to the azapi view. Properties map the top level
+// properties of the Bicep resource to the azapi_resource arguments, Differences are the body property changes from the
+// Bicep type to the AzAPI type, the breaking ones being Bicep properties azapi would reject or types it would treat
+// differently, see CompareApiVersions.
+type BicepTypeComparison struct {
+	ResourceType     string                 `json:"resource_type"`
+	ApiVersion       string                 `json:"api_version"`
+	BicepResource    string                 `json:"bicep_resource"`
+	AzAPIType        string                 `json:"azapi_type"`
+	KnownByAzAPI     bool                   `json:"known_by_azapi"`
+	Properties       []BicepPropertyMapping `json:"properties"`
+	BreakingChanges  int                    `json:"breaking_changes"`
+	Differences      []PropertyChange       `json:"differences"`
+	BicepApiVersions []string               `json:"bicep_api_versions,omitempty"`
+}
+
+// bicepTypeLocation is a type in a types.json of the Bicep types, like `keyvault/microsoft.keyvault/2023-07-01/types.json#/17`
+type bicepTypeLocation struct {
+	Ref string `json:"$ref"`
+}
+
+type bicepTypesIndex struct {
+	Resources map[string]bicepTypeLocation `json:"resources"`
+}
+
+// bicepTopLevelProperties map the top level properties of Bicep resources that aren't in the azapi body
+var bicepTopLevelProperties = map[string]BicepPropertyMapping{
+	"id":         {AzAPI: "id", Note: "read-only, the id attribute of the azapi_resource"},
+	"name":       {AzAPI: "name"},
+	"type":       {AzAPI: "type", Note: "the resource type and the api-version, like `Microsoft.KeyVault/vaults@2023-07-01`"},
+	"apiVersion": {AzAPI: "type", Note: "the api-version after `@` in type"},
+	"location":   {AzAPI: "location"},
+	"tags":       {AzAPI: "tags"},
+	"identity":   {AzAPI: "identity", Note: "an identity block, the keys of userAssignedIdentities are its identity_ids"},
+}
+
+// CompareBicepType resolves resourceType@apiVersion from the latest Bicep types and compares it with the AzAPI type
+// of the same api-version, to help migrating Bicep templates to azapi_resource. apiVersion may be `latest` or
+// `latest-stable`, resolved against the api-versions of the Bicep types, which can be newer than the AzAPI ones.
+func CompareBicepType(ctx context.Context, resourceType, apiVersion string) (*BicepTypeComparison, error) {
+	index, err := cachedLookup("bicep-index", func() (*bicepTypesIndex, error) {
+		return loadBicepTypesIndex(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	canonical, versions := bicepApiVersions(index, resourceType)
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("resource type %s is not in the Bicep types", resourceType)
+	}
+	if alias := strings.ToLower(strings.TrimSpace(apiVersion)); alias == "" || alias == ApiVersionLatest || alias == ApiVersionLatestStable {
+		stable, preview := latestApiVersions(versions)
+		switch {
+		case stable != "":
+			apiVersion = stable
+		case alias == ApiVersionLatestStable:
+			return nil, fmt.Errorf("no stable api-version of %s in the Bicep types, use %q to select the newest preview version", canonical, ApiVersionLatest)
+		default:
+			apiVersion = preview
+		}
+	}
+	location, ok := index.Resources[canonical+"@"+apiVersion]
+	if !ok {
+		return nil, fmt.Errorf("api-version %s of %s is not in the Bicep types, must be one of %s", apiVersion, canonical, strings.Join(versions, ", "))
+	}
+	bicepType, err := loadBicepResourceType(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the Bicep type of %s@%s: %w", canonical, apiVersion, err)
+	}
+
+	comparison := &BicepTypeComparison{
+		ResourceType:     canonical,
+		ApiVersion:       apiVersion,
+		BicepResource:    fmt.Sprintf("resource %s '%s@%s' = { ... }", bicepSymbolicName(canonical), canonical, apiVersion),
+		AzAPIType:        canonical + "@" + apiVersion,
+		Properties:       bicepPropertyMappings(bicepType.Body.Type),
+		Differences:      make([]PropertyChange, 0),
+		BicepApiVersions: versions,
+	}
+	azapiType, err := getAzApiType(canonical, apiVersion)
+	if err != nil || azapiType.Body == nil {
+		// newer api-versions are only in the Bicep types until the AzAPI types catch up
+		return comparison, nil
+	}
+	comparison.KnownByAzAPI = true
+	comparison.Differences = compareShapes(bodyShapes(bicepType.Body.Type), bodyShapes(azapiType.Body.Type))
+	for _, change := range comparison.Differences {
+		if change.Breaking {
+			comparison.BreakingChanges++
+		}
+	}
+	return comparison, nil
+}
+
+// bicepApiVersions returns the resource type as it's spelled in the Bicep types and its api-versions, newest first
+func bicepApiVersions(index *bicepTypesIndex, resourceType string) (string, []string) {
+	canonical := ""
+	versions := make([]string, 0)
+	for key := range index.Resources {
+		at := strings.LastIndex(key, "@")
+		if at < 0 || !strings.EqualFold(key[:at], resourceType) {
+			continue
+		}
+		canonical = key[:at]
+		versions = append(versions, key[at+1:])
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return newerApiVersion(versions[i], versions[j])
+	})
+	return canonical, versions
+}
+
+// bicepPropertyMappings maps the top level properties of a Bicep resource body to the azapi_resource arguments, the
+// parent or scope of Bicep resources is the parent_id
+func bicepPropertyMappings(bodyType types.TypeBase) []BicepPropertyMapping {
+	mappings := []BicepPropertyMapping{
+		{Bicep: "parent / scope", AzAPI: "parent_id", Note: "the ID of the parent resource or scope, Bicep deploys to the resource group of the deployment by default"},
+	}
+	obj, ok := bodyType.(*types.ObjectType)
+	if !ok {
+		return mappings
+	}
+	names := make([]string, 0, len(obj.Properties))
+	for name := range obj.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if mapping, ok := bicepTopLevelProperties[name]; ok {
+			mapping.Bicep = name
+			mappings = append(mappings, mapping)
+			continue
+		}
+		mapping := BicepPropertyMapping{Bicep: name, AzAPI: "body." + name}
+		if property := obj.Properties[name]; property.IsReadOnly() {
+			mapping.Note = "read-only, read it with response_export_values"
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings
+}
+
+// bicepSymbolicName is a Bicep symbolic name for a resource type, like `vaults` for `Microsoft.KeyVault/vaults`
+func bicepSymbolicName(resourceType string) string {
+	return resourceType[strings.LastIndex(resourceType, "/")+1:]
+}
+
+func loadBicepTypesIndex(ctx context.Context) (*bicepTypesIndex, error) {
+	content, err := downloadBicepTypes(ctx, "index.json")
+	if err != nil {
+		return nil, err
+	}
+	var index bicepTypesIndex
+	if err := json.Unmarshal(content, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse Bicep types index: %w", err)
+	}
+	return &index, nil
+}
+
+// loadBicepResourceType loads the resource type at location, like `keyvault/microsoft.keyvault/2023-07-01/types.json#/17`
+func loadBicepResourceType(ctx context.Context, location bicepTypeLocation) (*types.ResourceType, error) {
+	file, ref, ok := strings.Cut(location.Ref, "#/")
+	if !ok {
+		return nil, fmt.Errorf("invalid type reference %q", location.Ref)
+	}
+	i, err := strconv.Atoi(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type reference %q: %w", location.Ref, err)
+	}
+	decoded, err := cachedLookup("bicep-types:"+file, func() ([]*types.TypeBase, error) {
+		content, err := downloadBicepTypes(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+		return decodeBicepTypes(content)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if i < 0 || i >= len(decoded) {
+		return nil, fmt.Errorf("type reference %q is out of range", location.Ref)
+	}
+	resourceType, ok := (*decoded[i]).(*types.ResourceType)
+	if !ok || resourceType.Body == nil || resourceType.Body.Type == nil {
+		return nil, fmt.Errorf("type reference %q is not a resource type", location.Ref)
+	}
+	return resourceType, nil
+}
+
+// decodeBicepTypes decodes a types.json of the Bicep types like the AzAPI types do, but tolerates what the AzAPI types
+// don't know yet: unknown kinds of types are any and unknown fields are ignored
+func decodeBicepTypes(content []byte) ([]*types.TypeBase, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(content, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse Bicep types: %w", err)
+	}
+	decoded := make([]*types.TypeBase, 0, len(items))
+	for i, item := range items {
+		var kind struct {
+			Type string `json:"$type"`
+		}
+		if err := json.Unmarshal(item, &kind); err != nil {
+			return nil, fmt.Errorf("failed to parse Bicep type #/%d: %w", i, err)
+		}
+		var t types.TypeBase
+		switch kind.Type {
+		case "StringType":
+			t = &types.StringType{}
+		case "StringLiteralType":
+			t = &types.StringLiteralType{}
+		case "IntegerType":
+			t = &types.IntegerType{}
+		case "BooleanType":
+			t = &types.BooleanType{}
+		case "ObjectType":
+			t = &types.ObjectType{}
+		case "ArrayType":
+			t = &types.ArrayType{}
+		case "UnionType":
+			t = &types.UnionType{}
+		case "DiscriminatedObjectType":
+			t = &types.DiscriminatedObjectType{}
+		case "ResourceType":
+			// only the body matters, the scopes and flags changed across versions of the Bicep types
+			var resource struct {
+				Name string               `json:"name"`
+				Body *types.TypeReference `json:"body"`
+			}
+			if err := json.Unmarshal(item, &resource); err != nil {
+				return nil, fmt.Errorf("failed to parse Bicep type #/%d: %w", i, err)
+			}
+			t = &types.ResourceType{Type: kind.Type, Name: resource.Name, Body: resource.Body}
+			decoded = append(decoded, &t)
+			continue
+		default:
+			t = &types.AnyType{Type: "AnyType"}
+			decoded = append(decoded, &t)
+			continue
+		}
+		if err := json.Unmarshal(item, t); err != nil {
+			return nil, fmt.Errorf("failed to parse Bicep type #/%d: %w", i, err)
+		}
+		decoded = append(decoded, &t)
+	}
+	for _, t := range decoded {
+		switch v := (*t).(type) {
+		case *types.ObjectType:
+			v.AdditionalProperties.UpdateType(decoded)
+			for _, property := range v.Properties {
+				property.Type.UpdateType(decoded)
+			}
+		case *types.ArrayType:
+			v.ItemType.UpdateType(decoded)
+		case *types.UnionType:
+			for _, element := range v.Elements {
+				element.UpdateType(decoded)
+			}
+		case *types.DiscriminatedObjectType:
+			for _, element := range v.Elements {
+				element.UpdateType(decoded)
+			}
+			for _, property := range v.BaseProperties {
+				property.Type.UpdateType(decoded)
+			}
+		case *types.ResourceType:
+			v.Body.UpdateType(decoded)
+		}
+	}
+	return decoded, nil
+}
+
+func downloadBicepTypes(ctx context.Context, file string) ([]byte, error) {
+	url := bicepTypesURL + "/" + file
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := bicepTypesClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download Bicep types %s: %w", file, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download Bicep types from %s: status %d", url, resp.StatusCode)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Bicep types %s: %w", file, err)
+	}
+	return content, nil
+}
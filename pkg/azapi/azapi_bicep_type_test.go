@@ -0,0 +1,100 @@
+package azapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	typesEmbed "github.com/ms-henglu/go-azure-types/embed"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBicepTypes serves the Bicep types embedded in the AzAPI types, with the replacements applied to the types.json
+// files
+func stubBicepTypes(t *testing.T, replacements ...string) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, err := typesEmbed.StaticFiles.ReadFile("generated" + r.URL.Path)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/types.json") {
+			content = []byte(strings.NewReplacer(replacements...).Replace(string(content)))
+		}
+		_, _ = w.Write(content)
+	}))
+	stubs := gostub.Stub(&bicepTypesURL, server.URL).Stub(&lookupCache, newLookupCache())
+	t.Cleanup(func() {
+		stubs.Reset()
+		server.Close()
+	})
+}
+
+func TestCompareBicepType_SameTypes(t *testing.T) {
+	stubBicepTypes(t)
+
+	comparison, err := CompareBicepType(context.Background(), "microsoft.keyvault/vaults", "2023-07-01")
+	require.NoError(t, err)
+	assert.Equal(t, "Microsoft.KeyVault/vaults", comparison.ResourceType)
+	assert.Equal(t, "Microsoft.KeyVault/vaults@2023-07-01", comparison.AzAPIType)
+	assert.Equal(t, "resource vaults 'Microsoft.KeyVault/vaults@2023-07-01' = { ... }", comparison.BicepResource)
+	assert.True(t, comparison.KnownByAzAPI)
+	assert.Empty(t, comparison.Differences)
+	assert.Zero(t, comparison.BreakingChanges)
+	assert.Contains(t, comparison.Properties, BicepPropertyMapping{Bicep: "parent / scope", AzAPI: "parent_id", Note: "the ID of the parent resource or scope, Bicep deploys to the resource group of the deployment by default"})
+	assert.Contains(t, comparison.Properties, BicepPropertyMapping{Bicep: "properties", AzAPI: "body.properties"})
+	assert.Contains(t, comparison.Properties, BicepPropertyMapping{Bicep: "location", AzAPI: "location"})
+	assert.Contains(t, comparison.Properties, BicepPropertyMapping{Bicep: "systemData", AzAPI: "body.systemData", Note: "read-only, read it with response_export_values"})
+}
+
+func TestCompareBicepType_Differences(t *testing.T) {
+	stubBicepTypes(t, `"enableSoftDelete": {`, `"enableSoftDeleted": {`)
+
+	comparison, err := CompareBicepType(context.Background(), "Microsoft.KeyVault/vaults", "2023-07-01")
+	require.NoError(t, err)
+	assert.Equal(t, 1, comparison.BreakingChanges)
+	assert.Equal(t, []PropertyChange{
+		{Path: "body.properties.enableSoftDeleted", Kind: ChangeRenamed, Detail: "possibly renamed to body.properties.enableSoftDelete", Breaking: true},
+	}, comparison.Differences)
+}
+
+func TestCompareBicepType_LatestApiVersion(t *testing.T) {
+	stubBicepTypes(t)
+
+	comparison, err := CompareBicepType(context.Background(), "Microsoft.KeyVault/vaults", ApiVersionLatest)
+	require.NoError(t, err)
+	latest, err := ResolveApiVersion("Microsoft.KeyVault/vaults", ApiVersionLatestStable)
+	require.NoError(t, err)
+	assert.Equal(t, latest, comparison.ApiVersion)
+	assert.Contains(t, comparison.BicepApiVersions, "2023-07-01")
+}
+
+func TestCompareBicepType_UnknownApiVersion(t *testing.T) {
+	stubBicepTypes(t)
+
+	_, err := CompareBicepType(context.Background(), "Microsoft.KeyVault/vaults", "2000-01-01")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api-version 2000-01-01 of Microsoft.KeyVault/vaults is not in the Bicep types, must be one of")
+
+	_, err = CompareBicepType(context.Background(), "Microsoft.Unknown/things", "")
+	assert.EqualError(t, err, "resource type Microsoft.Unknown/things is not in the Bicep types")
+}
+
+func TestDecodeBicepTypes_ToleratesUnknownTypes(t *testing.T) {
+	decoded, err := decodeBicepTypes([]byte(`[
+  {"$type": "StringType", "newField": true},
+  {"$type": "NullType"},
+  {"$type": "ObjectType", "name": "body", "properties": {"name": {"type": {"$ref": "#/0"}, "flags": 1}, "nothing": {"type": {"$ref": "#/1"}, "flags": 0}}},
+  {"$type": "ResourceType", "name": "Microsoft.Test/things@2024-01-01", "readableScopes": 8, "writableScopes": 8, "body": {"$ref": "#/2"}}
+]`))
+	require.NoError(t, err)
+	require.Len(t, decoded, 4)
+	shapes := bodyShapes(*decoded[2])
+	assert.Equal(t, "string", shapes["body.name"].kind)
+	assert.True(t, shapes["body.name"].required)
+	assert.Equal(t, "any", shapes["body.nothing"].kind)
+}
@@ -0,0 +1,66 @@
+package azapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// DefaultBlockType is the azapi block type queried when none is specified
+const DefaultBlockType = "azapi_resource"
+
+// bodyBlockTypes are the block types whose `body` is the resource body, the swagger type of the resource is merged
+// into their schema. Action bodies depend on the action and aren't merged.
+var bodyBlockTypes = map[string]bool{
+	"azapi_resource":            true,
+	"azapi_update_resource":     true,
+	"azapi_data_plane_resource": true,
+}
+
+// BlockTypes lists the azapi block types that can be queried, data sources and ephemeral resources are prefixed with
+// `data.` and `ephemeral.`, like `data.azapi_resource_list`
+func BlockTypes() []string {
+	schema := ProviderSchema()
+	blockTypes := make([]string, 0, len(schema.ResourceSchemas)+len(schema.DataSourceSchemas)+len(schema.EphemeralResourceSchemas))
+	for name := range schema.ResourceSchemas {
+		blockTypes = append(blockTypes, name)
+	}
+	for name := range schema.DataSourceSchemas {
+		blockTypes = append(blockTypes, "data."+name)
+	}
+	for name := range schema.EphemeralResourceSchemas {
+		blockTypes = append(blockTypes, "ephemeral."+name)
+	}
+	sort.Strings(blockTypes)
+	return blockTypes
+}
+
+// BlockMergesBody returns whether the swagger type of the resource is merged into the schema of blockType, such
+// block types need a resource type and an api-version
+func BlockMergesBody(blockType string) bool {
+	if blockType == "" {
+		blockType = DefaultBlockType
+	}
+	return bodyBlockTypes[blockType]
+}
+
+// blockSchema returns the provider schema of an azapi block type
+func blockSchema(blockType string) (*tfjson.SchemaBlock, error) {
+	if blockType == "" {
+		blockType = DefaultBlockType
+	}
+	provider := ProviderSchema()
+	schemas, name := provider.ResourceSchemas, blockType
+	if n, ok := strings.CutPrefix(blockType, "data."); ok {
+		schemas, name = provider.DataSourceSchemas, n
+	} else if n, ok := strings.CutPrefix(blockType, "ephemeral."); ok {
+		schemas, name = provider.EphemeralResourceSchemas, n
+	}
+	schema, ok := schemas[name]
+	if !ok || schema == nil || schema.Block == nil {
+		return nil, fmt.Errorf("unknown azapi block type %q, must be one of %s", blockType, strings.Join(BlockTypes(), ", "))
+	}
+	return schema.Block, nil
+}
@@ -0,0 +1,82 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockTypes(t *testing.T) {
+	blockTypes := BlockTypes()
+	assert.Contains(t, blockTypes, "azapi_resource")
+	assert.Contains(t, blockTypes, "azapi_update_resource")
+	assert.Contains(t, blockTypes, "azapi_resource_action")
+	assert.Contains(t, blockTypes, "azapi_data_plane_resource")
+	assert.Contains(t, blockTypes, "data.azapi_resource_list")
+	assert.Contains(t, blockTypes, "ephemeral.azapi_resource_action")
+}
+
+func TestGetBlockSchema(t *testing.T) {
+	cases := []struct {
+		desc         string
+		blockType    string
+		resourceType string
+		apiVersion   string
+		path         string
+		expectedType string
+	}{
+		{
+			desc:         "update resource merges the resource body",
+			blockType:    "azapi_update_resource",
+			resourceType: "Microsoft.CognitiveServices/accounts",
+			apiVersion:   "2025-06-01",
+			path:         "body.properties.publicNetworkAccess",
+			expectedType: "String",
+		},
+		{
+			desc:         "update resource attribute",
+			blockType:    "azapi_update_resource",
+			resourceType: "Microsoft.CognitiveServices/accounts",
+			apiVersion:   "2025-06-01",
+			path:         "resource_id",
+			expectedType: "String",
+		},
+		{
+			desc:         "resource action without resource type",
+			blockType:    "azapi_resource_action",
+			path:         "method",
+			expectedType: "String",
+		},
+		{
+			desc:         "data source",
+			blockType:    "data.azapi_client_config",
+			path:         "subscription_id",
+			expectedType: "String",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			schema, err := GetBlockSchema(c.blockType, c.resourceType, c.apiVersion, c.path, false)
+			require.NoError(t, err)
+			assert.Equal(t, c.expectedType, schema)
+		})
+	}
+}
+
+func TestGetBlockSchema_UnknownBlockType(t *testing.T) {
+	_, err := GetBlockSchema("azapi_unknown", "", "", "", false)
+	assert.ErrorContains(t, err, `unknown azapi block type "azapi_unknown"`)
+}
+
+func TestGetBlockSchemaDescription(t *testing.T) {
+	description, err := GetBlockSchemaDescription("data.azapi_resource_list", "", "", "parent_id", false)
+	require.NoError(t, err)
+	desc, ok := description.(string)
+	require.True(t, ok)
+	assert.NotEmpty(t, desc)
+
+	description, err = GetBlockSchemaDescription("azapi_update_resource", "Microsoft.CognitiveServices/accounts", "2025-06-01", "body.properties.publicNetworkAccess", false)
+	require.NoError(t, err)
+	assert.Contains(t, description, "Whether or not public endpoint access is allowed for this account.")
+}
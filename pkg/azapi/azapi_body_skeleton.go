@@ -0,0 +1,224 @@
+package azapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/ms-henglu/go-azure-types/types"
+)
+
+// Body skeleton formats
+const (
+	BodyFormatHCL  = "hcl"
+	BodyFormatJSON = "json"
+)
+
+// bodyPlaceholder is the value of required string properties in generated bodies
+const bodyPlaceholder = "REPLACE_ME"
+
+// azapiTopLevelProperties are body properties set through azapi_resource arguments, they're left out of skeletons
+var azapiTopLevelProperties = map[string]bool{
+	"name":     true,
+	"type":     true,
+	"id":       true,
+	"location": true,
+	"tags":     true,
+	"identity": true,
+}
+
+// bodyNode is a value of a generated body, hint is written as a comment in HCL
+type bodyNode struct {
+	// value is a scalar value, used when properties and items are both nil
+	value      any
+	properties []namedBodyNode
+	items      []*bodyNode
+	hint       string
+}
+
+type namedBodyNode struct {
+	name string
+	node *bodyNode
+}
+
+// GenerateBodySkeleton renders a ready-to-edit `body` for an azapi_resource of resourceType@apiVersion. Required
+// properties are filled with typed placeholders, the first possible value of enums is used and, in HCL, all possible
+// values are written as comments. Properties set through azapi_resource arguments, like name and location, are left out.
+func GenerateBodySkeleton(resourceType, apiVersion, format string) (string, error) {
+	if format == "" {
+		format = BodyFormatHCL
+	}
+	if format != BodyFormatHCL && format != BodyFormatJSON {
+		return "", fmt.Errorf("invalid format %q, must be one of %s, %s", format, BodyFormatHCL, BodyFormatJSON)
+	}
+	apiType, err := getAzApiType(resourceType, apiVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to get azapi type for resource %s api-version %s: %w", resourceType, apiVersion, err)
+	}
+	bodyType, ok := apiType.Body.Type.(*types.ObjectType)
+	if !ok {
+		return "", fmt.Errorf("resource body type is not an object type")
+	}
+	body := objectBodyNode(bodyType.Properties, map[types.TypeBase]bool{bodyType: true}, true)
+
+	if format == BodyFormatJSON {
+		content, err := json.MarshalIndent(body.jsonValue(), "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal body: %w", err)
+		}
+		return string(content), nil
+	}
+	sb := &strings.Builder{}
+	sb.WriteString("body = ")
+	body.writeHCL(sb)
+	sb.WriteString("\n")
+	return string(hclwrite.Format([]byte(sb.String()))), nil
+}
+
+// objectBodyNode returns the required, writable properties of an object. visiting holds the object types being
+// expanded, recursive types stop at an empty object.
+func objectBodyNode(properties map[string]types.ObjectProperty, visiting map[types.TypeBase]bool, topLevel bool) *bodyNode {
+	node := &bodyNode{properties: []namedBodyNode{}}
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property := properties[name]
+		if !property.IsRequired() || property.IsReadOnly() || (topLevel && azapiTopLevelProperties[name]) {
+			continue
+		}
+		var t types.TypeBase
+		if property.Type != nil {
+			t = property.Type.Type
+		}
+		node.properties = append(node.properties, namedBodyNode{name: name, node: typeBodyNode(t, visiting)})
+	}
+	return node
+}
+
+func typeBodyNode(t types.TypeBase, visiting map[types.TypeBase]bool) *bodyNode {
+	if t != nil && visiting[t] {
+		return &bodyNode{properties: []namedBodyNode{}, hint: "recursive type"}
+	}
+	switch v := t.(type) {
+	case *types.StringType:
+		return &bodyNode{value: bodyPlaceholder}
+	case *types.StringLiteralType:
+		return &bodyNode{value: v.Value}
+	case *types.IntegerType:
+		return &bodyNode{value: 0}
+	case *types.BooleanType:
+		return &bodyNode{value: false}
+	case *types.UnionType:
+		values := literalValues(v)
+		if len(values) == 0 {
+			return &bodyNode{value: nil}
+		}
+		return &bodyNode{value: values[0], hint: "Possible values: " + strings.Join(values, ", ")}
+	case *types.ArrayType:
+		if v.ItemType == nil {
+			return &bodyNode{items: []*bodyNode{}}
+		}
+		return &bodyNode{items: []*bodyNode{typeBodyNode(v.ItemType.Type, visiting)}}
+	case *types.ObjectType:
+		visiting[v] = true
+		defer delete(visiting, v)
+		return objectBodyNode(v.Properties, visiting, false)
+	case *types.DiscriminatedObjectType:
+		visiting[v] = true
+		defer delete(visiting, v)
+		node := objectBodyNode(v.BaseProperties, visiting, false)
+		kinds := make([]string, 0, len(v.Elements))
+		for kind := range v.Elements {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		discriminator := &bodyNode{value: bodyPlaceholder}
+		if len(kinds) > 0 {
+			discriminator = &bodyNode{value: kinds[0], hint: "Possible values: " + strings.Join(kinds, ", ")}
+			if element, ok := v.Elements[kinds[0]]; ok && element != nil {
+				if object, ok := element.Type.(*types.ObjectType); ok {
+					visiting[object] = true
+					node.properties = append(node.properties, objectBodyNode(object.Properties, visiting, false).properties...)
+					delete(visiting, object)
+				}
+			}
+		}
+		node.properties = append([]namedBodyNode{{name: v.Discriminator, node: discriminator}}, node.properties...)
+		return node
+	}
+	// AnyType and unknown types accept any value
+	return &bodyNode{value: nil}
+}
+
+// literalValues returns the string literals of a union
+func literalValues(union *types.UnionType) []string {
+	values := make([]string, 0, len(union.Elements))
+	for _, element := range union.Elements {
+		if element == nil {
+			continue
+		}
+		if literal, ok := element.Type.(*types.StringLiteralType); ok {
+			values = append(values, literal.Value)
+		}
+	}
+	return values
+}
+
+func (n *bodyNode) jsonValue() any {
+	switch {
+	case n.properties != nil:
+		object := make(map[string]any, len(n.properties))
+		for _, p := range n.properties {
+			object[p.name] = p.node.jsonValue()
+		}
+		return object
+	case n.items != nil:
+		array := make([]any, 0, len(n.items))
+		for _, item := range n.items {
+			array = append(array, item.jsonValue())
+		}
+		return array
+	}
+	return n.value
+}
+
+// writeHCL writes the node as an HCL expression, the hint of a scalar is written as a trailing comment by the parent
+func (n *bodyNode) writeHCL(sb *strings.Builder) {
+	switch {
+	case n.properties != nil && len(n.properties) == 0 && n.hint == "":
+		sb.WriteString("{}")
+	case n.properties != nil:
+		sb.WriteString("{")
+		if n.hint != "" {
+			sb.WriteString(" # " + n.hint)
+		}
+		sb.WriteString("\n")
+		for _, p := range n.properties {
+			sb.WriteString(p.name + " = ")
+			p.node.writeHCL(sb)
+			if p.node.properties == nil && p.node.items == nil && p.node.hint != "" {
+				sb.WriteString(" # " + p.node.hint)
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("}")
+	case n.items != nil:
+		sb.WriteString("[")
+		for i, item := range n.items {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			item.writeHCL(sb)
+		}
+		sb.WriteString("]")
+	default:
+		// JSON scalars are valid HCL literals
+		literal, _ := json.Marshal(n.value)
+		sb.WriteString(string(literal))
+	}
+}
@@ -0,0 +1,46 @@
+package azapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateBodySkeleton_HCL(t *testing.T) {
+	skeleton, err := GenerateBodySkeleton("Microsoft.KeyVault/vaults", "2023-07-01", "")
+	require.NoError(t, err)
+	assert.Equal(t, `body = {
+  properties = {
+    sku = {
+      family = "A"        # Possible values: A
+      name   = "standard" # Possible values: standard, premium
+    }
+    tenantId = "REPLACE_ME"
+  }
+}
+`, skeleton)
+}
+
+func TestGenerateBodySkeleton_JSON(t *testing.T) {
+	skeleton, err := GenerateBodySkeleton("Microsoft.Storage/storageAccounts", "2023-05-01", BodyFormatJSON)
+	require.NoError(t, err)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(skeleton), &body))
+	assert.Equal(t, map[string]any{
+		"kind": "Storage",
+		"sku":  map[string]any{"name": "Standard_LRS"},
+	}, body)
+}
+
+func TestGenerateBodySkeleton_NoRequiredProperties(t *testing.T) {
+	skeleton, err := GenerateBodySkeleton("Microsoft.Compute/virtualMachines", "2024-11-01", BodyFormatHCL)
+	require.NoError(t, err)
+	assert.Equal(t, "body = {}\n", skeleton)
+}
+
+func TestGenerateBodySkeleton_InvalidFormat(t *testing.T) {
+	_, err := GenerateBodySkeleton("Microsoft.KeyVault/vaults", "2023-07-01", "yaml")
+	assert.ErrorContains(t, err, `invalid format "yaml"`)
+}
@@ -0,0 +1,304 @@
+package azapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ms-henglu/go-azure-types/types"
+)
+
+// Kinds of body diagnostics
+const (
+	DiagnosticUnknownProperty = "unknown_property"
+	DiagnosticMissingRequired = "missing_required"
+	DiagnosticReadOnly        = "read_only"
+	DiagnosticTypeMismatch    = "type_mismatch"
+	DiagnosticInvalidValue    = "invalid_value"
+)
+
+// BodyDiagnostic is a problem found in a body, Path is the JSON path of the offending value like `body.properties.sku.name`
+type BodyDiagnostic struct {
+	Path    string `json:"path"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// BodyValidation is the result of validating a body
+type BodyValidation struct {
+	Valid       bool             `json:"valid"`
+	Diagnostics []BodyDiagnostic `json:"diagnostics"`
+}
+
+// ValidateBody validates the JSON body of an azapi_resource against the type of resourceType@apiVersion. It reports
+// unknown properties, missing required properties, read-only properties, type mismatches and values out of enums or
+// bounds. Properties set through azapi_resource arguments, like name and location, may be left out of the body.
+func ValidateBody(resourceType, apiVersion, body string) (*BodyValidation, error) {
+	var value any
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return nil, fmt.Errorf("body is not valid JSON: %w", err)
+	}
+	apiType, err := getAzApiType(resourceType, apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get azapi type for resource %s api-version %s: %w", resourceType, apiVersion, err)
+	}
+	bodyType, ok := apiType.Body.Type.(*types.ObjectType)
+	if !ok {
+		return nil, fmt.Errorf("resource body type is not an object type")
+	}
+	v := &bodyValidator{diagnostics: []BodyDiagnostic{}}
+	if object, ok := value.(map[string]any); ok {
+		v.properties(bodyType.Properties, bodyType.AdditionalProperties, object, "body", true)
+	} else {
+		v.report("body", DiagnosticTypeMismatch, fmt.Sprintf("expected object, got %s", jsonTypeName(value)))
+	}
+	return &BodyValidation{Valid: len(v.diagnostics) == 0, Diagnostics: v.diagnostics}, nil
+}
+
+type bodyValidator struct {
+	diagnostics []BodyDiagnostic
+}
+
+func (v *bodyValidator) report(path, kind, message string) {
+	v.diagnostics = append(v.diagnostics, BodyDiagnostic{Path: path, Kind: kind, Message: message})
+}
+
+func (v *bodyValidator) properties(properties map[string]types.ObjectProperty, additional *types.TypeReference, object map[string]any, path string, topLevel bool) {
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		propertyPath := path + "." + key
+		property, ok := properties[key]
+		switch {
+		case ok && property.IsReadOnly():
+			v.report(propertyPath, DiagnosticReadOnly, "property is read-only and can't be set")
+		case ok:
+			if property.Type != nil {
+				v.value(property.Type.Type, object[key], propertyPath)
+			}
+		case additional != nil:
+			v.value(additional.Type, object[key], propertyPath)
+		default:
+			message := "property is not declared by the schema"
+			if suggestion := closestProperty(key, properties); suggestion != "" {
+				message += fmt.Sprintf(", did you mean %q?", suggestion)
+			}
+			v.report(propertyPath, DiagnosticUnknownProperty, message)
+		}
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property := properties[name]
+		if !property.IsRequired() || property.IsReadOnly() || (topLevel && azapiTopLevelProperties[name]) {
+			continue
+		}
+		if _, ok := object[name]; !ok {
+			v.report(path+"."+name, DiagnosticMissingRequired, "required property is missing")
+		}
+	}
+}
+
+func (v *bodyValidator) value(t types.TypeBase, value any, path string) {
+	if value == nil || t == nil {
+		return
+	}
+	switch typ := t.(type) {
+	case *types.ObjectType:
+		object, ok := value.(map[string]any)
+		if !ok {
+			v.report(path, DiagnosticTypeMismatch, fmt.Sprintf("expected object, got %s", jsonTypeName(value)))
+			return
+		}
+		v.properties(typ.Properties, typ.AdditionalProperties, object, path, false)
+	case *types.DiscriminatedObjectType:
+		v.discriminatedObject(typ, value, path)
+	case *types.ArrayType:
+		array, ok := value.([]any)
+		if !ok {
+			v.report(path, DiagnosticTypeMismatch, fmt.Sprintf("expected array, got %s", jsonTypeName(value)))
+			return
+		}
+		if typ.MinLength != nil && len(array) < *typ.MinLength {
+			v.report(path, DiagnosticInvalidValue, fmt.Sprintf("expected at least %d items, got %d", *typ.MinLength, len(array)))
+		}
+		if typ.MaxLength != nil && len(array) > *typ.MaxLength {
+			v.report(path, DiagnosticInvalidValue, fmt.Sprintf("expected at most %d items, got %d", *typ.MaxLength, len(array)))
+		}
+		if typ.ItemType == nil {
+			return
+		}
+		for i, item := range array {
+			v.value(typ.ItemType.Type, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case *types.StringType:
+		s, ok := value.(string)
+		if !ok {
+			v.report(path, DiagnosticTypeMismatch, fmt.Sprintf("expected string, got %s", jsonTypeName(value)))
+			return
+		}
+		if typ.MinLength != nil && len(s) < *typ.MinLength {
+			v.report(path, DiagnosticInvalidValue, fmt.Sprintf("expected at least %d characters, got %d", *typ.MinLength, len(s)))
+		}
+		if typ.MaxLength != nil && len(s) > *typ.MaxLength {
+			v.report(path, DiagnosticInvalidValue, fmt.Sprintf("expected at most %d characters, got %d", *typ.MaxLength, len(s)))
+		}
+	case *types.StringLiteralType:
+		if s, ok := value.(string); !ok || s != typ.Value {
+			v.report(path, DiagnosticInvalidValue, fmt.Sprintf("expected %q", typ.Value))
+		}
+	case *types.IntegerType:
+		n, ok := value.(float64)
+		if !ok {
+			v.report(path, DiagnosticTypeMismatch, fmt.Sprintf("expected integer, got %s", jsonTypeName(value)))
+			return
+		}
+		// some integer types are numbers in the API specs, fractional values are accepted
+		if typ.MinValue != nil && n < float64(*typ.MinValue) {
+			v.report(path, DiagnosticInvalidValue, fmt.Sprintf("value is less than %d", *typ.MinValue))
+		}
+		if typ.MaxValue != nil && n > float64(*typ.MaxValue) {
+			v.report(path, DiagnosticInvalidValue, fmt.Sprintf("value is greater than %d", *typ.MaxValue))
+		}
+	case *types.BooleanType:
+		if _, ok := value.(bool); !ok {
+			v.report(path, DiagnosticTypeMismatch, fmt.Sprintf("expected boolean, got %s", jsonTypeName(value)))
+		}
+	case *types.UnionType:
+		v.union(typ, value, path)
+	}
+}
+
+// union accepts a value matching any element, values of enums are checked against the possible values
+func (v *bodyValidator) union(union *types.UnionType, value any, path string) {
+	for _, element := range union.Elements {
+		if element == nil || element.Type == nil {
+			continue
+		}
+		if _, ok := element.Type.(*types.StringLiteralType); ok {
+			continue
+		}
+		nested := &bodyValidator{}
+		nested.value(element.Type, value, path)
+		if len(nested.diagnostics) == 0 {
+			return
+		}
+	}
+	values := literalValues(union)
+	s, isString := value.(string)
+	switch {
+	case len(values) == 0:
+		v.report(path, DiagnosticTypeMismatch, "value doesn't match any accepted type")
+	case !isString:
+		v.report(path, DiagnosticTypeMismatch, fmt.Sprintf("expected string, got %s", jsonTypeName(value)))
+	default:
+		for _, possible := range values {
+			if possible == s {
+				return
+			}
+		}
+		v.report(path, DiagnosticInvalidValue, fmt.Sprintf("value %q is not one of the possible values: %s", s, strings.Join(values, ", ")))
+	}
+}
+
+func (v *bodyValidator) discriminatedObject(t *types.DiscriminatedObjectType, value any, path string) {
+	object, ok := value.(map[string]any)
+	if !ok {
+		v.report(path, DiagnosticTypeMismatch, fmt.Sprintf("expected object, got %s", jsonTypeName(value)))
+		return
+	}
+	kinds := make([]string, 0, len(t.Elements))
+	for kind := range t.Elements {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	properties := make(map[string]types.ObjectProperty, len(t.BaseProperties))
+	for name, property := range t.BaseProperties {
+		properties[name] = property
+	}
+	kind, ok := object[t.Discriminator].(string)
+	element, known := t.Elements[kind]
+	switch {
+	case object[t.Discriminator] == nil:
+		v.report(path+"."+t.Discriminator, DiagnosticMissingRequired, fmt.Sprintf("discriminator is missing, possible values: %s", strings.Join(kinds, ", ")))
+		return
+	case !ok || !known:
+		v.report(path+"."+t.Discriminator, DiagnosticInvalidValue, fmt.Sprintf("discriminator value is not one of the possible values: %s", strings.Join(kinds, ", ")))
+		return
+	}
+	if element != nil {
+		if elementObject, ok := element.Type.(*types.ObjectType); ok {
+			for name, property := range elementObject.Properties {
+				properties[name] = property
+			}
+		}
+	}
+	// the discriminator itself is validated above
+	delete(properties, t.Discriminator)
+	rest := make(map[string]any, len(object))
+	for k, val := range object {
+		if k != t.Discriminator {
+			rest[k] = val
+		}
+	}
+	v.properties(properties, nil, rest, path, false)
+}
+
+// closestProperty returns the declared property closest to key, when the difference is only in case or a few characters
+func closestProperty(key string, properties map[string]types.ObjectProperty) string {
+	best, bestDistance := "", 3
+	for name := range properties {
+		if strings.EqualFold(name, key) {
+			return name
+		}
+		if d := editDistance(strings.ToLower(key), strings.ToLower(name)); d < bestDistance || (d == bestDistance && best != "" && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "null"
+}
@@ -0,0 +1,62 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBody(t *testing.T) {
+	cases := []struct {
+		desc     string
+		body     string
+		expected []BodyDiagnostic
+	}{
+		{
+			desc: "valid",
+			body: `{"properties": {"tenantId": "00000000-0000-0000-0000-000000000000", "sku": {"family": "A", "name": "premium"}, "enableSoftDelete": true, "accessPolicies": []}}`,
+		},
+		{
+			desc: "missing required",
+			body: `{"properties": {"sku": {"family": "A"}}}`,
+			expected: []BodyDiagnostic{
+				{Path: "body.properties.sku.name", Kind: DiagnosticMissingRequired, Message: "required property is missing"},
+				{Path: "body.properties.tenantId", Kind: DiagnosticMissingRequired, Message: "required property is missing"},
+			},
+		},
+		{
+			desc: "unknown, enum, type and read-only",
+			body: `{"id": "x", "properties": {"tenantid": "x", "tenantId": "00000000-0000-0000-0000-000000000000", "sku": {"family": "A", "name": "basic"}, "enableSoftDelete": "yes", "hsmPoolResourceId": "x", "accessPolicies": [{"tenantId": "00000000-0000-0000-0000-000000000000", "objectId": "y"}]}}`,
+			expected: []BodyDiagnostic{
+				{Path: "body.id", Kind: DiagnosticReadOnly, Message: "property is read-only and can't be set"},
+				{Path: "body.properties.accessPolicies[0].permissions", Kind: DiagnosticMissingRequired, Message: "required property is missing"},
+				{Path: "body.properties.enableSoftDelete", Kind: DiagnosticTypeMismatch, Message: "expected boolean, got string"},
+				{Path: "body.properties.hsmPoolResourceId", Kind: DiagnosticReadOnly, Message: "property is read-only and can't be set"},
+				{Path: "body.properties.sku.name", Kind: DiagnosticInvalidValue, Message: `value "basic" is not one of the possible values: standard, premium`},
+				{Path: "body.properties.tenantid", Kind: DiagnosticUnknownProperty, Message: `property is not declared by the schema, did you mean "tenantId"?`},
+			},
+		},
+		{
+			desc:     "not an object",
+			body:     `[]`,
+			expected: []BodyDiagnostic{{Path: "body", Kind: DiagnosticTypeMismatch, Message: "expected object, got array"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			validation, err := ValidateBody("Microsoft.KeyVault/vaults", "2023-07-01", c.body)
+			require.NoError(t, err)
+			if c.expected == nil {
+				c.expected = []BodyDiagnostic{}
+			}
+			assert.Equal(t, c.expected, validation.Diagnostics)
+			assert.Equal(t, len(c.expected) == 0, validation.Valid)
+		})
+	}
+}
+
+func TestValidateBody_InvalidJSON(t *testing.T) {
+	_, err := ValidateBody("Microsoft.KeyVault/vaults", "2023-07-01", `{`)
+	assert.ErrorContains(t, err, "body is not valid JSON")
+}
@@ -0,0 +1,52 @@
+package azapi
+
+import (
+	"fmt"
+
+	"github.com/lonegunmanb/newres/v3/pkg/azapi"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+	"github.com/ms-henglu/go-azure-types/types"
+)
+
+// CacheEntriesEnv is the maximum number of AzAPI lookups kept in memory, defaults to 256, kept along the generic
+// `CACHE_AZAPI_LOOKUP_*` variables
+const CacheEntriesEnv = "AZAPI_CACHE_ENTRIES"
+
+const defaultCacheEntries = 256
+
+// lookupCache holds the results of the most recently used AzAPI lookups of this process, like resolved types and
+// converted schemas, keyed by the kind of lookup and its arguments. Cached values are shared and must not be modified.
+var lookupCache = cache.Register(newLookupCache())
+
+func newLookupCache() *cache.Cache[any] {
+	return cache.New[any](cache.Options{
+		Name:          "azapi_lookup",
+		Description:   "AzAPI lookups like resolved types and converted schemas, keyed by the kind of lookup and its arguments",
+		MaxEntries:    defaultCacheEntries,
+		MemoryOnly:    true,
+		MaxEntriesEnv: CacheEntriesEnv,
+	})
+}
+
+// cachedLookup returns the cached result of key, or loads and caches it. Errors aren't cached.
+func cachedLookup[T any](key string, load func() (T, error)) (T, error) {
+	cached, _, ok := lookupCache.Get(key)
+	telemetry.RecordCacheLookup("azapi_lookup", ok)
+	if ok {
+		return cached.(T), nil
+	}
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	_ = lookupCache.Put(key, value)
+	return value, nil
+}
+
+// getAzApiType returns the go-azure-types definition of resourceType@apiVersion, see azapi.GetAzApiType
+func getAzApiType(resourceType, apiVersion string) (*types.ResourceType, error) {
+	return cachedLookup(fmt.Sprintf("type:%s@%s", resourceType, apiVersion), func() (*types.ResourceType, error) {
+		return azapi.GetAzApiType(resourceType, apiVersion)
+	})
+}
@@ -0,0 +1,67 @@
+package azapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupCache_Config(t *testing.T) {
+	t.Setenv(CacheEntriesEnv, "2")
+	t.Setenv("CACHE_AZAPI_LOOKUP_BACKEND", cache.BackendDisk)
+	assert.Equal(t, cache.Config{Backend: cache.BackendMemory, MaxEntries: 2}, newLookupCache().Config(), "converted types can't be stored on disk")
+}
+
+func TestCachedLookup(t *testing.T) {
+	stubs := gostub.Stub(&lookupCache, newLookupCache())
+	defer stubs.Reset()
+
+	loads := 0
+	load := func() (string, error) {
+		loads++
+		return "value", nil
+	}
+	for i := 0; i < 3; i++ {
+		v, err := cachedLookup("key", load)
+		require.NoError(t, err)
+		assert.Equal(t, "value", v)
+	}
+	assert.Equal(t, 1, loads)
+
+	failures := 0
+	fail := func() (string, error) {
+		failures++
+		return "", errors.New("boom")
+	}
+	for i := 0; i < 2; i++ {
+		_, err := cachedLookup("failing", fail)
+		assert.EqualError(t, err, "boom")
+	}
+	assert.Equal(t, 2, failures, "errors must not be cached")
+}
+
+func TestGetBlockSchema_Cached(t *testing.T) {
+	stubs := gostub.Stub(&lookupCache, newLookupCache())
+	defer stubs.Reset()
+
+	first, err := GetBlockSchema(DefaultBlockType, "Microsoft.KeyVault/vaults", "2023-07-01", "body.properties.sku", false)
+	require.NoError(t, err)
+	_, _, ok := lookupCache.Get("schema:azapi_resource:Microsoft.KeyVault/vaults@2023-07-01:body.properties.sku:go:false")
+	assert.True(t, ok)
+	_, _, ok = lookupCache.Get("type:Microsoft.KeyVault/vaults@2023-07-01")
+	assert.True(t, ok)
+
+	second, err := GetBlockSchema(DefaultBlockType, "Microsoft.KeyVault/vaults", "2023-07-01", "body.properties.sku", false)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	description, err := GetBlockSchemaDescription(DefaultBlockType, "Microsoft.KeyVault/vaults", "2023-07-01", "body.properties.sku.family", false)
+	require.NoError(t, err)
+	assert.Equal(t, "SKU family name (Required) (Possible values: A)", description)
+	_, _, ok = lookupCache.Get("body-description:Microsoft.KeyVault/vaults@2023-07-01:false")
+	assert.True(t, ok)
+}
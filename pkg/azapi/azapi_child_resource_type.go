@@ -0,0 +1,103 @@
+package azapi
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ChildResourceType is a child resource type of an ARM resource type, its azapi_resource `parent_id` is the id of a
+// ParentResourceType resource
+type ChildResourceType struct {
+	ResourceType       string   `json:"resource_type"`
+	ParentResourceType string   `json:"parent_resource_type"`
+	ApiVersions        []string `json:"api_versions"`
+}
+
+// ChildResourceTypes lists the child resource types of ResourceType
+type ChildResourceTypes struct {
+	ResourceType       string              `json:"resource_type"`
+	ChildResourceTypes []ChildResourceType `json:"child_resource_types"`
+}
+
+// ListChildResourceTypes lists the child resource types of parentType known by go-azure-types, like
+// `Microsoft.Network/virtualNetworks/subnets` for `Microsoft.Network/virtualNetworks`, with their api-versions.
+// parentType is matched case-insensitively. Only direct children are listed unless recursive is true.
+func ListChildResourceTypes(parentType string, recursive bool) (*ChildResourceTypes, error) {
+	schema := azureSchemaLoader.GetSchema()
+	if schema == nil {
+		return nil, fmt.Errorf("failed to load azure schema index")
+	}
+	parentType = canonicalResourceType(strings.TrimSuffix(strings.TrimSpace(parentType), "/"))
+	if strings.Count(parentType, "/") < 1 {
+		return nil, fmt.Errorf("invalid resource type %q, must be like Microsoft.Network/virtualNetworks", parentType)
+	}
+	prefix := strings.ToLower(parentType) + "/"
+	parentKnown := false
+	// the index has a few resource types under several casings, like Microsoft.Network/virtualnetworks, they're merged
+	// into the casing with the most api-versions
+	merged := make(map[string]*ChildResourceType)
+	for resourceType := range schema.Resources {
+		lower := strings.ToLower(resourceType)
+		if lower+"/" == prefix {
+			parentKnown = true
+			continue
+		}
+		if !strings.HasPrefix(lower, prefix) {
+			continue
+		}
+		if !recursive && strings.Contains(lower[len(prefix):], "/") {
+			continue
+		}
+		apiVersions := azureSchemaLoader.ListApiVersions(resourceType)
+		child, ok := merged[lower]
+		if !ok {
+			child = &ChildResourceType{}
+			merged[lower] = child
+		}
+		if len(apiVersions) > len(child.ApiVersions) || len(apiVersions) == len(child.ApiVersions) && resourceType < child.ResourceType {
+			child.ResourceType = resourceType
+		}
+		child.ApiVersions = mergeApiVersions(child.ApiVersions, apiVersions)
+	}
+	children := make([]ChildResourceType, 0, len(merged))
+	for _, child := range merged {
+		child.ParentResourceType = parentType + child.ResourceType[len(parentType):strings.LastIndex(child.ResourceType, "/")]
+		children = append(children, *child)
+	}
+	if !parentKnown && len(children) == 0 {
+		return nil, fmt.Errorf("resource type %s not found", parentType)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return strings.ToLower(children[i].ResourceType) < strings.ToLower(children[j].ResourceType)
+	})
+	return &ChildResourceTypes{ResourceType: parentType, ChildResourceTypes: children}, nil
+}
+
+// canonicalResourceType returns the casing of resourceType with the most api-versions in the index, resourceType itself
+// when it's unknown
+func canonicalResourceType(resourceType string) string {
+	canonical, count := resourceType, 0
+	for rt := range azureSchemaLoader.GetSchema().Resources {
+		if !strings.EqualFold(rt, resourceType) {
+			continue
+		}
+		if n := len(azureSchemaLoader.ListApiVersions(rt)); n > count || n == count && rt < canonical {
+			canonical, count = rt, n
+		}
+	}
+	return canonical
+}
+
+func mergeApiVersions(a, b []string) []string {
+	versions := make([]string, 0, len(a)+len(b))
+	versions = append(versions, a...)
+	for _, v := range b {
+		if !slices.Contains(versions, v) {
+			versions = append(versions, v)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
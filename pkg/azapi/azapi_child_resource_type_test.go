@@ -0,0 +1,63 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func childResourceTypeNames(children *ChildResourceTypes) []string {
+	names := make([]string, 0, len(children.ChildResourceTypes))
+	for _, c := range children.ChildResourceTypes {
+		names = append(names, c.ResourceType)
+	}
+	return names
+}
+
+func TestListChildResourceTypes(t *testing.T) {
+	children, err := ListChildResourceTypes("microsoft.network/VIRTUALNETWORKS", false)
+	require.NoError(t, err)
+	assert.Equal(t, "Microsoft.Network/virtualNetworks", children.ResourceType)
+	names := childResourceTypeNames(children)
+	assert.Contains(t, names, "Microsoft.Network/virtualNetworks/subnets")
+	assert.Contains(t, names, "Microsoft.Network/virtualNetworks/virtualNetworkPeerings")
+	assert.NotContains(t, names, "Microsoft.Network/virtualNetworks")
+	assert.NotContains(t, names, "Microsoft.Network/virtualNetworkGateways")
+	for _, c := range children.ChildResourceTypes {
+		assert.Equal(t, "Microsoft.Network/virtualNetworks", c.ParentResourceType)
+		assert.NotEmpty(t, c.ApiVersions)
+	}
+	apiVersions, err := GetApiVersions("Microsoft.Network/virtualNetworks/subnets")
+	require.NoError(t, err)
+	for _, c := range children.ChildResourceTypes {
+		if c.ResourceType == "Microsoft.Network/virtualNetworks/subnets" {
+			assert.Subset(t, c.ApiVersions, apiVersions)
+		}
+	}
+}
+
+func TestListChildResourceTypes_Recursive(t *testing.T) {
+	direct, err := ListChildResourceTypes("Microsoft.Storage/storageAccounts", false)
+	require.NoError(t, err)
+	assert.NotContains(t, childResourceTypeNames(direct), "Microsoft.Storage/storageAccounts/blobServices/containers")
+
+	recursive, err := ListChildResourceTypes("Microsoft.Storage/storageAccounts", true)
+	require.NoError(t, err)
+	names := childResourceTypeNames(recursive)
+	assert.Contains(t, names, "Microsoft.Storage/storageAccounts/blobServices")
+	assert.Contains(t, names, "Microsoft.Storage/storageAccounts/blobServices/containers")
+	for _, c := range recursive.ChildResourceTypes {
+		if c.ResourceType == "Microsoft.Storage/storageAccounts/blobServices/containers" {
+			assert.Equal(t, "Microsoft.Storage/storageAccounts/blobServices", c.ParentResourceType)
+		}
+	}
+}
+
+func TestListChildResourceTypes_Unknown(t *testing.T) {
+	_, err := ListChildResourceTypes("Microsoft.DoesNotExist/things", false)
+	assert.ErrorContains(t, err, "resource type Microsoft.DoesNotExist/things not found")
+
+	_, err = ListChildResourceTypes("Microsoft.Network", false)
+	assert.ErrorContains(t, err, "invalid resource type")
+}
@@ -0,0 +1,120 @@
+package azapi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Description output formats
+const (
+	DescriptionFormatJSON     = "json"
+	DescriptionFormatMarkdown = "markdown"
+)
+
+// descriptionFlags are the flags appended to property descriptions by ConvertAzApiObjectPropertyToMap, like
+// `The resource name (Required) (DeployTimeConstant)`
+var descriptionFlags = []string{"Required", "ReadOnly", "WriteOnly", "Identifier", "DeployTimeConstant", "Discriminator"}
+
+var possibleValuesSuffix = regexp.MustCompile(`\s*\(Possible values: ([^()]*)\)$`)
+
+// DescriptionMarkdown renders a description returned by GetBlockSchemaDescription as a nested Markdown document, with
+// a property table per object and a section per nested object. title is the top level heading, like
+// `Microsoft.KeyVault/vaults@2023-07-01`, path is the path the description was queried at, empty for the whole block.
+func DescriptionMarkdown(title, path string, description any) string {
+	sb := &strings.Builder{}
+	sb.WriteString(fmt.Sprintf("# %s\n", title))
+	object, ok := description.(map[string]any)
+	if !ok {
+		if path != "" {
+			sb.WriteString(fmt.Sprintf("\n## %s\n", path))
+		}
+		writePropertyTable(sb, map[string]any{lastSegment(path): description})
+		return sb.String()
+	}
+	level := 1
+	if path != "" {
+		level = 2
+	}
+	writeDescriptionSection(sb, path, object, level)
+	return sb.String()
+}
+
+func writeDescriptionSection(sb *strings.Builder, path string, object map[string]any, level int) {
+	if path != "" {
+		sb.WriteString(fmt.Sprintf("\n%s %s\n", strings.Repeat("#", min(level, 6)), path))
+	}
+	writePropertyTable(sb, object)
+	for _, name := range sortedKeys(object) {
+		if nested, ok := object[name].(map[string]any); ok {
+			writeDescriptionSection(sb, joinSegment(path, name), nested, level+1)
+		}
+	}
+}
+
+func writePropertyTable(sb *strings.Builder, object map[string]any) {
+	if len(object) == 0 {
+		sb.WriteString("\nNo properties.\n")
+		return
+	}
+	sb.WriteString("\n| Property | Description | Flags | Possible values |\n")
+	sb.WriteString("| --- | --- | --- | --- |\n")
+	for _, name := range sortedKeys(object) {
+		description, flags, values := "Object, see the section below.", []string{}, []string{}
+		if d, ok := object[name].(string); ok {
+			description, flags, values = splitDescription(d)
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", name, escapeMarkdownCell(description),
+			strings.Join(flags, ", "), escapeMarkdownCell(strings.Join(values, ", "))))
+	}
+}
+
+// splitDescription splits the flags and possible values appended to a property description off the description
+func splitDescription(description string) (string, []string, []string) {
+	var flags, values []string
+	for {
+		trimmed := strings.TrimSpace(description)
+		if match := possibleValuesSuffix.FindStringSubmatch(trimmed); match != nil {
+			for _, v := range strings.Split(match[1], ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					values = append(values, v)
+				}
+			}
+			description = trimmed[:len(trimmed)-len(match[0])]
+			continue
+		}
+		found := false
+		for _, flag := range descriptionFlags {
+			if rest, ok := strings.CutSuffix(trimmed, "("+flag+")"); ok {
+				flags = append([]string{flag}, flags...)
+				description, found = rest, true
+				break
+			}
+		}
+		if !found {
+			return trimmed, flags, values
+		}
+	}
+}
+
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func lastSegment(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return path[strings.LastIndex(path, ".")+1:]
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitDescription(t *testing.T) {
+	cases := []struct {
+		description string
+		expected    string
+		flags       []string
+		values      []string
+	}{
+		{
+			description: "The resource name (Required) (DeployTimeConstant)",
+			expected:    "The resource name",
+			flags:       []string{"Required", "DeployTimeConstant"},
+		},
+		{
+			description: "SKU name. (Required) (Possible values: standard,premium)",
+			expected:    "SKU name.",
+			flags:       []string{"Required"},
+			values:      []string{"standard", "premium"},
+		},
+		{
+			description: "Plain description (with parentheses)",
+			expected:    "Plain description (with parentheses)",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			description, flags, values := splitDescription(c.description)
+			assert.Equal(t, c.expected, description)
+			assert.Equal(t, c.flags, flags)
+			assert.Equal(t, c.values, values)
+		})
+	}
+}
+
+func TestDescriptionMarkdown(t *testing.T) {
+	description := map[string]any{
+		"parent_id": "The ID of the parent resource.",
+		"body": map[string]any{
+			"properties": map[string]any{
+				"sku": map[string]any{
+					"name": "SKU name. (Required) (Possible values: standard,premium)",
+				},
+				"tenantId": "The tenant ID | GUID. (Required)",
+			},
+		},
+	}
+	expected := "# Microsoft.KeyVault/vaults@2023-07-01\n" +
+		"\n| Property | Description | Flags | Possible values |\n| --- | --- | --- | --- |\n" +
+		"| `body` | Object, see the section below. |  |  |\n" +
+		"| `parent_id` | The ID of the parent resource. |  |  |\n" +
+		"\n## body\n" +
+		"\n| Property | Description | Flags | Possible values |\n| --- | --- | --- | --- |\n" +
+		"| `properties` | Object, see the section below. |  |  |\n" +
+		"\n### body.properties\n" +
+		"\n| Property | Description | Flags | Possible values |\n| --- | --- | --- | --- |\n" +
+		"| `sku` | Object, see the section below. |  |  |\n" +
+		"| `tenantId` | The tenant ID \\| GUID. | Required |  |\n" +
+		"\n#### body.properties.sku\n" +
+		"\n| Property | Description | Flags | Possible values |\n| --- | --- | --- | --- |\n" +
+		"| `name` | SKU name. | Required | standard, premium |\n"
+	assert.Equal(t, expected, DescriptionMarkdown("Microsoft.KeyVault/vaults@2023-07-01", "", description))
+}
+
+func TestDescriptionMarkdown_Path(t *testing.T) {
+	description, err := GetResourceSchemaDescription("Microsoft.KeyVault/vaults", "2023-07-01", "body.properties.sku.name")
+	require.NoError(t, err)
+	expected := "# Microsoft.KeyVault/vaults@2023-07-01\n" +
+		"\n## body.properties.sku.name\n" +
+		"\n| Property | Description | Flags | Possible values |\n| --- | --- | --- | --- |\n" +
+		"| `name` | SKU name to specify whether the key vault is a standard vault or a premium vault. | Required | standard, premium |\n"
+	assert.Equal(t, expected, DescriptionMarkdown("Microsoft.KeyVault/vaults@2023-07-01", "body.properties.sku.name", description))
+}
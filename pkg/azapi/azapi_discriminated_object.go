@@ -0,0 +1,220 @@
+package azapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lonegunmanb/newres/v3/pkg/azapi"
+	"github.com/ms-henglu/go-azure-types/types"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// rootBodyProperties are the body properties the azapi schema lifts out of `body`
+var rootBodyProperties = map[string]bool{
+	"location": true,
+	"name":     true,
+	"tags":     true,
+	"identity": true,
+}
+
+// variantKey is the key of a discriminated object variant in the outputs, like `dhcpType=RELAY`
+func variantKey(t *types.DiscriminatedObjectType, variant string) string {
+	return t.Discriminator + "=" + variant
+}
+
+func variantNames(t *types.DiscriminatedObjectType) []string {
+	variants := make([]string, 0, len(t.Elements))
+	for variant, element := range t.Elements {
+		if element != nil {
+			variants = append(variants, variant)
+		}
+	}
+	sort.Strings(variants)
+	return variants
+}
+
+// variantProperties returns the properties a variant adds to the base properties, the discriminator excluded
+func variantProperties(t *types.DiscriminatedObjectType, variant string) map[string]types.ObjectProperty {
+	properties := make(map[string]types.ObjectProperty)
+	element, ok := t.Elements[variant]
+	if !ok || element == nil {
+		return properties
+	}
+	obj, ok := element.Type.(*types.ObjectType)
+	if !ok {
+		return properties
+	}
+	for name, property := range obj.Properties {
+		if _, ok := t.BaseProperties[name]; ok || name == t.Discriminator {
+			continue
+		}
+		properties[name] = property
+	}
+	return properties
+}
+
+// findDiscriminatedObjects collects the discriminated objects of the swagger body type keyed by their path in the
+// Go type, i.e. root properties are lifted out of `body` and arrays and maps are traversed transparently.
+// Variants aren't traversed, their properties have no path in the Go type.
+func findDiscriminatedObjects(bodyType *types.ObjectType) map[string]*types.DiscriminatedObjectType {
+	result := make(map[string]*types.DiscriminatedObjectType)
+	for name, property := range bodyType.Properties {
+		path := "body." + name
+		if rootBodyProperties[name] {
+			path = name
+		}
+		collectDiscriminatedObjects(property, path, result)
+	}
+	return result
+}
+
+func collectDiscriminatedObjects(property types.ObjectProperty, path string, result map[string]*types.DiscriminatedObjectType) {
+	if property.IsReadOnly() || property.Type == nil {
+		return
+	}
+	t := property.Type.Type
+	for {
+		switch v := t.(type) {
+		case *types.ArrayType:
+			if v.ItemType == nil {
+				return
+			}
+			t = v.ItemType.Type
+			continue
+		case *types.ObjectType:
+			if len(v.Properties) == 0 && v.AdditionalProperties != nil {
+				t = v.AdditionalProperties.Type
+				continue
+			}
+			for name, p := range v.Properties {
+				collectDiscriminatedObjects(p, path+"."+name, result)
+			}
+		case *types.DiscriminatedObjectType:
+			result[path] = v
+		}
+		return
+	}
+}
+
+// discriminatedObjectComments describes the discriminated objects at or under path, as types can only represent them
+// as DynamicPseudoType. Each object has a group of comment lines listing its base properties and the distinct
+// properties of every variant, rendered as compact types in format.
+func discriminatedObjectComments(bodyType *types.ObjectType, path, format string) ([][]string, error) {
+	objects := findDiscriminatedObjects(bodyType)
+	paths := make([]string, 0, len(objects))
+	for p := range objects {
+		if path == "" || p == path || strings.HasPrefix(p, path+".") {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	comments := make([][]string, 0, len(paths))
+	for _, p := range paths {
+		t := objects[p]
+		base, err := objectType(t.BaseProperties, format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert base properties of %s: %w", p, err)
+		}
+		lines := []string{
+			fmt.Sprintf("%s is a discriminated object, %q selects the variant", p, t.Discriminator),
+			fmt.Sprintf("base: %s", base),
+		}
+		for _, variant := range variantNames(t) {
+			properties, err := objectType(variantProperties(t, variant), format)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert variant %s of %s: %w", variant, p, err)
+			}
+			lines = append(lines, fmt.Sprintf("%s: %s", variantKey(t, variant), properties))
+		}
+		comments = append(comments, lines)
+	}
+	return comments, nil
+}
+
+// objectType returns the compact type in format of an object with properties
+func objectType(properties map[string]types.ObjectProperty, format string) (string, error) {
+	t, err := objectCtyType(properties)
+	if err != nil {
+		return "", err
+	}
+	return formatType(t, format, true)
+}
+
+// discriminatedObjectSchemas returns the JSON schemas of the discriminated objects at or under path keyed by their
+// path, each one is `oneOf` its variants, whose discriminator is a const of the variant name
+func discriminatedObjectSchemas(bodyType *types.ObjectType, path string) (map[string]map[string]any, error) {
+	result := make(map[string]map[string]any)
+	for p, t := range findDiscriminatedObjects(bodyType) {
+		if path != "" && p != path && !strings.HasPrefix(p, path+".") {
+			continue
+		}
+		variants := make([]any, 0, len(t.Elements))
+		for _, variant := range variantNames(t) {
+			properties := make(map[string]types.ObjectProperty)
+			for name, property := range t.BaseProperties {
+				properties[name] = property
+			}
+			for name, property := range variantProperties(t, variant) {
+				properties[name] = property
+			}
+			delete(properties, t.Discriminator)
+			objType, err := objectCtyType(properties)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert variant %s of %s: %w", variant, p, err)
+			}
+			schema := jsonSchemaType(objType)
+			schema["properties"].(map[string]any)[t.Discriminator] = map[string]any{"const": variant}
+			required, _ := schema["required"].([]string)
+			schema["required"] = append([]string{t.Discriminator}, required...)
+			variants = append(variants, schema)
+		}
+		result[p] = map[string]any{"oneOf": variants}
+	}
+	return result, nil
+}
+
+// objectCtyType returns the type of an object with properties
+func objectCtyType(properties map[string]types.ObjectProperty) (cty.Type, error) {
+	// newres only converts whole bodies, so the object is wrapped into a body property to get its type
+	block, err := azapi.ConvertAzApiObjectTypeToTerraformJsonSchemaAttribute(types.ObjectProperty{
+		Type: &types.TypeReference{
+			Type: &types.ObjectType{
+				Properties: map[string]types.ObjectProperty{
+					"object": {Type: &types.TypeReference{Type: &types.ObjectType{Properties: properties}}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return cty.NilType, err
+	}
+	body, ok := block.Attributes["body"]
+	if !ok || !body.AttributeType.IsObjectType() || !body.AttributeType.HasAttribute("object") {
+		return cty.NilType, fmt.Errorf("unexpected body type")
+	}
+	return body.AttributeType.AttributeType("object"), nil
+}
+
+// discriminatedObjectToMap converts a DiscriminatedObjectType to the descriptions of its base properties, with the
+// descriptions of the distinct properties of each variant keyed by variantKey
+func discriminatedObjectToMap(t *types.DiscriminatedObjectType) (map[string]any, error) {
+	result, err := convertObjectTypeToMap(&types.ObjectType{Properties: t.BaseProperties})
+	if err != nil {
+		return nil, err
+	}
+	variants := variantNames(t)
+	discriminator := fmt.Sprintf("Selects the variant of the object (Discriminator) (Possible values: %s)", strings.Join(variants, ","))
+	if description, ok := result[t.Discriminator].(string); ok {
+		discriminator = fmt.Sprintf("%s (Discriminator) (Possible values: %s)", description, strings.Join(variants, ","))
+	}
+	result[t.Discriminator] = discriminator
+	for _, variant := range variants {
+		properties, err := convertObjectTypeToMap(&types.ObjectType{Properties: variantProperties(t, variant)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert variant %s: %w", variant, err)
+		}
+		result[variantKey(t, variant)] = properties
+	}
+	return result, nil
+}
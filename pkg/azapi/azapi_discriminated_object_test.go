@@ -0,0 +1,65 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dhcpConfigurationType = "Microsoft.AVS/privateClouds/workloadNetworks/dhcpConfigurations"
+
+func TestGetBlockSchema_DiscriminatedObject(t *testing.T) {
+	expectedComments := `
+// body.properties is a discriminated object, "dhcpType" selects the variant
+// base: ObjectWithOptionalAttrs(map[string]Type{"displayName":String, "revision":Number}, []string{"displayName", "revision"})
+// dhcpType=RELAY: ObjectWithOptionalAttrs(map[string]Type{"serverAddresses":List(String)}, []string{"serverAddresses"})
+// dhcpType=SERVER: ObjectWithOptionalAttrs(map[string]Type{"leaseTime":Number, "serverAddress":String}, []string{"leaseTime", "serverAddress"})
+`
+	cases := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "parent path",
+			path:     "body",
+			expected: `ObjectWithOptionalAttrs(map[string]Type{"properties":DynamicPseudoType}, []string{"properties"})` + expectedComments,
+		},
+		{
+			name:     "discriminated object path",
+			path:     "body.properties",
+			expected: `DynamicPseudoType` + expectedComments,
+		},
+		{
+			name:     "unrelated path",
+			path:     "name",
+			expected: `String`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			schema, err := GetBlockSchema(DefaultBlockType, dhcpConfigurationType, "2024-09-01", c.path, false)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, schema)
+		})
+	}
+}
+
+func TestGetResourceSchemaDescription_DiscriminatedObject(t *testing.T) {
+	description, err := GetResourceSchemaDescription(dhcpConfigurationType, "2024-09-01", "body.properties")
+	require.NoError(t, err)
+	properties, ok := description.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "Selects the variant of the object (Discriminator) (Possible values: RELAY,SERVER)", properties["dhcpType"])
+	assert.Equal(t, "Display name of the DHCP entity.", properties["displayName"])
+	assert.Equal(t, map[string]any{"serverAddresses": "DHCP Relay Addresses. Max 3."}, properties["dhcpType=RELAY"])
+	assert.Equal(t, map[string]any{
+		"leaseTime":     "DHCP Server Lease Time.",
+		"serverAddress": "DHCP Server Address.",
+	}, properties["dhcpType=SERVER"])
+
+	description, err = GetResourceSchemaDescription(dhcpConfigurationType, "2024-09-01", "body.properties.dhcpType=SERVER.leaseTime")
+	require.NoError(t, err)
+	assert.Equal(t, "DHCP Server Lease Time.", description)
+}
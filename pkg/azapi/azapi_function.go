@@ -0,0 +1,161 @@
+package azapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FunctionParameter is a parameter of an azapi provider function, Type is a Terraform type constraint like
+// `list(string)`
+type FunctionParameter struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// Function documents an azapi provider function, called like `provider::azapi::build_resource_id(...)`
+type Function struct {
+	Name        string              `json:"name"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description,omitempty"`
+	Parameters  []FunctionParameter `json:"parameters,omitempty"`
+	ReturnType  string              `json:"return_type,omitempty"`
+	Example     string              `json:"example,omitempty"`
+	// ExampleResult is the value the example evaluates to
+	ExampleResult string `json:"example_result,omitempty"`
+}
+
+// functionCallPrefix is the prefix of azapi provider function calls, functions need Terraform 1.8 or later
+const functionCallPrefix = "provider::azapi::"
+
+const exampleSubscriptionID = "00000000-0000-0000-0000-000000000000"
+
+const exampleResourceGroupID = "/subscriptions/" + exampleSubscriptionID + "/resourceGroups/myResourceGroup"
+
+var resourceNamesParameter = FunctionParameter{
+	Name:        "resource_names",
+	Type:        "list(string)",
+	Description: "The names of the resource and its parents, from the top level resource, one per segment of the resource type after the provider namespace.",
+}
+
+// azapiFunctions are the provider functions of the azapi provider 2.x, which are missing from the generated azapi
+// schema
+var azapiFunctions = map[string]*Function{
+	"build_resource_id": {
+		Name:        "build_resource_id",
+		Summary:     "Builds an Azure resource ID from the parent ID, resource type and resource name.",
+		Description: "The parent ID is the ID of the scope the resource is deployed to, like a resource group ID, or of the parent resource of a child resource, the same value as the `parent_id` of an azapi_resource. The resource type is the full type of the resource, including its parent types.",
+		Parameters: []FunctionParameter{
+			{Name: "parent_id", Type: "string", Description: "The ID of the parent resource or scope, like a resource group ID."},
+			{Name: "resource_type", Type: "string", Description: "The resource type, like `Microsoft.Network/virtualNetworks/subnets`."},
+			{Name: "name", Type: "string", Description: "The name of the resource."},
+		},
+		ReturnType:    "string",
+		Example:       `provider::azapi::build_resource_id("` + exampleResourceGroupID + `", "Microsoft.Network/virtualNetworks", "myVNet")`,
+		ExampleResult: exampleResourceGroupID + "/providers/Microsoft.Network/virtualNetworks/myVNet",
+	},
+	"parse_resource_id": {
+		Name:        "parse_resource_id",
+		Summary:     "Parses an Azure resource ID into its components.",
+		Description: "Returns an object with the `id`, `type`, `name`, `parent_id`, `subscription_id`, `resource_group_name`, `provider_namespace` and `parts`, a map of the key/value segments of the ID. The resource type must match the ID.",
+		Parameters: []FunctionParameter{
+			{Name: "resource_type", Type: "string", Description: "The resource type of the ID, like `Microsoft.Network/virtualNetworks`."},
+			{Name: "resource_id", Type: "string", Description: "The resource ID to parse."},
+		},
+		ReturnType:    "object",
+		Example:       `provider::azapi::parse_resource_id("Microsoft.Network/virtualNetworks", "` + exampleResourceGroupID + `/providers/Microsoft.Network/virtualNetworks/myVNet")`,
+		ExampleResult: `{ id = "` + exampleResourceGroupID + `/providers/Microsoft.Network/virtualNetworks/myVNet", type = "Microsoft.Network/virtualNetworks", name = "myVNet", parent_id = "` + exampleResourceGroupID + `", subscription_id = "` + exampleSubscriptionID + `", resource_group_name = "myResourceGroup", provider_namespace = "Microsoft.Network", parts = { subscriptions = "` + exampleSubscriptionID + `", resourceGroups = "myResourceGroup", providers = "Microsoft.Network", virtualNetworks = "myVNet" } }`,
+	},
+	"tenant_resource_id": {
+		Name:    "tenant_resource_id",
+		Summary: "Builds the ID of a resource deployed at the tenant scope.",
+		Parameters: []FunctionParameter{
+			{Name: "resource_type", Type: "string", Description: "The resource type, like `Microsoft.Billing/billingAccounts`."},
+			resourceNamesParameter,
+		},
+		ReturnType:    "string",
+		Example:       `provider::azapi::tenant_resource_id("Microsoft.Billing/billingAccounts/billingProfiles", ["ba1", "bp1"])`,
+		ExampleResult: "/providers/Microsoft.Billing/billingAccounts/ba1/billingProfiles/bp1",
+	},
+	"subscription_resource_id": {
+		Name:    "subscription_resource_id",
+		Summary: "Builds the ID of a resource deployed at the subscription scope.",
+		Parameters: []FunctionParameter{
+			{Name: "subscription_id", Type: "string", Description: "The subscription ID."},
+			{Name: "resource_type", Type: "string", Description: "The resource type, like `Microsoft.Resources/resourceGroups`."},
+			resourceNamesParameter,
+		},
+		ReturnType:    "string",
+		Example:       `provider::azapi::subscription_resource_id("` + exampleSubscriptionID + `", "Microsoft.Resources/resourceGroups", ["myResourceGroup"])`,
+		ExampleResult: exampleResourceGroupID,
+	},
+	"management_group_resource_id": {
+		Name:    "management_group_resource_id",
+		Summary: "Builds the ID of a resource deployed at the management group scope.",
+		Parameters: []FunctionParameter{
+			{Name: "management_group_name", Type: "string", Description: "The name of the management group."},
+			{Name: "resource_type", Type: "string", Description: "The resource type, like `Microsoft.Authorization/policyDefinitions`."},
+			resourceNamesParameter,
+		},
+		ReturnType:    "string",
+		Example:       `provider::azapi::management_group_resource_id("myManagementGroup", "Microsoft.Authorization/policyDefinitions", ["myPolicy"])`,
+		ExampleResult: "/providers/Microsoft.Management/managementGroups/myManagementGroup/providers/Microsoft.Authorization/policyDefinitions/myPolicy",
+	},
+	"resource_group_resource_id": {
+		Name:    "resource_group_resource_id",
+		Summary: "Builds the ID of a resource deployed at the resource group scope.",
+		Parameters: []FunctionParameter{
+			{Name: "subscription_id", Type: "string", Description: "The subscription ID."},
+			{Name: "resource_group_name", Type: "string", Description: "The name of the resource group."},
+			{Name: "resource_type", Type: "string", Description: "The resource type, like `Microsoft.Network/virtualNetworks/subnets`."},
+			resourceNamesParameter,
+		},
+		ReturnType:    "string",
+		Example:       `provider::azapi::resource_group_resource_id("` + exampleSubscriptionID + `", "myResourceGroup", "Microsoft.Network/virtualNetworks/subnets", ["myVNet", "mySubnet"])`,
+		ExampleResult: exampleResourceGroupID + "/providers/Microsoft.Network/virtualNetworks/myVNet/subnets/mySubnet",
+	},
+	"extension_resource_id": {
+		Name:        "extension_resource_id",
+		Summary:     "Builds the ID of an extension resource, which extends another resource like a role assignment or a lock.",
+		Description: "The base resource ID is the ID of the resource the extension resource is applied to.",
+		Parameters: []FunctionParameter{
+			{Name: "base_resource_id", Type: "string", Description: "The ID of the resource the extension resource is applied to."},
+			{Name: "resource_type", Type: "string", Description: "The resource type of the extension resource, like `Microsoft.Authorization/locks`."},
+			resourceNamesParameter,
+		},
+		ReturnType:    "string",
+		Example:       `provider::azapi::extension_resource_id("` + exampleResourceGroupID + `/providers/Microsoft.Network/virtualNetworks/myVNet", "Microsoft.Authorization/locks", ["myLock"])`,
+		ExampleResult: exampleResourceGroupID + "/providers/Microsoft.Network/virtualNetworks/myVNet/providers/Microsoft.Authorization/locks/myLock",
+	},
+}
+
+// FunctionNames returns the names of the azapi provider functions
+func FunctionNames() []string {
+	names := make([]string, 0, len(azapiFunctions))
+	for name := range azapiFunctions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ListFunctions returns the names and summaries of the azapi provider functions, sorted by name
+func ListFunctions() []Function {
+	functions := make([]Function, 0, len(azapiFunctions))
+	for _, name := range FunctionNames() {
+		f := azapiFunctions[name]
+		functions = append(functions, Function{Name: f.Name, Summary: f.Summary})
+	}
+	return functions
+}
+
+// GetFunction returns the documentation of an azapi provider function with its parameters and an example. name may be
+// prefixed with `provider::azapi::`.
+func GetFunction(name string) (*Function, error) {
+	f, ok := azapiFunctions[strings.TrimPrefix(strings.TrimSpace(name), functionCallPrefix)]
+	if !ok {
+		return nil, fmt.Errorf("unknown azapi function %q, must be one of %s", name, strings.Join(FunctionNames(), ", "))
+	}
+	return f, nil
+}
@@ -0,0 +1,63 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListFunctions(t *testing.T) {
+	functions := ListFunctions()
+	names := make([]string, 0, len(functions))
+	for _, f := range functions {
+		names = append(names, f.Name)
+		assert.NotEmpty(t, f.Summary)
+		assert.Empty(t, f.Parameters)
+	}
+	assert.Equal(t, []string{
+		"build_resource_id",
+		"extension_resource_id",
+		"management_group_resource_id",
+		"parse_resource_id",
+		"resource_group_resource_id",
+		"subscription_resource_id",
+		"tenant_resource_id",
+	}, names)
+}
+
+func TestGetFunction(t *testing.T) {
+	for _, name := range []string{"build_resource_id", "provider::azapi::build_resource_id", " build_resource_id "} {
+		f, err := GetFunction(name)
+		require.NoError(t, err)
+		assert.Equal(t, "build_resource_id", f.Name)
+		assert.Equal(t, []string{"parent_id", "resource_type", "name"}, []string{f.Parameters[0].Name, f.Parameters[1].Name, f.Parameters[2].Name})
+	}
+	_, err := GetFunction("unique_id")
+	assert.ErrorContains(t, err, "unknown azapi function")
+}
+
+func TestFunctionExamples(t *testing.T) {
+	for _, f := range azapiFunctions {
+		assert.Contains(t, f.Example, functionCallPrefix+f.Name+"(", f.Name)
+		assert.NotEmpty(t, f.ExampleResult, f.Name)
+	}
+	// the example results agree with the resource IDs built by this package
+	cases := []struct {
+		function   string
+		components ResourceIDComponents
+	}{
+		{function: "build_resource_id", components: ResourceIDComponents{ParentID: exampleResourceGroupID, ResourceType: "Microsoft.Network/virtualNetworks", Name: "myVNet"}},
+		{function: "subscription_resource_id", components: ResourceIDComponents{SubscriptionID: exampleSubscriptionID, ResourceType: ResourceGroupResourceType, Name: "myResourceGroup"}},
+		{function: "resource_group_resource_id", components: ResourceIDComponents{SubscriptionID: exampleSubscriptionID, ResourceGroup: "myResourceGroup", ResourceType: "Microsoft.Network/virtualNetworks/subnets", Name: "myVNet/mySubnet"}},
+		{function: "extension_resource_id", components: ResourceIDComponents{ParentID: exampleResourceGroupID + "/providers/Microsoft.Network/virtualNetworks/myVNet", ResourceType: "Microsoft.Authorization/locks", Name: "myLock"}},
+		{function: "management_group_resource_id", components: ResourceIDComponents{ParentID: "/providers/Microsoft.Management/managementGroups/myManagementGroup", ResourceType: "Microsoft.Authorization/policyDefinitions", Name: "myPolicy"}},
+	}
+	for _, c := range cases {
+		t.Run(c.function, func(t *testing.T) {
+			id, err := BuildResourceID(c.components)
+			require.NoError(t, err)
+			assert.Equal(t, id.ID, azapiFunctions[c.function].ExampleResult)
+		})
+	}
+}
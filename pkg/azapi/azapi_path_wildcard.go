@@ -0,0 +1,82 @@
+package azapi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Path wildcards: `*` matches any single segment, `**` matches any number of segments including none
+const (
+	wildcardSegment     = "*"
+	deepWildcardSegment = "**"
+)
+
+func hasWildcard(path string) bool {
+	return slices.ContainsFunc(strings.Split(path, "."), func(segment string) bool {
+		return segment == wildcardSegment || segment == deepWildcardSegment
+	})
+}
+
+// matchPath returns the nodes matching a path with wildcards keyed by their concrete path, like
+// `body.properties.**.subnetId`. children returns the named children of a node, leaves have none.
+func matchPath[T any](root T, path string, children func(T) map[string]T) (map[string]T, error) {
+	matches := map[string]T{}
+	matchSegments(root, "", strings.Split(path, "."), children, matches)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no property matches path '%s'", path)
+	}
+	return matches, nil
+}
+
+func matchSegments[T any](node T, prefix string, segments []string, children func(T) map[string]T, matches map[string]T) {
+	if len(segments) == 0 {
+		if prefix != "" {
+			matches[prefix] = node
+		}
+		return
+	}
+	segment, rest := segments[0], segments[1:]
+	switch segment {
+	case deepWildcardSegment:
+		matchSegments(node, prefix, rest, children, matches)
+		for name, child := range children(node) {
+			matchSegments(child, joinSegment(prefix, name), segments, children, matches)
+		}
+	case wildcardSegment:
+		for name, child := range children(node) {
+			matchSegments(child, joinSegment(prefix, name), rest, children, matches)
+		}
+	default:
+		if child, ok := children(node)[segment]; ok {
+			matchSegments(child, joinSegment(prefix, segment), rest, children, matches)
+		}
+	}
+}
+
+func joinSegment(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// typeChildren returns the attributes of an object type, collections are traversed to their element type like in
+// queryTypeFromType
+func typeChildren(t cty.Type) map[string]cty.Type {
+	for t.IsListType() || t.IsSetType() || t.IsMapType() {
+		t = t.ElementType()
+	}
+	if !t.IsObjectType() {
+		return nil
+	}
+	return t.AttributeTypes()
+}
+
+// descriptionChildren returns the properties of a description object, descriptions of leaves are strings
+func descriptionChildren(description any) map[string]any {
+	m, _ := description.(map[string]any)
+	return m
+}
@@ -0,0 +1,63 @@
+package azapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestMatchPath_Types(t *testing.T) {
+	root := cty.Object(map[string]cty.Type{
+		"body": cty.Object(map[string]cty.Type{
+			"properties": cty.Object(map[string]cty.Type{
+				"subnets": cty.List(cty.Object(map[string]cty.Type{
+					"subnetId": cty.String,
+					"delegation": cty.Object(map[string]cty.Type{
+						"subnetId": cty.String,
+					}),
+				})),
+				"name": cty.String,
+			}),
+		}),
+	})
+	cases := []struct {
+		path     string
+		expected []string
+	}{
+		{path: "body.properties.**.subnetId", expected: []string{"body.properties.subnets.delegation.subnetId", "body.properties.subnets.subnetId"}},
+		{path: "body.properties.*", expected: []string{"body.properties.name", "body.properties.subnets"}},
+		{path: "body.*.subnets.*", expected: []string{"body.properties.subnets.delegation", "body.properties.subnets.subnetId"}},
+		{path: "**.name", expected: []string{"body.properties.name"}},
+	}
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			matches, err := matchPath(root, c.path, typeChildren)
+			require.NoError(t, err)
+			paths := make([]string, 0, len(matches))
+			for p := range matches {
+				paths = append(paths, p)
+			}
+			assert.ElementsMatch(t, c.expected, paths)
+		})
+	}
+
+	_, err := matchPath(root, "body.**.missing", typeChildren)
+	assert.ErrorContains(t, err, "no property matches path 'body.**.missing'")
+}
+
+func TestGetResourceSchema_WildcardPath(t *testing.T) {
+	schema, err := GetResourceSchema("Microsoft.Compute/virtualMachines", "2024-11-01", "body.properties.osProfile.**.sourceVault.id")
+	require.NoError(t, err)
+	var types map[string]string
+	require.NoError(t, json.Unmarshal([]byte(schema), &types))
+	assert.Equal(t, map[string]string{"body.properties.osProfile.secrets.sourceVault.id": "String"}, types)
+}
+
+func TestGetResourceSchemaDescription_WildcardPath(t *testing.T) {
+	descriptions, err := GetResourceSchemaDescription("Microsoft.CognitiveServices/accounts", "2025-06-01", "body.properties.encryption.*.keyName")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"body.properties.encryption.keyVaultProperties.keyName": "Name of the Key from KeyVault"}, descriptions)
+}
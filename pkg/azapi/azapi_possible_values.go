@@ -0,0 +1,104 @@
+package azapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ms-henglu/go-azure-types/types"
+)
+
+// PropertyValues describes the values a body property accepts
+type PropertyValues struct {
+	Path     string `json:"path"`
+	Required bool   `json:"required"`
+	ReadOnly bool   `json:"read_only,omitempty"`
+	// PossibleValues are the literal values of an enum, empty when the property isn't an enum
+	PossibleValues []string `json:"possible_values"`
+	// OtherValuesAllowed is set when the enum is open, i.e. any string is accepted besides the possible values
+	OtherValuesAllowed bool `json:"other_values_allowed,omitempty"`
+}
+
+// GetPossibleValues returns the allowed literal values of the body property at path, like `body.properties.publicNetworkAccess`.
+// Array items are traversed transparently, and all variants of discriminated objects are searched.
+func GetPossibleValues(resourceType, apiVersion, path string) (*PropertyValues, error) {
+	segments := strings.Split(path, ".")
+	if len(segments) < 2 || segments[0] != "body" {
+		return nil, fmt.Errorf("invalid path %q, must be a body property like body.properties.publicNetworkAccess", path)
+	}
+	apiType, err := getAzApiType(resourceType, apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get azapi type for resource %s api-version %s: %w", resourceType, apiVersion, err)
+	}
+	var property types.ObjectProperty
+	current := apiType.Body.Type
+	for i, segment := range segments[1:] {
+		p, ok := bodyProperty(current, segment)
+		if !ok {
+			return nil, fmt.Errorf("property '%s' not found at path '%s'", segment, strings.Join(segments[:i+2], "."))
+		}
+		property = p
+		current = nil
+		if p.Type != nil {
+			current = p.Type.Type
+		}
+	}
+
+	values := &PropertyValues{
+		Path:           path,
+		Required:       property.IsRequired(),
+		ReadOnly:       property.IsReadOnly(),
+		PossibleValues: []string{},
+	}
+	switch t := elementType(current).(type) {
+	case *types.StringLiteralType:
+		values.PossibleValues = []string{t.Value}
+	case *types.UnionType:
+		values.PossibleValues = literalValues(t)
+		for _, element := range t.Elements {
+			if element != nil {
+				if _, ok := element.Type.(*types.StringType); ok {
+					values.OtherValuesAllowed = true
+				}
+			}
+		}
+	}
+	return values, nil
+}
+
+// bodyProperty returns the property name of an object type, array items are traversed to their element type
+func bodyProperty(t types.TypeBase, name string) (types.ObjectProperty, bool) {
+	switch v := elementType(t).(type) {
+	case *types.ObjectType:
+		p, ok := v.Properties[name]
+		return p, ok
+	case *types.DiscriminatedObjectType:
+		if p, ok := v.BaseProperties[name]; ok {
+			return p, true
+		}
+		kinds := make([]string, 0, len(v.Elements))
+		for kind := range v.Elements {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			if element := v.Elements[kind]; element != nil {
+				if p, ok := bodyProperty(element.Type, name); ok {
+					return p, true
+				}
+			}
+		}
+	}
+	return types.ObjectProperty{}, false
+}
+
+// elementType returns the innermost item type of nested arrays
+func elementType(t types.TypeBase) types.TypeBase {
+	for {
+		array, ok := t.(*types.ArrayType)
+		if !ok || array.ItemType == nil {
+			return t
+		}
+		t = array.ItemType.Type
+	}
+}
@@ -0,0 +1,63 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPossibleValues(t *testing.T) {
+	cases := []struct {
+		desc         string
+		resourceType string
+		apiVersion   string
+		path         string
+		expected     *PropertyValues
+	}{
+		{
+			desc:         "enum",
+			resourceType: "Microsoft.CognitiveServices/accounts",
+			apiVersion:   "2025-06-01",
+			path:         "body.properties.publicNetworkAccess",
+			expected:     &PropertyValues{Path: "body.properties.publicNetworkAccess", PossibleValues: []string{"Enabled", "Disabled"}, OtherValuesAllowed: true},
+		},
+		{
+			desc:         "required enum",
+			resourceType: "Microsoft.KeyVault/vaults",
+			apiVersion:   "2023-07-01",
+			path:         "body.properties.sku.name",
+			expected:     &PropertyValues{Path: "body.properties.sku.name", Required: true, PossibleValues: []string{"standard", "premium"}},
+		},
+		{
+			desc:         "enum in array items",
+			resourceType: "Microsoft.KeyVault/vaults",
+			apiVersion:   "2023-07-01",
+			path:         "body.properties.accessPolicies.permissions.keys",
+			expected: &PropertyValues{Path: "body.properties.accessPolicies.permissions.keys", PossibleValues: []string{
+				"all", "encrypt", "decrypt", "wrapKey", "unwrapKey", "sign", "verify", "get", "list", "create", "update", "import", "delete", "backup", "restore", "recover", "purge", "release", "rotate", "getrotationpolicy", "setrotationpolicy",
+			}, OtherValuesAllowed: true},
+		},
+		{
+			desc:         "not an enum",
+			resourceType: "Microsoft.KeyVault/vaults",
+			apiVersion:   "2023-07-01",
+			path:         "body.properties.tenantId",
+			expected:     &PropertyValues{Path: "body.properties.tenantId", Required: true, PossibleValues: []string{}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			values, err := GetPossibleValues(c.resourceType, c.apiVersion, c.path)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, values)
+		})
+	}
+}
+
+func TestGetPossibleValues_InvalidPath(t *testing.T) {
+	_, err := GetPossibleValues("Microsoft.KeyVault/vaults", "2023-07-01", "properties.sku")
+	assert.ErrorContains(t, err, `invalid path "properties.sku"`)
+	_, err = GetPossibleValues("Microsoft.KeyVault/vaults", "2023-07-01", "body.properties.unknown")
+	assert.ErrorContains(t, err, "property 'unknown' not found at path 'body.properties.unknown'")
+}
@@ -0,0 +1,179 @@
+package azapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ms-henglu/go-azure-types/types"
+)
+
+// propertyFlags are the property flags properties can be filtered by, keyed by their names in descriptions
+var propertyFlags = map[string]types.ObjectPropertyFlag{
+	"Required":           types.Required,
+	"ReadOnly":           types.ReadOnly,
+	"WriteOnly":          types.WriteOnly,
+	"Identifier":         types.Identifier,
+	"DeployTimeConstant": types.DeployTimeConstant,
+}
+
+// FlaggedProperty is a body property with its flags, Path is like `body.properties.provisioningState`
+type FlaggedProperty struct {
+	Path        string   `json:"path"`
+	Description string   `json:"description,omitempty"`
+	Flags       []string `json:"flags"`
+}
+
+// PropertyFlagNames returns the names of the flags properties can be filtered by
+func PropertyFlagNames() []string {
+	names := make([]string, 0, len(propertyFlags))
+	for name := range propertyFlags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetPropertiesWithFlag returns the body properties of resourceType@apiVersion carrying flag, like ReadOnly to list
+// what may go in response_export_values, sorted by path. Only properties at or under path are returned when path is
+// set. Array items are traversed transparently and the properties of all variants of discriminated objects are
+// included, like GetPossibleValues. Properties of read-only objects are read-only too, so they're only listed through
+// their object when flag is ReadOnly.
+func GetPropertiesWithFlag(resourceType, apiVersion, flag, path string) ([]FlaggedProperty, error) {
+	f, ok := lookupPropertyFlag(flag)
+	if !ok {
+		return nil, fmt.Errorf("invalid flag %q, must be one of %s", flag, strings.Join(PropertyFlagNames(), ", "))
+	}
+	apiType, err := getAzApiType(resourceType, apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get azapi type for resource %s api-version %s: %w", resourceType, apiVersion, err)
+	}
+	found := collectBodyProperties(apiType.Body.Type, func(_ string, property types.ObjectProperty) bool {
+		return hasPropertyFlag(property, f)
+	}, f == types.ReadOnly)
+	result := make([]FlaggedProperty, 0, len(found))
+	for _, property := range found {
+		if path == "" || property.Path == path || strings.HasPrefix(property.Path, path+".") {
+			result = append(result, property)
+		}
+	}
+	return result, nil
+}
+
+// lookupPropertyFlag matches flag case-insensitively
+func lookupPropertyFlag(flag string) (types.ObjectPropertyFlag, bool) {
+	for name, f := range propertyFlags {
+		if strings.EqualFold(name, flag) {
+			return f, true
+		}
+	}
+	return types.None, false
+}
+
+// collectBodyProperties returns the properties of bodyType matching match, sorted by path. Properties found in several
+// variants of discriminated objects are returned once. Matched properties aren't traversed when skipMatched is set.
+func collectBodyProperties(bodyType types.TypeBase, match func(name string, property types.ObjectProperty) bool, skipMatched bool) []FlaggedProperty {
+	found := make(map[string]FlaggedProperty)
+	walkBodyProperties(bodyType, func(path, name string, property types.ObjectProperty) bool {
+		matched := match(name, property)
+		if _, ok := found[path]; matched && !ok {
+			found[path] = flaggedProperty(property, path)
+		}
+		return !matched || !skipMatched
+	})
+	result := make([]FlaggedProperty, 0, len(found))
+	for _, property := range found {
+		result = append(result, property)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+// walkBodyProperties calls visit with the path of every property of bodyType, like `body.properties.tenantId`, the
+// properties of a property are visited when visit returns true. Array items and maps are traversed transparently and
+// all variants of discriminated objects are visited at the same paths. Recursive types are visited once per path.
+func walkBodyProperties(bodyType types.TypeBase, visit func(path, name string, property types.ObjectProperty) bool) {
+	w := &bodyPropertyWalker{visit: visit, visiting: make(map[types.TypeBase]bool)}
+	w.typ(bodyType, "body")
+}
+
+type bodyPropertyWalker struct {
+	visit    func(path, name string, property types.ObjectProperty) bool
+	visiting map[types.TypeBase]bool
+}
+
+func (w *bodyPropertyWalker) typ(t types.TypeBase, path string) {
+	t = elementType(t)
+	if t == nil || w.visiting[t] {
+		return
+	}
+	w.visiting[t] = true
+	defer delete(w.visiting, t)
+	switch v := t.(type) {
+	case *types.ObjectType:
+		w.properties(v.Properties, path)
+		if len(v.Properties) == 0 && v.AdditionalProperties != nil {
+			w.typ(v.AdditionalProperties.Type, path)
+		}
+	case *types.DiscriminatedObjectType:
+		w.properties(v.BaseProperties, path)
+		for _, variant := range variantNames(v) {
+			w.typ(v.Elements[variant].Type, path)
+		}
+	}
+}
+
+func (w *bodyPropertyWalker) properties(properties map[string]types.ObjectProperty, path string) {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property := properties[name]
+		propertyPath := path + "." + name
+		if w.visit(propertyPath, name, property) && property.Type != nil {
+			w.typ(property.Type.Type, propertyPath)
+		}
+	}
+}
+
+func hasPropertyFlag(property types.ObjectProperty, flag types.ObjectPropertyFlag) bool {
+	for _, f := range property.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func flaggedProperty(property types.ObjectProperty, path string) FlaggedProperty {
+	result := FlaggedProperty{Path: path, Flags: []string{}}
+	if property.Description != nil {
+		result.Description = *property.Description
+	}
+	for _, name := range descriptionFlags {
+		if f, ok := propertyFlags[name]; ok && hasPropertyFlag(property, f) {
+			result.Flags = append(result.Flags, name)
+		}
+	}
+	return result
+}
+
+// FlaggedPropertiesMarkdown renders the properties returned by GetPropertiesWithFlag as a Markdown property table
+func FlaggedPropertiesMarkdown(title string, properties []FlaggedProperty) string {
+	descriptions := make(map[string]any, len(properties))
+	for _, p := range properties {
+		description := p.Description
+		for _, flag := range p.Flags {
+			description += " (" + flag + ")"
+		}
+		descriptions[p.Path] = description
+	}
+	sb := &strings.Builder{}
+	sb.WriteString(fmt.Sprintf("# %s\n", title))
+	writePropertyTable(sb, descriptions)
+	return sb.String()
+}
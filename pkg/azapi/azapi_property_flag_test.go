@@ -0,0 +1,92 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPropertiesWithFlag(t *testing.T) {
+	cases := []struct {
+		desc     string
+		flag     string
+		path     string
+		expected []string
+	}{
+		{
+			desc:     "read-only",
+			flag:     "ReadOnly",
+			expected: []string{"body.apiVersion", "body.id", "body.properties.hsmPoolResourceId", "body.properties.privateEndpointConnections", "body.systemData", "body.type"},
+		},
+		{
+			desc:     "case-insensitive flag",
+			flag:     "writeonly",
+			expected: []string{"body.properties.createMode"},
+		},
+		{
+			desc:     "deploy-time constant",
+			flag:     "DeployTimeConstant",
+			expected: []string{"body.apiVersion", "body.id", "body.name", "body.type"},
+		},
+		{
+			desc:     "under path",
+			flag:     "ReadOnly",
+			path:     "body.properties",
+			expected: []string{"body.properties.hsmPoolResourceId", "body.properties.privateEndpointConnections"},
+		},
+		{
+			desc:     "array items",
+			flag:     "Required",
+			path:     "body.properties.accessPolicies",
+			expected: []string{"body.properties.accessPolicies.objectId", "body.properties.accessPolicies.permissions", "body.properties.accessPolicies.tenantId"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			properties, err := GetPropertiesWithFlag("Microsoft.KeyVault/vaults", "2023-07-01", c.flag, c.path)
+			require.NoError(t, err)
+			paths := make([]string, 0, len(properties))
+			for _, p := range properties {
+				paths = append(paths, p.Path)
+			}
+			assert.Equal(t, c.expected, paths)
+		})
+	}
+}
+
+func TestGetPropertiesWithFlag_Flags(t *testing.T) {
+	properties, err := GetPropertiesWithFlag("Microsoft.KeyVault/vaults", "2023-07-01", "ReadOnly", "body.id")
+	require.NoError(t, err)
+	require.Len(t, properties, 1)
+	assert.Equal(t, []string{"ReadOnly", "DeployTimeConstant"}, properties[0].Flags)
+	assert.NotEmpty(t, properties[0].Description)
+}
+
+func TestGetPropertiesWithFlag_DiscriminatedObject(t *testing.T) {
+	properties, err := GetPropertiesWithFlag("Microsoft.AVS/privateClouds/workloadNetworks/dhcpConfigurations", "2024-09-01", "ReadOnly", "body.properties")
+	require.NoError(t, err)
+	paths := make([]string, 0, len(properties))
+	for _, p := range properties {
+		paths = append(paths, p.Path)
+	}
+	// provisioningState and segments are read-only base properties of the discriminated object
+	assert.Contains(t, paths, "body.properties.provisioningState")
+	assert.Contains(t, paths, "body.properties.segments")
+}
+
+func TestGetPropertiesWithFlag_InvalidFlag(t *testing.T) {
+	_, err := GetPropertiesWithFlag("Microsoft.KeyVault/vaults", "2023-07-01", "Secret", "")
+	assert.ErrorContains(t, err, "invalid flag")
+}
+
+func TestFlaggedPropertiesMarkdown(t *testing.T) {
+	markdown := FlaggedPropertiesMarkdown("Microsoft.KeyVault/vaults@2023-07-01 ReadOnly properties", []FlaggedProperty{
+		{Path: "body.id", Description: "The resource ID", Flags: []string{"ReadOnly", "DeployTimeConstant"}},
+	})
+	expected := "# Microsoft.KeyVault/vaults@2023-07-01 ReadOnly properties\n\n" +
+		"| Property | Description | Flags | Possible values |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| `body.id` | The resource ID | ReadOnly, DeployTimeConstant |  |\n"
+	assert.Equal(t, expected, markdown)
+}
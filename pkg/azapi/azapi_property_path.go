@@ -0,0 +1,41 @@
+package azapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ms-henglu/go-azure-types/types"
+)
+
+// FindPropertyPaths returns every body property of resourceType@apiVersion named name, case-insensitively, with its
+// path like `body.properties.publicNetworkAccess`, description and flags, sorted by path. Array items and maps are
+// traversed transparently and all variants of discriminated objects are searched, so the paths can be used with
+// GetPossibleValues. An error with the closest property name is returned when no property matches.
+func FindPropertyPaths(resourceType, apiVersion, name string) ([]FlaggedProperty, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("property name is required")
+	}
+	apiType, err := getAzApiType(resourceType, apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get azapi type for resource %s api-version %s: %w", resourceType, apiVersion, err)
+	}
+	found := collectBodyProperties(apiType.Body.Type, func(n string, _ types.ObjectProperty) bool {
+		return strings.EqualFold(n, name)
+	}, false)
+	if len(found) > 0 {
+		return found, nil
+	}
+	message := fmt.Sprintf("property %q not found in %s@%s", name, resourceType, apiVersion)
+	all := make(map[string]types.ObjectProperty)
+	for _, property := range collectBodyProperties(apiType.Body.Type, func(string, types.ObjectProperty) bool {
+		return true
+	}, false) {
+		all[property.Path[strings.LastIndex(property.Path, ".")+1:]] = types.ObjectProperty{}
+	}
+	if suggestion := closestProperty(name, all); suggestion != "" {
+		message += fmt.Sprintf(", did you mean %q?", suggestion)
+	}
+	return nil, errors.New(message)
+}
@@ -0,0 +1,60 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPropertyPaths(t *testing.T) {
+	cases := []struct {
+		desc         string
+		resourceType string
+		apiVersion   string
+		name         string
+		expected     []string
+	}{
+		{
+			desc:         "single path",
+			resourceType: "Microsoft.KeyVault/vaults",
+			apiVersion:   "2023-07-01",
+			name:         "publicNetworkAccess",
+			expected:     []string{"body.properties.publicNetworkAccess"},
+		},
+		{
+			desc:         "case-insensitive, several paths through arrays",
+			resourceType: "Microsoft.KeyVault/vaults",
+			apiVersion:   "2023-07-01",
+			name:         "TENANTID",
+			expected:     []string{"body.properties.accessPolicies.tenantId", "body.properties.tenantId"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			properties, err := FindPropertyPaths(c.resourceType, c.apiVersion, c.name)
+			require.NoError(t, err)
+			paths := make([]string, 0, len(properties))
+			for _, p := range properties {
+				paths = append(paths, p.Path)
+			}
+			assert.Equal(t, c.expected, paths)
+		})
+	}
+}
+
+func TestFindPropertyPaths_PossibleValues(t *testing.T) {
+	properties, err := FindPropertyPaths("Microsoft.KeyVault/vaults", "2023-07-01", "publicNetworkAccess")
+	require.NoError(t, err)
+	require.Len(t, properties, 1)
+	_, err = GetPossibleValues("Microsoft.KeyVault/vaults", "2023-07-01", properties[0].Path)
+	assert.NoError(t, err)
+}
+
+func TestFindPropertyPaths_NotFound(t *testing.T) {
+	_, err := FindPropertyPaths("Microsoft.KeyVault/vaults", "2023-07-01", "publicNetworkAcess")
+	assert.ErrorContains(t, err, `did you mean "publicNetworkAccess"?`)
+
+	_, err = FindPropertyPaths("Microsoft.KeyVault/vaults", "2023-07-01", " ")
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package azapi
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/lonegunmanb/terraform-azapi-schema/v2/generated/data"
+	"github.com/lonegunmanb/terraform-azapi-schema/v2/generated/ephemeral"
+	"github.com/lonegunmanb/terraform-azapi-schema/v2/generated/resource"
+)
+
+// ProviderVersion is the azapi provider version the generated schemas were generated from
+const ProviderVersion = "2.5.0"
+
+// DisabledEnv set to `true` doesn't register the AzAPI tools, the AzAPI schemas and types are never loaded then
+const DisabledEnv = "AZAPI_DISABLED"
+
+// providerSchema unmarshals the generated azapi provider schemas on first use rather than at startup, as they pin a
+// large amount of memory. The generated package isn't imported since its init unmarshals every schema, the block
+// types are listed here instead.
+var providerSchema = sync.OnceValue(func() *tfjson.ProviderSchema {
+	return &tfjson.ProviderSchema{
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"azapi_data_plane_resource": resource.AzapiDataPlaneResourceSchema(),
+			"azapi_resource":            resource.AzapiResourceSchema(),
+			"azapi_resource_action":     resource.AzapiResourceActionSchema(),
+			"azapi_update_resource":     resource.AzapiUpdateResourceSchema(),
+		},
+		DataSourceSchemas: map[string]*tfjson.Schema{
+			"azapi_client_config":   data.AzapiClientConfigSchema(),
+			"azapi_resource":        data.AzapiResourceSchema(),
+			"azapi_resource_action": data.AzapiResourceActionSchema(),
+			"azapi_resource_id":     data.AzapiResourceIdSchema(),
+			"azapi_resource_list":   data.AzapiResourceListSchema(),
+		},
+		EphemeralResourceSchemas: map[string]*tfjson.Schema{
+			"azapi_resource_action": ephemeral.AzapiResourceActionSchema(),
+		},
+	}
+})
+
+// ProviderSchema returns the schema of the azapi provider of version ProviderVersion, loaded on first use. The schema
+// is shared and must not be modified.
+func ProviderSchema() *tfjson.ProviderSchema {
+	return providerSchema()
+}
+
+// Disabled reports whether the AzAPI tools are disabled through DisabledEnv
+func Disabled() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv(DisabledEnv)), "true")
+}
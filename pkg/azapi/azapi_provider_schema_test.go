@@ -0,0 +1,42 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderSchema_ContainsAllBlockTypes(t *testing.T) {
+	schema := ProviderSchema()
+
+	require.NotNil(t, schema)
+	assert.Contains(t, schema.ResourceSchemas, "azapi_resource")
+	assert.Contains(t, schema.ResourceSchemas, "azapi_update_resource")
+	assert.Contains(t, schema.DataSourceSchemas, "azapi_resource_list")
+	assert.Contains(t, schema.EphemeralResourceSchemas, "azapi_resource_action")
+	assert.Contains(t, schema.ResourceSchemas["azapi_resource"].Block.Attributes, "body")
+}
+
+func TestProviderSchema_LoadedOnce(t *testing.T) {
+	assert.Same(t, ProviderSchema(), ProviderSchema())
+}
+
+func TestDisabled(t *testing.T) {
+	cases := []struct {
+		value    string
+		disabled bool
+	}{
+		{value: "true", disabled: true},
+		{value: " TRUE ", disabled: true},
+		{value: "false", disabled: false},
+		{value: "1", disabled: false},
+		{value: "", disabled: false},
+	}
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			t.Setenv(DisabledEnv, c.value)
+			assert.Equal(t, c.disabled, Disabled())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package azapi
+
+import (
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/ms-henglu/go-azure-types/types"
+)
+
+// swaggerBodyType returns the swagger body type of resourceType@apiVersion, trimmed to the properties that must be
+// set when requiredOnly is true, see requiredObjectType
+func swaggerBodyType(resourceType, apiVersion string, requiredOnly bool) (*types.ObjectType, error) {
+	apiType, err := getAzApiType(resourceType, apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get azapi type for resource %s api-version %s: %w", resourceType, apiVersion, err)
+	}
+	bodyType, ok := apiType.Body.Type.(*types.ObjectType)
+	if !ok {
+		return nil, fmt.Errorf("resource body type is not an object type")
+	}
+	if requiredOnly {
+		bodyType = requiredObjectType(bodyType)
+	}
+	return bodyType, nil
+}
+
+// requiredObjectType returns a copy of t that only keeps the writable Required and DeployTimeConstant properties,
+// nested objects, discriminated objects and arrays of objects are trimmed the same way
+func requiredObjectType(t *types.ObjectType) *types.ObjectType {
+	trimmed := *t
+	trimmed.Properties = make(map[string]types.ObjectProperty)
+	for name, property := range t.Properties {
+		if property.IsReadOnly() || !(property.IsRequired() || property.IsDeployTimeConstant()) {
+			continue
+		}
+		if property.Type != nil {
+			ref := *property.Type
+			ref.Type = requiredType(ref.Type)
+			property.Type = &ref
+		}
+		trimmed.Properties[name] = property
+	}
+	return &trimmed
+}
+
+func requiredType(t types.TypeBase) types.TypeBase {
+	switch typ := t.(type) {
+	case *types.ObjectType:
+		return requiredObjectType(typ)
+	case *types.ArrayType:
+		if typ.ItemType == nil {
+			return typ
+		}
+		trimmed, ref := *typ, *typ.ItemType
+		ref.Type = requiredType(ref.Type)
+		trimmed.ItemType = &ref
+		return &trimmed
+	case *types.DiscriminatedObjectType:
+		trimmed := *typ
+		trimmed.BaseProperties = requiredObjectType(&types.ObjectType{Properties: typ.BaseProperties}).Properties
+		trimmed.Elements = make(map[string]*types.TypeReference, len(typ.Elements))
+		for variant, element := range typ.Elements {
+			if element != nil {
+				ref := *element
+				ref.Type = requiredType(ref.Type)
+				element = &ref
+			}
+			trimmed.Elements[variant] = element
+		}
+		return &trimmed
+	default:
+		return t
+	}
+}
+
+// requiredSchemaBlock returns a copy of block that only keeps the required attributes and the nested blocks with
+// at least one required item
+func requiredSchemaBlock(block *tfjson.SchemaBlock) *tfjson.SchemaBlock {
+	trimmed := *block
+	trimmed.Attributes = make(map[string]*tfjson.SchemaAttribute)
+	for name, attr := range block.Attributes {
+		if attr.Required {
+			trimmed.Attributes[name] = attr
+		}
+	}
+	trimmed.NestedBlocks = make(map[string]*tfjson.SchemaBlockType)
+	for name, nestedBlock := range block.NestedBlocks {
+		if nestedBlock.MinItems == 0 || nestedBlock.Block == nil {
+			continue
+		}
+		nested := *nestedBlock
+		nested.Block = requiredSchemaBlock(nestedBlock.Block)
+		trimmed.NestedBlocks[name] = &nested
+	}
+	return &trimmed
+}
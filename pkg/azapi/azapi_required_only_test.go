@@ -0,0 +1,43 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlockSchema_RequiredOnly(t *testing.T) {
+	schema, err := GetBlockSchema(DefaultBlockType, "Microsoft.KeyVault/vaults", "2023-07-01", "", true)
+	require.NoError(t, err)
+	assert.Equal(t, `Object(map[string]Type{"body":Object(map[string]Type{"properties":Object(map[string]Type{"sku":Object(map[string]Type{"family":String, "name":String}), "tenantId":String})}), "location":String, "name":String, "type":String})`, schema)
+
+	schema, err = GetBlockSchema(DefaultBlockType, "Microsoft.KeyVault/vaults", "2023-07-01", "body.properties.sku", true)
+	require.NoError(t, err)
+	assert.Equal(t, `Object(map[string]Type{"family":String, "name":String})`, schema)
+
+	_, err = GetBlockSchema(DefaultBlockType, "Microsoft.KeyVault/vaults", "2023-07-01", "body.properties.enableSoftDelete", true)
+	assert.Error(t, err)
+}
+
+func TestGetBlockSchemaDescription_RequiredOnly(t *testing.T) {
+	description, err := GetBlockSchemaDescription(DefaultBlockType, "Microsoft.KeyVault/vaults", "2023-07-01", "body.properties", true)
+	require.NoError(t, err)
+	properties, ok := description.(map[string]any)
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"sku", "tenantId"}, keys(properties))
+
+	description, err = GetBlockSchemaDescription("data.azapi_resource_list", "", "", "", true)
+	require.NoError(t, err)
+	arguments, ok := description.(map[string]any)
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"parent_id", "type"}, keys(arguments))
+}
+
+func keys(m map[string]any) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
@@ -0,0 +1,163 @@
+package azapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resource types of the scopes that aren't under a resource provider in resource IDs
+const (
+	TenantResourceType        = "Microsoft.Resources/tenants"
+	SubscriptionResourceType  = "Microsoft.Resources/subscriptions"
+	ResourceGroupResourceType = "Microsoft.Resources/resourceGroups"
+)
+
+// ResourceID is a parsed Azure resource ID
+type ResourceID struct {
+	ID              string `json:"id"`
+	SubscriptionID  string `json:"subscription_id,omitempty"`
+	ResourceGroup   string `json:"resource_group,omitempty"`
+	ManagementGroup string `json:"management_group,omitempty"`
+	// ResourceType is the full type of the resource, like `Microsoft.Network/virtualNetworks/subnets`
+	ResourceType string `json:"resource_type"`
+	// Name is the name of the resource, Names are the names of the resource and its parents from the top level resource,
+	// like `["vnet1", "subnet1"]`
+	Name  string   `json:"name"`
+	Names []string `json:"names,omitempty"`
+	// ParentID is the ID of the parent resource or scope, the `parent_id` of an azapi_resource
+	ParentID string `json:"parent_id"`
+	// KnownResourceType is set when the resource type is known by the AzAPI types
+	KnownResourceType bool     `json:"known_resource_type"`
+	Warnings          []string `json:"warnings,omitempty"`
+}
+
+// ResourceIDComponents are the components a resource ID is built from. The scope is ParentID when set, or the
+// resource group or subscription otherwise. Name is the name of the resource, or the `/` separated names of the
+// resource and its parents like ARM templates use, e.g. `vnet1/subnet1` for a subnet.
+type ResourceIDComponents struct {
+	SubscriptionID string
+	ResourceGroup  string
+	ParentID       string
+	ResourceType   string
+	Name           string
+}
+
+// ParseResourceID parses an Azure resource ID like
+// `/subscriptions/{id}/resourceGroups/{rg}/providers/Microsoft.Network/virtualNetworks/{vnet}/subnets/{subnet}`.
+// Subscriptions, resource groups, management groups and extension resources are supported. The resource type is
+// validated against the AzAPI types, unknown resource types are reported as warnings.
+func ParseResourceID(id string) (*ResourceID, error) {
+	trimmed := strings.Trim(strings.TrimSpace(id), "/")
+	result := &ResourceID{ID: "/" + trimmed, ResourceType: TenantResourceType, ParentID: "/"}
+	if trimmed == "" {
+		result.ID = "/"
+		result.KnownResourceType = true
+		return result, nil
+	}
+	segments := strings.Split(trimmed, "/")
+	if len(segments)%2 != 0 {
+		return nil, fmt.Errorf("invalid resource ID %q, segments must be key/value pairs", id)
+	}
+	scope := ""
+	for i := 0; i < len(segments); i += 2 {
+		key, value := segments[i], segments[i+1]
+		if value == "" {
+			return nil, fmt.Errorf("invalid resource ID %q, %s has an empty value", id, key)
+		}
+		switch {
+		case strings.EqualFold(key, "subscriptions") && scope == "":
+			result.SubscriptionID = value
+			result.ResourceType, result.Name, result.Names, result.ParentID = SubscriptionResourceType, value, nil, "/"
+		case strings.EqualFold(key, "resourceGroups") && result.SubscriptionID != "" && result.ResourceGroup == "" && result.ResourceType == SubscriptionResourceType:
+			result.ResourceGroup = value
+			result.ResourceType, result.Name, result.Names, result.ParentID = ResourceGroupResourceType, value, nil, scope
+		case strings.EqualFold(key, "providers"):
+			// a provider resource, or an extension resource of the resource before
+			if i+3 >= len(segments) {
+				return nil, fmt.Errorf("invalid resource ID %q, %s must be followed by a resource type and a name", id, value)
+			}
+			parentID := "/"
+			if scope != "" {
+				parentID = scope
+			}
+			result.ResourceType = value + "/" + segments[i+2]
+			result.Name, result.Names, result.ParentID = segments[i+3], []string{segments[i+3]}, parentID
+			if strings.EqualFold(result.ResourceType, "Microsoft.Management/managementGroups") && scope == "" {
+				result.ManagementGroup = result.Name
+			}
+			scope += "/" + strings.Join(segments[i:i+4], "/")
+			i += 2
+			continue
+		case result.Names != nil:
+			// a child resource of the provider resource before
+			result.ResourceType += "/" + key
+			result.Name, result.Names, result.ParentID = value, append(result.Names, value), scope
+		default:
+			return nil, fmt.Errorf("invalid resource ID %q, unexpected segment %q", id, key)
+		}
+		scope += "/" + key + "/" + value
+	}
+	if result.ResourceType == SubscriptionResourceType || result.ResourceType == ResourceGroupResourceType {
+		result.KnownResourceType = true
+	} else if canonical := canonicalResourceType(result.ResourceType); len(azureSchemaLoader.ListApiVersions(canonical)) > 0 {
+		result.ResourceType, result.KnownResourceType = canonical, true
+	} else {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("resource type %s is not known by the AzAPI types", result.ResourceType))
+	}
+	return result, nil
+}
+
+// BuildResourceID builds the ID of a resource from its components and parses it, see ParseResourceID
+func BuildResourceID(c ResourceIDComponents) (*ResourceID, error) {
+	if c.ResourceType == "" || c.Name == "" {
+		return nil, fmt.Errorf("resource type and name are required")
+	}
+	scope := c.ParentID
+	if scope == "" {
+		switch {
+		case c.SubscriptionID != "" && c.ResourceGroup != "":
+			scope = fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", c.SubscriptionID, c.ResourceGroup)
+		case c.SubscriptionID != "":
+			scope = fmt.Sprintf("/subscriptions/%s", c.SubscriptionID)
+		case c.ResourceGroup != "":
+			return nil, fmt.Errorf("subscription ID is required with resource group %s", c.ResourceGroup)
+		}
+	}
+	scope = strings.TrimSuffix(scope, "/")
+	names := strings.Split(strings.Trim(c.Name, "/"), "/")
+	typeSegments := strings.Split(strings.Trim(c.ResourceType, "/"), "/")
+	if len(typeSegments) < 2 {
+		return nil, fmt.Errorf("invalid resource type %q, must be like Microsoft.Network/virtualNetworks", c.ResourceType)
+	}
+
+	var id string
+	switch {
+	case strings.EqualFold(c.ResourceType, SubscriptionResourceType):
+		id = "/subscriptions/" + c.Name
+	case strings.EqualFold(c.ResourceType, ResourceGroupResourceType):
+		parent, err := ParseResourceID(scope)
+		if err != nil || parent.ResourceType != SubscriptionResourceType {
+			return nil, fmt.Errorf("the parent of a resource group must be a subscription, got %q", scope)
+		}
+		id = scope + "/resourceGroups/" + c.Name
+	case len(names) == len(typeSegments)-1:
+		id = scope + "/providers/" + typeSegments[0]
+		for i, name := range names {
+			id += "/" + typeSegments[i+1] + "/" + name
+		}
+	case len(names) == 1 && c.ParentID != "":
+		// the parent ID is the ID of the parent resource of a child resource
+		parent, err := ParseResourceID(scope)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent ID: %w", err)
+		}
+		parentType := strings.Join(typeSegments[:len(typeSegments)-1], "/")
+		if !strings.EqualFold(parent.ResourceType, parentType) {
+			return nil, fmt.Errorf("the parent of a %s must be a %s, got a %s", c.ResourceType, parentType, parent.ResourceType)
+		}
+		id = scope + "/" + typeSegments[len(typeSegments)-1] + "/" + c.Name
+	default:
+		return nil, fmt.Errorf("resource type %s needs %d names separated by '/', got %q", c.ResourceType, len(typeSegments)-1, c.Name)
+	}
+	return ParseResourceID(id)
+}
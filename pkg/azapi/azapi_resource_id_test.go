@@ -0,0 +1,204 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSubscriptionID = "00000000-0000-0000-0000-000000000000"
+
+func TestParseResourceID(t *testing.T) {
+	rg := "/subscriptions/" + testSubscriptionID + "/resourceGroups/rg1"
+	cases := []struct {
+		name     string
+		id       string
+		expected ResourceID
+	}{
+		{
+			name: "tenant",
+			id:   "/",
+			expected: ResourceID{
+				ID:                "/",
+				ResourceType:      TenantResourceType,
+				ParentID:          "/",
+				KnownResourceType: true,
+			},
+		},
+		{
+			name: "subscription",
+			id:   "/subscriptions/" + testSubscriptionID,
+			expected: ResourceID{
+				ID:                "/subscriptions/" + testSubscriptionID,
+				SubscriptionID:    testSubscriptionID,
+				ResourceType:      SubscriptionResourceType,
+				Name:              testSubscriptionID,
+				ParentID:          "/",
+				KnownResourceType: true,
+			},
+		},
+		{
+			name: "resource group",
+			id:   rg,
+			expected: ResourceID{
+				ID:                rg,
+				SubscriptionID:    testSubscriptionID,
+				ResourceGroup:     "rg1",
+				ResourceType:      ResourceGroupResourceType,
+				Name:              "rg1",
+				ParentID:          "/subscriptions/" + testSubscriptionID,
+				KnownResourceType: true,
+			},
+		},
+		{
+			name: "child resource with lower-case type",
+			id:   rg + "/providers/microsoft.network/virtualnetworks/vnet1/subnets/subnet1/",
+			expected: ResourceID{
+				ID:                rg + "/providers/microsoft.network/virtualnetworks/vnet1/subnets/subnet1",
+				SubscriptionID:    testSubscriptionID,
+				ResourceGroup:     "rg1",
+				ResourceType:      "Microsoft.Network/virtualNetworks/subnets",
+				Name:              "subnet1",
+				Names:             []string{"vnet1", "subnet1"},
+				ParentID:          rg + "/providers/microsoft.network/virtualnetworks/vnet1",
+				KnownResourceType: true,
+			},
+		},
+		{
+			name: "extension resource",
+			id:   rg + "/providers/Microsoft.KeyVault/vaults/kv1/providers/Microsoft.Authorization/roleAssignments/ra1",
+			expected: ResourceID{
+				ID:                rg + "/providers/Microsoft.KeyVault/vaults/kv1/providers/Microsoft.Authorization/roleAssignments/ra1",
+				SubscriptionID:    testSubscriptionID,
+				ResourceGroup:     "rg1",
+				ResourceType:      "Microsoft.Authorization/roleAssignments",
+				Name:              "ra1",
+				Names:             []string{"ra1"},
+				ParentID:          rg + "/providers/Microsoft.KeyVault/vaults/kv1",
+				KnownResourceType: true,
+			},
+		},
+		{
+			name: "management group",
+			id:   "/providers/Microsoft.Management/managementGroups/mg1",
+			expected: ResourceID{
+				ID:                "/providers/Microsoft.Management/managementGroups/mg1",
+				ManagementGroup:   "mg1",
+				ResourceType:      "Microsoft.Management/managementGroups",
+				Name:              "mg1",
+				Names:             []string{"mg1"},
+				ParentID:          "/",
+				KnownResourceType: true,
+			},
+		},
+		{
+			name: "unknown resource type",
+			id:   rg + "/providers/Microsoft.DoesNotExist/things/thing1",
+			expected: ResourceID{
+				ID:             rg + "/providers/Microsoft.DoesNotExist/things/thing1",
+				SubscriptionID: testSubscriptionID,
+				ResourceGroup:  "rg1",
+				ResourceType:   "Microsoft.DoesNotExist/things",
+				Name:           "thing1",
+				Names:          []string{"thing1"},
+				ParentID:       rg,
+				Warnings:       []string{"resource type Microsoft.DoesNotExist/things is not known by the AzAPI types"},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			id, err := ParseResourceID(c.id)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, *id)
+		})
+	}
+}
+
+func TestParseResourceID_Invalid(t *testing.T) {
+	for _, id := range []string{
+		"/subscriptions",
+		"/subscriptions/" + testSubscriptionID + "/resourceGroups/rg1/providers/Microsoft.Network",
+		"/subscriptions/" + testSubscriptionID + "/foo/bar",
+		"/subscriptions//resourceGroups/rg1",
+	} {
+		_, err := ParseResourceID(id)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestBuildResourceID(t *testing.T) {
+	rg := "/subscriptions/" + testSubscriptionID + "/resourceGroups/rg1"
+	vnet := rg + "/providers/Microsoft.Network/virtualNetworks/vnet1"
+	cases := []struct {
+		name       string
+		components ResourceIDComponents
+		expected   string
+	}{
+		{
+			name:       "resource group",
+			components: ResourceIDComponents{SubscriptionID: testSubscriptionID, ResourceType: ResourceGroupResourceType, Name: "rg1"},
+			expected:   rg,
+		},
+		{
+			name:       "top level resource",
+			components: ResourceIDComponents{SubscriptionID: testSubscriptionID, ResourceGroup: "rg1", ResourceType: "Microsoft.Network/virtualNetworks", Name: "vnet1"},
+			expected:   vnet,
+		},
+		{
+			name:       "child resource with ARM template name",
+			components: ResourceIDComponents{SubscriptionID: testSubscriptionID, ResourceGroup: "rg1", ResourceType: "Microsoft.Network/virtualNetworks/subnets", Name: "vnet1/subnet1"},
+			expected:   vnet + "/subnets/subnet1",
+		},
+		{
+			name:       "child resource of parent ID",
+			components: ResourceIDComponents{ParentID: vnet, ResourceType: "Microsoft.Network/virtualNetworks/subnets", Name: "subnet1"},
+			expected:   vnet + "/subnets/subnet1",
+		},
+		{
+			name:       "extension resource",
+			components: ResourceIDComponents{ParentID: vnet, ResourceType: "Microsoft.Authorization/roleAssignments", Name: "ra1"},
+			expected:   vnet + "/providers/Microsoft.Authorization/roleAssignments/ra1",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			id, err := BuildResourceID(c.components)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, id.ID)
+			assert.True(t, id.KnownResourceType)
+		})
+	}
+}
+
+func TestBuildResourceID_Invalid(t *testing.T) {
+	rg := "/subscriptions/" + testSubscriptionID + "/resourceGroups/rg1"
+	cases := []struct {
+		name       string
+		components ResourceIDComponents
+		err        string
+	}{
+		{
+			name:       "wrong parent type",
+			components: ResourceIDComponents{ParentID: rg + "/providers/Microsoft.KeyVault/vaults/kv1", ResourceType: "Microsoft.Network/virtualNetworks/subnets", Name: "subnet1"},
+			err:        "the parent of a Microsoft.Network/virtualNetworks/subnets must be a Microsoft.Network/virtualNetworks, got a Microsoft.KeyVault/vaults",
+		},
+		{
+			name:       "missing parent names",
+			components: ResourceIDComponents{SubscriptionID: testSubscriptionID, ResourceGroup: "rg1", ResourceType: "Microsoft.Network/virtualNetworks/subnets", Name: "subnet1"},
+			err:        "resource type Microsoft.Network/virtualNetworks/subnets needs 2 names separated by '/', got \"subnet1\"",
+		},
+		{
+			name:       "resource group without subscription",
+			components: ResourceIDComponents{ResourceGroup: "rg1", ResourceType: "Microsoft.Network/virtualNetworks", Name: "vnet1"},
+			err:        "subscription ID is required with resource group rg1",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := BuildResourceID(c.components)
+			assert.EqualError(t, err, c.err)
+		})
+	}
+}
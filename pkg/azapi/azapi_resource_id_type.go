@@ -0,0 +1,54 @@
+package azapi
+
+import (
+	"fmt"
+)
+
+// ResourceIDType is the resource type of an existing resource inferred from its ID, with the api-versions to manage it
+// with an azapi_resource
+type ResourceIDType struct {
+	ID           string `json:"id"`
+	ResourceType string `json:"resource_type"`
+	Name         string `json:"name"`
+	ParentID     string `json:"parent_id"`
+	// Type is the `type` of an azapi_resource managing the resource, with the latest stable api-version, or the newest
+	// api-version when there is no stable one
+	Type string `json:"type"`
+	// ImportID is the ID to import the resource into an azapi_resource with
+	ImportID     string       `json:"import_id"`
+	LatestStable string       `json:"latest_stable,omitempty"`
+	Newest       string       `json:"newest"`
+	ApiVersions  []ApiVersion `json:"api_versions"`
+}
+
+// InferResourceType infers the resource type of an Azure resource ID, including nested types like
+// `Microsoft.Network/virtualNetworks/subnets` and extension resources, and lists its api-versions from the newest to
+// the oldest. An error is returned when the resource type isn't known by the AzAPI types.
+func InferResourceType(id string) (*ResourceIDType, error) {
+	parsed, err := ParseResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !parsed.KnownResourceType {
+		return nil, fmt.Errorf("resource type %s of %s is not known by the AzAPI types", parsed.ResourceType, parsed.ID)
+	}
+	versions, err := ListApiVersions(parsed.ResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("resource type %s of %s can't be managed with an azapi_resource: %w", parsed.ResourceType, parsed.ID, err)
+	}
+	apiVersion := versions.LatestStable
+	if apiVersion == "" {
+		apiVersion = versions.Newest
+	}
+	return &ResourceIDType{
+		ID:           parsed.ID,
+		ResourceType: parsed.ResourceType,
+		Name:         parsed.Name,
+		ParentID:     parsed.ParentID,
+		Type:         fmt.Sprintf("%s@%s", parsed.ResourceType, apiVersion),
+		ImportID:     fmt.Sprintf("%s?api-version=%s", parsed.ID, apiVersion),
+		LatestStable: versions.LatestStable,
+		Newest:       versions.Newest,
+		ApiVersions:  versions.ApiVersions,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferResourceType(t *testing.T) {
+	cases := []struct {
+		desc         string
+		id           string
+		resourceType string
+		parentID     string
+	}{
+		{
+			desc:         "nested resource type with lower-case segments",
+			id:           "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/rg/providers/microsoft.network/virtualnetworks/vnet1/subnets/subnet1",
+			resourceType: "Microsoft.Network/virtualNetworks/subnets",
+			parentID:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/rg/providers/microsoft.network/virtualnetworks/vnet1",
+		},
+		{
+			desc:         "resource group",
+			id:           "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			resourceType: ResourceGroupResourceType,
+			parentID:     "/subscriptions/00000000-0000-0000-0000-000000000000",
+		},
+		{
+			desc:         "extension resource",
+			id:           "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/kv/providers/Microsoft.Authorization/locks/lock1",
+			resourceType: "Microsoft.Authorization/locks",
+			parentID:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/kv",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			inferred, err := InferResourceType(c.id)
+			require.NoError(t, err)
+			assert.Equal(t, c.resourceType, inferred.ResourceType)
+			assert.Equal(t, c.parentID, inferred.ParentID)
+			require.NotEmpty(t, inferred.ApiVersions)
+			assert.Equal(t, inferred.ApiVersions[0].ApiVersion, inferred.Newest)
+			assert.NotEmpty(t, inferred.LatestStable)
+			assert.Equal(t, c.resourceType+"@"+inferred.LatestStable, inferred.Type)
+			assert.Equal(t, c.id+"?api-version="+inferred.LatestStable, inferred.ImportID)
+		})
+	}
+}
+
+func TestInferResourceType_Unknown(t *testing.T) {
+	_, err := InferResourceType("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Foo/bars/bar")
+	assert.ErrorContains(t, err, "not known by the AzAPI types")
+
+	_, err = InferResourceType("/subscriptions/00000000-0000-0000-0000-000000000000")
+	assert.ErrorContains(t, err, "can't be managed with an azapi_resource")
+
+	_, err = InferResourceType("/subscriptions")
+	assert.Error(t, err)
+}
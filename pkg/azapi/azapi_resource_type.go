@@ -1,51 +1,192 @@
 package azapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/lonegunmanb/newres/v3/pkg/azapi"
-	azapi_resource "github.com/lonegunmanb/terraform-azapi-schema/v2/generated"
 	"github.com/ms-henglu/go-azure-types/types"
 	"github.com/zclconf/go-cty/cty"
 )
 
 func GetResourceSchema(resourceType, apiVersion, path string) (string, error) {
-	t, err := getSwaggerResourceType(resourceType, apiVersion)
+	return GetBlockSchema(DefaultBlockType, resourceType, apiVersion, path, false)
+}
+
+// GetBlockSchema returns the Go type string of an azapi block type, see BlockTypes. The swagger type of
+// resourceType@apiVersion is merged for block types whose body is the resource body, see BlockMergesBody.
+// When requiredOnly is true, only the properties that must be set are returned. Results are cached.
+func GetBlockSchema(blockType, resourceType, apiVersion, path string, requiredOnly bool) (string, error) {
+	return GetBlockSchemaInFormat(blockType, resourceType, apiVersion, path, TypeFormatGo, requiredOnly)
+}
+
+// GetBlockSchemaInFormat is GetBlockSchema rendering the type in one of TypeFormats, like an HCL type constraint.
+// Discriminated objects are described by comments in format, JSON schemas list their variants with `oneOf` instead.
+func GetBlockSchemaInFormat(blockType, resourceType, apiVersion, path, format string, requiredOnly bool) (string, error) {
+	format, err := validateTypeFormat(format)
 	if err != nil {
 		return "", err
 	}
-	schema := azapi_resource.Resources["azapi_resource"]
-	schemaType, err := toCtyType(schema.Block)
+	key := fmt.Sprintf("schema:%s:%s@%s:%s:%s:%t", blockType, resourceType, apiVersion, path, format, requiredOnly)
+	return cachedLookup(key, func() (string, error) {
+		return blockTypeString(blockType, resourceType, apiVersion, path, format, requiredOnly)
+	})
+}
+
+func blockTypeString(blockType, resourceType, apiVersion, path, format string, requiredOnly bool) (string, error) {
+	block, err := blockSchema(blockType)
 	if err != nil {
-		return "", fmt.Errorf("failed to convert azapi resource schema to cty type: %w", err)
+		return "", err
+	}
+	if requiredOnly {
+		block = requiredSchemaBlock(block)
+	}
+	schemaType, err := toCtyType(block)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert azapi schema to cty type: %w", err)
 	}
 	attributeTypes := schemaType.AttributeTypes()
-	for n, at := range t.AttributeTypes() {
-		attributeTypes[n] = at
+	var bodyType *types.ObjectType
+	if BlockMergesBody(blockType) {
+		bodyType, err = swaggerBodyType(resourceType, apiVersion, requiredOnly)
+		if err != nil {
+			return "", err
+		}
+		t, err := cachedLookup(fmt.Sprintf("body-type:%s@%s:%t", resourceType, apiVersion, requiredOnly), func() (cty.Type, error) {
+			return bodyCtyType(bodyType)
+		})
+		if err != nil {
+			return "", err
+		}
+		for n, at := range t.AttributeTypes() {
+			attributeTypes[n] = at
+		}
 	}
 	mergedType := cty.Object(attributeTypes)
 
-	if path == "" {
-		return compactGoType(mergedType.GoString()), nil
+	if hasWildcard(path) {
+		return queryWildcardTypes(mergedType, path, format)
+	}
+	subType := mergedType
+	if path != "" {
+		subType, err = queryTypeFromType(mergedType, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to query type from path %s: %w", path, err)
+		}
+	}
+	if bodyType != nil && format == TypeFormatJSONSchema {
+		objects, err := discriminatedObjectSchemas(bodyType, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to describe discriminated objects of %s@%s: %w", resourceType, apiVersion, err)
+		}
+		return jsonSchemaWithVariants(subType, path, objects)
+	}
+	var comments [][]string
+	if bodyType != nil {
+		comments, err = discriminatedObjectComments(bodyType, path, format)
+		if err != nil {
+			return "", fmt.Errorf("failed to describe discriminated objects of %s@%s: %w", resourceType, apiVersion, err)
+		}
+	}
+	typeString, err := formatType(subType, format, false)
+	if err != nil {
+		return "", err
+	}
+	if len(comments) == 0 {
+		return typeString, nil
+	}
+	sb := strings.Builder{}
+	sb.WriteString(strings.TrimSuffix(typeString, "\n"))
+	for _, c := range comments {
+		sb.WriteString("\n")
+		for _, line := range c {
+			sb.WriteString(commentPrefix(format) + line + "\n")
+		}
 	}
-	subType, err := queryTypeFromType(mergedType, path)
+	return sb.String(), nil
+}
+
+// jsonSchemaWithVariants returns the JSON schema of t at path, with the schemas of the discriminated objects keyed by
+// their path in place of their untyped schemas
+func jsonSchemaWithVariants(t cty.Type, path string, objects map[string]map[string]any) (string, error) {
+	schema := jsonSchemaType(t)
+	for p, object := range objects {
+		relative := strings.TrimPrefix(strings.TrimPrefix(p, path), ".")
+		var segments []string
+		if relative != "" {
+			segments = strings.Split(relative, ".")
+		}
+		replaceJSONSchemaAt(schema, segments, object)
+	}
+	content, err := json.MarshalIndent(schema, "", "  ")
 	if err != nil {
-		return "", fmt.Errorf("failed to query type from path %s: %w", path, err)
+		return "", fmt.Errorf("failed to marshal JSON schema: %w", err)
+	}
+	return string(content), nil
+}
+
+// replaceJSONSchemaAt replaces the schema of the property at segments, array items and map values are traversed
+// transparently like paths do
+func replaceJSONSchemaAt(schema map[string]any, segments []string, replacement map[string]any) {
+	for {
+		if items, ok := schema["items"].(map[string]any); ok {
+			schema = items
+			continue
+		}
+		if values, ok := schema["additionalProperties"].(map[string]any); ok {
+			schema = values
+			continue
+		}
+		break
+	}
+	if len(segments) == 0 {
+		clear(schema)
+		for k, v := range replacement {
+			schema[k] = v
+		}
+		return
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	if property, ok := properties[segments[0]].(map[string]any); ok {
+		replaceJSONSchemaAt(property, segments[1:], replacement)
 	}
-	return compactGoType(subType.GoString()), nil
 }
 
-func getSwaggerResourceType(resourceType, apiVersion string) (cty.Type, error) {
-	apiType, err := azapi.GetAzApiType(resourceType, apiVersion)
+// queryWildcardTypes returns a JSON object of the compact types in format of all paths matching a wildcard path
+func queryWildcardTypes(t cty.Type, path, format string) (string, error) {
+	matches, err := matchPath(t, path, typeChildren)
 	if err != nil {
-		return cty.NilType, fmt.Errorf("failed to get azapi type for resource %s api-version %s: %w", resourceType, apiVersion, err)
+		return "", err
 	}
-	bodyType, ok := apiType.Body.Type.(*types.ObjectType)
-	if !ok {
-		return cty.NilType, fmt.Errorf("resource body type is not an object type")
+	typeStrings := make(map[string]any, len(matches))
+	for p, match := range matches {
+		if format == TypeFormatJSONSchema {
+			typeStrings[p] = jsonSchemaType(match)
+			continue
+		}
+		typeStrings[p], err = formatType(match, format, true)
+		if err != nil {
+			return "", err
+		}
+	}
+	content, err := json.Marshal(typeStrings)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal types of path %s: %w", path, err)
 	}
+	return string(content), nil
+}
+
+func getSwaggerResourceType(resourceType, apiVersion string, requiredOnly bool) (cty.Type, error) {
+	bodyType, err := swaggerBodyType(resourceType, apiVersion, requiredOnly)
+	if err != nil {
+		return cty.NilType, err
+	}
+	return bodyCtyType(bodyType)
+}
+
+func bodyCtyType(bodyType *types.ObjectType) (cty.Type, error) {
 	blockSchema, err := azapi.ConvertAzApiObjectTypeToTerraformJsonSchemaAttribute(types.ObjectProperty{
 		Type: &types.TypeReference{
 			Type: bodyType,
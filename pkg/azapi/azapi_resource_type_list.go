@@ -0,0 +1,51 @@
+package azapi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ListResourceTypes lists the ARM resource types known by go-azure-types, sorted case-insensitively.
+// filter is matched case-insensitively as a substring, or as a glob when it contains `*`, like `Microsoft.Storage/*`,
+// where `*` also matches `/` so child resource types are included.
+func ListResourceTypes(filter string) ([]string, error) {
+	schema := azureSchemaLoader.GetSchema()
+	if schema == nil {
+		return nil, fmt.Errorf("failed to load azure schema index")
+	}
+	match, err := resourceTypeMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	resourceTypes := make([]string, 0)
+	for resourceType := range schema.Resources {
+		if match(resourceType) {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	sort.Slice(resourceTypes, func(i, j int) bool {
+		return strings.ToLower(resourceTypes[i]) < strings.ToLower(resourceTypes[j])
+	})
+	return resourceTypes, nil
+}
+
+func resourceTypeMatcher(filter string) (func(string) bool, error) {
+	filter = strings.TrimSpace(filter)
+	if !strings.Contains(filter, "*") {
+		lower := strings.ToLower(filter)
+		return func(resourceType string) bool {
+			return strings.Contains(strings.ToLower(resourceType), lower)
+		}, nil
+	}
+	parts := strings.Split(filter, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	pattern, err := regexp.Compile("(?i)^" + strings.Join(parts, ".*") + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource type filter %q: %w", filter, err)
+	}
+	return pattern.MatchString, nil
+}
@@ -0,0 +1,54 @@
+package azapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListResourceTypes(t *testing.T) {
+	cases := []struct {
+		desc     string
+		filter   string
+		contains []string
+		excludes []string
+	}{
+		{
+			desc:     "namespace glob includes child resource types",
+			filter:   "microsoft.storage/*",
+			contains: []string{"Microsoft.Storage/storageAccounts", "Microsoft.Storage/storageAccounts/blobServices/containers"},
+			excludes: []string{"Microsoft.StorageCache/caches", "Microsoft.Compute/virtualMachines"},
+		},
+		{
+			desc:     "substring",
+			filter:   "virtualmachines",
+			contains: []string{"Microsoft.Compute/virtualMachines", "Microsoft.Compute/virtualMachines/extensions"},
+			excludes: []string{"Microsoft.Storage/storageAccounts"},
+		},
+		{
+			desc:     "glob anchored at both ends",
+			filter:   "Microsoft.Compute/*Machines",
+			contains: []string{"Microsoft.Compute/virtualMachines"},
+			excludes: []string{"Microsoft.Compute/virtualMachines/extensions"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			resourceTypes, err := ListResourceTypes(c.filter)
+			require.NoError(t, err)
+			for _, rt := range c.contains {
+				assert.Contains(t, resourceTypes, rt)
+			}
+			for _, rt := range c.excludes {
+				assert.NotContains(t, resourceTypes, rt)
+			}
+		})
+	}
+}
+
+func TestListResourceTypes_NoMatch(t *testing.T) {
+	resourceTypes, err := ListResourceTypes("Microsoft.DoesNotExist/*")
+	require.NoError(t, err)
+	assert.Empty(t, resourceTypes)
+}
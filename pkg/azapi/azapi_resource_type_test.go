@@ -10,7 +10,7 @@ import (
 )
 
 func TestGetAzAPIType_WithoutJsonPath(t *testing.T) {
-	resourceType, err := getSwaggerResourceType("Microsoft.Resources/resourcegroups", "2024-07-01")
+	resourceType, err := getSwaggerResourceType("Microsoft.Resources/resourcegroups", "2024-07-01", false)
 	require.NoError(t, err)
 	require.True(t, resourceType.IsObjectType())
 	require.True(t, resourceType.HasAttribute("location"))
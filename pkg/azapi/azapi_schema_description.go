@@ -3,29 +3,47 @@ package azapi
 import (
 	"fmt"
 	tfjson "github.com/hashicorp/terraform-json"
-	"github.com/lonegunmanb/newres/v3/pkg/azapi"
-	azapi_resource "github.com/lonegunmanb/terraform-azapi-schema/v2/generated"
 	"github.com/ms-henglu/go-azure-types/types"
 	"strings"
 )
 
 func GetResourceSchemaDescription(resourceType, apiVersion, path string) (any, error) {
-	// Get swagger resource descriptions
-	swaggerDescriptions, err := getSwaggerResourceDescriptions(resourceType, apiVersion)
-	if err != nil {
-		return nil, err
-	}
+	return GetBlockSchemaDescription(DefaultBlockType, resourceType, apiVersion, path, false)
+}
+
+// GetBlockSchemaDescription returns the descriptions of an azapi block type, see BlockTypes. The swagger descriptions of
+// resourceType@apiVersion are merged for block types whose body is the resource body, see BlockMergesBody.
+// When requiredOnly is true, only the properties that must be set are returned. Results are cached, the returned
+// descriptions are shared and must not be modified.
+func GetBlockSchemaDescription(blockType, resourceType, apiVersion, path string, requiredOnly bool) (any, error) {
+	key := fmt.Sprintf("description:%s:%s@%s:%s:%t", blockType, resourceType, apiVersion, path, requiredOnly)
+	return cachedLookup(key, func() (any, error) {
+		return blockDescription(blockType, resourceType, apiVersion, path, requiredOnly)
+	})
+}
 
-	// Get azapi resource schema descriptions
-	azapiDescriptions, err := getAzapiResourceDescriptions()
+func blockDescription(blockType, resourceType, apiVersion, path string, requiredOnly bool) (any, error) {
+	block, err := blockSchema(blockType)
 	if err != nil {
 		return nil, err
 	}
-
-	// Merge descriptions
-	mergedDescriptions := azapiDescriptions
-	for k, v := range swaggerDescriptions {
-		mergedDescriptions[k] = v
+	if requiredOnly {
+		block = requiredSchemaBlock(block)
+	}
+	// Get azapi block schema descriptions
+	mergedDescriptions := convertSchemaBlockToDescriptionMap(block)
+
+	if BlockMergesBody(blockType) {
+		// Get swagger resource descriptions
+		swaggerDescriptions, err := cachedLookup(fmt.Sprintf("body-description:%s@%s:%t", resourceType, apiVersion, requiredOnly), func() (map[string]any, error) {
+			return getSwaggerResourceDescriptions(resourceType, apiVersion, requiredOnly)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range swaggerDescriptions {
+			mergedDescriptions[k] = v
+		}
 	}
 
 	if path == "" {
@@ -34,14 +52,10 @@ func GetResourceSchemaDescription(resourceType, apiVersion, path string) (any, e
 	return queryDescriptionInObject(mergedDescriptions, path)
 }
 
-func getSwaggerResourceDescriptions(resourceType, apiVersion string) (map[string]any, error) {
-	apiType, err := azapi.GetAzApiType(resourceType, apiVersion)
+func getSwaggerResourceDescriptions(resourceType, apiVersion string, requiredOnly bool) (map[string]any, error) {
+	bodyType, err := swaggerBodyType(resourceType, apiVersion, requiredOnly)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get azapi type for resource %s api-version %s: %w", resourceType, apiVersion, err)
-	}
-	bodyType, ok := apiType.Body.Type.(*types.ObjectType)
-	if !ok {
-		return nil, fmt.Errorf("resource body type is not an object type")
+		return nil, err
 	}
 	result := make(map[string]any)
 	for n, p := range bodyType.Properties {
@@ -56,11 +70,6 @@ func getSwaggerResourceDescriptions(resourceType, apiVersion string) (map[string
 	}, nil
 }
 
-func getAzapiResourceDescriptions() (map[string]any, error) {
-	schema := azapi_resource.Resources["azapi_resource"]
-	return convertSchemaBlockToDescriptionMap(schema.Block), nil
-}
-
 func convertSchemaBlockToDescriptionMap(block *tfjson.SchemaBlock) map[string]any {
 	result := make(map[string]any)
 
@@ -101,7 +110,12 @@ func convertNestedAttributeToDescriptionMap(nestedType *tfjson.SchemaNestedAttri
 	return result
 }
 
+// queryDescriptionInObject returns the description at path, or the descriptions of all matching paths keyed by path
+// when path has wildcards
 func queryDescriptionInObject(result map[string]any, path string) (any, error) {
+	if hasWildcard(path) {
+		return matchPath[any](result, path, descriptionChildren)
+	}
 	parts := strings.Split(path, ".")
 	current := result
 
@@ -128,8 +142,12 @@ func queryDescriptionInObject(result map[string]any, path string) (any, error) {
 }
 
 // ConvertAzApiObjectPropertyToMap converts types.ObjectProperty to map[string]any
-// where values are property descriptions, or nested maps for object properties
+// where values are property descriptions, or nested maps for object properties. Discriminated objects are
+// converted by discriminatedObjectToMap.
 func ConvertAzApiObjectPropertyToMap(property types.ObjectProperty) (any, error) {
+	if discriminatedType, ok := property.Type.Type.(*types.DiscriminatedObjectType); ok {
+		return discriminatedObjectToMap(discriminatedType)
+	}
 	objType, ok := property.Type.Type.(*types.ObjectType)
 	if !ok {
 		// If it's not an object type, return a simple map with description
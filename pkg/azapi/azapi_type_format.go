@@ -0,0 +1,279 @@
+package azapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Type languages AzAPI schemas can be rendered in
+const (
+	// TypeFormatGo renders cty Go type strings, like `Object(map[string]Type{"name":String})`
+	TypeFormatGo = "go"
+	// TypeFormatHCL renders Terraform type constraints, like `object({ name = string })`
+	TypeFormatHCL = "hcl"
+	// TypeFormatTypeScript renders TypeScript types, like `{ name: string }`
+	TypeFormatTypeScript = "typescript"
+	// TypeFormatJSONSchema renders JSON Schemas, like `{"type": "object", "properties": {"name": {"type": "string"}}}`
+	TypeFormatJSONSchema = "json_schema"
+)
+
+var (
+	hclIdentifierPattern        = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	typeScriptIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// TypeFormats returns the type languages AzAPI schemas can be rendered in, TypeFormatGo is the default
+func TypeFormats() []string {
+	return []string{TypeFormatGo, TypeFormatHCL, TypeFormatTypeScript, TypeFormatJSONSchema}
+}
+
+func validateTypeFormat(format string) (string, error) {
+	if format == "" {
+		return TypeFormatGo, nil
+	}
+	for _, f := range TypeFormats() {
+		if strings.EqualFold(f, format) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("invalid format %q, must be one of %s", format, strings.Join(TypeFormats(), ", "))
+}
+
+// formatType renders t in format. Compact types fit on one line, to be embedded in comments or JSON values.
+// DynamicPseudoType, used for discriminated objects and untyped properties, is rendered as the any type of format,
+// optional attributes are marked the way format does.
+func formatType(t cty.Type, format string, compact bool) (string, error) {
+	switch format {
+	case TypeFormatGo:
+		return compactGoType(t.GoString()), nil
+	case TypeFormatHCL:
+		sb := &strings.Builder{}
+		writeHCLType(sb, t, "", compact)
+		if compact {
+			return sb.String(), nil
+		}
+		return string(hclwrite.Format([]byte(sb.String()))), nil
+	case TypeFormatTypeScript:
+		sb := &strings.Builder{}
+		writeTypeScriptType(sb, t, "", compact)
+		return sb.String(), nil
+	case TypeFormatJSONSchema:
+		var content []byte
+		var err error
+		if compact {
+			content, err = json.Marshal(jsonSchemaType(t))
+		} else {
+			content, err = json.MarshalIndent(jsonSchemaType(t), "", "  ")
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal JSON schema: %w", err)
+		}
+		return string(content), nil
+	}
+	return "", fmt.Errorf("invalid format %q, must be one of %s", format, strings.Join(TypeFormats(), ", "))
+}
+
+// commentPrefix returns the line comment prefix of format, JSON schemas have no comments
+func commentPrefix(format string) string {
+	if format == TypeFormatHCL {
+		return "# "
+	}
+	return "// "
+}
+
+// writeHCLType writes a type constraint with optional attributes marked by `optional()`, multi-line types are aligned
+// by hclwrite.Format afterward
+func writeHCLType(sb *strings.Builder, t cty.Type, indent string, compact bool) {
+	switch {
+	case t.IsObjectType():
+		attributes := t.AttributeTypes()
+		if len(attributes) == 0 {
+			sb.WriteString("object({})")
+			return
+		}
+		sb.WriteString(openObject("object({", compact))
+		for i, name := range sortedAttributeNames(attributes) {
+			writeAttributeStart(sb, hclAttributeName(name)+" = ", ", ", i, indent, compact)
+			if t.AttributeOptional(name) {
+				sb.WriteString("optional(")
+			}
+			writeHCLType(sb, attributes[name], indent+"  ", compact)
+			if t.AttributeOptional(name) {
+				sb.WriteString(")")
+			}
+			if !compact {
+				sb.WriteString("\n")
+			}
+		}
+		sb.WriteString(closeObject("})", indent, compact))
+	case t.IsListType() || t.IsSetType() || t.IsMapType():
+		kind := "list"
+		if t.IsSetType() {
+			kind = "set"
+		} else if t.IsMapType() {
+			kind = "map"
+		}
+		sb.WriteString(kind + "(")
+		writeHCLType(sb, t.ElementType(), indent, compact)
+		sb.WriteString(")")
+	case t.IsTupleType():
+		sb.WriteString("tuple([")
+		for i, et := range t.TupleElementTypes() {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			writeHCLType(sb, et, indent, compact)
+		}
+		sb.WriteString("])")
+	default:
+		sb.WriteString(typeexpr.TypeString(t))
+	}
+}
+
+// writeTypeScriptType writes a TypeScript type, optional attributes are optional properties
+func writeTypeScriptType(sb *strings.Builder, t cty.Type, indent string, compact bool) {
+	switch {
+	case t.IsObjectType():
+		attributes := t.AttributeTypes()
+		if len(attributes) == 0 {
+			sb.WriteString("{}")
+			return
+		}
+		sb.WriteString(openObject("{", compact))
+		for i, name := range sortedAttributeNames(attributes) {
+			optional := ""
+			if t.AttributeOptional(name) {
+				optional = "?"
+			}
+			writeAttributeStart(sb, typeScriptPropertyName(name)+optional+": ", "; ", i, indent, compact)
+			writeTypeScriptType(sb, attributes[name], indent+"  ", compact)
+			if !compact {
+				sb.WriteString(";\n")
+			}
+		}
+		sb.WriteString(closeObject("}", indent, compact))
+	case t.IsListType() || t.IsSetType():
+		sb.WriteString("Array<")
+		writeTypeScriptType(sb, t.ElementType(), indent, compact)
+		sb.WriteString(">")
+	case t.IsMapType():
+		sb.WriteString("Record<string, ")
+		writeTypeScriptType(sb, t.ElementType(), indent, compact)
+		sb.WriteString(">")
+	case t.IsTupleType():
+		sb.WriteString("[")
+		for i, et := range t.TupleElementTypes() {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			writeTypeScriptType(sb, et, indent, compact)
+		}
+		sb.WriteString("]")
+	case t == cty.String:
+		sb.WriteString("string")
+	case t == cty.Number:
+		sb.WriteString("number")
+	case t == cty.Bool:
+		sb.WriteString("boolean")
+	default:
+		sb.WriteString("any")
+	}
+}
+
+func openObject(open string, compact bool) string {
+	if compact {
+		return open + " "
+	}
+	return open + "\n"
+}
+
+func closeObject(close, indent string, compact bool) string {
+	if compact {
+		return " " + close
+	}
+	return indent + close
+}
+
+// writeAttributeStart writes the start of the i-th attribute of an object, compact attributes are separated by
+// separator and the others are indented on their own lines
+func writeAttributeStart(sb *strings.Builder, start, separator string, i int, indent string, compact bool) {
+	switch {
+	case !compact:
+		sb.WriteString(indent + "  ")
+	case i > 0:
+		sb.WriteString(separator)
+	}
+	sb.WriteString(start)
+}
+
+// jsonSchemaType converts t to a JSON Schema, objects accept no other properties than their attributes
+func jsonSchemaType(t cty.Type) map[string]any {
+	switch {
+	case t.IsObjectType():
+		properties := make(map[string]any)
+		required := make([]string, 0)
+		for _, name := range sortedAttributeNames(t.AttributeTypes()) {
+			properties[name] = jsonSchemaType(t.AttributeType(name))
+			if !t.AttributeOptional(name) {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	case t.IsListType():
+		return map[string]any{"type": "array", "items": jsonSchemaType(t.ElementType())}
+	case t.IsSetType():
+		return map[string]any{"type": "array", "items": jsonSchemaType(t.ElementType()), "uniqueItems": true}
+	case t.IsMapType():
+		return map[string]any{"type": "object", "additionalProperties": jsonSchemaType(t.ElementType())}
+	case t.IsTupleType():
+		items := make([]any, 0, t.Length())
+		for _, et := range t.TupleElementTypes() {
+			items = append(items, jsonSchemaType(et))
+		}
+		return map[string]any{"type": "array", "prefixItems": items, "items": false}
+	case t == cty.String:
+		return map[string]any{"type": "string"}
+	case t == cty.Number:
+		return map[string]any{"type": "number"}
+	case t == cty.Bool:
+		return map[string]any{"type": "boolean"}
+	}
+	return map[string]any{}
+}
+
+// hclAttributeName quotes attribute names that aren't HCL identifiers, like `@odata.type`. Terraform type constraints
+// only accept identifiers, such attributes can't be declared there.
+func hclAttributeName(name string) string {
+	if hclIdentifierPattern.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+// typeScriptPropertyName quotes property names that aren't TypeScript identifiers
+func typeScriptPropertyName(name string) string {
+	if typeScriptIdentifierPattern.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+func sortedAttributeNames(attributes map[string]cty.Type) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,125 @@
+package azapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestFormatType(t *testing.T) {
+	typ := cty.ObjectWithOptionalAttrs(map[string]cty.Type{
+		"name":        cty.String,
+		"tags":        cty.Map(cty.String),
+		"ports":       cty.List(cty.Number),
+		"enabled":     cty.Bool,
+		"@odata.type": cty.String,
+		"settings":    cty.DynamicPseudoType,
+	}, []string{"tags", "ports", "enabled", "@odata.type", "settings"})
+	cases := []struct {
+		format   string
+		compact  bool
+		expected string
+	}{
+		{
+			format:   TypeFormatHCL,
+			expected: "object({\n  \"@odata.type\" = optional(string)\n  enabled       = optional(bool)\n  name          = string\n  ports         = optional(list(number))\n  settings      = optional(any)\n  tags          = optional(map(string))\n})",
+		},
+		{
+			format:   TypeFormatHCL,
+			compact:  true,
+			expected: `object({ "@odata.type" = optional(string), enabled = optional(bool), name = string, ports = optional(list(number)), settings = optional(any), tags = optional(map(string)) })`,
+		},
+		{
+			format:   TypeFormatTypeScript,
+			expected: "{\n  \"@odata.type\"?: string;\n  enabled?: boolean;\n  name: string;\n  ports?: Array<number>;\n  settings?: any;\n  tags?: Record<string, string>;\n}",
+		},
+		{
+			format:   TypeFormatTypeScript,
+			compact:  true,
+			expected: `{ "@odata.type"?: string; enabled?: boolean; name: string; ports?: Array<number>; settings?: any; tags?: Record<string, string> }`,
+		},
+		{
+			format:   TypeFormatJSONSchema,
+			compact:  true,
+			expected: `{"additionalProperties":false,"properties":{"@odata.type":{"type":"string"},"enabled":{"type":"boolean"},"name":{"type":"string"},"ports":{"items":{"type":"number"},"type":"array"},"settings":{},"tags":{"additionalProperties":{"type":"string"},"type":"object"}},"required":["name"],"type":"object"}`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			actual, err := formatType(typ, c.format, c.compact)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestGetBlockSchemaInFormat(t *testing.T) {
+	cases := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format:   "",
+			expected: `Object(map[string]Type{"family":String, "name":String})`,
+		},
+		{
+			format:   TypeFormatHCL,
+			expected: "object({\n  family = string\n  name   = string\n})",
+		},
+		{
+			format:   TypeFormatTypeScript,
+			expected: "{\n  family: string;\n  name: string;\n}",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			schema, err := GetBlockSchemaInFormat(DefaultBlockType, "Microsoft.KeyVault/vaults", "2023-07-01", "body.properties.sku", c.format, false)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, schema)
+		})
+	}
+}
+
+func TestGetBlockSchemaInFormat_DiscriminatedObjectComments(t *testing.T) {
+	schema, err := GetBlockSchemaInFormat(DefaultBlockType, dhcpConfigurationType, "2024-09-01", "body", TypeFormatHCL, false)
+	require.NoError(t, err)
+	assert.Equal(t, `object({
+  properties = optional(any)
+})
+# body.properties is a discriminated object, "dhcpType" selects the variant
+# base: object({ displayName = optional(string), revision = optional(number) })
+# dhcpType=RELAY: object({ serverAddresses = optional(list(string)) })
+# dhcpType=SERVER: object({ leaseTime = optional(number), serverAddress = optional(string) })
+`, schema)
+}
+
+func TestGetBlockSchemaInFormat_JSONSchemaVariants(t *testing.T) {
+	schema, err := GetBlockSchemaInFormat(DefaultBlockType, dhcpConfigurationType, "2024-09-01", "body", TypeFormatJSONSchema, false)
+	require.NoError(t, err)
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(schema), &parsed))
+
+	properties := parsed["properties"].(map[string]any)["properties"].(map[string]any)
+	variants, ok := properties["oneOf"].([]any)
+	require.True(t, ok)
+	require.Len(t, variants, 2)
+	relay := variants[0].(map[string]any)
+	assert.Equal(t, map[string]any{"const": "RELAY"}, relay["properties"].(map[string]any)["dhcpType"])
+	assert.Contains(t, relay["properties"], "serverAddresses")
+	assert.Contains(t, relay["properties"], "displayName")
+	assert.Equal(t, []any{"dhcpType"}, relay["required"])
+}
+
+func TestGetBlockSchemaInFormat_Wildcard(t *testing.T) {
+	schema, err := GetBlockSchemaInFormat(DefaultBlockType, "Microsoft.Compute/virtualMachines", "2024-11-01", "body.properties.osProfile.**.sourceVault", TypeFormatHCL, false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"body.properties.osProfile.secrets.sourceVault":"object({ id = optional(string) })"}`, schema)
+}
+
+func TestGetBlockSchemaInFormat_InvalidFormat(t *testing.T) {
+	_, err := GetBlockSchemaInFormat(DefaultBlockType, "Microsoft.KeyVault/vaults", "2023-07-01", "", "yaml", false)
+	assert.EqualError(t, err, `invalid format "yaml", must be one of go, hcl, typescript, json_schema`)
+}
@@ -0,0 +1,119 @@
+package azapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// azurermResourceMapURL is the azurerm resource type map maintained by aztft, which maps azurerm resource types to the
+// ARM resource types they manage. Package-level to allow test stubbing.
+var azurermResourceMapURL = "https://raw.githubusercontent.com/Azure/aztft/main/internal/resmap/map.json"
+
+var azurermResourceMapClient = &http.Client{Timeout: 30 * time.Second}
+
+// ResourceTypeMapping pairs an azurerm resource type with the ARM resource type it manages
+type ResourceTypeMapping struct {
+	AzurermResourceType string `json:"azurerm_resource_type"`
+	ARMResourceType     string `json:"arm_resource_type"`
+	// Scopes are the parent scopes the ARM resource is deployed to, like `/subscriptions/resourceGroups`
+	Scopes []string `json:"scopes,omitempty"`
+	// LatestApiVersion is the newest stable api-version of the ARM resource type known by the AzAPI types, empty when
+	// the type is unknown
+	LatestApiVersion string `json:"latest_api_version,omitempty"`
+}
+
+// ResourceTypeMappings lists the mappings of a resource type, one azurerm resource type may manage several ARM resource
+// types and vice versa, e.g. `azurerm_linux_virtual_machine` and `azurerm_windows_virtual_machine` both manage
+// `Microsoft.Compute/virtualMachines`
+type ResourceTypeMappings struct {
+	ResourceType string                `json:"resource_type"`
+	Mappings     []ResourceTypeMapping `json:"mappings"`
+}
+
+type azurermResourceMapEntry struct {
+	ManagementPlane *struct {
+		Scopes   []string `json:"scopes"`
+		Provider string   `json:"provider"`
+		Types    []string `json:"types"`
+	} `json:"management_plane"`
+}
+
+// MapResourceType maps an azurerm resource type like `azurerm_storage_account` to its ARM resource types, or an ARM
+// resource type like `Microsoft.Storage/storageAccounts` back to the azurerm resource types managing it. ARM resource
+// types are matched case-insensitively. Data plane only azurerm resources have no ARM resource type and aren't mapped.
+func MapResourceType(ctx context.Context, resourceType string) (*ResourceTypeMappings, error) {
+	resourceType = strings.TrimSpace(resourceType)
+	if resourceType == "" {
+		return nil, fmt.Errorf("resource type is required")
+	}
+	resourceMap, err := loadAzurermResourceMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fromAzurerm := strings.HasPrefix(resourceType, "azurerm_")
+	mappings := make([]ResourceTypeMapping, 0)
+	for azurermType, entry := range resourceMap {
+		if entry.ManagementPlane == nil || entry.ManagementPlane.Provider == "" || len(entry.ManagementPlane.Types) == 0 {
+			continue
+		}
+		armType := entry.ManagementPlane.Provider + "/" + strings.Join(entry.ManagementPlane.Types, "/")
+		matched := strings.EqualFold(armType, resourceType)
+		if fromAzurerm {
+			matched = azurermType == resourceType
+		}
+		if !matched {
+			continue
+		}
+		mapping := ResourceTypeMapping{
+			AzurermResourceType: azurermType,
+			ARMResourceType:     armType,
+			Scopes:              entry.ManagementPlane.Scopes,
+		}
+		if apiVersion, err := ResolveApiVersion(armType, ApiVersionLatest); err == nil {
+			mapping.LatestApiVersion = apiVersion
+		}
+		mappings = append(mappings, mapping)
+	}
+	if len(mappings) == 0 {
+		return nil, fmt.Errorf("no mapping found for resource type %s", resourceType)
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].AzurermResourceType != mappings[j].AzurermResourceType {
+			return mappings[i].AzurermResourceType < mappings[j].AzurermResourceType
+		}
+		return mappings[i].ARMResourceType < mappings[j].ARMResourceType
+	})
+	return &ResourceTypeMappings{ResourceType: resourceType, Mappings: mappings}, nil
+}
+
+func loadAzurermResourceMap(ctx context.Context) (map[string]azurermResourceMapEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, azurermResourceMapURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := azurermResourceMapClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download azurerm resource map: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download azurerm resource map from %s: status %d", azurermResourceMapURL, resp.StatusCode)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read azurerm resource map: %w", err)
+	}
+	var resourceMap map[string]azurermResourceMapEntry
+	if err := json.Unmarshal(content, &resourceMap); err != nil {
+		return nil, fmt.Errorf("failed to parse azurerm resource map: %w", err)
+	}
+	return resourceMap, nil
+}
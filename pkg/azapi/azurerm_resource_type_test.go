@@ -0,0 +1,107 @@
+package azapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAzurermResourceMap = `{
+  "azurerm_key_vault": {
+    "management_plane": {
+      "scopes": ["/subscriptions/resourceGroups"],
+      "provider": "Microsoft.KeyVault",
+      "types": ["vaults"],
+      "import_specs": ["/subscriptions/resourceGroups/Microsoft.KeyVault/vaults"]
+    }
+  },
+  "azurerm_key_vault_secret": {
+    "data_plane": {}
+  },
+  "azurerm_linux_virtual_machine": {
+    "management_plane": {
+      "scopes": ["/subscriptions/resourceGroups"],
+      "provider": "Microsoft.Compute",
+      "types": ["virtualMachines"]
+    }
+  },
+  "azurerm_windows_virtual_machine": {
+    "management_plane": {
+      "scopes": ["/subscriptions/resourceGroups"],
+      "provider": "Microsoft.Compute",
+      "types": ["virtualMachines"]
+    }
+  },
+  "azurerm_subnet": {
+    "management_plane": {
+      "scopes": ["/subscriptions/resourceGroups"],
+      "provider": "Microsoft.Network",
+      "types": ["virtualNetworks", "subnets"]
+    }
+  }
+}`
+
+func stubAzurermResourceMap(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testAzurermResourceMap))
+	}))
+	stubs := gostub.Stub(&azurermResourceMapURL, server.URL)
+	t.Cleanup(func() {
+		stubs.Reset()
+		server.Close()
+	})
+}
+
+func TestMapResourceType(t *testing.T) {
+	stubAzurermResourceMap(t)
+	cases := []struct {
+		name         string
+		resourceType string
+		expected     map[string]string
+	}{
+		{
+			name:         "azurerm to ARM",
+			resourceType: "azurerm_key_vault",
+			expected:     map[string]string{"azurerm_key_vault": "Microsoft.KeyVault/vaults"},
+		},
+		{
+			name:         "child resource type",
+			resourceType: "azurerm_subnet",
+			expected:     map[string]string{"azurerm_subnet": "Microsoft.Network/virtualNetworks/subnets"},
+		},
+		{
+			name:         "ARM to azurerm",
+			resourceType: "microsoft.compute/VIRTUALMACHINES",
+			expected: map[string]string{
+				"azurerm_linux_virtual_machine":   "Microsoft.Compute/virtualMachines",
+				"azurerm_windows_virtual_machine": "Microsoft.Compute/virtualMachines",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := MapResourceType(context.Background(), c.resourceType)
+			require.NoError(t, err)
+			actual := make(map[string]string)
+			for _, m := range result.Mappings {
+				actual[m.AzurermResourceType] = m.ARMResourceType
+				assert.Equal(t, []string{"/subscriptions/resourceGroups"}, m.Scopes)
+				assert.NotEmpty(t, m.LatestApiVersion)
+			}
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestMapResourceType_NotFound(t *testing.T) {
+	stubAzurermResourceMap(t)
+	for _, resourceType := range []string{"azurerm_key_vault_secret", "Microsoft.Unknown/things"} {
+		_, err := MapResourceType(context.Background(), resourceType)
+		assert.ErrorContains(t, err, "no mapping found for resource type "+resourceType)
+	}
+}
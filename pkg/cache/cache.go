@@ -0,0 +1,404 @@
+// Package cache is the cache subsystem shared by the packages of the server. A cache keeps its most recently used
+// values in memory within an entry and memory budget, optionally backed by a disk directory that survives restarts,
+// and expires values after a time to live. Caches are named, so they can be configured with environment variables,
+// and registered, so they can be listed and cleared by name.
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Backends of a cache
+const (
+	// BackendMemory keeps values in memory only
+	BackendMemory = "memory"
+	// BackendDisk keeps values in memory and on disk, evicted values and values of previous processes are read back
+	// from disk
+	BackendDisk = "disk"
+	// BackendOff disables the cache, every lookup is a miss
+	BackendOff = "off"
+)
+
+// DirEnv overrides the root directory of the disk caches, every cache uses a sub-directory of it, so clearing a cache
+// never touches the other files of the directory. It defaults to
+// `terraform-mcp-eva` under the user cache directory, set it to `off` to keep every cache in memory.
+const DirEnv = "CACHE_DIR"
+
+// Environment variables configuring a cache, formatted with the upper-cased cache name, e.g. `CACHE_TFSCHEMA_TTL`
+const (
+	// BackendEnvFormat is the backend of the cache: `memory`, `disk` or `off`
+	BackendEnvFormat = "CACHE_%s_BACKEND"
+	// TTLEnvFormat is how long values are served, like `30m`, `0` means forever
+	TTLEnvFormat = "CACHE_%s_TTL"
+	// MaxEntriesEnvFormat is the maximum number of values kept in memory
+	MaxEntriesEnvFormat = "CACHE_%s_MAX_ENTRIES"
+	// MaxMBEnvFormat is the memory budget in megabytes, approximated by the serialized size of the values
+	MaxMBEnvFormat = "CACHE_%s_MAX_MB"
+)
+
+// Options describe a cache and its default configuration
+type Options struct {
+	// Name identifies the cache in its environment variables and in the cache admin tool, like `tfschema`
+	Name string
+	// Description tells what the cache holds
+	Description string
+	// Backend is the default backend, defaults to BackendMemory
+	Backend string
+	// MaxEntries is the default maximum number of values kept in memory, zero means unlimited
+	MaxEntries int
+	// MaxBytes is the default memory budget, zero means unlimited
+	MaxBytes int
+	// TTL is the default time to live of the values, zero means forever
+	TTL time.Duration
+	// MemoryOnly is set for caches whose values can't be serialized, they never use the disk backend
+	MemoryOnly bool
+	// DirName is the directory of the disk backend under the root directory, or under DirEnv, defaults to Name
+	DirName string
+	// Path returns the file of a key relative to the disk directory, defaults to the SHA-256 of the key
+	Path func(key string) string
+	// DirEnv, MaxEntriesEnv and MaxMBEnv are package specific environment variables used when the generic ones aren't
+	// set. DirEnv replaces the root directory of the cache, `off` disables the disk backend.
+	DirEnv        string
+	MaxEntriesEnv string
+	MaxMBEnv      string
+}
+
+// Source tells where a cached value was found
+type Source string
+
+// Sources of cached values
+const (
+	SourceMemory Source = "memory"
+	SourceDisk   Source = "disk"
+)
+
+// Config is the configuration of a cache resolved from its Options and the environment
+type Config struct {
+	Backend    string
+	MaxEntries int
+	MaxBytes   int
+	TTL        time.Duration
+	// Dir is the disk directory, empty unless the backend is BackendDisk
+	Dir string
+}
+
+// Cache is a named cache of values of type V. Values are shared by the callers loading the same key, they must not be
+// modified. Disk entries are JSON encoded.
+type Cache[V any] struct {
+	opts Options
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	size    int
+}
+
+type entry[V any] struct {
+	key    string
+	value  V
+	size   int
+	stored time.Time
+}
+
+// New returns an empty cache, caches are configured every time they're used, so environment changes apply right away
+func New[V any](opts Options) *Cache[V] {
+	return &Cache[V]{
+		opts:    opts,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Name returns the name of the cache
+func (c *Cache[V]) Name() string {
+	return c.opts.Name
+}
+
+// Config resolves the configuration of the cache
+func (c *Cache[V]) Config() Config {
+	name := strings.ToUpper(c.opts.Name)
+	config := Config{
+		Backend:    c.opts.Backend,
+		MaxEntries: c.opts.MaxEntries,
+		MaxBytes:   c.opts.MaxBytes,
+		TTL:        c.opts.TTL,
+	}
+	switch backend := os.Getenv(fmt.Sprintf(BackendEnvFormat, name)); backend {
+	case BackendMemory, BackendDisk, BackendOff:
+		config.Backend = backend
+	}
+	if config.Backend == "" {
+		config.Backend = BackendMemory
+	}
+	if n, err := strconv.Atoi(lookupEnv(fmt.Sprintf(MaxEntriesEnvFormat, name), c.opts.MaxEntriesEnv)); err == nil && n > 0 {
+		config.MaxEntries = n
+	}
+	if mb, err := strconv.Atoi(lookupEnv(fmt.Sprintf(MaxMBEnvFormat, name), c.opts.MaxMBEnv)); err == nil && mb > 0 {
+		config.MaxBytes = mb << 20
+	}
+	if ttl, err := time.ParseDuration(os.Getenv(fmt.Sprintf(TTLEnvFormat, name))); err == nil && ttl >= 0 {
+		config.TTL = ttl
+	}
+	if config.Backend == BackendDisk {
+		config.Dir = c.dir()
+		if config.Dir == "" {
+			config.Backend = BackendMemory
+		}
+	}
+	return config
+}
+
+// dir returns the disk directory of the cache, or an empty string when the disk backend is unavailable
+func (c *Cache[V]) dir() string {
+	if c.opts.MemoryOnly {
+		return ""
+	}
+	dirName := c.opts.DirName
+	if dirName == "" {
+		dirName = c.opts.Name
+	}
+	if c.opts.DirEnv != "" {
+		if dir := os.Getenv(c.opts.DirEnv); dir != "" {
+			if dir == "off" {
+				return ""
+			}
+			return filepath.Join(dir, dirName)
+		}
+	}
+	if root := os.Getenv(DirEnv); root != "" {
+		if root == "off" {
+			return ""
+		}
+		return filepath.Join(root, dirName)
+	}
+	root, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(root, "terraform-mcp-eva", dirName)
+}
+
+// Get returns the cached value of key and where it was found, values found on disk are loaded into memory
+func (c *Cache[V]) Get(key string) (V, Source, bool) {
+	config := c.Config()
+	if config.Backend == BackendOff {
+		var zero V
+		return zero, "", false
+	}
+	if value, ok := c.load(key, config); ok {
+		return value, SourceMemory, true
+	}
+	if config.Dir != "" {
+		if value, stored, ok := c.readDisk(key, config); ok {
+			c.store(key, value, stored, config)
+			return value, SourceDisk, true
+		}
+	}
+	var zero V
+	return zero, "", false
+}
+
+// Put caches the value of key. The value is always cached in memory, the error reports a failure to write it to disk,
+// which only costs a reload later.
+func (c *Cache[V]) Put(key string, value V) error {
+	config := c.Config()
+	if config.Backend == BackendOff {
+		return nil
+	}
+	c.store(key, value, time.Now(), config)
+	if config.Dir == "" {
+		return nil
+	}
+	return c.writeDisk(key, value, config)
+}
+
+// Len returns the number of values in memory
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Clear drops every cached value from memory, and from disk with the disk backend, and returns the number of dropped
+// memory entries and disk files. Only the entries in the own directory of the cache are removed.
+func (c *Cache[V]) Clear() (entries, files int, err error) {
+	c.mu.Lock()
+	entries = c.order.Len()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.size = 0
+	c.mu.Unlock()
+
+	dir := c.Config().Dir
+	if dir == "" {
+		return entries, 0, nil
+	}
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		files++
+		return nil
+	})
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	if err != nil {
+		return entries, files, fmt.Errorf("failed to clear disk cache %s: %w", dir, err)
+	}
+	return entries, files, nil
+}
+
+// load returns the value of key from memory and marks it as the most recently used, expired values are dropped
+func (c *Cache[V]) load(key string, config Config) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e := element.Value.(*entry[V])
+	if expired(e.stored, config.TTL) {
+		c.remove(element)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return e.value, true
+}
+
+// store caches the value stored at the given time in memory and evicts the least recently used values exceeding the budget. The stored value
+// itself is never evicted, even when it alone exceeds the memory budget.
+func (c *Cache[V]) store(key string, value V, stored time.Time, config Config) {
+	size := 0
+	if config.MaxBytes > 0 {
+		size = valueSize(value)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&entry[V]{key: key, value: value, size: size, stored: stored})
+	c.size += size
+	for c.order.Len() > 1 && ((config.MaxEntries > 0 && c.order.Len() > config.MaxEntries) || (config.MaxBytes > 0 && c.size > config.MaxBytes)) {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache[V]) remove(element *list.Element) {
+	e := c.order.Remove(element).(*entry[V])
+	delete(c.entries, e.key)
+	c.size -= e.size
+}
+
+// path returns the disk file of key, or an empty string when the key would escape the disk directory
+func (c *Cache[V]) path(dir, key string) string {
+	if c.opts.Path == nil {
+		sum := sha256.Sum256([]byte(key))
+		return filepath.Join(dir, hex.EncodeToString(sum[:])+".json")
+	}
+	path := filepath.FromSlash(c.opts.Path(key))
+	if !filepath.IsLocal(path) {
+		return ""
+	}
+	return filepath.Join(dir, path)
+}
+
+// readDisk returns the value of key from disk and when it was stored, unreadable and expired entries are treated as
+// misses
+func (c *Cache[V]) readDisk(key string, config Config) (V, time.Time, bool) {
+	var value V
+	path := c.path(config.Dir, key)
+	if path == "" {
+		return value, time.Time{}, false
+	}
+	info, err := os.Stat(path)
+	if err != nil || expired(info.ModTime(), config.TTL) {
+		return value, time.Time{}, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return value, time.Time{}, false
+	}
+	if err = json.Unmarshal(content, &value); err != nil {
+		return value, time.Time{}, false
+	}
+	return value, info.ModTime(), true
+}
+
+// writeDisk stores the value atomically, so concurrent server processes never read a partially written entry
+func (c *Cache[V]) writeDisk(key string, value V, config Config) error {
+	path := c.path(config.Dir, key)
+	if path == "" {
+		return fmt.Errorf("invalid cache key %q", key)
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
+
+func expired(stored time.Time, ttl time.Duration) bool {
+	return ttl > 0 && time.Since(stored) >= ttl
+}
+
+// valueSize approximates the memory held by a value with its serialized size
+func valueSize(value any) int {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return 0
+	}
+	return len(content)
+}
+
+// lookupEnv returns the value of the first environment variable that is set
+func lookupEnv(names ...string) string {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
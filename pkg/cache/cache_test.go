@@ -0,0 +1,244 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testValue struct {
+	Name string `json:"name"`
+}
+
+func newTestCache(t *testing.T, opts Options) *Cache[*testValue] {
+	if opts.Name == "" {
+		opts.Name = "test"
+	}
+	t.Setenv(DirEnv, t.TempDir())
+	return New[*testValue](opts)
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newTestCache(t, Options{MaxEntries: 2})
+
+	require.NoError(t, c.Put("a", &testValue{Name: "a"}))
+	require.NoError(t, c.Put("b", &testValue{Name: "b"}))
+	_, _, ok := c.Get("a")
+	require.True(t, ok)
+	require.NoError(t, c.Put("c", &testValue{Name: "c"}))
+
+	assert.Equal(t, 2, c.Len())
+	_, _, ok = c.Get("b")
+	assert.False(t, ok, "least recently used value should be evicted")
+	value, source, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, SourceMemory, source)
+	assert.Equal(t, "a", value.Name)
+	_, _, ok = c.Get("c")
+	assert.True(t, ok)
+}
+
+func TestCache_MemoryBudget(t *testing.T) {
+	c := newTestCache(t, Options{})
+	t.Setenv("CACHE_TEST_MAX_MB", "1")
+	large := func() *testValue {
+		return &testValue{Name: strings.Repeat("x", 700<<10)}
+	}
+
+	require.NoError(t, c.Put("a", large()))
+	require.NoError(t, c.Put("b", large()))
+	assert.Equal(t, 1, c.Len())
+	_, _, ok := c.Get("b")
+	assert.True(t, ok, "the value just stored is kept even when it exceeds the budget")
+}
+
+func TestCache_PutReplacesEntry(t *testing.T) {
+	c := newTestCache(t, Options{})
+	first, second := &testValue{Name: "first"}, &testValue{Name: "second"}
+
+	require.NoError(t, c.Put("a", first))
+	require.NoError(t, c.Put("a", second))
+
+	assert.Equal(t, 1, c.Len())
+	cached, _, ok := c.Get("a")
+	require.True(t, ok)
+	assert.Same(t, second, cached)
+}
+
+func TestCache_TTL(t *testing.T) {
+	c := newTestCache(t, Options{Backend: BackendDisk, TTL: 50 * time.Millisecond})
+
+	require.NoError(t, c.Put("a", &testValue{Name: "a"}))
+	_, _, ok := c.Get("a")
+	require.True(t, ok)
+
+	time.Sleep(60 * time.Millisecond)
+	_, _, ok = c.Get("a")
+	assert.False(t, ok, "expired values must not be served from memory or disk")
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_DiskSurvivesRestart(t *testing.T) {
+	c := newTestCache(t, Options{Backend: BackendDisk})
+	require.NoError(t, c.Put("a", &testValue{Name: "a"}))
+
+	// a new process only has the disk cache
+	restarted := New[*testValue](c.opts)
+	value, source, ok := restarted.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, SourceDisk, source)
+	assert.Equal(t, "a", value.Name)
+	_, source, _ = restarted.Get("a")
+	assert.Equal(t, SourceMemory, source)
+}
+
+func TestCache_CorruptedDiskEntryIsAMiss(t *testing.T) {
+	c := newTestCache(t, Options{Backend: BackendDisk, Path: func(key string) string { return key + ".json" }})
+	dir := c.Config().Dir
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte("{not json"), 0644))
+
+	_, _, ok := c.Get("a")
+	assert.False(t, ok)
+}
+
+func TestCache_PathOutsideDirectory(t *testing.T) {
+	c := newTestCache(t, Options{Backend: BackendDisk, Path: func(key string) string { return key + ".json" }})
+
+	err := c.Put("../escape", &testValue{Name: "a"})
+	require.Error(t, err)
+	_, err = os.Stat(filepath.Join(filepath.Dir(c.Config().Dir), "escape.json"))
+	assert.True(t, os.IsNotExist(err))
+	_, _, ok := c.Get("../escape")
+	assert.True(t, ok, "the value is still cached in memory")
+}
+
+func TestCache_BackendOff(t *testing.T) {
+	c := newTestCache(t, Options{Backend: BackendDisk})
+	t.Setenv("CACHE_TEST_BACKEND", BackendOff)
+
+	require.NoError(t, c.Put("a", &testValue{Name: "a"}))
+	_, _, ok := c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_Config(t *testing.T) {
+	root := t.TempDir()
+	tests := []struct {
+		name     string
+		opts     Options
+		env      map[string]string
+		expected Config
+	}{
+		{
+			name:     "defaults",
+			opts:     Options{Name: "test", MaxEntries: 8},
+			env:      map[string]string{DirEnv: root},
+			expected: Config{Backend: BackendMemory, MaxEntries: 8},
+		},
+		{
+			name:     "disk backend under the root directory",
+			opts:     Options{Name: "test", Backend: BackendDisk, DirName: "values"},
+			env:      map[string]string{DirEnv: root},
+			expected: Config{Backend: BackendDisk, Dir: filepath.Join(root, "values")},
+		},
+		{
+			name: "generic environment variables",
+			opts: Options{Name: "my_test", MaxEntries: 8},
+			env: map[string]string{
+				DirEnv:                      root,
+				"CACHE_MY_TEST_BACKEND":     BackendDisk,
+				"CACHE_MY_TEST_MAX_ENTRIES": "3",
+				"CACHE_MY_TEST_MAX_MB":      "2",
+				"CACHE_MY_TEST_TTL":         "30m",
+			},
+			expected: Config{Backend: BackendDisk, MaxEntries: 3, MaxBytes: 2 << 20, TTL: 30 * time.Minute, Dir: filepath.Join(root, "my_test")},
+		},
+		{
+			name: "package specific environment variables",
+			opts: Options{Name: "test", Backend: BackendDisk, MaxEntries: 8, DirEnv: "TEST_DIR", MaxEntriesEnv: "TEST_ENTRIES", MaxMBEnv: "TEST_MB"},
+			env: map[string]string{
+				"TEST_DIR":     filepath.Join(root, "legacy"),
+				"TEST_ENTRIES": "5",
+				"TEST_MB":      "1",
+			},
+			expected: Config{Backend: BackendDisk, MaxEntries: 5, MaxBytes: 1 << 20, Dir: filepath.Join(root, "legacy", "test")},
+		},
+		{
+			name: "generic environment variables take precedence",
+			opts: Options{Name: "test", MaxEntries: 8, MaxEntriesEnv: "TEST_ENTRIES"},
+			env: map[string]string{
+				"TEST_ENTRIES":           "5",
+				"CACHE_TEST_MAX_ENTRIES": "6",
+			},
+			expected: Config{Backend: BackendMemory, MaxEntries: 6},
+		},
+		{
+			name:     "invalid values are ignored",
+			opts:     Options{Name: "test", MaxEntries: 8, TTL: time.Minute},
+			env:      map[string]string{"CACHE_TEST_BACKEND": "redis", "CACHE_TEST_MAX_ENTRIES": "none", "CACHE_TEST_TTL": "soon"},
+			expected: Config{Backend: BackendMemory, MaxEntries: 8, TTL: time.Minute},
+		},
+		{
+			name:     "disk disabled by the root directory",
+			opts:     Options{Name: "test", Backend: BackendDisk},
+			env:      map[string]string{DirEnv: "off"},
+			expected: Config{Backend: BackendMemory},
+		},
+		{
+			name:     "disk disabled by the package specific directory",
+			opts:     Options{Name: "test", Backend: BackendDisk, DirEnv: "TEST_DIR"},
+			env:      map[string]string{DirEnv: root, "TEST_DIR": "off"},
+			expected: Config{Backend: BackendMemory},
+		},
+		{
+			name:     "memory only cache",
+			opts:     Options{Name: "test", MemoryOnly: true},
+			env:      map[string]string{DirEnv: root, "CACHE_TEST_BACKEND": BackendDisk},
+			expected: Config{Backend: BackendMemory},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			assert.Equal(t, tt.expected, New[*testValue](tt.opts).Config())
+		})
+	}
+}
+
+func TestCache_Clear(t *testing.T) {
+	c := newTestCache(t, Options{Backend: BackendDisk, Path: func(key string) string { return key + ".json" }})
+	require.NoError(t, c.Put("a", &testValue{Name: "a"}))
+	require.NoError(t, c.Put("nested/b", &testValue{Name: "b"}))
+	dir := c.Config().Dir
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not a cache entry"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(dir), "other.json"), []byte("{}"), 0644))
+
+	entries, files, err := c.Clear()
+	require.NoError(t, err)
+	assert.Equal(t, 2, entries)
+	assert.Equal(t, 2, files)
+	assert.Equal(t, 0, c.Len())
+	_, _, ok := c.Get("nested/b")
+	assert.False(t, ok)
+	_, err = os.Stat(filepath.Join(dir, "README"))
+	assert.NoError(t, err, "files that aren't cache entries are kept")
+	_, err = os.Stat(filepath.Join(filepath.Dir(dir), "other.json"))
+	assert.NoError(t, err, "files outside of the directory of the cache are kept")
+}
+
+func TestCache_ClearWithoutDiskDirectory(t *testing.T) {
+	c := newTestCache(t, Options{Backend: BackendDisk})
+	entries, files, err := c.Clear()
+	require.NoError(t, err)
+	assert.Zero(t, entries)
+	assert.Zero(t, files)
+}
@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// registered is the part of a cache used to list and clear it by name, whatever the type of its values
+type registered interface {
+	Name() string
+	Description() string
+	Config() Config
+	Len() int
+	Clear() (int, int, error)
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]registered)
+)
+
+// Register makes the cache listed by List and cleared by Clear, it returns the cache so package-level caches can be
+// registered where they're declared. Registering another cache with the same name replaces it.
+func Register[V any](c *Cache[V]) *Cache[V] {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[c.Name()] = c
+	return c
+}
+
+// Description returns what the cache holds
+func (c *Cache[V]) Description() string {
+	return c.opts.Description
+}
+
+// Info describes a registered cache and its current configuration
+type Info struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Backend     string `json:"backend"`
+	// Entries is the number of values in memory
+	Entries    int    `json:"entries"`
+	MaxEntries int    `json:"max_entries,omitempty"`
+	MaxBytes   int    `json:"max_bytes,omitempty"`
+	TTL        string `json:"ttl,omitempty"`
+	Dir        string `json:"dir,omitempty"`
+}
+
+// ClearResult is what Clear dropped from a cache
+type ClearResult struct {
+	Name    string `json:"name"`
+	Entries int    `json:"entries"`
+	Files   int    `json:"files"`
+}
+
+// List describes the registered caches sorted by name
+func List() []Info {
+	var infos []Info
+	for _, c := range registeredCaches() {
+		config := c.Config()
+		info := Info{
+			Name:        c.Name(),
+			Description: c.Description(),
+			Backend:     config.Backend,
+			Entries:     c.Len(),
+			MaxEntries:  config.MaxEntries,
+			MaxBytes:    config.MaxBytes,
+			Dir:         config.Dir,
+		}
+		if config.TTL > 0 {
+			info.TTL = config.TTL.String()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Clear drops the values of the named caches, or of every registered cache when no name is given
+func Clear(names ...string) ([]ClearResult, error) {
+	caches := registeredCaches()
+	if len(names) > 0 {
+		byName := make(map[string]registered, len(caches))
+		known := make([]string, 0, len(caches))
+		for _, c := range caches {
+			byName[c.Name()] = c
+			known = append(known, c.Name())
+		}
+		caches = caches[:0]
+		for _, name := range names {
+			c, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown cache %q, known caches are: %s", name, strings.Join(known, ", "))
+			}
+			caches = append(caches, c)
+		}
+	}
+	var results []ClearResult
+	for _, c := range caches {
+		entries, files, err := c.Clear()
+		if err != nil {
+			return results, fmt.Errorf("failed to clear cache %s: %w", c.Name(), err)
+		}
+		results = append(results, ClearResult{Name: c.Name(), Entries: entries, Files: files})
+	}
+	return results, nil
+}
+
+// Names returns the names of the registered caches sorted
+func Names() []string {
+	caches := registeredCaches()
+	names := make([]string, 0, len(caches))
+	for _, c := range caches {
+		names = append(names, c.Name())
+	}
+	return names
+}
+
+func registeredCaches() []registered {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	caches := make([]registered, 0, len(registry))
+	for _, c := range registry {
+		caches = append(caches, c)
+	}
+	sort.Slice(caches, func(i, j int) bool {
+		return caches[i].Name() < caches[j].Name()
+	})
+	return caches
+}
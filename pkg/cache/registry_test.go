@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Setenv(DirEnv, t.TempDir())
+	stubs := gostub.Stub(&registry, make(map[string]registered))
+	defer stubs.Reset()
+	schemas := Register(New[string](Options{Name: "schemas", Description: "Schemas", Backend: BackendDisk, MaxEntries: 8}))
+	lookups := Register(New[int](Options{Name: "lookups", Description: "Lookups", TTL: time.Minute}))
+	require.NoError(t, schemas.Put("a", "schema"))
+	require.NoError(t, lookups.Put("a", 1))
+	require.NoError(t, lookups.Put("b", 2))
+
+	infos := List()
+	require.Len(t, infos, 2)
+	assert.Equal(t, Info{Name: "lookups", Description: "Lookups", Backend: BackendMemory, Entries: 2, TTL: "1m0s"}, infos[0])
+	assert.Equal(t, "schemas", infos[1].Name)
+	assert.Equal(t, BackendDisk, infos[1].Backend)
+	assert.Equal(t, 1, infos[1].Entries)
+	assert.NotEmpty(t, infos[1].Dir)
+
+	results, err := Clear("lookups")
+	require.NoError(t, err)
+	assert.Equal(t, []ClearResult{{Name: "lookups", Entries: 2}}, results)
+	assert.Equal(t, 1, schemas.Len())
+
+	_, err = Clear("unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown cache "unknown", known caches are: lookups, schemas`)
+
+	results, err = Clear()
+	require.NoError(t, err)
+	assert.Equal(t, []ClearResult{{Name: "lookups"}, {Name: "schemas", Entries: 1, Files: 1}}, results)
+	assert.Equal(t, 0, schemas.Len())
+}
@@ -2,6 +2,7 @@ package conftest
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/spf13/afero"
 )
@@ -16,6 +17,40 @@ var predefinedPolicyConfigs = map[string][]string{
 	"all":    {"git::https://github.com/Azure/policy-library-avm.git//policy/Azure-Proactive-Resiliency-Library-v2", "git::https://github.com/Azure/policy-library-avm.git//policy/avmsec"},
 }
 
+// defaultPolicyLibrary is the policy library scanned when neither an alias nor policy URLs are set
+const defaultPolicyLibrary = "all"
+
+var policyLibraryDescriptions = map[string]string{
+	"aprl":   "Azure Proactive Resiliency Library v2 policies, checking the reliability recommendations of Azure resources",
+	"avmsec": "Azure Verified Modules security policies",
+	"all":    "Both the APRL and the AVM security policies",
+}
+
+// PolicyLibrary is a predefined policy library, selected with its alias in predefined_policy_library_alias
+type PolicyLibrary struct {
+	Alias       string   `json:"alias"`
+	Description string   `json:"description"`
+	URLs        []string `json:"urls"`
+	Default     bool     `json:"default,omitempty"`
+}
+
+// PolicyLibraries returns the predefined policy libraries sorted by alias
+func PolicyLibraries() []PolicyLibrary {
+	libraries := make([]PolicyLibrary, 0, len(predefinedPolicyConfigs))
+	for alias, urls := range predefinedPolicyConfigs {
+		libraries = append(libraries, PolicyLibrary{
+			Alias:       alias,
+			Description: policyLibraryDescriptions[alias],
+			URLs:        urls,
+			Default:     alias == defaultPolicyLibrary,
+		})
+	}
+	sort.Slice(libraries, func(i, j int) bool {
+		return libraries[i].Alias < libraries[j].Alias
+	})
+	return libraries
+}
+
 // resolvePolicyUrls resolves policy URLs based on predefined alias or custom URLs
 func resolvePolicyUrls(predefinedAlias string, customUrls []string) ([]string, error) {
 	// Check for mutually exclusive parameters
@@ -38,5 +73,5 @@ func resolvePolicyUrls(predefinedAlias string, customUrls []string) ([]string, e
 	}
 
 	// Default to "all" when both are empty
-	return predefinedPolicyConfigs["all"], nil
+	return predefinedPolicyConfigs[defaultPolicyLibrary], nil
 }
@@ -100,3 +100,15 @@ func TestResolvePolicyUrls(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicyLibraries(t *testing.T) {
+	libraries := PolicyLibraries()
+	aliases := make([]string, 0, len(libraries))
+	for _, library := range libraries {
+		aliases = append(aliases, library.Alias)
+		assert.NotEmpty(t, library.Description)
+		assert.Equal(t, predefinedPolicyConfigs[library.Alias], library.URLs)
+		assert.Equal(t, library.Alias == "all", library.Default)
+	}
+	assert.Equal(t, []string{"all", "aprl", "avmsec"}, aliases)
+}
@@ -14,24 +14,27 @@ import (
 	"time"
 
 	getter "github.com/hashicorp/go-getter/v2"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
 	"github.com/spf13/afero"
 )
 
 // CommandExecutor interface for executing system commands (following tflint pattern)
 type CommandExecutor interface {
-	ExecuteCommand(dir, command string) (stdout, stderr string, err error)
+	ExecuteCommand(ctx context.Context, dir, command string) (stdout, stderr string, err error)
 }
 
-// RealCommandExecutor implements CommandExecutor using exec.Command
+// RealCommandExecutor implements CommandExecutor using exec.CommandContext, the command is killed when ctx is done
 type RealCommandExecutor struct{}
 
-func (r *RealCommandExecutor) ExecuteCommand(dir, command string) (stdout, stderr string, err error) {
+func (r *RealCommandExecutor) ExecuteCommand(ctx context.Context, dir, command string) (stdout, stderr string, err error) {
 	parts := strings.Fields(command)
 	if len(parts) == 0 {
 		return "", "", fmt.Errorf("empty command")
 	}
 
-	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
 	cmd.Dir = dir
 
 	stdoutBytes, err := cmd.Output()
@@ -50,13 +53,13 @@ var commandExecutor CommandExecutor = &RealCommandExecutor{}
 
 // PolicyDownloader interface for downloading policy sources (following tflint pattern)
 type PolicyDownloader interface {
-	DownloadPolicy(url, destDir string) error
+	DownloadPolicy(ctx context.Context, url, destDir string) error
 }
 
 // RealPolicyDownloader implements PolicyDownloader using go-getter
 type RealPolicyDownloader struct{}
 
-func (r *RealPolicyDownloader) DownloadPolicy(url, destDir string) error {
+func (r *RealPolicyDownloader) DownloadPolicy(ctx context.Context, url, destDir string) error {
 	// Apply timeout with env var override (default 60s, override via CONFTEST_POLICY_DOWNLOAD_TIMEOUT_SECONDS)
 	timeout := 60 * time.Second
 	if v := os.Getenv("CONFTEST_POLICY_DOWNLOAD_TIMEOUT_SECONDS"); v != "" {
@@ -65,7 +68,7 @@ func (r *RealPolicyDownloader) DownloadPolicy(url, destDir string) error {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Use go-getter to download to the destination directory
@@ -78,7 +81,84 @@ func (r *RealPolicyDownloader) DownloadPolicy(url, destDir string) error {
 }
 
 // Global policy downloader for testing (following tflint pattern)
-var policyDownloader PolicyDownloader = &RealPolicyDownloader{}
+var policyDownloader PolicyDownloader = &cachingPolicyDownloader{base: &RealPolicyDownloader{}}
+
+// policyCache holds the files of the downloaded policy sources, keyed by source URL
+var policyCache = cache.Register(newPolicyCache())
+
+func newPolicyCache() *cache.Cache[map[string][]byte] {
+	return cache.New[map[string][]byte](cache.Options{
+		Name:        "conftest_policy",
+		Description: "Downloaded Conftest policy sources, keyed by source URL",
+		Backend:     cache.BackendDisk,
+		MaxEntries:  32,
+		TTL:         time.Hour,
+	})
+}
+
+// cachingPolicyDownloader restores the policy sources downloaded in the last hour from policyCache instead of downloading them again
+type cachingPolicyDownloader struct {
+	base PolicyDownloader
+}
+
+func (c *cachingPolicyDownloader) DownloadPolicy(ctx context.Context, url, destDir string) error {
+	files, _, ok := policyCache.Get(url)
+	telemetry.RecordCacheLookup("conftest_policy", ok)
+	if ok {
+		return restorePolicyFiles(destDir, files)
+	}
+	if err := c.base.DownloadPolicy(ctx, url, destDir); err != nil {
+		return err
+	}
+	files, err := snapshotPolicyFiles(destDir)
+	if err != nil {
+		// The cache is an optimization, the download itself succeeded
+		return nil
+	}
+	_ = policyCache.Put(url, files)
+	return nil
+}
+
+// snapshotPolicyFiles reads the files under path, keyed by their slash separated path relative to it, VCS metadata is skipped
+func snapshotPolicyFiles(path string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := afero.Walk(fs, path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		content, err := afero.ReadFile(fs, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	return files, err
+}
+
+// restorePolicyFiles writes the files captured by snapshotPolicyFiles back under path
+func restorePolicyFiles(path string, files map[string][]byte) error {
+	for rel, content := range files {
+		dest := filepath.Join(path, filepath.FromSlash(rel))
+		if err := fs.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("failed to restore cached policy %s: %w", rel, err)
+		}
+		if err := afero.WriteFile(fs, dest, content, 0644); err != nil {
+			return fmt.Errorf("failed to restore cached policy %s: %w", rel, err)
+		}
+	}
+	return nil
+}
 
 // validateTargetFile validates that the target file exists and is a file
 func validateTargetFile(targetFile string) error {
@@ -122,8 +202,8 @@ func buildConftestCommand(targetFile string, policySources []PolicySource, names
 }
 
 // executeConftestScan executes the conftest command and returns the output
-func executeConftestScan(workingDir, command string) (string, error) {
-	stdout, stderr, err := commandExecutor.ExecuteCommand(workingDir, command)
+func executeConftestScan(ctx context.Context, workingDir, command string) (string, error) {
+	stdout, stderr, err := commandExecutor.ExecuteCommand(ctx, workingDir, command)
 	if err != nil {
 		// Conftest may exit with non-zero status when violations are found, but still provide valid output
 		if stdout != "" {
@@ -320,7 +400,7 @@ func resolvePredefinedPolicyLibrary(alias string) ([]string, error) {
 }
 
 // downloadPolicySource downloads a policy source from a URL and returns a PolicySource
-func downloadPolicySource(url, tempDir string) (*PolicySource, error) {
+func downloadPolicySource(ctx context.Context, url, tempDir string) (*PolicySource, error) {
 	// Create a unique subdirectory for this policy source
 	policyDir, err := afero.TempDir(fs, tempDir, fmt.Sprintf("policy-%d", rand.Int63()))
 	if err != nil {
@@ -328,7 +408,7 @@ func downloadPolicySource(url, tempDir string) (*PolicySource, error) {
 	}
 
 	// Download the policy source using go-getter
-	if err := downloadPolicyToDirectory(url, policyDir); err != nil {
+	if err := downloadPolicyToDirectory(ctx, url, policyDir); err != nil {
 		return nil, fmt.Errorf("failed to download policy from %s: %w", url, err)
 	}
 
@@ -347,8 +427,8 @@ func downloadPolicySource(url, tempDir string) (*PolicySource, error) {
 }
 
 // downloadPolicyToDirectory downloads a policy source to a directory using go-getter
-func downloadPolicyToDirectory(url, destDir string) error {
-	return policyDownloader.DownloadPolicy(url, destDir)
+func downloadPolicyToDirectory(ctx context.Context, url, destDir string) error {
+	return policyDownloader.DownloadPolicy(ctx, url, destDir)
 }
 
 // countPolicyFiles counts the number of .rego files in a directory recursively
@@ -370,7 +450,7 @@ func countPolicyFiles(dir string) (int, error) {
 }
 
 // downloadDefaultAVMExceptions downloads the default AVM exceptions from the Azure policy library
-func downloadDefaultAVMExceptions(tempDir string) (*PolicySource, error) {
+func downloadDefaultAVMExceptions(ctx context.Context, tempDir string) (*PolicySource, error) {
 	const defaultAVMExceptionsURL = "https://raw.githubusercontent.com/Azure/policy-library-avm/refs/heads/main/policy/avmsec/avm_exceptions.rego.bak"
 	const exceptionsFileName = "avmsec_exceptions.rego"
 
@@ -383,7 +463,7 @@ func downloadDefaultAVMExceptions(tempDir string) (*PolicySource, error) {
 
 	// Download the exceptions file directly using go-getter
 	exceptionsFilePath := filepath.Join(exceptionsDir, exceptionsFileName)
-	if err := downloadPolicyToDirectory(defaultAVMExceptionsURL, exceptionsFilePath); err != nil {
+	if err := downloadPolicyToDirectory(ctx, defaultAVMExceptionsURL, exceptionsFilePath); err != nil {
 		return nil, fmt.Errorf("failed to download default AVM exceptions from %s: %w", defaultAVMExceptionsURL, err)
 	}
 
@@ -396,7 +476,7 @@ func downloadDefaultAVMExceptions(tempDir string) (*PolicySource, error) {
 }
 
 // resolvePolicySources resolves predefined policy aliases and creates policy sources
-func resolvePolicySources(param ScanParam, tempDir string) ([]PolicySource, error) {
+func resolvePolicySources(ctx context.Context, param ScanParam, tempDir string) ([]PolicySource, error) {
 	var allUrls []string
 
 	// First, process predefined policy libraries if specified
@@ -425,7 +505,7 @@ func resolvePolicySources(param ScanParam, tempDir string) ([]PolicySource, erro
 	// Download and create policy sources
 	var policySources []PolicySource
 	for _, url := range allUrls {
-		source, err := downloadPolicySource(url, tempDir)
+		source, err := downloadPolicySource(ctx, url, tempDir)
 		if err != nil {
 			return nil, fmt.Errorf("failed to download policy source %s: %w", url, err)
 		}
@@ -434,7 +514,7 @@ func resolvePolicySources(param ScanParam, tempDir string) ([]PolicySource, erro
 
 	// Handle default AVM exceptions if requested
 	if param.IncludeDefaultAVMExceptions {
-		defaultExceptionsSource, err := downloadDefaultAVMExceptions(tempDir)
+		defaultExceptionsSource, err := downloadDefaultAVMExceptions(ctx, tempDir)
 		if err != nil {
 			return nil, fmt.Errorf("failed to download default AVM exceptions: %w", err)
 		}
@@ -464,7 +544,14 @@ func resolvePolicySources(param ScanParam, tempDir string) ([]PolicySource, erro
 }
 
 // Scan performs a conftest scan with the given parameters
-func Scan(param ScanParam) (*ScanResult, error) {
+func Scan(ctx context.Context, param ScanParam) (*ScanResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "conftest.scan")
+	result, err := scan(ctx, param)
+	telemetry.EndSpan(span, err)
+	return result, err
+}
+
+func scan(ctx context.Context, param ScanParam) (*ScanResult, error) {
 	// Validate parameters
 	if err := param.Validate(); err != nil {
 		return nil, fmt.Errorf("parameter validation failed: %w", err)
@@ -482,8 +569,14 @@ func Scan(param ScanParam) (*ScanResult, error) {
 	}
 	defer fs.RemoveAll(tempDir) // Ensure cleanup
 
+	logger := logging.FromContext(ctx).With("target", param.TargetFile)
+	logger.InfoContext(ctx, "conftest scan started")
+
 	// Resolve and prepare policy sources
-	policySources, err := resolvePolicySources(param, tempDir)
+	logger.InfoContext(ctx, "downloading policies", "policy_library", param.PreDefinedPolicyLibraryAlias, "policy_urls", len(param.PolicyUrls))
+	policySources, err := telemetry.Trace(ctx, "conftest.download_policies", func(ctx context.Context) ([]PolicySource, error) {
+		return resolvePolicySources(ctx, param, tempDir)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("policy source resolution failed: %w", err)
 	}
@@ -492,8 +585,12 @@ func Scan(param ScanParam) (*ScanResult, error) {
 	command := buildConftestCommand(param.TargetFile, policySources, param.Namespaces)
 
 	// Execute conftest scan
-	output, err := executeConftestScan("", command)
+	logger.InfoContext(ctx, "running conftest", "policy_sources", len(policySources))
+	output, err := telemetry.TraceCommand(ctx, "conftest.run", "", command, func(ctx context.Context) (string, error) {
+		return executeConftestScan(ctx, "", command)
+	})
 	if err != nil {
+		logger.ErrorContext(ctx, "conftest failed", "error", err)
 		return nil, fmt.Errorf("conftest execution failed: %w", err)
 	}
 
@@ -503,6 +600,8 @@ func Scan(param ScanParam) (*ScanResult, error) {
 		return nil, fmt.Errorf("output parsing failed: %w", err)
 	}
 
+	logger.InfoContext(ctx, "conftest scan finished", "violations", len(violations), "warnings", len(warnings))
+
 	// Build result
 	result := &ScanResult{
 		Success:       true,
@@ -1,6 +1,7 @@
 package conftest
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -24,7 +25,7 @@ type MockCommandResult struct {
 	err    error
 }
 
-func (m *MockCommandExecutor) ExecuteCommand(dir, command string) (string, string, error) {
+func (m *MockCommandExecutor) ExecuteCommand(_ context.Context, dir, command string) (string, string, error) {
 	// First try exact match
 	result, exists := m.commands[command]
 	if exists {
@@ -53,7 +54,7 @@ type MockDownloadResult struct {
 	err error
 }
 
-func (m *MockPolicyDownloader) DownloadPolicy(url, destDir string) error {
+func (m *MockPolicyDownloader) DownloadPolicy(_ context.Context, url, destDir string) error {
 	// Check if there's a specific result for this URL
 	if m.downloads != nil {
 		if result, exists := m.downloads[url]; exists {
@@ -288,7 +289,7 @@ func TestExecuteConftestScan(t *testing.T) {
 			defer stubs.Reset()
 
 			// Execute
-			output, err := executeConftestScan("", tt.command)
+			output, err := executeConftestScan(context.Background(), "", tt.command)
 
 			// Assert
 			if tt.expectError {
@@ -560,7 +561,7 @@ deny[msg] {
 			defer downloaderStubs.Reset()
 
 			// Execute
-			result, err := Scan(tt.param)
+			result, err := Scan(context.Background(), tt.param)
 
 			// Assert
 			if tt.expectError {
@@ -734,7 +735,7 @@ func TestDownloadPolicy_Integration(t *testing.T) {
 	testURL := "git::https://github.com/Azure/policy-library-avm.git//policy"
 
 	// Execute the download
-	err = downloader.DownloadPolicy(testURL, tempDir)
+	err = downloader.DownloadPolicy(context.Background(), testURL, tempDir)
 
 	// Assertions
 	assert.NoError(t, err, "Policy download should succeed")
@@ -794,7 +795,7 @@ exception contains ["test_exception"] if {
 	defer func() { policyDownloader = originalPolicyDownloader }()
 
 	// Test the function
-	source, err := downloadDefaultAVMExceptions(tempDir)
+	source, err := downloadDefaultAVMExceptions(context.Background(), tempDir)
 
 	// Assertions
 	require.NoError(t, err)
@@ -846,7 +847,7 @@ func TestResolvePolicySources_WithDefaultAVMExceptions(t *testing.T) {
 	}
 
 	// Execute
-	sources, err := resolvePolicySources(param, tempDir)
+	sources, err := resolvePolicySources(context.Background(), param, tempDir)
 
 	// Assertions
 	require.NoError(t, err)
@@ -899,7 +900,7 @@ func TestResolvePolicySources_WithoutDefaultAVMExceptions(t *testing.T) {
 	}
 
 	// Execute
-	sources, err := resolvePolicySources(param, tempDir)
+	sources, err := resolvePolicySources(context.Background(), param, tempDir)
 
 	// Assertions
 	require.NoError(t, err)
@@ -910,3 +911,33 @@ func TestResolvePolicySources_WithoutDefaultAVMExceptions(t *testing.T) {
 		assert.NotEqual(t, "https://raw.githubusercontent.com/Azure/policy-library-avm/refs/heads/main/policy/avmsec/avm_exceptions.rego.bak", source.OriginalURL)
 	}
 }
+
+func TestCachingPolicyDownloader(t *testing.T) {
+	t.Setenv("CACHE_DIR", "off")
+	memFs := afero.NewMemMapFs()
+	downloads := 0
+	stubs := gostub.Stub(&fs, memFs).Stub(&policyCache, newPolicyCache())
+	defer stubs.Reset()
+	downloader := &cachingPolicyDownloader{base: &MockPolicyDownloader{
+		setupPolicyFiles: func(fs afero.Fs, destDir string, url string) error {
+			downloads++
+			require.NoError(t, fs.MkdirAll(filepath.Join(destDir, ".git"), 0755))
+			require.NoError(t, afero.WriteFile(fs, filepath.Join(destDir, ".git", "HEAD"), []byte("ref: refs/heads/main"), 0644))
+			require.NoError(t, fs.MkdirAll(filepath.Join(destDir, "nested"), 0755))
+			return afero.WriteFile(fs, filepath.Join(destDir, "nested", "sample.rego"), []byte("package test"), 0644)
+		},
+	}}
+
+	require.NoError(t, downloader.DownloadPolicy(context.Background(), "git::https://example.com/policies.git", "/first"))
+	require.NoError(t, downloader.DownloadPolicy(context.Background(), "git::https://example.com/policies.git", "/second"))
+	assert.Equal(t, 1, downloads)
+	content, err := afero.ReadFile(memFs, "/second/nested/sample.rego")
+	require.NoError(t, err)
+	assert.Equal(t, "package test", string(content))
+	exists, err := afero.Exists(memFs, "/second/.git/HEAD")
+	require.NoError(t, err)
+	assert.False(t, exists, "VCS metadata is not cached")
+
+	require.NoError(t, downloader.DownloadPolicy(context.Background(), "git::https://example.com/other.git", "/third"))
+	assert.Equal(t, 2, downloads)
+}
@@ -0,0 +1,147 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// AcceptanceTests is the acceptance test file of a terraform block in the upstream repository of its provider
+type AcceptanceTests struct {
+	BlockType     string `json:"block_type"`
+	TerraformType string `json:"terraform_type"`
+	// Path is the file path in the upstream repository, like `internal/services/network/subnet_resource_test.go`
+	Path string `json:"path"`
+	// Permalink is the GitHub URL of Source, pinned to the tag the file was read from
+	Permalink string `json:"permalink"`
+	// Tests are the test functions of the file, like `TestAccSubnet_basic`
+	Tests []string `json:"tests"`
+	// Source is the file content, or the source code of a test and the functions and methods of the file it calls
+	Source string `json:"source"`
+}
+
+// GetAcceptanceTests reads the acceptance tests of a terraform block from the upstream repository of its provider. The
+// index holds no tests, so the Go file declaring the block is located like GetGolangSourceCodeWithLocation and its
+// `_test.go` file is read, like `subnet_resource_test.go` for `subnet_resource.go`. When test is set, like
+// `TestAccSubnet_basic`, only the source code of the test and of the functions and methods of the file it calls,
+// transitively, is returned, like the configurations of its steps.
+func GetAcceptanceTests(ctx context.Context, providerName, blockType, terraformType, test, tag string) (*AcceptanceTests, error) {
+	if blockType != "resource" && blockType != "data" && blockType != "ephemeral" {
+		return nil, fmt.Errorf("invalid block type: %s, only resources, data sources and ephemeral resources have acceptance tests", blockType)
+	}
+	var entrypoint *terraformEntrypoint
+	// the entrypoints every block of the type implements, declared in the file of the block
+	for _, entrypointName := range []string{"schema", "create", "read", "open"} {
+		if _, ok := validEntrypoints[blockType][entrypointName]; !ok {
+			continue
+		}
+		e, err := readTerraformEntrypoint(ctx, providerName, blockType, terraformType, entrypointName, tag)
+		if errors.Is(err, NotFoundError) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entrypoint = e
+		break
+	}
+	if entrypoint == nil {
+		return nil, fmt.Errorf("%s %s has no entrypoint to locate its source file: %w", blockType, terraformType, NotFoundError)
+	}
+	remoteIndex, err := namespaceRemoteIndex(entrypoint.namespace)
+	if err != nil {
+		return nil, err
+	}
+	location, err := locateSource(ctx, remoteIndex, entrypoint.namespace, entrypoint.source, entrypoint.tag)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		return nil, fmt.Errorf("source file of %s %s not found in the upstream repository: %w", blockType, terraformType, NotFoundError)
+	}
+	owner, repo, err := upstreamRepository(remoteIndex)
+	if err != nil {
+		return nil, err
+	}
+	testPath := strings.TrimSuffix(location.Path, ".go") + "_test.go"
+	content, err := readURLContent(ctx, owner, repo, testPath, entrypoint.tag)
+	if errors.Is(err, NotFoundError) {
+		return nil, fmt.Errorf("%s %s has no acceptance tests in %s: %w", blockType, terraformType, testPath, NotFoundError)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s/%s/%s: %w", owner, repo, testPath, err)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, testPath, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", testPath, err)
+	}
+	result := &AcceptanceTests{BlockType: blockType, TerraformType: terraformType, Path: testPath, Tests: make([]string, 0)}
+	// functions and methods by name, test files declare the methods of one test resource type
+	declarations := make(map[string]*ast.FuncDecl)
+	for _, decl := range file.Decls {
+		function, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		if _, ok := declarations[function.Name.Name]; !ok {
+			declarations[function.Name.Name] = function
+		}
+		if function.Recv == nil && strings.HasPrefix(function.Name.Name, "Test") {
+			result.Tests = append(result.Tests, function.Name.Name)
+		}
+	}
+	if test == "" {
+		result.Source = string(content)
+		result.Permalink = permalink(owner, repo, testPath, entrypoint.tag, 1, strings.Count(strings.TrimSuffix(result.Source, "\n"), "\n")+1)
+		return result, nil
+	}
+	function, ok := declarations[test]
+	if !ok || function.Recv != nil {
+		return nil, fmt.Errorf("test %s not found in %s: %w", test, testPath, NotFoundError)
+	}
+	called := calledDeclarations(function, declarations)
+	var sources []string
+	for _, decl := range file.Decls {
+		if function, ok := decl.(*ast.FuncDecl); ok && called[function] {
+			sources = append(sources, string(content[fset.Position(function.Pos()).Offset:fset.Position(function.End()).Offset]))
+		}
+	}
+	result.Source = strings.Join(sources, "\n\n")
+	result.Permalink = permalink(owner, repo, testPath, entrypoint.tag, fset.Position(function.Pos()).Line, fset.Position(function.End()).Line)
+	return result, nil
+}
+
+// calledDeclarations returns function and the declarations it calls by name, like `r.basic(data)` or
+// `testAccVPCConfig_basic(rName)`, transitively
+func calledDeclarations(function *ast.FuncDecl, declarations map[string]*ast.FuncDecl) map[*ast.FuncDecl]bool {
+	called := map[*ast.FuncDecl]bool{function: true}
+	queue := []*ast.FuncDecl{function}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		ast.Inspect(current, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			var name string
+			switch fun := call.Fun.(type) {
+			case *ast.Ident:
+				name = fun.Name
+			case *ast.SelectorExpr:
+				name = fun.Sel.Name
+			}
+			if declaration, ok := declarations[name]; ok && !called[declaration] {
+				called[declaration] = true
+				queue = append(queue, declaration)
+			}
+			return true
+		})
+	}
+	return called
+}
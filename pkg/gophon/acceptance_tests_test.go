@@ -0,0 +1,87 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const subnetResourceTest = `package network_test
+
+func TestAccSubnet_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
+	r := SubnetResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+		},
+	})
+}
+
+func TestAccSubnet_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
+	r := SubnetResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.requiresImport(data),
+		},
+	})
+}
+
+func (r SubnetResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+%s
+
+resource "azurerm_subnet" "test" {
+  name = "internal"
+}
+` + "`" + `, r.template(data))
+}
+
+func (r SubnetResource) requiresImport(data acceptance.TestData) string {
+	return r.basic(data)
+}
+
+func (SubnetResource) template(data acceptance.TestData) string {
+	return "provider \"azurerm\" {}"
+}
+`
+
+func TestGetAcceptanceTests(t *testing.T) {
+	schema := "func resourceSubnet() *pluginsdk.Resource {\n\treturn nil\n}"
+	stubIndexFiles(t, map[string]string{
+		"index/resources/azurerm_subnet.json":                         `{"namespace": "github.com/hashicorp/terraform-provider-azurerm/internal/services/network", "schema_index": "func.resourceSubnet.goindex"}`,
+		"index/internal/services/network/func.resourceSubnet.goindex": schema,
+		"index/resources/azurerm_route.json":                          `{"namespace": "github.com/hashicorp/terraform-provider-azurerm/internal/services/network", "schema_index": "func.resourceRoute.goindex"}`,
+		"index/internal/services/network/func.resourceRoute.goindex":  "func resourceRoute() *pluginsdk.Resource {\n\treturn nil\n}",
+		"internal/services/network/route_resource.go":                 "package network\n\nfunc resourceRoute() *pluginsdk.Resource {\n\treturn nil\n}\n",
+		"internal/services/network/subnet_resource.go":                "package network\n\n" + schema + "\n",
+		"internal/services/network/subnet_resource_test.go":           subnetResourceTest,
+	})
+
+	result, err := GetAcceptanceTests(context.Background(), "", "resource", "azurerm_subnet", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "internal/services/network/subnet_resource_test.go", result.Path)
+	assert.Equal(t, []string{"TestAccSubnet_basic", "TestAccSubnet_requiresImport"}, result.Tests)
+	assert.Equal(t, subnetResourceTest, result.Source)
+	assert.Equal(t, "https://github.com/hashicorp/terraform-provider-azurerm/blob/HEAD/internal/services/network/subnet_resource_test.go#L1-L39", result.Permalink)
+
+	result, err = GetAcceptanceTests(context.Background(), "", "resource", "azurerm_subnet", "TestAccSubnet_requiresImport", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TestAccSubnet_basic", "TestAccSubnet_requiresImport"}, result.Tests)
+	assert.Contains(t, result.Source, "func TestAccSubnet_requiresImport(")
+	assert.Contains(t, result.Source, "func (r SubnetResource) requiresImport(")
+	assert.Contains(t, result.Source, "func (r SubnetResource) basic(")
+	assert.Contains(t, result.Source, "func (SubnetResource) template(")
+	assert.NotContains(t, result.Source, "func TestAccSubnet_basic(")
+	assert.Equal(t, "https://github.com/hashicorp/terraform-provider-azurerm/blob/HEAD/internal/services/network/subnet_resource_test.go#L13-L21", result.Permalink)
+
+	_, err = GetAcceptanceTests(context.Background(), "", "resource", "azurerm_subnet", "TestAccSubnet_complete", "")
+	assert.ErrorIs(t, err, NotFoundError)
+	_, err = GetAcceptanceTests(context.Background(), "", "resource", "azurerm_route", "", "")
+	assert.ErrorIs(t, err, NotFoundError)
+	_, err = GetAcceptanceTests(context.Background(), "", "function", "azurerm_route", "", "")
+	assert.Error(t, err)
+}
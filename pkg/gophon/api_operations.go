@@ -0,0 +1,151 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// apiEntrypoints are the entrypoints of a block calling APIs, in the order they're analyzed
+var apiEntrypoints = map[string][]string{
+	"resource":  {"create", "read", "update", "delete"},
+	"data":      {"read"},
+	"ephemeral": {"open", "renew", "close"},
+	"function":  {"run"},
+}
+
+// APIOperation is a method call on an SDK client, like `client.CreateOrUpdateThenPoll` of go-azure-sdk or
+// `conn.CreateVpc` of the AWS SDK
+type APIOperation struct {
+	// Client is the expression of the client, like `metadata.Client.ContainerApps.ContainerAppClient`
+	Client string `json:"client"`
+	Method string `json:"method"`
+	// Line is the 1-based line number of the call in the entrypoint's source code
+	Line int `json:"line"`
+}
+
+// ClientHelperCall is a call to a function with an SDK client argument, like `findVPCByID(ctx, conn, id)`, whose
+// source code holds more API operations
+type ClientHelperCall struct {
+	Function string `json:"function"`
+	Client   string `json:"client"`
+	Line     int    `json:"line"`
+}
+
+// EntrypointAPIOperations are the API operations of one entrypoint of a block
+type EntrypointAPIOperations struct {
+	Entrypoint string             `json:"entrypoint"`
+	Operations []APIOperation     `json:"operations"`
+	Helpers    []ClientHelperCall `json:"helpers,omitempty"`
+}
+
+// APIOperationsResult is the result of GetAPIOperations
+type APIOperationsResult struct {
+	BlockType     string                    `json:"block_type"`
+	TerraformType string                    `json:"terraform_type"`
+	Entrypoints   []EntrypointAPIOperations `json:"entrypoints"`
+}
+
+// GetAPIOperations reads the source code of the entrypoints of a terraform block and extracts the SDK client methods
+// it calls, answering which API operations the block makes. entrypoints default to the entrypoints calling APIs, like
+// create, read, update and delete for resources, the ones the block doesn't implement are skipped. Clients are found
+// by name: variables assigned from, and calls on, expressions ending in `Client` or `Conn`, like
+// `metadata.Client.Network.SubnetsClient` or `meta.(*conns.AWSClient).EC2Client(ctx)`.
+func GetAPIOperations(ctx context.Context, providerName, blockType, terraformType string, entrypoints []string, tag string) (*APIOperationsResult, error) {
+	if len(entrypoints) == 0 {
+		var ok bool
+		if entrypoints, ok = apiEntrypoints[blockType]; !ok {
+			return nil, fmt.Errorf("invalid block type: %s", blockType)
+		}
+	}
+	result := &APIOperationsResult{BlockType: blockType, TerraformType: terraformType, Entrypoints: make([]EntrypointAPIOperations, 0, len(entrypoints))}
+	for _, entrypoint := range entrypoints {
+		source, err := GetProviderTerraformSourceCode(ctx, providerName, blockType, terraformType, entrypoint, tag)
+		if errors.Is(err, NotFoundError) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		operations, helpers, err := extractAPIOperations(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze %s of %s %s: %w", entrypoint, blockType, terraformType, err)
+		}
+		result.Entrypoints = append(result.Entrypoints, EntrypointAPIOperations{Entrypoint: entrypoint, Operations: operations, Helpers: helpers})
+	}
+	return result, nil
+}
+
+// extractAPIOperations returns the client method calls and the calls with a client argument in the source code of a
+// func or method declaration, in source order
+func extractAPIOperations(source string) ([]APIOperation, []ClientHelperCall, error) {
+	declaration, err := parseDeclaration(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	clients := make(map[string]string)
+	operations := make([]APIOperation, 0)
+	var helpers []ClientHelperCall
+	ast.Inspect(declaration.file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && isClientExpr(n.Rhs[i]) {
+					clients[ident.Name] = types.ExprString(n.Rhs[i])
+				}
+			}
+		case *ast.CallExpr:
+			if selector, ok := n.Fun.(*ast.SelectorExpr); ok {
+				if client, ok := clientOf(selector.X, clients); ok {
+					operations = append(operations, APIOperation{Client: client, Method: selector.Sel.Name, Line: declaration.line(n.Pos())})
+					return true
+				}
+			}
+			for _, arg := range n.Args {
+				if client, ok := clientOf(arg, clients); ok {
+					helpers = append(helpers, ClientHelperCall{Function: types.ExprString(n.Fun), Client: client, Line: declaration.line(n.Pos())})
+					break
+				}
+			}
+		}
+		return true
+	})
+	return operations, helpers, nil
+}
+
+// clientOf returns the client expression of expr, a variable assigned a client or a client expression itself
+func clientOf(expr ast.Expr, clients map[string]string) (string, bool) {
+	if ident, ok := expr.(*ast.Ident); ok {
+		client, ok := clients[ident.Name]
+		return client, ok
+	}
+	if isClientExpr(expr) {
+		return types.ExprString(expr), true
+	}
+	return "", false
+}
+
+// isClientExpr returns true for selectors, or calls of selectors, ending in `Client` or `Conn` after a service name,
+// the bare `Client` of `metadata.Client` holds all clients and isn't one
+func isClientExpr(expr ast.Expr) bool {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		expr = call.Fun
+	}
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	name := selector.Sel.Name
+	for _, suffix := range []string{"Client", "Conn"} {
+		if strings.HasSuffix(name, suffix) && name != suffix {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractAPIOperations(t *testing.T) {
+	cases := []struct {
+		desc               string
+		source             string
+		expectedOperations []APIOperation
+		expectedHelpers    []ClientHelperCall
+	}{
+		{
+			desc: "typed resource",
+			source: `func (r ContainerAppResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerApps.ContainerAppClient
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := client.CreateOrUpdateThenPoll(ctx, id, containerApp); err != nil {
+				return err
+			}
+			return metadata.Encode(&app)
+		},
+	}
+}`,
+			expectedOperations: []APIOperation{
+				{Client: "metadata.Client.ContainerApps.ContainerAppClient", Method: "Get", Line: 5},
+				{Client: "metadata.Client.ContainerApps.ContainerAppClient", Method: "CreateOrUpdateThenPoll", Line: 9},
+			},
+		},
+		{
+			desc: "untyped resource",
+			source: `func resourceSubnetRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.SubnetsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	resp, err := client.Get(ctx, *id, subnets.DefaultGetOperationOptions())
+	vnet, err := meta.(*clients.Client).Network.VirtualNetworks.Get(ctx, vnetId)
+	future.WaitForCompletionRef(ctx, client.Client)
+	return nil
+}`,
+			expectedOperations: []APIOperation{
+				{Client: "meta.(*clients.Client).Network.SubnetsClient", Method: "Get", Line: 5},
+			},
+		},
+		{
+			desc: "aws sdk",
+			source: `func resourceVPCCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*conns.AWSClient).EC2Client(ctx)
+	output, err := conn.CreateVpc(ctx, input)
+	vpc, err := waitVPCCreated(ctx, conn, d.Id())
+	return append(diags, resourceVPCUpdate(ctx, d, meta)...)
+}`,
+			expectedOperations: []APIOperation{
+				{Client: "meta.(*conns.AWSClient).EC2Client(ctx)", Method: "CreateVpc", Line: 3},
+			},
+			expectedHelpers: []ClientHelperCall{
+				{Function: "waitVPCCreated", Client: "meta.(*conns.AWSClient).EC2Client(ctx)", Line: 4},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			operations, helpers, err := extractAPIOperations(c.source)
+			require.NoError(t, err)
+			assert.Equal(t, c.expectedOperations, operations)
+			assert.Equal(t, c.expectedHelpers, helpers)
+		})
+	}
+}
+
+func TestGetAPIOperations(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/resources/azurerm_subnet.json":                               `{"namespace": "github.com/hashicorp/terraform-provider-azurerm/internal/services/network", "create_index": "func.resourceSubnetCreate.goindex", "read_index": "func.resourceSubnetRead.goindex"}`,
+		"index/internal/services/network/func.resourceSubnetCreate.goindex": "func resourceSubnetCreate(d *pluginsdk.ResourceData, meta interface{}) error {\n\tclient := meta.(*clients.Client).Network.SubnetsClient\n\treturn client.CreateOrUpdateThenPoll(ctx, id, subnet)\n}",
+		"index/internal/services/network/func.resourceSubnetRead.goindex":   "func resourceSubnetRead(d *pluginsdk.ResourceData, meta interface{}) error {\n\tclient := meta.(*clients.Client).Network.SubnetsClient\n\t_, err := client.Get(ctx, id)\n\treturn err\n}",
+	})
+
+	result, err := GetAPIOperations(context.Background(), "", "resource", "azurerm_subnet", nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, &APIOperationsResult{
+		BlockType:     "resource",
+		TerraformType: "azurerm_subnet",
+		Entrypoints: []EntrypointAPIOperations{
+			{Entrypoint: "create", Operations: []APIOperation{{Client: "meta.(*clients.Client).Network.SubnetsClient", Method: "CreateOrUpdateThenPoll", Line: 3}}},
+			{Entrypoint: "read", Operations: []APIOperation{{Client: "meta.(*clients.Client).Network.SubnetsClient", Method: "Get", Line: 3}}},
+		},
+	}, result)
+
+	_, err = GetAPIOperations(context.Background(), "", "provider", "azurerm_subnet", nil, "")
+	assert.EqualError(t, err, "invalid block type: provider")
+}
@@ -0,0 +1,282 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxAttributeValidators caps the validator functions resolved for one attribute, every namespace tried is a request
+const maxAttributeValidators = 10
+
+// validationKeys are the schema fields holding the validation of an attribute, for plugin SDKv2 and
+// terraform-plugin-framework
+var validationKeys = map[string]struct{}{
+	"ValidateFunc":     {},
+	"ValidateDiagFunc": {},
+	"Validators":       {},
+}
+
+// Validator is a function referenced by the validation of an attribute
+type Validator struct {
+	// Function is the function as referenced in the schema, like `validate.ContainerAppName`
+	Function string `json:"function"`
+	// Namespace and Source are empty when the function isn't found in the index, like functions of an SDK
+	Namespace string `json:"namespace,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
+
+// AttributeValidators is the validation of a schema attribute
+type AttributeValidators struct {
+	Attribute string `json:"attribute"`
+	// Definition is the source code of the attribute schema
+	Definition string `json:"definition"`
+	// Validation are the validation expressions of the attribute, like
+	// `validation.StringInSlice([]string{"Basic", "Standard"}, false)`
+	Validation []string    `json:"validation"`
+	Validators []Validator `json:"validators"`
+}
+
+// GetAttributeValidators finds an attribute in the schema of a terraform block and returns its validation
+// expressions, with the source code of the functions they reference. attribute is a path of attribute names
+// separated by dots for nested blocks, like `ip_configuration.name`. Functions are looked up in the namespace of the
+// schema for unqualified names, and in the `<package>` sub-package of the namespace or of its parents for qualified
+// names, like `<namespace>/validate` for `validate.ContainerAppName`.
+func GetAttributeValidators(ctx context.Context, providerName, blockType, terraformType, attribute, tag string) (*AttributeValidators, error) {
+	if attribute == "" {
+		return nil, fmt.Errorf("attribute cannot be empty")
+	}
+	var entrypoint *terraformEntrypoint
+	var schema *attributeSchema
+	for _, entrypointName := range []string{"schema", "attribute"} {
+		if _, ok := validEntrypoints[blockType][entrypointName]; !ok {
+			continue
+		}
+		e, err := readTerraformEntrypoint(ctx, providerName, blockType, terraformType, entrypointName, tag)
+		if errors.Is(err, NotFoundError) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if schema, err = findAttributeSchema(e.source, attribute); err != nil {
+			return nil, fmt.Errorf("failed to parse %s of %s %s: %w", entrypointName, blockType, terraformType, err)
+		}
+		if schema != nil {
+			entrypoint = e
+			break
+		}
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("attribute %s not found in the schema of %s %s, it may be declared by a helper function: %w", attribute, blockType, terraformType, NotFoundError)
+	}
+	result := &AttributeValidators{
+		Attribute:  attribute,
+		Definition: schema.definition,
+		Validation: schema.validation,
+		Validators: make([]Validator, 0, len(schema.functions)),
+	}
+	for _, function := range schema.functions[:min(len(schema.functions), maxAttributeValidators)] {
+		validator, err := resolveValidator(ctx, entrypoint.namespace, function, entrypoint.tag)
+		if err != nil {
+			return nil, err
+		}
+		result.Validators = append(result.Validators, validator)
+	}
+	return result, nil
+}
+
+// resolveValidator reads the source code of function from the candidate namespaces, the first one declaring it wins
+func resolveValidator(ctx context.Context, namespace, function, tag string) (Validator, error) {
+	validator := Validator{Function: function}
+	pkg, name, qualified := strings.Cut(function, ".")
+	if !qualified {
+		pkg, name = "", function
+	}
+	remoteIndex, err := namespaceRemoteIndex(namespace)
+	if err != nil {
+		return validator, err
+	}
+	candidates := []string{namespace}
+	if qualified {
+		candidates = nil
+		for n := namespace; strings.HasPrefix(n, remoteIndex.PackagePath); n = path.Dir(n) {
+			candidates = append(candidates, n+"/"+pkg)
+		}
+	}
+	for _, candidate := range candidates {
+		// the parents of a namespace may not be indexed, like the module root
+		if _, err := namespaceRemoteIndex(candidate); err != nil {
+			continue
+		}
+		source, err := GetGolangSourceCode(ctx, candidate, "func", "", name, tag)
+		if errors.Is(err, NotFoundError) {
+			continue
+		}
+		if err != nil {
+			return validator, err
+		}
+		validator.Namespace, validator.Source = candidate, source
+		break
+	}
+	return validator, nil
+}
+
+// attributeSchema is an attribute found in the source code of a schema
+type attributeSchema struct {
+	definition string
+	validation []string
+	// functions are the functions referenced by the validation, in source order without duplicates
+	functions []string
+}
+
+// findAttributeSchema returns the schema of the attribute at attributePath in source, or nil when it's not found.
+// Attributes are the composite literals keyed by their name, like `"name": {` or `"name": schema.StringAttribute{`.
+func findAttributeSchema(source, attributePath string) (*attributeSchema, error) {
+	declaration, err := parseDeclaration(source)
+	if err != nil {
+		return nil, err
+	}
+	var node ast.Node = declaration.file
+	var literal *ast.CompositeLit
+	for _, name := range strings.Split(attributePath, ".") {
+		if literal = findKeyedLiteral(node, name); literal == nil {
+			return nil, nil
+		}
+		node = literal
+	}
+	schema := &attributeSchema{definition: declaration.text(literal)}
+	seen := make(map[string]struct{})
+	for _, expr := range validationExprs(literal) {
+		schema.validation = append(schema.validation, declaration.text(expr))
+		for _, function := range referencedFunctions(expr) {
+			if _, ok := seen[function]; !ok {
+				seen[function] = struct{}{}
+				schema.functions = append(schema.functions, function)
+			}
+		}
+	}
+	return schema, nil
+}
+
+// findKeyedLiteral returns the first composite literal under node keyed by the string name
+func findKeyedLiteral(node ast.Node, name string) *ast.CompositeLit {
+	var found *ast.CompositeLit
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+		key, ok := kv.Key.(*ast.BasicLit)
+		if !ok || key.Kind != token.STRING {
+			return true
+		}
+		if k, err := strconv.Unquote(key.Value); err != nil || k != name {
+			return true
+		}
+		if literal, ok := unwrapLiteral(kv.Value); ok {
+			found = literal
+		}
+		return found == nil
+	})
+	return found
+}
+
+// validationExprs returns the validation of an attribute schema, including the validation of the elements of a list
+// or set of primitives in `Elem`
+func validationExprs(literal *ast.CompositeLit) []ast.Expr {
+	var exprs []ast.Expr
+	for _, elt := range literal.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if _, ok := validationKeys[key.Name]; ok {
+			exprs = append(exprs, kv.Value)
+			continue
+		}
+		if elem, ok := unwrapLiteral(kv.Value); ok && key.Name == "Elem" && strings.HasSuffix(types.ExprString(elem.Type), "Schema") {
+			exprs = append(exprs, validationExprs(elem)...)
+		}
+	}
+	return exprs
+}
+
+// builtins are the predeclared identifiers that aren't validators, like the `string` conversion
+var builtins = map[string]struct{}{
+	"true":    {},
+	"false":   {},
+	"nil":     {},
+	"string":  {},
+	"int":     {},
+	"int64":   {},
+	"float64": {},
+	"bool":    {},
+	"len":     {},
+	"append":  {},
+}
+
+// referencedFunctions returns the functions called or passed as values in a validation expression, like
+// `validation.All` and `validate.ContainerAppName` in `validation.All(validate.ContainerAppName, ...)`
+func referencedFunctions(expr ast.Expr) []string {
+	var functions []string
+	var visit func(expr ast.Expr)
+	visit = func(expr ast.Expr) {
+		switch e := expr.(type) {
+		case *ast.CallExpr:
+			function, ok := functionName(e.Fun)
+			if !ok {
+				// conversions like `string(subnets.ServiceEndpointSql)` convert values
+				return
+			}
+			functions = append(functions, function)
+			for _, arg := range e.Args {
+				visit(arg)
+			}
+		case *ast.CompositeLit:
+			// the elements of a list of validators are calls, other elements are values like the accepted strings
+			for _, elt := range e.Elts {
+				if call, ok := elt.(*ast.CallExpr); ok {
+					visit(call)
+				}
+			}
+		case *ast.UnaryExpr:
+			visit(e.X)
+		default:
+			if function, ok := functionName(e); ok {
+				functions = append(functions, function)
+			}
+		}
+	}
+	visit(expr)
+	return functions
+}
+
+// functionName returns the name of a function referenced by an identifier or a package qualified identifier
+func functionName(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if _, ok := builtins[e.Name]; ok {
+			return "", false
+		}
+		return e.Name, true
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); ok {
+			return pkg.Name + "." + e.Sel.Name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,104 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const subnetSchema = `func resourceSubnet() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.SubnetName,
+			},
+
+			"service_endpoints": {
+				Type:     pluginsdk.TypeSet,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"Microsoft.Storage", string(subnets.ServiceEndpointSql)}, false),
+				},
+			},
+
+			"delegation": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.All(validateDelegationName, validation.StringIsNotEmpty),
+						},
+					},
+				},
+			},
+		},
+	}
+}`
+
+func TestGetAttributeValidators(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/resources/azurerm_subnet.json":                                 `{"namespace": "github.com/hashicorp/terraform-provider-azurerm/internal/services/network", "schema_index": "func.resourceSubnet.goindex"}`,
+		"index/internal/services/network/func.resourceSubnet.goindex":         subnetSchema,
+		"index/internal/services/network/validate/func.SubnetName.goindex":    "func SubnetName(v interface{}, k string) ([]string, []error) {}",
+		"index/internal/services/network/func.validateDelegationName.goindex": "func validateDelegationName(v interface{}, k string) ([]string, []error) {}",
+		"index/internal/tf/validation/func.StringIsNotEmpty.goindex":          "func StringIsNotEmpty(i interface{}, k string) ([]string, []error) {}",
+		"index/internal/services/validate/func.SubnetName.goindex":            "func SubnetName() {}",
+	})
+	network := AzureRMInternal + "/services/network"
+	cases := []struct {
+		attribute          string
+		expectedValidation []string
+		expectedValidators []Validator
+	}{
+		{
+			attribute:          "name",
+			expectedValidation: []string{"validate.SubnetName"},
+			expectedValidators: []Validator{
+				{Function: "validate.SubnetName", Namespace: network + "/validate", Source: "func SubnetName(v interface{}, k string) ([]string, []error) {}"},
+			},
+		},
+		{
+			attribute:          "service_endpoints",
+			expectedValidation: []string{`validation.StringInSlice([]string{"Microsoft.Storage", string(subnets.ServiceEndpointSql)}, false)`},
+			expectedValidators: []Validator{
+				{Function: "validation.StringInSlice"},
+			},
+		},
+		{
+			attribute:          "delegation.name",
+			expectedValidation: []string{"validation.All(validateDelegationName, validation.StringIsNotEmpty)"},
+			expectedValidators: []Validator{
+				{Function: "validation.All"},
+				{Function: "validateDelegationName", Namespace: network, Source: "func validateDelegationName(v interface{}, k string) ([]string, []error) {}"},
+				{Function: "validation.StringIsNotEmpty"},
+			},
+		},
+		{
+			attribute: "delegation",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.attribute, func(t *testing.T) {
+			result, err := GetAttributeValidators(context.Background(), "", "resource", "azurerm_subnet", c.attribute, "")
+			require.NoError(t, err)
+			assert.Equal(t, c.attribute, result.Attribute)
+			assert.Equal(t, c.expectedValidation, result.Validation)
+			if c.expectedValidators == nil {
+				c.expectedValidators = []Validator{}
+			}
+			assert.Equal(t, c.expectedValidators, result.Validators)
+			assert.Contains(t, subnetSchema, result.Definition)
+		})
+	}
+
+	_, err := GetAttributeValidators(context.Background(), "", "resource", "azurerm_subnet", "address_prefixes", "")
+	assert.ErrorIs(t, err, NotFoundError)
+}
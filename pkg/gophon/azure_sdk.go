@@ -0,0 +1,90 @@
+package gophon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// azureSDKResourceManager is the namespace of the resource manager services of go-azure-sdk
+const azureSDKResourceManager = HashiCorpGoAzureSdk + "/resource-manager"
+
+// AzureSDKService is a resource manager service of go-azure-sdk, like `storage`, with its API versions and the
+// packages of one of them
+type AzureSDKService struct {
+	Service string `json:"service"`
+	// APIVersions are the API versions of the service in ascending order, like `2023-01-01`
+	APIVersions []string `json:"api_versions"`
+	// APIVersion is the API version Packages are listed of, the latest stable one unless requested
+	APIVersion string `json:"api_version"`
+	// Namespace is the namespace of APIVersion, like `github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01`
+	Namespace string `json:"namespace"`
+	// Packages are the namespaces of the resource packages of APIVersion, like `<namespace>/storageaccounts`
+	Packages []string `json:"packages"`
+}
+
+// ListAzureSDKServices lists the resource manager services of go-azure-sdk containing filter, case-insensitively,
+// like `storage` or `containerservice`
+func ListAzureSDKServices(ctx context.Context, filter, tag string) ([]string, error) {
+	listing, err := ListPackages(ctx, azureSDKResourceManager, tag, false)
+	if err != nil {
+		return nil, err
+	}
+	services := make([]string, 0, len(listing.Packages))
+	for _, p := range listing.Packages {
+		service := strings.TrimPrefix(p, azureSDKResourceManager+"/")
+		if strings.Contains(strings.ToLower(service), strings.ToLower(filter)) {
+			services = append(services, service)
+		}
+	}
+	return services, nil
+}
+
+// GetAzureSDKService returns the API versions of a resource manager service of go-azure-sdk and the namespaces of the
+// packages of apiVersion, the latest stable API version by default, or the latest preview one when the service has
+// no stable API version. Packages are the ones containing filter, case-insensitively, like `storageaccounts`.
+func GetAzureSDKService(ctx context.Context, service, apiVersion, filter, tag string) (*AzureSDKService, error) {
+	service = strings.Trim(service, "/")
+	if service == "" {
+		return nil, fmt.Errorf("service cannot be empty")
+	}
+	listing, err := ListPackages(ctx, azureSDKResourceManager+"/"+service, tag, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API versions of service %s: %w", service, err)
+	}
+	result := &AzureSDKService{Service: service, APIVersions: make([]string, 0, len(listing.Packages)), Packages: make([]string, 0)}
+	for _, p := range listing.Packages {
+		result.APIVersions = append(result.APIVersions, strings.TrimPrefix(p, listing.Namespace+"/"))
+	}
+	if len(result.APIVersions) == 0 {
+		return nil, fmt.Errorf("service %s has no API versions: %w", service, NotFoundError)
+	}
+	sort.Strings(result.APIVersions)
+	result.APIVersion = apiVersion
+	if apiVersion == "" {
+		result.APIVersion = latestAPIVersion(result.APIVersions)
+	}
+	result.Namespace = listing.Namespace + "/" + result.APIVersion
+	packages, err := ListPackages(ctx, result.Namespace, tag, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages of API version %s of service %s: %w", result.APIVersion, service, err)
+	}
+	for _, p := range packages.Packages {
+		if strings.Contains(strings.ToLower(strings.TrimPrefix(p, result.Namespace+"/")), strings.ToLower(filter)) {
+			result.Packages = append(result.Packages, p)
+		}
+	}
+	return result, nil
+}
+
+// latestAPIVersion returns the latest stable API version of the sorted versions, or the latest one when they're all
+// previews
+func latestAPIVersion(versions []string) string {
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !strings.Contains(versions[i], "preview") {
+			return versions[i]
+		}
+	}
+	return versions[len(versions)-1]
+}
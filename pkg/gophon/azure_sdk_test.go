@@ -0,0 +1,109 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIndexDirectories stubs the directories of the index, keyed by path, with their sub-directories
+func stubIndexDirectories(t *testing.T, dirs map[string][]string) {
+	stubTags(t)
+	stubs := gostub.Stub(&readDirectoryContent, func(_ context.Context, owner string, repo string, dir string, tag string) ([]*github.RepositoryContent, error) {
+		assert.Equal(t, "lonegunmanb/hashicorp-go-azure-sdk-index", owner+"/"+repo)
+		names, ok := dirs[dir]
+		if !ok {
+			return nil, NotFoundError
+		}
+		entries := []*github.RepositoryContent{{Name: github.Ptr("client.go.goindex"), Type: github.Ptr("file")}}
+		for _, name := range names {
+			entries = append(entries, &github.RepositoryContent{Name: github.Ptr(name), Type: github.Ptr("dir")})
+		}
+		return entries, nil
+	})
+	t.Cleanup(stubs.Reset)
+}
+
+func TestListAzureSDKServices(t *testing.T) {
+	stubIndexDirectories(t, map[string][]string{
+		"index/resource-manager": {"storage", "storagecache", "network"},
+	})
+
+	services, err := ListAzureSDKServices(context.Background(), "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"network", "storage", "storagecache"}, services)
+
+	services, err = ListAzureSDKServices(context.Background(), "STORAGE", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"storage", "storagecache"}, services)
+}
+
+func TestGetAzureSDKService(t *testing.T) {
+	stubIndexDirectories(t, map[string][]string{
+		"index/resource-manager/storage":                          {"2023-05-01", "2022-09-01", "2024-01-01-preview"},
+		"index/resource-manager/storage/2023-05-01":               {"storageaccounts", "blobservice"},
+		"index/resource-manager/storage/2022-09-01":               {"storageaccounts"},
+		"index/resource-manager/containerapps":                    {"2024-02-02-preview"},
+		"index/resource-manager/containerapps/2024-02-02-preview": {"containerapps"},
+	})
+	storage := HashiCorpGoAzureSdk + "/resource-manager/storage"
+	cases := []struct {
+		desc       string
+		service    string
+		apiVersion string
+		filter     string
+		expected   *AzureSDKService
+	}{
+		{
+			desc:    "latest stable API version",
+			service: "storage",
+			expected: &AzureSDKService{
+				Service:     "storage",
+				APIVersions: []string{"2022-09-01", "2023-05-01", "2024-01-01-preview"},
+				APIVersion:  "2023-05-01",
+				Namespace:   storage + "/2023-05-01",
+				Packages:    []string{storage + "/2023-05-01/blobservice", storage + "/2023-05-01/storageaccounts"},
+			},
+		},
+		{
+			desc:       "API version and filter",
+			service:    "storage",
+			apiVersion: "2022-09-01",
+			filter:     "Accounts",
+			expected: &AzureSDKService{
+				Service:     "storage",
+				APIVersions: []string{"2022-09-01", "2023-05-01", "2024-01-01-preview"},
+				APIVersion:  "2022-09-01",
+				Namespace:   storage + "/2022-09-01",
+				Packages:    []string{storage + "/2022-09-01/storageaccounts"},
+			},
+		},
+		{
+			desc:    "preview only",
+			service: "containerapps",
+			expected: &AzureSDKService{
+				Service:     "containerapps",
+				APIVersions: []string{"2024-02-02-preview"},
+				APIVersion:  "2024-02-02-preview",
+				Namespace:   HashiCorpGoAzureSdk + "/resource-manager/containerapps/2024-02-02-preview",
+				Packages:    []string{HashiCorpGoAzureSdk + "/resource-manager/containerapps/2024-02-02-preview/containerapps"},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			service, err := GetAzureSDKService(context.Background(), c.service, c.apiVersion, c.filter, "")
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, service)
+		})
+	}
+
+	_, err := GetAzureSDKService(context.Background(), "unknown", "", "", "")
+	assert.ErrorIs(t, err, NotFoundError)
+	_, err = GetAzureSDKService(context.Background(), "storage", "2020-01-01", "", "")
+	assert.ErrorIs(t, err, NotFoundError)
+}
@@ -0,0 +1,52 @@
+package gophon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+)
+
+// blockIndexTTL is how long the parsed index file of a terraform block is reused, so the entrypoints of the same block
+// queried one after the other read its index file once
+var blockIndexTTL = 10 * time.Minute
+
+// blockIndexCacheEntries is the maximum number of parsed index files kept in memory
+const blockIndexCacheEntries = 256
+
+// blockIndexes holds the index files parsed by readBlockIndex
+var blockIndexes = cache.Register(newBlockIndexCache())
+
+func newBlockIndexCache() *cache.Cache[map[string]string] {
+	return cache.New[map[string]string](cache.Options{
+		Name:        "gophon_block_index",
+		Description: "Parsed index files of terraform blocks in the Golang source indexes, keyed by block and tag",
+		MaxEntries:  blockIndexCacheEntries,
+		TTL:         blockIndexTTL,
+	})
+}
+
+// readBlockIndex returns the parsed index file of a terraform block at path, like
+// `index/resources/azurerm_subnet.json`, mapping its entrypoints to their index files. The map is shared by the
+// callers reading the same file and version, it must not be modified.
+func readBlockIndex(ctx context.Context, remoteIndex RemoteIndex, path, tag string) (map[string]string, error) {
+	key := fmt.Sprintf("%s/%s/%s@%s", remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, path, tag)
+	cached, _, ok := blockIndexes.Get(key)
+	telemetry.RecordCacheLookup("gophon_block_index", ok)
+	if ok {
+		return cached, nil
+	}
+	content, err := readURLContent(ctx, remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, path, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content from URL: %w", err)
+	}
+	index := make(map[string]string)
+	if err = json.Unmarshal(content, &index); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON content from URL %s: %w", path, err)
+	}
+	_ = blockIndexes.Put(key, index)
+	return index, nil
+}
@@ -0,0 +1,49 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBlockIndex_Cached(t *testing.T) {
+	files := map[string]string{
+		"index/resources/azurerm_subnet.json":                               `{"namespace": "github.com/hashicorp/terraform-provider-azurerm/internal/services/network", "schema_index": "func.resourceSubnetSchema.goindex", "create_index": "func.resourceSubnetCreate.goindex"}`,
+		"index/internal/services/network/func.resourceSubnetSchema.goindex": "func resourceSubnetSchema() {}",
+		"index/internal/services/network/func.resourceSubnetCreate.goindex": "func resourceSubnetCreate() {}",
+	}
+	reads := make(map[string]int)
+	stubTags(t)
+	stubs := gostub.Stub(&blockIndexes, newBlockIndexCache()).Stub(&readURLContent, func(_ context.Context, owner string, repo string, path string, tag string) ([]byte, error) {
+		reads[path+"@"+tag]++
+		content, ok := files[path]
+		if !ok {
+			return nil, NotFoundError
+		}
+		return []byte(content), nil
+	})
+	defer stubs.Reset()
+
+	for _, entrypoint := range []string{"schema", "create", "schema"} {
+		_, err := GetTerraformSourceCode(context.Background(), "resource", "azurerm_subnet", entrypoint, "v4.25.0")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, reads["index/resources/azurerm_subnet.json@v4.25.0"])
+
+	// another version has its own index file
+	_, err := GetTerraformSourceCode(context.Background(), "resource", "azurerm_subnet", "create", "v4.26.0")
+	require.NoError(t, err)
+	assert.Equal(t, 1, reads["index/resources/azurerm_subnet.json@v4.26.0"])
+
+	// expired index files are read again
+	stubs.Stub(&blockIndexTTL, time.Nanosecond).Stub(&blockIndexes, newBlockIndexCache())
+	for range 2 {
+		_, err = GetTerraformSourceCode(context.Background(), "resource", "azurerm_subnet", "create", "v4.25.0")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, reads["index/resources/azurerm_subnet.json@v4.25.0"])
+}
@@ -0,0 +1,149 @@
+package gophon
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// changelogHeading matches the heading of a version in a provider changelog, like `## 4.30.0 (May 22, 2025)`
+var changelogHeading = regexp.MustCompile(`^##\s+v?(\d+\.\d+\.\d+\S*)\s*(.*)$`)
+
+// changelogCategory matches the category lines of a changelog version, like `FEATURES:` or `BUG FIXES:`
+var changelogCategory = regexp.MustCompile(`^[A-Z][A-Z /]*:\s*$`)
+
+// ChangelogEntry is the changelog section of a provider version
+type ChangelogEntry struct {
+	Version string `json:"version"`
+	// Date is the release date of the version as written in the changelog, like `May 22, 2025` or `Unreleased`
+	Date    string `json:"date,omitempty"`
+	Content string `json:"content"`
+}
+
+// Changelog is the result of GetChangelog
+type Changelog struct {
+	Provider string           `json:"provider"`
+	Entries  []ChangelogEntry `json:"entries"`
+}
+
+// GetChangelog reads the `CHANGELOG.md` of the upstream repository of a provider and returns the section of tag, or
+// the sections of the versions after fromTag up to toTag when tag is empty, toTag defaults to the latest version. When
+// filter isn't empty, only the lines containing it, like `azurerm_storage_account`, are kept with their category, and
+// versions without any are dropped. Versions older than the changelog, that some providers move to another file, aren't
+// found.
+func GetChangelog(ctx context.Context, providerName, tag, fromTag, toTag, filter string) (*Changelog, error) {
+	if tag == "" && fromTag == "" {
+		return nil, fmt.Errorf("either tag or from tag must be set")
+	}
+	indexKey, ok := ProviderIndexMap[providerName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider type: %s, supported providers are: %v", providerName, GetSupportedProviders())
+	}
+	owner, repo, err := upstreamRepository(RemoteIndexMap[indexKey])
+	if err != nil {
+		return nil, err
+	}
+	inRange, err := changelogRange(tag, fromTag, toTag)
+	if err != nil {
+		return nil, err
+	}
+	// the changelog of the default branch has every released version
+	content, err := readURLContent(ctx, owner, repo, "CHANGELOG.md", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the changelog of %s/%s: %w", owner, repo, err)
+	}
+	changelog := &Changelog{Provider: providerName, Entries: make([]ChangelogEntry, 0)}
+	for _, entry := range parseChangelog(string(content)) {
+		v, err := goversion.NewVersion(entry.Version)
+		if err != nil || !inRange(v) {
+			continue
+		}
+		if filter != "" {
+			if entry.Content = filterChangelog(entry.Content, filter); entry.Content == "" {
+				continue
+			}
+		}
+		changelog.Entries = append(changelog.Entries, entry)
+	}
+	if tag != "" && len(changelog.Entries) == 0 && filter == "" {
+		return nil, fmt.Errorf("version %s not found in the changelog of %s/%s: %w", tag, owner, repo, NotFoundError)
+	}
+	return changelog, nil
+}
+
+// changelogRange returns whether a version is tag, or after fromTag up to toTag
+func changelogRange(tag, fromTag, toTag string) (func(v *goversion.Version) bool, error) {
+	if tag != "" {
+		version, err := goversion.NewVersion(tag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag %s: %w", tag, err)
+		}
+		return version.Equal, nil
+	}
+	from, err := goversion.NewVersion(fromTag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from tag %s: %w", fromTag, err)
+	}
+	if toTag == "" || toTag == TagLatest {
+		return from.LessThan, nil
+	}
+	to, err := goversion.NewVersion(toTag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to tag %s: %w", toTag, err)
+	}
+	return func(v *goversion.Version) bool {
+		return v.GreaterThan(from) && v.LessThanOrEqual(to)
+	}, nil
+}
+
+// parseChangelog splits a changelog into the sections of its versions, newest first like the changelog
+func parseChangelog(content string) []ChangelogEntry {
+	var entries []ChangelogEntry
+	var lines []string
+	flush := func() {
+		if len(entries) > 0 {
+			entries[len(entries)-1].Content = strings.TrimSpace(strings.Join(lines, "\n"))
+		}
+		lines = nil
+	}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if match := changelogHeading.FindStringSubmatch(line); match != nil {
+			flush()
+			date := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(match[2]), "("), ")")
+			entries = append(entries, ChangelogEntry{Version: match[1], Date: date})
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return entries
+}
+
+// filterChangelog keeps the lines of a version section containing filter, case-insensitively, under their category
+func filterChangelog(content, filter string) string {
+	filter = strings.ToLower(filter)
+	var kept []string
+	category := ""
+	for _, line := range strings.Split(content, "\n") {
+		if changelogCategory.MatchString(strings.TrimSpace(line)) {
+			category = strings.TrimSpace(line)
+			continue
+		}
+		if !strings.Contains(strings.ToLower(line), filter) {
+			continue
+		}
+		if category != "" {
+			if len(kept) > 0 {
+				kept = append(kept, "")
+			}
+			kept = append(kept, category)
+			category = ""
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,120 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const azurermChangelog = `## 4.31.0 (Unreleased)
+
+ENHANCEMENTS:
+
+* ` + "`azurerm_subnet`" + ` - support for the ` + "`sharing_scope`" + ` property
+
+## 4.30.0 (May 22, 2025)
+
+FEATURES:
+
+* **New Resource**: ` + "`azurerm_storage_account_queue_properties`" + `
+
+ENHANCEMENTS:
+
+* ` + "`azurerm_storage_account`" + ` - support for the ` + "`provisioned_billing_model_version`" + ` property
+* ` + "`azurerm_linux_web_app`" + ` - support for the ` + "`vnet_image_pull_enabled`" + ` property
+
+BUG FIXES:
+
+* ` + "`azurerm_storage_account`" + ` - fix a crash when ` + "`queue_properties`" + ` is empty
+
+## 4.29.0 (May 15, 2025)
+
+BUG FIXES:
+
+* ` + "`azurerm_key_vault`" + ` - fix the validation of ` + "`name`" + `
+`
+
+func stubChangelog(t *testing.T) {
+	stubs := gostub.Stub(&readURLContent, func(_ context.Context, owner string, repo string, path string, tag string) ([]byte, error) {
+		assert.Equal(t, "hashicorp/terraform-provider-azurerm/CHANGELOG.md", owner+"/"+repo+"/"+path)
+		return []byte(azurermChangelog), nil
+	})
+	t.Cleanup(stubs.Reset)
+}
+
+func TestGetChangelog(t *testing.T) {
+	stubChangelog(t)
+	cases := []struct {
+		desc             string
+		tag              string
+		fromTag          string
+		toTag            string
+		filter           string
+		expectedVersions []string
+	}{
+		{
+			desc:             "tag",
+			tag:              "v4.30.0",
+			expectedVersions: []string{"4.30.0"},
+		},
+		{
+			desc:             "range",
+			fromTag:          "v4.29.0",
+			toTag:            "v4.30.0",
+			expectedVersions: []string{"4.30.0"},
+		},
+		{
+			desc:             "range to latest",
+			fromTag:          "4.28.0",
+			expectedVersions: []string{"4.31.0", "4.30.0", "4.29.0"},
+		},
+		{
+			desc:             "filter",
+			fromTag:          "v4.28.0",
+			filter:           "AZURERM_STORAGE_ACCOUNT`",
+			expectedVersions: []string{"4.30.0"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			changelog, err := GetChangelog(context.Background(), "azurerm", c.tag, c.fromTag, c.toTag, c.filter)
+			require.NoError(t, err)
+			assert.Equal(t, "azurerm", changelog.Provider)
+			var versions []string
+			for _, entry := range changelog.Entries {
+				versions = append(versions, entry.Version)
+			}
+			assert.Equal(t, c.expectedVersions, versions)
+		})
+	}
+}
+
+func TestGetChangelog_Content(t *testing.T) {
+	stubChangelog(t)
+
+	changelog, err := GetChangelog(context.Background(), "azurerm", "v4.30.0", "", "", "azurerm_storage_account`")
+	require.NoError(t, err)
+	require.Len(t, changelog.Entries, 1)
+	assert.Equal(t, "May 22, 2025", changelog.Entries[0].Date)
+	assert.Equal(t, "ENHANCEMENTS:\n* `azurerm_storage_account` - support for the `provisioned_billing_model_version` property\n\nBUG FIXES:\n* `azurerm_storage_account` - fix a crash when `queue_properties` is empty", changelog.Entries[0].Content)
+
+	changelog, err = GetChangelog(context.Background(), "azurerm", "4.31.0", "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []ChangelogEntry{{Version: "4.31.0", Date: "Unreleased", Content: "ENHANCEMENTS:\n\n* `azurerm_subnet` - support for the `sharing_scope` property"}}, changelog.Entries)
+}
+
+func TestGetChangelog_Errors(t *testing.T) {
+	stubChangelog(t)
+
+	_, err := GetChangelog(context.Background(), "azurerm", "", "", "", "")
+	assert.EqualError(t, err, "either tag or from tag must be set")
+	_, err = GetChangelog(context.Background(), "unknown", "v1.0.0", "", "", "")
+	assert.ErrorContains(t, err, "unsupported provider type: unknown")
+	_, err = GetChangelog(context.Background(), "azurerm", "main", "", "", "")
+	assert.ErrorContains(t, err, "invalid tag main")
+	_, err = GetChangelog(context.Background(), "azurerm", "v3.0.0", "", "", "")
+	assert.ErrorIs(t, err, NotFoundError)
+}
@@ -0,0 +1,139 @@
+package gophon
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+)
+
+// Environment variables of the GitHub content cache, kept along the generic `CACHE_GOPHON_GITHUB_*` variables
+const (
+	// CacheDirEnv overrides the parent directory of the on-disk GitHub content cache, which is its `gophon`
+	// sub-directory, set it to `off` to disable the disk cache
+	CacheDirEnv = "GOPHON_CACHE_DIR"
+	// CacheEntriesEnv is the maximum number of GitHub responses kept in memory, defaults to 1024
+	CacheEntriesEnv = "GOPHON_CACHE_ENTRIES"
+	// DebugEnv set to `true` defaults the log level to `debug`, logging whether every GitHub request was served from
+	// the cache
+	DebugEnv = "GOPHON_DEBUG"
+)
+
+const defaultCacheEntries = 1024
+
+// githubCacheName is the cache of the GitHub responses in the cache metrics
+const githubCacheName = "gophon_github"
+
+// githubTransport caches the GitHub responses of index files and directories. Cached responses are revalidated with
+// their ETag, GitHub answers `304 Not Modified` to unchanged content, which doesn't count against the rate limit of
+// authenticated requests and is faster than downloading the content again.
+var githubTransport http.RoundTripper = &cachingTransport{base: &rateLimitTransport{base: http.DefaultTransport}}
+
+// contentCache holds the cached GitHub responses in memory and on disk, keyed by URL
+var contentCache = cache.Register(newContentCache())
+
+func newContentCache() *cache.Cache[*cachedContent] {
+	return cache.New[*cachedContent](cache.Options{
+		Name:          githubCacheName,
+		Description:   "GitHub responses of the Golang source indexes, revalidated with their ETag",
+		Backend:       cache.BackendDisk,
+		MaxEntries:    defaultCacheEntries,
+		DirName:       "gophon",
+		DirEnv:        CacheDirEnv,
+		MaxEntriesEnv: CacheEntriesEnv,
+	})
+}
+
+// immutableGitObject matches the URLs of the git trees and blobs read by SHA, their content never changes so cached
+// responses are served without revalidation
+var immutableGitObject = regexp.MustCompile(`/git/(trees|blobs)/[0-9a-f]{40}$`)
+
+// cachedContent is a GitHub response that can be revalidated with its ETag
+type cachedContent struct {
+	ETag   string      `json:"etag"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+type cachingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip sends GET requests with the ETag of the cached response, and serves the cached response when the content
+// is not modified, or right away for immutable git objects. Successful responses with an ETag are cached in memory and
+// on disk.
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+	key := req.URL.String()
+	cached, source := loadCachedContent(key)
+	if cached != nil && immutableGitObject.MatchString(req.URL.Path) {
+		telemetry.RecordCacheLookup(githubCacheName, true)
+		logging.FromContext(req.Context()).Debug("gophon cache hit", "source", source, "immutable", true, "url", key)
+		return cached.response(req), nil
+	}
+	if cached != nil {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		telemetry.RecordCacheLookup(githubCacheName, true)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		logging.FromContext(req.Context()).Debug("gophon cache hit", "source", source, "etag", cached.ETag, "url", key)
+		return cached.response(req), nil
+	}
+	telemetry.RecordCacheLookup(githubCacheName, false)
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		logging.FromContext(req.Context()).Debug("gophon cache miss, not cacheable", "status", resp.StatusCode, "url", key)
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body from URL %s: %w", key, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	storeCachedContent(key, &cachedContent{ETag: etag, Header: resp.Header.Clone(), Body: body})
+	logging.FromContext(req.Context()).Debug("gophon cache miss", "etag", etag, "url", key)
+	return resp, nil
+}
+
+func (c *cachedContent) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+// loadCachedContent returns the cached response of key from memory or disk, and where it was found
+func loadCachedContent(key string) (*cachedContent, cache.Source) {
+	cached, source, ok := contentCache.Get(key)
+	if !ok || cached.ETag == "" {
+		return nil, ""
+	}
+	return cached, source
+}
+
+// storeCachedContent caches the response in memory and on disk, disk errors only cost a download later
+func storeCachedContent(key string, cached *cachedContent) {
+	_ = contentCache.Put(key, cached)
+}
@@ -0,0 +1,100 @@
+package gophon
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newETagServer serves body with the etag, and counts the requests and the `304 Not Modified` answers
+func newETagServer(t *testing.T, etag, body string) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	var requests, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if etag != "" && r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests, &notModified
+}
+
+func getThroughCache(t *testing.T, url string) string {
+	client := &http.Client{Transport: &cachingTransport{base: http.DefaultTransport}}
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestCachingTransport_RevalidatesWithETag(t *testing.T) {
+	t.Setenv(CacheDirEnv, "off")
+	stubs := gostub.Stub(&contentCache, newContentCache())
+	defer stubs.Reset()
+	server, requests, notModified := newETagServer(t, `"v1"`, "type Client struct {}")
+
+	assert.Equal(t, "type Client struct {}", getThroughCache(t, server.URL+"/index"))
+	assert.Equal(t, "type Client struct {}", getThroughCache(t, server.URL+"/index"))
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+	assert.Equal(t, 1, contentCache.Len())
+}
+
+func TestCachingTransport_DiskCache(t *testing.T) {
+	t.Setenv(CacheDirEnv, t.TempDir())
+	stubs := gostub.Stub(&contentCache, newContentCache())
+	defer stubs.Reset()
+	server, _, notModified := newETagServer(t, `"v1"`, "func Build() {}")
+
+	assert.Equal(t, "func Build() {}", getThroughCache(t, server.URL+"/index"))
+	// a new process only has the disk cache
+	contentCache = newContentCache()
+	assert.Equal(t, "func Build() {}", getThroughCache(t, server.URL+"/index"))
+	assert.Equal(t, int32(1), notModified.Load())
+
+	cached, source := loadCachedContent(server.URL + "/index")
+	require.NotNil(t, cached)
+	assert.Equal(t, cache.SourceMemory, source)
+	assert.Equal(t, `"v1"`, cached.ETag)
+}
+
+func TestCachingTransport_WithoutETag(t *testing.T) {
+	t.Setenv(CacheDirEnv, "off")
+	stubs := gostub.Stub(&contentCache, newContentCache())
+	defer stubs.Reset()
+	server, requests, _ := newETagServer(t, "", "no etag")
+
+	assert.Equal(t, "no etag", getThroughCache(t, server.URL+"/index"))
+	assert.Equal(t, "no etag", getThroughCache(t, server.URL+"/index"))
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Zero(t, contentCache.Len())
+}
+
+func TestContentCache_Config(t *testing.T) {
+	t.Setenv(CacheDirEnv, "/cache")
+	t.Setenv(CacheEntriesEnv, "2")
+	assert.Equal(t, cache.Config{Backend: cache.BackendDisk, MaxEntries: 2, Dir: filepath.Join("/cache", "gophon")}, newContentCache().Config())
+
+	t.Setenv(CacheDirEnv, "off")
+	t.Setenv(CacheEntriesEnv, "")
+	assert.Equal(t, cache.Config{Backend: cache.BackendMemory, MaxEntries: defaultCacheEntries}, newContentCache().Config())
+}
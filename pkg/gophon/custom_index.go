@@ -0,0 +1,53 @@
+package gophon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RemoteIndexesEnv registers additional gophon indexed namespaces when the server starts, separated by commas, e.g.
+// `github.com/contoso/terraform-provider-contoso/internal=contoso/terraform-provider-contoso-index@github.com/contoso/terraform-provider-contoso`.
+// The package path after `@` is the module the index was built from, it defaults to the namespace.
+const RemoteIndexesEnv = "GOPHON_REMOTE_INDEXES"
+
+// ParseRemoteIndexes parses a comma-separated list of `namespace=owner/repo[@package_path]` entries
+func ParseRemoteIndexes(list string) (map[string]RemoteIndex, error) {
+	indexes := make(map[string]RemoteIndex)
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		namespace, target, ok := strings.Cut(entry, "=")
+		namespace = strings.TrimSuffix(strings.TrimSpace(namespace), "/")
+		repository, packagePath, _ := strings.Cut(strings.TrimSpace(target), "@")
+		owner, repo, _ := strings.Cut(repository, "/")
+		if !ok || namespace == "" || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			return nil, fmt.Errorf("invalid remote index entry %q, expected namespace=owner/repo[@package_path]", entry)
+		}
+		packagePath = strings.TrimSuffix(packagePath, "/")
+		if packagePath == "" {
+			packagePath = namespace
+		}
+		if namespace != packagePath && !strings.HasPrefix(namespace, packagePath+"/") {
+			return nil, fmt.Errorf("invalid remote index entry %q, namespace must be in package path %s", entry, packagePath)
+		}
+		indexes[namespace] = RemoteIndex{
+			GitHubOwner: owner,
+			GitHubRepo:  repo,
+			PackagePath: packagePath,
+		}
+	}
+	return indexes, nil
+}
+
+// RegisterRemoteIndexes adds the indexes to the supported namespaces, an index replaces the built-in index of the same
+// namespace. It must be called before the server starts serving requests.
+func RegisterRemoteIndexes(indexes map[string]RemoteIndex) {
+	for namespace, remoteIndex := range indexes {
+		if _, ok := RemoteIndexMap[namespace]; !ok {
+			Namespaces = append(Namespaces, namespace)
+		}
+		RemoteIndexMap[namespace] = remoteIndex
+	}
+}
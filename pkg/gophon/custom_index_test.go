@@ -0,0 +1,83 @@
+package gophon
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteIndexes(t *testing.T) {
+	cases := []struct {
+		desc     string
+		list     string
+		expected map[string]RemoteIndex
+		err      string
+	}{
+		{
+			desc:     "empty",
+			list:     " ",
+			expected: map[string]RemoteIndex{},
+		},
+		{
+			desc: "package path",
+			list: "github.com/contoso/terraform-provider-contoso/internal=contoso/terraform-provider-contoso-index@github.com/contoso/terraform-provider-contoso",
+			expected: map[string]RemoteIndex{
+				"github.com/contoso/terraform-provider-contoso/internal": {GitHubOwner: "contoso", GitHubRepo: "terraform-provider-contoso-index", PackagePath: "github.com/contoso/terraform-provider-contoso"},
+			},
+		},
+		{
+			desc: "default package path",
+			list: "github.com/contoso/sdk/ = contoso/sdk-index , github.com/contoso/helpers=contoso/helpers-index",
+			expected: map[string]RemoteIndex{
+				"github.com/contoso/sdk":     {GitHubOwner: "contoso", GitHubRepo: "sdk-index", PackagePath: "github.com/contoso/sdk"},
+				"github.com/contoso/helpers": {GitHubOwner: "contoso", GitHubRepo: "helpers-index", PackagePath: "github.com/contoso/helpers"},
+			},
+		},
+		{
+			desc: "missing repo",
+			list: "github.com/contoso/sdk=contoso",
+			err:  `invalid remote index entry "github.com/contoso/sdk=contoso", expected namespace=owner/repo[@package_path]`,
+		},
+		{
+			desc: "missing namespace",
+			list: "contoso/sdk-index",
+			err:  `invalid remote index entry "contoso/sdk-index", expected namespace=owner/repo[@package_path]`,
+		},
+		{
+			desc: "namespace outside package path",
+			list: "github.com/contoso/sdk=contoso/sdk-index@github.com/contoso/sdk2",
+			err:  `invalid remote index entry "github.com/contoso/sdk=contoso/sdk-index@github.com/contoso/sdk2", namespace must be in package path github.com/contoso/sdk2`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			indexes, err := ParseRemoteIndexes(c.list)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, indexes)
+		})
+	}
+}
+
+func TestRegisterRemoteIndexes(t *testing.T) {
+	stubs := gostub.Stub(&RemoteIndexMap, maps.Clone(RemoteIndexMap)).Stub(&Namespaces, slices.Clone(Namespaces))
+	defer stubs.Reset()
+	contoso := RemoteIndex{GitHubOwner: "contoso", GitHubRepo: "azurerm-network-index", PackagePath: AzureRMInternal + "/services/network"}
+
+	RegisterRemoteIndexes(map[string]RemoteIndex{AzureRMInternal + "/services/network": contoso})
+
+	assert.Contains(t, ListSupportedNamespaces(), AzureRMInternal+"/services/network")
+	remoteIndex, err := namespaceRemoteIndex(AzureRMInternal + "/services/network/parse")
+	require.NoError(t, err)
+	assert.Equal(t, contoso, remoteIndex)
+	remoteIndex, err = namespaceRemoteIndex(AzureRMInternal + "/services/compute")
+	require.NoError(t, err)
+	assert.Equal(t, "terraform-provider-azurerm-index", remoteIndex.GitHubRepo)
+}
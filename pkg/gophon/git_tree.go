@@ -0,0 +1,199 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"golang.org/x/sync/singleflight"
+)
+
+// rootTreeTTL is how long the root tree a ref resolves to is reused before the ref is read again
+var rootTreeTTL = 10 * time.Minute
+
+// rootTrees holds the root trees of the refs read by readGitTree
+var rootTrees = newRootTreeCache()
+
+// errTreeTruncated is returned for directories with more entries than GitHub returns in one tree
+var errTreeTruncated = errors.New("git tree truncated")
+
+// rootTreeCache guards its entries with the mutex only, refs are resolved outside of it and concurrent reads of the
+// same ref are coalesced, so a slow or rate limited ref resolution doesn't block the lookups of other refs
+type rootTreeCache struct {
+	sync.Mutex
+	entries map[string]rootTree
+	loads   singleflight.Group
+}
+
+func newRootTreeCache() *rootTreeCache {
+	return &rootTreeCache{entries: make(map[string]rootTree)}
+}
+
+type rootTree struct {
+	commit  string
+	entries []*github.TreeEntry
+	expires time.Time
+}
+
+// readGitFile reads a file of a repository at tag, or at the default branch when tag is empty, as the blob of its
+// directory tree
+func readGitFile(ctx context.Context, client *github.Client, owner, repo, filePath, tag string) ([]byte, error) {
+	dir, name := path.Split(filePath)
+	entries, err := readGitTree(ctx, client, owner, repo, dir, tag)
+	if err != nil {
+		return nil, err
+	}
+	entry := findTreeEntry(entries, name)
+	if entry == nil || entry.GetType() != "blob" {
+		return nil, NotFoundError
+	}
+	ctx, cancel := requestContext(ctx)
+	defer cancel()
+	content, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, entry.GetSHA())
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, NotFoundError
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch URL %s: %w", filePath, describeRateLimit(err))
+	}
+	return content, nil
+}
+
+// readGitTree returns the entries of dir in a repository at tag, walking the trees from the root tree of tag. Trees
+// and blobs are read by SHA, their content never changes so the content cache serves them without requests once
+// they're read, and the directories of a lookup share the trees of their parents.
+func readGitTree(ctx context.Context, client *github.Client, owner, repo, dir, tag string) ([]*github.TreeEntry, error) {
+	entries, err := readRootTree(ctx, client, owner, repo, tag)
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range strings.Split(strings.Trim(dir, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		entry := findTreeEntry(entries, segment)
+		if entry == nil || entry.GetType() != "tree" {
+			return nil, NotFoundError
+		}
+		if entries, err = getGitTree(ctx, client, owner, repo, entry.GetSHA()); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// readRootTree returns the entries of the root tree of tag and records the commit tag resolves to in ctx. The ref is
+// resolved again after rootTreeTTL so the default branch and moved tags are refreshed, the root tree of a commit is
+// read by SHA.
+func readRootTree(ctx context.Context, client *github.Client, owner, repo, tag string) ([]*github.TreeEntry, error) {
+	ref := tag
+	if ref == "" {
+		ref = "HEAD"
+	}
+	key := fmt.Sprintf("%s/%s@%s", owner, repo, ref)
+	cached, ok := rootTrees.get(key)
+	if !ok {
+		v, err, _ := rootTrees.loads.Do(key, func() (any, error) {
+			commit, err := resolveCommit(ctx, client, owner, repo, ref)
+			if err != nil {
+				return rootTree{}, err
+			}
+			entries, err := getGitTree(ctx, client, owner, repo, commit)
+			if err != nil {
+				return rootTree{}, err
+			}
+			loaded := rootTree{commit: commit, entries: entries, expires: time.Now().Add(rootTreeTTL)}
+			rootTrees.put(key, loaded)
+			return loaded, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		cached = v.(rootTree)
+	}
+	recordIndexRef(ctx, IndexRef{Repository: owner + "/" + repo, Ref: tag, Commit: cached.commit})
+	return cached.entries, nil
+}
+
+func (c *rootTreeCache) get(key string) (rootTree, bool) {
+	c.Lock()
+	defer c.Unlock()
+	cached, ok := c.entries[key]
+	if !ok || !time.Now().Before(cached.expires) {
+		return rootTree{}, false
+	}
+	return cached, true
+}
+
+func (c *rootTreeCache) put(key string, tree rootTree) {
+	c.Lock()
+	defer c.Unlock()
+	c.entries[key] = tree
+}
+
+// resolveCommit returns the SHA of the commit of a ref
+func resolveCommit(ctx context.Context, client *github.Client, owner, repo, ref string) (string, error) {
+	ctx, cancel := requestContext(ctx)
+	defer cancel()
+	commit, resp, err := client.Repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+	if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+		return "", NotFoundError
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve ref %s of %s/%s: %w", ref, owner, repo, describeRateLimit(err))
+	}
+	return commit, nil
+}
+
+// getGitTree reads the entries of a tree by SHA, or of the root tree of a ref
+func getGitTree(ctx context.Context, client *github.Client, owner, repo, sha string) ([]*github.TreeEntry, error) {
+	ctx, cancel := requestContext(ctx)
+	defer cancel()
+	tree, resp, err := client.Git.GetTree(ctx, owner, repo, sha, false)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, NotFoundError
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree %s of %s/%s: %w", sha, owner, repo, describeRateLimit(err))
+	}
+	if tree.GetTruncated() {
+		return nil, errTreeTruncated
+	}
+	return tree.Entries, nil
+}
+
+func findTreeEntry(entries []*github.TreeEntry, name string) *github.TreeEntry {
+	for _, entry := range entries {
+		if entry.GetPath() == name {
+			return entry
+		}
+	}
+	return nil
+}
+
+// treeDirectoryContent lists tree entries the way GitHub lists directory contents
+func treeDirectoryContent(entries []*github.TreeEntry) []*github.RepositoryContent {
+	contents := make([]*github.RepositoryContent, 0, len(entries))
+	for _, entry := range entries {
+		entryType := "file"
+		switch entry.GetType() {
+		case "tree":
+			entryType = "dir"
+		case "commit":
+			entryType = "submodule"
+		}
+		contents = append(contents, &github.RepositoryContent{
+			Name: entry.Path,
+			Type: github.Ptr(entryType),
+			SHA:  entry.SHA,
+			Size: entry.Size,
+		})
+	}
+	return contents
+}
@@ -0,0 +1,222 @@
+package gophon
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gitTreeAPIPrefix = "/api/v3/repos/lonegunmanb/terraform-provider-azurerm-index/git/"
+
+const gitCommitsAPIPrefix = "/api/v3/repos/lonegunmanb/terraform-provider-azurerm-index/commits/"
+
+func gitObjectSHA(p string) string {
+	sum := sha1.Sum([]byte(p))
+	return hex.EncodeToString(sum[:])
+}
+
+func gitCommitSHA(ref string) string {
+	return gitObjectSHA("commit " + ref)
+}
+
+// gitTreeHandler serves files as the git trees and blobs of the azurerm index repository at the refs, a tree or blob
+// SHA is the hash of its path and the commit of a ref is gitCommitSHA(ref)
+func gitTreeHandler(t *testing.T, files map[string]string, refs ...string) http.HandlerFunc {
+	trees := map[string]map[string]string{"": {}}
+	blobs := make(map[string]string)
+	for p, content := range files {
+		blobs[gitObjectSHA(p)] = content
+		for dir, name := path.Split(p); ; dir, name = path.Split(strings.TrimSuffix(dir, "/")) {
+			dir = strings.TrimSuffix(dir, "/")
+			if trees[dir] == nil {
+				trees[dir] = make(map[string]string)
+			}
+			entryType := "blob"
+			if _, ok := trees[path.Join(dir, name)]; ok && path.Join(dir, name) != p {
+				entryType = "tree"
+			}
+			trees[dir][name] = entryType
+			if dir == "" {
+				break
+			}
+		}
+	}
+	shas := make(map[string]string)
+	for dir := range trees {
+		shas[gitObjectSHA(dir)] = dir
+	}
+	commits := make(map[string]string)
+	for _, ref := range refs {
+		commits[ref] = gitCommitSHA(ref)
+		shas[gitCommitSHA(ref)] = ""
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"`+r.URL.Path+`"`)
+		if ref, ok := strings.CutPrefix(r.URL.Path, gitCommitsAPIPrefix); ok {
+			if commit, ok := commits[ref]; ok {
+				_, _ = w.Write([]byte(commit))
+				return
+			}
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message": "No commit found for SHA: ` + ref + `"}`))
+			return
+		}
+		if sha, ok := strings.CutPrefix(r.URL.Path, gitTreeAPIPrefix+"blobs/"); ok {
+			if content, ok := blobs[sha]; ok {
+				_, _ = w.Write([]byte(content))
+				return
+			}
+		}
+		if sha, ok := strings.CutPrefix(r.URL.Path, gitTreeAPIPrefix+"trees/"); ok {
+			if dir, ok := shas[sha]; ok {
+				var names []string
+				for name := range trees[dir] {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				entries := make([]map[string]string, 0, len(names))
+				for _, name := range names {
+					p := path.Join(dir, name)
+					entries = append(entries, map[string]string{"path": name, "type": trees[dir][name], "sha": gitObjectSHA(p)})
+				}
+				w.Header().Set("Content-Type", "application/json")
+				require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"sha": gitObjectSHA(dir), "tree": entries}))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	}
+}
+
+func TestReadGitTree(t *testing.T) {
+	var requests atomic.Int32
+	handler := gitTreeHandler(t, map[string]string{
+		"index/internal/clients/type.Client.goindex":   "type Client struct {}",
+		"index/internal/clients/func.Build.goindex":    "func Build() {}",
+		"index/internal/clients/nested/type.A.goindex": "type A struct {}",
+	}, "v4.25.0")
+	stubRateLimitedGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler(w, r)
+	})
+
+	code, err := GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Client", "v4.25.0")
+	require.NoError(t, err)
+	assert.Equal(t, "type Client struct {}", code)
+	// the commit of the ref, its root tree, the trees of index, internal and clients, and the blob
+	assert.Equal(t, int32(6), requests.Load())
+
+	// trees and blobs read by SHA are served by the cache
+	code, err = GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Client", "v4.25.0")
+	require.NoError(t, err)
+	assert.Equal(t, "type Client struct {}", code)
+	code, err = GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "func", "", "Build", "v4.25.0")
+	require.NoError(t, err)
+	assert.Equal(t, "func Build() {}", code)
+	assert.Equal(t, int32(7), requests.Load())
+
+	symbols, err := SearchSymbols(context.Background(), AzureRMInternal+"/clients", "", "", "v4.25.0", false)
+	require.NoError(t, err)
+	assert.Equal(t, []Symbol{{Symbol: "func", Name: "Build"}, {Symbol: "type", Name: "Client"}}, symbols)
+	assert.Equal(t, int32(7), requests.Load())
+
+	_, err = GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Missing", "v4.25.0")
+	assert.ErrorIs(t, err, NotFoundError)
+	_, err = GetGolangSourceCode(context.Background(), AzureRMInternal+"/missing", "type", "", "Client", "v4.25.0")
+	assert.ErrorIs(t, err, NotFoundError)
+	_, err = GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Client", "v0.0.1")
+	assert.ErrorIs(t, err, NotFoundError)
+}
+
+func TestReadRootTree_Expires(t *testing.T) {
+	var requests atomic.Int32
+	handler := gitTreeHandler(t, map[string]string{"index/internal/clients/type.Client.goindex": "type Client struct {}"}, "v4.25.0")
+	stubRateLimitedGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler(w, r)
+	})
+	client, err := newGitHubClient()
+	require.NoError(t, err)
+
+	read := func() {
+		_, err := readRootTree(context.Background(), client, "lonegunmanb", "terraform-provider-azurerm-index", "v4.25.0")
+		require.NoError(t, err)
+	}
+
+	stubs := gostub.Stub(&rootTreeTTL, time.Duration(0))
+	read()
+	read()
+	// the ref is resolved again, the root tree of the commit is served by the cache
+	assert.Equal(t, int32(3), requests.Load())
+	stubs.Reset()
+	read()
+	read()
+	assert.Equal(t, int32(4), requests.Load())
+}
+
+func TestReadRootTree_SlowRefDoesNotBlockOtherRefs(t *testing.T) {
+	var slowResolutions atomic.Int32
+	release := make(chan struct{})
+	handler := gitTreeHandler(t, map[string]string{"index/internal/clients/type.Client.goindex": "type Client struct {}"}, "v4.25.0", "v4.26.0")
+	stubRateLimitedGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == gitCommitsAPIPrefix+"v4.25.0" {
+			slowResolutions.Add(1)
+			<-release
+		}
+		handler(w, r)
+	})
+	client, err := newGitHubClient()
+	require.NoError(t, err)
+	read := func(tag string) error {
+		_, err := readRootTree(context.Background(), client, "lonegunmanb", "terraform-provider-azurerm-index", tag)
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, read("v4.25.0"))
+		}()
+	}
+	assert.Eventually(t, func() bool { return slowResolutions.Load() == 1 }, time.Second, time.Millisecond)
+	// another ref is read while v4.25.0 is still being resolved
+	require.NoError(t, read("v4.26.0"))
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), slowResolutions.Load(), "concurrent reads of the same ref should share one resolution")
+}
+
+func TestIndexRefs(t *testing.T) {
+	handler := gitTreeHandler(t, map[string]string{"index/internal/clients/type.Client.goindex": "type Client struct {}"}, "v4.25.0")
+	stubRateLimitedGitHub(t, handler)
+
+	ctx, refs := WithIndexRefs(context.Background())
+	_, err := GetGolangSourceCode(ctx, AzureRMInternal+"/clients", "type", "", "Client", "v4.25.0")
+	require.NoError(t, err)
+	_, err = GetGolangSourceCode(ctx, AzureRMInternal+"/clients", "type", "", "Client", "v4.25.0")
+	require.NoError(t, err)
+	expected := IndexRef{Repository: "lonegunmanb/terraform-provider-azurerm-index", Ref: "v4.25.0", Commit: gitCommitSHA("v4.25.0")}
+	assert.Equal(t, []IndexRef{expected}, refs.List())
+	assert.Equal(t, "lonegunmanb/terraform-provider-azurerm-index@v4.25.0 (commit "+gitCommitSHA("v4.25.0")[:7]+")", expected.String())
+
+	// a context without WithIndexRefs records nothing
+	_, err = GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Client", "v4.25.0")
+	require.NoError(t, err)
+}
@@ -0,0 +1,59 @@
+package gophon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// GitHubAPIURLEnv is the API endpoint of a GitHub Enterprise Server hosting the index repositories, like
+// `https://github.contoso.com/api/v3`, the same variable GitHub Actions sets. The index repositories are read from
+// github.com when it's not set.
+const GitHubAPIURLEnv = "GITHUB_API_URL"
+
+// RequestTimeoutEnv is the timeout of every GitHub request, like `10s` or `1m`, including the retries of rate limited
+// requests, defaults to 60s
+const RequestTimeoutEnv = "GOPHON_REQUEST_TIMEOUT"
+
+const defaultRequestTimeout = 60 * time.Second
+
+// newGitHubClient returns a client reading the index repositories through githubTransport, authenticated with
+// GITHUB_TOKEN when it's set
+func newGitHubClient() (*github.Client, error) {
+	client := github.NewClient(&http.Client{Transport: githubTransport})
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		client = client.WithAuthToken(token)
+	}
+	apiURL := strings.TrimSpace(os.Getenv(GitHubAPIURLEnv))
+	if apiURL == "" || isPublicGitHubAPI(apiURL) {
+		return client, nil
+	}
+	client, err := client.WithEnterpriseURLs(apiURL, apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", GitHubAPIURLEnv, apiURL, err)
+	}
+	return client, nil
+}
+
+func isPublicGitHubAPI(apiURL string) bool {
+	u, err := url.Parse(apiURL)
+	return err == nil && strings.EqualFold(u.Host, "api.github.com")
+}
+
+// requestContext returns ctx with the deadline of one GitHub request
+func requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, requestTimeout())
+}
+
+func requestTimeout() time.Duration {
+	if timeout, err := time.ParseDuration(os.Getenv(RequestTimeoutEnv)); err == nil && timeout > 0 {
+		return timeout
+	}
+	return defaultRequestTimeout
+}
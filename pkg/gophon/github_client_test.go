@@ -0,0 +1,112 @@
+package gophon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubEnterpriseAPIURL(t *testing.T) {
+	var authorizations []string
+	index := gitTreeHandler(t, map[string]string{"index/internal/clients/type.Client.goindex": "type Client struct {}"}, "v4.25.0")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if r.URL.Path == "/api/v3/repos/lonegunmanb/terraform-provider-azurerm-index/tags" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"name": "v4.25.0"}, {"name": "v4.24.0"}})
+			return
+		}
+		index(w, r)
+	}))
+	defer server.Close()
+	t.Setenv(GitHubAPIURLEnv, server.URL)
+	t.Setenv("GITHUB_TOKEN", "ghe-token")
+	t.Setenv(CacheDirEnv, "off")
+	stubs := gostub.Stub(&contentCache, newContentCache()).Stub(&rootTrees, newRootTreeCache()).Stub(&blockIndexes, newBlockIndexCache())
+	defer stubs.Reset()
+
+	code, err := GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Client", "v4.25.0")
+	require.NoError(t, err)
+	assert.Equal(t, "type Client struct {}", code)
+
+	_, err = GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Missing", "v4.25.0")
+	assert.ErrorIs(t, err, NotFoundError)
+
+	tags, err := ListSupportedTags(context.Background(), AzureRMInternal)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v4.24.0", "v4.25.0"}, tags)
+
+	for _, authorization := range authorizations {
+		assert.Equal(t, "Bearer ghe-token", authorization)
+	}
+}
+
+func TestNewGitHubClient_PublicAPIURL(t *testing.T) {
+	for _, apiURL := range []string{"", "https://api.github.com", "https://api.github.com/"} {
+		t.Run(apiURL, func(t *testing.T) {
+			t.Setenv(GitHubAPIURLEnv, apiURL)
+			client, err := newGitHubClient()
+			require.NoError(t, err)
+			assert.Equal(t, "https://api.github.com/", client.BaseURL.String())
+		})
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "", expected: defaultRequestTimeout},
+		{value: "10s", expected: 10 * time.Second},
+		{value: "invalid", expected: defaultRequestTimeout},
+		{value: "-1s", expected: defaultRequestTimeout},
+	}
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			t.Setenv(RequestTimeoutEnv, c.value)
+			assert.Equal(t, c.expected, requestTimeout())
+		})
+	}
+}
+
+func TestReadURLContent_Timeout(t *testing.T) {
+	stubRateLimitedGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	t.Setenv(RequestTimeoutEnv, "50ms")
+
+	_, err := GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Client", "v4.25.0")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestListTags_CancelledBetweenPages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var server string
+	requests := 0
+	stubRateLimitedGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v3/repos/lonegunmanb/terraform-provider-azurerm-index/tags?page=%d>; rel="next"`, server, requests+1))
+		_ = json.NewEncoder(w).Encode([]map[string]any{{"name": fmt.Sprintf("v4.%d.0", requests)}})
+		cancel()
+	})
+	server = os.Getenv(GitHubAPIURLEnv)
+
+	_, err := ListSupportedTags(ctx, AzureRMInternal)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, requests)
+}
@@ -1,10 +1,44 @@
 package gophon
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
 )
 
+// maxSymbolQueries caps the symbols read by one GetGolangSourceCodes call
+const maxSymbolQueries = 50
+
+// SymbolQuery identifies the source code of a symbol to read with GetGolangSourceCodes
+type SymbolQuery struct {
+	Namespace string `json:"namespace"`
+	Symbol    string `json:"symbol"`
+	Receiver  string `json:"receiver,omitempty"`
+	Name      string `json:"name"`
+	Tag       string `json:"tag,omitempty"`
+}
+
+// Key returns the key of the query in the result of GetGolangSourceCodes, the namespace followed by the index file
+// name without extension, like `github.com/hashicorp/terraform-provider-azurerm/internal/services/network/func.expandSubnets`,
+// with `@tag` appended when the query has a tag
+func (q SymbolQuery) Key() string {
+	key := strings.TrimSuffix(q.Namespace, "/") + "/" + strings.TrimSuffix(symbolFileName(Symbol{Symbol: q.Symbol, Receiver: q.Receiver, Name: q.Name}), ".goindex")
+	if q.Tag != "" {
+		key += "@" + q.Tag
+	}
+	return key
+}
+
+// SymbolSource is the source code of a symbol read by GetGolangSourceCodes, or the error reading it
+type SymbolSource struct {
+	Source   string          `json:"source,omitempty"`
+	Location *SourceLocation `json:"location,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
 var validSymbols = map[string]struct{}{
 	"func":   {},
 	"method": {},
@@ -12,16 +46,10 @@ var validSymbols = map[string]struct{}{
 	"var":    {},
 }
 
-func GetGolangSourceCode(namespace, symbol, receiver, name, tag string) (string, error) {
-	var remoteKey string
-	for _, n := range Namespaces {
-		if strings.HasPrefix(namespace, n) {
-			remoteKey = n
-			break
-		}
-	}
-	if remoteKey == "" {
-		return "", fmt.Errorf("unsupported namespace: %s", namespace)
+func GetGolangSourceCode(ctx context.Context, namespace, symbol, receiver, name, tag string) (string, error) {
+	remoteIndex, err := namespaceRemoteIndex(namespace)
+	if err != nil {
+		return "", err
 	}
 	if _, ok := validSymbols[symbol]; !ok {
 		return "", fmt.Errorf("unsupported symbol: %s", symbol)
@@ -32,16 +60,75 @@ func GetGolangSourceCode(namespace, symbol, receiver, name, tag string) (string,
 	if receiver != "" && symbol != "method" {
 		return "", fmt.Errorf("receiver is only valid for methods")
 	}
-	remoteIndex := RemoteIndexMap[remoteKey]
 	//baseUrl := strings.ReplaceAll(remoteIndex.BaseUrl, "{version}", version)
 	namespace = strings.TrimPrefix(namespace, remoteIndex.PackagePath)
 	path := fmt.Sprintf("%s%s/%s.%s.%s.goindex", "index", namespace, symbol, receiver, name)
 	if receiver == "" {
 		path = fmt.Sprintf("%s%s/%s.%s.goindex", "index", namespace, symbol, name)
 	}
-	content, err := readURLContent(remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, path, tag)
+	content, err := readURLContent(ctx, remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, path, resolveTag(ctx, remoteIndex, tag))
 	if err != nil {
 		return "", fmt.Errorf("failed to read content from URL: %w", err)
 	}
 	return string(content), nil
 }
+
+// namespaceRemoteIndex returns the index of the supported namespace namespace is in, like
+// `github.com/hashicorp/terraform-provider-azurerm/internal/services/network` for AzureRMInternal. The longest
+// supported namespace wins, so registered namespaces can be nested in others.
+func namespaceRemoteIndex(namespace string) (RemoteIndex, error) {
+	var match string
+	for _, n := range Namespaces {
+		if strings.HasPrefix(namespace, n) && len(n) > len(match) {
+			match = n
+		}
+	}
+	if match == "" {
+		return RemoteIndex{}, fmt.Errorf("unsupported namespace: %s", namespace)
+	}
+	return RemoteIndexMap[match], nil
+}
+
+// GetGolangSourceCodes reads the source code of every query concurrently, keyed by SymbolQuery.Key, so a chain of
+// helper functions can be read in one call. A symbol that can't be read has its Error set instead of failing the others.
+// The location of every symbol is returned too when includeLocation is true.
+func GetGolangSourceCodes(ctx context.Context, queries []SymbolQuery, includeLocation bool) (map[string]SymbolSource, error) {
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("symbols cannot be empty")
+	}
+	if len(queries) > maxSymbolQueries {
+		return nil, fmt.Errorf("too many symbols: %d, at most %d can be read at once", len(queries), maxSymbolQueries)
+	}
+	sources := make(map[string]SymbolSource, len(queries))
+	var mu sync.Mutex
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(8)
+	for _, q := range queries {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			code := &SourceCode{}
+			var err error
+			if includeLocation {
+				code, err = GetGolangSourceCodeWithLocation(gctx, q.Namespace, q.Symbol, q.Receiver, q.Name, q.Tag)
+			} else {
+				code.Source, err = GetGolangSourceCode(gctx, q.Namespace, q.Symbol, q.Receiver, q.Name, q.Tag)
+			}
+			var source SymbolSource
+			if err != nil {
+				source = SymbolSource{Error: err.Error()}
+			} else {
+				source = SymbolSource{Source: code.Source, Location: code.Location}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			sources[q.Key()] = source
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
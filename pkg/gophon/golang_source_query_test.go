@@ -1,19 +1,62 @@
 package gophon
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
 
 func TestQueryTypeWithTag(t *testing.T) {
-	code, err := GetGolangSourceCode("github.com/hashicorp/terraform-provider-azurerm/internal/clients", "type", "", "Client", "v4.25.0")
+	code, err := GetGolangSourceCode(context.Background(), "github.com/hashicorp/terraform-provider-azurerm/internal/clients", "type", "", "Client", "v4.25.0")
 	require.NoError(t, err)
 	assert.Contains(t, code, "type Client struct {")
 }
 
 func TestQueryMethodWithTag(t *testing.T) {
-	code, err := GetGolangSourceCode("github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps", "method", "ContainerAppResource", "Create", "v4.25.0")
+	code, err := GetGolangSourceCode(context.Background(), "github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps", "method", "ContainerAppResource", "Create", "v4.25.0")
 	require.NoError(t, err)
 	assert.Contains(t, code, "func (r ContainerAppResource) Create() sdk.ResourceFunc {")
 }
+
+func TestGetGolangSourceCodes(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/internal/services/network/func.expandSubnets.goindex":                 "func expandSubnets() {}",
+		"index/internal/services/network/method.VirtualNetworkResource.Read.goindex": "func (r VirtualNetworkResource) Read() {}",
+	})
+	network := AzureRMInternal + "/services/network"
+
+	sources, err := GetGolangSourceCodes(context.Background(), []SymbolQuery{
+		{Namespace: network, Symbol: "func", Name: "expandSubnets"},
+		{Namespace: network, Symbol: "method", Receiver: "VirtualNetworkResource", Name: "Read"},
+		{Namespace: network, Symbol: "func", Name: "missing", Tag: "v4.0.0"},
+		{Namespace: network, Symbol: "const", Name: "invalid"},
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]SymbolSource{
+		network + "/func.expandSubnets":                 {Source: "func expandSubnets() {}"},
+		network + "/method.VirtualNetworkResource.Read": {Source: "func (r VirtualNetworkResource) Read() {}"},
+		network + "/func.missing@v4.0.0":                {Error: "failed to read content from URL: source code not found (404)"},
+		network + "/const.invalid":                      {Error: "unsupported symbol: const"},
+	}, sources)
+}
+
+func TestGetGolangSourceCodes_InvalidQueryWithLocation(t *testing.T) {
+	stubIndexFiles(t, map[string]string{})
+
+	sources, err := GetGolangSourceCodes(context.Background(), []SymbolQuery{
+		{Namespace: "github.com/example/unsupported", Symbol: "func", Name: "missing"},
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]SymbolSource{
+		"github.com/example/unsupported/func.missing": {Error: "unsupported namespace: github.com/example/unsupported"},
+	}, sources)
+}
+
+func TestGetGolangSourceCodes_InvalidQueries(t *testing.T) {
+	_, err := GetGolangSourceCodes(context.Background(), nil, false)
+	assert.EqualError(t, err, "symbols cannot be empty")
+	_, err = GetGolangSourceCodes(context.Background(), make([]SymbolQuery, maxSymbolQueries+1), false)
+	assert.ErrorContains(t, err, "too many symbols")
+}
@@ -0,0 +1,57 @@
+package gophon
+
+import "regexp"
+
+// Implementation styles of terraform blocks, returned by DetectImplementationStyle
+const (
+	// ImplementationPluginFramework blocks are implemented with terraform-plugin-framework, values are typed like
+	// `types.String` and unknown values must be handled explicitly
+	ImplementationPluginFramework = "plugin-framework"
+	// ImplementationTypedSDK blocks are azurerm typed resources, `sdk.Resource` implementations decoding the state into a
+	// model struct with `metadata.Decode`, built on plugin SDKv2
+	ImplementationTypedSDK = "typed-sdk"
+	// ImplementationSDKv2 blocks are implemented with plugin SDKv2, reading the state with `d.Get` on `*schema.ResourceData`
+	// or its `pluginsdk` alias
+	ImplementationSDKv2 = "sdkv2"
+	// ImplementationUnknown is returned when the source code has no hint of its implementation style
+	ImplementationUnknown = "unknown"
+)
+
+// ImplementationStyle is the metadata about how a terraform block is implemented
+type ImplementationStyle struct {
+	Implementation string `json:"implementation"`
+	Description    string `json:"description"`
+}
+
+var implementationStyles = []struct {
+	style       string
+	pattern     *regexp.Regexp
+	description string
+}{
+	{
+		style:       ImplementationPluginFramework,
+		pattern:     regexp.MustCompile(`\b(resource|datasource|ephemeral|function|provider)\.\w+(Request|Response)\b`),
+		description: "Implemented with terraform-plugin-framework: the plan, state and config are typed values like `types.String` read with `req.Plan.Get`, unknown and null values are distinct, and diagnostics are appended to the response.",
+	},
+	{
+		style:       ImplementationTypedSDK,
+		pattern:     regexp.MustCompile(`\bsdk\.(Resource\w*|DataSource\w*)\b|\bmetadata\.(Decode|Encode|ResourceData)\b|(?m)^func \(\w+ \*?\w+\) (Arguments|Attributes|ModelObject|ResourceType|IDValidationFunc)\(\)`),
+		description: "Implemented as an azurerm typed resource on plugin SDKv2: the state is decoded into a model struct with `metadata.Decode` and written with `metadata.Encode`, the schema comes from the `Arguments` and `Attributes` methods.",
+	},
+	{
+		style:       ImplementationSDKv2,
+		pattern:     regexp.MustCompile(`\b(pluginsdk|schema)\.(ResourceData|Resource|Schema)\b`),
+		description: "Implemented with plugin SDKv2: the state is read with `d.Get` and written with `d.Set` on `*schema.ResourceData` (`*pluginsdk.ResourceData` in azurerm), unknown and null values read as zero values.",
+	},
+}
+
+// DetectImplementationStyle detects how the terraform block with the source code of one of its entrypoints is
+// implemented, from the types the source code uses
+func DetectImplementationStyle(source string) ImplementationStyle {
+	for _, s := range implementationStyles {
+		if s.pattern.MatchString(source) {
+			return ImplementationStyle{Implementation: s.style, Description: s.description}
+		}
+	}
+	return ImplementationStyle{Implementation: ImplementationUnknown, Description: "The implementation style couldn't be detected from the source code."}
+}
@@ -0,0 +1,58 @@
+package gophon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectImplementationStyle(t *testing.T) {
+	cases := []struct {
+		desc     string
+		source   string
+		expected string
+	}{
+		{
+			desc:     "plugin framework resource",
+			source:   "func (r *vpcResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {}",
+			expected: ImplementationPluginFramework,
+		},
+		{
+			desc:     "ephemeral resource",
+			source:   "func (e *KeyVaultSecretEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {}",
+			expected: ImplementationPluginFramework,
+		},
+		{
+			desc:     "typed resource",
+			source:   "func (r ContainerAppResource) Create() sdk.ResourceFunc {\n\treturn sdk.ResourceFunc{}\n}",
+			expected: ImplementationTypedSDK,
+		},
+		{
+			desc:     "typed resource arguments",
+			source:   "func (r ContainerAppResource) Arguments() map[string]*pluginsdk.Schema {\n\treturn nil\n}",
+			expected: ImplementationTypedSDK,
+		},
+		{
+			desc:     "untyped azurerm resource",
+			source:   "func resourceSubnetRead(d *pluginsdk.ResourceData, meta interface{}) error {\n\treturn nil\n}",
+			expected: ImplementationSDKv2,
+		},
+		{
+			desc:     "sdkv2 resource",
+			source:   "func resourceVPCCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {\n\treturn nil\n}",
+			expected: ImplementationSDKv2,
+		},
+		{
+			desc:     "unknown",
+			source:   "func expandSubnets() {}",
+			expected: ImplementationUnknown,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			style := DetectImplementationStyle(c.source)
+			assert.Equal(t, c.expected, style.Implementation)
+			assert.NotEmpty(t, style.Description)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package gophon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// IndexRef is the version of a repository that served the content of a response
+type IndexRef struct {
+	// Repository is the GitHub repository, like `lonegunmanb/terraform-provider-azurerm-index`
+	Repository string `json:"repository"`
+	// Ref is the tag read, empty for the default branch
+	Ref string `json:"ref,omitempty"`
+	// Commit is the commit SHA the ref resolved to when the content was read, empty for local indexes
+	Commit string `json:"commit,omitempty"`
+	// LocalPath is the clone the content was read from for local indexes
+	LocalPath string `json:"local_path,omitempty"`
+}
+
+// String returns the ref like `lonegunmanb/terraform-provider-azurerm-index@v4.25.0 (commit 1a2b3c4)`
+func (r IndexRef) String() string {
+	ref := r.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	s := fmt.Sprintf("%s@%s", r.Repository, ref)
+	switch {
+	case r.LocalPath != "":
+		s += fmt.Sprintf(" (local %s)", r.LocalPath)
+	case r.Commit != "":
+		s += fmt.Sprintf(" (commit %s)", r.Commit[:min(len(r.Commit), 7)])
+	}
+	return s
+}
+
+// IndexRefs collects the versions of the repositories read with a context returned by WithIndexRefs
+type IndexRefs struct {
+	mu   sync.Mutex
+	refs map[IndexRef]struct{}
+}
+
+type indexRefsKey struct{}
+
+// WithIndexRefs returns a context recording the versions of the repositories read with it into the returned
+// IndexRefs, so a response can tell which index commit served it
+func WithIndexRefs(ctx context.Context) (context.Context, *IndexRefs) {
+	refs := &IndexRefs{refs: make(map[IndexRef]struct{})}
+	return context.WithValue(ctx, indexRefsKey{}, refs), refs
+}
+
+// List returns the recorded versions sorted by repository and ref
+func (r *IndexRefs) List() []IndexRef {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	refs := make([]IndexRef, 0, len(r.refs))
+	for ref := range r.refs {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Repository != refs[j].Repository {
+			return refs[i].Repository < refs[j].Repository
+		}
+		return refs[i].Ref < refs[j].Ref
+	})
+	return refs
+}
+
+// recordIndexRef records ref in the IndexRefs of ctx, if any
+func recordIndexRef(ctx context.Context, ref IndexRef) {
+	refs, ok := ctx.Value(indexRefsKey{}).(*IndexRefs)
+	if !ok {
+		return
+	}
+	refs.mu.Lock()
+	defer refs.mu.Unlock()
+	refs.refs[ref] = struct{}{}
+}
@@ -0,0 +1,65 @@
+package gophon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// IndexDirEnv points to a directory of locally cloned index repositories, like
+// `$GOPHON_INDEX_DIR/terraform-provider-azurerm-index`. When the repository of a namespace is cloned there, its
+// source code is read from the clone instead of GitHub.
+const IndexDirEnv = "GOPHON_INDEX_DIR"
+
+// localIndexPath returns the local clone of the owner/repo index repository, or an empty string when it's read from
+// GitHub. The LocalPath of the RemoteIndex takes precedence over IndexDirEnv.
+func localIndexPath(owner, repo string) string {
+	for _, remoteIndex := range RemoteIndexMap {
+		if remoteIndex.GitHubOwner == owner && remoteIndex.GitHubRepo == repo && remoteIndex.LocalPath != "" {
+			return remoteIndex.LocalPath
+		}
+	}
+	if dir := os.Getenv(IndexDirEnv); dir != "" {
+		p := filepath.Join(dir, repo)
+		if info, err := os.Stat(p); err == nil && info.IsDir() {
+			return p
+		}
+	}
+	return ""
+}
+
+// readLocalFile reads path from a local index, missing files are NotFoundError like on GitHub
+func readLocalFile(root, path string) ([]byte, error) {
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, NotFoundError
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return content, nil
+}
+
+// readLocalDirectory lists the entries of path in a local index the way GitHub lists directory contents
+func readLocalDirectory(root, path string) ([]*github.RepositoryContent, error) {
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(path)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, NotFoundError
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory %s: %w", path, err)
+	}
+	contents := make([]*github.RepositoryContent, 0, len(entries))
+	for _, entry := range entries {
+		entryType := "file"
+		if entry.IsDir() {
+			entryType = "dir"
+		}
+		contents = append(contents, &github.RepositoryContent{Name: github.Ptr(entry.Name()), Type: github.Ptr(entryType)})
+	}
+	return contents, nil
+}
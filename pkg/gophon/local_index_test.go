@@ -0,0 +1,73 @@
+package gophon
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLocalIndexFile(t *testing.T, root, path, content string) {
+	p := filepath.Join(root, filepath.FromSlash(path))
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+}
+
+func TestLocalIndexDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(IndexDirEnv, dir)
+	root := filepath.Join(dir, "terraform-provider-azurerm-index")
+	writeLocalIndexFile(t, root, "index/internal/clients/type.Client.goindex", "type Client struct {}")
+	writeLocalIndexFile(t, root, "index/internal/clients/func.Build.goindex", "func Build() {}")
+	writeLocalIndexFile(t, root, "index/internal/clients/auth/type.Config.goindex", "type Config struct {}")
+
+	code, err := GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Client", "v4.25.0")
+	require.NoError(t, err)
+	assert.Equal(t, "type Client struct {}", code)
+
+	_, err = GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Missing", "")
+	assert.ErrorIs(t, err, NotFoundError)
+
+	symbols, err := SearchSymbols(context.Background(), AzureRMInternal+"/clients", "", "", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, []Symbol{{Symbol: "func", Name: "Build"}, {Symbol: "type", Name: "Client"}}, symbols)
+
+	listing, err := ListPackages(context.Background(), AzureRMInternal+"/clients", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{AzureRMInternal + "/clients/auth"}, listing.Packages)
+
+	tags, err := ListSupportedTags(context.Background(), AzureRMInternal)
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestLocalIndexPath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "terraform-provider-azuread-index"), 0755))
+	t.Setenv(IndexDirEnv, dir)
+	remoteIndexMap := maps.Clone(RemoteIndexMap)
+	azureRM := remoteIndexMap[AzureRMInternal]
+	azureRM.LocalPath = "/src/azurerm-index"
+	remoteIndexMap[AzureRMInternal] = azureRM
+	stubs := gostub.Stub(&RemoteIndexMap, remoteIndexMap)
+	defer stubs.Reset()
+
+	cases := []struct {
+		repo     string
+		expected string
+	}{
+		{repo: "terraform-provider-azurerm-index", expected: "/src/azurerm-index"},
+		{repo: "terraform-provider-azuread-index", expected: filepath.Join(dir, "terraform-provider-azuread-index")},
+		{repo: "terraform-provider-aws-index", expected: ""},
+	}
+	for _, c := range cases {
+		t.Run(c.repo, func(t *testing.T) {
+			assert.Equal(t, c.expected, localIndexPath("lonegunmanb", c.repo))
+		})
+	}
+}
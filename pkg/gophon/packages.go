@@ -0,0 +1,49 @@
+package gophon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PackageListing is the content of an indexed namespace
+type PackageListing struct {
+	Namespace string   `json:"namespace"`
+	Packages  []string `json:"packages"`
+	Files     []string `json:"files,omitempty"`
+}
+
+// ListPackages lists the sub-packages directly under namespace, like
+// `github.com/hashicorp/terraform-provider-azurerm/internal/services` for
+// `github.com/hashicorp/terraform-provider-azurerm/internal`, so they can be navigated one level at a time. The index
+// files of namespace are listed too when includeFiles is true.
+func ListPackages(ctx context.Context, namespace, tag string, includeFiles bool) (*PackageListing, error) {
+	namespace = strings.TrimSuffix(namespace, "/")
+	remoteIndex, err := namespaceRemoteIndex(namespace)
+	if err != nil {
+		return nil, err
+	}
+	path := "index" + strings.TrimPrefix(namespace, remoteIndex.PackagePath)
+	entries, err := readDirectoryContent(ctx, remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, path, resolveTag(ctx, remoteIndex, tag))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages of namespace %s: %w", namespace, err)
+	}
+	listing := &PackageListing{
+		Namespace: namespace,
+		Packages:  make([]string, 0),
+	}
+	for _, entry := range entries {
+		switch entry.GetType() {
+		case "dir":
+			listing.Packages = append(listing.Packages, namespace+"/"+entry.GetName())
+		case "file":
+			if includeFiles {
+				listing.Files = append(listing.Files, entry.GetName())
+			}
+		}
+	}
+	sort.Strings(listing.Packages)
+	sort.Strings(listing.Files)
+	return listing, nil
+}
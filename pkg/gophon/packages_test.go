@@ -0,0 +1,49 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPackages(t *testing.T) {
+	entries := []string{"network/", "containerapps/", "func.Register.goindex", "type.Client.goindex"}
+	cases := []struct {
+		desc         string
+		includeFiles bool
+		expected     *PackageListing
+	}{
+		{
+			desc: "packages only",
+			expected: &PackageListing{
+				Namespace: AzureRMInternal + "/services",
+				Packages:  []string{AzureRMInternal + "/services/containerapps", AzureRMInternal + "/services/network"},
+			},
+		},
+		{
+			desc:         "with files",
+			includeFiles: true,
+			expected: &PackageListing{
+				Namespace: AzureRMInternal + "/services",
+				Packages:  []string{AzureRMInternal + "/services/containerapps", AzureRMInternal + "/services/network"},
+				Files:     []string{"func.Register.goindex", "type.Client.goindex"},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			stubIndexDirectory(t, "index/internal/services", entries...)
+
+			listing, err := ListPackages(context.Background(), AzureRMInternal+"/services/", "", c.includeFiles)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, listing)
+		})
+	}
+}
+
+func TestListPackages_UnsupportedNamespace(t *testing.T) {
+	_, err := ListPackages(context.Background(), "github.com/unknown/module", "", false)
+	assert.EqualError(t, err, "unsupported namespace: github.com/unknown/module")
+}
@@ -0,0 +1,99 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// providerConfigureSymbol is a symbol configuring a provider, its namespace is relative to the PackagePath of the index
+type providerConfigureSymbol struct {
+	namespace string
+	symbol    string
+	receiver  string
+	name      string
+}
+
+// providerConfigureSymbols are the symbols reading the provider block and building the API clients of each provider
+// index, in the order they run. Symbols moved between provider versions are listed at every location they had, the ones
+// missing from a version are skipped.
+var providerConfigureSymbols = map[string][]providerConfigureSymbol{
+	AzureRMInternal: {
+		{namespace: "internal/provider", symbol: "func", name: "providerConfigure"},
+		{namespace: "internal/provider", symbol: "func", name: "buildClient"},
+		{namespace: "internal/provider/framework", symbol: "method", receiver: "azureRmFrameworkProvider", name: "Configure"},
+		{namespace: "internal/provider/framework", symbol: "method", receiver: "ProviderConfig", name: "Load"},
+		{namespace: "internal/clients", symbol: "method", receiver: "Client", name: "Build"},
+	},
+	AzureADInternal: {
+		{namespace: "internal/provider", symbol: "func", name: "providerConfigure"},
+		{namespace: "internal/provider", symbol: "func", name: "buildClient"},
+		{namespace: "internal/clients", symbol: "method", receiver: "Client", name: "build"},
+	},
+	AWSInternal: {
+		{namespace: "internal/provider", symbol: "func", name: "configure"},
+		{namespace: "internal/provider/sdkv2", symbol: "func", name: "configure"},
+		{namespace: "internal/provider/fwprovider", symbol: "method", receiver: "fwprovider", name: "Configure"},
+		{namespace: "internal/provider/framework", symbol: "method", receiver: "frameworkProvider", name: "Configure"},
+		{namespace: "internal/conns", symbol: "method", receiver: "Config", name: "ConfigureProvider"},
+	},
+	AWSCCInternal: {
+		{namespace: "internal/provider", symbol: "method", receiver: "ccProvider", name: "Configure"},
+		{namespace: "internal/provider", symbol: "func", name: "newProviderData"},
+	},
+	GoogleProvider: {
+		{namespace: "google/provider", symbol: "func", name: "ProviderConfigure"},
+		{namespace: "google/fwprovider", symbol: "method", receiver: "FrameworkProvider", name: "Configure"},
+		{namespace: "google/transport", symbol: "method", receiver: "Config", name: "LoadAndValidate"},
+	},
+	GoogleBetaProvider: {
+		{namespace: "google-beta/provider", symbol: "func", name: "ProviderConfigure"},
+		{namespace: "google-beta/fwprovider", symbol: "method", receiver: "FrameworkProvider", name: "Configure"},
+		{namespace: "google-beta/transport", symbol: "method", receiver: "Config", name: "LoadAndValidate"},
+	},
+}
+
+// readProviderConfigure reads the source code configuring a provider, where its authentication, endpoints and features
+// are wired, as the `configure` entrypoint of the `provider` block whose terraform type is the provider name. The
+// symbols are concatenated in the order they run, each one preceded by a comment naming it, like
+// `// github.com/hashicorp/terraform-provider-azurerm/internal/clients.Client.Build`.
+func readProviderConfigure(ctx context.Context, providerName, terraformType, tag string) (*terraformEntrypoint, error) {
+	if providerName == "" {
+		providerName = terraformType
+	}
+	if providerName != terraformType {
+		return nil, fmt.Errorf("terraform type of a provider must be its name, got %s for provider %s", terraformType, providerName)
+	}
+	indexKey, ok := ProviderIndexMap[providerName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider type: %s, supported providers are: %v", providerName, GetSupportedProviders())
+	}
+	remoteIndex := RemoteIndexMap[indexKey]
+	tag = resolveTag(ctx, remoteIndex, tag)
+	entrypoint := &terraformEntrypoint{tag: tag}
+	var sources []string
+	for _, s := range providerConfigureSymbols[indexKey] {
+		namespace := remoteIndex.PackagePath + "/" + s.namespace
+		source, err := GetGolangSourceCode(ctx, namespace, s.symbol, s.receiver, s.name, tag)
+		if errors.Is(err, NotFoundError) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if entrypoint.namespace == "" {
+			entrypoint.namespace = namespace
+		}
+		name := s.name
+		if s.receiver != "" {
+			name = s.receiver + "." + name
+		}
+		sources = append(sources, fmt.Sprintf("// %s.%s\n%s", namespace, name, source))
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("provider %s has no configure: %w", providerName, NotFoundError)
+	}
+	entrypoint.source = strings.Join(sources, "\n\n")
+	return entrypoint, nil
+}
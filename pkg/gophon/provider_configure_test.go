@@ -0,0 +1,42 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProviderTerraformSourceCode_ProviderConfigure(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/internal/provider/func.providerConfigure.goindex": "func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {}",
+		"index/internal/clients/method.Client.Build.goindex":     "func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error {}",
+	})
+
+	code, err := GetProviderTerraformSourceCode(context.Background(), "", "provider", "azurerm", "configure", "")
+	require.NoError(t, err)
+	assert.Equal(t, "// "+AzureRMInternal+"/provider.providerConfigure\n"+
+		"func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {}\n\n"+
+		"// "+AzureRMInternal+"/clients.Client.Build\n"+
+		"func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error {}", code)
+
+	tests := []struct {
+		desc     string
+		provider string
+		typ      string
+		notFound bool
+	}{
+		{desc: "no configure indexed", typ: "awscc", notFound: true},
+		{desc: "provider mismatch", provider: "azurerm", typ: "azuread"},
+		{desc: "unsupported provider", typ: "azurestack"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := GetProviderTerraformSourceCode(context.Background(), tt.provider, "provider", tt.typ, "configure", "")
+			require.Error(t, err)
+			assert.Equal(t, tt.notFound, errors.Is(err, NotFoundError))
+		})
+	}
+}
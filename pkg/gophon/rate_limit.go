@@ -0,0 +1,106 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
+)
+
+var (
+	// rateLimitRetries is the number of times a rate limited GitHub request is retried
+	rateLimitRetries = 3
+	// maxRateLimitWait is the longest wait before a retry, requests whose rate limit resets later fail right away
+	maxRateLimitWait = 30 * time.Second
+	// waitRateLimit waits d before a retry, it's a variable so tests don't sleep
+	waitRateLimit = func(ctx context.Context, d time.Duration) error {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}
+)
+
+// rateLimitTransport retries GitHub GET requests rejected by the primary or secondary rate limit, after the wait GitHub
+// asks for. Responses are returned as is when the wait is longer than maxRateLimitWait or the retries are exhausted.
+type rateLimitTransport struct {
+	base http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || req.Method != http.MethodGet {
+			return resp, err
+		}
+		wait, limited := rateLimitWait(resp, attempt, time.Now())
+		if !limited || attempt >= rateLimitRetries || wait > maxRateLimitWait {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		logging.FromContext(req.Context()).Info("gophon rate limited by GitHub, retrying", "status", resp.StatusCode, "wait", wait, "url", req.URL.String())
+		if err = waitRateLimit(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rateLimitWait returns how long to wait before retrying a rate limited response. `Retry-After` is honored first,
+// then the reset time of an exhausted primary rate limit, other `429 Too Many Requests` back off exponentially.
+// 403 responses without rate limit headers are permission errors and aren't rate limited.
+func rateLimitWait(resp *http.Response, attempt int, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Unix(reset, 0).Sub(now), time.Second), true
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return time.Second << attempt, true
+	}
+	return 0, false
+}
+
+// describeRateLimit adds the remaining quota and the reset time of GitHub rate limit errors to err, with a hint to
+// set GITHUB_TOKEN for unauthenticated requests. Other errors are returned as is.
+func describeRateLimit(err error) error {
+	hint := ""
+	if os.Getenv("GITHUB_TOKEN") == "" {
+		hint = ", set GITHUB_TOKEN to raise the rate limit of unauthenticated requests"
+	}
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return fmt.Errorf("GitHub API rate limit exceeded, %d of %d requests remaining, resets at %s%s: %w",
+			rateLimitErr.Rate.Remaining, rateLimitErr.Rate.Limit, rateLimitErr.Rate.Reset.Format(time.RFC3339), hint, err)
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		retry := "later"
+		if abuseErr.RetryAfter != nil {
+			retry = "in " + abuseErr.RetryAfter.String()
+		}
+		return fmt.Errorf("GitHub API secondary rate limit exceeded, retry %s%s: %w", retry, hint, err)
+	}
+	var responseErr *github.ErrorResponse
+	if errors.As(err, &responseErr) && responseErr.Response != nil && responseErr.Response.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("GitHub API rate limit exceeded (429)%s: %w", hint, err)
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package gophon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRateLimitedGitHub serves GitHub API responses from handler through GitHubAPIURLEnv without caching, and records
+// the waits before retries instead of sleeping
+func stubRateLimitedGitHub(t *testing.T, handler http.HandlerFunc) *[]time.Duration {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	t.Setenv(GitHubAPIURLEnv, server.URL)
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv(CacheDirEnv, "off")
+	var waits []time.Duration
+	stubs := gostub.Stub(&contentCache, newContentCache()).Stub(&rootTrees, newRootTreeCache()).Stub(&blockIndexes, newBlockIndexCache()).Stub(&waitRateLimit, func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	})
+	t.Cleanup(stubs.Reset)
+	return &waits
+}
+
+func writeRateLimited(w http.ResponseWriter, reset time.Time) {
+	w.Header().Set("X-RateLimit-Limit", "60")
+	w.Header().Set("X-RateLimit-Remaining", "0")
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	w.WriteHeader(http.StatusForbidden)
+	_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+}
+
+func TestRateLimit_Retry(t *testing.T) {
+	requests := 0
+	handler := gitTreeHandler(t, map[string]string{"index/internal/clients/type.Client.goindex": "type Client struct {}"}, "v4.25.0")
+	waits := stubRateLimitedGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			writeRateLimited(w, time.Now().Add(10*time.Second))
+			return
+		}
+		handler(w, r)
+	})
+
+	code, err := GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Client", "v4.25.0")
+	require.NoError(t, err)
+	assert.Equal(t, "type Client struct {}", code)
+	// the retried commit of the ref, its root tree, the trees of index, internal and clients, and the blob
+	assert.Equal(t, 7, requests)
+	require.Len(t, *waits, 1)
+	assert.InDelta(t, 10*time.Second, (*waits)[0], float64(2*time.Second))
+}
+
+func TestRateLimit_QuotaInError(t *testing.T) {
+	requests := 0
+	reset := time.Now().Add(time.Hour)
+	waits := stubRateLimitedGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeRateLimited(w, reset)
+	})
+
+	_, err := GetGolangSourceCode(context.Background(), AzureRMInternal+"/clients", "type", "", "Client", "v4.25.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GitHub API rate limit exceeded, 0 of 60 requests remaining, resets at "+reset.Format(time.RFC3339))
+	assert.Contains(t, err.Error(), "set GITHUB_TOKEN to raise the rate limit")
+	assert.Equal(t, 1, requests)
+	assert.Empty(t, *waits)
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cases := []struct {
+		desc     string
+		status   int
+		headers  map[string]string
+		attempt  int
+		expected time.Duration
+		limited  bool
+	}{
+		{desc: "ok", status: http.StatusOK},
+		{desc: "forbidden", status: http.StatusForbidden},
+		{desc: "retry after", status: http.StatusForbidden, headers: map[string]string{"Retry-After": "5"}, expected: 5 * time.Second, limited: true},
+		{desc: "reset", status: http.StatusForbidden, headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1700000020"}, expected: 20 * time.Second, limited: true},
+		{desc: "reset passed", status: http.StatusForbidden, headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1699999990"}, expected: time.Second, limited: true},
+		{desc: "too many requests", status: http.StatusTooManyRequests, attempt: 2, expected: 4 * time.Second, limited: true},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			resp := &http.Response{StatusCode: c.status, Header: http.Header{}}
+			for k, v := range c.headers {
+				resp.Header.Set(k, v)
+			}
+			wait, limited := rateLimitWait(resp, c.attempt, now)
+			assert.Equal(t, c.limited, limited)
+			assert.Equal(t, c.expected, wait)
+		})
+	}
+}
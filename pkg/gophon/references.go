@@ -0,0 +1,193 @@
+package gophon
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// maxReferenceFiles caps the index files read by one reference or source search, every file is a GitHub request
+var maxReferenceFiles = 1000
+
+// Reference is an indexed symbol whose source code references another symbol
+type Reference struct {
+	Namespace string `json:"namespace"`
+	Symbol
+	Lines []string `json:"lines"`
+}
+
+// ReferenceSearchResult is the result of FindReferences
+type ReferenceSearchResult struct {
+	References []Reference `json:"references"`
+	// Scanned is the number of symbols whose source code was searched
+	Scanned int `json:"scanned"`
+	// Truncated is true when the namespaces have more symbols than maxReferenceFiles, the others weren't searched
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// FindReferences searches the source code of the symbols indexed in namespace, and in the extra searchNamespaces, for
+// references to a func, method, type or var of namespace. The index has no references, so they're found by reading
+// every symbol and matching the name: unqualified in namespace, qualified by the package name in the other
+// namespaces, methods are matched by `.Name` on any receiver. The references are candidates that may include symbols
+// using another identifier with the same name, or miss packages imported with an alias.
+func FindReferences(ctx context.Context, namespace, symbol, receiver, name, tag string, searchNamespaces []string) (*ReferenceSearchResult, error) {
+	if _, ok := validSymbols[symbol]; !ok {
+		return nil, fmt.Errorf("unsupported symbol: %s", symbol)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	if receiver != "" && symbol != "method" {
+		return nil, fmt.Errorf("receiver is only valid for methods")
+	}
+	target := Symbol{Symbol: symbol, Receiver: receiver, Name: name}
+	namespace = strings.TrimSuffix(namespace, "/")
+	sources, err := listIndexedSources(ctx, append([]string{namespace}, searchNamespaces...), tag)
+	if err != nil {
+		return nil, err
+	}
+	sources = slices.DeleteFunc(sources, func(source indexedSource) bool {
+		return source.namespace == namespace && source.symbol == target
+	})
+	result := &ReferenceSearchResult{References: make([]Reference, 0)}
+	sources, result.Truncated = truncateSources(sources)
+	result.Scanned = len(sources)
+
+	localPattern := referencePattern(target, path.Base(namespace), true)
+	qualifiedPattern := referencePattern(target, path.Base(namespace), false)
+	var mu sync.Mutex
+	err = readIndexedSources(ctx, sources, func(source indexedSource, content string) {
+		pattern := qualifiedPattern
+		if source.namespace == namespace {
+			pattern = localPattern
+		}
+		lines := referenceLines(content, pattern)
+		if len(lines) == 0 {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		result.References = append(result.References, Reference{Namespace: source.namespace, Symbol: source.symbol, Lines: lines})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(result.References, func(i, j int) bool {
+		a, b := result.References[i], result.References[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return symbolFileName(a.Symbol) < symbolFileName(b.Symbol)
+	})
+	return result, nil
+}
+
+// referencePattern matches references to target, from its own package when local is true, otherwise qualified by
+// packageName
+func referencePattern(target Symbol, packageName string, local bool) *regexp.Regexp {
+	name := regexp.QuoteMeta(target.Name)
+	switch {
+	case target.Symbol == "method":
+		return regexp.MustCompile(`\.` + name + `\b`)
+	case local:
+		return regexp.MustCompile(`(^|[^.\w])` + name + `\b`)
+	}
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(packageName) + `\.` + name + `\b`)
+}
+
+// referenceLines returns the trimmed lines of source matching pattern
+func referenceLines(source string, pattern *regexp.Regexp) []string {
+	var lines []string
+	for _, line := range strings.Split(source, "\n") {
+		if pattern.MatchString(line) {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return lines
+}
+
+// symbolFileName returns the index file name of s
+func symbolFileName(s Symbol) string {
+	if s.Receiver == "" {
+		return fmt.Sprintf("%s.%s.goindex", s.Symbol, s.Name)
+	}
+	return fmt.Sprintf("%s.%s.%s.goindex", s.Symbol, s.Receiver, s.Name)
+}
+
+// indexedSource is the index file of a symbol in a namespace
+type indexedSource struct {
+	namespace string
+	index     RemoteIndex
+	tag       string
+	symbol    Symbol
+}
+
+func (s indexedSource) path() string {
+	return fmt.Sprintf("index%s/%s", strings.TrimPrefix(s.namespace, s.index.PackagePath), symbolFileName(s.symbol))
+}
+
+// listIndexedSources lists the index files of the symbols in namespaces at tag, duplicated namespaces are listed once
+func listIndexedSources(ctx context.Context, namespaces []string, tag string) ([]indexedSource, error) {
+	var sources []indexedSource
+	seen := make(map[string]struct{})
+	for _, n := range namespaces {
+		n = strings.TrimSuffix(n, "/")
+		if _, ok := seen[n]; ok {
+			continue
+		}
+		seen[n] = struct{}{}
+		remoteIndex, err := namespaceRemoteIndex(n)
+		if err != nil {
+			return nil, err
+		}
+		indexTag := resolveTag(ctx, remoteIndex, tag)
+		entries, err := readDirectoryContent(ctx, remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, "index"+strings.TrimPrefix(n, remoteIndex.PackagePath), indexTag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list symbols of namespace %s: %w", n, err)
+		}
+		for _, entry := range entries {
+			s, ok := parseSymbol(entry.GetName())
+			if !ok || entry.GetType() != "file" {
+				continue
+			}
+			sources = append(sources, indexedSource{namespace: n, index: remoteIndex, tag: indexTag, symbol: s})
+		}
+	}
+	return sources, nil
+}
+
+// truncateSources keeps the first maxReferenceFiles sources, and returns true when others were dropped
+func truncateSources(sources []indexedSource) ([]indexedSource, bool) {
+	if len(sources) > maxReferenceFiles {
+		return sources[:maxReferenceFiles], true
+	}
+	return sources, false
+}
+
+// readIndexedSources reads the sources concurrently and calls fn with the content of each one, fn must be safe for
+// concurrent use
+func readIndexedSources(ctx context.Context, sources []indexedSource, fn func(source indexedSource, content string)) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(8)
+	for _, source := range sources {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			content, err := readURLContent(ctx, source.index.GitHubOwner, source.index.GitHubRepo, source.path(), source.tag)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", source.path(), err)
+			}
+			fn(source, string(content))
+			return nil
+		})
+	}
+	return g.Wait()
+}
@@ -0,0 +1,106 @@
+package gophon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIndexFiles serves files, keyed by their path in the AzureRM index, as the index content
+func stubIndexFiles(t *testing.T, files map[string]string) {
+	stubTags(t)
+	stubs := gostub.Stub(&blockIndexes, newBlockIndexCache()).Stub(&readDirectoryContent, func(_ context.Context, owner string, repo string, dir string, tag string) ([]*github.RepositoryContent, error) {
+		var entries []*github.RepositoryContent
+		for p := range files {
+			if name, ok := strings.CutPrefix(p, dir+"/"); ok && !strings.Contains(name, "/") {
+				entries = append(entries, &github.RepositoryContent{Name: github.Ptr(name), Type: github.Ptr("file")})
+			}
+		}
+		return entries, nil
+	}).Stub(&readURLContent, func(_ context.Context, owner string, repo string, path string, tag string) ([]byte, error) {
+		content, ok := files[path]
+		if !ok {
+			return nil, NotFoundError
+		}
+		return []byte(content), nil
+	})
+	t.Cleanup(stubs.Reset)
+}
+
+func TestFindReferences(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/internal/services/network/func.flattenSubnets.goindex":                      "func flattenSubnets(input []Subnet) []interface{} {\n\treturn nil\n}",
+		"index/internal/services/network/method.VirtualNetworkResource.Read.goindex":       "func (r VirtualNetworkResource) Read() {\n\tsubnets := flattenSubnets(props.Subnets)\n}",
+		"index/internal/services/network/func.expandSubnets.goindex":                       "func expandSubnets() {\n\tr.flattenSubnets()\n}",
+		"index/internal/services/network/func.unrelated.goindex":                           "func unrelated() {\n\tflattenSubnetsV2()\n}",
+		"index/internal/services/containerapps/func.flattenEnvironment.goindex":            "func flattenEnvironment() {\n\tnetwork.flattenSubnets(nil)\n\tflattenSubnets(nil)\n}",
+		"index/internal/services/containerapps/method.ContainerAppResource.Create.goindex": "func (r ContainerAppResource) Create() {}",
+	})
+
+	result, err := FindReferences(context.Background(), AzureRMInternal+"/services/network", "func", "", "flattenSubnets", "", []string{AzureRMInternal + "/services/containerapps"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Scanned)
+	assert.False(t, result.Truncated)
+	assert.Equal(t, []Reference{
+		{
+			Namespace: AzureRMInternal + "/services/containerapps",
+			Symbol:    Symbol{Symbol: "func", Name: "flattenEnvironment"},
+			Lines:     []string{"network.flattenSubnets(nil)"},
+		},
+		{
+			Namespace: AzureRMInternal + "/services/network",
+			Symbol:    Symbol{Symbol: "method", Receiver: "VirtualNetworkResource", Name: "Read"},
+			Lines:     []string{"subnets := flattenSubnets(props.Subnets)"},
+		},
+	}, result.References)
+}
+
+func TestFindReferences_Method(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/internal/services/network/method.VirtualNetworkResource.Read.goindex":   "func (r VirtualNetworkResource) Read() {}",
+		"index/internal/services/network/method.VirtualNetworkResource.Create.goindex": "func (r VirtualNetworkResource) Create() {\n\treturn r.Read()\n}",
+		"index/internal/services/network/func.Read.goindex":                            "func Read() {}",
+	})
+
+	result, err := FindReferences(context.Background(), AzureRMInternal+"/services/network", "method", "VirtualNetworkResource", "Read", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Reference{
+		{
+			Namespace: AzureRMInternal + "/services/network",
+			Symbol:    Symbol{Symbol: "method", Receiver: "VirtualNetworkResource", Name: "Create"},
+			Lines:     []string{"return r.Read()"},
+		},
+	}, result.References)
+}
+
+func TestFindReferences_Truncated(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/internal/services/network/func.a.goindex": "func a() { target() }",
+		"index/internal/services/network/func.b.goindex": "func b() { target() }",
+		"index/internal/services/network/func.c.goindex": "func c() { target() }",
+	})
+	stubs := gostub.Stub(&maxReferenceFiles, 2)
+	defer stubs.Reset()
+
+	result, err := FindReferences(context.Background(), AzureRMInternal+"/services/network", "func", "", "target", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Scanned)
+	assert.True(t, result.Truncated)
+	assert.Len(t, result.References, 2)
+}
+
+func TestFindReferences_InvalidArguments(t *testing.T) {
+	_, err := FindReferences(context.Background(), AzureRMInternal, "const", "", "target", "", nil)
+	assert.EqualError(t, err, "unsupported symbol: const")
+	_, err = FindReferences(context.Background(), AzureRMInternal, "func", "", "", "", nil)
+	assert.EqualError(t, err, "name cannot be empty")
+	_, err = FindReferences(context.Background(), AzureRMInternal, "func", "Client", "target", "", nil)
+	assert.EqualError(t, err, "receiver is only valid for methods")
+	_, err = FindReferences(context.Background(), "github.com/unknown/module", "func", "", "target", "", nil)
+	assert.EqualError(t, err, "unsupported namespace: github.com/unknown/module")
+}
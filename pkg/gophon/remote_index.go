@@ -4,12 +4,18 @@ type RemoteIndex struct {
 	GitHubOwner string
 	GitHubRepo  string
 	PackagePath string
+	// LocalPath is a local clone of the index repository, the source code is read from it instead of GitHub when set.
+	// A local index serves its checked out version, tags are ignored.
+	LocalPath string
 }
 
 var ProviderIndexMap = map[string]string{
-	"azurerm": AzureRMInternal,
-	"azuread": AzureADInternal,
-	"aws":     AWSInternal,
+	"azurerm":     AzureRMInternal,
+	"azuread":     AzureADInternal,
+	"aws":         AWSInternal,
+	"awscc":       AWSCCInternal,
+	"google":      GoogleProvider,
+	"google-beta": GoogleBetaProvider,
 }
 
 const (
@@ -17,7 +23,11 @@ const (
 	AzureGoHelpers      = "github.com/hashicorp/go-azure-helpers"
 	AzureADInternal     = "github.com/hashicorp/terraform-provider-azuread/internal"
 	AWSInternal         = "github.com/hashicorp/terraform-provider-aws/internal"
+	AWSCCInternal       = "github.com/hashicorp/terraform-provider-awscc/internal"
 	HashiCorpGoAzureSdk = "github.com/hashicorp/go-azure-sdk"
+	// GoogleProvider and GoogleBetaProvider hold the code of the google providers, which isn't under internal
+	GoogleProvider     = "github.com/hashicorp/terraform-provider-google/google"
+	GoogleBetaProvider = "github.com/hashicorp/terraform-provider-google-beta/google-beta"
 )
 
 var Namespaces = func() []string {
@@ -44,6 +54,12 @@ var RemoteIndexMap = map[string]RemoteIndex{
 		GitHubRepo:  "terraform-provider-aws-index",
 		PackagePath: "github.com/hashicorp/terraform-provider-aws",
 	},
+	// awscc resources are generated under internal/aws as `*_gen.go` files, which are indexed too
+	AWSCCInternal: {
+		GitHubOwner: "lonegunmanb",
+		GitHubRepo:  "terraform-provider-awscc-index",
+		PackagePath: "github.com/hashicorp/terraform-provider-awscc",
+	},
 	AzureGoHelpers: {
 		GitHubOwner: "lonegunmanb",
 		GitHubRepo:  "hashicorp-go-azure-helpers-index",
@@ -54,6 +70,16 @@ var RemoteIndexMap = map[string]RemoteIndex{
 		GitHubRepo:  "hashicorp-go-azure-sdk-index",
 		PackagePath: "github.com/hashicorp/go-azure-sdk",
 	},
+	GoogleProvider: {
+		GitHubOwner: "lonegunmanb",
+		GitHubRepo:  "terraform-provider-google-index",
+		PackagePath: "github.com/hashicorp/terraform-provider-google",
+	},
+	GoogleBetaProvider: {
+		GitHubOwner: "lonegunmanb",
+		GitHubRepo:  "terraform-provider-google-beta-index",
+		PackagePath: "github.com/hashicorp/terraform-provider-google-beta",
+	},
 }
 
 // GetSupportedProviders returns a slice of all supported provider names
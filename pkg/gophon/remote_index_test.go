@@ -12,7 +12,7 @@ func TestGetSupportedProviders(t *testing.T) {
 	}{
 		{
 			name:     "should return all supported provider names",
-			expected: []string{"azurerm"},
+			expected: []string{"azurerm", "awscc", "google", "google-beta"},
 		},
 	}
 
@@ -0,0 +1,60 @@
+package gophon
+
+import (
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// Source code modes of GetGolangSourceCode results
+const (
+	SourceModeFull      = "full"
+	SourceModeSignature = "signature"
+)
+
+// elidedBody replaces the bodies of function literals and the elements of composite literals in signatures
+const elidedBody = "{ ... }"
+
+// Signature returns the declaration of the source code of an indexed symbol without its implementation: the doc
+// comment and signature of funcs and methods, types as they are since their fields and methods are their declaration,
+// and variables with the bodies of function literals and the elements of composite literals elided. Source code that
+// can't be parsed is returned as is.
+func Signature(source string) string {
+	declaration, err := parseDeclaration(source)
+	if err != nil {
+		return source
+	}
+	type cut struct {
+		start, end  int
+		replacement string
+	}
+	var cuts []cut
+	for _, decl := range declaration.file.Decls {
+		if function, ok := decl.(*ast.FuncDecl); ok {
+			if function.Body != nil {
+				cuts = append(cuts, cut{start: declaration.offset(function.Type.End()), end: declaration.offset(function.Body.End())})
+			}
+			continue
+		}
+		ast.Inspect(decl, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				cuts = append(cuts, cut{start: declaration.offset(n.Body.Lbrace), end: declaration.offset(n.Body.End()), replacement: elidedBody})
+				return false
+			case *ast.CompositeLit:
+				if len(n.Elts) > 0 {
+					cuts = append(cuts, cut{start: declaration.offset(n.Lbrace), end: declaration.offset(n.End()), replacement: elidedBody})
+				}
+				return false
+			}
+			return true
+		})
+	}
+	sort.Slice(cuts, func(i, j int) bool {
+		return cuts[i].start > cuts[j].start
+	})
+	for _, c := range cuts {
+		source = source[:c.start] + c.replacement + source[c.end:]
+	}
+	return strings.TrimSpace(source)
+}
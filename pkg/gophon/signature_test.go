@@ -0,0 +1,74 @@
+package gophon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature(t *testing.T) {
+	cases := []struct {
+		desc     string
+		source   string
+		expected string
+	}{
+		{
+			desc: "func with doc comment",
+			source: `// expandSubnets expands the subnets of a virtual network
+func expandSubnets(input []interface{}) (*[]Subnet, error) {
+	return nil, nil
+}`,
+			expected: `// expandSubnets expands the subnets of a virtual network
+func expandSubnets(input []interface{}) (*[]Subnet, error)`,
+		},
+		{
+			desc: "method",
+			source: `func (r ContainerAppResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{}
+}`,
+			expected: "func (r ContainerAppResource) Create() sdk.ResourceFunc",
+		},
+		{
+			desc: "struct",
+			source: `// Client holds the clients
+type Client struct {
+	// SubnetsClient reads subnets
+	SubnetsClient *subnets.SubnetsClient
+}`,
+			expected: `// Client holds the clients
+type Client struct {
+	// SubnetsClient reads subnets
+	SubnetsClient *subnets.SubnetsClient
+}`,
+		},
+		{
+			desc: "var with function literal",
+			source: `var validateName = func(i interface{}, k string) ([]string, []error) {
+	return nil, nil
+}`,
+			expected: "var validateName = func(i interface{}, k string) ([]string, []error) { ... }",
+		},
+		{
+			desc: "var with composite literal",
+			source: `var skus = map[string]string{
+	"Standard_LRS": "Standard",
+}`,
+			expected: "var skus = map[string]string{ ... }",
+		},
+		{
+			desc:     "constant spec",
+			source:   `SkuNameStandardLRS SkuName = "Standard_LRS"`,
+			expected: `SkuNameStandardLRS SkuName = "Standard_LRS"`,
+		},
+		{
+			desc:     "invalid source",
+			source:   "func {",
+			expected: "func {",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			assert.Equal(t, c.expected, Signature(c.source))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package gophon
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// declarationHeader makes the source code of an indexed declaration, which has no package clause, a Go file
+const declarationHeader = "package p\n"
+
+// parsedDeclaration is the syntax tree of the source code of an indexed declaration
+type parsedDeclaration struct {
+	file   *ast.File
+	fset   *token.FileSet
+	source string
+	// header is the code prepended to source to parse it
+	header string
+}
+
+func parseDeclaration(source string) (*parsedDeclaration, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", declarationHeader+source, parser.SkipObjectResolution)
+	if err == nil {
+		return &parsedDeclaration{file: file, fset: fset, source: source, header: declarationHeader}, nil
+	}
+	// the specs of grouped variables and constants may be indexed without `var` or `const`
+	header := declarationHeader + "var "
+	file, specErr := parser.ParseFile(fset, "", header+source, parser.SkipObjectResolution)
+	if specErr != nil {
+		return nil, err
+	}
+	return &parsedDeclaration{file: file, fset: fset, source: source, header: header}, nil
+}
+
+// line returns the 1-based line number of pos in the declaration's source code
+func (d *parsedDeclaration) line(pos token.Pos) int {
+	return d.fset.Position(pos).Line - 1
+}
+
+// text returns the source code of n
+func (d *parsedDeclaration) text(n ast.Node) string {
+	return d.source[d.offset(n.Pos()):d.offset(n.End())]
+}
+
+// offset returns the offset of pos in the declaration's source code
+func (d *parsedDeclaration) offset(pos token.Pos) int {
+	return max(d.fset.Position(pos).Offset-len(d.header), 0)
+}
+
+// unwrapLiteral returns the composite literal of expr, or of its address like `&pluginsdk.Schema{`
+func unwrapLiteral(expr ast.Expr) (*ast.CompositeLit, bool) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	literal, ok := expr.(*ast.CompositeLit)
+	return literal, ok
+}
@@ -0,0 +1,110 @@
+package gophon
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
+)
+
+// SourceLocation is where the source code of an indexed symbol is declared in its upstream repository
+type SourceLocation struct {
+	// Path is the file path in the upstream repository, like `internal/services/network/subnet_resource.go`
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	// Permalink is the GitHub URL of the lines, pinned to the tag the source code was read from
+	Permalink string `json:"permalink"`
+}
+
+// SourceCode is the source code of a symbol and its location
+type SourceCode struct {
+	Source string `json:"source"`
+	// Location is nil when the source code couldn't be found in the upstream repository
+	Location *SourceLocation `json:"location,omitempty"`
+}
+
+// GetGolangSourceCodeWithLocation is GetGolangSourceCode returning the location of the source code too
+func GetGolangSourceCodeWithLocation(ctx context.Context, namespace, symbol, receiver, name, tag string) (*SourceCode, error) {
+	remoteIndex, err := namespaceRemoteIndex(namespace)
+	if err != nil {
+		return nil, err
+	}
+	tag = resolveTag(ctx, remoteIndex, tag)
+	source, err := GetGolangSourceCode(ctx, namespace, symbol, receiver, name, tag)
+	if err != nil {
+		return nil, err
+	}
+	location, err := locateSource(ctx, remoteIndex, strings.TrimSuffix(namespace, "/"), source, tag)
+	if err != nil {
+		logging.FromContext(ctx).Debug("gophon failed to locate source code", "symbol", symbol, "name", name, "namespace", namespace, "error", err)
+	}
+	return &SourceCode{Source: source, Location: location}, nil
+}
+
+// locateSource finds source in the Go files of namespace in the upstream repository of the index, the repository of
+// its PackagePath. The index files hold no position, so the source code is searched in the package files at tag,
+// index repositories are tagged like the repositories they index. It returns nil when the source code isn't found.
+func locateSource(ctx context.Context, remoteIndex RemoteIndex, namespace, source, tag string) (*SourceLocation, error) {
+	owner, repo, err := upstreamRepository(remoteIndex)
+	if err != nil {
+		return nil, err
+	}
+	dir := strings.TrimPrefix(strings.TrimPrefix(namespace, remoteIndex.PackagePath), "/")
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return nil, nil
+	}
+	entries, err := readDirectoryContent(ctx, owner, repo, dir, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of %s/%s/%s: %w", owner, repo, dir, err)
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.GetName()
+		if entry.GetType() == "file" && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		filePath := path.Join(dir, file)
+		content, err := readURLContent(ctx, owner, repo, filePath, tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s/%s/%s: %w", owner, repo, filePath, err)
+		}
+		offset := strings.Index(string(content), source)
+		if offset < 0 {
+			continue
+		}
+		startLine := strings.Count(string(content[:offset]), "\n") + 1
+		endLine := startLine + strings.Count(source, "\n")
+		return &SourceLocation{
+			Path:      filePath,
+			StartLine: startLine,
+			EndLine:   endLine,
+			Permalink: permalink(owner, repo, filePath, tag, startLine, endLine),
+		}, nil
+	}
+	return nil, nil
+}
+
+// permalink returns the GitHub URL of the lines of a file at tag, or at the default branch when tag is empty
+func permalink(owner, repo, filePath, tag string, startLine, endLine int) string {
+	if tag == "" {
+		tag = "HEAD"
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s#L%d-L%d", owner, repo, tag, filePath, startLine, endLine)
+}
+
+// upstreamRepository returns the GitHub repository indexed by remoteIndex, the repository of its PackagePath
+func upstreamRepository(remoteIndex RemoteIndex) (string, string, error) {
+	segments := strings.Split(remoteIndex.PackagePath, "/")
+	if len(segments) != 3 || segments[0] != "github.com" {
+		return "", "", fmt.Errorf("package path %s is not a GitHub repository", remoteIndex.PackagePath)
+	}
+	return segments[1], segments[2], nil
+}
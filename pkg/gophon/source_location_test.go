@@ -0,0 +1,93 @@
+package gophon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubUpstreamFiles(t *testing.T, files map[string]string) {
+	stubTags(t)
+	stubs := gostub.Stub(&readDirectoryContent, func(_ context.Context, owner string, repo string, dir string, tag string) ([]*github.RepositoryContent, error) {
+		assert.Equal(t, "hashicorp/terraform-provider-azurerm", owner+"/"+repo)
+		assert.Equal(t, "internal/services/network", dir)
+		var entries []*github.RepositoryContent
+		for p := range files {
+			if name, ok := strings.CutPrefix(p, dir+"/"); ok {
+				entries = append(entries, &github.RepositoryContent{Name: github.Ptr(name), Type: github.Ptr("file")})
+			}
+		}
+		return entries, nil
+	}).Stub(&readURLContent, func(_ context.Context, owner string, repo string, path string, tag string) ([]byte, error) {
+		content, ok := files[path]
+		if !ok {
+			return nil, NotFoundError
+		}
+		return []byte(content), nil
+	})
+	t.Cleanup(stubs.Reset)
+}
+
+func TestGetGolangSourceCodeWithLocation(t *testing.T) {
+	source := "func expandSubnets() {\n\treturn nil\n}\n"
+	cases := []struct {
+		desc     string
+		tag      string
+		files    map[string]string
+		expected *SourceLocation
+	}{
+		{
+			desc: "found",
+			tag:  "v4.25.0",
+			files: map[string]string{
+				"internal/services/network/a_resource.go":      "package network\n\nfunc a() {}\n",
+				"internal/services/network/subnet.go":          "package network\n\nimport \"fmt\"\n\nfunc expandSubnets() {\n\treturn nil\n}\n",
+				"internal/services/network/subnet_test.go":     "package network\n\nfunc expandSubnets() {\n\treturn nil\n}\n",
+				"internal/services/network/testdata/README.md": "func expandSubnets() {\n\treturn nil\n}\n",
+			},
+			expected: &SourceLocation{
+				Path:      "internal/services/network/subnet.go",
+				StartLine: 5,
+				EndLine:   7,
+				Permalink: "https://github.com/hashicorp/terraform-provider-azurerm/blob/v4.25.0/internal/services/network/subnet.go#L5-L7",
+			},
+		},
+		{
+			desc: "default branch",
+			files: map[string]string{
+				"internal/services/network/subnet.go": "func expandSubnets() {\n\treturn nil\n}",
+			},
+			expected: &SourceLocation{
+				Path:      "internal/services/network/subnet.go",
+				StartLine: 1,
+				EndLine:   3,
+				Permalink: "https://github.com/hashicorp/terraform-provider-azurerm/blob/HEAD/internal/services/network/subnet.go#L1-L3",
+			},
+		},
+		{
+			desc: "not found",
+			files: map[string]string{
+				"internal/services/network/subnet.go": "package network\n",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			files := map[string]string{"index/internal/services/network/func.expandSubnets.goindex": source}
+			for p, content := range c.files {
+				files[p] = content
+			}
+			stubUpstreamFiles(t, files)
+
+			code, err := GetGolangSourceCodeWithLocation(context.Background(), AzureRMInternal+"/services/network", "func", "", "expandSubnets", c.tag)
+			require.NoError(t, err)
+			assert.Equal(t, source, code.Source)
+			assert.Equal(t, c.expected, code.Location)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package gophon
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const maxSourceSearchContext = 10
+
+// SourceMatch is a line of an indexed symbol's source code matching a search pattern
+type SourceMatch struct {
+	Namespace string `json:"namespace"`
+	Symbol
+	// Line is the 1-based line number in the symbol's source code
+	Line   int      `json:"line"`
+	Text   string   `json:"text"`
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+}
+
+// SourceSearchResult is the result of SearchSource
+type SourceSearchResult struct {
+	Matches []SourceMatch `json:"matches"`
+	// Scanned is the number of symbols whose source code was searched
+	Scanned int `json:"scanned"`
+	// Truncated is true when the namespace has more symbols than maxReferenceFiles, the others weren't searched
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// SearchSource searches the source code of the symbols indexed in namespace, not its sub-packages, for lines matching
+// the regular expression pattern. Every match comes with contextLines lines before and after it, at most 10.
+func SearchSource(ctx context.Context, namespace, pattern, tag string, contextLines int) (*SourceSearchResult, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("pattern cannot be empty")
+	}
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	contextLines = min(max(contextLines, 0), maxSourceSearchContext)
+
+	sources, err := listIndexedSources(ctx, []string{namespace}, tag)
+	if err != nil {
+		return nil, err
+	}
+	result := &SourceSearchResult{Matches: make([]SourceMatch, 0)}
+	sources, result.Truncated = truncateSources(sources)
+	result.Scanned = len(sources)
+	var mu sync.Mutex
+	err = readIndexedSources(ctx, sources, func(source indexedSource, content string) {
+		matches := sourceMatches(source, content, r, contextLines)
+		if len(matches) == 0 {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		result.Matches = append(result.Matches, matches...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(result.Matches, func(i, j int) bool {
+		a, b := result.Matches[i], result.Matches[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if fa, fb := symbolFileName(a.Symbol), symbolFileName(b.Symbol); fa != fb {
+			return fa < fb
+		}
+		return a.Line < b.Line
+	})
+	return result, nil
+}
+
+func sourceMatches(source indexedSource, content string, pattern *regexp.Regexp, contextLines int) []SourceMatch {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	var matches []SourceMatch
+	for i, line := range lines {
+		if !pattern.MatchString(line) {
+			continue
+		}
+		match := SourceMatch{Namespace: source.namespace, Symbol: source.symbol, Line: i + 1, Text: line}
+		if contextLines > 0 {
+			match.Before = lines[max(i-contextLines, 0):i]
+			match.After = lines[i+1 : min(i+1+contextLines, len(lines))]
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
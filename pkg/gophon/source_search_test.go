@@ -0,0 +1,72 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchSource(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/internal/services/network/func.expandSubnets.goindex":                 "func expandSubnets() {\n\tfeatures := ExpandFeatures(input)\n\treturn features\n}\n",
+		"index/internal/services/network/method.VirtualNetworkResource.Read.goindex": "func (r VirtualNetworkResource) Read() {\n\tExpandFeatures(nil)\n}",
+		"index/internal/services/network/func.flattenSubnets.goindex":                "func flattenSubnets() {}",
+	})
+
+	result, err := SearchSource(context.Background(), AzureRMInternal+"/services/network", `ExpandFeatures\(`, "", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Scanned)
+	assert.False(t, result.Truncated)
+	assert.Equal(t, []SourceMatch{
+		{
+			Namespace: AzureRMInternal + "/services/network",
+			Symbol:    Symbol{Symbol: "func", Name: "expandSubnets"},
+			Line:      2,
+			Text:      "\tfeatures := ExpandFeatures(input)",
+			Before:    []string{"func expandSubnets() {"},
+			After:     []string{"\treturn features"},
+		},
+		{
+			Namespace: AzureRMInternal + "/services/network",
+			Symbol:    Symbol{Symbol: "method", Receiver: "VirtualNetworkResource", Name: "Read"},
+			Line:      2,
+			Text:      "\tExpandFeatures(nil)",
+			Before:    []string{"func (r VirtualNetworkResource) Read() {"},
+			After:     []string{"}"},
+		},
+	}, result.Matches)
+}
+
+func TestSearchSource_Bounded(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/internal/services/network/func.a.goindex": "func a() {\n\ttarget()\n\ttarget()\n}",
+		"index/internal/services/network/func.b.goindex": "func b() {\n\ttarget()\n}",
+		"index/internal/services/network/func.c.goindex": "func c() {\n\ttarget()\n}",
+	})
+
+	result, err := SearchSource(context.Background(), AzureRMInternal+"/services/network", "target", "", 0)
+	require.NoError(t, err)
+	assert.False(t, result.Truncated)
+	assert.Equal(t, 3, result.Scanned)
+	require.Len(t, result.Matches, 4)
+	assert.Equal(t, "a", result.Matches[1].Name)
+	assert.Equal(t, 3, result.Matches[1].Line)
+	assert.Nil(t, result.Matches[0].Before)
+
+	stubs := gostub.Stub(&maxReferenceFiles, 1)
+	defer stubs.Reset()
+	result, err = SearchSource(context.Background(), AzureRMInternal+"/services/network", "target", "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Scanned)
+	assert.True(t, result.Truncated)
+}
+
+func TestSearchSource_InvalidPattern(t *testing.T) {
+	_, err := SearchSource(context.Background(), AzureRMInternal, "", "", 0)
+	assert.EqualError(t, err, "pattern cannot be empty")
+	_, err = SearchSource(context.Background(), AzureRMInternal, "(", "", 0)
+	assert.ErrorContains(t, err, `invalid pattern "("`)
+}
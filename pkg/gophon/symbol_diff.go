@@ -0,0 +1,100 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Statuses of a symbol between two tags
+const (
+	SymbolUnchanged = "unchanged"
+	SymbolModified  = "modified"
+	SymbolAdded     = "added"
+	SymbolRemoved   = "removed"
+)
+
+// SymbolDiff is the change of a symbol's source code between two tags
+type SymbolDiff struct {
+	FromTag string `json:"from_tag"`
+	ToTag   string `json:"to_tag"`
+	Status  string `json:"status"`
+	// Diff is the unified diff from FromTag to ToTag, empty when the symbol is unchanged
+	Diff string `json:"diff,omitempty"`
+}
+
+// DiffGolangSourceCode returns the unified diff of a func, method, type or var between fromTag and toTag, toTag
+// defaults to the latest version. A symbol that only exists at one of the tags is added or removed.
+func DiffGolangSourceCode(ctx context.Context, namespace, symbol, receiver, name, fromTag, toTag string) (*SymbolDiff, error) {
+	if fromTag == "" {
+		return nil, fmt.Errorf("from tag cannot be empty")
+	}
+	remoteIndex, err := namespaceRemoteIndex(namespace)
+	if err != nil {
+		return nil, err
+	}
+	fromTag, toTag = resolveTag(ctx, remoteIndex, fromTag), resolveTag(ctx, remoteIndex, toTag)
+	from, err := golangSourceCodeAt(ctx, namespace, symbol, receiver, name, fromTag)
+	if err != nil {
+		return nil, err
+	}
+	to, err := golangSourceCodeAt(ctx, namespace, symbol, receiver, name, toTag)
+	if err != nil {
+		return nil, err
+	}
+	toLabel := toTag
+	if toLabel == "" {
+		toLabel = "latest"
+	}
+	result := &SymbolDiff{FromTag: fromTag, ToTag: toLabel}
+	switch {
+	case from == nil && to == nil:
+		return nil, fmt.Errorf("%s %s not found at %s and %s: %w", symbol, name, fromTag, toLabel, NotFoundError)
+	case from == nil:
+		result.Status = SymbolAdded
+	case to == nil:
+		result.Status = SymbolRemoved
+	case *from == *to:
+		result.Status = SymbolUnchanged
+		return result, nil
+	default:
+		result.Status = SymbolModified
+	}
+	file := symbolFileName(Symbol{Symbol: symbol, Receiver: receiver, Name: name})
+	result.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(from),
+		B:        diffLines(to),
+		FromFile: fmt.Sprintf("%s@%s", file, fromTag),
+		ToFile:   fmt.Sprintf("%s@%s", file, toLabel),
+		Context:  3,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s %s: %w", symbol, name, err)
+	}
+	return result, nil
+}
+
+// golangSourceCodeAt returns the source code of the symbol at tag, or nil when it doesn't exist at tag
+func golangSourceCodeAt(ctx context.Context, namespace, symbol, receiver, name, tag string) (*string, error) {
+	code, err := GetGolangSourceCode(ctx, namespace, symbol, receiver, name, tag)
+	if errors.Is(err, NotFoundError) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// diffLines splits code into lines ending with a newline, missing code has no lines
+func diffLines(code *string) []string {
+	if code == nil || *code == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(strings.TrimSuffix(*code, "\n"), "\n")
+	lines[len(lines)-1] += "\n"
+	return lines
+}
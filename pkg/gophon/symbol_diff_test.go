@@ -0,0 +1,82 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTaggedSourceCode serves the source code of the symbol by tag, tags without code are NotFoundError
+func stubTaggedSourceCode(t *testing.T, codes map[string]string) {
+	stubTags(t)
+	stubs := gostub.Stub(&readURLContent, func(_ context.Context, owner string, repo string, path string, tag string) ([]byte, error) {
+		assert.Equal(t, "index/internal/services/containerapps/method.ContainerAppResource.Create.goindex", path)
+		code, ok := codes[tag]
+		if !ok {
+			return nil, NotFoundError
+		}
+		return []byte(code), nil
+	})
+	t.Cleanup(stubs.Reset)
+}
+
+func TestDiffGolangSourceCode(t *testing.T) {
+	stubTaggedSourceCode(t, map[string]string{
+		"v4.20.0": "func (r ContainerAppResource) Create() {\n\tcreate()\n}\n",
+		"v4.30.0": "func (r ContainerAppResource) Create() {\n\tvalidate()\n\tcreate()\n}\n",
+		"":        "func (r ContainerAppResource) Create() {\n\tvalidate()\n\tcreate()\n}\n",
+	})
+	cases := []struct {
+		desc     string
+		fromTag  string
+		toTag    string
+		expected *SymbolDiff
+	}{
+		{
+			desc:    "modified",
+			fromTag: "v4.20.0",
+			toTag:   "v4.30.0",
+			expected: &SymbolDiff{
+				FromTag: "v4.20.0",
+				ToTag:   "v4.30.0",
+				Status:  SymbolModified,
+				Diff:    "--- method.ContainerAppResource.Create.goindex@v4.20.0\n+++ method.ContainerAppResource.Create.goindex@v4.30.0\n@@ -1,3 +1,4 @@\n func (r ContainerAppResource) Create() {\n+\tvalidate()\n \tcreate()\n }\n",
+			},
+		},
+		{
+			desc:     "unchanged",
+			fromTag:  "v4.30.0",
+			expected: &SymbolDiff{FromTag: "v4.30.0", ToTag: "latest", Status: SymbolUnchanged},
+		},
+		{
+			desc:    "added",
+			fromTag: "v4.0.0",
+			toTag:   "v4.20.0",
+			expected: &SymbolDiff{
+				FromTag: "v4.0.0",
+				ToTag:   "v4.20.0",
+				Status:  SymbolAdded,
+				Diff:    "--- method.ContainerAppResource.Create.goindex@v4.0.0\n+++ method.ContainerAppResource.Create.goindex@v4.20.0\n@@ -0,0 +1,3 @@\n+func (r ContainerAppResource) Create() {\n+\tcreate()\n+}\n",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			diff, err := DiffGolangSourceCode(context.Background(), AzureRMInternal+"/services/containerapps", "method", "ContainerAppResource", "Create", c.fromTag, c.toTag)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, diff)
+		})
+	}
+}
+
+func TestDiffGolangSourceCode_Errors(t *testing.T) {
+	stubTaggedSourceCode(t, map[string]string{})
+
+	_, err := DiffGolangSourceCode(context.Background(), AzureRMInternal+"/services/containerapps", "method", "ContainerAppResource", "Create", "", "v4.30.0")
+	assert.EqualError(t, err, "from tag cannot be empty")
+	_, err = DiffGolangSourceCode(context.Background(), AzureRMInternal+"/services/containerapps", "method", "ContainerAppResource", "Create", "v4.20.0", "v4.30.0")
+	assert.ErrorIs(t, err, NotFoundError)
+}
@@ -0,0 +1,142 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// Symbol is a func, method, type or var indexed in a namespace
+type Symbol struct {
+	Symbol   string `json:"symbol"`
+	Receiver string `json:"receiver,omitempty"`
+	Name     string `json:"name"`
+}
+
+// readDirectoryContent lists the entries in a directory of a GitHub repository as its git tree, it's a variable so
+// tests can stub it
+var readDirectoryContent = func(ctx context.Context, owner string, repo string, path string, tag string) ([]*github.RepositoryContent, error) {
+	if root := localIndexPath(owner, repo); root != "" {
+		recordIndexRef(ctx, IndexRef{Repository: owner + "/" + repo, LocalPath: root})
+		return readLocalDirectory(root, path)
+	}
+	githubClient, err := newGitHubClient()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := readGitTree(ctx, githubClient, owner, repo, path, tag)
+	if errors.Is(err, errTreeTruncated) {
+		return readContentsDirectory(ctx, githubClient, owner, repo, path, tag)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory %s: %w", path, err)
+	}
+	return treeDirectoryContent(entries), nil
+}
+
+// readContentsDirectory lists a directory with the contents API, for directories too large for a git tree
+func readContentsDirectory(ctx context.Context, githubClient *github.Client, owner string, repo string, path string, tag string) ([]*github.RepositoryContent, error) {
+	option := &github.RepositoryContentGetOptions{}
+	if tag != "" {
+		option.Ref = tag
+	}
+	ctx, cancel := requestContext(ctx)
+	defer cancel()
+	_, directoryContent, resp, err := githubClient.Repositories.GetContents(ctx, owner, repo, path, option)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, NotFoundError
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory %s: %w", path, describeRateLimit(err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if directoryContent == nil {
+		return nil, fmt.Errorf("%s is not a directory", path)
+	}
+	return directoryContent, nil
+}
+
+// SearchSymbols lists the symbols indexed in namespace whose name matches pattern. Pattern is a case-insensitive
+// substring, or a regular expression when regex is true. Methods also match against `Receiver.Name`. An empty
+// pattern matches all symbols, kind filters the symbols by `func`, `method`, `type` or `var` when it's not empty.
+func SearchSymbols(ctx context.Context, namespace, pattern, kind, tag string, regex bool) ([]Symbol, error) {
+	remoteIndex, err := namespaceRemoteIndex(namespace)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := validSymbols[kind]; kind != "" && !ok {
+		return nil, fmt.Errorf("unsupported symbol: %s", kind)
+	}
+	match, err := symbolMatcher(pattern, regex)
+	if err != nil {
+		return nil, err
+	}
+	path := "index" + strings.TrimPrefix(namespace, remoteIndex.PackagePath)
+	entries, err := readDirectoryContent(ctx, remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, path, resolveTag(ctx, remoteIndex, tag))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list symbols of namespace %s: %w", namespace, err)
+	}
+	symbols := make([]Symbol, 0)
+	for _, entry := range entries {
+		if entry.GetType() != "file" {
+			continue
+		}
+		symbol, ok := parseSymbol(entry.GetName())
+		if !ok || (kind != "" && symbol.Symbol != kind) {
+			continue
+		}
+		if match(symbol.Name) || (symbol.Receiver != "" && match(symbol.Receiver+"."+symbol.Name)) {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		if symbols[i].Symbol != symbols[j].Symbol {
+			return symbols[i].Symbol < symbols[j].Symbol
+		}
+		if symbols[i].Receiver != symbols[j].Receiver {
+			return symbols[i].Receiver < symbols[j].Receiver
+		}
+		return symbols[i].Name < symbols[j].Name
+	})
+	return symbols, nil
+}
+
+func symbolMatcher(pattern string, regex bool) (func(string) bool, error) {
+	if !regex {
+		pattern = strings.ToLower(pattern)
+		return func(name string) bool {
+			return strings.Contains(strings.ToLower(name), pattern)
+		}, nil
+	}
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return r.MatchString, nil
+}
+
+// parseSymbol parses an index file name, like `type.Client.goindex` or `method.ContainerAppResource.Create.goindex`
+func parseSymbol(fileName string) (Symbol, bool) {
+	if !strings.HasSuffix(fileName, ".goindex") {
+		return Symbol{}, false
+	}
+	segments := strings.Split(strings.TrimSuffix(fileName, ".goindex"), ".")
+	if _, ok := validSymbols[segments[0]]; !ok {
+		return Symbol{}, false
+	}
+	switch {
+	case len(segments) == 3 && segments[0] == "method":
+		return Symbol{Symbol: segments[0], Receiver: segments[1], Name: segments[2]}, true
+	case len(segments) == 2 && segments[0] != "method":
+		return Symbol{Symbol: segments[0], Name: segments[1]}, true
+	}
+	return Symbol{}, false
+}
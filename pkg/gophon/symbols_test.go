@@ -0,0 +1,108 @@
+package gophon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIndexDirectory serves names as the entries of the expectedPath directory of the AzureRM index, names ending with
+// `/` are directories
+func stubIndexDirectory(t *testing.T, expectedPath string, names ...string) {
+	stubTags(t)
+	stubs := gostub.Stub(&readDirectoryContent, func(_ context.Context, owner string, repo string, path string, tag string) ([]*github.RepositoryContent, error) {
+		assert.Equal(t, "lonegunmanb", owner)
+		assert.Equal(t, "terraform-provider-azurerm-index", repo)
+		assert.Equal(t, expectedPath, path)
+		entries := make([]*github.RepositoryContent, 0, len(names))
+		for _, name := range names {
+			entryType := "file"
+			if strings.HasSuffix(name, "/") {
+				entryType = "dir"
+			}
+			entries = append(entries, &github.RepositoryContent{Name: github.Ptr(strings.TrimSuffix(name, "/")), Type: github.Ptr(entryType)})
+		}
+		return entries, nil
+	})
+	t.Cleanup(stubs.Reset)
+}
+
+func TestSearchSymbols(t *testing.T) {
+	files := []string{
+		"func.NewContainerAppResource.goindex",
+		"method.ContainerAppResource.Create.goindex",
+		"method.ContainerAppResource.Read.goindex",
+		"method.ContainerAppJobResource.Create.goindex",
+		"type.ContainerAppResource.goindex",
+		"var.containerAppsClient.goindex",
+		"README.md",
+		"method.goindex",
+		"type.Nested.goindex/",
+	}
+	cases := []struct {
+		desc     string
+		pattern  string
+		kind     string
+		regex    bool
+		expected []Symbol
+	}{
+		{
+			desc:    "substring is case-insensitive",
+			pattern: "containerappresource",
+			expected: []Symbol{
+				{Symbol: "func", Name: "NewContainerAppResource"},
+				{Symbol: "method", Receiver: "ContainerAppResource", Name: "Create"},
+				{Symbol: "method", Receiver: "ContainerAppResource", Name: "Read"},
+				{Symbol: "type", Name: "ContainerAppResource"},
+			},
+		},
+		{
+			desc:    "kind filter",
+			pattern: "Create",
+			kind:    "method",
+			expected: []Symbol{
+				{Symbol: "method", Receiver: "ContainerAppJobResource", Name: "Create"},
+				{Symbol: "method", Receiver: "ContainerAppResource", Name: "Create"},
+			},
+		},
+		{
+			desc:    "regex",
+			pattern: `^ContainerApp\w*Resource\.Create$`,
+			regex:   true,
+			expected: []Symbol{
+				{Symbol: "method", Receiver: "ContainerAppJobResource", Name: "Create"},
+				{Symbol: "method", Receiver: "ContainerAppResource", Name: "Create"},
+			},
+		},
+		{
+			desc:     "no match",
+			pattern:  "Storage",
+			expected: []Symbol{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			stubIndexDirectory(t, "index/internal/services/containerapps", files...)
+
+			symbols, err := SearchSymbols(context.Background(), AzureRMInternal+"/services/containerapps", c.pattern, c.kind, "", c.regex)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, symbols)
+		})
+	}
+}
+
+func TestSearchSymbols_InvalidArguments(t *testing.T) {
+	stubIndexDirectory(t, "index/internal/services/containerapps")
+
+	_, err := SearchSymbols(context.Background(), "github.com/unknown/module", "", "", "", false)
+	assert.EqualError(t, err, "unsupported namespace: github.com/unknown/module")
+	_, err = SearchSymbols(context.Background(), AzureRMInternal+"/services/containerapps", "", "const", "", false)
+	assert.EqualError(t, err, "unsupported symbol: const")
+	_, err = SearchSymbols(context.Background(), AzureRMInternal+"/services/containerapps", "(", "", "", true)
+	assert.ErrorContains(t, err, `invalid pattern "("`)
+}
@@ -3,33 +3,114 @@ package gophon
 import (
 	"context"
 	"fmt"
-	"os"
+	"slices"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-github/v74/github"
-	"golang.org/x/oauth2"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
+	"golang.org/x/sync/singleflight"
 )
 
+// TagLatest resolves to the latest stable tag of a namespace, like an empty tag
+const TagLatest = "latest"
+
+// Orders of the tags listed by ListSupportedTagsWithOptions
+const (
+	TagOrderAsc  = "asc"
+	TagOrderDesc = "desc"
+)
+
+// TagOptions filters the tags listed by ListSupportedTagsWithOptions
+type TagOptions struct {
+	// ExcludePrerelease drops pre-release tags like `v5.0.0-beta1`
+	ExcludePrerelease bool
+	// Prefix keeps the tags starting with it, like `v4.`
+	Prefix string
+	// Order is TagOrderAsc or TagOrderDesc, defaults to TagOrderAsc
+	Order string
+}
+
+// latestTagTTL is how long the resolved latest tag of an index is reused before the tags are listed again
+var latestTagTTL = 10 * time.Minute
+
+// latestTags holds the latest tags resolved by resolveTag
+var latestTags = newLatestTagCache()
+
+// latestTagCache guards its entries with the mutex only, the tags are listed outside of it and concurrent listings of
+// the same index are coalesced, so resolving the latest tag of one index doesn't block the others
+type latestTagCache struct {
+	sync.Mutex
+	entries map[RemoteIndex]latestTag
+	loads   singleflight.Group
+}
+
+func newLatestTagCache() *latestTagCache {
+	return &latestTagCache{entries: make(map[RemoteIndex]latestTag)}
+}
+
+type latestTag struct {
+	tag     string
+	expires time.Time
+}
+
 // ListSupportedTags returns all supported tags/versions for a given golang namespace
-func ListSupportedTags(namespace string) ([]string, error) {
+func ListSupportedTags(ctx context.Context, namespace string) ([]string, error) {
+	return ListSupportedTagsWithOptions(ctx, namespace, TagOptions{})
+}
+
+// ListSupportedTagsWithOptions returns the tags of a given golang namespace filtered by options, in ascending semantic
+// version order by default, tags that aren't versions are sorted lexicographically before them
+func ListSupportedTagsWithOptions(ctx context.Context, namespace string, options TagOptions) ([]string, error) {
+	if options.Order != "" && options.Order != TagOrderAsc && options.Order != TagOrderDesc {
+		return nil, fmt.Errorf("invalid order: %s, valid orders are `%s` and `%s`", options.Order, TagOrderAsc, TagOrderDesc)
+	}
 	// Get the remote index configuration for the namespace
 	remoteIndex, exists := RemoteIndexMap[namespace]
 	if !exists {
 		return nil, fmt.Errorf("unsupported namespace: %s", namespace)
 	}
+	allTags, err := listTags(ctx, remoteIndex)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(allTags))
+	for _, tag := range allTags {
+		if options.ExcludePrerelease && isPrerelease(tag) || !strings.HasPrefix(tag, options.Prefix) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	sortTags(tags)
+	if options.Order == TagOrderDesc {
+		slices.Reverse(tags)
+	}
+	return tags, nil
+}
+
+// LatestTag returns the latest stable tag of a given golang namespace, or an empty string when it has no stable tags
+func LatestTag(ctx context.Context, namespace string) (string, error) {
+	tags, err := ListSupportedTagsWithOptions(ctx, namespace, TagOptions{ExcludePrerelease: true})
+	if err != nil {
+		return "", err
+	}
+	return latestVersionTag(tags), nil
+}
+
+// listTags lists the tags of the index repository, it's a variable so tests can stub it
+var listTags = func(ctx context.Context, remoteIndex RemoteIndex) ([]string, error) {
+	// A local index has no tags, it serves its checked out version
+	if localIndexPath(remoteIndex.GitHubOwner, remoteIndex.GitHubRepo) != "" {
+		return []string{}, nil
+	}
 
 	// Create GitHub client with authentication if token is available
-	var client *github.Client
-	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-		// Create authenticated client using OAuth2
-		ts := oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: token},
-		)
-		tc := oauth2.NewClient(context.Background(), ts)
-		client = github.NewClient(tc)
-	} else {
-		// Create unauthenticated client for public repositories
-		client = github.NewClient(nil)
+	client, err := newGitHubClient()
+	if err != nil {
+		return nil, err
 	}
 
 	// List all tags from the repository
@@ -38,10 +119,14 @@ func ListSupportedTags(namespace string) ([]string, error) {
 	// Use pagination to get all tags
 	opts := &github.ListOptions{PerPage: 100}
 	for {
-		tags, resp, err := client.Repositories.ListTags(context.Background(), remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, opts)
+		// every page is a request, stop paginating as soon as the tool call is cancelled
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		tags, resp, err := listTagsPage(ctx, client, remoteIndex, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list tags from GitHub repository %s/%s: %w",
-				remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, err)
+				remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, describeRateLimit(err))
 		}
 
 		// Extract tag names
@@ -57,11 +142,93 @@ func ListSupportedTags(namespace string) ([]string, error) {
 		}
 		opts.Page = resp.NextPage
 	}
+	return allTags, nil
+}
 
-	// Sort tags in ascending order (lexicographic sorting)
-	// Note: For proper semantic version sorting, we would need a library like semver
-	// For now, lexicographic sorting will work reasonably well for most version tags
-	sort.Strings(allTags)
+func listTagsPage(ctx context.Context, client *github.Client, remoteIndex RemoteIndex, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+	ctx, cancel := requestContext(ctx)
+	defer cancel()
+	return client.Repositories.ListTags(ctx, remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, opts)
+}
 
-	return allTags, nil
+// resolveTag resolves an empty tag or TagLatest to the latest stable tag of the index, so the source code of a symbol
+// and of the index files pointing to it comes from the same version. It falls back to the default branch, which holds
+// the latest indexed version too, when the index has no stable tags or they can't be listed.
+func resolveTag(ctx context.Context, remoteIndex RemoteIndex, tag string) string {
+	if tag != "" && tag != TagLatest {
+		return tag
+	}
+	if latest, ok := latestTags.get(remoteIndex); ok {
+		return latest
+	}
+	repository := remoteIndex.GitHubOwner + "/" + remoteIndex.GitHubRepo
+	v, err, _ := latestTags.loads.Do(fmt.Sprintf("%+v", remoteIndex), func() (any, error) {
+		tags, err := listTags(ctx, remoteIndex)
+		if err != nil {
+			return "", err
+		}
+		latest := latestVersionTag(tags)
+		latestTags.put(remoteIndex, latest)
+		return latest, nil
+	})
+	if err != nil {
+		logging.FromContext(ctx).Warn("gophon failed to resolve the latest tag, reading the default branch", "repository", repository, "error", err)
+		return ""
+	}
+	return v.(string)
+}
+
+func (c *latestTagCache) get(remoteIndex RemoteIndex) (string, bool) {
+	c.Lock()
+	defer c.Unlock()
+	cached, ok := c.entries[remoteIndex]
+	if !ok || !time.Now().Before(cached.expires) {
+		return "", false
+	}
+	return cached.tag, true
+}
+
+func (c *latestTagCache) put(remoteIndex RemoteIndex, tag string) {
+	c.Lock()
+	defer c.Unlock()
+	c.entries[remoteIndex] = latestTag{tag: tag, expires: time.Now().Add(latestTagTTL)}
+}
+
+// sortTags sorts tags in ascending semantic version order, so `v4.9.0` is before `v4.10.0`
+func sortTags(tags []string) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		vi, erri := goversion.NewVersion(tags[i])
+		vj, errj := goversion.NewVersion(tags[j])
+		switch {
+		case erri != nil && errj != nil:
+			return tags[i] < tags[j]
+		case erri != nil || errj != nil:
+			return erri != nil
+		case vi.Equal(vj):
+			return tags[i] < tags[j]
+		}
+		return vi.LessThan(vj)
+	})
+}
+
+// latestVersionTag returns the greatest stable version of tags, or an empty string when there's none
+func latestVersionTag(tags []string) string {
+	var latest string
+	var latestVersion *goversion.Version
+	for _, tag := range tags {
+		v, err := goversion.NewVersion(tag)
+		if err != nil || v.Prerelease() != "" {
+			continue
+		}
+		if latestVersion == nil || v.GreaterThan(latestVersion) {
+			latest, latestVersion = tag, v
+		}
+	}
+	return latest
+}
+
+// isPrerelease returns true for version tags with a pre-release, like `v5.0.0-beta1`
+func isPrerelease(tag string) bool {
+	v, err := goversion.NewVersion(tag)
+	return err == nil && v.Prerelease() != ""
 }
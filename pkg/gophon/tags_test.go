@@ -1,9 +1,15 @@
 package gophon
 
 import (
+	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListSupportedTags(t *testing.T) {
@@ -33,7 +39,7 @@ func TestListSupportedTags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tags, err := ListSupportedTags(tt.namespace)
+			tags, err := ListSupportedTags(context.Background(), tt.namespace)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -50,3 +56,114 @@ func TestListSupportedTags(t *testing.T) {
 		})
 	}
 }
+
+// stubTags serves tags as the tags of every index
+func stubTags(t *testing.T, tags ...string) {
+	stubs := gostub.Stub(&listTags, func(context.Context, RemoteIndex) ([]string, error) {
+		return tags, nil
+	}).Stub(&latestTags, newLatestTagCache())
+	t.Cleanup(stubs.Reset)
+}
+
+func TestListSupportedTagsWithOptions(t *testing.T) {
+	stubTags(t, "v4.10.0", "v4.9.0", "v5.0.0-beta1", "v4.10.1", "main-snapshot", "v4.2.0")
+	cases := []struct {
+		desc     string
+		options  TagOptions
+		expected []string
+	}{
+		{
+			desc:     "semantic order",
+			expected: []string{"main-snapshot", "v4.2.0", "v4.9.0", "v4.10.0", "v4.10.1", "v5.0.0-beta1"},
+		},
+		{
+			desc:     "exclude pre-releases",
+			options:  TagOptions{ExcludePrerelease: true},
+			expected: []string{"main-snapshot", "v4.2.0", "v4.9.0", "v4.10.0", "v4.10.1"},
+		},
+		{
+			desc:     "prefix",
+			options:  TagOptions{Prefix: "v4.1"},
+			expected: []string{"v4.10.0", "v4.10.1"},
+		},
+		{
+			desc:     "latest first",
+			options:  TagOptions{Order: TagOrderDesc},
+			expected: []string{"v5.0.0-beta1", "v4.10.1", "v4.10.0", "v4.9.0", "v4.2.0", "main-snapshot"},
+		},
+		{
+			desc:     "oldest first",
+			options:  TagOptions{Order: TagOrderAsc, ExcludePrerelease: true, Prefix: "v4."},
+			expected: []string{"v4.2.0", "v4.9.0", "v4.10.0", "v4.10.1"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			tags, err := ListSupportedTagsWithOptions(context.Background(), AzureRMInternal, c.options)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, tags)
+		})
+	}
+
+	_, err := ListSupportedTagsWithOptions(context.Background(), AzureRMInternal, TagOptions{Order: "newest"})
+	assert.EqualError(t, err, "invalid order: newest, valid orders are `asc` and `desc`")
+
+	latest, err := LatestTag(context.Background(), AzureRMInternal)
+	require.NoError(t, err)
+	assert.Equal(t, "v4.10.1", latest)
+}
+
+func TestResolveTag(t *testing.T) {
+	listed := 0
+	stubs := gostub.Stub(&listTags, func(context.Context, RemoteIndex) ([]string, error) {
+		listed++
+		return []string{"v4.9.0", "v4.10.0", "v5.0.0-beta1"}, nil
+	}).Stub(&latestTags, newLatestTagCache())
+	defer stubs.Reset()
+	remoteIndex := RemoteIndexMap[AzureRMInternal]
+
+	assert.Equal(t, "v4.10.0", resolveTag(context.Background(), remoteIndex, ""))
+	assert.Equal(t, "v4.10.0", resolveTag(context.Background(), remoteIndex, TagLatest))
+	assert.Equal(t, "v4.9.0", resolveTag(context.Background(), remoteIndex, "v4.9.0"))
+	assert.Equal(t, 1, listed)
+}
+
+func TestResolveTag_ListsOutsideOfTheLock(t *testing.T) {
+	azurerm := RemoteIndexMap[AzureRMInternal]
+	release := make(chan struct{})
+	var listed atomic.Int32
+	stubs := gostub.Stub(&listTags, func(_ context.Context, remoteIndex RemoteIndex) ([]string, error) {
+		if remoteIndex == azurerm {
+			listed.Add(1)
+			<-release
+		}
+		return []string{"v1.0.0"}, nil
+	}).Stub(&latestTags, newLatestTagCache())
+	defer stubs.Reset()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "v1.0.0", resolveTag(context.Background(), azurerm, ""))
+		}()
+	}
+	// Another index resolves while the listing of azurerm is still in flight
+	assert.Eventually(t, func() bool { return listed.Load() == 1 }, time.Second, time.Millisecond)
+	other := RemoteIndex{GitHubOwner: "example", GitHubRepo: "other-index"}
+	assert.Equal(t, "v1.0.0", resolveTag(context.Background(), other, TagLatest))
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), listed.Load(), "concurrent resolutions of the same index should share one listing")
+}
+
+func TestResolveTag_FallsBackToDefaultBranch(t *testing.T) {
+	stubs := gostub.Stub(&listTags, func(context.Context, RemoteIndex) ([]string, error) {
+		return nil, NotFoundError
+	}).Stub(&latestTags, newLatestTagCache())
+	defer stubs.Reset()
+
+	assert.Equal(t, "", resolveTag(context.Background(), RemoteIndexMap[AzureRMInternal], ""))
+}
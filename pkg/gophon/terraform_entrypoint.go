@@ -2,24 +2,27 @@ package gophon
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"os"
 	"strings"
 
 	"github.com/google/go-github/v74/github"
 )
 
 var validEntrypoints = map[string]map[string]struct{}{
+	// identity, state_upgrade and customize_diff are only in the index of resources implementing them, like the
+	// resource identity of recent providers, the schema version migrations and the CustomizeDiff of a resource
 	"resource": {
-		"create":    {},
-		"read":      {},
-		"update":    {},
-		"delete":    {},
-		"schema":    {},
-		"attribute": {},
+		"create":         {},
+		"read":           {},
+		"update":         {},
+		"delete":         {},
+		"schema":         {},
+		"attribute":      {},
+		"identity":       {},
+		"state_upgrade":  {},
+		"customize_diff": {},
 	},
 	"data": {
 		"read":      {},
@@ -32,26 +35,51 @@ var validEntrypoints = map[string]map[string]struct{}{
 		"renew":  {},
 		"schema": {},
 	},
+	"function": {
+		"definition": {},
+		"run":        {},
+	},
+	// the terraform type of a provider is the provider name, like `azurerm`
+	"provider": {
+		"configure": {},
+	},
 }
 
 var NotFoundError = errors.New("source code not found (404)")
 
-// readURLContent reads content from a URL and returns it as []byte
-func readURLContent(owner string, repo string, path string, tag string) ([]byte, error) {
-	githubClient := github.NewClient(&http.Client{})
-
-	// Add GitHub token as Bearer authorization header if environment variable is set
-	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-		githubClient = githubClient.WithAuthToken(token)
+// readURLContent reads a file of a GitHub repository at tag through the git trees of its directories, so repeated reads
+// of the same version are served by the content cache without requests. It's a variable so tests can stub it.
+var readURLContent = func(ctx context.Context, owner string, repo string, path string, tag string) ([]byte, error) {
+	if root := localIndexPath(owner, repo); root != "" {
+		recordIndexRef(ctx, IndexRef{Repository: owner + "/" + repo, LocalPath: root})
+		return readLocalFile(root, path)
+	}
+	githubClient, err := newGitHubClient()
+	if err != nil {
+		return nil, err
+	}
+	content, err := readGitFile(ctx, githubClient, owner, repo, path, tag)
+	if !errors.Is(err, errTreeTruncated) {
+		return content, err
 	}
+	return readContentsFile(ctx, githubClient, owner, repo, path, tag)
+}
+
+// readContentsFile reads a file with the contents API, for the files of directories too large for a git tree
+func readContentsFile(ctx context.Context, githubClient *github.Client, owner string, repo string, path string, tag string) ([]byte, error) {
 	option := &github.RepositoryContentGetOptions{}
 	if tag != "" {
 		option.Ref = tag
 	}
-	fileContent, _, resp, err := githubClient.Repositories.GetContents(context.Background(), owner, repo, path, option)
-
+	ctx, cancel := requestContext(ctx)
+	defer cancel()
+	fileContent, _, resp, err := githubClient.Repositories.GetContents(ctx, owner, repo, path, option)
+	// GitHub client returns an error for 404 responses
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, NotFoundError
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch URL %s: %w", path, err)
+		return nil, fmt.Errorf("failed to fetch URL %s: %w", path, describeRateLimit(err))
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
@@ -59,10 +87,6 @@ func readURLContent(owner string, repo string, path string, tag string) ([]byte,
 		}
 	}()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, NotFoundError
-	}
-
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("HTTP request failed with status %d for URL: %s", resp.StatusCode, path)
 	}
@@ -74,48 +98,112 @@ func readURLContent(owner string, repo string, path string, tag string) ([]byte,
 	return []byte(content), nil
 }
 
-func GetTerraformSourceCode(blockType, terraformType, entrypointName, tag string) (string, error) {
+func GetTerraformSourceCode(ctx context.Context, blockType, terraformType, entrypointName, tag string) (string, error) {
+	return GetProviderTerraformSourceCode(ctx, "", blockType, terraformType, entrypointName, tag)
+}
+
+// GetProviderTerraformSourceCode is GetTerraformSourceCode reading the code of providerName, for providers sharing the
+// terraform types of another one like `google-beta`. providerName defaults to the prefix of terraformType.
+func GetProviderTerraformSourceCode(ctx context.Context, providerName, blockType, terraformType, entrypointName, tag string) (string, error) {
+	entrypoint, err := readTerraformEntrypoint(ctx, providerName, blockType, terraformType, entrypointName, tag)
+	if err != nil {
+		return "", err
+	}
+	return entrypoint.source, nil
+}
+
+// terraformEntrypoint is the source code of an entrypoint of a terraform block, and where it was read from
+type terraformEntrypoint struct {
+	// namespace is the golang namespace the entrypoint is declared in
+	namespace string
+	tag       string
+	source    string
+}
+
+func readTerraformEntrypoint(ctx context.Context, providerName, blockType, terraformType, entrypointName, tag string) (*terraformEntrypoint, error) {
 	entryPoints, ok := validEntrypoints[blockType]
 	if !ok {
-		return "", fmt.Errorf("invalid block type: %s", blockType)
+		return nil, fmt.Errorf("invalid block type: %s", blockType)
 	}
 	if _, ok := entryPoints[entrypointName]; !ok {
-		return "", fmt.Errorf("invalid entrypoint name: %s for block type: %s", entrypointName, blockType)
-	}
-	segments := strings.Split(terraformType, "_")
-	if len(segments) < 2 {
-		return "", fmt.Errorf("invalid terraform type: %s, valid terraform type should be like `azurerm_resource_group`", terraformType)
+		return nil, fmt.Errorf("invalid entrypoint name: %s for block type: %s", entrypointName, blockType)
 	}
-	providerType := segments[0]
-	indexKey, ok := ProviderIndexMap[providerType]
-	if !ok {
-		return "", fmt.Errorf("unsupported provider type: %s, supported providers are: %v", providerType, GetSupportedProviders())
+	if blockType == "provider" {
+		return readProviderConfigure(ctx, providerName, terraformType, tag)
 	}
-	remoteIndex := RemoteIndexMap[indexKey]
-	if blockType != "ephemeral" {
-		blockType += "s"
+	var remoteIndex RemoteIndex
+	var err error
+	if blockType == "function" {
+		remoteIndex, terraformType, err = functionRemoteIndex(providerName, terraformType)
+	} else {
+		remoteIndex, err = providerRemoteIndex(providerName, terraformType)
 	}
-	path := fmt.Sprintf("%s/%s/%s.json", "index", blockType, terraformType)
-
-	// Use the helper function to read content from the URL
-	content, err := readURLContent(remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, path, tag)
 	if err != nil {
-		return "", fmt.Errorf("failed to read content from URL: %w", err)
+		return nil, err
 	}
+	path := fmt.Sprintf("%s/%s/%s.json", "index", blockIndexDir(blockType), terraformType)
+	tag = resolveTag(ctx, remoteIndex, tag)
 
-	index := make(map[string]string)
-	if err = json.Unmarshal(content, &index); err != nil {
-		return "", fmt.Errorf("failed to unmarshal JSON content from URL %s: %w", path, err)
+	index, err := readBlockIndex(ctx, remoteIndex, path, tag)
+	if err != nil {
+		return nil, err
 	}
 	entrypointName += "_index"
 	entryPoint := index[entrypointName]
+	if entryPoint == "" {
+		return nil, fmt.Errorf("%s %s has no %s: %w", blockType, terraformType, strings.TrimSuffix(entrypointName, "_index"), NotFoundError)
+	}
 	namespace := index["namespace"]
-	namespace = strings.TrimPrefix(namespace, remoteIndex.PackagePath)
-	sourceCode, err := readURLContent(remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, "index"+namespace+"/"+entryPoint, "")
+	sourceCode, err := readURLContent(ctx, remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, "index"+strings.TrimPrefix(namespace, remoteIndex.PackagePath)+"/"+entryPoint, tag)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	return &terraformEntrypoint{namespace: namespace, tag: tag, source: string(sourceCode)}, nil
+}
+
+// providerRemoteIndex returns the index of providerName, or of the provider prefixing terraformType when it's empty.
+// Beta providers share the terraform types of their GA provider, like `google_compute_instance`.
+func providerRemoteIndex(providerName, terraformType string) (RemoteIndex, error) {
+	segments := strings.Split(terraformType, "_")
+	if len(segments) < 2 {
+		return RemoteIndex{}, fmt.Errorf("invalid terraform type: %s, valid terraform type should be like `azurerm_resource_group`", terraformType)
+	}
+	providerType := segments[0]
+	if providerName == "" {
+		providerName = providerType
+	}
+	if strings.TrimSuffix(providerName, "-beta") != providerType {
+		return RemoteIndex{}, fmt.Errorf("terraform type %s doesn't belong to provider %s", terraformType, providerName)
+	}
+	indexKey, ok := ProviderIndexMap[providerName]
+	if !ok {
+		return RemoteIndex{}, fmt.Errorf("unsupported provider type: %s, supported providers are: %v", providerName, GetSupportedProviders())
+	}
+	return RemoteIndexMap[indexKey], nil
+}
+
+// functionRemoteIndex returns the index of the provider defining a provider function and the function name. Function
+// names aren't prefixed by their provider, so the function is either called like `provider::azurerm::parse_resource_id`
+// or providerName must be set.
+func functionRemoteIndex(providerName, function string) (RemoteIndex, string, error) {
+	if after, ok := strings.CutPrefix(function, "provider::"); ok {
+		provider, name, ok := strings.Cut(after, "::")
+		if !ok || provider == "" || name == "" || strings.Contains(name, "::") {
+			return RemoteIndex{}, "", fmt.Errorf("invalid provider function: %s, valid provider function should be like `provider::azurerm::parse_resource_id`", function)
+		}
+		if providerName != "" && providerName != provider {
+			return RemoteIndex{}, "", fmt.Errorf("provider function %s doesn't belong to provider %s", function, providerName)
+		}
+		providerName, function = provider, name
+	}
+	if providerName == "" {
+		return RemoteIndex{}, "", fmt.Errorf("provider is required for provider function %s, or call it like `provider::<provider>::%s`", function, function)
+	}
+	indexKey, ok := ProviderIndexMap[providerName]
+	if !ok {
+		return RemoteIndex{}, "", fmt.Errorf("unsupported provider type: %s, supported providers are: %v", providerName, GetSupportedProviders())
 	}
-	return string(sourceCode), nil
+	return RemoteIndexMap[indexKey], function, nil
 }
 
 func formatVersion(tag string) string {
@@ -1,26 +1,188 @@
 package gophon
 
 import (
+	"context"
 	"testing"
 
+	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestGetLatestResourceCreateSourceCode(t *testing.T) {
-	code, err := GetTerraformSourceCode("resource", "azurerm_resource_group", "create", "")
+	code, err := GetTerraformSourceCode(context.Background(), "resource", "azurerm_resource_group", "create", "")
 	require.NoError(t, err)
 	assert.Contains(t, code, "func resourceResourceGroupCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error")
 }
 
 func TestGetTagVersionResourceCreateSourceCode(t *testing.T) {
-	code, err := GetTerraformSourceCode("resource", "azurerm_resource_group", "create", "v4.25.0")
+	code, err := GetTerraformSourceCode(context.Background(), "resource", "azurerm_resource_group", "create", "v4.25.0")
 	require.NoError(t, err)
 	assert.Contains(t, code, "func resourceResourceGroupCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error")
 }
 
 func TestGetTagVersionEphemeralOpenSourceCode(t *testing.T) {
-	code, err := GetTerraformSourceCode("ephemeral", "azurerm_key_vault_secret", "open", "v4.25.0")
+	code, err := GetTerraformSourceCode(context.Background(), "ephemeral", "azurerm_key_vault_secret", "open", "v4.25.0")
 	require.NoError(t, err)
 	assert.Contains(t, code, "func (e *KeyVaultSecretEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {")
 }
+
+func TestProviderRemoteIndex(t *testing.T) {
+	cases := []struct {
+		name          string
+		providerName  string
+		terraformType string
+		expectedRepo  string
+		expectedError string
+	}{
+		{
+			name:          "provider inferred from terraform type",
+			terraformType: "google_compute_instance",
+			expectedRepo:  "terraform-provider-google-index",
+		},
+		{
+			name:          "beta provider",
+			providerName:  "google-beta",
+			terraformType: "google_compute_instance",
+			expectedRepo:  "terraform-provider-google-beta-index",
+		},
+		{
+			name:          "provider prefixed by another provider",
+			terraformType: "awscc_ec2_instance",
+			expectedRepo:  "terraform-provider-awscc-index",
+		},
+		{
+			name:          "provider not matching terraform type",
+			providerName:  "google-beta",
+			terraformType: "azurerm_resource_group",
+			expectedError: "terraform type azurerm_resource_group doesn't belong to provider google-beta",
+		},
+		{
+			name:          "invalid terraform type",
+			terraformType: "google",
+			expectedError: "invalid terraform type: google, valid terraform type should be like `azurerm_resource_group`",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			index, err := providerRemoteIndex(c.providerName, c.terraformType)
+			if c.expectedError != "" {
+				assert.EqualError(t, err, c.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expectedRepo, index.GitHubRepo)
+		})
+	}
+}
+
+func TestFunctionRemoteIndex(t *testing.T) {
+	cases := []struct {
+		name             string
+		providerName     string
+		function         string
+		expectedRepo     string
+		expectedFunction string
+		expectedError    string
+	}{
+		{
+			name:             "provider function call",
+			function:         "provider::azurerm::parse_resource_id",
+			expectedRepo:     "terraform-provider-azurerm-index",
+			expectedFunction: "parse_resource_id",
+		},
+		{
+			name:             "function name with provider",
+			providerName:     "aws",
+			function:         "arn_parse",
+			expectedRepo:     "terraform-provider-aws-index",
+			expectedFunction: "arn_parse",
+		},
+		{
+			name:          "function name without provider",
+			function:      "parse_resource_id",
+			expectedError: "provider is required for provider function parse_resource_id, or call it like `provider::<provider>::parse_resource_id`",
+		},
+		{
+			name:          "provider not matching function",
+			providerName:  "aws",
+			function:      "provider::azurerm::parse_resource_id",
+			expectedError: "provider function provider::azurerm::parse_resource_id doesn't belong to provider aws",
+		},
+		{
+			name:          "invalid function",
+			function:      "provider::azurerm",
+			expectedError: "invalid provider function: provider::azurerm, valid provider function should be like `provider::azurerm::parse_resource_id`",
+		},
+		{
+			name:          "unsupported provider",
+			function:      "provider::unknown::parse",
+			expectedError: "unsupported provider type: unknown",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			index, function, err := functionRemoteIndex(c.providerName, c.function)
+			if c.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), c.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expectedRepo, index.GitHubRepo)
+			assert.Equal(t, c.expectedFunction, function)
+		})
+	}
+}
+
+func TestGetProviderTerraformSourceCode_Function(t *testing.T) {
+	files := map[string]string{
+		"index/functions/parse_resource_id.json":                                             `{"namespace": "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function", "definition_index": "method.ParseResourceIDFunction.Definition.goindex", "run_index": "method.ParseResourceIDFunction.Run.goindex"}`,
+		"index/internal/provider/function/method.ParseResourceIDFunction.Run.goindex":        "func (a *ParseResourceIDFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {}",
+		"index/internal/provider/function/method.ParseResourceIDFunction.Definition.goindex": "func (a *ParseResourceIDFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {}",
+	}
+	stubTags(t)
+	stubs := gostub.Stub(&blockIndexes, newBlockIndexCache()).Stub(&readURLContent, func(_ context.Context, owner string, repo string, path string, tag string) ([]byte, error) {
+		assert.Equal(t, "terraform-provider-azurerm-index", repo)
+		content, ok := files[path]
+		if !ok {
+			return nil, NotFoundError
+		}
+		return []byte(content), nil
+	})
+	defer stubs.Reset()
+
+	code, err := GetProviderTerraformSourceCode(context.Background(), "", "function", "provider::azurerm::parse_resource_id", "run", "")
+	require.NoError(t, err)
+	assert.Contains(t, code, "func (a *ParseResourceIDFunction) Run(")
+
+	code, err = GetProviderTerraformSourceCode(context.Background(), "azurerm", "function", "parse_resource_id", "definition", "")
+	require.NoError(t, err)
+	assert.Contains(t, code, "func (a *ParseResourceIDFunction) Definition(")
+
+	_, err = GetProviderTerraformSourceCode(context.Background(), "azurerm", "function", "parse_resource_id", "create", "")
+	assert.EqualError(t, err, "invalid entrypoint name: create for block type: function")
+}
+
+func TestGetProviderTerraformSourceCode_OptionalEntrypoints(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/resources/azurerm_storage_account.json":                             `{"namespace": "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage", "state_upgrade_index": "func.StorageAccountV0ToV1.goindex", "customize_diff_index": "func.storageAccountCustomizeDiff.goindex"}`,
+		"index/internal/services/storage/func.StorageAccountV0ToV1.goindex":        "func StorageAccountV0ToV1() {}",
+		"index/internal/services/storage/func.storageAccountCustomizeDiff.goindex": "func storageAccountCustomizeDiff() {}",
+	})
+
+	code, err := GetTerraformSourceCode(context.Background(), "resource", "azurerm_storage_account", "state_upgrade", "")
+	require.NoError(t, err)
+	assert.Equal(t, "func StorageAccountV0ToV1() {}", code)
+
+	code, err = GetTerraformSourceCode(context.Background(), "resource", "azurerm_storage_account", "customize_diff", "")
+	require.NoError(t, err)
+	assert.Equal(t, "func storageAccountCustomizeDiff() {}", code)
+
+	_, err = GetTerraformSourceCode(context.Background(), "resource", "azurerm_storage_account", "identity", "")
+	assert.ErrorIs(t, err, NotFoundError)
+	assert.EqualError(t, err, "resource azurerm_storage_account has no identity: source code not found (404)")
+
+	_, err = GetTerraformSourceCode(context.Background(), "data", "azurerm_storage_account", "identity", "")
+	assert.EqualError(t, err, "invalid entrypoint name: identity for block type: data")
+}
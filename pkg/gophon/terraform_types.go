@@ -0,0 +1,61 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// TerraformBlockTypes are the block types indexed by provider index repositories, in the order they're listed
+var TerraformBlockTypes = []string{"resource", "data", "ephemeral", "function"}
+
+// ListTerraformTypes lists the terraform types indexed for a provider at tag, keyed by block type, from the
+// `index/<block type>s` directories of its index repository. Provider functions are listed by name, like
+// `parse_resource_id`. blockType lists the types of one block type when it's not empty, and only the types containing
+// filter, case-insensitively, are listed when it's not empty. Block types the index has no directory for are empty.
+func ListTerraformTypes(ctx context.Context, providerName, blockType, filter, tag string) (map[string][]string, error) {
+	indexKey, ok := ProviderIndexMap[providerName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider type: %s, supported providers are: %v", providerName, GetSupportedProviders())
+	}
+	blockTypes := TerraformBlockTypes
+	if blockType != "" {
+		if !slices.Contains(TerraformBlockTypes, blockType) {
+			return nil, fmt.Errorf("invalid block type: %s", blockType)
+		}
+		blockTypes = []string{blockType}
+	}
+	remoteIndex := RemoteIndexMap[indexKey]
+	tag = resolveTag(ctx, remoteIndex, tag)
+	filter = strings.ToLower(filter)
+	types := make(map[string][]string, len(blockTypes))
+	for _, b := range blockTypes {
+		dir := "index/" + blockIndexDir(b)
+		entries, err := readDirectoryContent(ctx, remoteIndex.GitHubOwner, remoteIndex.GitHubRepo, dir, tag)
+		if err != nil && !errors.Is(err, NotFoundError) {
+			return nil, fmt.Errorf("failed to list %s types of provider %s: %w", b, providerName, err)
+		}
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			name, ok := strings.CutSuffix(entry.GetName(), ".json")
+			if !ok || entry.GetType() != "file" || !strings.Contains(strings.ToLower(name), filter) {
+				continue
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		types[b] = names
+	}
+	return types, nil
+}
+
+// blockIndexDir returns the directory of the index files of blockType under `index`, like `resources`
+func blockIndexDir(blockType string) string {
+	if blockType == "ephemeral" {
+		return blockType
+	}
+	return blockType + "s"
+}
@@ -0,0 +1,58 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTerraformTypes(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/resources/azurerm_subnet.json":            "{}",
+		"index/resources/azurerm_resource_group.json":    "{}",
+		"index/resources/README.md":                      "",
+		"index/datas/azurerm_subnet.json":                "{}",
+		"index/functions/parse_resource_id.json":         "{}",
+		"index/internal/services/network/func.a.goindex": "",
+	})
+	cases := []struct {
+		desc      string
+		blockType string
+		filter    string
+		expected  map[string][]string
+	}{
+		{
+			desc: "all block types",
+			expected: map[string][]string{
+				"resource":  {"azurerm_resource_group", "azurerm_subnet"},
+				"data":      {"azurerm_subnet"},
+				"ephemeral": {},
+				"function":  {"parse_resource_id"},
+			},
+		},
+		{
+			desc:      "block type",
+			blockType: "resource",
+			expected:  map[string][]string{"resource": {"azurerm_resource_group", "azurerm_subnet"}},
+		},
+		{
+			desc:     "filter",
+			filter:   "SUBNET",
+			expected: map[string][]string{"resource": {"azurerm_subnet"}, "data": {"azurerm_subnet"}, "ephemeral": {}, "function": {}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			types, err := ListTerraformTypes(context.Background(), "azurerm", c.blockType, c.filter, "")
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, types)
+		})
+	}
+
+	_, err := ListTerraformTypes(context.Background(), "unknown", "", "", "")
+	assert.Error(t, err)
+	_, err = ListTerraformTypes(context.Background(), "azurerm", "module", "", "")
+	assert.Error(t, err)
+}
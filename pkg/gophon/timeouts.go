@@ -0,0 +1,218 @@
+package gophon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationUnits are the duration constants of the time package
+var durationUnits = map[string]time.Duration{
+	"Nanosecond":  time.Nanosecond,
+	"Microsecond": time.Microsecond,
+	"Millisecond": time.Millisecond,
+	"Second":      time.Second,
+	"Minute":      time.Minute,
+	"Hour":        time.Hour,
+}
+
+// Timeout is the default timeout of an operation of a terraform block
+type Timeout struct {
+	// Operation is `create`, `read`, `update`, `delete`, or `default` for the timeout of the operations without one
+	Operation string `json:"operation"`
+	// Default is the duration of Expression, like `30m0s`, empty when it isn't a constant like `defaultCreateTimeout`
+	Default    string `json:"default,omitempty"`
+	Expression string `json:"expression"`
+}
+
+// BlockTimeouts is the result of GetTimeouts
+type BlockTimeouts struct {
+	BlockType     string    `json:"block_type"`
+	TerraformType string    `json:"terraform_type"`
+	Timeouts      []Timeout `json:"timeouts"`
+}
+
+// GetTimeouts reads the default timeouts of the operations of a resource or data source from its source code, that
+// schemas don't have. Timeouts are read from the `Timeouts: &pluginsdk.ResourceTimeout{` of the schema of plugin SDKv2
+// blocks, and from the `Timeout` of the `sdk.ResourceFunc` of every entrypoint of typed blocks. Blocks of the plugin
+// framework set their timeouts at runtime and aren't supported.
+func GetTimeouts(ctx context.Context, providerName, blockType, terraformType, tag string) (*BlockTimeouts, error) {
+	if blockType != "resource" && blockType != "data" {
+		return nil, fmt.Errorf("invalid block type: %s, only resources and data sources have timeouts", blockType)
+	}
+	result := &BlockTimeouts{BlockType: blockType, TerraformType: terraformType}
+	source, err := GetProviderTerraformSourceCode(ctx, providerName, blockType, terraformType, "schema", tag)
+	if err != nil && !errors.Is(err, NotFoundError) {
+		return nil, err
+	}
+	if err == nil {
+		if result.Timeouts, err = findResourceTimeouts(source); err != nil {
+			return nil, fmt.Errorf("failed to parse schema of %s %s: %w", blockType, terraformType, err)
+		}
+	}
+	if len(result.Timeouts) > 0 {
+		return result, nil
+	}
+	for _, entrypoint := range apiEntrypoints[blockType] {
+		source, err := GetProviderTerraformSourceCode(ctx, providerName, blockType, terraformType, entrypoint, tag)
+		if errors.Is(err, NotFoundError) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		timeout, err := findResourceFuncTimeout(source, entrypoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s of %s %s: %w", entrypoint, blockType, terraformType, err)
+		}
+		if timeout != nil {
+			result.Timeouts = append(result.Timeouts, *timeout)
+		}
+	}
+	if len(result.Timeouts) == 0 {
+		return nil, fmt.Errorf("no timeouts found in the source code of %s %s, they may be set at runtime: %w", blockType, terraformType, NotFoundError)
+	}
+	return result, nil
+}
+
+// findResourceTimeouts returns the timeouts of the first `ResourceTimeout` literal keyed by `Timeouts` in source, like
+// `Timeouts: &pluginsdk.ResourceTimeout{Create: pluginsdk.DefaultTimeout(30 * time.Minute)}`
+func findResourceTimeouts(source string) ([]Timeout, error) {
+	declaration, err := parseDeclaration(source)
+	if err != nil {
+		return nil, err
+	}
+	literal := findFieldLiteral(declaration.file, "Timeouts", "ResourceTimeout")
+	if literal == nil {
+		return nil, nil
+	}
+	var timeouts []Timeout
+	for _, elt := range literal.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		value := kv.Value
+		// `DefaultTimeout(d)` returns a pointer to d
+		if call, ok := value.(*ast.CallExpr); ok && len(call.Args) == 1 && strings.HasSuffix(types.ExprString(call.Fun), "DefaultTimeout") {
+			value = call.Args[0]
+		}
+		timeouts = append(timeouts, newTimeout(strings.ToLower(key.Name), declaration.text(kv.Value), value))
+	}
+	return timeouts, nil
+}
+
+// findResourceFuncTimeout returns the `Timeout` of the `ResourceFunc` literal returned by a typed entrypoint, or nil
+// when it's not found
+func findResourceFuncTimeout(source, entrypoint string) (*Timeout, error) {
+	declaration, err := parseDeclaration(source)
+	if err != nil {
+		return nil, err
+	}
+	var found *Timeout
+	ast.Inspect(declaration.file, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		literal, ok := n.(*ast.CompositeLit)
+		if !ok || !strings.HasSuffix(types.ExprString(literal.Type), "ResourceFunc") {
+			return true
+		}
+		for _, elt := range literal.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok && isIdent(kv.Key, "Timeout") {
+				timeout := newTimeout(entrypoint, declaration.text(kv.Value), kv.Value)
+				found = &timeout
+				return false
+			}
+		}
+		return true
+	})
+	return found, nil
+}
+
+// findFieldLiteral returns the first composite literal under node keyed by the field name whose type ends with
+// typeSuffix
+func findFieldLiteral(node ast.Node, name, typeSuffix string) *ast.CompositeLit {
+	var found *ast.CompositeLit
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok || !isIdent(kv.Key, name) {
+			return true
+		}
+		if literal, ok := unwrapLiteral(kv.Value); ok && strings.HasSuffix(types.ExprString(literal.Type), typeSuffix) {
+			found = literal
+		}
+		return found == nil
+	})
+	return found
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+func newTimeout(operation, expression string, value ast.Expr) Timeout {
+	timeout := Timeout{Operation: operation, Expression: expression}
+	if d, ok := evalDuration(value); ok {
+		timeout.Default = d.String()
+	}
+	return timeout
+}
+
+// evalDuration evaluates constant duration expressions, like `30 * time.Minute` or `time.Hour + 30*time.Minute`
+func evalDuration(expr ast.Expr) (time.Duration, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT {
+			return 0, false
+		}
+		v, err := strconv.ParseInt(e.Value, 0, 64)
+		return time.Duration(v), err == nil
+	case *ast.SelectorExpr:
+		if !isIdent(e.X, "time") {
+			return 0, false
+		}
+		unit, ok := durationUnits[e.Sel.Name]
+		return unit, ok
+	case *ast.ParenExpr:
+		return evalDuration(e.X)
+	case *ast.CallExpr:
+		// conversions like `time.Duration(30)`
+		if len(e.Args) != 1 || types.ExprString(e.Fun) != "time.Duration" {
+			return 0, false
+		}
+		return evalDuration(e.Args[0])
+	case *ast.BinaryExpr:
+		x, ok := evalDuration(e.X)
+		if !ok {
+			return 0, false
+		}
+		y, ok := evalDuration(e.Y)
+		if !ok {
+			return 0, false
+		}
+		switch e.Op {
+		case token.MUL:
+			return x * y, true
+		case token.ADD:
+			return x + y, true
+		case token.SUB:
+			return x - y, true
+		}
+	}
+	return 0, false
+}
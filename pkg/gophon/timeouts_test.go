@@ -0,0 +1,99 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTimeouts(t *testing.T) {
+	network := "index/internal/services/network"
+	containers := "index/internal/services/containerapps"
+	stubIndexFiles(t, map[string]string{
+		"index/resources/azurerm_subnet.json": `{"namespace": "github.com/hashicorp/terraform-provider-azurerm/internal/services/network", "schema_index": "func.resourceSubnet.goindex"}`,
+		network + "/func.resourceSubnet.goindex": `func resourceSubnet() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(time.Hour + 30*time.Minute),
+			Delete: pluginsdk.DefaultTimeout(defaultDeleteTimeout),
+		},
+	}
+}`,
+		"index/resources/azurerm_container_app.json": `{"namespace": "github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps", "schema_index": "method.ContainerAppResource.Arguments.goindex", "create_index": "method.ContainerAppResource.Create.goindex", "delete_index": "method.ContainerAppResource.Delete.goindex"}`,
+		containers + "/method.ContainerAppResource.Arguments.goindex": `func (r ContainerAppResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}`,
+		containers + "/method.ContainerAppResource.Create.goindex": `func (r ContainerAppResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return nil
+		},
+	}
+}`,
+		containers + "/method.ContainerAppResource.Delete.goindex": `func (r ContainerAppResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+	}
+}`,
+		"index/resources/azurerm_function.json": `{"namespace": "github.com/hashicorp/terraform-provider-azurerm/internal/services/network", "schema_index": "func.resourceFunction.goindex"}`,
+		network + "/func.resourceFunction.goindex": `func resourceFunction() *pluginsdk.Resource {
+	return &pluginsdk.Resource{}
+}`,
+	})
+	cases := []struct {
+		terraformType string
+		expected      []Timeout
+	}{
+		{
+			terraformType: "azurerm_subnet",
+			expected: []Timeout{
+				{Operation: "create", Default: "30m0s", Expression: "pluginsdk.DefaultTimeout(30 * time.Minute)"},
+				{Operation: "read", Default: "5m0s", Expression: "pluginsdk.DefaultTimeout(5 * time.Minute)"},
+				{Operation: "update", Default: "1h30m0s", Expression: "pluginsdk.DefaultTimeout(time.Hour + 30*time.Minute)"},
+				{Operation: "delete", Expression: "pluginsdk.DefaultTimeout(defaultDeleteTimeout)"},
+			},
+		},
+		{
+			terraformType: "azurerm_container_app",
+			expected: []Timeout{
+				{Operation: "create", Default: "30m0s", Expression: "30 * time.Minute"},
+				{Operation: "delete", Default: "30m0s", Expression: "30 * time.Minute"},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.terraformType, func(t *testing.T) {
+			result, err := GetTimeouts(context.Background(), "", "resource", c.terraformType, "")
+			require.NoError(t, err)
+			assert.Equal(t, c.terraformType, result.TerraformType)
+			assert.Equal(t, c.expected, result.Timeouts)
+		})
+	}
+
+	_, err := GetTimeouts(context.Background(), "", "resource", "azurerm_function", "")
+	assert.ErrorIs(t, err, NotFoundError)
+	_, err = GetTimeouts(context.Background(), "", "ephemeral", "azurerm_subnet", "")
+	assert.Error(t, err)
+}
+
+func TestEvalDuration(t *testing.T) {
+	cases := map[string]string{
+		"30 * time.Minute":                "30m0s",
+		"(2 * time.Hour)":                 "2h0m0s",
+		"time.Duration(90) * time.Second": "1m30s",
+		"time.Hour - 15*time.Minute":      "45m0s",
+	}
+	for expression, expected := range cases {
+		t.Run(expression, func(t *testing.T) {
+			timeouts, err := findResourceTimeouts("var v = x{Timeouts: &schema.ResourceTimeout{Create: schema.DefaultTimeout(" + expression + ")}}")
+			require.NoError(t, err)
+			require.Len(t, timeouts, 1)
+			assert.Equal(t, expected, timeouts[0].Default)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package gophon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// maxTypeMethodsSize caps the bytes of method source code returned by GetTypeWithMethods
+var maxTypeMethodsSize = 64 * 1024
+
+// MethodSource is the source code of a method of a type
+type MethodSource struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// TypeWithMethods is the source code of a type and of its indexed methods
+type TypeWithMethods struct {
+	Source   string          `json:"source"`
+	Location *SourceLocation `json:"location,omitempty"`
+	Methods  []MethodSource  `json:"methods"`
+	// OmittedMethods are the methods left out once the methods exceeded maxTypeMethodsSize, they can be read one by one
+	OmittedMethods []string `json:"omitted_methods,omitempty"`
+}
+
+// GetTypeWithMethods reads the source code of the type name in namespace along with the source code of the methods
+// indexed for it as receiver, sorted by name, so a struct and its methods are read in one call. Methods are added
+// until their source code exceeds maxTypeMethodsSize bytes, the names of the others are returned in OmittedMethods.
+// The location of the type is returned too when includeLocation is true.
+func GetTypeWithMethods(ctx context.Context, namespace, name, tag string, includeLocation bool) (*TypeWithMethods, error) {
+	remoteIndex, err := namespaceRemoteIndex(namespace)
+	if err != nil {
+		return nil, err
+	}
+	tag = resolveTag(ctx, remoteIndex, tag)
+	code := &SourceCode{}
+	if includeLocation {
+		code, err = GetGolangSourceCodeWithLocation(ctx, namespace, "type", "", name, tag)
+	} else {
+		code.Source, err = GetGolangSourceCode(ctx, namespace, "type", "", name, tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sources, err := listIndexedSources(ctx, []string{namespace}, tag)
+	if err != nil {
+		return nil, err
+	}
+	sources = slices.DeleteFunc(sources, func(source indexedSource) bool {
+		return source.symbol.Symbol != "method" || source.symbol.Receiver != name
+	})
+	methods := make([]MethodSource, 0, len(sources))
+	var mu sync.Mutex
+	err = readIndexedSources(ctx, sources, func(source indexedSource, content string) {
+		mu.Lock()
+		defer mu.Unlock()
+		methods = append(methods, MethodSource{Name: source.symbol.Name, Source: content})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read methods of %s: %w", name, err)
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+	result := &TypeWithMethods{Source: code.Source, Location: code.Location, Methods: make([]MethodSource, 0, len(methods))}
+	size := 0
+	for i, method := range methods {
+		if size += len(method.Source); size > maxTypeMethodsSize {
+			for _, omitted := range methods[i:] {
+				result.OmittedMethods = append(result.OmittedMethods, omitted.Name)
+			}
+			break
+		}
+		result.Methods = append(result.Methods, method)
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTypeWithMethods(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/internal/services/network/type.VirtualNetworkResource.goindex":          "type VirtualNetworkResource struct{}",
+		"index/internal/services/network/method.VirtualNetworkResource.Read.goindex":   "func (r VirtualNetworkResource) Read() {}",
+		"index/internal/services/network/method.VirtualNetworkResource.Create.goindex": "func (r VirtualNetworkResource) Create() {}",
+		"index/internal/services/network/method.VirtualNetworkResource.Update.goindex": "func (r VirtualNetworkResource) Update() {}",
+		"index/internal/services/network/method.SubnetResource.Read.goindex":           "func (r SubnetResource) Read() {}",
+		"index/internal/services/network/func.VirtualNetworkResource.goindex":          "func VirtualNetworkResource() {}",
+	})
+	network := AzureRMInternal + "/services/network"
+
+	tests := []struct {
+		desc     string
+		maxSize  int
+		expected *TypeWithMethods
+	}{
+		{
+			desc:    "all methods",
+			maxSize: 1024,
+			expected: &TypeWithMethods{
+				Source: "type VirtualNetworkResource struct{}",
+				Methods: []MethodSource{
+					{Name: "Create", Source: "func (r VirtualNetworkResource) Create() {}"},
+					{Name: "Read", Source: "func (r VirtualNetworkResource) Read() {}"},
+					{Name: "Update", Source: "func (r VirtualNetworkResource) Update() {}"},
+				},
+			},
+		},
+		{
+			desc:    "methods beyond the size are omitted",
+			maxSize: 90,
+			expected: &TypeWithMethods{
+				Source: "type VirtualNetworkResource struct{}",
+				Methods: []MethodSource{
+					{Name: "Create", Source: "func (r VirtualNetworkResource) Create() {}"},
+					{Name: "Read", Source: "func (r VirtualNetworkResource) Read() {}"},
+				},
+				OmittedMethods: []string{"Update"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			stubs := gostub.Stub(&maxTypeMethodsSize, tt.maxSize)
+			defer stubs.Reset()
+			result, err := GetTypeWithMethods(context.Background(), network, "VirtualNetworkResource", "", false)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, err := GetTypeWithMethods(context.Background(), network, "Missing", "", false)
+	assert.ErrorIs(t, err, NotFoundError)
+}
@@ -0,0 +1,144 @@
+package gophon
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ValueMatch is a constant or variable whose value matches a value search
+type ValueMatch struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Type is the declared type, like `SkuName`, empty for untyped constants
+	Type string `json:"type,omitempty"`
+	// Value is the value of the literal, unquoted for strings, like `Standard_LRS`
+	Value string `json:"value"`
+	// Declaration is the source code of the declaration, like `SkuNameStandardLRS SkuName = "Standard_LRS"`
+	Declaration string `json:"declaration"`
+}
+
+// ValueSearchResult is the result of SearchValues
+type ValueSearchResult struct {
+	Matches []ValueMatch `json:"matches"`
+	// Scanned is the number of variables and constants whose source code was searched
+	Scanned int `json:"scanned"`
+	// Truncated is true when the namespace has more variables than maxReferenceFiles, the others weren't searched
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// SearchValues searches the constants and variables indexed in namespace, not its sub-packages, for the ones whose
+// value is a literal equal to value, case-insensitively, or matching the regular expression value when regex is true.
+// It answers where a value like `Standard_LRS` or an enum default is defined, which a search by name can't. Literals
+// passed to a call with one argument, like the conversion `SkuName("Standard_LRS")`, are matched too, computed values
+// aren't.
+func SearchValues(ctx context.Context, namespace, value, tag string, regex bool) (*ValueSearchResult, error) {
+	if value == "" {
+		return nil, fmt.Errorf("value cannot be empty")
+	}
+	match := func(v string) bool {
+		return strings.EqualFold(v, value)
+	}
+	if regex {
+		r, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", value, err)
+		}
+		match = r.MatchString
+	}
+	sources, err := listIndexedSources(ctx, []string{namespace}, tag)
+	if err != nil {
+		return nil, err
+	}
+	// constants are indexed with the variables
+	sources = slices.DeleteFunc(sources, func(source indexedSource) bool {
+		return source.symbol.Symbol != "var"
+	})
+	result := &ValueSearchResult{Matches: make([]ValueMatch, 0)}
+	sources, result.Truncated = truncateSources(sources)
+	result.Scanned = len(sources)
+	var mu sync.Mutex
+	err = readIndexedSources(ctx, sources, func(source indexedSource, content string) {
+		var matches []ValueMatch
+		for _, v := range literalValues(content) {
+			if match(v.Value) {
+				v.Namespace = source.namespace
+				matches = append(matches, v)
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		result.Matches = append(result.Matches, matches...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(result.Matches, func(i, j int) bool {
+		a, b := result.Matches[i], result.Matches[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
+
+// literalValues returns the constants and variables of the source code of a var index file whose value is a literal.
+// The source code is a `var` or `const` declaration, or a bare spec of a declaration group.
+func literalValues(source string) []ValueMatch {
+	declaration, err := parseDeclaration(source)
+	if err != nil {
+		return nil
+	}
+	var values []ValueMatch
+	ast.Inspect(declaration.file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, name := range spec.Names {
+			if i >= len(spec.Values) {
+				break
+			}
+			v, ok := literalValue(spec.Values[i])
+			if !ok {
+				continue
+			}
+			match := ValueMatch{Name: name.Name, Value: v, Declaration: declaration.text(spec)}
+			if spec.Type != nil {
+				match.Type = declaration.text(spec.Type)
+			} else if call, ok := spec.Values[i].(*ast.CallExpr); ok {
+				match.Type = declaration.text(call.Fun)
+			}
+			values = append(values, match)
+		}
+		return false
+	})
+	return values
+}
+
+// literalValue returns the value of a basic literal, unquoted for strings, or of a literal passed to a call with one
+// argument like a conversion
+func literalValue(expr ast.Expr) (string, bool) {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if _, ok := functionName(call.Fun); ok {
+			expr = call.Args[0]
+		}
+	}
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok {
+		return "", false
+	}
+	if literal.Kind == token.STRING {
+		v, err := strconv.Unquote(literal.Value)
+		return v, err == nil
+	}
+	return literal.Value, true
+}
@@ -0,0 +1,75 @@
+package gophon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchValues(t *testing.T) {
+	stubIndexFiles(t, map[string]string{
+		"index/internal/services/storage/var.SkuNameStandardLRS.goindex": `const SkuNameStandardLRS SkuName = "Standard_LRS"`,
+		"index/internal/services/storage/var.defaultSku.goindex":         `var defaultSku = SkuName("standard_lrs")`,
+		"index/internal/services/storage/var.skus.goindex": `const (
+	SkuNamePremiumLRS = "Premium_LRS"
+	SkuNameStandardGRS = "Standard_GRS"
+)`,
+		"index/internal/services/storage/var.maxRetries.goindex":   "maxRetries = 3",
+		"index/internal/services/storage/var.computed.goindex":     `var computed = fmt.Sprintf("%s_LRS", "Standard")`,
+		"index/internal/services/storage/func.StandardLRS.goindex": `func StandardLRS() string { return "Standard_LRS" }`,
+		"index/internal/services/storage/sub/var.Nested.goindex":   `const Nested = "Standard_LRS"`,
+	})
+	namespace := AzureRMInternal + "/services/storage"
+	cases := []struct {
+		desc     string
+		value    string
+		regex    bool
+		expected []ValueMatch
+	}{
+		{
+			desc:  "case-insensitive value",
+			value: "Standard_LRS",
+			expected: []ValueMatch{
+				{Namespace: namespace, Name: "SkuNameStandardLRS", Type: "SkuName", Value: "Standard_LRS", Declaration: `SkuNameStandardLRS SkuName = "Standard_LRS"`},
+				{Namespace: namespace, Name: "defaultSku", Type: "SkuName", Value: "standard_lrs", Declaration: `defaultSku = SkuName("standard_lrs")`},
+			},
+		},
+		{
+			desc:  "regex",
+			value: "^Premium|GRS$",
+			regex: true,
+			expected: []ValueMatch{
+				{Namespace: namespace, Name: "SkuNamePremiumLRS", Value: "Premium_LRS", Declaration: `SkuNamePremiumLRS = "Premium_LRS"`},
+				{Namespace: namespace, Name: "SkuNameStandardGRS", Value: "Standard_GRS", Declaration: `SkuNameStandardGRS = "Standard_GRS"`},
+			},
+		},
+		{
+			desc:  "bare spec",
+			value: "3",
+			expected: []ValueMatch{
+				{Namespace: namespace, Name: "maxRetries", Value: "3", Declaration: "maxRetries = 3"},
+			},
+		},
+		{
+			desc:     "no match",
+			value:    "Standard_ZRS",
+			expected: []ValueMatch{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			result, err := SearchValues(context.Background(), namespace, c.value, "", c.regex)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, result.Matches)
+			assert.Equal(t, 5, result.Scanned)
+			assert.False(t, result.Truncated)
+		})
+	}
+
+	_, err := SearchValues(context.Background(), namespace, "", "", false)
+	assert.Error(t, err)
+	_, err = SearchValues(context.Background(), namespace, "(", "", true)
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Environment variables of the server logs
+const (
+	// LevelEnv is the minimum level of the server logs, `debug`, `info` (default), `warn` or `error`
+	LevelEnv = "LOG_LEVEL"
+	// FormatEnv is the format of the server logs, `text` (default) or `json`
+	FormatEnv = "LOG_FORMAT"
+)
+
+// output is where the server logs are written, stdout is reserved for the stdio transport
+var output io.Writer = os.Stderr
+
+// Init installs the default logger writing the server logs to stderr, the standard `log` package writes to it too
+func Init(level, format string) error {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("invalid log level %s: %w", level, err)
+	}
+	options := &slog.HandlerOptions{Level: l}
+	var handler slog.Handler
+	switch format {
+	case "", "text":
+		handler = slog.NewTextHandler(output, options)
+	case "json":
+		handler = slog.NewJSONHandler(output, options)
+	default:
+		return fmt.Errorf("invalid log format %s, must be one of 'text' or 'json'", format)
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+type loggerKey struct{}
+
+// WithLogger returns a context holding logger
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of ctx, which forwards the logs of a tool call to its client, or the default logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// Middleware is an MCP receiving middleware giving every tool call a logger, returned by FromContext, that writes to
+// the server logs and sends `notifications/message` logging notifications to the client at the level it set with
+// `logging/setLevel`. Clients that didn't set a level get no notifications. The start and the end of every tool call
+// are logged.
+func Middleware(next mcp.MethodHandler[*mcp.ServerSession]) mcp.MethodHandler[*mcp.ServerSession] {
+	return func(ctx context.Context, session *mcp.ServerSession, method string, params mcp.Params) (mcp.Result, error) {
+		toolName := telemetry.ToolName(params)
+		if toolName == "" {
+			return next(ctx, session, method, params)
+		}
+		handler := slog.Default().Handler()
+		if session != nil {
+			handler = fanoutHandler{handler, mcp.NewLoggingHandler(session, &mcp.LoggingHandlerOptions{LoggerName: toolName})}
+		}
+		logger := slog.New(handler).With("tool", toolName)
+		ctx = WithLogger(ctx, logger)
+
+		start := time.Now()
+		logger.DebugContext(ctx, "tool call started")
+		result, err := next(ctx, session, method, params)
+		duration := time.Since(start).Round(time.Millisecond)
+		callErr := err
+		if r, ok := result.(*mcp.CallToolResult); ok && r != nil && r.IsError && err == nil {
+			callErr = errors.New(errorText(r))
+		}
+		if callErr != nil {
+			logger.WarnContext(ctx, "tool call failed", "duration", duration, "error", callErr)
+		} else {
+			logger.InfoContext(ctx, "tool call finished", "duration", duration)
+		}
+		return result, err
+	}
+}
+
+func errorText(r *mcp.CallToolResult) string {
+	for _, c := range r.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return "tool call failed"
+}
+
+// fanoutHandler sends every record to each of its handlers enabled for the level of the record
+type fanoutHandler []slog.Handler
+
+func (h fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h {
+		if handler.Enabled(ctx, r.Level) {
+			errs = append(errs, handler.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(fanoutHandler, 0, len(h))
+	for _, handler := range h {
+		handlers = append(handlers, handler.WithAttrs(attrs))
+	}
+	return handlers
+}
+
+func (h fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make(fanoutHandler, 0, len(h))
+	for _, handler := range h {
+		handlers = append(handlers, handler.WithGroup(name))
+	}
+	return handlers
+}
@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit(t *testing.T) {
+	cases := []struct {
+		name       string
+		level      string
+		format     string
+		expected   []string
+		errMessage string
+	}{
+		{
+			name:     "text at info",
+			level:    "info",
+			format:   "text",
+			expected: []string{"level=INFO msg=info key=value", "level=WARN msg=warn key=value"},
+		},
+		{
+			name:     "json at warn",
+			level:    "warn",
+			format:   "json",
+			expected: []string{`"level":"WARN","msg":"warn","key":"value"`},
+		},
+		{
+			name:       "invalid level",
+			level:      "verbose",
+			errMessage: `invalid log level verbose: slog: level string "verbose": unknown name`,
+		},
+		{
+			name:       "invalid format",
+			level:      "info",
+			format:     "xml",
+			errMessage: "invalid log format xml, must be one of 'text' or 'json'",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			stubs := gostub.Stub(&output, &buf)
+			defer stubs.Reset()
+			defaultLogger := slog.Default()
+			defer slog.SetDefault(defaultLogger)
+
+			err := Init(c.level, c.format)
+			if c.errMessage != "" {
+				assert.EqualError(t, err, c.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			slog.Debug("debug", "key", "value")
+			slog.Info("info", "key", "value")
+			slog.Warn("warn", "key", "value")
+			lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+			require.Len(t, lines, len(c.expected))
+			for i, expected := range c.expected {
+				assert.Contains(t, string(lines[i]), expected)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Same(t, slog.Default(), FromContext(context.Background()))
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	assert.Same(t, logger, FromContext(WithLogger(context.Background(), logger)))
+}
+
+func TestMiddleware(t *testing.T) {
+	var serverLogs bytes.Buffer
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+	slog.SetDefault(slog.New(slog.NewTextHandler(&serverLogs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.1.0"}, nil)
+	server.AddReceivingMiddleware(Middleware)
+	mcp.AddTool(server, &mcp.Tool{Name: "scan", Description: "scan"}, func(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]any]) (*mcp.CallToolResultFor[any], error) {
+		FromContext(ctx).DebugContext(ctx, "reading files")
+		FromContext(ctx).InfoContext(ctx, "scanning", "files", 3)
+		if params.Arguments["fail"] == true {
+			return nil, fmt.Errorf("scan failed")
+		}
+		return &mcp.CallToolResultFor[any]{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil
+	})
+
+	var mu sync.Mutex
+	var notifications []*mcp.LoggingMessageParams
+	received := make(chan struct{}, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "0.1.0"}, &mcp.ClientOptions{
+		LoggingMessageHandler: func(_ context.Context, _ *mcp.ClientSession, params *mcp.LoggingMessageParams) {
+			mu.Lock()
+			notifications = append(notifications, params)
+			mu.Unlock()
+			received <- struct{}{}
+		},
+	})
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ctx := context.Background()
+	_, err := server.Connect(ctx, serverTransport)
+	require.NoError(t, err)
+	session, err := client.Connect(ctx, clientTransport)
+	require.NoError(t, err)
+	defer session.Close()
+
+	// clients get no notifications before they set a level
+	_, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "scan", Arguments: map[string]any{}})
+	require.NoError(t, err)
+	assert.Contains(t, serverLogs.String(), "level=DEBUG msg=\"tool call started\" tool=scan")
+	assert.Contains(t, serverLogs.String(), "level=INFO msg=scanning tool=scan files=3")
+	assert.Contains(t, serverLogs.String(), "level=INFO msg=\"tool call finished\" tool=scan")
+
+	require.NoError(t, session.SetLevel(ctx, &mcp.SetLevelParams{Level: "info"}))
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "scan", Arguments: map[string]any{"fail": true}})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	for range 2 {
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatal("logging notification not received")
+		}
+	}
+	assert.Contains(t, serverLogs.String(), "level=WARN msg=\"tool call failed\" tool=scan")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, notifications, 2)
+	assert.Equal(t, "scan", notifications[0].Logger)
+	assert.Equal(t, mcp.LoggingLevel("info"), notifications[0].Level)
+	data, ok := notifications[0].Data.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "scanning", data["msg"])
+	assert.Equal(t, "scan", data["tool"])
+	assert.Equal(t, float64(3), data["files"])
+	assert.Equal(t, mcp.LoggingLevel("warning"), notifications[1].Level)
+}
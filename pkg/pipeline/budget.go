@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// defaultBudget is the total duration budget when none is provided
+// (override via PIPELINE_SCAN_BUDGET_SECONDS)
+const defaultBudget = 10 * time.Minute
+
+// defaultStepDeadlines are the soft deadlines applied to each step unless overridden
+var defaultStepDeadlines = map[string]time.Duration{
+	StepFmt:      30 * time.Second,
+	StepValidate: 3 * time.Minute,
+	StepLint:     3 * time.Minute,
+	StepPolicy:   3 * time.Minute,
+}
+
+// now is a package-level clock to allow test stubbing
+var now = time.Now
+
+// budget tracks the total duration budget of a pipeline run
+type budget struct {
+	total     time.Duration
+	start     time.Time
+	deadlines map[string]time.Duration
+}
+
+func newBudget(budgetSeconds int, stepDeadlines map[string]int) *budget {
+	total := defaultBudget
+	if v := os.Getenv("PIPELINE_SCAN_BUDGET_SECONDS"); v != "" {
+		if secs, parseErr := strconv.Atoi(v); parseErr == nil && secs > 0 {
+			total = time.Duration(secs) * time.Second
+		}
+	}
+	if budgetSeconds > 0 {
+		total = time.Duration(budgetSeconds) * time.Second
+	}
+
+	deadlines := make(map[string]time.Duration, len(defaultStepDeadlines))
+	for step, d := range defaultStepDeadlines {
+		deadlines[step] = d
+	}
+	for step, secs := range stepDeadlines {
+		if secs > 0 {
+			deadlines[step] = time.Duration(secs) * time.Second
+		}
+	}
+
+	return &budget{
+		total:     total,
+		start:     now(),
+		deadlines: deadlines,
+	}
+}
+
+func (b *budget) elapsed() time.Duration {
+	return now().Sub(b.start)
+}
+
+func (b *budget) remaining() time.Duration {
+	return b.total - b.elapsed()
+}
+
+// deadline returns the soft deadline of a step
+func (b *budget) deadline(step string) time.Duration {
+	return b.deadlines[step]
+}
+
+// admit decides whether a step may start, returning a skip reason when it may not.
+// A step is only started when the remaining budget covers its soft deadline.
+func (b *budget) admit(step string) (bool, string) {
+	remaining := b.remaining()
+	if remaining <= 0 {
+		return false, fmt.Sprintf("total budget of %s exhausted", b.total)
+	}
+	if d := b.deadline(step); remaining < d {
+		return false, fmt.Sprintf("remaining budget %s is less than the %s soft deadline of step %s", remaining.Round(time.Second), d, step)
+	}
+	return true, ""
+}
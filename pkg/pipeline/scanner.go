@@ -0,0 +1,282 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/conftest"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tflint"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CommandExecutor interface for executing system commands (following tflint pattern)
+type CommandExecutor interface {
+	ExecuteCommand(ctx context.Context, dir, command string) (stdout, stderr string, err error)
+}
+
+// RealCommandExecutor implements CommandExecutor using exec.CommandContext, the command is killed when ctx is done
+type RealCommandExecutor struct{}
+
+func (r *RealCommandExecutor) ExecuteCommand(ctx context.Context, dir, command string) (stdout, stderr string, err error) {
+	parts := strings.Fields(command)
+	if len(parts) == 0 {
+		return "", "", fmt.Errorf("empty command")
+	}
+
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
+	cmd.Dir = dir
+
+	stdoutBytes, err := cmd.Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			return string(stdoutBytes), string(exitError.Stderr), err
+		}
+	}
+
+	return string(stdoutBytes), "", err
+}
+
+// Global command executor for testing (following tflint pattern)
+var commandExecutor CommandExecutor = &RealCommandExecutor{}
+
+// Scanners used by the lint and policy steps, package-level to allow test stubbing
+var tflintScan = tflint.Scan
+var conftestScan = conftest.Scan
+
+// stepRunner runs a single step and returns its status, output and error
+type stepRunner func(ctx context.Context, param ScanParam, targetPath string) (string, any, error)
+
+var stepOrder = []string{StepFmt, StepValidate, StepLint, StepPolicy}
+
+var stepRunners = map[string]stepRunner{
+	StepFmt:      runFmt,
+	StepValidate: runValidate,
+	StepLint:     runLint,
+	StepPolicy:   runPolicy,
+}
+
+// Scan runs terraform fmt, terraform validate, TFLint and conftest in order within a total duration budget.
+// Before each step starts, the remaining budget is compared with the step's soft deadline; steps that
+// cannot fit are skipped with a reason instead of being started. The total budget is a hard ceiling:
+// a step still running when it runs out is cancelled, while per-step deadlines are only reported.
+func Scan(ctx context.Context, param ScanParam) (*ScanResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "pipeline.scan")
+	result, err := scan(ctx, param)
+	telemetry.EndSpan(span, err)
+	return result, err
+}
+
+func scan(ctx context.Context, param ScanParam) (*ScanResult, error) {
+	for _, step := range param.SkipSteps {
+		if _, ok := stepRunners[step]; !ok {
+			return nil, fmt.Errorf("invalid step name in skip_steps: %s, valid steps are: %s", step, strings.Join(stepOrder, ", "))
+		}
+	}
+	for step := range param.StepDeadlines {
+		if _, ok := stepRunners[step]; !ok {
+			return nil, fmt.Errorf("invalid step name in step_deadlines: %s, valid steps are: %s", step, strings.Join(stepOrder, ", "))
+		}
+	}
+
+	targetPath, err := resolveTargetPath(param.TargetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	b := newBudget(param.BudgetSeconds, param.StepDeadlines)
+	ctx, cancel := context.WithTimeout(ctx, b.total)
+	defer cancel()
+	result := &ScanResult{
+		Success:       true,
+		TargetPath:    targetPath,
+		BudgetSeconds: b.total.Seconds(),
+	}
+
+	logger := logging.FromContext(ctx).With("target", targetPath)
+	for _, step := range stepOrder {
+		stepResult := StepResult{
+			Name:            step,
+			DeadlineSeconds: b.deadline(step).Seconds(),
+		}
+
+		reason := explicitSkipReason(param, step)
+		if reason == "" && ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			reason = "pipeline scan cancelled"
+		}
+		if reason == "" {
+			if ok, budgetReason := b.admit(step); !ok {
+				reason = budgetReason
+			}
+		}
+		if reason != "" {
+			_, span := telemetry.StartSpan(ctx, "pipeline."+step, attribute.String("pipeline.step.status", StatusSkipped), attribute.String("pipeline.step.skip_reason", reason))
+			span.End()
+			logger.InfoContext(ctx, "pipeline step skipped", "step", step, "reason", reason)
+			stepResult.Status = StatusSkipped
+			stepResult.SkipReason = reason
+			result.Steps = append(result.Steps, stepResult)
+			result.Skipped = append(result.Skipped, SkipReport{Step: step, Reason: reason})
+			continue
+		}
+
+		stepCtx, span := telemetry.StartSpan(ctx, "pipeline."+step)
+		logger.InfoContext(ctx, "pipeline step started", "step", step)
+		start := now()
+		status, output, runErr := stepRunners[step](stepCtx, param, targetPath)
+		duration := now().Sub(start)
+		span.SetAttributes(attribute.String("pipeline.step.status", status))
+		telemetry.EndSpan(span, runErr)
+		logger.InfoContext(ctx, "pipeline step finished", "step", step, "status", status, "duration", duration.Round(time.Millisecond))
+
+		stepResult.Status = status
+		stepResult.Output = output
+		stepResult.DurationSeconds = duration.Seconds()
+		stepResult.ExceededSoftDeadline = duration > b.deadline(step)
+		if runErr != nil {
+			stepResult.Error = runErr.Error()
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil && status != StatusPassed {
+			stepResult.Status = StatusError
+			stepResult.Error = budgetExceededError(ctxErr, b, runErr).Error()
+		}
+		if status != StatusPassed {
+			result.Success = false
+		}
+		result.Steps = append(result.Steps, stepResult)
+	}
+
+	result.ElapsedSeconds = b.elapsed().Seconds()
+	return result, nil
+}
+
+// budgetExceededError explains why a step was cancelled once the pipeline context is done
+func budgetExceededError(ctxErr error, b *budget, runErr error) error {
+	reason := "pipeline scan cancelled"
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		reason = fmt.Sprintf("total budget of %s exceeded", b.total)
+	}
+	if runErr != nil {
+		return fmt.Errorf("%s: %w", reason, runErr)
+	}
+	return errors.New(reason)
+}
+
+// explicitSkipReason returns a skip reason that doesn't depend on the budget
+func explicitSkipReason(param ScanParam, step string) string {
+	for _, s := range param.SkipSteps {
+		if s == step {
+			return "skipped by request"
+		}
+	}
+	if step == StepPolicy && param.PlanFile == "" {
+		return "no plan_file provided, policy checks require a Terraform plan in JSON format"
+	}
+	return ""
+}
+
+// executeCommand runs command through the command executor inside a span, so each subprocess shows up in traces
+func executeCommand(ctx context.Context, dir, command string) (stdout, stderr string, err error) {
+	// Name spans after the subcommand, e.g. `terraform validate`, to keep span names low-cardinality
+	name := strings.Join(strings.Fields(command)[:2], " ")
+	_, span := telemetry.StartSpan(ctx, name, telemetry.CommandAttributes(dir, command)...)
+	start := time.Now()
+	stdout, stderr, err = commandExecutor.ExecuteCommand(ctx, dir, command)
+	telemetry.RecordCommand(name, time.Since(start), err)
+	telemetry.EndSpan(span, err)
+	return stdout, stderr, err
+}
+
+func resolveTargetPath(targetPath string) (string, error) {
+	if targetPath == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve target path: %w", err)
+		}
+		return cwd, nil
+	}
+	absPath, err := filepath.Abs(targetPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve target path: %w", err)
+	}
+	return absPath, nil
+}
+
+// runFmt runs `terraform fmt -check` and reports unformatted files
+func runFmt(ctx context.Context, _ ScanParam, targetPath string) (string, any, error) {
+	stdout, stderr, err := executeCommand(ctx, targetPath, "terraform fmt -check -recursive -list=true -no-color")
+	output := &FmtOutput{}
+	for _, line := range strings.Split(stdout, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			output.UnformattedFiles = append(output.UnformattedFiles, line)
+		}
+	}
+	if err != nil {
+		if len(output.UnformattedFiles) > 0 {
+			return StatusFailed, output, nil
+		}
+		return StatusError, nil, fmt.Errorf("terraform fmt failed: %w, stderr: %s", err, stderr)
+	}
+	return StatusPassed, output, nil
+}
+
+// runValidate runs `terraform init -backend=false` followed by `terraform validate -json`
+func runValidate(ctx context.Context, _ ScanParam, targetPath string) (string, any, error) {
+	if _, stderr, err := executeCommand(ctx, targetPath, "terraform init -backend=false -input=false -no-color"); err != nil {
+		return StatusError, nil, fmt.Errorf("terraform init failed: %w, stderr: %s", err, stderr)
+	}
+
+	stdout, stderr, err := executeCommand(ctx, targetPath, "terraform validate -json -no-color")
+	// terraform validate exits with non-zero status on invalid configuration, but still provides valid output
+	var output ValidateOutput
+	if parseErr := json.Unmarshal([]byte(stdout), &output); parseErr != nil {
+		if err != nil {
+			return StatusError, nil, fmt.Errorf("terraform validate failed: %w, stderr: %s", err, stderr)
+		}
+		return StatusError, nil, fmt.Errorf("failed to parse terraform validate output: %w", parseErr)
+	}
+	if !output.Valid {
+		return StatusFailed, &output, nil
+	}
+	return StatusPassed, &output, nil
+}
+
+// runLint runs a TFLint scan against the target path
+func runLint(ctx context.Context, param ScanParam, targetPath string) (string, any, error) {
+	lintParam := param.TFLint
+	lintParam.TargetPath = targetPath
+	result, err := tflintScan(ctx, lintParam)
+	if err != nil {
+		return StatusError, result, err
+	}
+	if result.Summary.TotalIssues > 0 {
+		return StatusFailed, result, nil
+	}
+	return StatusPassed, result, nil
+}
+
+// runPolicy runs a conftest scan against the plan file
+func runPolicy(ctx context.Context, param ScanParam, targetPath string) (string, any, error) {
+	policyParam := param.Conftest
+	policyParam.TargetFile = param.PlanFile
+	if !filepath.IsAbs(policyParam.TargetFile) {
+		policyParam.TargetFile = filepath.Join(targetPath, policyParam.TargetFile)
+	}
+	result, err := conftestScan(ctx, policyParam)
+	if err != nil {
+		return StatusError, nil, err
+	}
+	if result.Summary.TotalViolations > 0 {
+		return StatusFailed, result, nil
+	}
+	return StatusPassed, result, nil
+}
@@ -0,0 +1,227 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/conftest"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tflint"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockCommandExecutor for testing (following tflint pattern)
+type MockCommandExecutor struct {
+	patterns map[string]*MockCommandResult
+	// advance moves the fake clock forward on every executed command
+	advance func()
+}
+
+type MockCommandResult struct {
+	stdout string
+	stderr string
+	err    error
+}
+
+func (m *MockCommandExecutor) ExecuteCommand(_ context.Context, dir, command string) (string, string, error) {
+	if m.advance != nil {
+		m.advance()
+	}
+	for pattern, result := range m.patterns {
+		if strings.Contains(command, pattern) {
+			return result.stdout, result.stderr, result.err
+		}
+	}
+	return "", "", assert.AnError
+}
+
+// fakeClock is a controllable clock for budget tests
+type fakeClock struct {
+	current time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.current
+}
+
+func passingExecutor() *MockCommandExecutor {
+	return &MockCommandExecutor{
+		patterns: map[string]*MockCommandResult{
+			"terraform fmt":      {stdout: ""},
+			"terraform init":     {stdout: "Terraform has been successfully initialized!"},
+			"terraform validate": {stdout: `{"valid":true,"error_count":0,"warning_count":0,"diagnostics":[]}`},
+		},
+	}
+}
+
+func stubScanners(t *testing.T, lintCalled, policyCalled *bool) *gostub.Stubs {
+	return gostub.Stub(&tflintScan, func(_ context.Context, param tflint.ScanParam) (*tflint.ScanResult, error) {
+		*lintCalled = true
+		assert.NotEmpty(t, param.TargetPath)
+		return &tflint.ScanResult{Success: true}, nil
+	}).Stub(&conftestScan, func(_ context.Context, param conftest.ScanParam) (*conftest.ScanResult, error) {
+		*policyCalled = true
+		assert.True(t, strings.HasSuffix(param.TargetFile, "plan.json"))
+		return &conftest.ScanResult{Success: true}, nil
+	})
+}
+
+func TestScan_AllStepsPass(t *testing.T) {
+	var lintCalled, policyCalled bool
+	stubs := stubScanners(t, &lintCalled, &policyCalled)
+	defer stubs.Reset()
+	stubs.Stub(&commandExecutor, passingExecutor())
+
+	result, err := Scan(context.Background(), ScanParam{TargetPath: t.TempDir(), PlanFile: "plan.json"})
+	require.NoError(t, err)
+
+	assert.True(t, result.Success)
+	assert.True(t, lintCalled)
+	assert.True(t, policyCalled)
+	require.Len(t, result.Steps, 4)
+	for i, step := range []string{StepFmt, StepValidate, StepLint, StepPolicy} {
+		assert.Equal(t, step, result.Steps[i].Name)
+		assert.Equal(t, StatusPassed, result.Steps[i].Status)
+	}
+	assert.Empty(t, result.Skipped)
+}
+
+func TestScan_PolicySkippedWithoutPlanFile(t *testing.T) {
+	var lintCalled, policyCalled bool
+	stubs := stubScanners(t, &lintCalled, &policyCalled)
+	defer stubs.Reset()
+	stubs.Stub(&commandExecutor, passingExecutor())
+
+	result, err := Scan(context.Background(), ScanParam{TargetPath: t.TempDir()})
+	require.NoError(t, err)
+
+	assert.False(t, policyCalled)
+	require.Len(t, result.Skipped, 1)
+	assert.Equal(t, StepPolicy, result.Skipped[0].Step)
+	assert.Contains(t, result.Skipped[0].Reason, "no plan_file provided")
+	assert.True(t, result.Success, "skipped steps should not fail the pipeline")
+}
+
+func TestScan_SkipsStepsWhenBudgetExhausted(t *testing.T) {
+	clock := &fakeClock{current: time.Unix(0, 0)}
+	executor := passingExecutor()
+	// Every command takes 50 seconds, fmt (1 command) and validate (2 commands) use 150s in total
+	executor.advance = func() {
+		clock.current = clock.current.Add(50 * time.Second)
+	}
+
+	var lintCalled, policyCalled bool
+	stubs := stubScanners(t, &lintCalled, &policyCalled)
+	defer stubs.Reset()
+	stubs.Stub(&commandExecutor, executor)
+	stubs.Stub(&now, clock.now)
+
+	result, err := Scan(context.Background(), ScanParam{
+		TargetPath:    t.TempDir(),
+		PlanFile:      "plan.json",
+		BudgetSeconds: 200,
+		StepDeadlines: map[string]int{StepFmt: 10, StepValidate: 120, StepLint: 60, StepPolicy: 60},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, lintCalled)
+	assert.False(t, policyCalled)
+	assert.True(t, result.Steps[0].ExceededSoftDeadline, "fmt took 50s against a 10s soft deadline")
+	assert.False(t, result.Steps[1].ExceededSoftDeadline)
+	require.Len(t, result.Skipped, 2)
+	assert.Equal(t, StepLint, result.Skipped[0].Step)
+	assert.Contains(t, result.Skipped[0].Reason, "less than the 1m0s soft deadline")
+	assert.Equal(t, StepPolicy, result.Skipped[1].Step)
+	assert.Equal(t, float64(150), result.ElapsedSeconds)
+}
+
+func TestScan_CancelsStepRunningPastTotalBudget(t *testing.T) {
+	// The fake clock never advances, so the lint step is admitted and only the real budget deadline stops it
+	clock := &fakeClock{current: time.Unix(0, 0)}
+	stubs := gostub.Stub(&now, clock.now)
+	defer stubs.Reset()
+	stubs.Stub(&tflintScan, func(ctx context.Context, _ tflint.ScanParam) (*tflint.ScanResult, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	result, err := Scan(context.Background(), ScanParam{
+		TargetPath:    t.TempDir(),
+		BudgetSeconds: 1,
+		StepDeadlines: map[string]int{StepLint: 1},
+		SkipSteps:     []string{StepFmt, StepValidate},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, result.Success)
+	lint := result.Steps[2]
+	assert.Equal(t, StatusError, lint.Status)
+	assert.Contains(t, lint.Error, "total budget of 1s exceeded")
+}
+
+func TestScan_SkipsRemainingStepsWhenCancelled(t *testing.T) {
+	var lintCalled, policyCalled bool
+	stubs := stubScanners(t, &lintCalled, &policyCalled)
+	defer stubs.Reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	executor := passingExecutor()
+	executor.advance = cancel
+	stubs.Stub(&commandExecutor, executor)
+
+	result, err := Scan(ctx, ScanParam{TargetPath: t.TempDir(), PlanFile: "plan.json"})
+	require.NoError(t, err)
+
+	assert.False(t, lintCalled)
+	assert.False(t, policyCalled)
+	assert.Equal(t, StatusSkipped, result.Steps[1].Status)
+	assert.Equal(t, "pipeline scan cancelled", result.Steps[1].SkipReason)
+}
+
+func TestScan_FailedStepsMarkPipelineUnsuccessful(t *testing.T) {
+	var lintCalled, policyCalled bool
+	stubs := stubScanners(t, &lintCalled, &policyCalled)
+	defer stubs.Reset()
+	stubs.Stub(&commandExecutor, &MockCommandExecutor{
+		patterns: map[string]*MockCommandResult{
+			"terraform fmt":      {stdout: "main.tf\nvariables.tf\n", err: assert.AnError},
+			"terraform init":     {stdout: "ok"},
+			"terraform validate": {stdout: `{"valid":false,"error_count":1,"warning_count":0,"diagnostics":[{"severity":"error","summary":"Unsupported argument"}]}`, err: assert.AnError},
+		},
+	})
+
+	result, err := Scan(context.Background(), ScanParam{TargetPath: t.TempDir(), SkipSteps: []string{StepLint}})
+	require.NoError(t, err)
+
+	assert.False(t, result.Success)
+	assert.Equal(t, StatusFailed, result.Steps[0].Status)
+	assert.Equal(t, []string{"main.tf", "variables.tf"}, result.Steps[0].Output.(*FmtOutput).UnformattedFiles)
+	assert.Equal(t, StatusFailed, result.Steps[1].Status)
+	assert.Equal(t, 1, result.Steps[1].Output.(*ValidateOutput).ErrorCount)
+	assert.Equal(t, StatusSkipped, result.Steps[2].Status)
+	assert.Equal(t, "skipped by request", result.Steps[2].SkipReason)
+	assert.False(t, lintCalled)
+}
+
+func TestScan_InvalidStepName(t *testing.T) {
+	_, err := Scan(context.Background(), ScanParam{SkipSteps: []string{"plan"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid step name in skip_steps: plan")
+
+	_, err = Scan(context.Background(), ScanParam{StepDeadlines: map[string]int{"apply": 10}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid step name in step_deadlines: apply")
+}
+
+func TestNewBudget_EnvOverride(t *testing.T) {
+	t.Setenv("PIPELINE_SCAN_BUDGET_SECONDS", "42")
+	b := newBudget(0, nil)
+	assert.Equal(t, 42*time.Second, b.total)
+
+	b = newBudget(7, map[string]int{StepLint: 5})
+	assert.Equal(t, 7*time.Second, b.total, "explicit budget wins over env")
+	assert.Equal(t, 5*time.Second, b.deadline(StepLint))
+	assert.Equal(t, defaultStepDeadlines[StepFmt], b.deadline(StepFmt))
+}
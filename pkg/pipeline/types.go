@@ -0,0 +1,88 @@
+package pipeline
+
+import (
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/conftest"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tflint"
+)
+
+// Step names in execution order
+const (
+	StepFmt      = "fmt"
+	StepValidate = "validate"
+	StepLint     = "lint"
+	StepPolicy   = "policy"
+)
+
+// Step statuses
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusError   = "error"
+	StatusSkipped = "skipped"
+)
+
+// ScanParam represents the input parameters for a combined fmt/validate/lint/policy scan
+type ScanParam struct {
+	TargetPath    string             `json:"target_path,omitempty"`    // Directory containing Terraform code, defaults to current directory
+	PlanFile      string             `json:"plan_file,omitempty"`      // JSON plan file for the policy step, policy step is skipped when empty
+	BudgetSeconds int                `json:"budget_seconds,omitempty"` // Total duration budget for the whole pipeline
+	StepDeadlines map[string]int     `json:"step_deadlines,omitempty"` // Per-step soft deadlines in seconds, keyed by step name
+	SkipSteps     []string           `json:"skip_steps,omitempty"`     // Steps the caller explicitly wants to skip
+	TFLint        tflint.ScanParam   `json:"tflint"`                   // TFLint parameters, TargetPath is overridden by the pipeline
+	Conftest      conftest.ScanParam `json:"conftest"`                 // Conftest parameters, TargetFile is overridden by the pipeline
+}
+
+// ScanResult represents the result of a pipeline scan
+type ScanResult struct {
+	Success        bool         `json:"success"`
+	TargetPath     string       `json:"target_path"`
+	BudgetSeconds  float64      `json:"budget_seconds"`
+	ElapsedSeconds float64      `json:"elapsed_seconds"`
+	Steps          []StepResult `json:"steps"`
+	Skipped        []SkipReport `json:"skipped,omitempty"`
+}
+
+// StepResult represents the outcome of a single pipeline step
+type StepResult struct {
+	Name                 string  `json:"name"`
+	Status               string  `json:"status"`
+	SkipReason           string  `json:"skip_reason,omitempty"`
+	DeadlineSeconds      float64 `json:"deadline_seconds"`
+	DurationSeconds      float64 `json:"duration_seconds"`
+	ExceededSoftDeadline bool    `json:"exceeded_soft_deadline,omitempty"`
+	Error                string  `json:"error,omitempty"`
+	Output               any     `json:"output,omitempty"`
+}
+
+// SkipReport lists a skipped step along with the reason it was skipped
+type SkipReport struct {
+	Step   string `json:"step"`
+	Reason string `json:"reason"`
+}
+
+// FmtOutput represents the result of `terraform fmt -check`
+type FmtOutput struct {
+	UnformattedFiles []string `json:"unformatted_files,omitempty"`
+}
+
+// ValidateOutput represents the JSON output of `terraform validate -json`
+type ValidateOutput struct {
+	Valid        bool                 `json:"valid"`
+	ErrorCount   int                  `json:"error_count"`
+	WarningCount int                  `json:"warning_count"`
+	Diagnostics  []ValidateDiagnostic `json:"diagnostics,omitempty"`
+}
+
+// ValidateDiagnostic represents a single diagnostic reported by `terraform validate`
+type ValidateDiagnostic struct {
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail,omitempty"`
+	Range    *struct {
+		Filename string `json:"filename"`
+		Start    struct {
+			Line   int `json:"line"`
+			Column int `json:"column"`
+		} `json:"start"`
+	} `json:"range,omitempty"`
+}
@@ -0,0 +1,15 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// argument returns the argument name of the prompt request, or fallback when the client didn't provide it
+func argument(params *mcp.GetPromptParams, name, fallback string) string {
+	if value := strings.TrimSpace(params.Arguments[name]); value != "" {
+		return value
+	}
+	return fallback
+}
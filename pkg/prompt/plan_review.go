@@ -0,0 +1,51 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func AddReviewPlanPrompt(s *mcp.Server) {
+	s.AddPrompt(&mcp.Prompt{
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        "plan_file",
+				Description: "The Terraform plan file in JSON format to review, relative to the current workspace, for example: `tfplan.json`. If not provided, the prompt will generate it from the current workspace.",
+			},
+			{
+				Name:        "policy_library",
+				Description: "The predefined policy library to review the plan against: `aprl`, `avmsec` or `all`. Defaults to `all`.",
+			},
+		},
+		Description: "Use this prompt to review a Terraform plan against the Azure Verified Modules (AVM) policies before applying it or opening a Pull Request. The prompt will return instructions to scan the plan with conftest, explain every violation and propose fixes.",
+		Name:        "review_terraform_plan_avm",
+	}, func(ctx context.Context, session *mcp.ServerSession, params *mcp.GetPromptParams) (*mcp.GetPromptResult, error) {
+		planFile := argument(params, "plan_file", "")
+		policyLibrary := argument(params, "policy_library", "all")
+		planStep := fmt.Sprintf("The plan to review is '%s', make sure it exists and is a JSON plan, not a binary plan file.", planFile)
+		if planFile == "" {
+			planFile = "tfplan.json"
+			planStep = "Generate the plan of the current workspace with `terraform init`, `terraform plan -out=tfplan` and `terraform show -json tfplan > tfplan.json`. If the plan needs variables or credentials you don't have, ask the user for them or for an existing plan file instead of guessing."
+		}
+		return &mcp.GetPromptResult{
+			Messages: []*mcp.PromptMessage{
+				{
+					Content: &mcp.TextContent{
+						Text: fmt.Sprintf(`As an AVM development expert, review a Terraform plan against the AVM policies by strictly following these steps:
+1. %s
+2. Call the 'conftest_scan' tool with 'target_file' set to '%s' and 'predefined_policy_library_alias' set to '%s'. Keep 'include_default_avm_exceptions' to true, so the exceptions every AVM module is granted are honored.
+3. Call the 'check_terraform_plan_conformance' tool with 'plan_file' set to '%s' to find attributes that are deprecated or unknown in the provider versions the plan uses.
+4. Report every violation grouped by policy namespace and rule, with the address of the offending resource, what the policy checks and why it matters. Report warnings separately.
+5. For every violation, propose the smallest change of the Terraform code that satisfies the policy. Consult the 'query_terraform_schema' tool, or the tools with the 'query_azapi_' prefix for 'azapi' resources, to confirm the attributes you propose exist in the provider version in use.
+6. Do not change any code, and never suppress a violation, before the user has agreed with your proposals. When the user decides a violation is an accepted exception, use the 'author_avm_policy_exception' prompt instead of changing the code.
+7. After the agreed changes are made, regenerate the plan and repeat the scan until there is no violation left, or only accepted exceptions.
+Now, please begin execution.`, planStep, planFile, policyLibrary, planFile),
+					},
+					Role: "user",
+				},
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,72 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func AddPolicyExceptionPrompt(s *mcp.Server) {
+	s.AddPrompt(&mcp.Prompt{
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        "namespace",
+				Description: "The namespace of the policy to except, as reported by `conftest_scan`, for example: `Azure_Proactive_Resiliency_Library_v2` or `avmsec`.",
+				Required:    true,
+			},
+			{
+				Name:        "rule",
+				Description: "The name of the rule to except, as reported by `conftest_scan`, for example: `storage_accounts_are_zone_or_region_redundant`.",
+				Required:    true,
+			},
+			{
+				Name:        "justification",
+				Description: "Why the module is exempt from the rule. If not provided, the prompt will ask the user for it.",
+			},
+		},
+		Description: "If a Terraform plan of an Azure Verified Modules (AVM) repository violates a policy that the module can't or shouldn't satisfy, use this prompt to author a conftest policy exception for the rule, documented with its justification, instead of changing the code.",
+		Name:        "author_avm_policy_exception",
+	}, func(ctx context.Context, session *mcp.ServerSession, params *mcp.GetPromptParams) (*mcp.GetPromptResult, error) {
+		namespace := argument(params, "namespace", "")
+		rule := argument(params, "rule", "")
+		if namespace == "" || rule == "" {
+			return nil, fmt.Errorf("namespace and rule are required")
+		}
+		justification := argument(params, "justification", "")
+		justificationStep := fmt.Sprintf("The justification of the exception is: %s", justification)
+		if justification == "" {
+			justification = "<justification>"
+			justificationStep = "Ask the user why the module is exempt from the rule, exceptions without a justification must not be merged."
+		}
+		fileName := fmt.Sprintf("exceptions/%s.rego", strings.ToLower(namespace))
+		return &mcp.GetPromptResult{
+			Messages: []*mcp.PromptMessage{
+				{
+					Content: &mcp.TextContent{
+						Text: fmt.Sprintf(`As an AVM development expert, author a conftest policy exception for the rule '%s' of the policy namespace '%s' by strictly following these steps:
+1. %s
+2. Confirm the violation first: call the 'conftest_scan' tool on the JSON plan of the module and check the rule '%s' of the namespace '%s' is reported. If it isn't, stop and report that no exception is needed.
+3. Make sure the violation can't reasonably be fixed in the code, like a property the module has to expose to its callers. If it can, propose the fix to the user instead.
+4. Add the exception to '%s', create it if it doesn't exist. If the file already has an 'exception' rule for the namespace, add the rule name to its list instead of adding another one. The file must look like:
+
+package %s
+
+import rego.v1
+
+# %s
+exception contains rules if {
+    rules = ["%s"]
+}
+
+5. Check the exception: re-run the 'conftest_scan' tool with 'ignored_policies' set to [{"namespace": "%s", "name": "%s"}], which applies the same exception, and check only the rule '%s' disappears from the violations.
+6. Report the exception file, the justification and the scan result, then propose committing the exception with a commit message quoting the justification.
+Now, please begin execution.`, rule, namespace, justificationStep, rule, namespace, fileName, namespace, justification, rule, namespace, rule, rule),
+					},
+					Role: "user",
+				},
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,99 @@
+package prompt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAVMWorkflowPrompts(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.1.0"}, nil)
+	AddReviewPlanPrompt(server)
+	AddPolicyExceptionPrompt(server)
+	AddUpgradeProviderPrompt(server)
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "0.1.0"}, nil)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := server.Connect(context.Background(), serverTransport)
+	require.NoError(t, err)
+	session, err := client.Connect(context.Background(), clientTransport)
+	require.NoError(t, err)
+	defer session.Close()
+
+	tests := []struct {
+		name        string
+		prompt      string
+		arguments   map[string]string
+		contains    []string
+		notContains []string
+		errMessage  string
+	}{
+		{
+			name:      "review an existing plan",
+			prompt:    "review_terraform_plan_avm",
+			arguments: map[string]string{"plan_file": "plan.json", "policy_library": "avmsec"},
+			contains:  []string{"The plan to review is 'plan.json'", "'target_file' set to 'plan.json' and 'predefined_policy_library_alias' set to 'avmsec'"},
+		},
+		{
+			name:        "review a generated plan against all policies",
+			prompt:      "review_terraform_plan_avm",
+			arguments:   map[string]string{},
+			contains:    []string{"terraform show -json tfplan > tfplan.json", "'target_file' set to 'tfplan.json' and 'predefined_policy_library_alias' set to 'all'"},
+			notContains: []string{"%!"},
+		},
+		{
+			name:      "policy exception with justification",
+			prompt:    "author_avm_policy_exception",
+			arguments: map[string]string{"namespace": "avmsec", "rule": "mi_enabled", "justification": "the module exposes the identity"},
+			contains:  []string{"exceptions/avmsec.rego", "package avmsec", "# the module exposes the identity", `rules = ["mi_enabled"]`, `[{"namespace": "avmsec", "name": "mi_enabled"}]`},
+		},
+		{
+			name:      "policy exception without justification",
+			prompt:    "author_avm_policy_exception",
+			arguments: map[string]string{"namespace": "avmsec", "rule": "mi_enabled"},
+			contains:  []string{"Ask the user why the module is exempt from the rule"},
+		},
+		{
+			name:       "policy exception without rule",
+			prompt:     "author_avm_policy_exception",
+			arguments:  map[string]string{"namespace": "avmsec"},
+			errMessage: "namespace and rule are required",
+		},
+		{
+			name:      "provider upgrade to a version",
+			prompt:    "upgrade_module_provider",
+			arguments: map[string]string{"provider": "hashicorp/azurerm", "target_version": "4.30.0"},
+			contains:  []string{"The target version is 4.30.0.", "'provider' set to 'azurerm'", "'name' set to 'hashicorp/azurerm', 'version' set to the target version", "the target version 4.30.0"},
+		},
+		{
+			name:        "provider upgrade to the latest version",
+			prompt:      "upgrade_module_provider",
+			arguments:   map[string]string{"provider": "Azure/azapi"},
+			contains:    []string{"Resolve the latest version of 'Azure/azapi'", "'provider' set to 'azapi'"},
+			notContains: []string{"%!"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.GetPrompt(context.Background(), &mcp.GetPromptParams{Name: tt.prompt, Arguments: tt.arguments})
+			if tt.errMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Messages, 1)
+			text := result.Messages[0].Content.(*mcp.TextContent).Text
+			assert.NotContains(t, text, "%!")
+			for _, s := range tt.contains {
+				assert.Contains(t, text, s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, text, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func AddUpgradeProviderPrompt(s *mcp.Server) {
+	s.AddPrompt(&mcp.Prompt{
+		Arguments: []*mcp.PromptArgument{
+			{
+				Name:        "provider",
+				Description: "The provider to upgrade, as written in `required_providers`, for example: `hashicorp/azurerm` or `Azure/azapi`.",
+				Required:    true,
+			},
+			{
+				Name:        "target_version",
+				Description: "The provider version to upgrade to, for example: `4.30.0`. Defaults to the latest version.",
+			},
+		},
+		Description: "If you're asked to upgrade a Terraform module, like an Azure Verified Modules (AVM) module, to the latest version of a provider, use this prompt to get instructions on how to find the breaking changes and deprecations between the versions, update the code and validate it.",
+		Name:        "upgrade_module_provider",
+	}, func(ctx context.Context, session *mcp.ServerSession, params *mcp.GetPromptParams) (*mcp.GetPromptResult, error) {
+		provider := argument(params, "provider", "")
+		if provider == "" {
+			return nil, fmt.Errorf("provider is required")
+		}
+		name := provider[strings.LastIndex(provider, "/")+1:]
+		targetVersion := argument(params, "target_version", "")
+		targetStep := fmt.Sprintf("The target version is %s.", targetVersion)
+		if targetVersion == "" {
+			targetVersion = "<target version>"
+			targetStep = fmt.Sprintf("Resolve the latest version of '%s': call the 'list_terraform_provider_items' tool with 'name' set to '%s', 'category' set to 'resource' and 'limit' set to 1, without a version, it reports the provider version it resolved. That is the target version.", provider, provider)
+		}
+		return &mcp.GetPromptResult{
+			Messages: []*mcp.PromptMessage{
+				{
+					Content: &mcp.TextContent{
+						Text: fmt.Sprintf(`As an AVM development expert, upgrade the module in the current workspace to a new version of the '%s' provider by strictly following these steps:
+1. Find the version constraint of '%s' in the 'required_providers' blocks of the module, usually in 'terraform.tf', and in the examples under the 'examples' directory. The lowest version it allows is the current version.
+2. %s
+3. Call the 'query_terraform_provider_changelog' tool with 'provider' set to '%s', 'from_tag' set to the current version and 'to_tag' set to the target version, both prefixed with 'v' like 'v4.30.0'. Use its 'filter' parameter with the resource types the module declares to focus on the changes that affect it. List the breaking changes, the deprecations and the new features relevant to the module.
+4. For every resource and data source type of the provider the module declares, call the 'query_terraform_deprecated_schema' tool with 'name' set to '%s', 'version' set to the target version and 'type' set to the type, and find the deprecated attributes and blocks the module uses.
+5. Create a new file named 'todo.md' in the root directory of the repository, write down the breaking changes and deprecations that affect the module and a detailed plan to address them, then ask the user to review it.
+6. After the user has agreed with your plan, update the version constraint to allow the target version, in the module and in the examples, and make the planned code changes. Consult the 'query_terraform_schema' tool with the target version for the attributes you change. Don't break the interface of the module without the user's approval: keep its variables and outputs, and mark the ones that have to go as deprecated.
+7. Call the 'terraform_pipeline_scan' tool to format, validate and lint the module, and fix the issues it reports.
+[CRITICAL STEP] After all changes are complete, you must execute:
+1. ./avm pre-commit (or './avm.ps1 pre-commit' if you on Windows').
+2. the following sub-checks: ['tfvalidatecheck', 'lint'] with './avm ' or './avm.ps1
+If checks succeeds too then you should:
+
+1. commit the changes with a commit message naming the provider and the target version %s, do not commit 'todo.md' file.
+2. propose creating a Pull Request (PR) listing the breaking changes you addressed. If it fails, report the failure message, try to solve the issues with best effort.
+Now, please begin execution.`, provider, provider, targetStep, name, provider, targetVersion),
+					},
+					Role: "user",
+				},
+			},
+		}, nil
+	})
+}
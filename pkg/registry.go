@@ -1,14 +1,23 @@
 package pkg
 
 import (
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
 	"github.com/lonegunmanb/terraform-mcp-eva/pkg/prompt"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/resource"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
 	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tool"
 	"github.com/modelcontextprotocol/go-sdk/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-func RegisterMcpServer(s *mcp.Server) {
-	mcp.AddTool(s, &mcp.Tool{
+// RegisterMcpServer adds the tools allowed by filter and the prompts to the server, a nil filter allows every tool. It
+// returns an error when a tool of the filter doesn't exist.
+func RegisterMcpServer(s *mcp.Server, filter *ToolFilter) error {
+	s.AddReceivingMiddleware(telemetry.TracingMiddleware, telemetry.MetricsMiddleware, logging.Middleware)
+	r := &toolRegistry{server: s, filter: filter}
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
 			IdempotentHint:  true,
@@ -22,7 +31,7 @@ func RegisterMcpServer(s *mcp.Server) {
 		Name:        "golang_source_code_server_get_supported_golang_namespaces",
 	}, tool.QuerySupportedGolangNamespaces)
 
-	mcp.AddTool(s, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
 			IdempotentHint:  true,
@@ -36,14 +45,35 @@ func RegisterMcpServer(s *mcp.Server) {
 					Type:        "string",
 					Description: "The golang namespace to get tags for (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal')",
 				},
+				"exclude_prerelease": {
+					Type:        "boolean",
+					Description: "Exclude pre-release tags like 'v5.0.0-beta1', defaults to false",
+				},
+				"prefix": {
+					Type:        "string",
+					Description: "Only return tags starting with the prefix, e.g.: 'v4.'",
+				},
+				"order": {
+					Type:        "string",
+					Description: "Order of the tags, 'asc' for oldest first or 'desc' for latest first, defaults to 'asc'",
+					Enum:        []interface{}{"asc", "desc"},
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of tags in a page, the first ones in 'order', returns all tags when not set. Set 'order' to 'desc' and a small limit to get the latest tags.",
+				},
 			},
 			Required: []string{"namespace"},
 		},
-		Description: "Get all supported tags/versions for a specific golang namespace. Requires a 'namespace' parameter (string) and returns a JSON array of version tags like ['v4.20.0', 'v4.21.0']. Use this tool when you need to: 1) Discover available versions/tags for a specific golang namespace, 2) Find the latest or specific versions before analyzing code from a particular tag, 3) Understand version history for indexed golang projects.",
+		Description: "Get all supported tags/versions for a specific golang namespace. Requires a 'namespace' parameter (string) and returns a JSON object with the page of version 'items' in ascending semantic version order like ['v4.9.0', 'v4.10.0'], the 'total' number of matching tags and a 'next_cursor' when more tags are available. Indexes have hundreds of tags, use 'prefix', 'order' and 'limit' to keep the response small. Use this tool when you need to: 1) Discover available versions/tags for a specific golang namespace, 2) Find the latest or specific versions before analyzing code from a particular tag, 3) Understand version history for indexed golang projects.",
 		Name:        "golang_source_code_server_get_supported_tags",
 	}, tool.QuerySupportedTags)
 
-	mcp.AddTool(s, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
 			IdempotentHint:  true,
@@ -56,7 +86,7 @@ func RegisterMcpServer(s *mcp.Server) {
 		Description: "Get all supported Terraform provider names available for source code query. Returns a JSON array of provider name strings like ['azurerm']. Use this tool when you need to: 1) Discover what Terraform providers have been indexed and are available for golang source query, you can study details of provider's behavior, 2) Find available providers before querying specific golang functions, methods, types, variables.",
 		Name:        "terraform_source_code_query_get_supported_providers",
 	}, tool.QuerySupportedProviders)
-	mcp.AddTool(s, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
 			IdempotentHint:  true,
@@ -66,29 +96,74 @@ func RegisterMcpServer(s *mcp.Server) {
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
+				"provider": {
+					Type:        "string",
+					Description: "The provider to list the indexed terraform types of (e.g. 'azurerm')",
+				},
 				"block_type": {
 					Type:        "string",
-					Description: "The terraform block type (e.g. 'resource', 'data', 'ephemeral')",
+					Description: "Optional terraform block type to list, lists all block types when not set",
+					Enum:        []interface{}{"resource", "data", "ephemeral", "function"},
+				},
+				"filter": {
+					Type:        "string",
+					Description: "Optional case-insensitive substring the listed types must contain (e.g. 'subnet')",
+				},
+				"tag": {
+					Type:        "string",
+					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of types to return. If not set, all matching types are returned.",
+				},
+			},
+			Required: []string{"provider"},
+		},
+		Description: "List the terraform types whose source code is indexed for a provider, returns a JSON object with the page of 'items', each with its `block_type` and `name` like {'block_type': 'resource', 'name': 'azurerm_subnet'} sorted by block type then name, the 'total' number of matching types and a 'next_cursor' when more types are available. Use this tool to check that a terraform type exists in the index, or in a given provider version, before calling `query_terraform_block_implementation_source_code`, set `filter` to keep the response small.",
+		Name:        "terraform_source_code_query_list_types",
+	}, tool.WithIndexRefs(tool.QueryTerraformTypes))
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"block_type": {
+					Type:        "string",
+					Description: "The terraform block type (e.g. 'resource', 'data', 'ephemeral', 'function', 'provider')",
 				},
 				"terraform_type": {
 					Type:        "string",
-					Description: "The terraform type (e.g. 'azurerm_resource_group')",
+					Description: "The terraform type (e.g. 'azurerm_resource_group'), or the provider function for 'function' (e.g. 'provider::azurerm::parse_resource_id'), or the provider name for 'provider' (e.g. 'azurerm')",
 				},
 				"entrypoint_name": {
 					Type:        "string",
-					Description: "The function or method name you want to read the source code (for 'resource': 'create', 'read', 'update', 'delete', 'schema', 'attribute'; for 'data': 'read', 'schema', 'attribute'; for 'ephemeral': 'open', 'close', 'renew', 'schema')",
+					Description: "The function or method name you want to read the source code (for 'resource': 'create', 'read', 'update', 'delete', 'schema', 'attribute', 'identity', 'state_upgrade', 'customize_diff'; for 'data': 'read', 'schema', 'attribute'; for 'ephemeral': 'open', 'close', 'renew', 'schema'; for 'function': 'definition', 'run'; for 'provider': 'configure', the provider configuration and API client setup)",
 				},
 				"tag": {
 					Type:        "string",
 					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
 				},
+				"provider": {
+					Type:        "string",
+					Description: "Optional provider to read the source code of, defaults to the prefix of terraform_type. Set 'google-beta' to read a google_* block from the google-beta provider. Required for provider functions not called like 'provider::<provider>::<function>'.",
+				},
 			},
 			Required: []string{"block_type", "terraform_type", "entrypoint_name"},
 		},
-		Description: "Read Terraform provider source code for a given Terraform block, if you see `source code not found (404)` in error, it implies that maybe the function or method is not implemented in the provider. Use this tool when you need to: 1) Read the source code of a specific Terraform function or method, 2) How a Terraform Provider calls API, 3) Debug issues related to specific Terraform resource.",
+		Description: "Read Terraform provider source code for a given Terraform block, if you see `source code not found (404)` in error, it implies that maybe the function or method is not implemented in the provider. The source code is followed by a JSON object with the `implementation` style of the block: `plugin-framework`, `typed-sdk` (azurerm typed resource on plugin SDKv2), `sdkv2` or `unknown`, and a `description` of how to read it. Use this tool when you need to: 1) Read the source code of a specific Terraform function or method, 2) How a Terraform Provider calls API, 3) Debug issues related to specific Terraform resource, 4) Read the state upgraders (`state_upgrade`), `CustomizeDiff` (`customize_diff`) or resource identity (`identity`) of a resource explaining a surprising plan, they're only indexed for resources implementing them, 5) Read how a provider configures authentication, endpoints and feature flags with block_type 'provider', terraform_type the provider name like 'azurerm' and entrypoint_name 'configure'.",
 		Name:        "query_terraform_block_implementation_source_code",
-	}, tool.QueryTerraformSourceCode)
-	mcp.AddTool(s, &mcp.Tool{
+	}, tool.WithIndexRefs(tool.QueryTerraformSourceCode))
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
 			IdempotentHint:  true,
@@ -98,35 +173,72 @@ func RegisterMcpServer(s *mcp.Server) {
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
-				"namespace": {
+				"block_type": {
 					Type:        "string",
-					Description: "[Required] The golang namespace to query (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal'). When you are reading golang source code and want to read a specific function, method, type or variable, you need to infer the correct namespace first. To infer the namespace of a given symbol, you must read 'package' declaration in the current golang code, along with all imports, then guess the symbol you'd like to read is in which namespace. The symbol could be placed in a different namespace, it's quite common.",
+					Description: "The terraform block type (e.g. 'resource', 'data', 'ephemeral', 'function')",
+					Enum:        []interface{}{"resource", "data", "ephemeral", "function"},
 				},
-				"symbol": {
+				"terraform_type": {
 					Type:        "string",
-					Description: "[Required] The symbol you want to read, possible values: 'func', 'method', 'type', 'var'",
-					Enum:        []interface{}{"func", "method", "type", "var"},
+					Description: "The terraform type (e.g. 'azurerm_resource_group'), or the provider function for 'function' (e.g. 'provider::azurerm::parse_resource_id')",
 				},
-				"receiver": {
+				"entrypoints": {
+					Type:        "array",
+					Description: "Optional entrypoints to analyze, defaults to 'create', 'read', 'update', 'delete' for 'resource', 'read' for 'data', 'open', 'renew', 'close' for 'ephemeral' and 'run' for 'function'",
+					Items:       &jsonschema.Schema{Type: "string"},
+				},
+				"tag": {
 					Type:        "string",
-					Description: "The type of method receiver, e.g.: 'ContainerAppResource'. Can only be set when symbol is 'method'.",
+					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
 				},
-				"name": {
+				"provider": {
+					Type:        "string",
+					Description: "Optional provider to read the source code of, defaults to the prefix of terraform_type. Set 'google-beta' to read a google_* block from the google-beta provider. Required for provider functions not called like 'provider::<provider>::<function>'.",
+				},
+			},
+			Required: []string{"block_type", "terraform_type"},
+		},
+		Description: "List the SDK client methods, like go-azure-sdk or AWS SDK operations, called by the create, read, update and delete entrypoints of a Terraform block, answering which REST API operations a resource makes. Returns a JSON object with the `operations` of every entrypoint: the `client` expression, the `method` and its `line` in the entrypoint source code, and the `helpers`, functions called with a client whose source code may hold more operations, read them with `query_golang_source_code`. Clients are recognized by name, so the result can miss operations of clients not named like `*Client` or `*Conn`.",
+		Name:        "query_terraform_block_api_operations",
+	}, tool.WithIndexRefs(tool.QueryTerraformAPIOperations))
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"block_type": {
 					Type:        "string",
-					Description: "[Required] The name of the function, method, type or variable you want to read. For example: 'NewContainerAppResource', 'ContainerAppResource'",
+					Description: "The terraform block type (e.g. 'resource', 'data', 'ephemeral')",
+					Enum:        []interface{}{"resource", "data", "ephemeral"},
+				},
+				"terraform_type": {
+					Type:        "string",
+					Description: "The terraform type (e.g. 'azurerm_subnet')",
+				},
+				"attribute": {
+					Type:        "string",
+					Description: "The attribute to read the validation of, nested attributes are separated by dots (e.g. 'name' or 'delegation.name')",
 				},
 				"tag": {
 					Type:        "string",
 					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
 				},
+				"provider": {
+					Type:        "string",
+					Description: "Optional provider to read the source code of, defaults to the prefix of terraform_type. Set 'google-beta' to read a google_* block from the google-beta provider.",
+				},
 			},
-			Required: []string{"namespace", "symbol", "name"},
+			Required: []string{"block_type", "terraform_type", "attribute"},
 		},
-		Description: "Read golang source code for given type, variable, constant, function or method definition, if you see `source code not found (404)` in error, it implies that maybe the function or method is not implemented in the provider, or it could be a variable with function type. `symbol` set to `var` for variable or constant, `type` for type definition including struct, interface or type alias, `func` for function without receiver, `method` for method that has receiver. If you want to know how a Terraform resource is implemented, you should call `query_terraform_block_implementation_source_code` before you call this tool. Use this tool when you need to: 1) You want to see other function, method, type, variable's definition while you're reading golang source code, 2) How a Terraform Provider expand or flatten struct, 3) Debug issues related to specific Terraform resource.",
-		Name:        "query_golang_source_code",
-	}, tool.QueryGolangSourceCode)
-
-	mcp.AddTool(s, &mcp.Tool{
+		Description: "Read the validation of a Terraform block attribute from the provider source code: the attribute `definition`, its `validation` expressions (`ValidateFunc`, `ValidateDiagFunc` or plugin framework `Validators`) and the source code of the `validators` they reference. Use this tool when you need to know exactly what values a provider accepts for an attribute, e.g.: the allowed characters of a name. Validators of SDKs outside the index have no `source`, their `validation` expression usually shows the accepted values.",
+		Name:        "query_terraform_attribute_validators",
+	}, tool.WithIndexRefs(tool.QueryTerraformAttributeValidators))
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
 			IdempotentHint:  true,
@@ -136,25 +248,33 @@ func RegisterMcpServer(s *mcp.Server) {
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
-				"resource_type": {
+				"provider": {
 					Type:        "string",
-					Description: "Azure resource type, for example: Microsoft.Compute/virtualMachines, combined with api_version to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01",
+					Description: "The provider to read the changelog of (e.g. 'azurerm')",
 				},
-				"api_version": {
+				"tag": {
 					Type:        "string",
-					Description: "Azure resource api-version, for example: 2024-11-01, combined with resource_type to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01",
+					Description: "The provider version to read the changelog of, e.g.: v4.30.0. Either 'tag' or 'from_tag' must be set.",
 				},
-				"path": {
+				"from_tag": {
 					Type:        "string",
-					Description: "JSON path to query the resource schema, for example: body.properties.osProfile.secrets.sourceVault.id, if not specified, the whole resource schema will be returned",
+					Description: "Read the changelog of the versions after this one, e.g.: v4.20.0",
+				},
+				"to_tag": {
+					Type:        "string",
+					Description: "The last version to read the changelog of with 'from_tag', e.g.: v4.30.0 (defaults to latest version if not specified)",
+				},
+				"filter": {
+					Type:        "string",
+					Description: "Only return the changelog lines containing the filter, case-insensitive, e.g.: 'azurerm_storage_account'",
 				},
 			},
-			Required: []string{"resource_type", "api_version"},
+			Required: []string{"provider"},
 		},
-		Description: "[You should use this tool before you try resolveProviderDocID]Query fine grained AzAPI resource schema by `resource type`, `api_version` and optional `path`. The returned type is a Go type string, which can be used in Go code to represent the resource schema. If you're querying AzAPI provider resource schema, this tool should have higher priority",
-		Name:        "query_azapi_resource_schema",
-	}, tool.QueryAzAPIResourceSchema)
-	mcp.AddTool(s, &mcp.Tool{
+		Description: "Read the CHANGELOG.md entries of a Terraform provider version, or of the versions after `from_tag` up to `to_tag`, from the upstream provider repository. Set `filter` to a terraform type to only keep its changes. Use this tool when you need to answer what changed in a provider version, e.g.: what changed in azurerm v4.30.0 for azurerm_storage_account, before reading source code diffs.",
+		Name:        "query_terraform_provider_changelog",
+	}, tool.WithIndexRefs(tool.QueryTerraformProviderChangelog))
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
 			IdempotentHint:  true,
@@ -164,17 +284,30 @@ func RegisterMcpServer(s *mcp.Server) {
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
-				"resource_type": {
+				"block_type": {
 					Type:        "string",
-					Description: "Azure resource type, for example: Microsoft.Compute/virtualMachines",
+					Description: "The terraform block type (e.g. 'resource', 'data')",
+					Enum:        []interface{}{"resource", "data"},
+				},
+				"terraform_type": {
+					Type:        "string",
+					Description: "The terraform type (e.g. 'azurerm_subnet')",
+				},
+				"tag": {
+					Type:        "string",
+					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
+				},
+				"provider": {
+					Type:        "string",
+					Description: "Optional provider to read the source code of, defaults to the prefix of terraform_type. Set 'google-beta' to read a google_* block from the google-beta provider.",
 				},
 			},
-			Required: []string{"resource_type"},
+			Required: []string{"block_type", "terraform_type"},
 		},
-		Description: "[You should use this tool before you try resolveProviderDocID]Query Azure API versions by `resource type`. The returned value is a list of API versions for the specified resource type, split by comma.",
-		Name:        "list_azapi_api_versions",
-	}, tool.QueryAzAPIVersions)
-	mcp.AddTool(s, &mcp.Tool{
+		Description: "Read the default create, read, update and delete timeouts of a Terraform resource or data source from the provider source code, since schemas only list the configurable timeouts without their defaults. Returns a JSON object with the `timeouts` of every operation: the `default` duration, like `30m0s`, and the source `expression`; `default` is empty when the expression isn't a constant. Blocks implemented with the plugin framework set their timeouts at runtime and aren't supported.",
+		Name:        "query_terraform_block_timeouts",
+	}, tool.WithIndexRefs(tool.QueryTerraformBlockTimeouts))
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
 			IdempotentHint:  true,
@@ -184,25 +317,34 @@ func RegisterMcpServer(s *mcp.Server) {
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
-				"resource_type": {
+				"block_type": {
 					Type:        "string",
-					Description: "Azure resource type, for example: Microsoft.Compute/virtualMachines, combined with api_version to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01",
+					Description: "The terraform block type (e.g. 'resource', 'data', 'ephemeral')",
+					Enum:        []interface{}{"resource", "data", "ephemeral"},
 				},
-				"api_version": {
+				"terraform_type": {
 					Type:        "string",
-					Description: "Azure resource api-version, for example: 2024-11-01, combined with resource_type to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01",
+					Description: "The terraform type (e.g. 'azurerm_subnet')",
 				},
-				"path": {
+				"test": {
+					Type:        "string",
+					Description: "Optional test function to read instead of the whole file, e.g.: 'TestAccSubnet_basic'. Returns the test with the functions and methods of the file it calls, like the configurations of its steps.",
+				},
+				"tag": {
 					Type:        "string",
-					Description: "JSON path to query the resource schema, for example: body.properties.osProfile.secrets.sourceVault.id, if not specified, the whole resource schema will be returned",
+					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
+				},
+				"provider": {
+					Type:        "string",
+					Description: "Optional provider to read the source code of, defaults to the prefix of terraform_type. Set 'google-beta' to read a google_* block from the google-beta provider.",
 				},
 			},
-			Required: []string{"resource_type", "api_version"},
+			Required: []string{"block_type", "terraform_type"},
 		},
-		Description: "[You should use this tool before you try resolveProviderDocID]Query fine grained AzAPI resource description by `resource type`, `api_version` and optional `path`. The returned value is either description of the property, or json object representing the object, the key is property name the value is the description of the property. Via description you can learn whether a property is id, readonly or writeonly, and possible values. If you're querying AzAPI provider resource description, this tool should have higher priority",
-		Name:        "query_azapi_resource_document",
-	}, tool.QueryAzAPIDescriptionSchema)
-	mcp.AddTool(s, &mcp.Tool{
+		Description: "Read the acceptance tests of a Terraform block from the upstream provider repository, the `_test.go` file next to the Go file declaring the block. Acceptance tests hold working HCL configurations of the block, use this tool when you need examples of valid configurations beyond the documentation. Returns a JSON object with the file `path`, a `permalink`, the `tests` declared in the file and the `source`; files are long, so list the `tests` first and read the one you need with `test`.",
+		Name:        "query_terraform_block_acceptance_tests",
+	}, tool.WithIndexRefs(tool.QueryTerraformAcceptanceTests))
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
 			IdempotentHint:  true,
@@ -212,39 +354,77 @@ func RegisterMcpServer(s *mcp.Server) {
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
-				"category": {
+				"namespace": {
 					Type:        "string",
-					Description: "Terraform block type, possible values: resource, data, ephemeral, function, provider",
-					Enum:        []interface{}{"resource", "data", "ephemeral", "function", "provider"},
+					Description: "[Required] The golang namespace to query (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal'). When you are reading golang source code and want to read a specific function, method, type or variable, you need to infer the correct namespace first. To infer the namespace of a given symbol, you must read 'package' declaration in the current golang code, along with all imports, then guess the symbol you'd like to read is in which namespace. The symbol could be placed in a different namespace, it's quite common. Optional when every item of 'symbols' has its own namespace.",
 				},
-				"type": {
+				"symbol": {
 					Type:        "string",
-					Description: "Terraform block type like: azurerm_resource_group or function name like: can. Not required for provider category.",
+					Description: "[Required] The symbol you want to read, possible values: 'func', 'method', 'type', 'var'. Not used when 'symbols' is set.",
+					Enum:        []interface{}{"func", "method", "type", "var"},
 				},
-				"path": {
+				"receiver": {
 					Type:        "string",
-					Description: "JSON path to query the resource schema, for example: default_node_pool.upgrade_settings, if not specified, the whole resource schema will be returned. Note: path queries are not supported for function schemas",
+					Description: "The type of method receiver, e.g.: 'ContainerAppResource'. Can only be set when symbol is 'method'.",
 				},
-				"version": {
+				"name": {
 					Type:        "string",
-					Description: "Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used.",
+					Description: "[Required] The name of the function, method, type or variable you want to read. For example: 'NewContainerAppResource', 'ContainerAppResource'. Not used when 'symbols' is set.",
 				},
-				"namespace": {
+				"tag": {
 					Type:        "string",
-					Description: "Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'.",
+					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
 				},
-				"name": {
+				"symbols": {
+					Type:        "array",
+					Description: "Optional list of symbols to read in one call, at most 50, instead of 'symbol', 'receiver' and 'name'. Returns a JSON object of the source code keyed by '<namespace>/<symbol>.[<receiver>.]<name>[@<tag>]', a symbol that can't be read has an 'error' instead of a 'source'.",
+					Items: &jsonschema.Schema{
+						Type: "object",
+						Properties: map[string]*jsonschema.Schema{
+							"namespace": {
+								Type:        "string",
+								Description: "The golang namespace of the symbol, defaults to the 'namespace' of the query",
+							},
+							"symbol": {
+								Type:        "string",
+								Description: "[Required] The symbol you want to read, possible values: 'func', 'method', 'type', 'var'",
+								Enum:        []interface{}{"func", "method", "type", "var"},
+							},
+							"receiver": {
+								Type:        "string",
+								Description: "The type of method receiver, e.g.: 'ContainerAppResource'. Can only be set when symbol is 'method'.",
+							},
+							"name": {
+								Type:        "string",
+								Description: "[Required] The name of the function, method, type or variable you want to read",
+							},
+							"tag": {
+								Type:        "string",
+								Description: "Optional tag version, defaults to the 'tag' of the query",
+							},
+						},
+						Required: []string{"symbol", "name"},
+					},
+				},
+				"include_location": {
+					Type:        "boolean",
+					Description: "Optional, returns a JSON object with the source code and its 'location': the file 'path' in the upstream repository, the 'start_line', 'end_line' and a GitHub 'permalink' pinned to the tag. Use it when you need to cite where the code is.",
+				},
+				"mode": {
 					Type:        "string",
-					Description: "Provider name (e.g., 'aws', 'azurerm', 'azapi'). Required for provider category. For other categories, if not provided, will be inferred from the type parameter (except for functions).",
+					Description: "Optional, 'full' (default) returns the whole source code, 'signature' returns the declaration only: the doc comment and signature of functions and methods, the fields of types, and variables with function bodies and literal elements elided as '{ ... }'. Read signatures first to save tokens, then read the bodies you need in 'full' mode.",
+					Enum:        []interface{}{"full", "signature"},
+				},
+				"include_methods": {
+					Type:        "boolean",
+					Description: "Optional, when 'symbol' is 'type' returns a JSON object with the type 'source' and the 'name' and 'source' of all its indexed 'methods'. Use it instead of reading the methods of a struct one by one. Methods beyond 64KB of source code are listed in 'omitted_methods'.",
 				},
 			},
-			Required: []string{"category"},
 		},
-		Description: "[You should use this tool before you try resolveProviderDocID]Query fine grained Terraform schema by `category`, `name` and optional `path`. For provider category, returns the complete provider schema including configuration options. For other categories (resource, data, ephemeral, function), returns specific resource/data source/function schema. The returned value is a json string representing the schema, including attribute descriptions, which can be used in Terraform provider schema. If you're querying schema information about providers or specified attribute or nested block schema of a resource from any provider, this tool should have higher priority. Supports all providers available in the Terraform Registry through dynamic schema loading.",
-		Name:        "query_terraform_schema",
-	}, tool.QuerySchema)
-
-	mcp.AddTool(s, &mcp.Tool{
+		Description: "Read golang source code for given type, variable, constant, function or method definition, if you see `source code not found (404)` in error, it implies that maybe the function or method is not implemented in the provider, or it could be a variable with function type. `symbol` set to `var` for variable or constant, `type` for type definition including struct, interface or type alias, `func` for function without receiver, `method` for method that has receiver. If you want to know how a Terraform resource is implemented, you should call `query_terraform_block_implementation_source_code` before you call this tool. Use this tool when you need to: 1) You want to see other function, method, type, variable's definition while you're reading golang source code, 2) How a Terraform Provider expand or flatten struct, 3) Debug issues related to specific Terraform resource. Set `symbols` to read several symbols at once, like a chain of helper functions, instead of calling this tool repeatedly.",
+		Name:        "query_golang_source_code",
+	}, tool.WithIndexRefs(tool.QueryGolangSourceCode))
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
 			IdempotentHint:  true,
@@ -254,134 +434,1400 @@ func RegisterMcpServer(s *mcp.Server) {
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
-				"category": {
+				"namespace": {
 					Type:        "string",
-					Description: "Terraform item type to list, possible values: resource, data, ephemeral, function",
-					Enum:        []interface{}{"resource", "data", "ephemeral", "function"},
+					Description: "[Required] The golang namespace to search in (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps')",
 				},
-				"namespace": {
+				"pattern": {
 					Type:        "string",
-					Description: "Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'.",
+					Description: "Case-insensitive substring of the symbol name, or a regular expression when regex is true. Methods also match 'Receiver.Name', e.g.: 'ContainerAppResource.Create'. Lists all symbols when empty.",
 				},
-				"name": {
+				"symbol": {
 					Type:        "string",
-					Description: "Provider name (e.g., 'aws', 'azurerm', 'azapi'). Required parameter.",
+					Description: "Optional symbol kind to filter by, possible values: 'func', 'method', 'type', 'var'",
+					Enum:        []interface{}{"func", "method", "type", "var"},
 				},
-				"version": {
+				"regex": {
+					Type:        "boolean",
+					Description: "Treat pattern as a regular expression, defaults to false",
+				},
+				"tag": {
 					Type:        "string",
-					Description: "Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used.",
+					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of symbols to return. If not set, all matching symbols are returned.",
 				},
 			},
-			Required: []string{"category", "name"},
+			Required: []string{"namespace"},
 		},
-		Description: "List all available items (resources, data sources, ephemeral resources, or functions) for a specific Terraform provider. This tool enables discovery of all capabilities provided by any Terraform provider in the registry. Use this tool when you need to: 1) Discover what resources/data sources/functions are available in a provider, 2) Find all resources that match a specific pattern or keyword, 3) Understand the full scope of a provider's capabilities, 4) Validate if a specific resource type exists before querying its schema. Supports all providers available in the Terraform Registry through dynamic loading.",
-		Name:        "list_terraform_provider_items",
-	}, tool.ListProviderItems)
-
-	mcp.AddTool(s, &mcp.Tool{
+		Description: "Search the indexed functions, methods, types and variables of a golang namespace by name, returns a JSON object with the page of symbol 'items' with `symbol`, `receiver` and `name` that can be passed to `query_golang_source_code`, the 'total' number of matching symbols and a 'next_cursor' when more symbols are available. Use this tool when you don't know the exact name of the symbol you want to read, e.g.: which methods `ContainerAppResource` has, or which functions expand or flatten a property.",
+		Name:        "search_golang_symbols",
+	}, tool.WithIndexRefs(tool.SearchGolangSymbols))
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
-			IdempotentHint:  false,
+			IdempotentHint:  true,
 			OpenWorldHint:   p(false),
 			ReadOnlyHint:    true,
 		},
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
-				"category": {
+				"namespace": {
 					Type:        "string",
-					Description: "Predefined AVM TFLint configuration category. Supported: 'reusable' (default) or 'example'. Mutually exclusive with 'remote_config_url' (cannot set both). If neither category nor remote_config_url provided, defaults to 'reusable'. Ignored when remote_config_url is set.",
+					Description: "[Required] The golang namespace to list packages of (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal/services')",
 				},
-				"remote_config_url": {
-					Type:        "string",
-					Description: "Optional remote TFLint configuration URL (go-getter syntax, e.g. git::https://...//path/to/file.tflint.hcl?ref=tag). Mutually exclusive with 'category'. Must point to a single file; fetched as remote.tflint.hcl. If neither is provided, defaults to category 'reusable'.",
+				"include_files": {
+					Type:        "boolean",
+					Description: "Also list the index files of the namespace, one file per func, method, type or var, defaults to false",
 				},
-				"target_directory": {
+				"tag": {
 					Type:        "string",
-					Description: "IMPORTANT: Set to '.' for a scan on current workspace! Target directory to scan. Only specify this parameter in rare cases when you need to scan a different directory than the current working directory. In most cases you're running this tool in a container, so you must use a path that can be accessed from the container. When left empty/unset, uses current working directory automatically. Can be absolute or relative path.",
+					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
 				},
-				"custom_config_file": {
+				"cursor": {
 					Type:        "string",
-					Description: "Path to custom TFLint configuration file. If specified, this will be used instead of the category-based configuration.",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
 				},
-				"ignored_rule_ids": {
-					Type: "array",
-					Items: &jsonschema.Schema{
-						Type: "string",
-					},
-					Description: "List of TFLint rule IDs to ignore during scanning. These rules will be disabled in the configuration.",
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of packages and files to return. If not set, all of them are returned.",
 				},
 			},
+			Required: []string{"namespace"},
 		},
-		Description: "Execute TFLint scanning on Terraform code with configurable parameters. This tool allows AI agents to perform static analysis of Terraform code using TFLint. It supports different configuration categories ('reusable' for production modules, 'example' for example code), custom configuration files, and selective rule ignoring. Returns detailed scan results including issues found, their severity levels, and scan summary statistics. Use this tool when you need to: 1) Validate Terraform code quality and best practices, 2) Identify potential issues in Terraform configurations, 3) Perform automated code review of Terraform modules, 4) Check compliance with Terraform coding standards.",
-		Name:        "tflint_scan",
-	}, tool.TFLintScan)
-
-	mcp.AddTool(s, &mcp.Tool{
+		Description: "List the sub-packages directly under an indexed golang namespace, and optionally its index files after them. Returns a JSON object with the `namespace`, the page of package namespaces and file names 'items', the 'total' number of entries and a 'next_cursor' when more entries are available. Use this tool to navigate an unfamiliar provider source tree one level at a time instead of inferring package paths from imports, e.g.: list `github.com/hashicorp/terraform-provider-azurerm/internal/services` to find the package of a service, then call `search_golang_symbols` on it.",
+		Name:        "list_golang_packages",
+	}, tool.WithIndexRefs(tool.ListGolangPackages))
+	addTool(r, &mcp.Tool{
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: p(false),
-			IdempotentHint:  false,
+			IdempotentHint:  true,
 			OpenWorldHint:   p(false),
 			ReadOnlyHint:    true,
 		},
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
-				"predefined_policy_library_alias": {
+				"namespace": {
 					Type:        "string",
-					Description: "Predefined policy library alias. Supported: 'aprl' (Azure Proactive Resiliency Library), 'avmsec' (AVM Security policies), or 'all' (both libraries, default). Mutually exclusive with 'policy_urls'.",
-					Enum:        []interface{}{"aprl", "avmsec", "all"},
+					Description: "[Required] The golang namespace the symbol is declared in (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal/services/network')",
 				},
-				"policy_urls": {
-					Type: "array",
-					Items: &jsonschema.Schema{
-						Type: "string",
-					},
-					Description: "Array of policy URLs in go-getter format (git::https://..., https://..., file://...). Mutually exclusive with 'predefined_policy_library_alias'. Supports git repositories, HTTP/HTTPS URLs, local files, and archives.",
+				"symbol": {
+					Type:        "string",
+					Description: "[Required] The symbol to find references to, possible values: 'func', 'method', 'type', 'var'",
+					Enum:        []interface{}{"func", "method", "type", "var"},
 				},
-				"target_file": {
+				"receiver": {
 					Type:        "string",
-					Description: "Required path to target file (Terraform plan file in JSON format or state file). IMPORTANT: Use relative paths in most cases, relative to the current Terraform workspace (e.g., './plan.json' for root workspace, './examples/default/plan.json' for AVM module examples). For plan files, generate using: 'terraform plan -out=plan.tfplan && terraform show -json plan.tfplan > plan.json'. For state files, generate using: 'terraform show -json > tf.json'.",
+					Description: "The type of method receiver, e.g.: 'ContainerAppResource'. Can only be set when symbol is 'method'.",
 				},
-				"ignored_policies": {
-					Type: "array",
-					Items: &jsonschema.Schema{
-						Type: "object",
-						Properties: map[string]*jsonschema.Schema{
-							"namespace": {
-								Type:        "string",
-								Description: "Required policy namespace (e.g., 'avmsec', 'aprl', 'custom').",
-							},
-							"name": {
-								Type:        "string",
-								Description: "Required policy rule name (e.g., 'storage_account_https_only').",
-							},
-						},
-						Required: []string{"namespace", "name"},
-					},
-					Description: "Array of policies to ignore. Each must specify both 'namespace' and 'name' for precise identification.",
+				"name": {
+					Type:        "string",
+					Description: "[Required] The name of the function, method, type or variable to find references to, e.g.: 'flattenSubnets'",
 				},
-				"namespaces": {
-					Type: "array",
-					Items: &jsonschema.Schema{
-						Type: "string",
-					},
-					Description: "Specific policy namespaces to test. If not specified, all namespaces will be tested.",
+				"search_namespaces": {
+					Type:        "array",
+					Items:       &jsonschema.Schema{Type: "string"},
+					Description: "Other namespaces to search for references in, the symbol's own namespace is always searched",
+				},
+				"tag": {
+					Type:        "string",
+					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of references to return. If not set, all references are returned.",
+				},
+			},
+			Required: []string{"namespace", "symbol", "name"},
+		},
+		Description: "Find the functions, methods, types and variables whose source code references a golang symbol, returns a JSON object with the page of referencing symbol 'items' with the matching lines, the 'total' number of references and a 'next_cursor' when more references are available. References are matched by name in the symbol's namespace and by `package.Name` in the other searched namespaces, methods are matched by `.Name` on any receiver, so the result is a list of candidates to read with `query_golang_source_code`. Every symbol of the searched namespaces is read, keep `search_namespaces` small. Use this tool when you need to trace how a function, like a flatten or expand function, is used before changing expectations about its behavior.",
+		Name:        "query_golang_references",
+	}, tool.WithIndexRefs(tool.QueryGolangReferences))
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"namespace": {
+					Type:        "string",
+					Description: "[Required] The golang namespace to query (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps')",
+				},
+				"symbol": {
+					Type:        "string",
+					Description: "[Required] The symbol you want to compare, possible values: 'func', 'method', 'type', 'var'",
+					Enum:        []interface{}{"func", "method", "type", "var"},
+				},
+				"receiver": {
+					Type:        "string",
+					Description: "The type of method receiver, e.g.: 'ContainerAppResource'. Can only be set when symbol is 'method'.",
+				},
+				"name": {
+					Type:        "string",
+					Description: "[Required] The name of the function, method, type or variable you want to compare",
+				},
+				"from_tag": {
+					Type:        "string",
+					Description: "[Required] The tag to compare from, e.g.: v4.20.0",
+				},
+				"to_tag": {
+					Type:        "string",
+					Description: "The tag to compare to, e.g.: v4.30.0 (defaults to latest version if not specified)",
+				},
+			},
+			Required: []string{"namespace", "symbol", "name", "from_tag"},
+		},
+		Description: "Compare a golang function, method, type or variable between two tags, returns its `status` (`unchanged`, `modified`, `added` or `removed`) and the unified `diff` from `from_tag` to `to_tag`. Use this tool when you need to answer what changed in a symbol between two versions, e.g.: what changed in `ContainerAppResource.Create` between v4.20.0 and v4.30.0, instead of reading it twice with `query_golang_source_code` and comparing manually.",
+		Name:        "diff_golang_source_code",
+	}, tool.WithIndexRefs(tool.DiffGolangSourceCode))
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"namespace": {
+					Type:        "string",
+					Description: "[Required] The golang namespace to search (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal/services/network'), its sub-packages are not searched",
+				},
+				"pattern": {
+					Type:        "string",
+					Description: "[Required] The Go regular expression to match against every line of source code, e.g.: 'ExpandFeatures\\('",
+				},
+				"context_lines": {
+					Type:        "integer",
+					Description: "Number of lines before and after every match to return, at most 10 (defaults to 0)",
+				},
+				"tag": {
+					Type:        "string",
+					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of matches to return. Defaults to 50.",
+				},
+			},
+			Required: []string{"namespace", "pattern"},
+		},
+		Description: "Search the source code of every function, method, type and variable indexed in a golang namespace with a regular expression, returns a JSON object with the page of matching line 'items' with their symbol, line number and context lines, the 'total' number of matches and a 'next_cursor' when more matches are available. Use this tool when you don't know which symbol holds some code, e.g.: which function validates a name with a given regex or sets a given property, then read the whole symbol with `query_golang_source_code`. `truncated` is true when the namespace has too many symbols and some weren't searched.",
+		Name:        "search_golang_source",
+	}, tool.WithIndexRefs(tool.SearchGolangSource))
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"namespace": {
+					Type:        "string",
+					Description: "[Required] The golang namespace to search (e.g. 'github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts'), its sub-packages are not searched",
+				},
+				"value": {
+					Type:        "string",
+					Description: "[Required] The value of the constants or variables to find, matched case-insensitively against the whole literal value, e.g.: 'Standard_LRS'",
+				},
+				"regex": {
+					Type:        "boolean",
+					Description: "Treat value as a Go regular expression matched against literal values (defaults to false)",
+				},
+				"tag": {
+					Type:        "string",
+					Description: "Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)",
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of matches to return. If not set, all matches are returned.",
+				},
+			},
+			Required: []string{"namespace", "value"},
+		},
+		Description: "Find the constants and variables indexed in a golang namespace whose value is a given literal, returns a JSON object with the page of 'items' with their `name`, declared `type`, `value` and `declaration`, the 'total' number of matches and a 'next_cursor' when more matches are available. Use this tool when you know a value but not the symbol defining it, e.g.: which enum constant is \"Standard_LRS\" or where a default value is defined, then search its references with `query_golang_references`. Only literal values, possibly converted to a type, are matched.",
+		Name:        "search_golang_values",
+	}, tool.WithIndexRefs(tool.SearchGolangValues))
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"service": {
+					Type:        "string",
+					Description: "Optional go-azure-sdk resource manager service (e.g. 'storage'), lists the services when not set",
+				},
+				"api_version": {
+					Type:        "string",
+					Description: "Optional API version of the service to list the packages of (e.g. '2023-05-01'), defaults to the latest stable API version",
+				},
+				"filter": {
+					Type:        "string",
+					Description: "Optional case-insensitive substring the listed services, or packages when 'service' is set, must contain (e.g. 'storageaccounts')",
+				},
+				"tag": {
+					Type:        "string",
+					Description: "Optional go-azure-sdk index tag version (defaults to latest version if not specified)",
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of services, or packages when 'service' is set, to return. If not set, all of them are returned.",
+				},
+			},
+		},
+		Description: "Navigate the resource manager services of go-azure-sdk, the SDK of the azurerm provider. Without `service`, returns a JSON object with the page of service name 'items' like ['storage', 'network']; with `service`, returns its `api_versions`, the `api_version` listed (the latest stable one by default), its `namespace` and the page of the package namespaces of its resources as 'items'. Both include the 'total' number of items and a 'next_cursor' when more items are available. Use this tool to resolve the namespace of an SDK package imported by a provider resource, like `github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-05-01/storageaccounts`, then read its code with `query_golang_source_code` or `search_golang_symbols`.",
+		Name:        "list_go_azure_sdk_services",
+	}, tool.WithIndexRefs(tool.QueryAzureSDK))
+
+	// the names of the AzAPI tools are still recorded when AzAPI is disabled, so a filter naming them stays valid
+	r.skip = azapi.Disabled()
+	registerAzAPITools(r)
+	r.skip = false
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"category": {
+					Type:        "string",
+					Description: "Terraform block type, possible values: resource, data, ephemeral, function, provider",
+					Enum:        []interface{}{"resource", "data", "ephemeral", "function", "provider"},
+				},
+				"type": {
+					Type:        "string",
+					Description: "Terraform block type like: azurerm_resource_group or function name like: can. Not required for provider category.",
+				},
+				"path": {
+					Type:        "string",
+					Description: "JSON path to query the resource schema, for example: default_node_pool.upgrade_settings, if not specified, the whole resource schema will be returned. Wildcard segments are supported: '*' matches any single attribute or block, '**' matches any depth of nested blocks, e.g. '*.tags' or '**.identity'; wildcard queries return a map from the full path of every match to its schema. Nested blocks are returned with their nesting_mode, min_items, max_items and a readable cardinality, e.g. 'list of blocks, at most 1, optional'. For function schemas, use 'parameters', 'parameters[<index>]' or 'parameters.<name>' (e.g. 'parameters[1]' or 'parameters.resource_type' of provider::azapi::build_resource_id) for a single parameter with its position, type and description, 'variadic_parameter' or 'return_type'.",
+				},
+				"version": {
+					Type:        "string",
+					Description: "Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used.",
+				},
+				"namespace": {
+					Type:        "string",
+					Description: "Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'.",
+				},
+				"name": {
+					Type:        "string",
+					Description: "Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). Required for provider category. For other categories, if not provided, will be inferred from the type parameter (except for functions).",
+				},
+				"view": {
+					Type:        "string",
+					Description: "Schema view: 'full' (default) returns the complete schema, 'required_only' keeps only required attributes and required nested blocks, 'names_and_types' returns every configurable attribute as 'type, required|optional', 'descriptions' returns a nested map of attribute and block descriptions without types or flags, prefer it for \"what does this attribute mean\" questions. 'required_only' and 'names_and_types' drop descriptions and computed-only attributes, prefer them for large resources such as azurerm_kubernetes_cluster. Both compact views keep the timeouts block, 'names_and_types' lists configurable timeout operations under 'timeouts'. Not supported for function schemas.",
+					Enum:        []interface{}{"full", "required_only", "names_and_types", "descriptions"},
+				},
+				"format": {
+					Type:        "string",
+					Description: "Output format: 'json' (default), 'markdown' or 'json_schema'. Markdown renders attribute tables (name, type, required, description) for the schema or path, ready to paste into PR descriptions and docs. 'json_schema' renders a JSON Schema draft 2020-12 document of the schema or path, computed-only attributes are marked readOnly and write-only attributes writeOnly, for external validators and form generators. Not supported with the 'names_and_types' and 'descriptions' views or for function schemas.",
+					Enum:        []interface{}{"json", "markdown", "json_schema"},
+				},
+				"queries": {
+					Type:        "array",
+					Description: "Bulk queries against one provider, use it to fetch schemas of several related resources in a single call. When set, top level category, type and path are ignored, and the result is a json object keyed by 'category:type' or 'category:type:path', each value holds either 'schema' or 'error'. The provider is inferred from the types unless 'name' is set, all queries must target the same provider.",
+					Items: &jsonschema.Schema{
+						Type: "object",
+						Properties: map[string]*jsonschema.Schema{
+							"category": {
+								Type:        "string",
+								Description: "Terraform block type, possible values: resource, data, ephemeral, function, provider",
+								Enum:        []interface{}{"resource", "data", "ephemeral", "function", "provider"},
+							},
+							"type": {
+								Type:        "string",
+								Description: "Terraform block type like: azurerm_resource_group or function name. Not required for provider category.",
+							},
+							"path": {
+								Type:        "string",
+								Description: "Optional JSON path to query, same as the top level path parameter",
+							},
+						},
+						Required: []string{"category"},
+					},
+				},
+			},
+		},
+		Description: "[You should use this tool before you try resolveProviderDocID]Query fine grained Terraform schema by `category`, `name` and optional `path`, or several schemas of one provider at once via `queries`. For provider category, returns the complete provider schema including configuration options. For other categories (resource, data, ephemeral, function), returns specific resource/data source/function schema. The returned value is a json string representing the schema, including attribute descriptions, which can be used in Terraform provider schema. If you're querying schema information about providers or specified attribute or nested block schema of a resource from any provider, this tool should have higher priority. Supports all providers available in the Terraform Registry through dynamic schema loading.",
+		Name:        "query_terraform_schema",
+	}, tool.QuerySchema)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"category": {
+					Type:        "string",
+					Description: "Terraform block type, possible values: resource, data, ephemeral, provider",
+					Enum:        []interface{}{"resource", "data", "ephemeral", "provider"},
+				},
+				"type": {
+					Type:        "string",
+					Description: "Terraform block type like: azurerm_resource_group. Not required for provider category.",
+				},
+				"version": {
+					Type:        "string",
+					Description: "Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used.",
+				},
+				"namespace": {
+					Type:        "string",
+					Description: "Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'.",
+				},
+				"name": {
+					Type:        "string",
+					Description: "Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). Required for provider category. For other categories, if not provided, will be inferred from the type parameter.",
+				},
+			},
+			Required: []string{"category"},
+		},
+		Description: "Generate a ready-to-edit HCL skeleton for a Terraform resource, data source, ephemeral resource or provider block from its schema. Required attributes are filled with placeholders (`\"REPLACE_ME\"` for strings, zero values for other types), required nested blocks are stubbed, optional attributes and optional nested blocks are commented out, and computed-only or deprecated attributes are omitted. Use this tool to accelerate authoring a new block, then use `query_terraform_schema` to learn about specific attributes.",
+		Name:        "generate_terraform_block_skeleton",
+	}, tool.GenerateSchemaSkeleton)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"category": {
+					Type:        "string",
+					Description: "Terraform block type to walk, possible values: resource, data, ephemeral, provider. If not set, the entire provider (configuration, resources, data sources and ephemeral resources) is walked.",
+					Enum:        []interface{}{"resource", "data", "ephemeral", "provider"},
+				},
+				"type": {
+					Type:        "string",
+					Description: "Terraform block type like: azurerm_kubernetes_cluster. If not set, every schema of the category is walked. Requires category.",
+				},
+				"version": {
+					Type:        "string",
+					Description: "Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used.",
+				},
+				"namespace": {
+					Type:        "string",
+					Description: "Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'.",
+				},
+				"name": {
+					Type:        "string",
+					Description: "Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). If not provided, will be inferred from the type parameter.",
+				},
+			},
+		},
+		Description: "Report only the deprecated attributes and nested blocks of a Terraform resource, data source, ephemeral resource, provider configuration, or an entire provider, with their paths and descriptions (providers document deprecations and replacements in descriptions). Use this tool to target provider upgrade work without reading full schemas.",
+		Name:        "query_terraform_deprecated_schema",
+	}, tool.QueryDeprecatedReport)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"category": {
+					Type:        "string",
+					Description: "Terraform block type to walk, possible values: resource, data, ephemeral, provider. If not set, the entire provider (configuration, resources, data sources and ephemeral resources) is walked.",
+					Enum:        []interface{}{"resource", "data", "ephemeral", "provider"},
+				},
+				"type": {
+					Type:        "string",
+					Description: "Terraform block type like: azurerm_key_vault_secret. If not set, every schema of the category is walked. Requires category.",
+				},
+				"filter": {
+					Type:        "string",
+					Description: "Only report attributes flagged 'sensitive' or 'write_only'. If not set, attributes flagged either are reported.",
+					Enum:        []interface{}{"sensitive", "write_only"},
+				},
+				"version": {
+					Type:        "string",
+					Description: "Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used.",
+				},
+				"namespace": {
+					Type:        "string",
+					Description: "Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'.",
+				},
+				"name": {
+					Type:        "string",
+					Description: "Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). If not provided, will be inferred from the type parameter.",
+				},
+			},
+		},
+		Description: "Report only the attributes flagged sensitive or write-only of a Terraform resource, data source, ephemeral resource, provider configuration, or an entire provider, with their paths. Write-only attributes accept ephemeral values and are never persisted to state. Use this tool for secret-handling reviews and to plan adopting ephemeral values without reading full schemas.",
+		Name:        "query_terraform_sensitive_schema",
+	}, tool.QuerySensitiveReport)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"namespace": {
+					Type:        "string",
+					Description: "Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'.",
+				},
+				"name": {
+					Type:        "string",
+					Description: "Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). Required parameter.",
+				},
+				"version": {
+					Type:        "string",
+					Description: "Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used.",
+				},
+			},
+			Required: []string{"name"},
+		},
+		Description: "For a provider version, list the resources exposing write-only attributes, with the companion '<attribute>_version' attribute that triggers updates when present, and the ephemeral resources that can feed them, with their outputs. Write-only attributes accept ephemeral values and are never persisted to plan or state. Use this tool to plan migrating secrets handling to ephemeral values.",
+		Name:        "query_terraform_ephemeral_compatibility",
+	}, tool.QueryEphemeralReport)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(true),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"source": {
+					Type:        "string",
+					Description: "Registry module source address as written in a module block (e.g., 'Azure/avm-res-keyvault-vault/azurerm', 'registry.terraform.io/Azure/avm-res-keyvault-vault/azurerm'). Required parameter.",
+				},
+				"version": {
+					Type:        "string",
+					Description: "Module version (e.g., '0.10.0'). If not specified, the latest version will be used.",
+				},
+			},
+			Required: []string{"source"},
+		},
+		Description: "Query the public Terraform Registry for a module (namespace/name/provider). Returns JSON with the module version, description, repository, input variables (required first, with types, descriptions and defaults), outputs, provider dependencies, submodules with the source address to use and their inputs and outputs, examples, and all available versions newest first. Use this tool when you need to write a module block for a registry module, e.g. an Azure Verified Module, without reading its source.",
+		Name:        "query_terraform_module_metadata",
+	}, tool.QueryModuleMetadata)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(true),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"source": {
+					Type:        "string",
+					Description: "Registry module source address as written in a module block (e.g., 'Azure/avm-res-keyvault-vault/azurerm'). Required parameter.",
+				},
+				"version_constraint": {
+					Type:        "string",
+					Description: "Version constraint as written in the module block (e.g., '~> 0.10', '>= 1.0, < 2.0'). If not specified, the latest release is selected.",
+				},
+			},
+			Required: []string{"source"},
+		},
+		Description: "Resolve a registry module version constraint the way 'terraform init' does. Returns JSON with the selected version (the newest version matching the constraint, pre-releases only when the constraint names one; empty when nothing matches), the latest release, and the available versions newer than the selected one, newest first. Use this tool to tell which module version a configuration uses and to give upgrade advice.",
+		Name:        "resolve_terraform_module_version",
+	}, tool.ResolveModuleVersion)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(true),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"source": {
+					Type:        "string",
+					Description: "Registry module source address (e.g., 'Azure/avm-res-keyvault-vault/azurerm'). Mutually exclusive with 'module_directory'.",
+				},
+				"version": {
+					Type:        "string",
+					Description: "Registry module version (e.g., '0.10.0'). If not specified, the latest version will be used. Only used with 'source'.",
+				},
+				"module_directory": {
+					Type:        "string",
+					Description: "Local module directory whose variable blocks are read. Mutually exclusive with 'source'.",
+				},
+			},
+		},
+		Description: "Generate a terraform.tfvars stub for the input variables of a registry module or a local module directory. Required variables are set to placeholders matching their type, optional variables are commented out with their defaults, and descriptions and types are written as comments. Exactly one of 'source' or 'module_directory' is required. Use this tool to start filling in the inputs of a module without reading its variables.",
+		Name:        "generate_terraform_tfvars_stub",
+	}, tool.GenerateTfvarsStub)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(true),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"query": {
+					Type:        "string",
+					Description: "Keywords matched against module names, display names, resource types and descriptions (e.g., 'key vault'). All keywords must match.",
+				},
+				"resource_type": {
+					Type:        "string",
+					Description: "ARM resource type or resource provider namespace prefix (e.g., 'Microsoft.KeyVault/vaults', 'Microsoft.KeyVault').",
+				},
+				"category": {
+					Type:        "string",
+					Description: "Module category. If not set, all categories are searched.",
+					Enum:        []interface{}{"resource", "pattern", "utility"},
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of modules to return. Defaults to 10.",
+				},
+			},
+		},
+		Description: "Search the Azure Verified Modules (AVM) Terraform module indexes by keyword or ARM resource type. Returns JSON with the page of module 'items', the 'total' number of matches and a 'next_cursor' when more modules are available. Per module, it has its name, category, resource type, status (e.g. 'Available', 'Proposed', 'Orphaned'), registry source address, repository and latest published version. At least one of 'query' or 'resource_type' is required. Use this tool to find the AVM module for a resource, e.g. Key Vault, then query its inputs with 'query_terraform_module_metadata'.",
+		Name:        "search_azure_verified_modules",
+	}, tool.SearchAVMModules)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"plan_file": {
+					Type:        "string",
+					Description: "Required path to Terraform plan file in JSON format. Use relative paths in most cases, relative to the current Terraform workspace (e.g., './plan.json'). Generate using: 'terraform plan -out=plan.tfplan && terraform show -json plan.tfplan > plan.json'.",
+				},
+				"provider_versions": {
+					Type: "object",
+					AdditionalProperties: &jsonschema.Schema{
+						Type: "string",
+					},
+					Description: "Provider versions or version constraints to check against keyed by provider source (e.g., {'hashicorp/azurerm': '5.0.0'}). Providers without an entry are checked against the version constraints declared in the plan configuration, or the latest version. Set a newer version to find what a provider upgrade would break.",
+				},
+			},
+			Required: []string{"plan_file"},
+		},
+		Description: "Cross-reference every resource change of a Terraform plan with its provider schema and report, per resource address, the attributes and blocks written in the configuration that are unknown to the schema, deprecated, or write-only. Resources that cannot be checked are listed as skipped with a reason. Use this tool when you need to: 1) Check a configuration against a newer provider version before upgrading, 2) Find deprecated arguments actually used by a deployment, 3) Review where write-only arguments are set.",
+		Name:        "check_terraform_plan_conformance",
+	}, tool.CheckPlanConformance)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"category": {
+					Type:        "string",
+					Description: "Terraform item type to list, possible values: resource, data, ephemeral, function",
+					Enum:        []interface{}{"resource", "data", "ephemeral", "function"},
+				},
+				"namespace": {
+					Type:        "string",
+					Description: "Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'.",
+				},
+				"name": {
+					Type:        "string",
+					Description: "Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). Required parameter.",
+				},
+				"version": {
+					Type:        "string",
+					Description: "Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used.",
+				},
+				"prefix": {
+					Type:        "string",
+					Description: "Only return items starting with this prefix (e.g., 'aws_lambda_')",
+				},
+				"contains": {
+					Type:        "string",
+					Description: "Only return items containing this substring (e.g., 'bucket')",
+				},
+				"regex": {
+					Type:        "string",
+					Description: "Only return items matching this regular expression (e.g., '_(vpc|subnet)$'). All filters are combined with AND.",
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of items to return. Defaults to 0, which returns all matching items. Large providers such as aws have more than 1400 resources, use filters or a limit to keep the response small.",
+				},
+			},
+			Required: []string{"category", "name"},
+		},
+		Description: "List all available items (resources, data sources, ephemeral resources, or functions) for a specific Terraform provider. This tool enables discovery of all capabilities provided by any Terraform provider in the registry. Use this tool when you need to: 1) Discover what resources/data sources/functions are available in a provider, 2) Find all resources that match a specific pattern or keyword, 3) Understand the full scope of a provider's capabilities, 4) Validate if a specific resource type exists before querying its schema. Supports prefix, substring and regex filters plus cursor/limit pagination. The response is a JSON object with the provider, the resolved version, the category, the page of 'items', the 'total' number of matching items, 'next_cursor' when more items are available, and 'counts' of unfiltered items per category. Supports all providers available in the Terraform Registry through dynamic loading.",
+		Name:        "list_terraform_provider_items",
+	}, tool.ListProviderItems)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  false,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"category": {
+					Type:        "string",
+					Description: "Predefined AVM TFLint configuration category. Supported: 'reusable' (default) or 'example'. Mutually exclusive with 'remote_config_url' (cannot set both). If neither category nor remote_config_url provided, defaults to 'reusable'. Ignored when remote_config_url is set.",
+				},
+				"remote_config_url": {
+					Type:        "string",
+					Description: "Optional remote TFLint configuration URL (go-getter syntax, e.g. git::https://...//path/to/file.tflint.hcl?ref=tag). Mutually exclusive with 'category'. Must point to a single file; fetched as remote.tflint.hcl. If neither is provided, defaults to category 'reusable'.",
+				},
+				"target_directory": {
+					Type:        "string",
+					Description: "IMPORTANT: Set to '.' for a scan on current workspace! Target directory to scan. Only specify this parameter in rare cases when you need to scan a different directory than the current working directory. In most cases you're running this tool in a container, so you must use a path that can be accessed from the container. When left empty/unset, uses current working directory automatically. Can be absolute or relative path.",
+				},
+				"custom_config_file": {
+					Type:        "string",
+					Description: "Path to custom TFLint configuration file. If specified, this will be used instead of the category-based configuration.",
+				},
+				"ignored_rule_ids": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+					Description: "List of TFLint rule IDs to ignore during scanning. These rules will be disabled in the configuration.",
+				},
+			},
+		},
+		Description: "Execute TFLint scanning on Terraform code with configurable parameters. This tool allows AI agents to perform static analysis of Terraform code using TFLint. It supports different configuration categories ('reusable' for production modules, 'example' for example code), custom configuration files, and selective rule ignoring. Returns detailed scan results including issues found, their severity levels, scan summary statistics, and the effective rule configuration (which rules ended up enabled or disabled and why, based on the category or remote config and ignored rules; custom configuration files are not reflected). Use this tool when you need to: 1) Validate Terraform code quality and best practices, 2) Identify potential issues in Terraform configurations, 3) Perform automated code review of Terraform modules, 4) Check compliance with Terraform coding standards.",
+		Name:        "tflint_scan",
+	}, tool.TFLintScan)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  false,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"predefined_policy_library_alias": {
+					Type:        "string",
+					Description: "Predefined policy library alias. Supported: 'aprl' (Azure Proactive Resiliency Library), 'avmsec' (AVM Security policies), or 'all' (both libraries, default). Mutually exclusive with 'policy_urls'.",
+					Enum:        []interface{}{"aprl", "avmsec", "all"},
+				},
+				"policy_urls": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+					Description: "Array of policy URLs in go-getter format (git::https://..., https://..., file://...). Mutually exclusive with 'predefined_policy_library_alias'. Supports git repositories, HTTP/HTTPS URLs, local files, and archives.",
+				},
+				"target_file": {
+					Type:        "string",
+					Description: "Required path to target file (Terraform plan file in JSON format or state file). IMPORTANT: Use relative paths in most cases, relative to the current Terraform workspace (e.g., './plan.json' for root workspace, './examples/default/plan.json' for AVM module examples). For plan files, generate using: 'terraform plan -out=plan.tfplan && terraform show -json plan.tfplan > plan.json'. For state files, generate using: 'terraform show -json > tf.json'.",
+				},
+				"ignored_policies": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "object",
+						Properties: map[string]*jsonschema.Schema{
+							"namespace": {
+								Type:        "string",
+								Description: "Required policy namespace (e.g., 'avmsec', 'aprl', 'custom').",
+							},
+							"name": {
+								Type:        "string",
+								Description: "Required policy rule name (e.g., 'storage_account_https_only').",
+							},
+						},
+						Required: []string{"namespace", "name"},
+					},
+					Description: "Array of policies to ignore. Each must specify both 'namespace' and 'name' for precise identification.",
+				},
+				"namespaces": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+					Description: "Specific policy namespaces to test. If not specified, all namespaces will be tested.",
+				},
+				"include_default_avm_exceptions": {
+					Type:        "boolean",
+					Description: "Whether to include default Azure Verified Modules (AVM) exceptions. Defaults to true. Downloads standard AVM policy exceptions when true.",
+				},
+			},
+			Required: []string{"target_file"},
+		},
+		Description: "Execute Open Policy Agent (OPA) conftest scanning on Terraform plans with policy-as-code. This tool allows AI agents to perform policy testing on Terraform plan files using predefined Azure policy libraries or custom policies. Supports Azure Proactive Resiliency Library (APRL), AVM Security policies, custom policy repositories, and selective policy ignoring. Returns detailed policy violations, warnings, and scan statistics. Use this tool when you need to: 1) Validate Terraform plans against organizational policies, 2) Check compliance with Azure security and resiliency standards, 3) Enforce governance rules on infrastructure deployments, 4) Perform automated policy compliance testing.",
+		Name:        "conftest_scan",
+	}, tool.ConftestScan)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  false,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"target_directory": {
+					Type:        "string",
+					Description: "Target directory containing Terraform code. When left empty/unset, uses current working directory automatically. Can be absolute or relative path.",
+				},
+				"plan_file": {
+					Type:        "string",
+					Description: "Path to Terraform plan file in JSON format used by the policy step, relative to target_directory (e.g., './plan.json'). The policy step is skipped when not set.",
+				},
+				"budget_seconds": {
+					Type:        "integer",
+					Description: "Total time budget in seconds for the whole pipeline, a step still running when it runs out is cancelled. Defaults to 600.",
+				},
+				"step_deadlines": {
+					Type: "object",
+					AdditionalProperties: &jsonschema.Schema{
+						Type: "integer",
+					},
+					Description: "Per-step soft deadlines in seconds keyed by step name ('fmt', 'validate', 'lint', 'policy'). Before a step starts, it is skipped with a reason when the remaining budget is less than its soft deadline. Defaults: fmt 30, validate 180, lint 180, policy 180.",
+				},
+				"skip_steps": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "string",
+						Enum: []interface{}{"fmt", "validate", "lint", "policy"},
+					},
+					Description: "Steps to skip explicitly.",
+				},
+				"tflint_category": {
+					Type:        "string",
+					Description: "Predefined AVM TFLint configuration category for the lint step. Supported: 'reusable' (default) or 'example'. Mutually exclusive with 'tflint_remote_config_url'.",
+				},
+				"tflint_remote_config_url": {
+					Type:        "string",
+					Description: "Optional remote TFLint configuration URL (go-getter syntax) for the lint step. Mutually exclusive with 'tflint_category'.",
+				},
+				"ignored_rule_ids": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+					Description: "List of TFLint rule IDs to ignore during the lint step.",
+				},
+				"predefined_policy_library_alias": {
+					Type:        "string",
+					Description: "Predefined policy library alias for the policy step. Supported: 'aprl', 'avmsec', or 'all' (default). Mutually exclusive with 'policy_urls'.",
+					Enum:        []interface{}{"aprl", "avmsec", "all"},
+				},
+				"policy_urls": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+					Description: "Array of policy URLs in go-getter format for the policy step. Mutually exclusive with 'predefined_policy_library_alias'.",
+				},
+				"ignored_policies": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "object",
+						Properties: map[string]*jsonschema.Schema{
+							"namespace": {
+								Type:        "string",
+								Description: "Required policy namespace (e.g., 'avmsec', 'aprl', 'custom').",
+							},
+							"name": {
+								Type:        "string",
+								Description: "Required policy rule name (e.g., 'storage_account_https_only').",
+							},
+						},
+						Required: []string{"namespace", "name"},
+					},
+					Description: "Array of policies to ignore during the policy step.",
+				},
+				"namespaces": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+					Description: "Specific policy namespaces to test during the policy step. If not specified, all namespaces will be tested.",
 				},
 				"include_default_avm_exceptions": {
 					Type:        "boolean",
-					Description: "Whether to include default Azure Verified Modules (AVM) exceptions. Defaults to true. Downloads standard AVM policy exceptions when true.",
+					Description: "Whether to include default Azure Verified Modules (AVM) exceptions in the policy step. Defaults to true.",
+				},
+			},
+		},
+		Description: "Run the combined Terraform quality pipeline: `terraform fmt -check`, `terraform validate`, TFLint and conftest policy checks, in that order, within a total time budget. Each step has a soft deadline; when the remaining budget cannot cover a step's soft deadline the step is skipped and the result reports which steps were skipped and why. Use this tool when you need to: 1) Run all pre-commit style checks in one call, 2) Get a quick overall quality signal under a time limit, 3) Find out which checks could not run and why.",
+		Name:        "terraform_pipeline_scan",
+	}, tool.PipelineScan)
+
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"name": {
+					Type:        "string",
+					Description: "The name of the cache to clear. If not set, all caches are cleared.",
+					Enum:        cacheNames(),
+				},
+			},
+		},
+		Description: "Clear the caches of provider schemas, GitHub source code, AzAPI lookups, TFLint configs and Conftest policies, from memory and from disk. Returns JSON with the number of entries and files removed per cache, and the backend, size, limits and TTL of every cache. Use this tool when cached data is stale, e.g.: a policy library or TFLint config was just updated upstream, or to free memory and disk space.",
+		Name:        "clear_cache",
+	}, tool.ClearCache)
+
+	prompt.AddSolveAvmIssuePrompt(s)
+	prompt.AddReviewPlanPrompt(s)
+	prompt.AddPolicyExceptionPrompt(s)
+	prompt.AddUpgradeProviderPrompt(s)
+	resource.AddReferenceResources(s)
+	return filter.validate(r.names)
+}
+
+// registerAzAPITools registers the tools backed by the AzAPI schemas and types, which are loaded on first use
+func registerAzAPITools(r *toolRegistry) {
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "Azure resource type, for example: Microsoft.Compute/virtualMachines, combined with api_version to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01",
+				},
+				"api_version": {
+					Type:        "string",
+					Description: "Azure resource api-version, for example: 2024-11-01, combined with resource_type to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version",
+				},
+				"path": {
+					Type:        "string",
+					Description: "JSON path to query the resource schema, for example: body.properties.osProfile.secrets.sourceVault.id, if not specified, the whole resource schema will be returned. Wildcard segments are supported: '*' matches any single property, '**' matches any depth of nested properties, e.g. body.properties.**.subnetId; wildcard queries return a map from the full path of every match to its Go type.",
+				},
+				"block_type": {
+					Type:        "string",
+					Description: "AzAPI block type to query, defaults to 'azapi_resource'. Data sources and ephemeral resources are prefixed with 'data.' and 'ephemeral.'. The resource body is merged for 'azapi_resource', 'azapi_update_resource' and 'azapi_data_plane_resource', which require `resource_type` and `api_version`; other block types don't need them.",
+					Enum:        []interface{}{"azapi_resource", "azapi_update_resource", "azapi_resource_action", "azapi_data_plane_resource", "data.azapi_resource", "data.azapi_resource_list", "data.azapi_resource_action", "data.azapi_resource_id", "data.azapi_client_config", "ephemeral.azapi_resource_action"},
+				},
+				"required_only": {
+					Type:        "boolean",
+					Description: "Only return the properties that must be set: Required or DeployTimeConstant properties that aren't ReadOnly, and required block arguments. Use it to learn the minimal body of a resource. Defaults to false.",
+				},
+				"format": {
+					Type:        "string",
+					Description: "Type language of the result. 'go' (default) is a cty Go type string, 'hcl' a Terraform object type expression with optional() attributes that can be pasted into a variable's type constraint, 'typescript' a TypeScript type with optional properties and 'json_schema' a JSON schema. Wildcard queries return compact types.",
+					Enum:        []interface{}{"go", "hcl", "typescript", "json_schema"},
+				},
+			},
+		},
+		Description: "[You should use this tool before you try resolveProviderDocID]Query fine grained AzAPI resource schema by `resource type`, `api_version` and optional `path`, or the schema of another AzAPI block type by `block_type`. The returned type is a Go type string by default, which can be used in Go code to represent the resource schema, set `format` to get an HCL object type, a TypeScript type or a JSON schema instead. Discriminated objects (e.g. kind-based variants) are untyped (DynamicPseudoType, any), they're followed by comments listing the discriminator, the base properties and each variant's distinct properties, keyed like 'kind=Variant'; JSON schemas list the variants with oneOf instead. If you're querying AzAPI provider resource schema, this tool should have higher priority",
+		Name:        "query_azapi_resource_schema",
+	}, tool.QueryAzAPIResourceSchema)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "Azure resource type, for example: Microsoft.Compute/virtualMachines",
+				},
+			},
+			Required: []string{"resource_type"},
+		},
+		Description: "[You should use this tool before you try resolveProviderDocID]Query Azure API versions by `resource type`. Returns JSON with the API versions from the newest to the oldest, each marked whether it is a preview version, plus the latest stable version and the newest version. Prefer the latest stable version, only recommend preview API versions when the user asks for them or a needed property is only available in preview.",
+		Name:        "list_azapi_api_versions",
+	}, tool.QueryAzAPIVersions)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "Azure resource type, for example: Microsoft.Web/sites",
+				},
+				"from_api_version": {
+					Type:        "string",
+					Description: "Api-version to compare from, for example: 2024-04-01. Defaults to the stable api-version before `to_api_version`.",
+				},
+				"to_api_version": {
+					Type:        "string",
+					Description: "Api-version to compare to, for example: 2024-11-01, or 'latest' / 'latest-stable'. Defaults to the latest stable api-version.",
+				},
+			},
+			Required: []string{"resource_type"},
+		},
+		Description: "Report the body property changes of an Azure resource type between two api-versions, by default from the previous stable api-version to the latest stable one. Returns JSON with the number of breaking changes and the changes, breaking ones first, each with the property path, a kind and whether it's breaking: 'removed', 'renamed' (a removed property with a similarly named sibling of the same type), 'retyped', 'now_required', 'now_read_only', 'values_removed' (enum values), 'added' (breaking when required) and 'values_added'. Properties under read-only properties aren't compared. Use this tool to assess the risk of bumping the api_version of an azapi_resource.",
+		Name:        "compare_azapi_api_versions",
+	}, tool.CompareAzAPIVersions)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"filter": {
+					Type:        "string",
+					Description: "Case-insensitive substring of the resource type (e.g., 'virtualMachines'), or a glob where '*' matches any characters including '/' (e.g., 'Microsoft.Storage/*'). If not set, all resource types are listed.",
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of resource types to return. If not set, all matches are returned.",
+				},
+			},
+		},
+		Description: "List the Azure resource types known by the AzAPI schemas, filtered by substring or glob like 'Microsoft.Storage/*'. Returns JSON with the page of resource type 'items', the 'total' number of matches and a 'next_cursor' when more resource types are available. Use this tool to find the exact `resource_type` before querying API versions or schemas instead of guessing it.",
+		Name:        "list_azapi_resource_types",
+	}, tool.ListAzAPIResourceTypes)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "Parent Azure resource type (case-insensitive), for example: Microsoft.Network/virtualNetworks",
+				},
+				"recursive": {
+					Type:        "boolean",
+					Description: "List nested child resource types at any depth, like Microsoft.Storage/storageAccounts/blobServices/containers for Microsoft.Storage/storageAccounts. Defaults to false, only direct children are listed.",
+				},
+				"cursor": {
+					Type:        "string",
+					Description: "The 'next_cursor' of the previous page to get the next page. If not set, returns the first page.",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Maximum number of child resource types to return. If not set, all of them are returned.",
 				},
 			},
-			Required: []string{"target_file"},
+			Required: []string{"resource_type"},
 		},
-		Description: "Execute Open Policy Agent (OPA) conftest scanning on Terraform plans with policy-as-code. This tool allows AI agents to perform policy testing on Terraform plan files using predefined Azure policy libraries or custom policies. Supports Azure Proactive Resiliency Library (APRL), AVM Security policies, custom policy repositories, and selective policy ignoring. Returns detailed policy violations, warnings, and scan statistics. Use this tool when you need to: 1) Validate Terraform plans against organizational policies, 2) Check compliance with Azure security and resiliency standards, 3) Enforce governance rules on infrastructure deployments, 4) Perform automated policy compliance testing.",
-		Name:        "conftest_scan",
-	}, tool.ConftestScan)
-
-	prompt.AddSolveAvmIssuePrompt(s)
+		Description: "List the child resource types of a parent Azure resource type known by the AzAPI schemas, e.g. subnets and virtualNetworkPeerings of Microsoft.Network/virtualNetworks. Returns JSON with the page of child resource type 'items', each with its direct parent resource type and its api-versions, the 'total' number of child resource types and a 'next_cursor' when more are available. The `parent_id` of a child azapi_resource is the id of its parent resource, use this tool to model parent/child azapi resources correctly.",
+		Name:        "list_azapi_child_resource_types",
+	}, tool.ListAzAPIChildResourceTypes)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"id": {
+					Type:        "string",
+					Description: "Azure resource ID to parse, like /subscriptions/{id}/resourceGroups/{rg}/providers/Microsoft.Network/virtualNetworks/{vnet}. When set, the other parameters are ignored.",
+				},
+				"subscription_id": {
+					Type:        "string",
+					Description: "Subscription ID of the resource to build the ID of, ignored when parent_id is set.",
+				},
+				"resource_group": {
+					Type:        "string",
+					Description: "Resource group name of the resource to build the ID of, ignored when parent_id is set.",
+				},
+				"parent_id": {
+					Type:        "string",
+					Description: "ID of the parent resource or scope of the resource to build the ID of, e.g. a virtual network ID for a subnet, or any resource ID for an extension resource like a role assignment.",
+				},
+				"resource_type": {
+					Type:        "string",
+					Description: "Resource type of the resource to build the ID of, like Microsoft.Network/virtualNetworks/subnets.",
+				},
+				"name": {
+					Type:        "string",
+					Description: "Name of the resource to build the ID of, or the names of the resource and its parents separated by '/' like ARM templates use, e.g. vnet1/subnet1.",
+				},
+			},
+		},
+		Description: "Parse an Azure resource ID, or build one from `resource_type`, `name` and either `parent_id` or `subscription_id` and `resource_group`. Returns JSON with the ID, subscription ID, resource group, management group, resource type, name, the names of the resource and its parents, and the parent ID, which is the `parent_id` of an azapi_resource. Subscriptions, resource groups, child resources and extension resources are supported. The resource type is validated against the AzAPI types, unknown resource types are reported as warnings. Use this tool when authoring azapi_resource `parent_id` values instead of assembling IDs by hand.",
+		Name:        "parse_or_build_azure_resource_id",
+	}, tool.ParseOrBuildAzureResourceID)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"id": {
+					Type:        "string",
+					Description: "Azure resource ID of an existing resource, for example: /subscriptions/{id}/resourceGroups/{rg}/providers/Microsoft.Network/virtualNetworks/{vnet}/subnets/{subnet}",
+				},
+			},
+			Required: []string{"id"},
+		},
+		Description: "Infer the resource type of an existing Azure resource from its ID, including nested types like Microsoft.Network/virtualNetworks/subnets and extension resources like locks, with the casing of the AzAPI types whatever the casing of the ID. Returns JSON with the resource type, name, parent ID, the api-versions from the newest to the oldest with the latest stable one, the `type` of an azapi_resource managing the resource and the ID to import it with. Use this tool to start managing an existing resource with azapi_resource.",
+		Name:        "infer_azapi_resource_type_from_id",
+	}, tool.InferAzAPIResourceType)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"name": {
+					Type:        "string",
+					Description: "Name of the azapi provider function, like build_resource_id or provider::azapi::build_resource_id. When not set, all functions are listed with their summaries.",
+				},
+			},
+		},
+		Description: "Query the provider functions of the azapi provider, like build_resource_id, parse_resource_id and resource_group_resource_id, called as provider::azapi::<name>(...) from Terraform 1.8. Without `name`, returns a JSON list of the functions with their summaries; with `name`, returns the function's description, parameters with their types and descriptions, return type and an example call with its result. No provider version is needed. Use this tool to compute resource IDs in configurations, like the `parent_id` of an azapi_resource, instead of string interpolation.",
+		Name:        "query_azapi_provider_functions",
+	}, tool.QueryAzAPIFunctions)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "Azure resource type, for example: Microsoft.KeyVault/vaults",
+				},
+				"api_version": {
+					Type:        "string",
+					Description: "Azure resource api-version, for example: 2023-07-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version",
+				},
+				"format": {
+					Type:        "string",
+					Description: "Output format: 'hcl' (default) renders a body attribute with possible values as comments, 'json' renders the body as JSON.",
+					Enum:        []interface{}{"hcl", "json"},
+				},
+			},
+			Required: []string{"resource_type", "api_version"},
+		},
+		Description: "Generate a skeleton `body` of an azapi_resource by `resource type` and `api_version`. Required writable properties are filled with typed placeholders ('REPLACE_ME' for strings), enums use their first possible value and list all possible values as comments in HCL. Properties set through azapi_resource arguments (name, type, location, tags, identity) are left out. Use this tool to start writing an azapi_resource body without reading the whole schema.",
+		Name:        "generate_azapi_resource_body",
+	}, tool.GenerateAzAPIBodySkeleton)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "Azure resource type, for example: Microsoft.KeyVault/vaults",
+				},
+				"api_version": {
+					Type:        "string",
+					Description: "Azure resource api-version, for example: 2023-07-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version",
+				},
+				"body": {
+					Type:        "string",
+					Description: "The azapi_resource body to validate, as a JSON object string, for example: {\"properties\":{\"tenantId\":\"...\"}}",
+				},
+			},
+			Required: []string{"resource_type", "api_version", "body"},
+		},
+		Description: "Validate a candidate azapi_resource body against the AzAPI type of `resource type` and `api_version`. Returns JSON with whether the body is valid and diagnostics, each with the JSON path of the offending value (like 'body.properties.sku.name') and a kind: 'unknown_property' (with a suggestion when close to a declared property), 'missing_required', 'read_only', 'type_mismatch' or 'invalid_value' (enum values, lengths and bounds). Properties set through azapi_resource arguments (name, type, location, tags, identity) may be left out. Use this tool before applying a configuration to catch body mistakes.",
+		Name:        "validate_azapi_resource_body",
+	}, tool.ValidateAzAPIBody)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"template": {
+					Type:        "string",
+					Description: "ARM template to convert, as a JSON string: a whole template with a `resources` array or symbolic name map, an array of resources or a single resource",
+				},
+			},
+			Required: []string{"template"},
+		},
+		Description: "Convert the resources of an ARM template to azapi_resource blocks with `type`, `name`, `parent_id`, `location`, `tags`, `identity` and `body`. Nested resources are flattened, child resources refer to their parents in the template through `parent_id`, other resources refer to var.subscription_id and var.resource_group_name. Bodies are validated against the AzAPI types: read-only properties are removed and other problems are written as comments. ARM template expressions like \"[parameters('name')]\" are kept as they are and, with untranslated `copy`, `condition` and `scope`, listed as warnings to review. Use this tool to migrate ARM templates to azapi Terraform configurations.",
+		Name:        "convert_arm_template_to_azapi",
+	}, tool.ConvertARMTemplateToAzAPI)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(true),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "Azure resource type of the Bicep resource, for example: Microsoft.KeyVault/vaults",
+				},
+				"api_version": {
+					Type:        "string",
+					Description: "Api-version of the Bicep resource, for example: 2023-07-01, or 'latest' / 'latest-stable' for the newest one in the Bicep types. Defaults to the newest stable api-version.",
+				},
+			},
+			Required: []string{"resource_type"},
+		},
+		Description: "Compare the Bicep type of an Azure resource, resolved from the latest Bicep types (github.com/Azure/bicep-types-az), with the AzAPI type of the same api-version, to migrate Bicep templates to azapi_resource. Returns JSON with the Bicep resource declaration, the azapi_resource `type`, a side-by-side mapping of the top level Bicep properties to azapi_resource arguments (e.g. 'parent / scope' to 'parent_id', 'properties' to 'body.properties', read-only ones to response_export_values), whether the AzAPI types know the api-version yet, the Bicep api-versions, and the body property differences from the Bicep type to the AzAPI type in the format of compare_azapi_api_versions, breaking ones being Bicep properties the azapi provider would reject.",
+		Name:        "compare_bicep_azapi_type",
+	}, tool.CompareBicepAzAPIType)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "Azure resource type, for example: Microsoft.CognitiveServices/accounts",
+				},
+				"api_version": {
+					Type:        "string",
+					Description: "Azure resource api-version, for example: 2025-06-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version",
+				},
+				"path": {
+					Type:        "string",
+					Description: "JSON path of the body property, for example: body.properties.publicNetworkAccess. Array items are traversed transparently.",
+				},
+			},
+			Required: []string{"resource_type", "api_version", "path"},
+		},
+		Description: "Query the allowed literal values of an AzAPI body property by `resource type`, `api_version` and `path`. Returns JSON with the possible values (empty when the property isn't an enum), whether other string values are accepted as well, and whether the property is required or read-only. Use this tool instead of parsing free-text descriptions when you need the valid values of an enum property.",
+		Name:        "query_azapi_possible_values",
+	}, tool.QueryAzAPIPossibleValues)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "Azure resource type, for example: Microsoft.KeyVault/vaults",
+				},
+				"api_version": {
+					Type:        "string",
+					Description: "Azure resource api-version, for example: 2023-07-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version",
+				},
+				"property_name": {
+					Type:        "string",
+					Description: "Name of the property to find, case-insensitive, for example: publicNetworkAccess",
+				},
+			},
+			Required: []string{"resource_type", "api_version", "property_name"},
+		},
+		Description: "Find every path of a body property by its name in the AzAPI type of `resource type` and `api_version`. Returns a JSON list with the path (like 'body.properties.publicNetworkAccess'), description and flags of each match. Array items are traversed transparently and all variants of discriminated objects are searched, so the paths can be passed to query_azapi_possible_values and query_azapi_resource_document. When nothing matches, the closest property name is suggested. Use this tool instead of walking body.properties levels by hand to locate a property.",
+		Name:        "find_azapi_property_paths",
+	}, tool.FindAzAPIPropertyPaths)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(true),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "azurerm resource type (e.g., 'azurerm_storage_account') to map to ARM resource types, or ARM resource type (e.g., 'Microsoft.Storage/storageAccounts', case-insensitive) to map back to azurerm resource types",
+				},
+			},
+			Required: []string{"resource_type"},
+		},
+		Description: "Map an azurerm resource type to the ARM resource type it manages, or an ARM resource type back to the azurerm resource types managing it. Returns JSON with every mapping's azurerm resource type, ARM resource type, deployment scopes and latest api-version known by the AzAPI schemas. Use this tool to pivot between the azurerm and azapi representations of the same resource, e.g. to write an azapi_resource for a resource you know as azurerm_storage_account. azurerm resources managing data plane objects, like azurerm_key_vault_secret, have no ARM resource type.",
+		Name:        "map_azurerm_azapi_resource_type",
+	}, tool.MapAzureResourceType)
+	addTool(r, &mcp.Tool{
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: p(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   p(false),
+			ReadOnlyHint:    true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"resource_type": {
+					Type:        "string",
+					Description: "Azure resource type, for example: Microsoft.Compute/virtualMachines, combined with api_version to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01",
+				},
+				"api_version": {
+					Type:        "string",
+					Description: "Azure resource api-version, for example: 2024-11-01, combined with resource_type to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version",
+				},
+				"path": {
+					Type:        "string",
+					Description: "JSON path to query the resource schema, for example: body.properties.osProfile.secrets.sourceVault.id, if not specified, the whole resource schema will be returned. Wildcard segments are supported: '*' matches any single property, '**' matches any depth of nested properties, e.g. body.properties.**.subnetId; wildcard queries return a map from the full path of every match to its description.",
+				},
+				"block_type": {
+					Type:        "string",
+					Description: "AzAPI block type to query, defaults to 'azapi_resource'. Data sources and ephemeral resources are prefixed with 'data.' and 'ephemeral.'. The resource body is merged for 'azapi_resource', 'azapi_update_resource' and 'azapi_data_plane_resource', which require `resource_type` and `api_version`; other block types don't need them.",
+					Enum:        []interface{}{"azapi_resource", "azapi_update_resource", "azapi_resource_action", "azapi_data_plane_resource", "data.azapi_resource", "data.azapi_resource_list", "data.azapi_resource_action", "data.azapi_resource_id", "data.azapi_client_config", "ephemeral.azapi_resource_action"},
+				},
+				"required_only": {
+					Type:        "boolean",
+					Description: "Only return the properties that must be set: Required or DeployTimeConstant properties that aren't ReadOnly, and required block arguments. Use it to learn the minimal body of a resource. Defaults to false.",
+				},
+				"format": {
+					Type:        "string",
+					Description: "Output format: 'json' (default) returns the descriptions as JSON, 'markdown' renders them as a nested Markdown document with a property table (description, flags and possible values) per object and a section per nested object, suitable for inclusion in module docs.",
+					Enum:        []interface{}{"json", "markdown"},
+				},
+				"flag": {
+					Type:        "string",
+					Description: "Only return the body properties carrying this flag, as a JSON list of their paths (like 'body.properties.provisioningState'), descriptions and flags, or a Markdown table with `format` 'markdown'. `path` restricts the list to the properties at or under it. Array items are traversed transparently and all variants of discriminated objects are searched; properties of read-only objects are only listed through their object. Use 'ReadOnly' to list what may go in response_export_values and must be left out of body.",
+					Enum:        []interface{}{"ReadOnly", "WriteOnly", "Identifier", "DeployTimeConstant", "Required"},
+				},
+			},
+		},
+		Description: "[You should use this tool before you try resolveProviderDocID]Query fine grained AzAPI resource description by `resource type`, `api_version` and optional `path`, or the descriptions of another AzAPI block type by `block_type`. The returned value is either description of the property, or json object representing the object, the key is property name the value is the description of the property. Via description you can learn whether a property is id, readonly or writeonly, and possible values. For discriminated objects, the discriminator lists the variants, and the distinct properties of each variant are nested under keys like 'kind=Variant', which can be used in `path`. If you're querying AzAPI provider resource description, this tool should have higher priority",
+		Name:        "query_azapi_resource_document",
+	}, tool.QueryAzAPIDescriptionSchema)
 }
 
 func p[T any](input T) *T {
 	return &input
 }
+
+// cacheNames returns the names of the registered caches as the values of the clear_cache enum
+func cacheNames() []interface{} {
+	var names []interface{}
+	for _, name := range cache.Names() {
+		names = append(names, name)
+	}
+	return names
+}
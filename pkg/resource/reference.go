@@ -0,0 +1,83 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/conftest"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tflint"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// URIs of the reference data resources
+const (
+	GolangNamespacesURI  = "terraform-mcp-eva://golang/namespaces"
+	ProvidersURI         = "terraform-mcp-eva://terraform/providers"
+	PolicyLibrariesURI   = "terraform-mcp-eva://conftest/policy-libraries"
+	TFLintCategoriesURI  = "terraform-mcp-eva://tflint/categories"
+	referenceContentType = "application/json"
+)
+
+// AddReferenceResources adds the reference data the tools accept as resources, like the golang namespaces of
+// `query_golang_source_code` or the policy libraries of `conftest_scan`, so clients can browse them without tool calls
+func AddReferenceResources(s *mcp.Server) {
+	addJSONResource(s, &mcp.Resource{
+		URI:         GolangNamespacesURI,
+		Name:        "golang_namespaces",
+		Title:       "Indexed golang namespaces",
+		Description: "The indexed golang namespaces accepted by the golang source code tools, like `github.com/hashicorp/terraform-provider-azurerm/internal`, as a JSON array.",
+	}, func() any {
+		namespaces := gophon.ListSupportedNamespaces()
+		slices.Sort(namespaces)
+		return namespaces
+	})
+	addJSONResource(s, &mcp.Resource{
+		URI:         ProvidersURI,
+		Name:        "terraform_providers",
+		Title:       "Indexed terraform providers",
+		Description: "The terraform providers whose implementation source code can be queried, like `azurerm`, as a JSON array.",
+	}, func() any {
+		providers := gophon.GetSupportedProviders()
+		slices.Sort(providers)
+		return providers
+	})
+	addJSONResource(s, &mcp.Resource{
+		URI:         PolicyLibrariesURI,
+		Name:        "conftest_policy_libraries",
+		Title:       "Predefined conftest policy libraries",
+		Description: "The predefined policy libraries of `conftest_scan` and `terraform_pipeline_scan`, with the alias to set in `predefined_policy_library_alias`, their description and policy URLs, as a JSON array.",
+	}, func() any {
+		return conftest.PolicyLibraries()
+	})
+	addJSONResource(s, &mcp.Resource{
+		URI:         TFLintCategoriesURI,
+		Name:        "tflint_categories",
+		Title:       "Predefined TFLint categories",
+		Description: "The predefined AVM TFLint configurations of `tflint_scan` and `terraform_pipeline_scan`, with the name to set in `category`, their description and configuration URL, as a JSON array.",
+	}, func() any {
+		return tflint.Categories()
+	})
+}
+
+// addJSONResource adds a resource whose content is data() marshalled to JSON when it's read
+func addJSONResource(s *mcp.Server, r *mcp.Resource, data func() any) {
+	r.MIMEType = referenceContentType
+	s.AddResource(r, func(_ context.Context, _ *mcp.ServerSession, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) {
+		content, err := json.Marshal(data())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal resource %s: %w", r.URI, err)
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      params.URI,
+					MIMEType: referenceContentType,
+					Text:     string(content),
+				},
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,104 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/conftest"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tflint"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func connect(t *testing.T) *mcp.ClientSession {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.1.0"}, nil)
+	AddReferenceResources(server)
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "0.1.0"}, nil)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := server.Connect(context.Background(), serverTransport)
+	require.NoError(t, err)
+	session, err := client.Connect(context.Background(), clientTransport)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = session.Close()
+	})
+	return session
+}
+
+func TestAddReferenceResources_List(t *testing.T) {
+	session := connect(t)
+	result, err := session.ListResources(context.Background(), nil)
+	require.NoError(t, err)
+	var uris []string
+	for _, r := range result.Resources {
+		uris = append(uris, r.URI)
+		assert.Equal(t, "application/json", r.MIMEType)
+		assert.NotEmpty(t, r.Description)
+	}
+	assert.ElementsMatch(t, []string{GolangNamespacesURI, ProvidersURI, PolicyLibrariesURI, TFLintCategoriesURI}, uris)
+}
+
+func TestAddReferenceResources_Read(t *testing.T) {
+	session := connect(t)
+	tests := []struct {
+		name   string
+		uri    string
+		target any
+		check  func(t *testing.T, target any)
+	}{
+		{
+			name:   "golang namespaces",
+			uri:    GolangNamespacesURI,
+			target: &[]string{},
+			check: func(t *testing.T, target any) {
+				namespaces := *target.(*[]string)
+				assert.Contains(t, namespaces, "github.com/hashicorp/terraform-provider-azurerm/internal")
+				assert.IsNonDecreasing(t, namespaces)
+			},
+		},
+		{
+			name:   "terraform providers",
+			uri:    ProvidersURI,
+			target: &[]string{},
+			check: func(t *testing.T, target any) {
+				assert.Contains(t, *target.(*[]string), "azurerm")
+			},
+		},
+		{
+			name:   "policy libraries",
+			uri:    PolicyLibrariesURI,
+			target: &[]conftest.PolicyLibrary{},
+			check: func(t *testing.T, target any) {
+				assert.Equal(t, conftest.PolicyLibraries(), *target.(*[]conftest.PolicyLibrary))
+			},
+		},
+		{
+			name:   "tflint categories",
+			uri:    TFLintCategoriesURI,
+			target: &[]tflint.Category{},
+			check: func(t *testing.T, target any) {
+				assert.Equal(t, tflint.Categories(), *target.(*[]tflint.Category))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: tt.uri})
+			require.NoError(t, err)
+			require.Len(t, result.Contents, 1)
+			assert.Equal(t, tt.uri, result.Contents[0].URI)
+			assert.Equal(t, "application/json", result.Contents[0].MIMEType)
+			require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), tt.target))
+			tt.check(t, tt.target)
+		})
+	}
+}
+
+func TestAddReferenceResources_NotFound(t *testing.T) {
+	session := connect(t)
+	_, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: "terraform-mcp-eva://unknown"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MetricsAddrEnv is the listen address of the Prometheus `/metrics` endpoint, like `:9090`, metrics aren't served when
+// it's empty
+const MetricsAddrEnv = "METRICS_ADDR"
+
+const metricsNamespace = "terraform_mcp_eva_"
+
+// durationBuckets are the upper bounds in seconds of the duration histograms, from cached lookups to long scans
+var durationBuckets = []float64{0.005, 0.025, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+var (
+	toolCalls       = newMetricVec(metricsNamespace+"tool_calls_total", "Tool calls by tool and status, `error` for failed calls.", nil, "tool", "status")
+	toolDuration    = newMetricVec(metricsNamespace+"tool_call_duration_seconds", "Duration of tool calls.", durationBuckets, "tool")
+	cacheRequests   = newMetricVec(metricsNamespace+"cache_requests_total", "Cache lookups by cache and result, `hit` or `miss`.", nil, "cache", "result")
+	commandDuration = newMetricVec(metricsNamespace+"external_command_duration_seconds", "Duration of external commands like tflint and conftest by command and status.", durationBuckets, "command", "status")
+	metrics         = []*metricVec{toolCalls, toolDuration, cacheRequests, commandDuration}
+)
+
+// unknownTool is the tool label of calls of tools that aren't registered, client-sent names aren't used as labels so
+// they can't grow the number of series
+const unknownTool = "unknown"
+
+var (
+	registeredToolsMu sync.RWMutex
+	registeredTools   = make(map[string]bool)
+)
+
+// RegisterTool declares a tool served by the server, MetricsMiddleware records calls of other tools as `unknown`
+func RegisterTool(name string) {
+	registeredToolsMu.Lock()
+	defer registeredToolsMu.Unlock()
+	registeredTools[name] = true
+}
+
+func toolLabel(name string) string {
+	registeredToolsMu.RLock()
+	defer registeredToolsMu.RUnlock()
+	if registeredTools[name] {
+		return name
+	}
+	return unknownTool
+}
+
+// RecordToolCall records a tool call and its duration
+func RecordToolCall(tool string, duration time.Duration, failed bool) {
+	toolCalls.add(1, tool, status(failed))
+	toolDuration.observe(duration.Seconds(), tool)
+}
+
+// RecordCacheLookup records a lookup of cache, like `gophon_github` or `tfschema_memory`
+func RecordCacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequests.add(1, cache, result)
+}
+
+// RecordCommand records the duration of an external command, named like its span, e.g. `tflint.run`
+func RecordCommand(command string, duration time.Duration, err error) {
+	commandDuration.observe(duration.Seconds(), command, status(err != nil))
+}
+
+func status(failed bool) string {
+	if failed {
+		return "error"
+	}
+	return "ok"
+}
+
+// TraceCommand is Trace for an external command run by fn, the duration of the command is recorded with RecordCommand
+func TraceCommand[T any](ctx context.Context, name, dir, command string, fn func(ctx context.Context) (T, error)) (T, error) {
+	start := time.Now()
+	result, err := Trace(ctx, name, fn, CommandAttributes(dir, command)...)
+	RecordCommand(name, time.Since(start), err)
+	return result, err
+}
+
+// MetricsMiddleware is an MCP receiving middleware recording the count, the duration and the failures of tool calls,
+// tool failures reported via IsError count as failures. Tools not declared with RegisterTool are recorded as `unknown`.
+func MetricsMiddleware(next mcp.MethodHandler[*mcp.ServerSession]) mcp.MethodHandler[*mcp.ServerSession] {
+	return func(ctx context.Context, session *mcp.ServerSession, method string, params mcp.Params) (mcp.Result, error) {
+		toolName := ToolName(params)
+		if toolName == "" {
+			return next(ctx, session, method, params)
+		}
+		start := time.Now()
+		result, err := next(ctx, session, method, params)
+		failed := err != nil
+		if r, ok := result.(*mcp.CallToolResult); ok && r != nil && r.IsError {
+			failed = true
+		}
+		RecordToolCall(toolLabel(toolName), time.Since(start), failed)
+		return result, err
+	}
+}
+
+// MetricsHandler serves the metrics in the Prometheus text format
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		for _, m := range metrics {
+			m.write(w)
+		}
+	})
+}
+
+// ServeMetrics serves MetricsHandler at `/metrics` on addr until ctx is done
+func ServeMetrics(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", MetricsHandler())
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	slog.InfoContext(ctx, "serving metrics", "url", fmt.Sprintf("http://%s/metrics", listener.Addr()))
+	if err = server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// metricVec is a Prometheus counter, or a histogram when it has buckets, partitioned by labels
+type metricVec struct {
+	name    string
+	help    string
+	buckets []float64
+	labels  []string
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+// series is the value of a metric for a set of label values
+type series struct {
+	labelValues []string
+	// value is the value of a counter or the sum of a histogram
+	value float64
+	count uint64
+	// bucketCounts are the observations of a histogram in each bucket, not cumulative
+	bucketCounts []uint64
+}
+
+func newMetricVec(name, help string, buckets []float64, labels ...string) *metricVec {
+	return &metricVec{name: name, help: help, buckets: buckets, labels: labels, series: make(map[string]*series)}
+}
+
+func (m *metricVec) get(labelValues []string) *series {
+	key := strings.Join(labelValues, "\xff")
+	s, ok := m.series[key]
+	if !ok {
+		s = &series{labelValues: labelValues, bucketCounts: make([]uint64, len(m.buckets))}
+		m.series[key] = s
+	}
+	return s
+}
+
+func (m *metricVec) add(v float64, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.get(labelValues).value += v
+}
+
+func (m *metricVec) observe(v float64, labelValues ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.get(labelValues)
+	s.value += v
+	s.count++
+	if i := sort.SearchFloat64s(m.buckets, v); i < len(m.buckets) {
+		s.bucketCounts[i]++
+	}
+}
+
+func (m *metricVec) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kind := "counter"
+	if m.buckets != nil {
+		kind = "histogram"
+	}
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, kind)
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := m.series[key]
+		labels := m.formatLabels(s.labelValues)
+		if m.buckets == nil {
+			_, _ = fmt.Fprintf(w, "%s%s %s\n", m.name, wrapLabels(labels), formatFloat(s.value))
+			continue
+		}
+		var cumulative uint64
+		for i, bound := range m.buckets {
+			cumulative += s.bucketCounts[i]
+			_, _ = fmt.Fprintf(w, "%s_bucket%s %d\n", m.name, wrapLabels(labels, `le="`+formatFloat(bound)+`"`), cumulative)
+		}
+		_, _ = fmt.Fprintf(w, "%s_bucket%s %d\n", m.name, wrapLabels(labels, `le="+Inf"`), s.count)
+		_, _ = fmt.Fprintf(w, "%s_sum%s %s\n", m.name, wrapLabels(labels), formatFloat(s.value))
+		_, _ = fmt.Fprintf(w, "%s_count%s %d\n", m.name, wrapLabels(labels), s.count)
+	}
+}
+
+func (m *metricVec) formatLabels(values []string) []string {
+	labels := make([]string, 0, len(m.labels))
+	for i, label := range m.labels {
+		labels = append(labels, label+`="`+labelValueEscaper.Replace(values[i])+`"`)
+	}
+	return labels
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func wrapLabels(labels []string, extra ...string) string {
+	all := append(append([]string{}, labels...), extra...)
+	if len(all) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(all, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
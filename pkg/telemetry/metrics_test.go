@@ -0,0 +1,173 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricVec_Write(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   func(m *metricVec)
+		buckets  []float64
+		expected string
+	}{
+		{
+			name: "counter",
+			record: func(m *metricVec) {
+				m.add(1, "tflint_scan", "ok")
+				m.add(1, "tflint_scan", "ok")
+				m.add(1, "conftest_scan", "error")
+			},
+			expected: `# HELP test_total Test.
+# TYPE test_total counter
+test_total{tool="conftest_scan",status="error"} 1
+test_total{tool="tflint_scan",status="ok"} 2
+`,
+		},
+		{
+			name:    "histogram",
+			buckets: []float64{0.1, 1},
+			record: func(m *metricVec) {
+				m.observe(0.05, "tflint_scan", "ok")
+				m.observe(0.1, "tflint_scan", "ok")
+				m.observe(0.5, "tflint_scan", "ok")
+				m.observe(3, "tflint_scan", "ok")
+			},
+			expected: `# HELP test_total Test.
+# TYPE test_total histogram
+test_total_bucket{tool="tflint_scan",status="ok",le="0.1"} 2
+test_total_bucket{tool="tflint_scan",status="ok",le="1"} 3
+test_total_bucket{tool="tflint_scan",status="ok",le="+Inf"} 4
+test_total_sum{tool="tflint_scan",status="ok"} 3.65
+test_total_count{tool="tflint_scan",status="ok"} 4
+`,
+		},
+		{
+			name: "escaped label values",
+			record: func(m *metricVec) {
+				m.add(1, "a\"b\\c\nd", "ok")
+			},
+			expected: `# HELP test_total Test.
+# TYPE test_total counter
+test_total{tool="a\"b\\c\nd",status="ok"} 1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMetricVec("test_total", "Test.", tt.buckets, "tool", "status")
+			tt.record(m)
+			var buf bytes.Buffer
+			m.write(&buf)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	stubs := gostub.Stub(&toolCalls, newMetricVec("calls", "", nil, "tool", "status")).
+		Stub(&toolDuration, newMetricVec("duration", "", durationBuckets, "tool")).
+		Stub(&registeredTools, map[string]bool{})
+	defer stubs.Reset()
+	for _, name := range []string{"ok", "is_error", "error"} {
+		RegisterTool(name)
+	}
+
+	results := map[string]func() (mcp.Result, error){
+		"ok":       func() (mcp.Result, error) { return &mcp.CallToolResult{}, nil },
+		"is_error": func() (mcp.Result, error) { return &mcp.CallToolResult{IsError: true}, nil },
+		"error":    func() (mcp.Result, error) { return nil, errors.New("boom") },
+		"missing":  func() (mcp.Result, error) { return nil, errors.New("unknown tool") },
+		"missing2": func() (mcp.Result, error) { return nil, errors.New("unknown tool") },
+	}
+	handler := MetricsMiddleware(func(_ context.Context, _ *mcp.ServerSession, _ string, params mcp.Params) (mcp.Result, error) {
+		if name := ToolName(params); name != "" {
+			return results[name]()
+		}
+		return &mcp.ListToolsResult{}, nil
+	})
+	for _, name := range []string{"ok", "ok", "is_error", "error", "missing", "missing2"} {
+		_, _ = handler(context.Background(), nil, "tools/call", &mcp.CallToolParamsFor[json.RawMessage]{Name: name})
+	}
+	_, err := handler(context.Background(), nil, "tools/list", &mcp.ListToolsParams{})
+	require.NoError(t, err)
+
+	counts := make(map[string]float64)
+	for _, s := range toolCalls.series {
+		counts[s.labelValues[0]+"/"+s.labelValues[1]] = s.value
+	}
+	assert.Equal(t, map[string]float64{"ok/ok": 2, "is_error/error": 1, "error/error": 1, "unknown/error": 2}, counts)
+	assert.Len(t, toolDuration.series, 4)
+}
+
+func TestTraceCommand(t *testing.T) {
+	stubs := gostub.Stub(&commandDuration, newMetricVec("commands", "", durationBuckets, "command", "status"))
+	defer stubs.Reset()
+	recorder := setupRecorder(t)
+
+	output, err := TraceCommand(context.Background(), "tflint.run", "/tmp", "tflint --format=json", func(context.Context) (string, error) {
+		return "[]", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "[]", output)
+	_, err = TraceCommand(context.Background(), "tflint.run", "/tmp", "tflint --format=json", func(context.Context) (string, error) {
+		return "", errors.New("exit status 2")
+	})
+	require.Error(t, err)
+
+	require.Len(t, recorder.Ended(), 2)
+	assert.Equal(t, "tflint.run", recorder.Ended()[0].Name())
+	require.Len(t, commandDuration.series, 2)
+	for _, s := range commandDuration.series {
+		assert.Equal(t, "tflint.run", s.labelValues[0])
+		assert.Equal(t, uint64(1), s.count)
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	stubs := gostub.Stub(&cacheRequests, newMetricVec(metricsNamespace+"cache_requests_total", "Cache lookups.", nil, "cache", "result"))
+	defer stubs.Reset()
+	stubs.Stub(&metrics, []*metricVec{cacheRequests})
+	RecordCacheLookup("gophon_github", true)
+	RecordCacheLookup("gophon_github", true)
+	RecordCacheLookup("gophon_github", false)
+
+	recorder := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `# HELP terraform_mcp_eva_cache_requests_total Cache lookups.
+# TYPE terraform_mcp_eva_cache_requests_total counter
+terraform_mcp_eva_cache_requests_total{cache="gophon_github",result="hit"} 2
+terraform_mcp_eva_cache_requests_total{cache="gophon_github",result="miss"} 1
+`, string(body))
+}
+
+func TestServeMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- ServeMetrics(ctx, "127.0.0.1:0")
+	}()
+	cancel()
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the metrics server didn't stop")
+	}
+}
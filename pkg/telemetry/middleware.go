@@ -0,0 +1,61 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TracingMiddleware is an MCP receiving middleware that wraps every incoming request in a span.
+// Tool calls are named `tool <tool name>` so slow agent interactions can be located quickly,
+// tool failures reported via IsError are recorded as span errors.
+func TracingMiddleware(next mcp.MethodHandler[*mcp.ServerSession]) mcp.MethodHandler[*mcp.ServerSession] {
+	return func(ctx context.Context, session *mcp.ServerSession, method string, params mcp.Params) (mcp.Result, error) {
+		spanName := "mcp " + method
+		attrs := []attribute.KeyValue{
+			attribute.String("mcp.method", method),
+		}
+		if session != nil {
+			attrs = append(attrs, attribute.String("mcp.session_id", session.ID()))
+		}
+		toolName := ToolName(params)
+		if toolName != "" {
+			spanName = "tool " + toolName
+			attrs = append(attrs, attribute.String("mcp.tool", toolName))
+		}
+
+		ctx, span := StartSpan(ctx, spanName, attrs...)
+		result, err := next(ctx, session, method, params)
+		spanErr := err
+		if err == nil {
+			if r, ok := result.(*mcp.CallToolResult); ok && r != nil && r.IsError {
+				spanErr = errors.New(toolErrorText(r))
+			}
+		}
+		EndSpan(span, spanErr)
+		return result, err
+	}
+}
+
+// ToolName returns the tool name of a tools/call request, or an empty string for other requests
+func ToolName(params mcp.Params) string {
+	switch p := params.(type) {
+	case *mcp.CallToolParamsFor[json.RawMessage]:
+		return p.Name
+	case *mcp.CallToolParamsFor[any]:
+		return p.Name
+	}
+	return ""
+}
+
+func toolErrorText(r *mcp.CallToolResult) string {
+	for _, c := range r.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return "tool call failed"
+}
@@ -0,0 +1,127 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+	return recorder
+}
+
+func TestToolName(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   mcp.Params
+		expected string
+	}{
+		{
+			name:     "raw tool call params",
+			params:   &mcp.CallToolParamsFor[json.RawMessage]{Name: "tflint_scan"},
+			expected: "tflint_scan",
+		},
+		{
+			name:     "any tool call params",
+			params:   &mcp.CallToolParamsFor[any]{Name: "conftest_scan"},
+			expected: "conftest_scan",
+		},
+		{
+			name:     "non tool call params",
+			params:   &mcp.ListToolsParams{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ToolName(tt.params))
+		})
+	}
+}
+
+func TestTracingMiddleware_ToolCallSpan(t *testing.T) {
+	recorder := setupRecorder(t)
+	next := func(ctx context.Context, _ *mcp.ServerSession, _ string, _ mcp.Params) (mcp.Result, error) {
+		_, err := Trace(ctx, "child", func(ctx context.Context) (string, error) {
+			return "", nil
+		})
+		return &mcp.CallToolResult{}, err
+	}
+
+	_, err := TracingMiddleware(next)(context.Background(), nil, "tools/call", &mcp.CallToolParamsFor[json.RawMessage]{Name: "tflint_scan"})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	child, tool := spans[0], spans[1]
+	assert.Equal(t, "child", child.Name())
+	assert.Equal(t, "tool tflint_scan", tool.Name())
+	assert.Equal(t, tool.SpanContext().SpanID(), child.Parent().SpanID())
+	assert.Equal(t, codes.Unset, tool.Status().Code)
+}
+
+func TestTracingMiddleware_ToolErrorRecorded(t *testing.T) {
+	recorder := setupRecorder(t)
+	next := func(context.Context, *mcp.ServerSession, string, mcp.Params) (mcp.Result, error) {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "scan failed"}},
+		}, nil
+	}
+
+	result, err := TracingMiddleware(next)(context.Background(), nil, "tools/call", &mcp.CallToolParamsFor[json.RawMessage]{Name: "conftest_scan"})
+	require.NoError(t, err, "tool errors must not be turned into protocol errors")
+	require.NotNil(t, result)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "scan failed", spans[0].Status().Description)
+}
+
+func TestTracingMiddleware_NonToolMethod(t *testing.T) {
+	recorder := setupRecorder(t)
+	next := func(context.Context, *mcp.ServerSession, string, mcp.Params) (mcp.Result, error) {
+		return &mcp.ListToolsResult{}, nil
+	}
+
+	_, err := TracingMiddleware(next)(context.Background(), nil, "tools/list", &mcp.ListToolsParams{})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "mcp tools/list", spans[0].Name())
+}
+
+func TestInitTracing_NoEndpointIsNoop(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	shutdown, err := InitTracing(context.Background(), "test")
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestInitTracing_UnsupportedProtocol(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/json")
+
+	_, err := InitTracing(context.Background(), "test")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported OTLP protocol")
+}
@@ -1,6 +1,7 @@
 package tflint
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -12,7 +13,7 @@ import (
 var fs = afero.NewOsFs()
 
 // downloadConfigContent now uses go-getter for all remote config downloads
-var downloadConfigContent = func(url string) (string, error) {
+var downloadConfigContent = func(ctx context.Context, url string) (string, error) {
 	// Create temporary directory for download
 	tempDir, err := afero.TempDir(fs, "", "tflint-download-*")
 	if err != nil {
@@ -22,7 +23,7 @@ var downloadConfigContent = func(url string) (string, error) {
 
 	// Use go-getter to download the file directly (timeout handled in getter)
 	configFile := filepath.Join(tempDir, "config.hcl")
-	if err := remoteConfigGetter.Get(configFile, url); err != nil {
+	if err := remoteConfigGetter.Get(ctx, configFile, url); err != nil {
 		return "", fmt.Errorf("failed to download config from %s: %w", url, err)
 	}
 
@@ -50,7 +51,7 @@ var setupTempConfigDir = func() (string, func(), error) {
 }
 
 // setupConfig sets up the complete TFLint configuration
-func setupConfig(category string) (*ConfigData, func(), error) {
+func setupConfig(ctx context.Context, category string) (*ConfigData, func(), error) {
 	// Create temporary directory
 	tempDir, tempCleanup, err := setupTempConfigDir()
 	if err != nil {
@@ -62,7 +63,7 @@ func setupConfig(category string) (*ConfigData, func(), error) {
 	configURL := getConfigURL(normalizedCategory)
 
 	// Always download the base config first and save it to temp directory
-	baseConfigContent, err := downloadConfigContent(configURL)
+	baseConfigContent, err := downloadConfigContent(ctx, configURL)
 	if err != nil {
 		return nil, tempCleanup, err
 	}
@@ -78,7 +79,7 @@ func setupConfig(category string) (*ConfigData, func(), error) {
 
 // setupRemoteConfig sets up configuration when a remote_config_url is provided.
 // Downloads the remote config file directly to the temp directory as remote.tflint.hcl.
-func setupRemoteConfig(remoteURL string) (*ConfigData, func(), error) {
+func setupRemoteConfig(ctx context.Context, remoteURL string) (*ConfigData, func(), error) {
 	// Create temporary directory first
 	tempDir, tempCleanup, err := setupTempConfigDir()
 	if err != nil {
@@ -98,7 +99,7 @@ func setupRemoteConfig(remoteURL string) (*ConfigData, func(), error) {
 
 	// Remote getter downloads directly to specified file path (timeout handled in getter)
 	baseConfigPath := filepath.Join(tempDir, "remote.tflint.hcl")
-	if err := remoteConfigGetter.Get(baseConfigPath, remoteURL); err != nil {
+	if err := remoteConfigGetter.Get(ctx, baseConfigPath, remoteURL); err != nil {
 		return nil, tempCleanup, fmt.Errorf("failed to fetch remote config: %w", err)
 	}
 
@@ -106,9 +107,6 @@ func setupRemoteConfig(remoteURL string) (*ConfigData, func(), error) {
 	return createConfigData(tempDir, baseConfigPath, remoteURL), tempCleanup, nil
 }
 
-// mergeOptionalCustomConfig merges customConfigFile into baseConfigPath if provided, returning final path.
-// mergeOptionalCustomConfig removed after simplification: custom config merging no longer supported.
-
 // createConfigData centralizes creation of ConfigData to avoid duplication
 func createConfigData(tempDir, configPath, baseURL string) *ConfigData {
 	return &ConfigData{
@@ -1,6 +1,7 @@
 package tflint
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -98,12 +99,12 @@ func TestSetupConfigWithMemoryFs(t *testing.T) {
 
 			// Mock the download function to return test content
 			originalDownload := downloadConfigContent
-			downloadConfigContent = func(url string) (string, error) {
+			downloadConfigContent = func(_ context.Context, url string) (string, error) {
 				return tt.configContent, nil
 			}
 			defer func() { downloadConfigContent = originalDownload }()
 
-			config, cleanup, err := setupConfig(tt.category)
+			config, cleanup, err := setupConfig(context.Background(), tt.category)
 
 			if tt.wantErr {
 				assert.Error(t, err)
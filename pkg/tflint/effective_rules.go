@@ -0,0 +1,125 @@
+package tflint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Reasons explaining why a rule ended up enabled or disabled
+const (
+	ReasonBaseConfig  = "base_config"
+	ReasonIgnoredRule = "ignored_rule_ids"
+)
+
+// buildEffectiveRules builds a normalized snapshot of which rules and plugins ended up enabled or disabled
+// after applying the ignored rule flags to the category or remote config.
+func buildEffectiveRules(configSource string, configContent []byte, ignoredRules []string) (*EffectiveRuleConfig, error) {
+	config, err := parseRuleConfig(configContent, "base.tflint.hcl")
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make(map[string]RuleConfig)
+	for name, enabled := range config.rules {
+		rules[name] = RuleConfig{Name: name, Enabled: enabled, Reason: ReasonBaseConfig}
+	}
+	for _, name := range ignoredRules {
+		rules[name] = RuleConfig{Name: name, Enabled: false, Reason: ReasonIgnoredRule}
+	}
+
+	result := &EffectiveRuleConfig{
+		ConfigSource: configSource,
+	}
+	for _, rule := range rules {
+		result.Rules = append(result.Rules, rule)
+		if rule.Enabled {
+			result.EnabledCount++
+		} else {
+			result.DisabledCount++
+		}
+	}
+	sort.Slice(result.Rules, func(i, j int) bool {
+		return result.Rules[i].Name < result.Rules[j].Name
+	})
+	for _, plugin := range config.plugins {
+		result.Plugins = append(result.Plugins, plugin)
+	}
+	sort.Slice(result.Plugins, func(i, j int) bool {
+		return result.Plugins[i].Name < result.Plugins[j].Name
+	})
+	return result, nil
+}
+
+type ruleConfig struct {
+	rules   map[string]bool
+	plugins map[string]PluginConfig
+}
+
+// parseRuleConfig extracts `rule` and `plugin` blocks from a TFLint configuration
+func parseRuleConfig(content []byte, filename string) (*ruleConfig, error) {
+	file, diags := hclsyntax.ParseConfig(content, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse TFLint config: %s", diags.Error())
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("unexpected TFLint config body type %T", file.Body)
+	}
+
+	result := &ruleConfig{
+		rules:   make(map[string]bool),
+		plugins: make(map[string]PluginConfig),
+	}
+	for _, block := range body.Blocks {
+		if len(block.Labels) != 1 {
+			continue
+		}
+		name := block.Labels[0]
+		switch block.Type {
+		case "rule":
+			// A rule block without `enabled` keeps the rule enabled
+			result.rules[name] = boolAttribute(block.Body, "enabled", true)
+		case "plugin":
+			result.plugins[name] = PluginConfig{
+				Name:    name,
+				Enabled: boolAttribute(block.Body, "enabled", false),
+				Preset:  stringAttribute(block.Body, "preset"),
+				Source:  stringAttribute(block.Body, "source"),
+				Version: stringAttribute(block.Body, "version"),
+			}
+		}
+	}
+	return result, nil
+}
+
+func boolAttribute(body *hclsyntax.Body, name string, fallback bool) bool {
+	value, ok := literalAttribute(body, name)
+	if !ok || value.Type() != cty.Bool {
+		return fallback
+	}
+	return value.True()
+}
+
+func stringAttribute(body *hclsyntax.Body, name string) string {
+	value, ok := literalAttribute(body, name)
+	if !ok || value.Type() != cty.String {
+		return ""
+	}
+	return value.AsString()
+}
+
+func literalAttribute(body *hclsyntax.Body, name string) (cty.Value, bool) {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return cty.NilVal, false
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsKnown() {
+		return cty.NilVal, false
+	}
+	return value, true
+}
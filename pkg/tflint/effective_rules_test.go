@@ -0,0 +1,52 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBaseConfig = `
+plugin "azurerm" {
+  enabled = true
+  version = "0.27.0"
+  source  = "github.com/terraform-linters/tflint-ruleset-azurerm"
+  preset  = "all"
+}
+
+rule "terraform_comment_syntax" {
+  enabled = true
+}
+
+rule "terraform_documented_outputs" {
+  enabled = false
+}
+
+rule "terraform_naming_convention" {
+  enabled = true
+}
+`
+
+func TestBuildEffectiveRules(t *testing.T) {
+	result, err := buildEffectiveRules("category:reusable", []byte(testBaseConfig), []string{"terraform_comment_syntax"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "category:reusable", result.ConfigSource)
+	assert.Equal(t, []RuleConfig{
+		{Name: "terraform_comment_syntax", Enabled: false, Reason: ReasonIgnoredRule},
+		{Name: "terraform_documented_outputs", Enabled: false, Reason: ReasonBaseConfig},
+		{Name: "terraform_naming_convention", Enabled: true, Reason: ReasonBaseConfig},
+	}, result.Rules)
+	assert.Equal(t, 1, result.EnabledCount)
+	assert.Equal(t, 2, result.DisabledCount)
+	assert.Equal(t, []PluginConfig{
+		{Name: "azurerm", Enabled: true, Preset: "all", Source: "github.com/terraform-linters/tflint-ruleset-azurerm", Version: "0.27.0"},
+	}, result.Plugins)
+}
+
+func TestBuildEffectiveRules_InvalidConfig(t *testing.T) {
+	_, err := buildEffectiveRules("category:reusable", []byte(`rule "x" {`), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse TFLint config")
+}
@@ -8,22 +8,65 @@ import (
 	"time"
 
 	getter "github.com/hashicorp/go-getter/v2"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+	"github.com/spf13/afero"
 )
 
 // RemoteGetter defines interface for fetching remote config sources using go-getter
-// Get should download src to dst (exact file path) with built-in timeout handling.
+// Get should download src to dst (exact file path) with built-in timeout handling, and give up when ctx is done.
 type RemoteGetter interface {
-	Get(dst, src string) error
+	Get(ctx context.Context, dst, src string) error
 }
 
 // remoteConfigGetter is a package-level variable to allow test stubbing. Initialized directly
 // with the production implementation so we don't need an init() function.
-var remoteConfigGetter RemoteGetter = goGetterImpl{}
+var remoteConfigGetter RemoteGetter = cachingGetter{base: goGetterImpl{}}
+
+// configCache holds the downloaded TFLint configs in memory and on disk, keyed by source
+var configCache = cache.Register(newConfigCache())
+
+func newConfigCache() *cache.Cache[string] {
+	return cache.New[string](cache.Options{
+		Name:        "tflint_config",
+		Description: "Downloaded TFLint configurations, keyed by source URL",
+		Backend:     cache.BackendDisk,
+		MaxEntries:  64,
+		TTL:         time.Hour,
+	})
+}
+
+// cachingGetter serves the configs downloaded in the last hour from configCache instead of downloading them again
+type cachingGetter struct {
+	base RemoteGetter
+}
+
+func (g cachingGetter) Get(ctx context.Context, dst, src string) error {
+	content, _, ok := configCache.Get(src)
+	telemetry.RecordCacheLookup("tflint_config", ok)
+	if ok {
+		if err := afero.WriteFile(fs, dst, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write cached config: %w", err)
+		}
+		return nil
+	}
+	if err := g.base.Get(ctx, dst, src); err != nil {
+		return err
+	}
+	// Only single files are cached, the callers report anything else
+	downloaded, err := afero.ReadFile(fs, dst)
+	if err != nil {
+		return nil
+	}
+	// The cache is an optimization, failing to write it must not fail the scan
+	_ = configCache.Put(src, string(downloaded))
+	return nil
+}
 
 // goGetterImpl implements RemoteGetter using go-getter for all remote downloads
 type goGetterImpl struct{}
 
-func (g goGetterImpl) Get(dst, src string) error {
+func (g goGetterImpl) Get(ctx context.Context, dst, src string) error {
 	// Apply timeout with env var override (default 60s, override via TFLINT_REMOTE_CONFIG_TIMEOUT_SECONDS)
 	timeout := 60 * time.Second
 	if v := os.Getenv("TFLINT_REMOTE_CONFIG_TIMEOUT_SECONDS"); v != "" {
@@ -32,7 +75,7 @@ func (g goGetterImpl) Get(dst, src string) error {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	if _, err := getter.GetFile(ctx, dst, src); err != nil {
@@ -1,29 +1,35 @@
 package tflint
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+	"github.com/spf13/afero"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // CommandExecutor interface for executing system commands
 type CommandExecutor interface {
-	ExecuteCommand(dir, command string) (stdout, stderr string, err error)
+	ExecuteCommand(ctx context.Context, dir, command string) (stdout, stderr string, err error)
 }
 
-// RealCommandExecutor implements CommandExecutor using exec.Command
+// RealCommandExecutor implements CommandExecutor using exec.CommandContext, the command is killed when ctx is done
 type RealCommandExecutor struct{}
 
-func (r *RealCommandExecutor) ExecuteCommand(dir, command string) (stdout, stderr string, err error) {
+func (r *RealCommandExecutor) ExecuteCommand(ctx context.Context, dir, command string) (stdout, stderr string, err error) {
 	parts := strings.Fields(command)
 	if len(parts) == 0 {
 		return "", "", fmt.Errorf("empty command")
 	}
 
-	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
 	cmd.Dir = dir
 
 	stdoutBytes, err := cmd.Output()
@@ -59,10 +65,10 @@ func validateTargetDirectory(targetPath string) error {
 }
 
 // executeTFLintInit runs tflint --init in the target directory
-func executeTFLintInit(targetPath, configPath string) (string, error) {
+func executeTFLintInit(ctx context.Context, targetPath, configPath string) (string, error) {
 	command := fmt.Sprintf("tflint --init --config=%s", configPath)
 
-	stdout, stderr, err := commandExecutor.ExecuteCommand(targetPath, command)
+	stdout, stderr, err := commandExecutor.ExecuteCommand(ctx, targetPath, command)
 	if err != nil {
 		return "", fmt.Errorf("tflint init failed: %w, stderr: %s", err, stderr)
 	}
@@ -71,7 +77,7 @@ func executeTFLintInit(targetPath, configPath string) (string, error) {
 }
 
 // executeTFLintScan runs tflint scan in the target directory
-func executeTFLintScan(targetPath, configPath string, ignoredRules []string) (string, error) {
+func executeTFLintScan(ctx context.Context, targetPath, configPath string, ignoredRules []string) (string, error) {
 	command := fmt.Sprintf("tflint --format=json --config=%s", configPath)
 
 	// Add disable-rule flags for ignored rules
@@ -79,7 +85,7 @@ func executeTFLintScan(targetPath, configPath string, ignoredRules []string) (st
 		command += fmt.Sprintf(" --disable-rule=%s", rule)
 	}
 
-	stdout, stderr, err := commandExecutor.ExecuteCommand(targetPath, command)
+	stdout, stderr, err := commandExecutor.ExecuteCommand(ctx, targetPath, command)
 	if err != nil {
 		// TFLint may exit with non-zero status when issues are found, but still provide valid output
 		if stdout != "" {
@@ -229,7 +235,14 @@ func parseScanOutput(jsonOutput string, category string, targetPath string, init
 }
 
 // Scan executes a complete TFLint scan
-func Scan(param ScanParam) (*ScanResult, error) {
+func Scan(ctx context.Context, param ScanParam) (*ScanResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "tflint.scan")
+	result, err := scan(ctx, param)
+	telemetry.EndSpan(span, err)
+	return result, err
+}
+
+func scan(ctx context.Context, param ScanParam) (*ScanResult, error) {
 	// Validate mutual exclusivity between Category and RemoteConfigUrl
 	if param.Category != "" && param.RemoteConfigUrl != "" {
 		return nil, fmt.Errorf("category and remote_config_url are mutually exclusive; set only one")
@@ -249,11 +262,20 @@ func Scan(param ScanParam) (*ScanResult, error) {
 
 	var config *ConfigData
 	var cleanup func()
+	configSource := "category:" + category
+	if param.RemoteConfigUrl != "" {
+		configSource = param.RemoteConfigUrl
+	}
+	logger := logging.FromContext(ctx).With("target", targetPath)
+	logger.InfoContext(ctx, "tflint scan started", "config_source", configSource)
+	_, downloadSpan := telemetry.StartSpan(ctx, "tflint.download_config")
 	if param.RemoteConfigUrl != "" {
-		config, cleanup, err = setupRemoteConfig(param.RemoteConfigUrl)
+		config, cleanup, err = setupRemoteConfig(ctx, param.RemoteConfigUrl)
 	} else {
-		config, cleanup, err = setupConfig(category)
+		config, cleanup, err = setupConfig(ctx, category)
 	}
+	downloadSpan.SetAttributes(attribute.String("tflint.config_source", configSource))
+	telemetry.EndSpan(downloadSpan, err)
 	if cleanup != nil {
 		defer cleanup()
 	}
@@ -261,32 +283,51 @@ func Scan(param ScanParam) (*ScanResult, error) {
 		return nil, fmt.Errorf("failed to setup config: %w", err)
 	}
 
-	// (custom config already merged in setup functions)
+	configContent, err := afero.ReadFile(fs, config.ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	effectiveRules, err := buildEffectiveRules(configSource, configContent, param.IgnoredRules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build effective rule configuration: %w", err)
+	}
 
 	// Initialize TFLint
-	initOutput, err := executeTFLintInit(targetPath, config.ConfigPath)
+	logger.InfoContext(ctx, "installing tflint plugins", "config", config.ConfigPath)
+	initOutput, err := telemetry.TraceCommand(ctx, "tflint.init", targetPath, "tflint --init", func(ctx context.Context) (string, error) {
+		return executeTFLintInit(ctx, targetPath, config.ConfigPath)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize TFLint: %w", err)
 	}
 
 	// Run TFLint scan
-	scanOutput, err := executeTFLintScan(targetPath, config.ConfigPath, param.IgnoredRules)
+	logger.InfoContext(ctx, "running tflint", "ignored_rules", len(param.IgnoredRules))
+	scanOutput, err := telemetry.TraceCommand(ctx, "tflint.run", targetPath, "tflint --format=json", func(ctx context.Context) (string, error) {
+		return executeTFLintScan(ctx, targetPath, config.ConfigPath, param.IgnoredRules)
+	})
 	if err != nil {
+		logger.ErrorContext(ctx, "tflint failed", "error", err)
 		return &ScanResult{
-			Success:    false,
-			Category:   category,
-			TargetPath: targetPath,
-			Issues:     nil,
-			Output:     fmt.Sprintf("Init: %s\nScan Error: %s", initOutput, err.Error()),
-			Summary:    ScanSummary{},
+			Success:        false,
+			Category:       category,
+			TargetPath:     targetPath,
+			Issues:         nil,
+			Output:         fmt.Sprintf("Init: %s\nScan Error: %s", initOutput, err.Error()),
+			Summary:        ScanSummary{},
+			EffectiveRules: effectiveRules,
 		}, err
 	}
 
 	// Parse scan results
 	result, err := parseScanOutput(scanOutput, category, targetPath, initOutput)
+	if result != nil {
+		result.EffectiveRules = effectiveRules
+	}
 	if err != nil {
 		return result, err
 	}
+	logger.InfoContext(ctx, "tflint scan finished", "issues", len(result.Issues))
 
 	return result, nil
 }
@@ -1,6 +1,7 @@
 package tflint
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -103,7 +104,7 @@ func TestExecuteTFLintInit(t *testing.T) {
 			stubs := gostub.Stub(&commandExecutor, mockExecutor)
 			defer stubs.Reset()
 
-			output, err := executeTFLintInit(tt.targetPath, tt.configPath)
+			output, err := executeTFLintInit(context.Background(), tt.targetPath, tt.configPath)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -179,7 +180,7 @@ func TestExecuteTFLintScan(t *testing.T) {
 			stubs := gostub.Stub(&commandExecutor, mockExecutor)
 			defer stubs.Reset()
 
-			output, err := executeTFLintScan(tt.targetPath, tt.configPath, tt.ignoredRules)
+			output, err := executeTFLintScan(context.Background(), tt.targetPath, tt.configPath, tt.ignoredRules)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -300,7 +301,7 @@ type MockCommandResult struct {
 	err    error
 }
 
-func (m *MockCommandExecutor) ExecuteCommand(dir, command string) (string, string, error) {
+func (m *MockCommandExecutor) ExecuteCommand(_ context.Context, dir, command string) (string, string, error) {
 	// First try exact match
 	result, exists := m.commands[command]
 	if exists {
@@ -409,6 +410,12 @@ func TestRunTFLintScan_Integration(t *testing.T) {
 				assert.Equal(t, "example", result.Category)
 				assert.Len(t, result.Issues, 0)
 				assert.Equal(t, 0, result.Summary.TotalIssues)
+				require.NotNil(t, result.EffectiveRules)
+				assert.Equal(t, "category:example", result.EffectiveRules.ConfigSource)
+				assert.Equal(t, []RuleConfig{
+					{Name: "terraform_deprecated_syntax", Enabled: true, Reason: ReasonBaseConfig},
+					{Name: "terraform_unused_declarations", Enabled: false, Reason: ReasonIgnoredRule},
+				}, result.EffectiveRules.Rules)
 			},
 		},
 		{
@@ -449,7 +456,7 @@ func TestRunTFLintScan_Integration(t *testing.T) {
 			defer cmdStubs.Reset()
 
 			// Mock the download function to return test content
-			downloadStubs := gostub.Stub(&downloadConfigContent, func(url string) (string, error) {
+			downloadStubs := gostub.Stub(&downloadConfigContent, func(_ context.Context, url string) (string, error) {
 				return `rule "terraform_deprecated_syntax" { enabled = true }`, nil
 			})
 			defer downloadStubs.Reset()
@@ -477,7 +484,7 @@ func TestRunTFLintScan_Integration(t *testing.T) {
 			defer func() { setupTempConfigDir = originalSetupTempConfigDir }()
 
 			// Run the test
-			result, err := Scan(tt.param)
+			result, err := Scan(context.Background(), tt.param)
 
 			// Verify results
 			tt.expectedResult(t, result, err)
@@ -17,6 +17,8 @@ type ScanResult struct {
 	Issues     []Issue     `json:"issues,omitempty"`
 	Output     string      `json:"output"`
 	Summary    ScanSummary `json:"summary"`
+	// EffectiveRules is the rule configuration the scan actually used
+	EffectiveRules *EffectiveRuleConfig `json:"effective_rules,omitempty"`
 }
 
 // Issue represents a single issue found by TFLint
@@ -53,3 +55,31 @@ type ConfigData struct {
 	TempDir    string `json:"temp_dir"`
 	ConfigPath string `json:"config_path"`
 }
+
+// EffectiveRuleConfig summarizes which rules and plugins ended up enabled or disabled
+// after applying ignored rules to the category/remote config. A custom config file is
+// not reflected here.
+type EffectiveRuleConfig struct {
+	ConfigSource  string         `json:"config_source"`
+	Plugins       []PluginConfig `json:"plugins,omitempty"`
+	Rules         []RuleConfig   `json:"rules,omitempty"`
+	EnabledCount  int            `json:"enabled_count"`
+	DisabledCount int            `json:"disabled_count"`
+}
+
+// PluginConfig represents a plugin block in the effective TFLint configuration.
+// Rules not listed explicitly follow the plugin's preset.
+type PluginConfig struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Preset  string `json:"preset,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// RuleConfig represents an explicitly configured rule and why it ended up in its state
+type RuleConfig struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason"` // base_config or ignored_rule_ids
+}
@@ -1,6 +1,7 @@
 package tflint
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -17,7 +18,7 @@ func TestScanParamCategoryAndRemoteConfigUrlMutualExclusion(t *testing.T) {
 	// Both set should produce an error once validation is added
 	param := ScanParam{Category: "reusable", RemoteConfigUrl: "https://example.com/config.tflint.hcl", TargetPath: "/tmp"}
 	// Expect Scan to return an error about mutual exclusivity
-	_, err := Scan(param)
+	_, err := Scan(context.Background(), param)
 	require.Error(t, err, "expected error when both category and remote_config_url are set")
 	assert.Contains(t, err.Error(), "mutually exclusive")
 }
@@ -62,7 +63,7 @@ func TestScanRemoteConfigSingleFileSuccess(t *testing.T) {
 	defer execStub.Reset()
 
 	param := ScanParam{RemoteConfigUrl: "https://example.com/remote.tflint.hcl", TargetPath: "/test/terraform"}
-	result, err := Scan(param)
+	result, err := Scan(context.Background(), param)
 	require.NoError(t, err)
 	require.NotNil(t, result)
 	assert.True(t, result.Success)
@@ -75,7 +76,7 @@ type mockRemoteGetter struct {
 	createFile func(dst string) error
 }
 
-func (m *mockRemoteGetter) Get(dst, src string) error {
+func (m *mockRemoteGetter) Get(_ context.Context, dst, src string) error {
 	if m.createFile != nil {
 		return m.createFile(dst)
 	}
@@ -102,7 +103,7 @@ func TestScanRemoteConfigDirectoryError(t *testing.T) {
 	defer execStub.Reset()
 
 	// Stub downloadConfigContent to return simple base config (category path will still be used until remote implemented)
-	dlStub := gostub.Stub(&downloadConfigContent, func(url string) (string, error) {
+	dlStub := gostub.Stub(&downloadConfigContent, func(_ context.Context, url string) (string, error) {
 		return `rule "terraform_deprecated_syntax" { enabled = true }`, nil
 	})
 	defer dlStub.Reset()
@@ -122,7 +123,7 @@ func TestScanRemoteConfigDirectoryError(t *testing.T) {
 
 	// Use a remote_config_url pointing to a repo root (directory) which we expect to error once implemented
 	param := ScanParam{RemoteConfigUrl: "git::https://example.com/org/repo.git", TargetPath: "/test/terraform"}
-	_, err := Scan(param)
+	_, err := Scan(context.Background(), param)
 
 	// EXPECTATION (future): error complaining remote_config_url must point to single file.
 	// Currently this will likely NOT error (category fallback) -> red.
@@ -163,7 +164,7 @@ func TestScanRemoteConfigGetterFailure(t *testing.T) {
 	defer getterStub.Reset()
 
 	param := ScanParam{RemoteConfigUrl: "https://example.com/config.tflint.hcl", TargetPath: "/test/terraform"}
-	_, err := Scan(param)
+	_, err := Scan(context.Background(), param)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to fetch remote config")
 }
@@ -194,7 +195,7 @@ func TestScanRemoteConfigTimeoutEnv(t *testing.T) {
 	defer execStub.Reset()
 
 	param := ScanParam{RemoteConfigUrl: "https://example.com/remote.tflint.hcl", TargetPath: "/test/terraform"}
-	result, err := Scan(param)
+	result, err := Scan(context.Background(), param)
 	require.NoError(t, err)
 	require.NotNil(t, result)
 }
@@ -218,7 +219,7 @@ func TestScanRemoteConfigNetworkFailure(t *testing.T) {
 	getterStub := gostub.Stub(&remoteConfigGetter, &mockRemoteGetter{createFile: func(dst string) error { return assert.AnError }})
 	defer getterStub.Reset()
 	param := ScanParam{RemoteConfigUrl: "https://example.com/network", TargetPath: "/test/terraform"}
-	_, err := Scan(param)
+	_, err := Scan(context.Background(), param)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to fetch remote config")
 }
@@ -253,8 +254,30 @@ func TestScanRemoteConfigIgnoredRulesApplied(t *testing.T) {
 	execStub := gostub.Stub(&commandExecutor, mockExecutor)
 	defer execStub.Reset()
 	param := ScanParam{RemoteConfigUrl: "https://example.com/remote.hcl", TargetPath: "/test/terraform", IgnoredRules: []string{"terraform_deprecated_syntax"}}
-	result, err := Scan(param)
+	result, err := Scan(context.Background(), param)
 	require.NoError(t, err)
 	require.NotNil(t, result)
 	assert.True(t, result.Success)
 }
+
+func TestCachingGetter(t *testing.T) {
+	t.Setenv("CACHE_DIR", "off")
+	memFs := afero.NewMemMapFs()
+	downloads := 0
+	stubs := gostub.Stub(&fs, memFs).Stub(&configCache, newConfigCache())
+	defer stubs.Reset()
+	getter := cachingGetter{base: &mockRemoteGetter{createFile: func(dst string) error {
+		downloads++
+		return afero.WriteFile(memFs, dst, []byte(`rule "terraform_required_version" { enabled = true }`), 0644)
+	}}}
+
+	require.NoError(t, getter.Get(context.Background(), "/first/config.hcl", "https://example.com/config.hcl"))
+	require.NoError(t, getter.Get(context.Background(), "/second/config.hcl", "https://example.com/config.hcl"))
+	assert.Equal(t, 1, downloads)
+	content, err := afero.ReadFile(memFs, "/second/config.hcl")
+	require.NoError(t, err)
+	assert.Equal(t, `rule "terraform_required_version" { enabled = true }`, string(content))
+
+	require.NoError(t, getter.Get(context.Background(), "/third/config.hcl", "https://example.com/other.hcl"))
+	assert.Equal(t, 2, downloads)
+}
@@ -23,6 +23,31 @@ func getConfigURL(category string) string {
 	}
 }
 
+// Category is a predefined AVM TFLint configuration, selected with its name in category
+type Category struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ConfigURL   string `json:"config_url"`
+	Default     bool   `json:"default,omitempty"`
+}
+
+// Categories returns the predefined AVM TFLint configurations
+func Categories() []Category {
+	return []Category{
+		{
+			Name:        "reusable",
+			Description: "Rules for reusable Terraform modules",
+			ConfigURL:   getConfigURL("reusable"),
+			Default:     true,
+		},
+		{
+			Name:        "example",
+			Description: "Rules for the example code of Terraform modules, more lenient for code that is deployed as is",
+			ConfigURL:   getConfigURL("example"),
+		},
+	}
+}
+
 // getDefaultTargetPath returns the current working directory if targetPath is empty
 var getDefaultTargetPath = func(targetPath string) (string, error) {
 	if targetPath == "" {
@@ -165,3 +165,13 @@ func TestValidateCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestCategories(t *testing.T) {
+	categories := Categories()
+	assert.Len(t, categories, 2)
+	for _, category := range categories {
+		assert.True(t, validateCategory(category.Name))
+		assert.Equal(t, getConfigURL(category.Name), category.ConfigURL)
+		assert.Equal(t, getDefaultCategory("") == category.Name, category.Default)
+	}
+}
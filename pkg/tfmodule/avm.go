@@ -0,0 +1,219 @@
+package tfmodule
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// AVM module categories, as split by the published module indexes
+const (
+	AVMCategoryResource = "resource"
+	AVMCategoryPattern  = "pattern"
+	AVMCategoryUtility  = "utility"
+)
+
+// AVMCategories lists all AVM module categories
+var AVMCategories = []string{AVMCategoryResource, AVMCategoryPattern, AVMCategoryUtility}
+
+// avmIndexURLs are the Terraform module indexes published by the Azure Verified Modules project, keyed by category.
+// Package-level to allow test stubbing.
+var avmIndexURLs = map[string]string{
+	AVMCategoryResource: "https://raw.githubusercontent.com/Azure/Azure-Verified-Modules/main/docs/static/module-indexes/TerraformResourceModules.csv",
+	AVMCategoryPattern:  "https://raw.githubusercontent.com/Azure/Azure-Verified-Modules/main/docs/static/module-indexes/TerraformPatternModules.csv",
+	AVMCategoryUtility:  "https://raw.githubusercontent.com/Azure/Azure-Verified-Modules/main/docs/static/module-indexes/TerraformUtilityModules.csv",
+}
+
+// avmRegistryPrefix is the public registry URL prefix of module references in the indexes
+const avmRegistryPrefix = "https://registry.terraform.io/modules/"
+
+// AVMModule is an Azure Verified Module listed in the module indexes
+type AVMModule struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+	Category    string `json:"category"`
+	// ResourceType is the ARM resource type a resource module deploys, like `Microsoft.KeyVault/vaults`
+	ResourceType string `json:"resource_type,omitempty"`
+	// Status is the lifecycle status of the module as published, like `Available`, `Proposed` or `Orphaned`
+	Status      string `json:"status"`
+	Source      string `json:"source"`
+	Repository  string `json:"repository,omitempty"`
+	Description string `json:"description,omitempty"`
+	// LatestVersion is looked up in the registry, empty when the module isn't published yet
+	LatestVersion string `json:"latest_version,omitempty"`
+}
+
+// AVMSearchRequest filters the module indexes, all conditions must match
+type AVMSearchRequest struct {
+	// Query is a list of keywords matched case-insensitively against names, resource types and descriptions
+	Query string
+	// ResourceType matches resource types by prefix, like `Microsoft.KeyVault/vaults` or `Microsoft.KeyVault`
+	ResourceType string
+	// Category restricts the search to one category, all categories are searched when empty
+	Category string
+}
+
+// SearchAVMModules searches the Azure Verified Modules indexes, the matching modules are sorted by name. Their latest
+// registry versions are not looked up, see LookupLatestVersions.
+func SearchAVMModules(ctx context.Context, req AVMSearchRequest) ([]AVMModule, error) {
+	categories := AVMCategories
+	if req.Category != "" {
+		if _, ok := avmIndexURLs[req.Category]; !ok {
+			return nil, fmt.Errorf("invalid category %q, must be one of %s", req.Category, strings.Join(AVMCategories, ", "))
+		}
+		categories = []string{req.Category}
+	}
+	modules := []AVMModule{}
+	for _, category := range categories {
+		index, err := loadAVMIndex(ctx, category)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range index {
+			if m.matches(req) {
+				modules = append(modules, m)
+			}
+		}
+	}
+	sort.SliceStable(modules, func(i, j int) bool {
+		return modules[i].Name < modules[j].Name
+	})
+	return modules, nil
+}
+
+// LookupLatestVersions sets the latest registry version of modules, a lookup for each module, so callers look up the
+// modules they return only
+func LookupLatestVersions(ctx context.Context, modules []AVMModule) {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(4)
+	for i := range modules {
+		m := &modules[i]
+		g.Go(func() error {
+			// modules that are not published yet have no version, lookup errors are not fatal either
+			m.LatestVersion, _ = latestVersion(gctx, m.Source)
+			return nil
+		})
+	}
+	_ = g.Wait()
+}
+
+func (m AVMModule) matches(req AVMSearchRequest) bool {
+	if req.ResourceType != "" && !strings.HasPrefix(strings.ToLower(m.ResourceType), strings.ToLower(req.ResourceType)) {
+		return false
+	}
+	text := strings.ToLower(strings.Join([]string{m.Name, m.DisplayName, m.ResourceType, m.Description}, " "))
+	for _, keyword := range strings.Fields(strings.ToLower(req.Query)) {
+		if !strings.Contains(text, keyword) {
+			return false
+		}
+	}
+	return true
+}
+
+// latestVersion returns the latest registry version of a module source
+func latestVersion(ctx context.Context, source string) (string, error) {
+	req, err := ParseModuleSource(source)
+	if err != nil {
+		return "", err
+	}
+	var module struct {
+		Version string `json:"version"`
+	}
+	if err := registryGet(ctx, []string{"v1", "modules", req.Namespace, req.Name, req.Provider}, &module); err != nil {
+		return "", err
+	}
+	return module.Version, nil
+}
+
+// loadAVMIndex downloads and parses the module index of a category. Columns are looked up by header since pattern
+// and utility indexes don't have resource type columns.
+func loadAVMIndex(ctx context.Context, category string) ([]AVMModule, error) {
+	u := avmIndexURLs[category]
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := registryClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download AVM %s module index: %w", category, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download AVM %s module index from %s: status %d", category, u, resp.StatusCode)
+	}
+	return parseAVMIndex(category, resp.Body)
+}
+
+func parseAVMIndex(category string, r io.Reader) ([]AVMModule, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read AVM %s module index: %w", category, err)
+	}
+	// the indexes are saved with a byte order mark, which the CSV reader rejects before a quoted field
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse AVM %s module index: %w", category, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	columns := map[string]int{}
+	for i, h := range records[0] {
+		columns[strings.TrimSpace(h)] = i
+	}
+	if _, ok := columns["ModuleName"]; !ok {
+		return nil, fmt.Errorf("AVM %s module index doesn't have a ModuleName column", category)
+	}
+	var modules []AVMModule
+	for _, record := range records[1:] {
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		name := field("ModuleName")
+		if name == "" {
+			continue
+		}
+		m := AVMModule{
+			Name:        name,
+			DisplayName: field("ModuleDisplayName"),
+			Category:    category,
+			Status:      avmStatus(field("ModuleStatus")),
+			Source:      fmt.Sprintf("Azure/%s/azurerm", name),
+			Repository:  field("RepoURL"),
+			Description: field("Description"),
+		}
+		if ns, rt := field("ProviderNamespace"), field("ResourceType"); ns != "" && rt != "" {
+			m.ResourceType = ns + "/" + rt
+		}
+		if ref := field("PublicRegistryReference"); strings.HasPrefix(ref, avmRegistryPrefix) {
+			parts := strings.Split(strings.TrimPrefix(ref, avmRegistryPrefix), "/")
+			if len(parts) >= 3 {
+				m.Source = strings.Join(parts[:3], "/")
+			}
+		}
+		modules = append(modules, m)
+	}
+	return modules, nil
+}
+
+// avmStatus removes the emoji decorating statuses in the indexes, like `Available 🟢`
+func avmStatus(status string) string {
+	if fields := strings.Fields(status); len(fields) > 0 {
+		return fields[0]
+	}
+	return status
+}
@@ -0,0 +1,112 @@
+package tfmodule
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testResourceIndex = "\ufeff" + `"ProviderNamespace","ResourceType","ModuleDisplayName","ModuleName","ModuleStatus","RepoURL","PublicRegistryReference","Description"
+"Microsoft.KeyVault","vaults","Key Vault","avm-res-keyvault-vault","Available 🟢","https://github.com/Azure/terraform-azurerm-avm-res-keyvault-vault","https://registry.terraform.io/modules/Azure/avm-res-keyvault-vault/azurerm/latest","Deploys a Key Vault"
+"Microsoft.KeyVault","managedHSMs","Key Vault Managed HSM","avm-res-keyvault-managedhsm","Proposed 🆕","","","Deploys a managed HSM"
+"Microsoft.Storage","storageAccounts","Storage Account","avm-res-storage-storageaccount","Available 🟢","","https://registry.terraform.io/modules/Azure/avm-res-storage-storageaccount/azurerm/latest","Deploys a storage account"
+`
+
+const testPatternIndex = `"ModuleDisplayName","ModuleName","ModuleStatus","Description"
+"AKS Production","avm-ptn-aks-production","Available 🟢","Production grade AKS with Key Vault secrets"
+`
+
+func setupAVMIndex(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource.csv", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, testResourceIndex)
+	})
+	mux.HandleFunc("/pattern.csv", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, testPatternIndex)
+	})
+	mux.HandleFunc("/utility.csv", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `"ModuleDisplayName","ModuleName","ModuleStatus"`)
+	})
+	for name, version := range map[string]string{"avm-res-keyvault-vault": "0.10.0", "avm-ptn-aks-production": "0.5.0"} {
+		mux.HandleFunc(fmt.Sprintf("/v1/modules/Azure/%s/azurerm", name), func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, `{"version": %q}`, version)
+		})
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	t.Setenv(RegistryURLEnv, server.URL)
+	stubs := gostub.Stub(&avmIndexURLs, map[string]string{
+		AVMCategoryResource: server.URL + "/resource.csv",
+		AVMCategoryPattern:  server.URL + "/pattern.csv",
+		AVMCategoryUtility:  server.URL + "/utility.csv",
+	})
+	t.Cleanup(stubs.Reset)
+}
+
+func TestSearchAVMModules(t *testing.T) {
+	setupAVMIndex(t)
+
+	tests := []struct {
+		name     string
+		req      AVMSearchRequest
+		expected []string
+	}{
+		{name: "keyword across categories", req: AVMSearchRequest{Query: "key vault"}, expected: []string{"avm-ptn-aks-production", "avm-res-keyvault-managedhsm", "avm-res-keyvault-vault"}},
+		{name: "resource type prefix", req: AVMSearchRequest{ResourceType: "microsoft.keyvault"}, expected: []string{"avm-res-keyvault-managedhsm", "avm-res-keyvault-vault"}},
+		{name: "exact resource type", req: AVMSearchRequest{ResourceType: "Microsoft.Storage/storageAccounts"}, expected: []string{"avm-res-storage-storageaccount"}},
+		{name: "category", req: AVMSearchRequest{Query: "key vault", Category: AVMCategoryPattern}, expected: []string{"avm-ptn-aks-production"}},
+		{name: "no match", req: AVMSearchRequest{Query: "cosmos"}, expected: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modules, err := SearchAVMModules(context.Background(), tt.req)
+			require.NoError(t, err)
+			names := []string{}
+			for _, m := range modules {
+				names = append(names, m.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestSearchAVMModules_Details(t *testing.T) {
+	setupAVMIndex(t)
+
+	modules, err := SearchAVMModules(context.Background(), AVMSearchRequest{ResourceType: "Microsoft.KeyVault"})
+	require.NoError(t, err)
+	LookupLatestVersions(context.Background(), modules)
+	assert.Equal(t, []AVMModule{
+		{
+			Name:         "avm-res-keyvault-managedhsm",
+			DisplayName:  "Key Vault Managed HSM",
+			Category:     AVMCategoryResource,
+			ResourceType: "Microsoft.KeyVault/managedHSMs",
+			Status:       "Proposed",
+			Source:       "Azure/avm-res-keyvault-managedhsm/azurerm",
+			Description:  "Deploys a managed HSM",
+		},
+		{
+			Name:          "avm-res-keyvault-vault",
+			DisplayName:   "Key Vault",
+			Category:      AVMCategoryResource,
+			ResourceType:  "Microsoft.KeyVault/vaults",
+			Status:        "Available",
+			Source:        "Azure/avm-res-keyvault-vault/azurerm",
+			Repository:    "https://github.com/Azure/terraform-azurerm-avm-res-keyvault-vault",
+			Description:   "Deploys a Key Vault",
+			LatestVersion: "0.10.0",
+		},
+	}, modules)
+}
+
+func TestSearchAVMModules_InvalidCategory(t *testing.T) {
+	_, err := SearchAVMModules(context.Background(), AVMSearchRequest{Category: "other"})
+	assert.ErrorContains(t, err, `invalid category "other"`)
+}
@@ -0,0 +1,277 @@
+package tfmodule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// RegistryURLEnv overrides the base URL of the module registry, e.g. to point at a registry mirror
+const RegistryURLEnv = "TFMODULE_REGISTRY_URL"
+
+const defaultRegistryURL = "https://registry.terraform.io"
+
+// registryHostnames are the hostnames accepted in module source addresses
+var registryHostnames = []string{"registry.terraform.io", "registry.opentofu.org"}
+
+var registryClient = &http.Client{Timeout: 30 * time.Second}
+
+// ModuleRequest identifies a registry module, an empty version selects the latest version
+type ModuleRequest struct {
+	Namespace string
+	Name      string
+	// Provider is the target system of the module, like `azurerm` in `Azure/avm-res-keyvault-vault/azurerm`
+	Provider string
+	Version  string
+}
+
+// Source returns the module source address as written in a module block
+func (r ModuleRequest) Source() string {
+	return fmt.Sprintf("%s/%s/%s", r.Namespace, r.Name, r.Provider)
+}
+
+// ParseModuleSource parses a registry module source address like `Azure/avm-res-keyvault-vault/azurerm` or
+// `registry.terraform.io/Azure/avm-res-keyvault-vault/azurerm`. Submodule paths after `//` are ignored.
+func ParseModuleSource(source string) (ModuleRequest, error) {
+	trimmed := strings.TrimSpace(source)
+	if i := strings.Index(trimmed, "//"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	parts := strings.Split(trimmed, "/")
+	if len(parts) == 4 {
+		host := strings.ToLower(parts[0])
+		if !slices.Contains(registryHostnames, host) {
+			return ModuleRequest{}, fmt.Errorf("module source %q uses registry host %s, only %s are supported", source, host, strings.Join(registryHostnames, ", "))
+		}
+		parts = parts[1:]
+	}
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return ModuleRequest{}, fmt.Errorf("invalid module source %q, expected [hostname/]namespace/name/provider", source)
+	}
+	return ModuleRequest{Namespace: parts[0], Name: parts[1], Provider: parts[2]}, nil
+}
+
+// Variable is an input variable of a module
+type Variable struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Default is the default value as an HCL expression, empty when the variable is required
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required"`
+}
+
+// Output is an output value of a module
+type Output struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ProviderDependency is a provider required by a module
+type ProviderDependency struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Source    string `json:"source,omitempty"`
+	Version   string `json:"version,omitempty"`
+}
+
+// Submodule is a nested module published under `modules/`, Source is the address to use in a module block
+type Submodule struct {
+	Path    string     `json:"path"`
+	Source  string     `json:"source"`
+	Inputs  []Variable `json:"inputs,omitempty"`
+	Outputs []Output   `json:"outputs,omitempty"`
+}
+
+// Example is an example configuration published under `examples/`
+type Example struct {
+	Path string `json:"path"`
+}
+
+// ModuleMetadata describes a version of a registry module, what agents need to wire up a module call
+type ModuleMetadata struct {
+	Source      string `json:"source"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+	// Repository is the URL of the source repository of the module
+	Repository  string               `json:"repository,omitempty"`
+	Verified    bool                 `json:"verified,omitempty"`
+	PublishedAt string               `json:"published_at,omitempty"`
+	Inputs      []Variable           `json:"inputs"`
+	Outputs     []Output             `json:"outputs"`
+	Providers   []ProviderDependency `json:"provider_dependencies,omitempty"`
+	Submodules  []Submodule          `json:"submodules,omitempty"`
+	Examples    []Example            `json:"examples,omitempty"`
+	Versions    []string             `json:"versions"`
+}
+
+// registryModule is the module detail document of the registry API
+type registryModule struct {
+	Namespace   string              `json:"namespace"`
+	Name        string              `json:"name"`
+	Provider    string              `json:"provider"`
+	Version     string              `json:"version"`
+	Description string              `json:"description"`
+	Source      string              `json:"source"`
+	PublishedAt string              `json:"published_at"`
+	Verified    bool                `json:"verified"`
+	Root        registrySubmodule   `json:"root"`
+	Submodules  []registrySubmodule `json:"submodules"`
+	Examples    []registrySubmodule `json:"examples"`
+	Versions    []string            `json:"versions"`
+}
+
+type registrySubmodule struct {
+	Path                 string               `json:"path"`
+	Inputs               []registryVariable   `json:"inputs"`
+	Outputs              []Output             `json:"outputs"`
+	ProviderDependencies []ProviderDependency `json:"provider_dependencies"`
+}
+
+// registryVariable is an input of the registry API, the default is usually an HCL expression encoded as a JSON string
+type registryVariable struct {
+	Name        string          `json:"name"`
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Default     json.RawMessage `json:"default"`
+	Required    bool            `json:"required"`
+}
+
+func (v registryVariable) variable() Variable {
+	variable := Variable{Name: v.Name, Type: v.Type, Description: v.Description, Required: v.Required}
+	var expression string
+	switch {
+	case len(v.Default) == 0 || string(v.Default) == "null":
+	case json.Unmarshal(v.Default, &expression) == nil:
+		if expression != "null" {
+			variable.Default = expression
+		}
+	default:
+		variable.Default = string(v.Default)
+	}
+	return variable
+}
+
+// GetModule returns the metadata of a module version from the registry, the latest version when none is requested
+func GetModule(ctx context.Context, req ModuleRequest) (*ModuleMetadata, error) {
+	path := []string{"v1", "modules", req.Namespace, req.Name, req.Provider}
+	if req.Version != "" {
+		path = append(path, req.Version)
+	}
+	var module registryModule
+	if err := registryGet(ctx, path, &module); err != nil {
+		return nil, fmt.Errorf("failed to get module %s: %w", req.Source(), err)
+	}
+
+	source := req.Source()
+	metadata := &ModuleMetadata{
+		Source:      source,
+		Version:     module.Version,
+		Description: module.Description,
+		Repository:  module.Source,
+		Verified:    module.Verified,
+		PublishedAt: module.PublishedAt,
+		Inputs:      sortVariables(module.Root.Inputs),
+		Outputs:     nonNil(module.Root.Outputs),
+		Providers:   module.Root.ProviderDependencies,
+		Versions:    sortVersions(module.Versions),
+	}
+	for _, s := range module.Submodules {
+		metadata.Submodules = append(metadata.Submodules, Submodule{
+			Path:    s.Path,
+			Source:  fmt.Sprintf("%s//%s", source, s.Path),
+			Inputs:  sortVariables(s.Inputs),
+			Outputs: s.Outputs,
+		})
+	}
+	for _, e := range module.Examples {
+		metadata.Examples = append(metadata.Examples, Example{Path: e.Path})
+	}
+	return metadata, nil
+}
+
+// sortVariables converts registry inputs and puts required variables first, keeping the registry order otherwise
+func sortVariables(inputs []registryVariable) []Variable {
+	sorted := make([]Variable, 0, len(inputs))
+	for _, input := range inputs {
+		sorted = append(sorted, input.variable())
+	}
+	requiredFirst(sorted)
+	return sorted
+}
+
+// requiredFirst puts required variables first, keeping the order otherwise
+func requiredFirst(variables []Variable) {
+	sort.SliceStable(variables, func(i, j int) bool {
+		return variables[i].Required && !variables[j].Required
+	})
+}
+
+// sortVersions sorts versions newest first, invalid versions are dropped
+func sortVersions(versions []string) []string {
+	var collection goversion.Collection
+	for _, v := range versions {
+		if parsed, err := goversion.NewVersion(v); err == nil {
+			collection = append(collection, parsed)
+		}
+	}
+	sort.Sort(sort.Reverse(collection))
+	sorted := make([]string, 0, len(collection))
+	for _, v := range collection {
+		sorted = append(sorted, v.Original())
+	}
+	return sorted
+}
+
+func registryURL() string {
+	if u := os.Getenv(RegistryURLEnv); u != "" {
+		return strings.TrimRight(u, "/")
+	}
+	return defaultRegistryURL
+}
+
+// registryGet fetches a JSON document of the registry API
+func registryGet(ctx context.Context, path []string, result any) error {
+	escaped := make([]string, 0, len(path))
+	for _, p := range path {
+		escaped = append(escaped, url.PathEscape(p))
+	}
+	u := registryURL() + "/" + strings.Join(escaped, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := registryClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", u, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("not found in the registry (%s)", u)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s failed with status %d", u, resp.StatusCode)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", u, err)
+	}
+	return nil
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -0,0 +1,117 @@
+package tfmodule
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testModuleResponse = `{
+  "id": "Azure/avm-res-keyvault-vault/azurerm/0.10.0",
+  "namespace": "Azure",
+  "name": "avm-res-keyvault-vault",
+  "provider": "azurerm",
+  "version": "0.10.0",
+  "description": "Key Vault module",
+  "source": "https://github.com/Azure/terraform-azurerm-avm-res-keyvault-vault",
+  "published_at": "2025-01-01T00:00:00Z",
+  "verified": true,
+  "root": {
+    "path": "",
+    "inputs": [
+      {"name": "tags", "type": "map(string)", "description": "Tags.", "default": "null", "required": false},
+      {"name": "sku_name", "type": "string", "default": "\"premium\"", "required": false},
+      {"name": "name", "type": "string", "description": "The name.", "default": "", "required": true}
+    ],
+    "outputs": [{"name": "resource_id", "description": "The ID."}],
+    "provider_dependencies": [{"name": "azurerm", "namespace": "hashicorp", "source": "hashicorp/azurerm", "version": "~> 4.0"}]
+  },
+  "submodules": [
+    {"path": "modules/secret", "inputs": [{"name": "value", "type": "string", "required": true}], "outputs": []}
+  ],
+  "examples": [{"path": "examples/default"}],
+  "versions": ["0.9.0", "0.10.0", "0.2.1"]
+}`
+
+// setupRegistry serves the test module for its latest version and 0.10.0, other modules are not found
+func setupRegistry(t *testing.T) {
+	mux := http.NewServeMux()
+	for _, path := range []string{"/v1/modules/Azure/avm-res-keyvault-vault/azurerm", "/v1/modules/Azure/avm-res-keyvault-vault/azurerm/0.10.0"} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprint(w, testModuleResponse)
+		})
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	t.Setenv(RegistryURLEnv, server.URL)
+}
+
+func TestParseModuleSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected ModuleRequest
+		err      string
+	}{
+		{name: "short", source: "Azure/avm-res-keyvault-vault/azurerm", expected: ModuleRequest{Namespace: "Azure", Name: "avm-res-keyvault-vault", Provider: "azurerm"}},
+		{name: "with host", source: "registry.terraform.io/Azure/avm-res-keyvault-vault/azurerm", expected: ModuleRequest{Namespace: "Azure", Name: "avm-res-keyvault-vault", Provider: "azurerm"}},
+		{name: "submodule", source: "Azure/avm-res-keyvault-vault/azurerm//modules/secret", expected: ModuleRequest{Namespace: "Azure", Name: "avm-res-keyvault-vault", Provider: "azurerm"}},
+		{name: "unsupported host", source: "example.com/Azure/avm-res-keyvault-vault/azurerm", err: "only registry.terraform.io, registry.opentofu.org are supported"},
+		{name: "invalid", source: "Azure/avm-res-keyvault-vault", err: "expected [hostname/]namespace/name/provider"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := ParseModuleSource(tt.source)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, req)
+		})
+	}
+}
+
+func TestGetModule(t *testing.T) {
+	setupRegistry(t)
+
+	for _, version := range []string{"", "0.10.0"} {
+		module, err := GetModule(context.Background(), ModuleRequest{Namespace: "Azure", Name: "avm-res-keyvault-vault", Provider: "azurerm", Version: version})
+		require.NoError(t, err)
+		assert.Equal(t, &ModuleMetadata{
+			Source:      "Azure/avm-res-keyvault-vault/azurerm",
+			Version:     "0.10.0",
+			Description: "Key Vault module",
+			Repository:  "https://github.com/Azure/terraform-azurerm-avm-res-keyvault-vault",
+			Verified:    true,
+			PublishedAt: "2025-01-01T00:00:00Z",
+			Inputs: []Variable{
+				{Name: "name", Type: "string", Description: "The name.", Required: true},
+				{Name: "tags", Type: "map(string)", Description: "Tags."},
+				{Name: "sku_name", Type: "string", Default: `"premium"`},
+			},
+			Outputs:   []Output{{Name: "resource_id", Description: "The ID."}},
+			Providers: []ProviderDependency{{Name: "azurerm", Namespace: "hashicorp", Source: "hashicorp/azurerm", Version: "~> 4.0"}},
+			Submodules: []Submodule{{
+				Path:    "modules/secret",
+				Source:  "Azure/avm-res-keyvault-vault/azurerm//modules/secret",
+				Inputs:  []Variable{{Name: "value", Type: "string", Required: true}},
+				Outputs: []Output{},
+			}},
+			Examples: []Example{{Path: "examples/default"}},
+			Versions: []string{"0.10.0", "0.9.0", "0.2.1"},
+		}, module)
+	}
+}
+
+func TestGetModule_NotFound(t *testing.T) {
+	setupRegistry(t)
+
+	_, err := GetModule(context.Background(), ModuleRequest{Namespace: "Azure", Name: "missing", Provider: "azurerm"})
+	assert.ErrorContains(t, err, "failed to get module Azure/missing/azurerm: not found in the registry")
+}
@@ -0,0 +1,154 @@
+package tfmodule
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/spf13/afero"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var fs = afero.NewOsFs()
+
+// tfvarsPlaceholder is the value of required string variables in generated stubs
+const tfvarsPlaceholder = "REPLACE_ME"
+
+// LocalVariables reads the variable blocks declared in the `.tf` files of a module directory, required variables first.
+// Type and default are kept as written.
+func LocalVariables(dir string) ([]Variable, error) {
+	files, err := afero.Glob(fs, filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Terraform files in %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Terraform files found in %s", dir)
+	}
+	sort.Strings(files)
+
+	var variables []Variable
+	for _, file := range files {
+		content, err := afero.ReadFile(fs, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		parsed, diags := hclsyntax.ParseConfig(content, file, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("failed to parse %s: %s", file, diags.Error())
+		}
+		body, ok := parsed.Body.(*hclsyntax.Body)
+		if !ok {
+			return nil, fmt.Errorf("unexpected body type %T of %s", parsed.Body, file)
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "variable" || len(block.Labels) != 1 {
+				continue
+			}
+			variables = append(variables, localVariable(block, content))
+		}
+	}
+	requiredFirst(variables)
+	return variables, nil
+}
+
+func localVariable(block *hclsyntax.Block, content []byte) Variable {
+	variable := Variable{Name: block.Labels[0], Required: true}
+	if attr, ok := block.Body.Attributes["type"]; ok {
+		variable.Type = expressionSource(attr.Expr, content)
+	}
+	if attr, ok := block.Body.Attributes["description"]; ok {
+		if value, diags := attr.Expr.Value(nil); !diags.HasErrors() && value.Type() == cty.String && value.IsKnown() && !value.IsNull() {
+			variable.Description = value.AsString()
+		}
+	}
+	if attr, ok := block.Body.Attributes["default"]; ok {
+		variable.Required = false
+		if source := expressionSource(attr.Expr, content); source != "null" {
+			variable.Default = source
+		}
+	}
+	return variable
+}
+
+func expressionSource(expr hclsyntax.Expression, content []byte) string {
+	return strings.TrimSpace(string(expr.Range().SliceBytes(content)))
+}
+
+// GenerateTfvars renders a terraform.tfvars stub: required variables are set to a placeholder matching their type,
+// optional variables are commented out with their default. Descriptions and types are written as comments.
+func GenerateTfvars(variables []Variable) string {
+	var required, optional []Variable
+	for _, v := range variables {
+		if v.Required {
+			required = append(required, v)
+		} else {
+			optional = append(optional, v)
+		}
+	}
+
+	var sb strings.Builder
+	section := func(title string, variables []Variable, commented bool) {
+		if len(variables) == 0 {
+			return
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("# %s\n", title))
+		for _, v := range variables {
+			sb.WriteString("\n")
+			writeComment(&sb, "", v.Description)
+			if v.Type != "" {
+				writeComment(&sb, "type: ", v.Type)
+			}
+			if !commented {
+				sb.WriteString(fmt.Sprintf("%s = %s\n", v.Name, tfvarsPlaceholderValue(v.Type)))
+				continue
+			}
+			value := v.Default
+			if value == "" {
+				value = "null"
+			}
+			writeComment(&sb, "", fmt.Sprintf("%s = %s", v.Name, value))
+		}
+	}
+	section("Required variables", required, false)
+	section("Optional variables, uncomment to override the default", optional, true)
+	return string(hclwrite.Format([]byte(sb.String())))
+}
+
+// writeComment writes text as comment lines, prefix is written before the first line only
+func writeComment(sb *strings.Builder, prefix, text string) {
+	if text == "" {
+		return
+	}
+	for i, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if i == 0 {
+			line = prefix + line
+		}
+		sb.WriteString(strings.TrimRight("# "+line, " "))
+		sb.WriteString("\n")
+	}
+}
+
+// tfvarsPlaceholderValue returns a placeholder value for a type constraint, null when the type is unknown
+func tfvarsPlaceholderValue(typeExpr string) string {
+	t := strings.Join(strings.Fields(typeExpr), "")
+	switch {
+	case t == "string":
+		return fmt.Sprintf("%q", tfvarsPlaceholder)
+	case t == "number":
+		return "0"
+	case t == "bool":
+		return "false"
+	case strings.HasPrefix(t, "list("), strings.HasPrefix(t, "set("), strings.HasPrefix(t, "tuple("):
+		return "[]"
+	case strings.HasPrefix(t, "map("), strings.HasPrefix(t, "object("):
+		return "{}"
+	}
+	return "null"
+}
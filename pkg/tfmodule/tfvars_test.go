@@ -0,0 +1,108 @@
+package tfmodule
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalVariables(t *testing.T) {
+	memFs := afero.NewMemMapFs()
+	stubs := gostub.Stub(&fs, memFs)
+	defer stubs.Reset()
+	require.NoError(t, afero.WriteFile(memFs, "/module/variables.tf", []byte(`
+variable "tags" {
+  type        = map(string)
+  description = "Tags of the resource."
+  default     = null
+}
+
+variable "name" {
+  type        = string
+  description = "The name."
+}
+
+variable "network_acls" {
+  type = object({
+    bypass = optional(string, "AzureServices")
+  })
+  default = {
+    bypass = "None"
+  }
+}
+`), 0644))
+	require.NoError(t, afero.WriteFile(memFs, "/module/main.tf", []byte(`
+variable "location" {}
+
+resource "azurerm_resource_group" "this" {
+  name     = var.name
+  location = var.location
+}
+`), 0644))
+
+	variables, err := LocalVariables("/module")
+	require.NoError(t, err)
+	assert.Equal(t, []Variable{
+		{Name: "location", Required: true},
+		{Name: "name", Type: "string", Description: "The name.", Required: true},
+		{Name: "tags", Type: "map(string)", Description: "Tags of the resource."},
+		{Name: "network_acls", Type: "object({\n    bypass = optional(string, \"AzureServices\")\n  })", Default: "{\n    bypass = \"None\"\n  }"},
+	}, variables)
+}
+
+func TestLocalVariables_NoFiles(t *testing.T) {
+	stubs := gostub.Stub(&fs, afero.NewMemMapFs())
+	defer stubs.Reset()
+
+	_, err := LocalVariables("/module")
+	assert.ErrorContains(t, err, "no Terraform files found in /module")
+}
+
+func TestGenerateTfvars(t *testing.T) {
+	stub := GenerateTfvars([]Variable{
+		{Name: "name", Type: "string", Description: "The name.", Required: true},
+		{Name: "instance_count", Type: "number", Required: true},
+		{Name: "subnet_ids", Type: "list(string)", Required: true},
+		{Name: "settings", Type: "object({\n  size = number\n})", Description: "Settings.\nSecond line.", Required: true},
+		{Name: "anything", Required: true},
+		{Name: "enabled", Type: "bool", Default: "true"},
+		{Name: "tags", Type: "map(string)"},
+	})
+	assert.Equal(t, `# Required variables
+
+# The name.
+# type: string
+name = "REPLACE_ME"
+
+# type: number
+instance_count = 0
+
+# type: list(string)
+subnet_ids = []
+
+# Settings.
+# Second line.
+# type: object({
+#   size = number
+# })
+settings = {}
+
+anything = null
+
+# Optional variables, uncomment to override the default
+
+# type: bool
+# enabled = true
+
+# type: map(string)
+# tags = null
+`, stub)
+}
+
+func TestGenerateTfvars_OptionalOnly(t *testing.T) {
+	stub := GenerateTfvars([]Variable{{Name: "enabled", Default: "true"}})
+	assert.Equal(t, "# Optional variables, uncomment to override the default\n\n# enabled = true\n", stub)
+}
@@ -0,0 +1,83 @@
+package tfmodule
+
+import (
+	"context"
+	"fmt"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// VersionResolution is the module version Terraform selects for a version constraint
+type VersionResolution struct {
+	Source     string `json:"source"`
+	Constraint string `json:"constraint,omitempty"`
+	// Selected is the newest version matching the constraint, empty when no version matches
+	Selected string `json:"selected,omitempty"`
+	Latest   string `json:"latest,omitempty"`
+	// Newer lists the available versions newer than the selected version, newest first, all versions when none is selected
+	Newer []string `json:"newer"`
+}
+
+// registryVersions is the module versions document of the registry API
+type registryVersions struct {
+	Modules []struct {
+		Versions []struct {
+			Version string `json:"version"`
+		} `json:"versions"`
+	} `json:"modules"`
+}
+
+// ResolveModuleVersion resolves the version constraint in req.Version the way `terraform init` does: the newest
+// version matching the constraint is selected, pre-releases only match constraints naming a pre-release. An empty
+// constraint selects the latest release.
+func ResolveModuleVersion(ctx context.Context, req ModuleRequest) (*VersionResolution, error) {
+	var constraints goversion.Constraints
+	if req.Version != "" {
+		c, err := goversion.NewConstraint(req.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid module version constraint %q: %w", req.Version, err)
+		}
+		constraints = c
+	}
+	versions, err := ModuleVersions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no available versions found for module %s", req.Source())
+	}
+
+	resolution := &VersionResolution{Source: req.Source(), Constraint: req.Version, Newer: []string{}}
+	for _, v := range versions {
+		parsed, _ := goversion.NewVersion(v)
+		release := parsed.Prerelease() == ""
+		if resolution.Latest == "" && release {
+			resolution.Latest = v
+		}
+		if resolution.Selected == "" && (constraints == nil && release || constraints != nil && constraints.Check(parsed)) {
+			resolution.Selected = v
+		}
+	}
+	for _, v := range versions {
+		if v == resolution.Selected {
+			break
+		}
+		resolution.Newer = append(resolution.Newer, v)
+	}
+	return resolution, nil
+}
+
+// ModuleVersions returns the available versions of a module, newest first
+func ModuleVersions(ctx context.Context, req ModuleRequest) ([]string, error) {
+	var result registryVersions
+	if err := registryGet(ctx, []string{"v1", "modules", req.Namespace, req.Name, req.Provider, "versions"}, &result); err != nil {
+		return nil, fmt.Errorf("failed to get versions of module %s: %w", req.Source(), err)
+	}
+	var versions []string
+	for _, m := range result.Modules {
+		for _, v := range m.Versions {
+			versions = append(versions, v.Version)
+		}
+	}
+	return sortVersions(versions), nil
+}
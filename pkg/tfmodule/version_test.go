@@ -0,0 +1,64 @@
+package tfmodule
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupVersions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/modules/Azure/avm-res-keyvault-vault/azurerm/versions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"modules": [{"source": "Azure/avm-res-keyvault-vault/azurerm", "versions": [
+			{"version": "0.9.0"}, {"version": "1.0.0"}, {"version": "0.10.1"}, {"version": "1.1.0-beta1"}, {"version": "0.10.0"}, {"version": "invalid"}
+		]}]}`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	t.Setenv(RegistryURLEnv, server.URL)
+}
+
+func TestResolveModuleVersion(t *testing.T) {
+	setupVersions(t)
+
+	tests := []struct {
+		constraint string
+		selected   string
+		newer      []string
+	}{
+		{constraint: "", selected: "1.0.0", newer: []string{"1.1.0-beta1"}},
+		{constraint: "~> 0.10", selected: "0.10.1", newer: []string{"1.1.0-beta1", "1.0.0"}},
+		{constraint: "~> 0.10.0", selected: "0.10.1", newer: []string{"1.1.0-beta1", "1.0.0"}},
+		{constraint: "0.9.0", selected: "0.9.0", newer: []string{"1.1.0-beta1", "1.0.0", "0.10.1", "0.10.0"}},
+		{constraint: ">= 0.9.0, < 0.10.0", selected: "0.9.0", newer: []string{"1.1.0-beta1", "1.0.0", "0.10.1", "0.10.0"}},
+		{constraint: "1.1.0-beta1", selected: "1.1.0-beta1", newer: []string{}},
+		{constraint: "> 2.0", newer: []string{"1.1.0-beta1", "1.0.0", "0.10.1", "0.10.0", "0.9.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			resolution, err := ResolveModuleVersion(context.Background(), ModuleRequest{Namespace: "Azure", Name: "avm-res-keyvault-vault", Provider: "azurerm", Version: tt.constraint})
+			require.NoError(t, err)
+			assert.Equal(t, "Azure/avm-res-keyvault-vault/azurerm", resolution.Source)
+			assert.Equal(t, "1.0.0", resolution.Latest)
+			assert.Equal(t, tt.selected, resolution.Selected)
+			assert.Equal(t, tt.newer, resolution.Newer)
+		})
+	}
+}
+
+func TestResolveModuleVersion_InvalidConstraint(t *testing.T) {
+	_, err := ResolveModuleVersion(context.Background(), ModuleRequest{Namespace: "Azure", Name: "avm-res-keyvault-vault", Provider: "azurerm", Version: "latest"})
+	assert.ErrorContains(t, err, `invalid module version constraint "latest"`)
+}
+
+func TestResolveModuleVersion_NotFound(t *testing.T) {
+	setupVersions(t)
+
+	_, err := ResolveModuleVersion(context.Background(), ModuleRequest{Namespace: "Azure", Name: "missing", Provider: "azurerm"})
+	assert.ErrorContains(t, err, "failed to get versions of module Azure/missing/azurerm: not found in the registry")
+}
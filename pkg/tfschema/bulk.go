@@ -0,0 +1,60 @@
+package tfschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SchemaQuery is one item of a bulk schema query
+type SchemaQuery struct {
+	Category string `json:"category"`
+	Type     string `json:"type,omitempty"`
+	Path     string `json:"path,omitempty"`
+}
+
+// Key identifies the query in bulk results, formatted as `category:type` or `category:type:path`
+func (q SchemaQuery) Key() string {
+	key := q.Category + ":" + q.Type
+	if q.Path != "" {
+		key += ":" + q.Path
+	}
+	return key
+}
+
+// BulkQueryResult holds either the schema or the error of one query, so a failed query doesn't fail the others
+type BulkQueryResult struct {
+	Schema json.RawMessage `json:"schema,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// QuerySchemas runs several schema queries against one provider, the provider version is resolved once
+// so all results come from the same version. Results are keyed by SchemaQuery.Key.
+func QuerySchemas(queries []SchemaQuery, providerReq ProviderRequest, opts QueryOptions) (map[string]BulkQueryResult, error) {
+	if len(queries) == 0 {
+		return nil, errors.New("at least one query is required")
+	}
+	resolved, err := ResolveVersion(providerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]BulkQueryResult, len(queries))
+	for _, q := range queries {
+		schema, err := QuerySchemaWithOptions(q.Category, q.Type, q.Path, resolved, opts)
+		if err != nil {
+			results[q.Key()] = BulkQueryResult{Error: fmt.Sprintf("failed to query schema for %s %s: %s", q.Category, q.Type, err.Error())}
+			continue
+		}
+		if opts.Format == FormatMarkdown {
+			// Markdown documents are embedded as JSON strings
+			content, err := json.Marshal(schema)
+			if err != nil {
+				return nil, err
+			}
+			schema = string(content)
+		}
+		results[q.Key()] = BulkQueryResult{Schema: json.RawMessage(schema)}
+	}
+	return results, nil
+}
@@ -0,0 +1,59 @@
+package tfschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuerySchemas(t *testing.T) {
+	stubSchemaSource(t)
+
+	results, err := QuerySchemas([]SchemaQuery{
+		{Category: "resource", Type: "azurerm_resource_group", Path: "name"},
+		{Category: "data", Type: "azurerm_client_config"},
+		{Category: "resource", Type: "azurerm_not_exist"},
+		{Category: "provider", Path: "features"},
+	}, cacheTestReq, QueryOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, results, 4)
+	assert.JSONEq(t, `{"type":"string","required":true}`, string(results["resource:azurerm_resource_group:name"].Schema))
+	assert.Empty(t, results["resource:azurerm_resource_group:name"].Error)
+	assert.NotEmpty(t, results["data:azurerm_client_config"].Schema)
+	assert.Contains(t, results["resource:azurerm_not_exist"].Error, "resource schema not found")
+	assert.Empty(t, results["resource:azurerm_not_exist"].Schema)
+	assert.NotEmpty(t, results["provider::features"].Schema)
+
+	content, err := json.Marshal(results["resource:azurerm_not_exist"])
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), `"schema":`)
+}
+
+func TestQuerySchemas_ResolvesVersionOnce(t *testing.T) {
+	stubSchemaSource(t)
+	calls := 0
+	stubs := gostub.Stub(&availableVersions, func(ProviderRequest) (goversion.Collection, error) {
+		calls++
+		return goversion.Collection{goversion.Must(goversion.NewVersion("4.39.0"))}, nil
+	})
+	defer stubs.Reset()
+
+	req := cacheTestReq
+	req.ProviderVersion = "~> 4.0"
+	_, err := QuerySchemas([]SchemaQuery{
+		{Category: "resource", Type: "azurerm_resource_group"},
+		{Category: "data", Type: "azurerm_client_config"},
+	}, req, QueryOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestQuerySchemas_Empty(t *testing.T) {
+	_, err := QuerySchemas(nil, cacheTestReq, QueryOptions{})
+	assert.ErrorContains(t, err, "at least one query is required")
+}
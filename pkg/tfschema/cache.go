@@ -0,0 +1,277 @@
+package tfschema
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	goversion "github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+	"github.com/matt-FFFFFF/tfpluginschema"
+	"github.com/spf13/afero"
+	"golang.org/x/sync/singleflight"
+)
+
+// Environment variables of the provider schema cache, kept along the generic `CACHE_TFSCHEMA_*` variables
+const (
+	// SchemaCacheDirEnv overrides the parent directory of the on-disk provider schema cache, which is its `schemas`
+	// sub-directory, set it to `off` to disable the disk cache
+	SchemaCacheDirEnv = "TFSCHEMA_CACHE_DIR"
+	// MemoryCacheEntriesEnv is the maximum number of provider versions kept in memory, defaults to 8
+	MemoryCacheEntriesEnv = "TFSCHEMA_MEMORY_CACHE_ENTRIES"
+	// MemoryCacheMBEnv is the memory budget of the cache in megabytes, approximated by the serialized size of the
+	// schemas, defaults to 0 which means unlimited
+	MemoryCacheMBEnv = "TFSCHEMA_MEMORY_CACHE_MB"
+)
+
+const defaultMemoryCacheEntries = 8
+
+var fs = afero.NewOsFs()
+
+// schemaCache holds the provider schemas used by this process in memory and on disk, keyed by schemaCacheKey. Evicted
+// schemas are still on disk, so reloading them doesn't download the provider again.
+var schemaCache = cache.Register(newSchemaCache())
+
+func newSchemaCache() *cache.Cache[*tfjson.ProviderSchema] {
+	return cache.New[*tfjson.ProviderSchema](cache.Options{
+		Name:          "tfschema",
+		Description:   "Provider schemas, keyed by provider and resolved version",
+		Backend:       cache.BackendDisk,
+		MaxEntries:    defaultMemoryCacheEntries,
+		DirName:       "schemas",
+		Path:          func(key string) string { return key + ".json" },
+		DirEnv:        SchemaCacheDirEnv,
+		MaxEntriesEnv: MemoryCacheEntriesEnv,
+		MaxMBEnv:      MemoryCacheMBEnv,
+	})
+}
+
+// schemaLoads coalesces concurrent loads of the same provider version
+var schemaLoads singleflight.Group
+
+// versionsCache holds the versions available for each provider for a few minutes, so resolving unpinned versions and
+// version constraints against a warm schema cache doesn't cost a registry round-trip every time
+var versionsCache = cache.Register(newVersionsCache())
+
+func newVersionsCache() *cache.Cache[[]string] {
+	return cache.New[[]string](cache.Options{
+		Name:        "tfschema_versions",
+		Description: "Versions available for each provider, keyed by version source and provider",
+		Backend:     cache.BackendMemory,
+		MaxEntries:  256,
+		TTL:         5 * time.Minute,
+	})
+}
+
+// availableVersions returns the sorted versions available for a provider, from the provider mirror in offline mode,
+// the private registry when configured, or the public registry otherwise, package-level to allow test stubbing
+var availableVersions = func(providerReq ProviderRequest) (goversion.Collection, error) {
+	if mirrorDir := providerMirrorDir(); mirrorDir != "" {
+		return mirrorVersions(mirrorDir, providerReq)
+	}
+	if host := registryHost(); host != "" {
+		return privateRegistryVersions(host, providerReq)
+	}
+	return getServer().GetAvailableVersions(tfpluginschema.VersionsRequest{
+		Namespace: providerReq.ProviderNamespace,
+		Name:      providerReq.ProviderName,
+	})
+}
+
+// fetchProviderSchema reads the full provider schema, from the provider mirror in offline mode or by downloading
+// the provider from the private or public registry otherwise, package-level to allow test stubbing. The load is shared
+// by concurrent callers through schemaLoads, so it isn't bound to the context of one of them.
+var fetchProviderSchema = func(providerReq ProviderRequest) (*tfjson.ProviderSchema, error) {
+	ctx := context.Background()
+	if mirrorDir := providerMirrorDir(); mirrorDir != "" {
+		return mirrorProviderSchema(ctx, mirrorDir, providerReq)
+	}
+	if host := registryHost(); host != "" {
+		return privateRegistryProviderSchema(ctx, host, providerReq)
+	}
+	return downloadProviderSchema(providerReq)
+}
+
+// providerSchema returns the full schema of the requested provider. Schemas are looked up in memory first, then in the
+// on-disk cache, and only downloaded from the registry when neither has them, so restarts don't re-download providers.
+// When the download fails the embedded schema of the same version is served, if any.
+func providerSchema(providerReq ProviderRequest) (*tfjson.ProviderSchema, error) {
+	resolved, err := ResolveVersion(providerReq)
+	if err != nil {
+		return nil, err
+	}
+	key := schemaCacheKey(resolved)
+	cached, source, ok := schemaCache.Get(key)
+	telemetry.RecordCacheLookup("tfschema_memory", source == cache.SourceMemory)
+	if source != cache.SourceMemory {
+		telemetry.RecordCacheLookup("tfschema_disk", source == cache.SourceDisk)
+	}
+	if ok {
+		return cached, nil
+	}
+
+	// Concurrent sessions querying the same provider version share a single load
+	v, err, _ := schemaLoads.Do(key, func() (any, error) {
+		return loadProviderSchema(key, resolved)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*tfjson.ProviderSchema), nil
+}
+
+// loadProviderSchema loads the schema of a resolved provider request from the registry into the cache, unless a
+// concurrent load cached it already
+func loadProviderSchema(key string, resolved ProviderRequest) (*tfjson.ProviderSchema, error) {
+	if cached, _, ok := schemaCache.Get(key); ok {
+		return cached, nil
+	}
+	schema, err := fetchProviderSchema(resolved)
+	if err != nil {
+		embedded, ok := embeddedSchema(resolved)
+		if !ok {
+			return nil, err
+		}
+		schema = embedded
+	}
+	// The disk cache is an optimization, failing to write it must not fail the query
+	_ = schemaCache.Put(key, schema)
+	return schema, nil
+}
+
+// ResolveVersion pins the provider version, resolving an empty version or a version constraint to the latest matching version.
+// Tools use it to report the concrete version their results were generated from.
+func ResolveVersion(providerReq ProviderRequest) (ProviderRequest, error) {
+	if _, err := goversion.NewVersion(providerReq.ProviderVersion); err == nil {
+		return providerReq, nil
+	}
+
+	var constraints goversion.Constraints
+	if providerReq.ProviderVersion != "" {
+		c, err := goversion.NewConstraint(providerReq.ProviderVersion)
+		if err != nil {
+			return ProviderRequest{}, fmt.Errorf("invalid provider version constraint %q: %w", providerReq.ProviderVersion, err)
+		}
+		constraints = c
+	}
+	versions, err := providerVersions(providerReq)
+	if err != nil || len(versions) == 0 {
+		// Fall back to the embedded schema when the registry or the offline mirror can't serve the provider
+		if v, ok := embeddedVersion(providerReq, constraints); ok {
+			providerReq.ProviderVersion = v.String()
+			return providerReq, nil
+		}
+	}
+	if err != nil {
+		return ProviderRequest{}, fmt.Errorf("failed to get available versions of %s/%s: %w", providerReq.ProviderNamespace, providerReq.ProviderName, err)
+	}
+	if len(versions) == 0 {
+		return ProviderRequest{}, fmt.Errorf("no available versions found for provider: %s/%s", providerReq.ProviderNamespace, providerReq.ProviderName)
+	}
+	latest, err := tfpluginschema.GetLatestVersionMatch(versions, constraints)
+	if err != nil {
+		return ProviderRequest{}, fmt.Errorf("failed to resolve version %q of %s/%s: %w", providerReq.ProviderVersion, providerReq.ProviderNamespace, providerReq.ProviderName, err)
+	}
+	providerReq.ProviderVersion = latest.String()
+	return providerReq, nil
+}
+
+// providerVersions returns the versions available for a provider from versionsCache, listing them with
+// availableVersions on a miss. Failures and empty lists aren't cached, so the embedded fallback isn't pinned.
+func providerVersions(providerReq ProviderRequest) (goversion.Collection, error) {
+	key := versionsCacheKey(providerReq)
+	cached, _, ok := versionsCache.Get(key)
+	telemetry.RecordCacheLookup("tfschema_versions", ok)
+	if ok {
+		versions := make(goversion.Collection, 0, len(cached))
+		for _, v := range cached {
+			if version, err := goversion.NewVersion(v); err == nil {
+				versions = append(versions, version)
+			}
+		}
+		return versions, nil
+	}
+	versions, err := availableVersions(providerReq)
+	if err != nil || len(versions) == 0 {
+		return versions, err
+	}
+	list := make([]string, 0, len(versions))
+	for _, v := range versions {
+		list = append(list, v.Original())
+	}
+	_ = versionsCache.Put(key, list)
+	return versions, nil
+}
+
+// versionsCacheKey returns the key of the versions of a provider, prefixed with where they are listed from, e.g.
+// `registry/hashicorp/azurerm`, so switching to the offline mirror or a private registry doesn't serve stale lists
+func versionsCacheKey(providerReq ProviderRequest) string {
+	source := "registry"
+	if mirrorDir := providerMirrorDir(); mirrorDir != "" {
+		source = "mirror:" + mirrorDir
+	} else if host := registryHost(); host != "" {
+		source = host
+	}
+	return path.Join(source, providerReq.ProviderNamespace, providerReq.ProviderName)
+}
+
+// schemaCacheKey returns the cache key of a resolved provider version, also its path in the disk cache, e.g.
+// `hashicorp/azurerm/4.39.0`. Providers of a private registry are cached under their host, e.g.
+// `app.terraform.io/example/azurerm/1.0.0`.
+func schemaCacheKey(providerReq ProviderRequest) string {
+	key := path.Join(providerReq.ProviderNamespace, providerReq.ProviderName, providerReq.ProviderVersion)
+	if host := registryHost(); host != "" {
+		key = path.Join(host, key)
+	}
+	return key
+}
+
+// downloadProviderSchema reads the full provider schema through the tfpluginschema server, which downloads the provider
+func downloadProviderSchema(providerReq ProviderRequest) (*tfjson.ProviderSchema, error) {
+	// The plugin server keeps every schema and provider binary it loaded, a short-lived server releases them
+	// once the schema is assembled, so the memory cache alone bounds what the process holds
+	server := tfpluginschema.NewServer(nil)
+	defer server.Cleanup()
+	request := toPluginSchemaRequest(providerReq)
+
+	configSchema, err := server.GetProviderSchema(request)
+	if err != nil {
+		return nil, err
+	}
+	schema := &tfjson.ProviderSchema{
+		ConfigSchema:             configSchema,
+		ResourceSchemas:          make(map[string]*tfjson.Schema),
+		DataSourceSchemas:        make(map[string]*tfjson.Schema),
+		EphemeralResourceSchemas: make(map[string]*tfjson.Schema),
+		Functions:                make(map[string]*tfjson.FunctionSignature),
+	}
+	if err = collect(schema.ResourceSchemas, server.ListResources, server.GetResourceSchema, request); err != nil {
+		return nil, err
+	}
+	if err = collect(schema.DataSourceSchemas, server.ListDataSources, server.GetDataSourceSchema, request); err != nil {
+		return nil, err
+	}
+	if err = collect(schema.EphemeralResourceSchemas, server.ListEphemeralResources, server.GetEphemeralResourceSchema, request); err != nil {
+		return nil, err
+	}
+	if err = collect(schema.Functions, server.ListFunctions, server.GetFunctionSchema, request); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func collect[T any](dst map[string]T, list func(tfpluginschema.Request) ([]string, error), get func(tfpluginschema.Request, string) (T, error), request tfpluginschema.Request) error {
+	names, err := list(request)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if dst[name], err = get(request, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package tfschema
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goversion "github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/prashantv/gostub"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var cacheTestReq = ProviderRequest{
+	ProviderNamespace: "hashicorp",
+	ProviderName:      "azurerm",
+	ProviderVersion:   "4.39.0",
+}
+
+func cacheTestSchema() *tfjson.ProviderSchema {
+	return &tfjson.ProviderSchema{
+		ConfigSchema: &tfjson.Schema{Block: testProviderBlock},
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"azurerm_resource_group": {Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"name":     {AttributeType: cty.String, Required: true},
+					"location": {AttributeType: cty.String, Required: true},
+				},
+			}},
+		},
+		DataSourceSchemas: map[string]*tfjson.Schema{
+			"azurerm_client_config": {Block: &tfjson.SchemaBlock{}},
+		},
+		Functions: map[string]*tfjson.FunctionSignature{
+			"normalise_resource_id": {ReturnType: cty.String},
+		},
+	}
+}
+
+// stubSchemaSource isolates the cache from the registry, the real file system and the user cache directory, it returns a pointer to the download counter
+func stubSchemaSource(t *testing.T, versions ...string) *int {
+	downloads := 0
+	var collection goversion.Collection
+	for _, v := range versions {
+		collection = append(collection, goversion.Must(goversion.NewVersion(v)))
+	}
+	t.Setenv(SchemaCacheDirEnv, t.TempDir())
+	stubs := gostub.Stub(&fs, afero.NewMemMapFs()).
+		Stub(&schemaCache, newSchemaCache()).
+		Stub(&versionsCache, newVersionsCache()).
+		Stub(&fetchProviderSchema, func(ProviderRequest) (*tfjson.ProviderSchema, error) {
+			downloads++
+			return cacheTestSchema(), nil
+		}).
+		Stub(&availableVersions, func(ProviderRequest) (goversion.Collection, error) {
+			return collection, nil
+		})
+	t.Cleanup(stubs.Reset)
+	return &downloads
+}
+
+func TestProviderSchema_DiskCacheSurvivesRestart(t *testing.T) {
+	downloads := stubSchemaSource(t)
+
+	_, err := providerSchema(cacheTestReq)
+	require.NoError(t, err)
+	require.Equal(t, 1, *downloads)
+
+	_, err = os.Stat(filepath.Join(os.Getenv(SchemaCacheDirEnv), "schemas", "hashicorp", "azurerm", "4.39.0.json"))
+	require.NoError(t, err)
+
+	// Simulate a process restart by dropping the in-memory cache
+	schemaCache = newSchemaCache()
+	schema, err := providerSchema(cacheTestReq)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *downloads, "schema should be served from the disk cache")
+	require.Contains(t, schema.ResourceSchemas, "azurerm_resource_group")
+	assert.Equal(t, cty.String, schema.ResourceSchemas["azurerm_resource_group"].Block.Attributes["name"].AttributeType)
+	assert.Equal(t, cty.String, schema.Functions["normalise_resource_id"].ReturnType)
+}
+
+func TestProviderSchema_VersionKeyed(t *testing.T) {
+	downloads := stubSchemaSource(t)
+
+	_, err := providerSchema(cacheTestReq)
+	require.NoError(t, err)
+	other := cacheTestReq
+	other.ProviderVersion = "4.40.0"
+	_, err = providerSchema(other)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, *downloads)
+}
+
+func TestProviderSchema_CorruptedEntryIsRefetched(t *testing.T) {
+	downloads := stubSchemaSource(t)
+	path := filepath.Join(os.Getenv(SchemaCacheDirEnv), "schemas", "hashicorp", "azurerm", "4.39.0.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0644))
+
+	schema, err := providerSchema(cacheTestReq)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *downloads)
+	assert.Contains(t, schema.ResourceSchemas, "azurerm_resource_group")
+}
+
+func TestProviderSchema_DiskCacheDisabled(t *testing.T) {
+	downloads := stubSchemaSource(t)
+	t.Setenv(cache.DirEnv, t.TempDir())
+	t.Setenv(SchemaCacheDirEnv, "off")
+
+	_, err := providerSchema(cacheTestReq)
+	require.NoError(t, err)
+	schemaCache = newSchemaCache()
+	_, err = providerSchema(cacheTestReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, *downloads)
+	entries, err := os.ReadDir(os.Getenv(cache.DirEnv))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestSchemaCache_Config(t *testing.T) {
+	t.Setenv(SchemaCacheDirEnv, "/cache")
+	assert.Equal(t, cache.Config{Backend: cache.BackendDisk, MaxEntries: defaultMemoryCacheEntries, Dir: filepath.Join("/cache", "schemas")}, newSchemaCache().Config())
+
+	t.Setenv(MemoryCacheEntriesEnv, "3")
+	t.Setenv(MemoryCacheMBEnv, "512")
+	config := newSchemaCache().Config()
+	assert.Equal(t, 3, config.MaxEntries)
+	assert.Equal(t, 512<<20, config.MaxBytes)
+
+	t.Setenv(MemoryCacheEntriesEnv, "none")
+	assert.Equal(t, defaultMemoryCacheEntries, newSchemaCache().Config().MaxEntries)
+}
+
+func TestResolveVersion(t *testing.T) {
+	stubSchemaSource(t, "3.117.0", "4.38.1", "4.39.0", "5.0.0")
+
+	tests := []struct {
+		name     string
+		version  string
+		expected string
+	}{
+		{name: "exact version", version: "4.1.0", expected: "4.1.0"},
+		{name: "empty version resolves to latest", version: "", expected: "5.0.0"},
+		{name: "pessimistic constraint", version: "~> 4.0", expected: "4.39.0"},
+		{name: "range constraint", version: ">= 3.0, < 4.39.0", expected: "4.38.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := cacheTestReq
+			req.ProviderVersion = tt.version
+			resolved, err := ResolveVersion(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved.ProviderVersion)
+		})
+	}
+}
+
+func TestResolveVersion_NoMatch(t *testing.T) {
+	stubSchemaSource(t, "4.39.0")
+
+	req := cacheTestReq
+	req.ProviderVersion = "~> 5.0"
+	_, err := ResolveVersion(req)
+	require.Error(t, err)
+}
+
+func TestResolveVersion_VersionListCached(t *testing.T) {
+	stubSchemaSource(t)
+	calls := 0
+	stubs := gostub.Stub(&availableVersions, func(ProviderRequest) (goversion.Collection, error) {
+		calls++
+		if calls > 1 {
+			return nil, assert.AnError
+		}
+		return goversion.Collection{goversion.Must(goversion.NewVersion("4.39.0"))}, nil
+	})
+	defer stubs.Reset()
+
+	for _, version := range []string{"", "~> 4.0", ""} {
+		req := cacheTestReq
+		req.ProviderVersion = version
+		resolved, err := ResolveVersion(req)
+		require.NoError(t, err)
+		assert.Equal(t, "4.39.0", resolved.ProviderVersion)
+	}
+	assert.Equal(t, 1, calls, "the version list should be served from the cache once listed")
+}
+
+func TestResolveVersion_FailedListingNotCached(t *testing.T) {
+	stubSchemaSource(t)
+	calls := 0
+	stubs := gostub.Stub(&availableVersions, func(ProviderRequest) (goversion.Collection, error) {
+		calls++
+		if calls == 1 {
+			return nil, assert.AnError
+		}
+		return goversion.Collection{goversion.Must(goversion.NewVersion("4.39.0"))}, nil
+	})
+	defer stubs.Reset()
+
+	// A provider without an embedded schema, so the failure isn't hidden by the embedded fallback
+	req := ProviderRequest{ProviderNamespace: "example", ProviderName: "example"}
+	_, err := ResolveVersion(req)
+	require.Error(t, err)
+	resolved, err := ResolveVersion(req)
+	require.NoError(t, err)
+	assert.Equal(t, "4.39.0", resolved.ProviderVersion)
+}
+
+func TestQuerySchema_FromCachedSchema(t *testing.T) {
+	stubSchemaSource(t)
+
+	_, err := QuerySchema("resource", "azurerm_resource_group", "location", cacheTestReq)
+	require.NoError(t, err)
+
+	_, err = QuerySchema("data", "azurerm_not_exist", "", cacheTestReq)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "data source schema not found: azurerm_not_exist")
+
+	items, err := ListItems("function", cacheTestReq)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"normalise_resource_id"}, items)
+}
+
+func TestProviderSchema_DownloadErrorNotCached(t *testing.T) {
+	stubSchemaSource(t)
+	stubs := gostub.Stub(&fetchProviderSchema, func(ProviderRequest) (*tfjson.ProviderSchema, error) {
+		return nil, errors.New("registry unavailable")
+	})
+	defer stubs.Reset()
+
+	_, err := providerSchema(cacheTestReq)
+	require.Error(t, err)
+	_, _, ok := schemaCache.Get(schemaCacheKey(cacheTestReq))
+	assert.False(t, ok)
+}
+
+func TestProviderSchema_ConcurrentLoadsAreCoalesced(t *testing.T) {
+	stubSchemaSource(t)
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	stubs := gostub.Stub(&fetchProviderSchema, func(ProviderRequest) (*tfjson.ProviderSchema, error) {
+		fetches.Add(1)
+		<-release
+		return cacheTestSchema(), nil
+	})
+	defer stubs.Reset()
+
+	var wg sync.WaitGroup
+	schemas := make([]*tfjson.ProviderSchema, 8)
+	for i := range schemas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			schema, err := providerSchema(cacheTestReq)
+			assert.NoError(t, err)
+			schemas[i] = schema
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), fetches.Load())
+	for _, schema := range schemas {
+		assert.Same(t, schemas[0], schema)
+	}
+}
@@ -0,0 +1,78 @@
+package tfschema
+
+import (
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// nestedBlockResult renders a nested block returned by a path query. Nesting mode, item limits and a readable
+// cardinality come before the block content, agents frequently get block repetition wrong otherwise.
+type nestedBlockResult struct {
+	NestingMode tfjson.SchemaNestingMode `json:"nesting_mode"`
+	MinItems    uint64                   `json:"min_items"`
+	// MaxItems is omitted when the number of blocks is unlimited
+	MaxItems    uint64              `json:"max_items,omitempty"`
+	Cardinality string              `json:"cardinality"`
+	Block       *tfjson.SchemaBlock `json:"block,omitempty"`
+}
+
+// describeNestedBlocks wraps nested blocks of a path query result, including those matched by wildcard paths,
+// into nestedBlockResult. Other values are returned unchanged.
+func describeNestedBlocks(value any) any {
+	switch v := value.(type) {
+	case *tfjson.SchemaBlockType:
+		return &nestedBlockResult{
+			NestingMode: v.NestingMode,
+			MinItems:    v.MinItems,
+			MaxItems:    v.MaxItems,
+			Cardinality: blockCardinality(v),
+			Block:       v.Block,
+		}
+	case PathMatches:
+		return describeNestedBlocks(map[string]any(v))
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for path, match := range v {
+			result[path] = describeNestedBlocks(match)
+		}
+		return result
+	}
+	return value
+}
+
+// blockCardinality summarizes how many times a nested block can be written, e.g. `list of blocks, at most 1, optional`
+func blockCardinality(block *tfjson.SchemaBlockType) string {
+	presence := "optional"
+	if block.MinItems > 0 {
+		presence = "required"
+	}
+	switch block.NestingMode {
+	case tfjson.SchemaNestingModeSingle:
+		return "single block, " + presence
+	case tfjson.SchemaNestingModeGroup:
+		return "single block, always present even when not written"
+	}
+
+	kind := "list of blocks"
+	switch block.NestingMode {
+	case tfjson.SchemaNestingModeSet:
+		kind = "set of blocks"
+	case tfjson.SchemaNestingModeMap:
+		kind = "map of blocks keyed by label"
+	}
+	var count string
+	switch {
+	case block.MinItems > 0 && block.MaxItems == block.MinItems:
+		count = fmt.Sprintf("exactly %d", block.MinItems)
+	case block.MinItems > 0 && block.MaxItems > 0:
+		count = fmt.Sprintf("between %d and %d", block.MinItems, block.MaxItems)
+	case block.MinItems > 0:
+		count = fmt.Sprintf("at least %d", block.MinItems)
+	case block.MaxItems > 0:
+		count = fmt.Sprintf("at most %d", block.MaxItems)
+	default:
+		count = "any number"
+	}
+	return fmt.Sprintf("%s, %s, %s", kind, count, presence)
+}
@@ -0,0 +1,63 @@
+package tfschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCardinality(t *testing.T) {
+	tests := []struct {
+		name     string
+		block    *tfjson.SchemaBlockType
+		expected string
+	}{
+		{name: "optional single", block: &tfjson.SchemaBlockType{NestingMode: tfjson.SchemaNestingModeSingle}, expected: "single block, optional"},
+		{name: "required single", block: &tfjson.SchemaBlockType{NestingMode: tfjson.SchemaNestingModeSingle, MinItems: 1}, expected: "single block, required"},
+		{name: "group", block: &tfjson.SchemaBlockType{NestingMode: tfjson.SchemaNestingModeGroup}, expected: "single block, always present even when not written"},
+		{name: "optional list of one", block: &tfjson.SchemaBlockType{NestingMode: tfjson.SchemaNestingModeList, MaxItems: 1}, expected: "list of blocks, at most 1, optional"},
+		{name: "required list of one", block: &tfjson.SchemaBlockType{NestingMode: tfjson.SchemaNestingModeList, MinItems: 1, MaxItems: 1}, expected: "list of blocks, exactly 1, required"},
+		{name: "bounded list", block: &tfjson.SchemaBlockType{NestingMode: tfjson.SchemaNestingModeList, MinItems: 1, MaxItems: 3}, expected: "list of blocks, between 1 and 3, required"},
+		{name: "unbounded set", block: &tfjson.SchemaBlockType{NestingMode: tfjson.SchemaNestingModeSet, MinItems: 2}, expected: "set of blocks, at least 2, required"},
+		{name: "map", block: &tfjson.SchemaBlockType{NestingMode: tfjson.SchemaNestingModeMap}, expected: "map of blocks keyed by label, any number, optional"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, blockCardinality(tt.block))
+		})
+	}
+}
+
+func TestQuerySchemaPath_NestedBlockCardinality(t *testing.T) {
+	stubSchemaSource(t)
+
+	result, err := QuerySchema("provider", "", "features", cacheTestReq)
+	require.NoError(t, err)
+	var block map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(result), &block))
+	assert.JSONEq(t, `"list"`, string(block["nesting_mode"]))
+	assert.JSONEq(t, `0`, string(block["min_items"]))
+	assert.JSONEq(t, `1`, string(block["max_items"]))
+	assert.JSONEq(t, `"list of blocks, at most 1, optional"`, string(block["cardinality"]))
+	assert.Contains(t, block, "block")
+	assert.Less(t, strings.Index(result, `"cardinality"`), strings.Index(result, `"block"`))
+}
+
+func TestDescribeNestedBlocks_WildcardMatches(t *testing.T) {
+	attr := testResourceBlock().Attributes["name"]
+	result := describeNestedBlocks(PathMatches{
+		"name":              attr,
+		"default_node_pool": testResourceBlock().NestedBlocks["default_node_pool"],
+	})
+
+	matches, ok := result.(map[string]any)
+	require.True(t, ok)
+	assert.Same(t, attr, matches["name"])
+	pool, ok := matches["default_node_pool"].(*nestedBlockResult)
+	require.True(t, ok)
+	assert.Equal(t, "list of blocks, exactly 1, required", pool.Cardinality)
+}
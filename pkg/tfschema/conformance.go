@@ -0,0 +1,270 @@
+package tfschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/spf13/afero"
+)
+
+// Issues reported by plan conformance checks
+const (
+	// ConformanceUnknown flags an attribute or block the provider schema doesn't declare, usually written for
+	// another provider version
+	ConformanceUnknown = "unknown"
+	// ConformanceDeprecated flags a deprecated schema, attribute or block
+	ConformanceDeprecated = "deprecated"
+	// ConformanceWriteOnly flags a write-only attribute, its value is never persisted to plan or state
+	ConformanceWriteOnly = "write_only"
+)
+
+// ConformanceFinding is an element written in the configuration of a resource that deserves attention.
+// Kind is one of DeprecatedKindSchema, DeprecatedKindAttribute or DeprecatedKindBlock.
+type ConformanceFinding struct {
+	// Path is the dot-separated path of the element in the configuration, empty for the whole schema
+	Path    string `json:"path,omitempty"`
+	Kind    string `json:"kind"`
+	Issue   string `json:"issue"`
+	Message string `json:"message,omitempty"`
+}
+
+// ResourceConformance lists the findings of one resource change
+type ResourceConformance struct {
+	Address  string               `json:"address"`
+	Category string               `json:"category"`
+	Type     string               `json:"type"`
+	Provider string               `json:"provider"`
+	Findings []ConformanceFinding `json:"findings"`
+}
+
+// SkippedResource is a resource change that couldn't be checked
+type SkippedResource struct {
+	Address string `json:"address"`
+	Reason  string `json:"reason"`
+}
+
+// ConformanceReport is the result of checking a plan against provider schemas. Only resources with findings are listed.
+type ConformanceReport struct {
+	// Providers maps provider sources to the schema versions the plan was checked against
+	Providers map[string]string     `json:"providers"`
+	Checked   int                   `json:"checked"`
+	Count     int                   `json:"count"`
+	Resources []ResourceConformance `json:"resources"`
+	Skipped   []SkippedResource     `json:"skipped,omitempty"`
+}
+
+// instanceKeyPattern matches count and for_each instance keys of an address, like `[0]` or `["east"]`
+var instanceKeyPattern = regexp.MustCompile(`\[(?:"(?:[^"\\]|\\.)*"|[^\]]*)\]`)
+
+// CheckPlanFileConformance reads a plan in JSON format, as written by `terraform show -json`, and checks it with CheckPlanConformance
+func CheckPlanFileConformance(planFile string, versions map[string]string) (*ConformanceReport, error) {
+	content, err := afero.ReadFile(fs, planFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file %s: %w", planFile, err)
+	}
+	plan := &tfjson.Plan{}
+	if err := json.Unmarshal(content, plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan file %s, generate it with `terraform show -json`: %w", planFile, err)
+	}
+	return CheckPlanConformance(plan, versions)
+}
+
+// CheckPlanConformance cross-references the configuration of every resource change in plan with its provider schema and
+// reports unknown, deprecated and write-only attributes and blocks written in the configuration.
+//
+// versions maps provider sources like `hashicorp/azurerm` to the version or version constraint to check against,
+// providers without an entry are checked against the version constraints declared in the plan configuration, or the
+// latest version. Checking a plan against a newer provider version reports what an upgrade would break, the plan's own
+// provider version never reports unknown elements since Terraform validated the configuration against it.
+func CheckPlanConformance(plan *tfjson.Plan, versions map[string]string) (*ConformanceReport, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("plan is nil")
+	}
+	if plan.Config == nil || plan.Config.RootModule == nil {
+		return nil, fmt.Errorf("plan doesn't contain the configuration, generate it with `terraform show -json` from a saved plan")
+	}
+
+	configs := map[string]*tfjson.ConfigResource{}
+	indexConfigResources(configs, "", plan.Config.RootModule)
+	constraints := providerConstraints(plan.Config)
+
+	report := &ConformanceReport{
+		Providers: map[string]string{},
+		Resources: []ResourceConformance{},
+	}
+	schemas := map[string]*tfjson.ProviderSchema{}
+	schemaErrors := map[string]error{}
+	for _, rc := range plan.ResourceChanges {
+		if rc == nil {
+			continue
+		}
+		category, ok := resourceModeCategories[rc.Mode]
+		if !ok {
+			report.Skipped = append(report.Skipped, SkippedResource{Address: rc.Address, Reason: fmt.Sprintf("unsupported resource mode %q", rc.Mode)})
+			continue
+		}
+		config, ok := configs[configAddress(rc.Address)]
+		if !ok {
+			report.Skipped = append(report.Skipped, SkippedResource{Address: rc.Address, Reason: "resource is not in the configuration"})
+			continue
+		}
+		namespace, name, err := ParseProviderSource(rc.ProviderName)
+		if err != nil {
+			report.Skipped = append(report.Skipped, SkippedResource{Address: rc.Address, Reason: err.Error()})
+			continue
+		}
+		source := fmt.Sprintf("%s/%s", namespace, name)
+		if _, ok := schemas[source]; !ok && schemaErrors[source] == nil {
+			version, ok := versions[source]
+			if !ok {
+				version = constraints[rc.ProviderName]
+			}
+			resolved, ps, err := conformanceSchema(ProviderRequest{ProviderNamespace: namespace, ProviderName: name, ProviderVersion: version})
+			if err != nil {
+				schemaErrors[source] = err
+			} else {
+				schemas[source] = ps
+				report.Providers[source] = resolved.ProviderVersion
+			}
+		}
+		if err := schemaErrors[source]; err != nil {
+			report.Skipped = append(report.Skipped, SkippedResource{Address: rc.Address, Reason: err.Error()})
+			continue
+		}
+
+		report.Checked++
+		var findings []ConformanceFinding
+		schemaMap, _ := categorySchemas(schemas[source], category)
+		schema, ok := schemaMap[rc.Type]
+		switch {
+		case !ok || schema == nil || schema.Block == nil:
+			findings = []ConformanceFinding{{
+				Kind:    DeprecatedKindSchema,
+				Issue:   ConformanceUnknown,
+				Message: fmt.Sprintf("%s %s is not declared by provider %s %s", categoryDisplayNames[category], rc.Type, source, report.Providers[source]),
+			}}
+		default:
+			if schema.Block.Deprecated {
+				findings = append(findings, ConformanceFinding{Kind: DeprecatedKindSchema, Issue: ConformanceDeprecated, Message: schema.Block.Description})
+			}
+			findings = append(findings, blockConformance("", config.Expressions, schema.Block)...)
+		}
+		if len(findings) == 0 {
+			continue
+		}
+		report.Count += len(findings)
+		report.Resources = append(report.Resources, ResourceConformance{
+			Address:  rc.Address,
+			Category: category,
+			Type:     rc.Type,
+			Provider: source,
+			Findings: findings,
+		})
+	}
+	return report, nil
+}
+
+var resourceModeCategories = map[tfjson.ResourceMode]string{
+	tfjson.ManagedResourceMode: "resource",
+	tfjson.DataResourceMode:    "data",
+}
+
+// conformanceSchema resolves and loads the provider schema a plan is checked against
+func conformanceSchema(providerReq ProviderRequest) (ProviderRequest, *tfjson.ProviderSchema, error) {
+	resolved, err := ResolveVersion(providerReq)
+	if err != nil {
+		return ProviderRequest{}, nil, err
+	}
+	ps, err := providerSchema(resolved)
+	if err != nil {
+		return ProviderRequest{}, nil, fmt.Errorf("failed to get schema of provider %s/%s: %w", providerReq.ProviderNamespace, providerReq.ProviderName, err)
+	}
+	return resolved, ps, nil
+}
+
+// indexConfigResources indexes resources of module and its descendants by their address without instance keys
+func indexConfigResources(index map[string]*tfjson.ConfigResource, prefix string, module *tfjson.ConfigModule) {
+	if module == nil {
+		return
+	}
+	for _, r := range module.Resources {
+		if r != nil {
+			index[joinPath(prefix, r.Address)] = r
+		}
+	}
+	for name, call := range module.ModuleCalls {
+		if call != nil {
+			indexConfigResources(index, joinPath(prefix, "module."+name), call.Module)
+		}
+	}
+}
+
+// configAddress removes instance keys from an absolute resource instance address
+func configAddress(address string) string {
+	return instanceKeyPattern.ReplaceAllString(address, "")
+}
+
+// providerConstraints combines the version constraints declared for each provider across all modules, keyed by full provider name
+func providerConstraints(config *tfjson.Config) map[string]string {
+	declared := map[string][]string{}
+	for _, pc := range config.ProviderConfigs {
+		if pc == nil || pc.VersionConstraint == "" || slices.Contains(declared[pc.FullName], pc.VersionConstraint) {
+			continue
+		}
+		declared[pc.FullName] = append(declared[pc.FullName], pc.VersionConstraint)
+	}
+	constraints := make(map[string]string, len(declared))
+	for name, c := range declared {
+		sort.Strings(c)
+		constraints[name] = strings.Join(c, ", ")
+	}
+	return constraints
+}
+
+// blockConformance checks the expressions written in a block body against block, path is the path of the body itself
+func blockConformance(path string, expressions map[string]*tfjson.Expression, block *tfjson.SchemaBlock) []ConformanceFinding {
+	var findings []ConformanceFinding
+	for _, n := range sortedKeys(expressions) {
+		expr := expressions[n]
+		elementPath := joinPath(path, n)
+		if attr, ok := block.Attributes[n]; ok && attr != nil {
+			if attr.Deprecated {
+				findings = append(findings, ConformanceFinding{Path: elementPath, Kind: DeprecatedKindAttribute, Issue: ConformanceDeprecated, Message: attr.Description})
+			}
+			if attr.WriteOnly {
+				findings = append(findings, ConformanceFinding{Path: elementPath, Kind: DeprecatedKindAttribute, Issue: ConformanceWriteOnly, Message: "the value is never persisted to plan or state"})
+			}
+			continue
+		}
+		if nested, ok := block.NestedBlocks[n]; ok && nested != nil && nested.Block != nil {
+			if nested.Block.Deprecated {
+				findings = append(findings, ConformanceFinding{Path: elementPath, Kind: DeprecatedKindBlock, Issue: ConformanceDeprecated, Message: nested.Block.Description})
+			}
+			if expr == nil || expr.ExpressionData == nil {
+				continue
+			}
+			// repeated blocks report each finding once
+			seen := map[ConformanceFinding]bool{}
+			for _, body := range expr.NestedBlocks {
+				for _, f := range blockConformance(elementPath, body, nested.Block) {
+					if !seen[f] {
+						seen[f] = true
+						findings = append(findings, f)
+					}
+				}
+			}
+			continue
+		}
+		kind := DeprecatedKindAttribute
+		if expr != nil && expr.ExpressionData != nil && expr.NestedBlocks != nil {
+			kind = DeprecatedKindBlock
+		}
+		findings = append(findings, ConformanceFinding{Path: elementPath, Kind: kind, Issue: ConformanceUnknown, Message: fmt.Sprintf("%s is not declared by the schema", kind)})
+	}
+	return findings
+}
@@ -0,0 +1,141 @@
+package tfschema
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const testConformancePlan = `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "azurerm_resource_group.this", "mode": "managed", "type": "azurerm_resource_group", "name": "this", "provider_name": "registry.terraform.io/hashicorp/azurerm", "change": {"actions": ["create"]}},
+    {"address": "module.aks[\"east\"].azurerm_kubernetes_cluster.this[0]", "module_address": "module.aks[\"east\"]", "mode": "managed", "type": "azurerm_kubernetes_cluster", "name": "this", "index": 0, "provider_name": "registry.terraform.io/hashicorp/azurerm", "change": {"actions": ["create"]}},
+    {"address": "azurerm_legacy.this", "mode": "managed", "type": "azurerm_legacy", "name": "this", "provider_name": "registry.terraform.io/hashicorp/azurerm", "change": {"actions": ["create"]}},
+    {"address": "azurerm_resource_group.removed", "mode": "managed", "type": "azurerm_resource_group", "name": "removed", "provider_name": "registry.terraform.io/hashicorp/azurerm", "change": {"actions": ["delete"]}},
+    {"address": "random_pet.this", "mode": "managed", "type": "random_pet", "name": "this", "provider_name": "example.com/hashicorp/random", "change": {"actions": ["create"]}}
+  ],
+  "configuration": {
+    "provider_config": {
+      "azurerm": {"name": "azurerm", "full_name": "registry.terraform.io/hashicorp/azurerm", "version_constraint": "~> 4.0"}
+    },
+    "root_module": {
+      "resources": [
+        {"address": "azurerm_resource_group.this", "mode": "managed", "type": "azurerm_resource_group", "name": "this", "expressions": {"name": {"constant_value": "rg"}, "location": {"constant_value": "eastus"}}},
+        {"address": "azurerm_legacy.this", "mode": "managed", "type": "azurerm_legacy", "name": "this", "expressions": {}},
+        {"address": "random_pet.this", "mode": "managed", "type": "random_pet", "name": "this", "expressions": {}}
+      ],
+      "module_calls": {
+        "aks": {
+          "source": "./modules/aks",
+          "module": {
+            "resources": [
+              {"address": "azurerm_kubernetes_cluster.this", "mode": "managed", "type": "azurerm_kubernetes_cluster", "name": "this", "expressions": {
+                "name": {"constant_value": "aks"},
+                "legacy": {"constant_value": true},
+                "admin_password_wo": {"references": ["var.password"]},
+                "retired": {"constant_value": "x"},
+                "default_node_pool": [{"vm_size": {"constant_value": "Standard_D2s_v5"}, "max_pods": {"constant_value": 30}}, {"max_pods": {"constant_value": 50}}],
+                "windows_profile": [{"admin_username": {"constant_value": "azure"}}]
+              }}
+            ]
+          }
+        }
+      }
+    }
+  }
+}`
+
+func stubConformanceSchema(t *testing.T) {
+	stubSchemaSource(t, "4.38.0", "4.39.0", "5.0.0")
+	original := fetchProviderSchema
+	fetchProviderSchema = func(req ProviderRequest) (*tfjson.ProviderSchema, error) {
+		schema, err := original(req)
+		require.NoError(t, err)
+		aks := testResourceBlock()
+		aks.Attributes["admin_password_wo"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, WriteOnly: true}
+		aks.Attributes["legacy"].Description = "use modern instead"
+		schema.ResourceSchemas["azurerm_kubernetes_cluster"] = &tfjson.Schema{Block: aks}
+		schema.ResourceSchemas["azurerm_legacy"] = &tfjson.Schema{Block: &tfjson.SchemaBlock{Deprecated: true, Description: "retired"}}
+		return schema, nil
+	}
+	t.Cleanup(func() {
+		fetchProviderSchema = original
+	})
+}
+
+func TestCheckPlanFileConformance(t *testing.T) {
+	stubConformanceSchema(t)
+	require.NoError(t, afero.WriteFile(fs, "/workspace/plan.json", []byte(testConformancePlan), 0644))
+
+	report, err := CheckPlanFileConformance("/workspace/plan.json", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"hashicorp/azurerm": "4.39.0"}, report.Providers)
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, []ResourceConformance{
+		{
+			Address:  `module.aks["east"].azurerm_kubernetes_cluster.this[0]`,
+			Category: "resource",
+			Type:     "azurerm_kubernetes_cluster",
+			Provider: "hashicorp/azurerm",
+			Findings: []ConformanceFinding{
+				{Path: "admin_password_wo", Kind: DeprecatedKindAttribute, Issue: ConformanceWriteOnly, Message: "the value is never persisted to plan or state"},
+				{Path: "default_node_pool.max_pods", Kind: DeprecatedKindAttribute, Issue: ConformanceUnknown, Message: "attribute is not declared by the schema"},
+				{Path: "legacy", Kind: DeprecatedKindAttribute, Issue: ConformanceDeprecated, Message: "use modern instead"},
+				{Path: "retired", Kind: DeprecatedKindAttribute, Issue: ConformanceUnknown, Message: "attribute is not declared by the schema"},
+				{Path: "windows_profile", Kind: DeprecatedKindBlock, Issue: ConformanceUnknown, Message: "block is not declared by the schema"},
+			},
+		},
+		{
+			Address:  "azurerm_legacy.this",
+			Category: "resource",
+			Type:     "azurerm_legacy",
+			Provider: "hashicorp/azurerm",
+			Findings: []ConformanceFinding{{Kind: DeprecatedKindSchema, Issue: ConformanceDeprecated, Message: "retired"}},
+		},
+	}, report.Resources)
+	assert.Equal(t, 6, report.Count)
+	require.Len(t, report.Skipped, 2)
+	assert.Equal(t, "azurerm_resource_group.removed", report.Skipped[0].Address)
+	assert.Equal(t, "random_pet.this", report.Skipped[1].Address)
+	assert.Contains(t, report.Skipped[1].Reason, "example.com")
+}
+
+func TestCheckPlanConformance_VersionOverride(t *testing.T) {
+	stubConformanceSchema(t)
+	plan := &tfjson.Plan{}
+	require.NoError(t, plan.UnmarshalJSON([]byte(testConformancePlan)))
+
+	report, err := CheckPlanConformance(plan, map[string]string{"hashicorp/azurerm": "4.38.0"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"hashicorp/azurerm": "4.38.0"}, report.Providers)
+}
+
+func TestCheckPlanConformance_UnknownType(t *testing.T) {
+	stubSchemaSource(t, "4.39.0")
+	plan := &tfjson.Plan{}
+	require.NoError(t, plan.UnmarshalJSON([]byte(testConformancePlan)))
+
+	report, err := CheckPlanConformance(plan, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Resources, 2)
+	assert.Equal(t, []ConformanceFinding{{
+		Kind:    DeprecatedKindSchema,
+		Issue:   ConformanceUnknown,
+		Message: "resource azurerm_kubernetes_cluster is not declared by provider hashicorp/azurerm 4.39.0",
+	}}, report.Resources[0].Findings)
+}
+
+func TestCheckPlanConformance_MissingConfiguration(t *testing.T) {
+	_, err := CheckPlanConformance(&tfjson.Plan{FormatVersion: "1.2"}, nil)
+	assert.ErrorContains(t, err, "doesn't contain the configuration")
+}
+
+func TestConfigAddress(t *testing.T) {
+	assert.Equal(t, "module.a.module.b.azurerm_subnet.this", configAddress(`module.a[0].module.b["x[1]"].azurerm_subnet.this["y"]`))
+	assert.Equal(t, "data.azurerm_client_config.current", configAddress("data.azurerm_client_config.current"))
+}
@@ -0,0 +1,162 @@
+package tfschema
+
+import (
+	"errors"
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Kinds of deprecated schema elements
+const (
+	DeprecatedKindSchema    = "schema"
+	DeprecatedKindAttribute = "attribute"
+	DeprecatedKindBlock     = "block"
+)
+
+// DeprecatedItem is a deprecated schema element. Provider schemas only carry a deprecated flag,
+// the element description is reported as message since providers document deprecations there.
+type DeprecatedItem struct {
+	Category string `json:"category"`
+	// Type is the resource, data source or ephemeral resource type, empty for the provider configuration
+	Type string `json:"type,omitempty"`
+	// Path is the dot-separated path of the element in the schema, empty when the whole schema is deprecated
+	Path    string `json:"path,omitempty"`
+	Kind    string `json:"kind"`
+	Message string `json:"message,omitempty"`
+}
+
+// DeprecationReport lists deprecated elements of one schema or a whole provider
+type DeprecationReport struct {
+	Provider string           `json:"provider"`
+	Version  string           `json:"version"`
+	Count    int              `json:"count"`
+	Items    []DeprecatedItem `json:"items"`
+}
+
+// reportCategories are the categories walked when reporting on an entire provider
+var reportCategories = []string{"provider", "resource", "data", "ephemeral"}
+
+// ReportDeprecated walks schemas and returns only their deprecated attributes and blocks. With a category and a name
+// a single schema is walked, with only a category every schema of that category, and with neither the whole provider.
+func ReportDeprecated(category, name string, providerReq ProviderRequest) (*DeprecationReport, error) {
+	if category == "function" {
+		return nil, errors.New("deprecation reports are not supported for function schemas")
+	}
+	items := []DeprecatedItem{}
+	resolved, err := walkSchemas(category, name, providerReq, func(category, typeName string, block *tfjson.SchemaBlock) {
+		items = append(items, deprecatedInBlock(category, typeName, "", block)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeprecationReport{
+		Provider: fmt.Sprintf("%s/%s", resolved.ProviderNamespace, resolved.ProviderName),
+		Version:  resolved.ProviderVersion,
+		Count:    len(items),
+		Items:    items,
+	}, nil
+}
+
+// walkSchemas visits the root block of the selected schemas in a stable order and returns the resolved provider.
+// With a category and a name a single schema is visited, with only a category every schema of that category,
+// and with neither every schema of the provider except functions.
+func walkSchemas(category, name string, providerReq ProviderRequest, visit func(category, typeName string, block *tfjson.SchemaBlock)) (ProviderRequest, error) {
+	if name != "" && category == "" {
+		return ProviderRequest{}, errors.New("category is required when type is set")
+	}
+
+	resolved, err := ResolveVersion(providerReq)
+	if err != nil {
+		return ProviderRequest{}, err
+	}
+	ps, err := providerSchema(resolved)
+	if err != nil {
+		return ProviderRequest{}, fmt.Errorf("failed to get schema of provider %s/%s: %w", providerReq.ProviderNamespace, providerReq.ProviderName, err)
+	}
+
+	categories := reportCategories
+	if category != "" {
+		categories = []string{category}
+	}
+	for _, c := range categories {
+		schemas, err := categorySchemas(ps, c)
+		if err != nil {
+			return ProviderRequest{}, err
+		}
+		if name != "" && c != "provider" {
+			schema, ok := schemas[name]
+			if !ok {
+				return ProviderRequest{}, fmt.Errorf("%s schema not found: %s", categoryDisplayNames[c], name)
+			}
+			schemas = map[string]*tfjson.Schema{name: schema}
+		}
+		for _, typeName := range sortedKeys(schemas) {
+			if schemas[typeName] == nil || schemas[typeName].Block == nil {
+				continue
+			}
+			visit(c, typeName, schemas[typeName].Block)
+		}
+	}
+	return resolved, nil
+}
+
+// categorySchemas returns schemas of a category keyed by type, the provider configuration is keyed by an empty string
+func categorySchemas(ps *tfjson.ProviderSchema, category string) (map[string]*tfjson.Schema, error) {
+	switch category {
+	case "provider":
+		return map[string]*tfjson.Schema{"": ps.ConfigSchema}, nil
+	case "resource":
+		return ps.ResourceSchemas, nil
+	case "data":
+		return ps.DataSourceSchemas, nil
+	case "ephemeral":
+		return ps.EphemeralResourceSchemas, nil
+	}
+	return nil, fmt.Errorf("unknown schema category %s, must be one of 'resource', 'data', 'ephemeral' or 'provider'", category)
+}
+
+// deprecatedInBlock collects deprecated elements of block, path is the path of block itself
+func deprecatedInBlock(category, typeName, path string, block *tfjson.SchemaBlock) []DeprecatedItem {
+	if block == nil {
+		return nil
+	}
+	var items []DeprecatedItem
+	if block.Deprecated {
+		kind := DeprecatedKindBlock
+		if path == "" {
+			kind = DeprecatedKindSchema
+		}
+		items = append(items, DeprecatedItem{Category: category, Type: typeName, Path: path, Kind: kind, Message: block.Description})
+	}
+	items = append(items, deprecatedInAttributes(category, typeName, path, block.Attributes)...)
+
+	for _, n := range sortedKeys(block.NestedBlocks) {
+		if nested := block.NestedBlocks[n]; nested != nil {
+			items = append(items, deprecatedInBlock(category, typeName, joinPath(path, n), nested.Block)...)
+		}
+	}
+	return items
+}
+
+func deprecatedInAttributes(category, typeName, path string, attributes map[string]*tfjson.SchemaAttribute) []DeprecatedItem {
+	var items []DeprecatedItem
+	for _, n := range sortedKeys(attributes) {
+		attr := attributes[n]
+		attrPath := joinPath(path, n)
+		if attr.Deprecated {
+			items = append(items, DeprecatedItem{Category: category, Type: typeName, Path: attrPath, Kind: DeprecatedKindAttribute, Message: attr.Description})
+		}
+		if attr.AttributeNestedType != nil {
+			items = append(items, deprecatedInAttributes(category, typeName, attrPath, attr.AttributeNestedType.Attributes)...)
+		}
+	}
+	return items
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
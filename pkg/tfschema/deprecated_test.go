@@ -0,0 +1,110 @@
+package tfschema
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestDeprecatedInBlock(t *testing.T) {
+	block := testResourceBlock()
+	block.Attributes["legacy"].Description = "Deprecated: use `modern` instead."
+	block.NestedBlocks["identity"].Block.Deprecated = true
+	block.NestedBlocks["identity"].Block.Description = "This block will be removed in v5.0."
+	block.NestedBlocks["default_node_pool"].Block.Attributes["os_sku"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, Deprecated: true}
+	block.Attributes["settings"].AttributeNestedType.Attributes["tier"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, Deprecated: true}
+
+	items := deprecatedInBlock("resource", "azurerm_kubernetes_cluster", "", block)
+
+	assert.Equal(t, []DeprecatedItem{
+		{Category: "resource", Type: "azurerm_kubernetes_cluster", Path: "legacy", Kind: DeprecatedKindAttribute, Message: "Deprecated: use `modern` instead."},
+		{Category: "resource", Type: "azurerm_kubernetes_cluster", Path: "settings.tier", Kind: DeprecatedKindAttribute},
+		{Category: "resource", Type: "azurerm_kubernetes_cluster", Path: "default_node_pool.os_sku", Kind: DeprecatedKindAttribute},
+		{Category: "resource", Type: "azurerm_kubernetes_cluster", Path: "identity", Kind: DeprecatedKindBlock, Message: "This block will be removed in v5.0."},
+	}, items)
+}
+
+func TestReportDeprecated(t *testing.T) {
+	stubSchemaSource(t)
+	stubs := stubDeprecatedSchema(t)
+	defer stubs()
+
+	tests := []struct {
+		name     string
+		category string
+		typeName string
+		expected []DeprecatedItem
+	}{
+		{
+			name:     "single resource",
+			category: "resource",
+			typeName: "azurerm_resource_group",
+			expected: []DeprecatedItem{
+				{Category: "resource", Type: "azurerm_resource_group", Path: "managed_by", Kind: DeprecatedKindAttribute},
+			},
+		},
+		{
+			name:     "whole category",
+			category: "data",
+			expected: []DeprecatedItem{
+				{Category: "data", Type: "azurerm_client_config", Kind: DeprecatedKindSchema, Message: "Use azurerm_subscription instead."},
+			},
+		},
+		{
+			name: "entire provider",
+			expected: []DeprecatedItem{
+				{Category: "provider", Path: "use_msi", Kind: DeprecatedKindAttribute},
+				{Category: "resource", Type: "azurerm_resource_group", Path: "managed_by", Kind: DeprecatedKindAttribute},
+				{Category: "data", Type: "azurerm_client_config", Kind: DeprecatedKindSchema, Message: "Use azurerm_subscription instead."},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := ReportDeprecated(tt.category, tt.typeName, cacheTestReq)
+			require.NoError(t, err)
+			assert.Equal(t, "hashicorp/azurerm", report.Provider)
+			assert.Equal(t, "4.39.0", report.Version)
+			assert.Equal(t, len(tt.expected), report.Count)
+			assert.Equal(t, tt.expected, report.Items)
+		})
+	}
+}
+
+func TestReportDeprecated_InvalidInput(t *testing.T) {
+	stubSchemaSource(t)
+
+	_, err := ReportDeprecated("", "azurerm_resource_group", cacheTestReq)
+	assert.ErrorContains(t, err, "category is required")
+
+	_, err = ReportDeprecated("function", "", cacheTestReq)
+	assert.ErrorContains(t, err, "not supported for function")
+
+	_, err = ReportDeprecated("resource", "azurerm_not_exist", cacheTestReq)
+	assert.ErrorContains(t, err, "resource schema not found: azurerm_not_exist")
+}
+
+// stubDeprecatedSchema marks a few elements of the cached test schema as deprecated
+func stubDeprecatedSchema(t *testing.T) func() {
+	original := fetchProviderSchema
+	fetchProviderSchema = func(req ProviderRequest) (*tfjson.ProviderSchema, error) {
+		schema, err := original(req)
+		require.NoError(t, err)
+		schema.ConfigSchema = &tfjson.Schema{Block: &tfjson.SchemaBlock{
+			Attributes: map[string]*tfjson.SchemaAttribute{
+				"use_msi":         {AttributeType: cty.Bool, Optional: true, Deprecated: true},
+				"subscription_id": {AttributeType: cty.String, Optional: true},
+			},
+		}}
+		schema.ResourceSchemas["azurerm_resource_group"].Block.Attributes["managed_by"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, Deprecated: true}
+		schema.DataSourceSchemas["azurerm_client_config"].Block.Deprecated = true
+		schema.DataSourceSchemas["azurerm_client_config"].Block.Description = "Use azurerm_subscription instead."
+		return schema, nil
+	}
+	return func() {
+		fetchProviderSchema = original
+	}
+}
@@ -0,0 +1,72 @@
+package tfschema
+
+import (
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// describeValue renders a schema, block, nested block, attribute or wildcard matches returned by a query as the
+// descriptions view, like query_azapi_resource_document does for azapi resources. Blocks and nested attributes
+// become maps keyed by name, attributes their description, elements without any description are left out.
+func describeValue(value any) any {
+	switch v := value.(type) {
+	case PathMatches:
+		return describeValue(map[string]any(v))
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for path, match := range v {
+			result[path] = describeValue(match)
+		}
+		return result
+	case *tfjson.Schema:
+		return describeBlock(v.Block)
+	case *tfjson.SchemaBlock:
+		return describeBlock(v)
+	case *tfjson.SchemaBlockType:
+		return describeBlock(v.Block)
+	case *tfjson.SchemaAttribute:
+		if d := describeAttribute(v); d != nil {
+			return d
+		}
+		return ""
+	}
+	return value
+}
+
+func describeBlock(block *tfjson.SchemaBlock) map[string]any {
+	result := make(map[string]any)
+	if block == nil {
+		return result
+	}
+	for name, attr := range block.Attributes {
+		if d := describeAttribute(attr); d != nil {
+			result[name] = d
+		}
+	}
+	for name, nested := range block.NestedBlocks {
+		if nested == nil {
+			continue
+		}
+		if d := describeBlock(nested.Block); len(d) > 0 {
+			result[name] = d
+		} else if nested.Block != nil && nested.Block.Description != "" {
+			result[name] = nested.Block.Description
+		}
+	}
+	return result
+}
+
+// describeAttribute returns the description of attr, or a map of descriptions of its nested attributes, nil when none is documented
+func describeAttribute(attr *tfjson.SchemaAttribute) any {
+	if attr == nil {
+		return nil
+	}
+	if nested := attr.AttributeNestedType; nested != nil {
+		if d := describeBlock(&tfjson.SchemaBlock{Attributes: nested.Attributes}); len(d) > 0 {
+			return d
+		}
+	}
+	if attr.Description != "" {
+		return attr.Description
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package tfschema
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestApplyView_Descriptions(t *testing.T) {
+	block := testDescribedBlock()
+	block.Attributes["settings"].AttributeNestedType.Attributes["size"].Description = "Size in GB."
+	block.NestedBlocks["default_node_pool"].Block.Attributes["vm_size"].Description = "The VM size."
+	block.NestedBlocks["identity"].Block.Description = "Managed identity."
+
+	assert.Equal(t, map[string]any{
+		"name":              "The name of the cluster.",
+		"tags":              "A mapping of tags.",
+		"settings":          map[string]any{"size": "Size in GB."},
+		"default_node_pool": map[string]any{"vm_size": "The VM size."},
+		"identity":          "Managed identity.",
+	}, applyView(&tfjson.Schema{Block: block}, ViewDescriptions))
+
+	assert.Equal(t, map[string]any{"vm_size": "The VM size."}, applyView(block.NestedBlocks["default_node_pool"], ViewDescriptions))
+	assert.Equal(t, "Zones.", applyView(&tfjson.SchemaAttribute{AttributeType: cty.List(cty.String), Description: "Zones."}, ViewDescriptions))
+
+	matches, err := queryWildcardPath(block, "*.vm_size")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"default_node_pool.vm_size": "The VM size."}, applyView(matches, ViewDescriptions))
+}
+
+func TestQuerySchemaWithOptions_Descriptions(t *testing.T) {
+	stubSchemaSource(t)
+	original := fetchProviderSchema
+	fetchProviderSchema = func(req ProviderRequest) (*tfjson.ProviderSchema, error) {
+		schema, err := original(req)
+		require.NoError(t, err)
+		schema.ResourceSchemas["azurerm_resource_group"].Block.Attributes["location"].Description = "The Azure Region."
+		return schema, nil
+	}
+	defer func() {
+		fetchProviderSchema = original
+	}()
+
+	result, err := QuerySchemaWithOptions("resource", "azurerm_resource_group", "", cacheTestReq, QueryOptions{View: ViewDescriptions})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"location":"The Azure Region."}`, result)
+
+	result, err = QuerySchemaWithOptions("resource", "azurerm_resource_group", "location", cacheTestReq, QueryOptions{View: ViewDescriptions})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"The Azure Region."`, result)
+
+	_, err = QuerySchemaWithOptions("resource", "azurerm_resource_group", "", cacheTestReq, QueryOptions{View: ViewDescriptions, Format: FormatMarkdown})
+	assert.ErrorContains(t, err, "descriptions view is not supported")
+}
@@ -0,0 +1,60 @@
+package tfschema
+
+import (
+	"fmt"
+	"strings"
+
+	goversion "github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+)
+
+// embeddedProvider is a provider schema compiled into the binary through a generated schema module,
+// the module version follows the provider version it was generated from
+type embeddedProvider struct {
+	version string
+	schema  func() *tfjson.ProviderSchema
+}
+
+// embeddedProviders are served when the provider can't be resolved or downloaded, e.g. without network access or when
+// the offline mirror lacks the provider, keyed by lower-cased `namespace/name`. Package-level to allow test stubbing.
+var embeddedProviders = map[string]embeddedProvider{
+	"azure/azapi": {
+		version: azapi.ProviderVersion,
+		schema:  azapi.ProviderSchema,
+	},
+}
+
+func lookupEmbeddedProvider(providerReq ProviderRequest) (embeddedProvider, bool) {
+	p, ok := embeddedProviders[strings.ToLower(fmt.Sprintf("%s/%s", providerReq.ProviderNamespace, providerReq.ProviderName))]
+	return p, ok
+}
+
+// embeddedVersion returns the embedded version of a provider when it satisfies constraints
+func embeddedVersion(providerReq ProviderRequest, constraints goversion.Constraints) (*goversion.Version, bool) {
+	p, ok := lookupEmbeddedProvider(providerReq)
+	if !ok {
+		return nil, false
+	}
+	v, err := goversion.NewVersion(p.version)
+	if err != nil || (constraints != nil && !constraints.Check(v)) {
+		return nil, false
+	}
+	return v, true
+}
+
+// embeddedSchema returns the embedded schema of a provider when its version is exactly the resolved version
+func embeddedSchema(resolved ProviderRequest) (*tfjson.ProviderSchema, bool) {
+	p, ok := lookupEmbeddedProvider(resolved)
+	if !ok {
+		return nil, false
+	}
+	want, err := goversion.NewVersion(resolved.ProviderVersion)
+	if err != nil {
+		return nil, false
+	}
+	if v, err := goversion.NewVersion(p.version); err != nil || !v.Equal(want) {
+		return nil, false
+	}
+	return p.schema(), true
+}
@@ -0,0 +1,79 @@
+package tfschema
+
+import (
+	"errors"
+	"testing"
+
+	goversion "github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var embeddedTestReq = ProviderRequest{ProviderNamespace: "Azure", ProviderName: "azapi"}
+
+func stubUnreachableRegistry(t *testing.T) {
+	stubSchemaSource(t)
+	stubs := gostub.Stub(&availableVersions, func(ProviderRequest) (goversion.Collection, error) {
+		return nil, errors.New("registry unreachable")
+	}).Stub(&fetchProviderSchema, func(ProviderRequest) (*tfjson.ProviderSchema, error) {
+		return nil, errors.New("registry unreachable")
+	})
+	t.Cleanup(stubs.Reset)
+}
+
+func TestResolveVersion_EmbeddedFallback(t *testing.T) {
+	stubUnreachableRegistry(t)
+
+	tests := []struct {
+		name     string
+		version  string
+		expected string
+		err      bool
+	}{
+		{name: "latest", version: "", expected: "2.5.0"},
+		{name: "matching constraint", version: "~> 2.0", expected: "2.5.0"},
+		{name: "exact version", version: "2.5.0", expected: "2.5.0"},
+		{name: "constraint excludes embedded version", version: ">= 3.0", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := embeddedTestReq
+			req.ProviderVersion = tt.version
+			resolved, err := ResolveVersion(req)
+			if tt.err {
+				assert.ErrorContains(t, err, "registry unreachable")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved.ProviderVersion)
+		})
+	}
+
+	_, err := ResolveVersion(ProviderRequest{ProviderNamespace: "hashicorp", ProviderName: "azurerm"})
+	assert.ErrorContains(t, err, "registry unreachable")
+}
+
+func TestProviderSchema_EmbeddedFallback(t *testing.T) {
+	stubUnreachableRegistry(t)
+
+	schema, err := providerSchema(embeddedTestReq)
+	require.NoError(t, err)
+	assert.Contains(t, schema.ResourceSchemas, "azapi_resource")
+	assert.Contains(t, schema.DataSourceSchemas, "azapi_client_config")
+
+	req := embeddedTestReq
+	req.ProviderVersion = "2.4.0"
+	_, err = providerSchema(req)
+	assert.ErrorContains(t, err, "registry unreachable")
+}
+
+func TestProviderSchema_DownloadPreferredOverEmbedded(t *testing.T) {
+	downloads := stubSchemaSource(t, "2.5.0")
+
+	schema, err := providerSchema(embeddedTestReq)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *downloads)
+	assert.NotContains(t, schema.ResourceSchemas, "azapi_resource")
+}
@@ -0,0 +1,109 @@
+package tfschema
+
+import (
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// WriteOnlyAttribute is a write-only attribute of a resource. VersionAttribute is the companion attribute, like
+// `password_wo_version` for `password_wo`, whose change triggers an update since write-only values are never compared.
+type WriteOnlyAttribute struct {
+	Path             string `json:"path"`
+	Required         bool   `json:"required,omitempty"`
+	VersionAttribute string `json:"version_attribute,omitempty"`
+}
+
+// WriteOnlyResource is a resource exposing write-only attributes
+type WriteOnlyResource struct {
+	Type       string               `json:"type"`
+	Attributes []WriteOnlyAttribute `json:"attributes"`
+}
+
+// EphemeralSource is an ephemeral resource whose results can feed write-only attributes
+type EphemeralSource struct {
+	Type string `json:"type"`
+	// Outputs are the computed attributes of the ephemeral resource, sensitive ones are usually the secrets to feed
+	Outputs          []string `json:"outputs,omitempty"`
+	SensitiveOutputs []string `json:"sensitive_outputs,omitempty"`
+}
+
+// EphemeralCompatibilityReport lists the resources of a provider version that accept ephemeral values through
+// write-only attributes, and the ephemeral resources that can produce such values
+type EphemeralCompatibilityReport struct {
+	Provider           string              `json:"provider"`
+	Version            string              `json:"version"`
+	WriteOnlyResources []WriteOnlyResource `json:"write_only_resources"`
+	EphemeralResources []EphemeralSource   `json:"ephemeral_resources"`
+}
+
+// ReportEphemeralCompatibility walks the resources and ephemeral resources of a provider to help migrating secrets
+// handling to ephemeral values
+func ReportEphemeralCompatibility(providerReq ProviderRequest) (*EphemeralCompatibilityReport, error) {
+	report := &EphemeralCompatibilityReport{
+		WriteOnlyResources: []WriteOnlyResource{},
+		EphemeralResources: []EphemeralSource{},
+	}
+	resolved, err := walkSchemas("resource", "", providerReq, func(_, typeName string, block *tfjson.SchemaBlock) {
+		if attributes := writeOnlyAttributes(typeName, block); len(attributes) > 0 {
+			report.WriteOnlyResources = append(report.WriteOnlyResources, WriteOnlyResource{Type: typeName, Attributes: attributes})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = walkSchemas("ephemeral", "", resolved, func(_, typeName string, block *tfjson.SchemaBlock) {
+		source := EphemeralSource{Type: typeName}
+		for _, n := range sortedKeys(block.Attributes) {
+			attr := block.Attributes[n]
+			if !attr.Computed {
+				continue
+			}
+			if attr.Sensitive {
+				source.SensitiveOutputs = append(source.SensitiveOutputs, n)
+			} else {
+				source.Outputs = append(source.Outputs, n)
+			}
+		}
+		report.EphemeralResources = append(report.EphemeralResources, source)
+	}); err != nil {
+		return nil, err
+	}
+	report.Provider = fmt.Sprintf("%s/%s", resolved.ProviderNamespace, resolved.ProviderName)
+	report.Version = resolved.ProviderVersion
+	return report, nil
+}
+
+// writeOnlyAttributes collects the write-only attributes of a resource schema with their version companion attributes
+func writeOnlyAttributes(typeName string, block *tfjson.SchemaBlock) []WriteOnlyAttribute {
+	var attributes []WriteOnlyAttribute
+	for _, item := range sensitiveInBlock("resource", typeName, "", block, SensitiveFilterWriteOnly) {
+		attr := WriteOnlyAttribute{Path: item.Path, Required: item.Required}
+		if companion := item.Path + "_version"; hasAttributePath(block, companion) {
+			attr.VersionAttribute = companion
+		}
+		attributes = append(attributes, attr)
+	}
+	return attributes
+}
+
+// hasAttributePath reports whether a dot-separated path addresses an attribute of block
+func hasAttributePath(block *tfjson.SchemaBlock, path string) bool {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		if block == nil {
+			return false
+		}
+		if i == len(segments)-1 {
+			_, ok := block.Attributes[segment]
+			return ok
+		}
+		nested, ok := block.NestedBlocks[segment]
+		if !ok || nested == nil {
+			return false
+		}
+		block = nested.Block
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package tfschema
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestReportEphemeralCompatibility(t *testing.T) {
+	stubSchemaSource(t)
+	original := fetchProviderSchema
+	fetchProviderSchema = func(req ProviderRequest) (*tfjson.ProviderSchema, error) {
+		schema, err := original(req)
+		require.NoError(t, err)
+		db := testResourceBlock()
+		db.Attributes["password_wo"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, Sensitive: true, WriteOnly: true}
+		db.Attributes["password_wo_version"] = &tfjson.SchemaAttribute{AttributeType: cty.Number, Optional: true}
+		db.NestedBlocks["identity"].Block.Attributes["secret_wo"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Required: true, WriteOnly: true}
+		schema.ResourceSchemas["azurerm_mssql_server"] = &tfjson.Schema{Block: db}
+		schema.EphemeralResourceSchemas = map[string]*tfjson.Schema{
+			"azurerm_key_vault_secret": {Block: &tfjson.SchemaBlock{Attributes: map[string]*tfjson.SchemaAttribute{
+				"name":         {AttributeType: cty.String, Required: true},
+				"value":        {AttributeType: cty.String, Computed: true, Sensitive: true},
+				"content_type": {AttributeType: cty.String, Computed: true},
+			}}},
+		}
+		return schema, nil
+	}
+	defer func() {
+		fetchProviderSchema = original
+	}()
+
+	report, err := ReportEphemeralCompatibility(ProviderRequest{ProviderNamespace: "hashicorp", ProviderName: "azurerm", ProviderVersion: "4.39.0"})
+	require.NoError(t, err)
+	assert.Equal(t, &EphemeralCompatibilityReport{
+		Provider: "hashicorp/azurerm",
+		Version:  "4.39.0",
+		WriteOnlyResources: []WriteOnlyResource{{
+			Type: "azurerm_mssql_server",
+			Attributes: []WriteOnlyAttribute{
+				{Path: "password_wo", VersionAttribute: "password_wo_version"},
+				{Path: "identity.secret_wo", Required: true},
+			},
+		}},
+		EphemeralResources: []EphemeralSource{{
+			Type:             "azurerm_key_vault_secret",
+			Outputs:          []string{"content_type"},
+			SensitiveOutputs: []string{"value"},
+		}},
+	}, report)
+}
+
+func TestHasAttributePath(t *testing.T) {
+	block := testResourceBlock()
+	assert.True(t, hasAttributePath(block, "name"))
+	assert.True(t, hasAttributePath(block, "default_node_pool.vm_size"))
+	assert.False(t, hasAttributePath(block, "default_node_pool"))
+	assert.False(t, hasAttributePath(block, "missing.name"))
+}
@@ -0,0 +1,116 @@
+package tfschema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Paths addressing parts of a function signature
+const (
+	functionPathParameters = "parameters"
+	functionPathVariadic   = "variadic_parameter"
+	functionPathReturnType = "return_type"
+)
+
+// functionParameterPattern matches `parameters[<index>]` and `parameters.<name>`
+var functionParameterPattern = regexp.MustCompile(`^parameters(?:\[(\d+)\]|\.([A-Za-z0-9_]+))$`)
+
+// functionParameterResult is a parameter returned by a function path query, with its position in the call
+type functionParameterResult struct {
+	Position    int      `json:"position"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Type        cty.Type `json:"type"`
+	IsNullable  bool     `json:"is_nullable,omitempty"`
+	// Variadic is set for the parameter accepting any number of trailing arguments
+	Variadic bool `json:"variadic,omitempty"`
+}
+
+// validateFunctionPath checks the syntax of a function path, it's done before loading the provider schema
+func validateFunctionPath(path string) error {
+	switch path {
+	case functionPathParameters, functionPathVariadic, functionPathReturnType:
+		return nil
+	}
+	if functionParameterPattern.MatchString(path) {
+		return nil
+	}
+	return fmt.Errorf("invalid function path %q, must be one of: %s, %s[<index>], %s.<name>, %s, %s", path,
+		functionPathParameters, functionPathParameters, functionPathParameters, functionPathVariadic, functionPathReturnType)
+}
+
+// queryFunctionPath returns the part of a function signature addressed by path: `parameters`, a single parameter as
+// `parameters[0]` or `parameters.resource_type`, `variadic_parameter` or `return_type`
+func queryFunctionPath(name string, signature *tfjson.FunctionSignature, path string) (any, error) {
+	if err := validateFunctionPath(path); err != nil {
+		return nil, err
+	}
+	switch path {
+	case functionPathReturnType:
+		return map[string]cty.Type{"return_type": signature.ReturnType}, nil
+	case functionPathParameters:
+		parameters := make([]*functionParameterResult, 0, len(signature.Parameters)+1)
+		for i, p := range signature.Parameters {
+			parameters = append(parameters, newFunctionParameterResult(i, p, false))
+		}
+		if signature.VariadicParameter != nil {
+			parameters = append(parameters, newFunctionParameterResult(len(signature.Parameters), signature.VariadicParameter, true))
+		}
+		return parameters, nil
+	case functionPathVariadic:
+		if signature.VariadicParameter == nil {
+			return nil, fmt.Errorf("function %s has no variadic parameter", name)
+		}
+		return newFunctionParameterResult(len(signature.Parameters), signature.VariadicParameter, true), nil
+	}
+
+	match := functionParameterPattern.FindStringSubmatch(path)
+	if match[1] != "" {
+		index, err := strconv.Atoi(match[1])
+		if err != nil || index >= len(signature.Parameters) {
+			return nil, fmt.Errorf("parameter index %s out of range, function %s has %d parameters: %s", match[1], name, len(signature.Parameters), parameterNames(signature))
+		}
+		return newFunctionParameterResult(index, signature.Parameters[index], false), nil
+	}
+	for i, p := range signature.Parameters {
+		if p != nil && p.Name == match[2] {
+			return newFunctionParameterResult(i, p, false), nil
+		}
+	}
+	if v := signature.VariadicParameter; v != nil && v.Name == match[2] {
+		return newFunctionParameterResult(len(signature.Parameters), v, true), nil
+	}
+	return nil, fmt.Errorf("parameter %s not found, function %s has parameters: %s", match[2], name, parameterNames(signature))
+}
+
+func newFunctionParameterResult(position int, p *tfjson.FunctionParameter, variadic bool) *functionParameterResult {
+	if p == nil {
+		return &functionParameterResult{Position: position, Type: cty.DynamicPseudoType, Variadic: variadic}
+	}
+	return &functionParameterResult{
+		Position:    position,
+		Name:        p.Name,
+		Description: p.Description,
+		Type:        p.Type,
+		IsNullable:  p.IsNullable,
+		Variadic:    variadic,
+	}
+}
+
+func parameterNames(signature *tfjson.FunctionSignature) string {
+	names := make([]string, 0, len(signature.Parameters))
+	for _, p := range signature.Parameters {
+		if p != nil {
+			names = append(names, p.Name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,88 @@
+package tfschema
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func testFunctionSignature() *tfjson.FunctionSignature {
+	return &tfjson.FunctionSignature{
+		Description: "Builds a resource ID.",
+		ReturnType:  cty.String,
+		Parameters: []*tfjson.FunctionParameter{
+			{Name: "parent_id", Type: cty.String, Description: "The parent ID."},
+			{Name: "resource_type", Type: cty.String, Description: "The resource type."},
+			{Name: "name", Type: cty.String, Description: "The resource name."},
+		},
+	}
+}
+
+func TestQueryFunctionPath(t *testing.T) {
+	signature := testFunctionSignature()
+	signature.VariadicParameter = &tfjson.FunctionParameter{Name: "segments", Type: cty.String, IsNullable: true}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected any
+	}{
+		{name: "by index", path: "parameters[1]", expected: &functionParameterResult{Position: 1, Name: "resource_type", Description: "The resource type.", Type: cty.String}},
+		{name: "by name", path: "parameters.name", expected: &functionParameterResult{Position: 2, Name: "name", Description: "The resource name.", Type: cty.String}},
+		{name: "variadic by name", path: "parameters.segments", expected: &functionParameterResult{Position: 3, Name: "segments", Type: cty.String, IsNullable: true, Variadic: true}},
+		{name: "variadic", path: "variadic_parameter", expected: &functionParameterResult{Position: 3, Name: "segments", Type: cty.String, IsNullable: true, Variadic: true}},
+		{name: "return type", path: "return_type", expected: map[string]cty.Type{"return_type": cty.String}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := queryFunctionPath("build_resource_id", signature, tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	result, err := queryFunctionPath("build_resource_id", signature, "parameters")
+	require.NoError(t, err)
+	assert.Len(t, result, 4)
+}
+
+func TestQueryFunctionPath_Errors(t *testing.T) {
+	signature := testFunctionSignature()
+
+	_, err := queryFunctionPath("build_resource_id", signature, "parameters[3]")
+	assert.ErrorContains(t, err, "out of range, function build_resource_id has 3 parameters: parent_id, resource_type, name")
+	_, err = queryFunctionPath("build_resource_id", signature, "parameters.id")
+	assert.ErrorContains(t, err, "parameter id not found")
+	_, err = queryFunctionPath("build_resource_id", signature, "variadic_parameter")
+	assert.ErrorContains(t, err, "has no variadic parameter")
+	_, err = queryFunctionPath("build_resource_id", signature, "parameters[x]")
+	assert.ErrorContains(t, err, "invalid function path")
+}
+
+func TestQuerySchema_FunctionPath(t *testing.T) {
+	stubSchemaSource(t)
+	original := fetchProviderSchema
+	fetchProviderSchema = func(req ProviderRequest) (*tfjson.ProviderSchema, error) {
+		schema, err := original(req)
+		require.NoError(t, err)
+		schema.Functions["build_resource_id"] = testFunctionSignature()
+		return schema, nil
+	}
+	defer func() {
+		fetchProviderSchema = original
+	}()
+
+	result, err := QuerySchema("function", "build_resource_id", "parameters[0]", cacheTestReq)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"position":0,"name":"parent_id","description":"The parent ID.","type":"string"}`, result)
+
+	result, err = QuerySchema("function", "build_resource_id", "return_type", cacheTestReq)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"return_type":"string"}`, result)
+
+	_, err = QuerySchema("function", "build_resource_id", "parameters[5]", cacheTestReq)
+	assert.ErrorContains(t, err, "failed to query path parameters[5] in function build_resource_id")
+}
@@ -0,0 +1,186 @@
+package tfschema
+
+import (
+	"slices"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// jsonSchemaDialect is the JSON Schema draft schemas are converted to
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is a JSON Schema draft 2020-12 document or subschema
+type jsonSchema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Type is empty for dynamic values, which accept any JSON value
+	Type       string                 `json:"type,omitempty"`
+	Properties map[string]*jsonSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+	// AdditionalProperties is false for blocks and objects, or the schema of map values
+	AdditionalProperties any           `json:"additionalProperties,omitempty"`
+	PrefixItems          []*jsonSchema `json:"prefixItems,omitempty"`
+	// Items is false for tuples, or the schema of list and set elements
+	Items       any                    `json:"items,omitempty"`
+	MinItems    uint64                 `json:"minItems,omitempty"`
+	MaxItems    uint64                 `json:"maxItems,omitempty"`
+	UniqueItems bool                   `json:"uniqueItems,omitempty"`
+	Deprecated  bool                   `json:"deprecated,omitempty"`
+	ReadOnly    bool                   `json:"readOnly,omitempty"`
+	WriteOnly   bool                   `json:"writeOnly,omitempty"`
+	Defs        map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// toJSONSchema converts a schema, block, nested block, attribute or wildcard matches returned by a query to a JSON Schema
+// document describing the JSON form of the configuration. Computed-only attributes are marked readOnly and write-only
+// attributes writeOnly, wildcard matches are rendered as `$defs` keyed by path.
+func toJSONSchema(title string, value any) *jsonSchema {
+	var document *jsonSchema
+	switch v := value.(type) {
+	case *tfjson.Schema:
+		document = blockJSONSchema(v.Block)
+	case *tfjson.SchemaBlock:
+		document = blockJSONSchema(v)
+	case *tfjson.SchemaBlockType:
+		document = blockTypeJSONSchema(v)
+	case *tfjson.SchemaAttribute:
+		document = attributeJSONSchema(v)
+	case PathMatches:
+		return toJSONSchema(title, map[string]any(v))
+	case map[string]any:
+		document = &jsonSchema{Defs: make(map[string]*jsonSchema, len(v))}
+		for path, match := range v {
+			document.Defs[path] = toJSONSchema("", match)
+		}
+	default:
+		document = &jsonSchema{}
+	}
+	if title != "" {
+		document.Schema = jsonSchemaDialect
+		document.Title = title
+	}
+	return document
+}
+
+func blockJSONSchema(block *tfjson.SchemaBlock) *jsonSchema {
+	s := &jsonSchema{Type: "object", AdditionalProperties: false}
+	if block == nil {
+		return s
+	}
+	s.Description = block.Description
+	s.Deprecated = block.Deprecated
+	s.Properties = make(map[string]*jsonSchema, len(block.Attributes)+len(block.NestedBlocks))
+	for name, attr := range block.Attributes {
+		if attr == nil {
+			continue
+		}
+		s.Properties[name] = attributeJSONSchema(attr)
+		if attr.Required {
+			s.Required = append(s.Required, name)
+		}
+	}
+	for name, nested := range block.NestedBlocks {
+		if nested == nil {
+			continue
+		}
+		s.Properties[name] = blockTypeJSONSchema(nested)
+		if nested.MinItems > 0 {
+			s.Required = append(s.Required, name)
+		}
+	}
+	slices.Sort(s.Required)
+	return s
+}
+
+func blockTypeJSONSchema(block *tfjson.SchemaBlockType) *jsonSchema {
+	body := blockJSONSchema(block.Block)
+	switch block.NestingMode {
+	case tfjson.SchemaNestingModeSingle, tfjson.SchemaNestingModeGroup:
+		return body
+	case tfjson.SchemaNestingModeMap:
+		return &jsonSchema{Type: "object", AdditionalProperties: body}
+	}
+	return &jsonSchema{
+		Type:        "array",
+		Items:       body,
+		MinItems:    block.MinItems,
+		MaxItems:    block.MaxItems,
+		UniqueItems: block.NestingMode == tfjson.SchemaNestingModeSet,
+	}
+}
+
+func attributeJSONSchema(attr *tfjson.SchemaAttribute) *jsonSchema {
+	var s *jsonSchema
+	if nested := attr.AttributeNestedType; nested != nil {
+		s = nestedAttributeJSONSchema(nested)
+	} else {
+		s = ctyJSONSchema(attr.AttributeType)
+	}
+	s.Description = attr.Description
+	s.Deprecated = attr.Deprecated
+	s.ReadOnly = attr.Computed && !attr.Optional && !attr.Required
+	s.WriteOnly = attr.WriteOnly
+	return s
+}
+
+func nestedAttributeJSONSchema(nested *tfjson.SchemaNestedAttributeType) *jsonSchema {
+	object := blockJSONSchema(&tfjson.SchemaBlock{Attributes: nested.Attributes})
+	switch nested.NestingMode {
+	case tfjson.SchemaNestingModeList, tfjson.SchemaNestingModeSet:
+		return &jsonSchema{
+			Type:        "array",
+			Items:       object,
+			MinItems:    nested.MinItems,
+			MaxItems:    nested.MaxItems,
+			UniqueItems: nested.NestingMode == tfjson.SchemaNestingModeSet,
+		}
+	case tfjson.SchemaNestingModeMap:
+		return &jsonSchema{Type: "object", AdditionalProperties: object}
+	}
+	return object
+}
+
+// ctyJSONSchema converts a type constraint, object attributes are required unless declared optional
+func ctyJSONSchema(t cty.Type) *jsonSchema {
+	switch {
+	case t == cty.String:
+		return &jsonSchema{Type: "string"}
+	case t == cty.Number:
+		return &jsonSchema{Type: "number"}
+	case t == cty.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case t.IsListType():
+		return &jsonSchema{Type: "array", Items: ctyJSONSchema(t.ElementType())}
+	case t.IsSetType():
+		return &jsonSchema{Type: "array", Items: ctyJSONSchema(t.ElementType()), UniqueItems: true}
+	case t.IsMapType():
+		return &jsonSchema{Type: "object", AdditionalProperties: ctyJSONSchema(t.ElementType())}
+	case t.IsObjectType():
+		s := &jsonSchema{Type: "object", AdditionalProperties: false, Properties: map[string]*jsonSchema{}}
+		for name, attrType := range t.AttributeTypes() {
+			s.Properties[name] = ctyJSONSchema(attrType)
+			if !t.AttributeOptional(name) {
+				s.Required = append(s.Required, name)
+			}
+		}
+		slices.Sort(s.Required)
+		return s
+	case t.IsTupleType():
+		s := &jsonSchema{Type: "array", Items: false}
+		for _, elementType := range t.TupleElementTypes() {
+			s.PrefixItems = append(s.PrefixItems, ctyJSONSchema(elementType))
+		}
+		s.MinItems = uint64(len(s.PrefixItems))
+		return s
+	}
+	// cty.DynamicPseudoType and unset types accept any value
+	return &jsonSchema{}
+}
+
+// jsonSchemaTitle turns a Markdown title into plain text
+func jsonSchemaTitle(title string) string {
+	return strings.ReplaceAll(title, "`", "")
+}
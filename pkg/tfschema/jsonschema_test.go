@@ -0,0 +1,106 @@
+package tfschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestToJSONSchema_Block(t *testing.T) {
+	block := testResourceBlock()
+	block.Attributes["password_wo"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, WriteOnly: true}
+
+	s := toJSONSchema("azurerm_kubernetes_cluster", &tfjson.Schema{Block: block})
+	assert.Equal(t, jsonSchemaDialect, s.Schema)
+	assert.Equal(t, "azurerm_kubernetes_cluster", s.Title)
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, false, s.AdditionalProperties)
+	assert.Equal(t, []string{"default_node_pool", "location", "name"}, s.Required)
+
+	assert.Equal(t, &jsonSchema{Type: "object", AdditionalProperties: &jsonSchema{Type: "string"}}, s.Properties["tags"])
+	assert.True(t, s.Properties["id"].ReadOnly)
+	assert.True(t, s.Properties["legacy"].Deprecated)
+	assert.True(t, s.Properties["password_wo"].WriteOnly)
+	assert.Equal(t, &jsonSchema{
+		Type:                 "object",
+		AdditionalProperties: false,
+		Properties:           map[string]*jsonSchema{"size": {Type: "number"}},
+		Required:             []string{"size"},
+	}, s.Properties["settings"])
+
+	pool := s.Properties["default_node_pool"]
+	assert.Equal(t, "array", pool.Type)
+	assert.Equal(t, uint64(1), pool.MinItems)
+	assert.Equal(t, uint64(1), pool.MaxItems)
+	assert.Equal(t, []string{"vm_size"}, pool.Items.(*jsonSchema).Required)
+	identityIDs := s.Properties["identity"].Items.(*jsonSchema).Properties["identity_ids"]
+	assert.Equal(t, &jsonSchema{Type: "array", Items: &jsonSchema{Type: "string"}, UniqueItems: true}, identityIDs)
+}
+
+func TestCtyJSONSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		t        cty.Type
+		expected string
+	}{
+		{name: "bool", t: cty.Bool, expected: `{"type":"boolean"}`},
+		{name: "dynamic", t: cty.DynamicPseudoType, expected: `{}`},
+		{name: "list", t: cty.List(cty.Number), expected: `{"type":"array","items":{"type":"number"}}`},
+		{
+			name:     "object with optional attribute",
+			t:        cty.ObjectWithOptionalAttrs(map[string]cty.Type{"a": cty.String, "b": cty.Bool}, []string{"b"}),
+			expected: `{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"boolean"}},"required":["a"],"additionalProperties":false}`,
+		},
+		{
+			name:     "tuple",
+			t:        cty.Tuple([]cty.Type{cty.String, cty.Number}),
+			expected: `{"type":"array","prefixItems":[{"type":"string"},{"type":"number"}],"items":false,"minItems":2}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := json.Marshal(ctyJSONSchema(tt.t))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(actual))
+		})
+	}
+}
+
+func TestToJSONSchema_PathResults(t *testing.T) {
+	block := testResourceBlock()
+	matches, err := queryWildcardPath(block, "*.vm_size")
+	require.NoError(t, err)
+
+	s := toJSONSchema("vm sizes", matches)
+	assert.Equal(t, map[string]*jsonSchema{"default_node_pool.vm_size": {Type: "string"}}, s.Defs)
+
+	s = toJSONSchema("identity", block.NestedBlocks["identity"])
+	assert.Equal(t, "array", s.Type)
+	assert.Equal(t, uint64(1), s.MaxItems)
+}
+
+func TestQuerySchemaWithOptions_JSONSchema(t *testing.T) {
+	stubSchemaSource(t)
+
+	result, err := QuerySchemaWithOptions("resource", "azurerm_resource_group", "", cacheTestReq, QueryOptions{Format: FormatJSONSchema})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "azurerm_resource_group",
+		"type": "object",
+		"properties": {"location": {"type": "string"}, "name": {"type": "string"}},
+		"required": ["location", "name"],
+		"additionalProperties": false
+	}`, result)
+
+	result, err = QuerySchemaWithOptions("resource", "azurerm_resource_group", "name", cacheTestReq, QueryOptions{Format: FormatJSONSchema})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"$schema": "https://json-schema.org/draft/2020-12/schema", "title": "azurerm_resource_group name", "type": "string"}`, result)
+
+	_, err = QuerySchemaWithOptions("resource", "azurerm_resource_group", "", cacheTestReq, QueryOptions{Format: FormatJSONSchema, View: ViewNamesAndTypes})
+	assert.ErrorContains(t, err, "not supported by the json_schema format")
+}
@@ -0,0 +1,97 @@
+package tfschema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ListItemsOptions narrows down the items returned by ListItemsWithMetadata. Filters are combined with AND.
+type ListItemsOptions struct {
+	Prefix   string
+	Contains string
+	Regex    string
+}
+
+// FilterItems returns the sorted items matching the filters of opts
+func FilterItems(items []string, opts ListItemsOptions) ([]string, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	var pattern *regexp.Regexp
+	if opts.Regex != "" {
+		pattern = regexp.MustCompile(opts.Regex)
+	}
+
+	matched := make([]string, 0, len(items))
+	for _, item := range items {
+		if !strings.HasPrefix(item, opts.Prefix) || !strings.Contains(item, opts.Contains) {
+			continue
+		}
+		if pattern != nil && !pattern.MatchString(item) {
+			continue
+		}
+		matched = append(matched, item)
+	}
+	return matched, nil
+}
+
+func (o ListItemsOptions) validate() error {
+	if o.Regex != "" {
+		if _, err := regexp.Compile(o.Regex); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", o.Regex, err)
+		}
+	}
+	return nil
+}
+
+// ItemList is the filtered items of one category with metadata about the provider
+type ItemList struct {
+	Provider string `json:"provider"`
+	// Version is the resolved provider version
+	Version  string   `json:"version"`
+	Category string   `json:"category"`
+	Items    []string `json:"items"`
+	// Counts is the number of items of every category before filtering
+	Counts map[string]int `json:"counts"`
+}
+
+// listCategories are the categories counted in ItemList
+var listCategories = []string{"resource", "data", "ephemeral", "function"}
+
+// ListItemsWithMetadata lists the items of a category matching the filters of opts, along with the resolved provider version and item counts per category
+func ListItemsWithMetadata(category string, providerReq ProviderRequest, opts ListItemsOptions) (*ItemList, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	resolved, err := ResolveVersion(providerReq)
+	if err != nil {
+		return nil, err
+	}
+	list := &ItemList{
+		Provider: fmt.Sprintf("%s/%s", resolved.ProviderNamespace, resolved.ProviderName),
+		Version:  resolved.ProviderVersion,
+		Category: category,
+		Counts:   make(map[string]int, len(listCategories)),
+	}
+	var items []string
+	for _, c := range listCategories {
+		categoryItems, err := ListItems(c, resolved)
+		if err != nil {
+			return nil, err
+		}
+		list.Counts[c] = len(categoryItems)
+		if c == category {
+			items = categoryItems
+		}
+	}
+	if _, ok := list.Counts[category]; !ok {
+		return nil, errors.New("unknown category, must be one of 'resource', 'data', 'ephemeral', or 'function'")
+	}
+	list.Items, err = FilterItems(items, opts)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,104 @@
+package tfschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testItems = []string{
+	"aws_instance",
+	"aws_lambda_function",
+	"aws_lambda_permission",
+	"aws_s3_bucket",
+	"aws_s3_bucket_policy",
+	"aws_vpc",
+}
+
+func TestFilterItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     ListItemsOptions
+		expected []string
+	}{
+		{
+			name:     "no filter returns all items",
+			opts:     ListItemsOptions{},
+			expected: testItems,
+		},
+		{
+			name:     "prefix",
+			opts:     ListItemsOptions{Prefix: "aws_lambda_"},
+			expected: []string{"aws_lambda_function", "aws_lambda_permission"},
+		},
+		{
+			name:     "substring",
+			opts:     ListItemsOptions{Contains: "bucket"},
+			expected: []string{"aws_s3_bucket", "aws_s3_bucket_policy"},
+		},
+		{
+			name:     "regex",
+			opts:     ListItemsOptions{Regex: `_(vpc|instance)$`},
+			expected: []string{"aws_instance", "aws_vpc"},
+		},
+		{
+			name:     "filters are combined",
+			opts:     ListItemsOptions{Prefix: "aws_s3", Regex: `policy`},
+			expected: []string{"aws_s3_bucket_policy"},
+		},
+		{
+			name:     "no match",
+			opts:     ListItemsOptions{Contains: "azurerm"},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := FilterItems(testItems, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, items)
+		})
+	}
+}
+
+func TestFilterItems_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          ListItemsOptions
+		errorContains string
+	}{
+		{name: "invalid regex", opts: ListItemsOptions{Regex: "aws_("}, errorContains: "invalid regex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FilterItems(testItems, tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestListItemsWithMetadata(t *testing.T) {
+	stubSchemaSource(t, "4.38.0", "4.39.0")
+
+	list, err := ListItemsWithMetadata("resource", ProviderRequest{ProviderNamespace: "hashicorp", ProviderName: "azurerm", ProviderVersion: "~> 4.0"}, ListItemsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, &ItemList{
+		Provider: "hashicorp/azurerm",
+		Version:  "4.39.0",
+		Category: "resource",
+		Items:    []string{"azurerm_resource_group"},
+		Counts:   map[string]int{"resource": 1, "data": 1, "ephemeral": 0, "function": 1},
+	}, list)
+
+	content, err := json.Marshal(list)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"provider":"hashicorp/azurerm","version":"4.39.0","category":"resource","items":["azurerm_resource_group"],"counts":{"resource":1,"data":1,"ephemeral":0,"function":1}}`, string(content))
+
+	_, err = ListItemsWithMetadata("module", cacheTestReq, ListItemsOptions{})
+	assert.ErrorContains(t, err, "unknown category")
+}
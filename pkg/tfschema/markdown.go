@@ -0,0 +1,182 @@
+package tfschema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Output formats of schema queries
+const (
+	// FormatJSON renders schemas as compact JSON
+	FormatJSON = "json"
+	// FormatMarkdown renders attribute tables as Markdown, ready to paste into PR descriptions and docs
+	FormatMarkdown = "markdown"
+	// FormatJSONSchema renders schemas as JSON Schema draft 2020-12 documents for external validators and form generators
+	FormatJSONSchema = "json_schema"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatJSON, FormatMarkdown, FormatJSONSchema}
+
+func validateFormat(format, view string) error {
+	if !slices.Contains(Formats, format) {
+		return fmt.Errorf("unknown format %q, must be one of: %s", format, strings.Join(Formats, ", "))
+	}
+	if format != FormatJSON && (view == ViewNamesAndTypes || view == ViewDescriptions) {
+		return fmt.Errorf("the %s view is not supported by the %s format", view, format)
+	}
+	return nil
+}
+
+// renderMarkdown renders a schema, block, nested block, attribute or wildcard matches returned by a query as
+// Markdown attribute tables. title is the heading of the document and path the queried path, if any.
+func renderMarkdown(title, path string, value any) string {
+	w := &markdownWriter{}
+	w.heading(2, title)
+	switch v := value.(type) {
+	case *tfjson.Schema:
+		if operations := timeoutOperations(v.Block); operations != nil {
+			w.paragraph(fmt.Sprintf("Configurable timeouts: `%s`", strings.Join(operations, "`, `")))
+		}
+		w.block(path, v.Block)
+	case *tfjson.SchemaBlock:
+		w.block(path, v)
+	case *tfjson.SchemaBlockType:
+		w.paragraph(fmt.Sprintf("_%s_", blockCardinality(v)))
+		w.block(path, v.Block)
+	case *tfjson.SchemaAttribute:
+		w.attributes(parentPath(path), map[string]*tfjson.SchemaAttribute{lastSegment(path): v})
+	case PathMatches:
+		w.matches(v)
+	case map[string]any:
+		w.matches(v)
+	}
+	return strings.TrimRight(w.String(), "\n") + "\n"
+}
+
+type markdownWriter struct {
+	strings.Builder
+}
+
+func (w *markdownWriter) heading(level int, text string) {
+	w.WriteString(strings.Repeat("#", level) + " " + text + "\n\n")
+}
+
+func (w *markdownWriter) paragraph(text string) {
+	w.WriteString(text + "\n\n")
+}
+
+// block writes the attribute table of block followed by a section for each nested attribute and nested block,
+// path is the path of block itself
+func (w *markdownWriter) block(path string, block *tfjson.SchemaBlock) {
+	if block == nil {
+		return
+	}
+	if block.Deprecated {
+		w.paragraph("**Deprecated**")
+	}
+	w.attributes(path, block.Attributes)
+	for _, name := range sortedKeys(block.NestedBlocks) {
+		nested := block.NestedBlocks[name]
+		if nested == nil {
+			continue
+		}
+		w.heading(3, fmt.Sprintf("Block `%s`", joinPath(path, name)))
+		w.paragraph(fmt.Sprintf("_%s_", blockCardinality(nested)))
+		w.block(joinPath(path, name), nested.Block)
+	}
+}
+
+// attributes writes a table of attributes, required attributes first, then a section for each nested attribute
+func (w *markdownWriter) attributes(path string, attributes map[string]*tfjson.SchemaAttribute) {
+	if len(attributes) == 0 {
+		return
+	}
+	names := sortedKeys(attributes)
+	slices.SortStableFunc(names, func(a, b string) int {
+		return boolRank(attributes[b].Required) - boolRank(attributes[a].Required)
+	})
+
+	w.WriteString("| Name | Type | Required | Description |\n| --- | --- | --- | --- |\n")
+	for _, name := range names {
+		attr := attributes[name]
+		w.WriteString(fmt.Sprintf("| `%s` | `%s` | %s | %s |\n", name, attributeTypeString(attr), attributePresence(attr), markdownDescription(attr)))
+	}
+	w.WriteString("\n")
+
+	for _, name := range names {
+		if nested := attributes[name].AttributeNestedType; nested != nil {
+			w.heading(3, fmt.Sprintf("Attribute `%s`", joinPath(path, name)))
+			w.attributes(joinPath(path, name), nested.Attributes)
+		}
+	}
+}
+
+func (w *markdownWriter) matches(matches map[string]any) {
+	for _, path := range sortedKeys(matches) {
+		switch v := matches[path].(type) {
+		case *tfjson.SchemaAttribute:
+			w.heading(3, fmt.Sprintf("Attribute `%s`", path))
+			w.attributes(parentPath(path), map[string]*tfjson.SchemaAttribute{lastSegment(path): v})
+		case *tfjson.SchemaBlockType:
+			w.heading(3, fmt.Sprintf("Block `%s`", path))
+			w.paragraph(fmt.Sprintf("_%s_", blockCardinality(v)))
+			w.block(path, v.Block)
+		}
+	}
+}
+
+// attributePresence renders whether an attribute is required, optional or computed
+func attributePresence(attr *tfjson.SchemaAttribute) string {
+	switch {
+	case attr.Required:
+		return "yes"
+	case attr.Optional && attr.Computed:
+		return "no (computed)"
+	case attr.Optional:
+		return "no"
+	}
+	return "computed"
+}
+
+// markdownDescription renders the description of an attribute on a single table row, prefixed by its flags
+func markdownDescription(attr *tfjson.SchemaAttribute) string {
+	var flags []string
+	if attr.Deprecated {
+		flags = append(flags, "**Deprecated**")
+	}
+	if attr.Sensitive {
+		flags = append(flags, "**Sensitive**")
+	}
+	if attr.WriteOnly {
+		flags = append(flags, "**Write-only**")
+	}
+	description := strings.TrimSpace(attr.Description)
+	description = strings.ReplaceAll(description, "|", `\|`)
+	description = strings.ReplaceAll(strings.ReplaceAll(description, "\r\n", "\n"), "\n", "<br>")
+	if description != "" {
+		flags = append(flags, description)
+	}
+	return strings.Join(flags, " ")
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func lastSegment(path string) string {
+	return path[strings.LastIndex(path, ".")+1:]
+}
+
+func parentPath(path string) string {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
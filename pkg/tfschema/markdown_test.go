@@ -0,0 +1,96 @@
+package tfschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRenderMarkdown_Schema(t *testing.T) {
+	block := testResourceBlock()
+	delete(block.NestedBlocks, "status")
+	delete(block.NestedBlocks, "identity")
+	block.Attributes["name"].Description = "The name of the cluster.\nChanging this forces a new resource."
+	block.Attributes["tags"].Description = "Tags | labels."
+	block.Attributes["secret"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, Computed: true, Sensitive: true, WriteOnly: true}
+
+	result := renderMarkdown("`azurerm_kubernetes_cluster`", "", &tfjson.Schema{Block: block})
+
+	expected := "## `azurerm_kubernetes_cluster`\n" +
+		"\n" +
+		"| Name | Type | Required | Description |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| `location` | `string` | yes |  |\n" +
+		"| `name` | `string` | yes | The name of the cluster.<br>Changing this forces a new resource. |\n" +
+		"| `id` | `string` | computed |  |\n" +
+		"| `legacy` | `bool` | no | **Deprecated** |\n" +
+		"| `secret` | `string` | no (computed) | **Sensitive** **Write-only** |\n" +
+		"| `settings` | `object` | no |  |\n" +
+		"| `tags` | `map(string)` | no | Tags \\| labels. |\n" +
+		"\n" +
+		"### Attribute `settings`\n" +
+		"\n" +
+		"| Name | Type | Required | Description |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| `size` | `number` | yes |  |\n" +
+		"\n" +
+		"### Block `default_node_pool`\n" +
+		"\n" +
+		"_list of blocks, exactly 1, required_\n" +
+		"\n" +
+		"| Name | Type | Required | Description |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| `vm_size` | `string` | yes |  |\n" +
+		"| `node_count` | `number` | no |  |\n"
+	assert.Equal(t, expected, result)
+}
+
+func TestRenderMarkdown_PathResults(t *testing.T) {
+	block := testResourceBlock()
+
+	result := renderMarkdown("`azurerm_kubernetes_cluster` `identity`", "identity", block.NestedBlocks["identity"])
+	assert.Contains(t, result, "_list of blocks, at most 1, optional_")
+	assert.Contains(t, result, "| `type` | `string` | yes |  |")
+
+	result = renderMarkdown("`azurerm_kubernetes_cluster` `default_node_pool.vm_size`", "default_node_pool.vm_size", block.NestedBlocks["default_node_pool"].Block.Attributes["vm_size"])
+	assert.Contains(t, result, "| `vm_size` | `string` | yes |  |")
+
+	matches, err := queryWildcardPath(block, "*.vm_size")
+	require.NoError(t, err)
+	result = renderMarkdown("`azurerm_kubernetes_cluster` `*.vm_size`", "*.vm_size", matches)
+	assert.Contains(t, result, "### Attribute `default_node_pool.vm_size`")
+}
+
+func TestQuerySchemaWithOptions_Markdown(t *testing.T) {
+	stubSchemaSource(t)
+
+	result, err := QuerySchemaWithOptions("resource", "azurerm_resource_group", "", cacheTestReq, QueryOptions{Format: FormatMarkdown})
+	require.NoError(t, err)
+	assert.Contains(t, result, "## `azurerm_resource_group`")
+	assert.Contains(t, result, "| `location` | `string` | yes |  |")
+
+	result, err = QuerySchemaWithOptions("provider", "", "features", cacheTestReq, QueryOptions{Format: FormatMarkdown})
+	require.NoError(t, err)
+	assert.Contains(t, result, "## Provider `hashicorp/azurerm` `features`")
+
+	_, err = QuerySchemaWithOptions("resource", "azurerm_resource_group", "", cacheTestReq, QueryOptions{Format: FormatMarkdown, View: ViewNamesAndTypes})
+	assert.ErrorContains(t, err, "not supported by the markdown format")
+	_, err = QuerySchemaWithOptions("resource", "azurerm_resource_group", "", cacheTestReq, QueryOptions{Format: "html"})
+	assert.ErrorContains(t, err, "unknown format")
+	_, err = QuerySchemaWithOptions("function", "normalise_resource_id", "", cacheTestReq, QueryOptions{Format: FormatMarkdown})
+	assert.Error(t, err)
+}
+
+func TestQuerySchemas_Markdown(t *testing.T) {
+	stubSchemaSource(t)
+
+	results, err := QuerySchemas([]SchemaQuery{{Category: "resource", Type: "azurerm_resource_group"}}, cacheTestReq, QueryOptions{Format: FormatMarkdown})
+	require.NoError(t, err)
+	var markdown string
+	require.NoError(t, json.Unmarshal(results["resource:azurerm_resource_group"].Schema, &markdown))
+	assert.Contains(t, markdown, "## `azurerm_resource_group`")
+}
@@ -0,0 +1,226 @@
+package tfschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	goversion "github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+	"github.com/spf13/afero"
+)
+
+// Environment variables selecting the offline mode
+const (
+	// ProviderMirrorDirEnv points to a `terraform providers mirror` directory or a plugin cache directory,
+	// when set provider versions and schemas are resolved from it instead of the public registry
+	ProviderMirrorDirEnv = "TFSCHEMA_PROVIDER_MIRROR_DIR"
+	// OfflineEnv set to `true` uses TF_PLUGIN_CACHE_DIR as the provider mirror when ProviderMirrorDirEnv is not set
+	OfflineEnv = "TFSCHEMA_OFFLINE"
+)
+
+// mirrorHostnames are the public registry hostnames looked up in the mirror, in order of preference
+var mirrorHostnames = []string{"registry.terraform.io", "registry.opentofu.org"}
+
+// mirrorHosts returns the hostnames looked up in the mirror, the private registry host comes first when configured
+func mirrorHosts() []string {
+	if host := registryHost(); host != "" {
+		return append([]string{host}, mirrorHostnames...)
+	}
+	return mirrorHostnames
+}
+
+// CommandExecutor interface for executing system commands. Arguments are passed as is, without any shell-like
+// splitting, so paths containing spaces stay a single argument.
+type CommandExecutor interface {
+	ExecuteCommand(ctx context.Context, dir string, args ...string) (stdout, stderr string, err error)
+}
+
+// RealCommandExecutor implements CommandExecutor using exec.CommandContext, the command is killed when ctx is done
+type RealCommandExecutor struct{}
+
+func (r *RealCommandExecutor) ExecuteCommand(ctx context.Context, dir string, args ...string) (stdout, stderr string, err error) {
+	if len(args) == 0 {
+		return "", "", fmt.Errorf("empty command")
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+
+	stdoutBytes, err := cmd.Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			return string(stdoutBytes), string(exitError.Stderr), err
+		}
+	}
+
+	return string(stdoutBytes), "", err
+}
+
+// Global command executor for testing
+var commandExecutor CommandExecutor = &RealCommandExecutor{}
+
+// executeCommand runs a command with commandExecutor, traced as an external command named like `terraform.init`
+func executeCommand(ctx context.Context, name, dir string, args ...string) (stdout, stderr string, err error) {
+	stdout, err = telemetry.TraceCommand(ctx, name, dir, strings.Join(args, " "), func(ctx context.Context) (string, error) {
+		var out string
+		var cmdErr error
+		out, stderr, cmdErr = commandExecutor.ExecuteCommand(ctx, dir, args...)
+		return out, cmdErr
+	})
+	return stdout, stderr, err
+}
+
+// providerMirrorDir returns the provider mirror directory, or an empty string when providers come from the registry
+func providerMirrorDir() string {
+	if dir := os.Getenv(ProviderMirrorDirEnv); dir != "" {
+		return dir
+	}
+	if strings.EqualFold(os.Getenv(OfflineEnv), "true") {
+		return os.Getenv("TF_PLUGIN_CACHE_DIR")
+	}
+	return ""
+}
+
+// packedProviderPattern matches packed mirror archives like `terraform-provider-azurerm_4.39.0_linux_amd64.zip`
+var packedProviderPattern = regexp.MustCompile(`^terraform-provider-(.+)_([^_]+)_([^_]+_[^_]+)\.zip$`)
+
+// mirrorVersions lists provider versions available in the mirror for the current platform. Both the packed layout
+// written by `terraform providers mirror` and the unpacked layout used by plugin cache directories are supported.
+func mirrorVersions(mirrorDir string, providerReq ProviderRequest) (goversion.Collection, error) {
+	var versions goversion.Collection
+	for _, host := range mirrorHosts() {
+		hostVersions, err := mirrorHostVersions(mirrorDir, host, providerReq)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range hostVersions {
+			if !slices.ContainsFunc(versions, v.Equal) {
+				versions = append(versions, v)
+			}
+		}
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("provider %s/%s for %s not found in provider mirror %s", providerReq.ProviderNamespace, providerReq.ProviderName, platform(), mirrorDir)
+	}
+	slices.SortFunc(versions, func(a, b *goversion.Version) int {
+		return a.Compare(b)
+	})
+	return versions, nil
+}
+
+func mirrorHostVersions(mirrorDir, host string, providerReq ProviderRequest) (goversion.Collection, error) {
+	providerDir := filepath.Join(mirrorDir, host, strings.ToLower(providerReq.ProviderNamespace), strings.ToLower(providerReq.ProviderName))
+	entries, err := afero.ReadDir(fs, providerDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read provider mirror directory %s: %w", providerDir, err)
+	}
+
+	var versions goversion.Collection
+	for _, entry := range entries {
+		var raw string
+		if entry.IsDir() {
+			// Unpacked layout: <version>/<os>_<arch>/terraform-provider-<name>...
+			if exists, _ := afero.DirExists(fs, filepath.Join(providerDir, entry.Name(), platform())); !exists {
+				continue
+			}
+			raw = entry.Name()
+		} else {
+			// Packed layout: terraform-provider-<name>_<version>_<os>_<arch>.zip
+			matches := packedProviderPattern.FindStringSubmatch(entry.Name())
+			if matches == nil || matches[3] != platform() {
+				continue
+			}
+			raw = matches[2]
+		}
+		if v, err := goversion.NewVersion(raw); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	return versions, nil
+}
+
+// mirrorProviderSchema reads the provider schema by installing the provider from the mirror into an empty
+// Terraform configuration and running `terraform providers schema -json`, no network access is required.
+func mirrorProviderSchema(ctx context.Context, mirrorDir string, providerReq ProviderRequest) (*tfjson.ProviderSchema, error) {
+	source, err := mirrorSource(mirrorDir, providerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := afero.TempDir(fs, "", "tfschema-mirror-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() {
+		_ = fs.RemoveAll(workDir)
+	}()
+
+	config := fmt.Sprintf(`terraform {
+  required_providers {
+    %s = {
+      source  = %q
+      version = %q
+    }
+  }
+}
+`, strings.ToLower(providerReq.ProviderName), source, "= "+providerReq.ProviderVersion)
+	if err = afero.WriteFile(fs, filepath.Join(workDir, "main.tf"), []byte(config), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write provider requirements: %w", err)
+	}
+
+	absMirrorDir, err := filepath.Abs(mirrorDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve provider mirror directory: %w", err)
+	}
+	if _, stderr, err := executeCommand(ctx, "terraform.init", workDir, "terraform", "init", "-backend=false", "-input=false", "-no-color", "-plugin-dir="+absMirrorDir); err != nil {
+		return nil, fmt.Errorf("failed to install provider %s %s from mirror: %w, stderr: %s", source, providerReq.ProviderVersion, err, stderr)
+	}
+	stdout, stderr, err := executeCommand(ctx, "terraform.providers_schema", workDir, "terraform", "providers", "schema", "-json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema of provider %s %s: %w, stderr: %s", source, providerReq.ProviderVersion, err, stderr)
+	}
+
+	var schemas tfjson.ProviderSchemas
+	if err = json.Unmarshal([]byte(stdout), &schemas); err != nil {
+		return nil, fmt.Errorf("failed to parse provider schema output: %w", err)
+	}
+	schema, ok := schemas.Schemas[source]
+	if !ok {
+		return nil, fmt.Errorf("schema of provider %s not found in terraform output", source)
+	}
+	return schema, nil
+}
+
+// mirrorSource returns the source address of the first mirror host containing the requested provider version
+func mirrorSource(mirrorDir string, providerReq ProviderRequest) (string, error) {
+	for _, host := range mirrorHosts() {
+		versions, err := mirrorHostVersions(mirrorDir, host, providerReq)
+		if err != nil {
+			return "", err
+		}
+		if slices.ContainsFunc(versions, func(v *goversion.Version) bool {
+			return v.Original() == providerReq.ProviderVersion || v.String() == providerReq.ProviderVersion
+		}) {
+			return strings.ToLower(fmt.Sprintf("%s/%s/%s", host, providerReq.ProviderNamespace, providerReq.ProviderName)), nil
+		}
+	}
+	return "", fmt.Errorf("provider %s/%s %s for %s not found in provider mirror %s", providerReq.ProviderNamespace, providerReq.ProviderName, providerReq.ProviderVersion, platform(), mirrorDir)
+}
+
+func platform() string {
+	return runtime.GOOS + "_" + runtime.GOARCH
+}
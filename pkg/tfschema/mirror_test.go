@@ -0,0 +1,177 @@
+package tfschema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockCommandExecutor records the arguments of executed commands and returns results by command prefix
+type MockCommandExecutor struct {
+	results  map[string]MockCommandResult
+	commands [][]string
+}
+
+type MockCommandResult struct {
+	Stdout string
+	Stderr string
+	Err    error
+}
+
+func (m *MockCommandExecutor) ExecuteCommand(_ context.Context, dir string, args ...string) (stdout, stderr string, err error) {
+	m.commands = append(m.commands, args)
+	command := strings.Join(args, " ")
+	for prefix, result := range m.results {
+		if strings.HasPrefix(command, prefix) {
+			return result.Stdout, result.Stderr, result.Err
+		}
+	}
+	return "", "", fmt.Errorf("unexpected command: %s", command)
+}
+
+const testMirrorSchemaOutput = `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/azurerm": {
+      "provider": {"version": 0, "block": {"attributes": {"subscription_id": {"type": "string", "optional": true}}}},
+      "resource_schemas": {
+        "azurerm_resource_group": {"version": 0, "block": {"attributes": {"name": {"type": "string", "required": true}}}}
+      }
+    }
+  }
+}`
+
+func setupMirror(t *testing.T, files ...string) {
+	memFs := afero.NewMemMapFs()
+	for _, f := range files {
+		require.NoError(t, memFs.MkdirAll(filepath.Dir(f), 0755))
+		require.NoError(t, afero.WriteFile(memFs, f, []byte("binary"), 0755))
+	}
+	stubs := gostub.Stub(&fs, memFs)
+	t.Cleanup(stubs.Reset)
+}
+
+func TestMirrorVersions_PackedAndUnpackedLayouts(t *testing.T) {
+	p := platform()
+	setupMirror(t,
+		// packed layout from `terraform providers mirror`
+		"/mirror/registry.terraform.io/hashicorp/azurerm/terraform-provider-azurerm_4.38.1_"+p+".zip",
+		"/mirror/registry.terraform.io/hashicorp/azurerm/terraform-provider-azurerm_4.39.0_"+p+".zip",
+		"/mirror/registry.terraform.io/hashicorp/azurerm/terraform-provider-azurerm_5.0.0_plan9_386.zip",
+		"/mirror/registry.terraform.io/hashicorp/azurerm/index.json",
+		// unpacked layout from a plugin cache directory
+		"/mirror/registry.opentofu.org/hashicorp/azurerm/4.40.0/"+p+"/terraform-provider-azurerm_v4.40.0_x5",
+		"/mirror/registry.opentofu.org/hashicorp/azurerm/4.39.0/"+p+"/terraform-provider-azurerm_v4.39.0_x5",
+		"/mirror/registry.opentofu.org/hashicorp/azurerm/5.1.0/plan9_386/terraform-provider-azurerm_v5.1.0_x5",
+	)
+
+	versions, err := mirrorVersions("/mirror", ProviderRequest{ProviderNamespace: "hashicorp", ProviderName: "azurerm"})
+	require.NoError(t, err)
+
+	var actual []string
+	for _, v := range versions {
+		actual = append(actual, v.String())
+	}
+	assert.Equal(t, []string{"4.38.1", "4.39.0", "4.40.0"}, actual)
+}
+
+func TestMirrorVersions_ProviderNotInMirror(t *testing.T) {
+	setupMirror(t)
+
+	_, err := mirrorVersions("/mirror", ProviderRequest{ProviderNamespace: "hashicorp", ProviderName: "aws"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found in provider mirror")
+}
+
+func TestMirrorProviderSchema(t *testing.T) {
+	setupMirror(t, "/mirror/registry.terraform.io/hashicorp/azurerm/terraform-provider-azurerm_4.39.0_"+platform()+".zip")
+	executor := &MockCommandExecutor{results: map[string]MockCommandResult{
+		"terraform init":             {},
+		"terraform providers schema": {Stdout: testMirrorSchemaOutput},
+	}}
+	stubs := gostub.Stub(&commandExecutor, executor)
+	defer stubs.Reset()
+
+	schema, err := mirrorProviderSchema(context.Background(), "/mirror", cacheTestReq)
+	require.NoError(t, err)
+	require.Contains(t, schema.ResourceSchemas, "azurerm_resource_group")
+	assert.True(t, schema.ResourceSchemas["azurerm_resource_group"].Block.Attributes["name"].Required)
+
+	require.Len(t, executor.commands, 2)
+	assert.Equal(t, []string{"terraform", "init", "-backend=false", "-input=false", "-no-color", "-plugin-dir=/mirror"}, executor.commands[0])
+	assert.Equal(t, []string{"terraform", "providers", "schema", "-json"}, executor.commands[1])
+}
+
+func TestRealCommandExecutor_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := (&RealCommandExecutor{}).ExecuteCommand(ctx, "", "go", "version")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMirrorProviderSchema_MirrorDirWithSpaces(t *testing.T) {
+	setupMirror(t, "/provider mirror/registry.terraform.io/hashicorp/azurerm/terraform-provider-azurerm_4.39.0_"+platform()+".zip")
+	executor := &MockCommandExecutor{results: map[string]MockCommandResult{
+		"terraform init":             {},
+		"terraform providers schema": {Stdout: testMirrorSchemaOutput},
+	}}
+	stubs := gostub.Stub(&commandExecutor, executor)
+	defer stubs.Reset()
+
+	_, err := mirrorProviderSchema(context.Background(), "/provider mirror", cacheTestReq)
+	require.NoError(t, err)
+	assert.Contains(t, executor.commands[0], "-plugin-dir=/provider mirror", "the mirror directory should stay a single argument")
+}
+
+func TestMirrorProviderSchema_InitFailure(t *testing.T) {
+	setupMirror(t, "/mirror/registry.terraform.io/hashicorp/azurerm/terraform-provider-azurerm_4.39.0_"+platform()+".zip")
+	executor := &MockCommandExecutor{results: map[string]MockCommandResult{
+		"terraform init": {Stderr: "checksum mismatch", Err: errors.New("exit status 1")},
+	}}
+	stubs := gostub.Stub(&commandExecutor, executor)
+	defer stubs.Reset()
+
+	_, err := mirrorProviderSchema(context.Background(), "/mirror", cacheTestReq)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+}
+
+func TestMirrorProviderSchema_VersionNotInMirror(t *testing.T) {
+	setupMirror(t, "/mirror/registry.terraform.io/hashicorp/azurerm/terraform-provider-azurerm_4.38.1_"+platform()+".zip")
+
+	_, err := mirrorProviderSchema(context.Background(), "/mirror", cacheTestReq)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "4.39.0")
+}
+
+func TestProviderMirrorDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		mirror   string
+		offline  string
+		cacheDir string
+		expected string
+	}{
+		{name: "registry by default", expected: ""},
+		{name: "explicit mirror", mirror: "/mirror", cacheDir: "/plugin-cache", expected: "/mirror"},
+		{name: "offline uses plugin cache", offline: "true", cacheDir: "/plugin-cache", expected: "/plugin-cache"},
+		{name: "plugin cache ignored when online", cacheDir: "/plugin-cache", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ProviderMirrorDirEnv, tt.mirror)
+			t.Setenv(OfflineEnv, tt.offline)
+			t.Setenv("TF_PLUGIN_CACHE_DIR", tt.cacheDir)
+			assert.Equal(t, tt.expected, providerMirrorDir())
+		})
+	}
+}
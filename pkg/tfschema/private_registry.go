@@ -0,0 +1,206 @@
+package tfschema
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	goversion "github.com/hashicorp/go-version"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/spf13/afero"
+)
+
+// RegistryHostEnv selects an alternative provider registry host, e.g. `app.terraform.io` or a Terraform Enterprise
+// hostname, so providers published to private registries can be queried. Credentials are read from `TF_TOKEN_<host>`
+// like the Terraform CLI does.
+const RegistryHostEnv = "TFSCHEMA_REGISTRY_HOST"
+
+// registryClient talks to private registries, package-level to allow test stubbing
+var registryClient = &http.Client{Timeout: 5 * time.Minute}
+
+// registryHost returns the configured private registry host, or an empty string when the public registry is used
+func registryHost() string {
+	return strings.ToLower(strings.TrimSpace(os.Getenv(RegistryHostEnv)))
+}
+
+// registryTokenEnv returns the credentials variable of a host following the Terraform CLI convention: dots become
+// underscores and dashes become double underscores, e.g. `TF_TOKEN_tfe_example__corp_com` for `tfe.example-corp.com`
+func registryTokenEnv(host string) string {
+	return "TF_TOKEN_" + strings.ReplaceAll(strings.ReplaceAll(host, "-", "__"), ".", "_")
+}
+
+type registryPlatform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+type registryVersionsResponse struct {
+	Versions []struct {
+		Version   string             `json:"version"`
+		Platforms []registryPlatform `json:"platforms"`
+	} `json:"versions"`
+}
+
+type registryDownloadResponse struct {
+	Filename    string `json:"filename"`
+	DownloadURL string `json:"download_url"`
+	Shasum      string `json:"shasum"`
+}
+
+// privateRegistryVersions lists provider versions published to the private registry for the current platform
+func privateRegistryVersions(host string, providerReq ProviderRequest) (goversion.Collection, error) {
+	base, err := discoverProvidersAPI(host)
+	if err != nil {
+		return nil, err
+	}
+	var resp registryVersionsResponse
+	if err = registryGet(host, base.JoinPath(providerReq.ProviderNamespace, providerReq.ProviderName, "versions"), &resp); err != nil {
+		return nil, fmt.Errorf("failed to list versions of %s/%s/%s: %w", host, providerReq.ProviderNamespace, providerReq.ProviderName, err)
+	}
+
+	var versions goversion.Collection
+	for _, v := range resp.Versions {
+		if !slices.Contains(v.Platforms, registryPlatform{OS: runtime.GOOS, Arch: runtime.GOARCH}) {
+			continue
+		}
+		if parsed, err := goversion.NewVersion(v.Version); err == nil {
+			versions = append(versions, parsed)
+		}
+	}
+	slices.SortFunc(versions, func(a, b *goversion.Version) int {
+		return a.Compare(b)
+	})
+	return versions, nil
+}
+
+// privateRegistryProviderSchema downloads the provider package from the private registry into a temporary mirror
+// directory and reads its schema the same way the offline mode does
+func privateRegistryProviderSchema(ctx context.Context, host string, providerReq ProviderRequest) (*tfjson.ProviderSchema, error) {
+	base, err := discoverProvidersAPI(host)
+	if err != nil {
+		return nil, err
+	}
+	var download registryDownloadResponse
+	downloadEndpoint := base.JoinPath(providerReq.ProviderNamespace, providerReq.ProviderName, providerReq.ProviderVersion, "download", runtime.GOOS, runtime.GOARCH)
+	if err = registryGet(host, downloadEndpoint, &download); err != nil {
+		return nil, fmt.Errorf("failed to locate package of %s/%s/%s %s: %w", host, providerReq.ProviderNamespace, providerReq.ProviderName, providerReq.ProviderVersion, err)
+	}
+
+	mirrorDir, err := afero.TempDir(fs, "", "tfschema-registry-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() {
+		_ = fs.RemoveAll(mirrorDir)
+	}()
+	archive := filepath.Join(mirrorDir, host, strings.ToLower(providerReq.ProviderNamespace), strings.ToLower(providerReq.ProviderName),
+		fmt.Sprintf("terraform-provider-%s_%s_%s.zip", strings.ToLower(providerReq.ProviderName), providerReq.ProviderVersion, platform()))
+	if err = downloadProviderPackage(host, downloadEndpoint, download, archive); err != nil {
+		return nil, err
+	}
+	return mirrorProviderSchema(ctx, mirrorDir, providerReq)
+}
+
+// discoverProvidersAPI resolves the providers.v1 endpoint of a host through the remote service discovery protocol
+func discoverProvidersAPI(host string) (*url.URL, error) {
+	discoveryURL := &url.URL{Scheme: "https", Host: host, Path: "/.well-known/terraform.json"}
+	var services map[string]any
+	if err := registryGet(host, discoveryURL, &services); err != nil {
+		return nil, fmt.Errorf("failed to discover services of registry %s: %w", host, err)
+	}
+	endpoint, ok := services["providers.v1"].(string)
+	if !ok {
+		return nil, fmt.Errorf("registry %s does not support the provider registry protocol", host)
+	}
+	base, err := discoveryURL.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid providers.v1 endpoint %q of registry %s: %w", endpoint, host, err)
+	}
+	return base, nil
+}
+
+// registryGet fetches a JSON document, the registry token is only sent to the registry host itself
+func registryGet(host string, u *url.URL, result any) error {
+	resp, err := registryDo(host, u)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", u.Redacted(), err)
+	}
+	return nil
+}
+
+func registryDo(host string, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := os.Getenv(registryTokenEnv(host)); token != "" && strings.EqualFold(u.Host, host) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := registryClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request %s: %w", u.Redacted(), err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return nil, fmt.Errorf("request to %s was denied with status %d, check %s", u.Redacted(), resp.StatusCode, registryTokenEnv(host))
+		}
+		return nil, fmt.Errorf("request to %s failed with status %d", u.Redacted(), resp.StatusCode)
+	}
+	return resp, nil
+}
+
+// downloadProviderPackage saves the provider archive to path, verifying its checksum when the registry reports one.
+// Relative download URLs are resolved against the download endpoint.
+func downloadProviderPackage(host string, endpoint *url.URL, download registryDownloadResponse, path string) error {
+	if download.DownloadURL == "" {
+		return fmt.Errorf("registry %s returned no download URL for %s", host, download.Filename)
+	}
+	u, err := endpoint.Parse(download.DownloadURL)
+	if err != nil {
+		return fmt.Errorf("invalid download URL %q: %w", download.DownloadURL, err)
+	}
+	resp, err := registryDo(host, u)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if err = fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create provider directory: %w", err)
+	}
+	f, err := fs.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create provider archive: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(f, hash), resp.Body); err != nil {
+		return fmt.Errorf("failed to download provider archive %s: %w", download.Filename, err)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); download.Shasum != "" && !strings.EqualFold(sum, download.Shasum) {
+		return fmt.Errorf("checksum mismatch of provider archive %s: expected %s, got %s", download.Filename, download.Shasum, sum)
+	}
+	return nil
+}
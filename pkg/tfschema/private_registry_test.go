@@ -0,0 +1,117 @@
+package tfschema
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProviderArchive = "provider archive"
+
+// setupPrivateRegistry starts a registry serving example/azurerm 1.0.0 and 1.1.0, 1.1.0 isn't built for this platform.
+// Requests without the token are denied.
+func setupPrivateRegistry(t *testing.T, shasum string) string {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/terraform.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"providers.v1": "/api/registry/v1/providers/"}`)
+	})
+	mux.HandleFunc("/api/registry/v1/providers/example/azurerm/versions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"versions": [
+			{"version": "1.1.0", "platforms": [{"os": "plan9", "arch": "386"}]},
+			{"version": "1.0.0", "platforms": [{"os": %q, "arch": %q}]}
+		]}`, runtime.GOOS, runtime.GOARCH)
+	})
+	mux.HandleFunc(fmt.Sprintf("/api/registry/v1/providers/example/azurerm/1.0.0/download/%s/%s", runtime.GOOS, runtime.GOARCH), func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"filename": "terraform-provider-azurerm_1.0.0.zip", "download_url": "/archives/azurerm.zip", "shasum": %q}`, shasum)
+	})
+	mux.HandleFunc("/archives/azurerm.zip", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, testProviderArchive)
+	})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	host := server.Listener.Addr().String()
+	t.Setenv(RegistryHostEnv, host)
+	t.Setenv(registryTokenEnv(host), "secret")
+	stubs := gostub.Stub(&registryClient, server.Client()).Stub(&fs, afero.NewMemMapFs())
+	t.Cleanup(stubs.Reset)
+	return host
+}
+
+func testArchiveShasum() string {
+	sum := sha256.Sum256([]byte(testProviderArchive))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRegistryTokenEnv(t *testing.T) {
+	assert.Equal(t, "TF_TOKEN_app_terraform_io", registryTokenEnv("app.terraform.io"))
+	assert.Equal(t, "TF_TOKEN_tfe_example__corp_com", registryTokenEnv("tfe.example-corp.com"))
+}
+
+func TestPrivateRegistryVersions(t *testing.T) {
+	host := setupPrivateRegistry(t, testArchiveShasum())
+
+	versions, err := privateRegistryVersions(host, ProviderRequest{ProviderNamespace: "example", ProviderName: "azurerm"})
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, "1.0.0", versions[0].String())
+}
+
+func TestPrivateRegistryVersions_Unauthorized(t *testing.T) {
+	host := setupPrivateRegistry(t, testArchiveShasum())
+	t.Setenv(registryTokenEnv(host), "")
+
+	_, err := privateRegistryVersions(host, ProviderRequest{ProviderNamespace: "example", ProviderName: "azurerm"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "denied with status 401")
+}
+
+func TestPrivateRegistryProviderSchema(t *testing.T) {
+	host := setupPrivateRegistry(t, testArchiveShasum())
+	source := host + "/example/azurerm"
+	executor := &MockCommandExecutor{results: map[string]MockCommandResult{
+		"terraform init":             {},
+		"terraform providers schema": {Stdout: strings.ReplaceAll(testMirrorSchemaOutput, "registry.terraform.io/hashicorp/azurerm", source)},
+	}}
+	stubs := gostub.Stub(&commandExecutor, executor)
+	defer stubs.Reset()
+
+	schema, err := privateRegistryProviderSchema(context.Background(), host, ProviderRequest{ProviderNamespace: "example", ProviderName: "azurerm", ProviderVersion: "1.0.0"})
+	require.NoError(t, err)
+	assert.Contains(t, schema.ResourceSchemas, "azurerm_resource_group")
+	require.Len(t, executor.commands, 2)
+	assert.True(t, strings.HasPrefix(executor.commands[0][len(executor.commands[0])-1], "-plugin-dir="))
+}
+
+func TestPrivateRegistryProviderSchema_ChecksumMismatch(t *testing.T) {
+	host := setupPrivateRegistry(t, "0000")
+
+	_, err := privateRegistryProviderSchema(context.Background(), host, ProviderRequest{ProviderNamespace: "example", ProviderName: "azurerm", ProviderVersion: "1.0.0"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+}
+
+func TestSchemaCacheKey_PrivateRegistry(t *testing.T) {
+	req := ProviderRequest{ProviderNamespace: "example", ProviderName: "azurerm", ProviderVersion: "1.0.0"}
+	assert.Equal(t, "example/azurerm/1.0.0", schemaCacheKey(req))
+
+	t.Setenv(RegistryHostEnv, "app.terraform.io")
+	assert.Equal(t, "app.terraform.io/example/azurerm/1.0.0", schemaCacheKey(req))
+}
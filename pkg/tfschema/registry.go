@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -30,87 +31,174 @@ func getServer() *tfpluginschema.Server {
 }
 
 func QuerySchema(category, name, path string, providerReq ProviderRequest) (string, error) {
-	server := getServer()
+	return QuerySchemaWithOptions(category, name, path, providerReq, QueryOptions{})
+}
 
-	request := tfpluginschema.Request{
-		Namespace: providerReq.ProviderNamespace,
-		Name:      providerReq.ProviderName,
-		Version:   providerReq.ProviderVersion,
+// QuerySchemaWithOptions queries a schema like QuerySchema, rendering the result according to opts
+func QuerySchemaWithOptions(category, name, path string, providerReq ProviderRequest, opts QueryOptions) (string, error) {
+	if opts.View == "" {
+		opts.View = ViewFull
 	}
-
-	var schema *tfjson.Schema
-	var functionSignature *tfjson.FunctionSignature
-	var err error
-
-	switch category {
-	case "resource":
-		schema, err = server.GetResourceSchema(request, name)
-	case "data":
-		schema, err = server.GetDataSourceSchema(request, name)
-	case "ephemeral":
-		schema, err = server.GetEphemeralResourceSchema(request, name)
-	case "function":
-		functionSignature, err = server.GetFunctionSchema(request, name)
-	case "provider":
-		schema, err = server.GetProviderSchema(request)
-	default:
-		return "", errors.New("unknown schema category, must be one of 'resource', 'data', 'ephemeral', 'function', or 'provider'")
+	if opts.Format == "" {
+		opts.Format = FormatJSON
 	}
-
-	if err != nil {
-		return "", fmt.Errorf("failed to get %s schema for %s/%s: %w", category, providerReq.ProviderNamespace, providerReq.ProviderName, err)
+	if err := validateView(opts.View); err != nil {
+		return "", err
+	}
+	if err := validateFormat(opts.Format, opts.View); err != nil {
+		return "", err
 	}
 
 	// Handle function signatures differently from schemas
 	if category == "function" {
 		if path != "" {
-			return "", errors.New("path queries are not supported for function schemas")
+			if err := validateFunctionPath(path); err != nil {
+				return "", err
+			}
+		}
+		if opts.View != ViewFull {
+			return "", errors.New("views are not supported for function schemas")
+		}
+		if opts.Format != FormatJSON {
+			return "", errors.New("formats other than json are not supported for function schemas")
+		}
+		functionSignature, err := getFunctionSignature(name, providerReq)
+		if err != nil {
+			return "", err
+		}
+		if path != "" {
+			result, err := queryFunctionPath(name, functionSignature, path)
+			if err != nil {
+				return "", fmt.Errorf("failed to query path %s in function %s: %w", path, name, err)
+			}
+			return toCompactJson(result)
 		}
 		return toCompactJson(functionSignature)
 	}
 
+	schema, err := getSchema(category, name, providerReq)
+	if err != nil {
+		return "", err
+	}
+
+	title := fmt.Sprintf("`%s`", name)
+	if category == "provider" {
+		title = fmt.Sprintf("Provider `%s/%s`", providerReq.ProviderNamespace, providerReq.ProviderName)
+	}
 	if path == "" {
-		return toCompactJson(schema)
+		switch opts.Format {
+		case FormatMarkdown:
+			return renderMarkdown(title, "", applyView(schema, opts.View)), nil
+		case FormatJSONSchema:
+			return toCompactJson(toJSONSchema(jsonSchemaTitle(title), applyView(schema, opts.View)))
+		}
+		return toCompactJson(applyView(schema, opts.View))
 	}
 
 	// Query the specific path in the schema
 	result, err := querySchemaPath(schema.Block, path)
 	if err != nil {
+		if category == "provider" {
+			name = fmt.Sprintf("provider %s/%s", providerReq.ProviderNamespace, providerReq.ProviderName)
+		}
 		return "", fmt.Errorf("failed to query path %s in schema %s: %w", path, name, err)
 	}
-	return toCompactJson(result)
+	switch opts.Format {
+	case FormatMarkdown:
+		return renderMarkdown(fmt.Sprintf("%s `%s`", title, path), path, applyView(result, opts.View)), nil
+	case FormatJSONSchema:
+		return toCompactJson(toJSONSchema(jsonSchemaTitle(fmt.Sprintf("%s `%s`", title, path)), applyView(result, opts.View)))
+	}
+	return toCompactJson(describeNestedBlocks(applyView(result, opts.View)))
 }
 
-// ListItems lists available items (resources, data sources, ephemeral resources, or functions) for a provider
-func ListItems(category string, providerReq ProviderRequest) ([]string, error) {
-	server := getServer()
+// getSchema fetches the schema of a resource, data source, ephemeral resource or provider block
+func getSchema(category, name string, providerReq ProviderRequest) (*tfjson.Schema, error) {
+	switch category {
+	case "resource", "data", "ephemeral", "provider":
+	default:
+		return nil, errors.New("unknown schema category, must be one of 'resource', 'data', 'ephemeral', 'function', or 'provider'")
+	}
 
-	request := tfpluginschema.Request{
-		Namespace: providerReq.ProviderNamespace,
-		Name:      providerReq.ProviderName,
-		Version:   providerReq.ProviderVersion,
+	ps, err := providerSchema(providerReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s schema for %s/%s: %w", category, providerReq.ProviderNamespace, providerReq.ProviderName, err)
 	}
 
-	var items []string
-	var err error
+	var schemas map[string]*tfjson.Schema
 	switch category {
 	case "resource":
-		items, err = server.ListResources(request)
+		schemas = ps.ResourceSchemas
 	case "data":
-		items, err = server.ListDataSources(request)
+		schemas = ps.DataSourceSchemas
 	case "ephemeral":
-		items, err = server.ListEphemeralResources(request)
-	case "function":
-		items, err = server.ListFunctions(request)
+		schemas = ps.EphemeralResourceSchemas
+	case "provider":
+		if ps.ConfigSchema == nil {
+			return &tfjson.Schema{Block: &tfjson.SchemaBlock{}}, nil
+		}
+		return ps.ConfigSchema, nil
+	}
+
+	schema, ok := schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("failed to get %s schema for %s/%s: %s schema not found: %s", category, providerReq.ProviderNamespace, providerReq.ProviderName, categoryDisplayNames[category], name)
+	}
+	return schema, nil
+}
+
+// getFunctionSignature fetches the signature of a provider function
+func getFunctionSignature(name string, providerReq ProviderRequest) (*tfjson.FunctionSignature, error) {
+	ps, err := providerSchema(providerReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get function schema for %s/%s: %w", providerReq.ProviderNamespace, providerReq.ProviderName, err)
+	}
+	signature, ok := ps.Functions[name]
+	if !ok {
+		return nil, fmt.Errorf("failed to get function schema for %s/%s: function schema not found: %s", providerReq.ProviderNamespace, providerReq.ProviderName, name)
+	}
+	return signature, nil
+}
+
+// categoryDisplayNames maps schema categories to the names used in error messages
+var categoryDisplayNames = map[string]string{
+	"resource":  "resource",
+	"data":      "data source",
+	"ephemeral": "ephemeral resource",
+	"function":  "function",
+}
+
+func toPluginSchemaRequest(providerReq ProviderRequest) tfpluginschema.Request {
+	return tfpluginschema.Request{
+		Namespace: providerReq.ProviderNamespace,
+		Name:      providerReq.ProviderName,
+		Version:   providerReq.ProviderVersion,
+	}
+}
+
+// ListItems lists available items (resources, data sources, ephemeral resources, or functions) for a provider
+func ListItems(category string, providerReq ProviderRequest) ([]string, error) {
+	switch category {
+	case "resource", "data", "ephemeral", "function":
 	default:
 		return nil, errors.New("unknown category, must be one of 'resource', 'data', 'ephemeral', or 'function'")
 	}
 
+	ps, err := providerSchema(providerReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list %s items for provider %s/%s: %w", category, providerReq.ProviderNamespace, providerReq.ProviderName, err)
 	}
 
-	return items, nil
+	switch category {
+	case "resource":
+		return sortedKeys(ps.ResourceSchemas), nil
+	case "data":
+		return sortedKeys(ps.DataSourceSchemas), nil
+	case "ephemeral":
+		return sortedKeys(ps.EphemeralResourceSchemas), nil
+	default:
+		return sortedKeys(ps.Functions), nil
+	}
 }
 
 // querySchemaPath traverses a schema block following the given dot-separated path
@@ -118,6 +206,9 @@ func querySchemaPath(block *tfjson.SchemaBlock, path string) (interface{}, error
 	if path == "" {
 		return block, nil
 	}
+	if hasWildcard(path) {
+		return queryWildcardPath(block, path)
+	}
 
 	segments := strings.Split(path, ".")
 	segment := segments[0]
@@ -141,7 +232,16 @@ func querySchemaPath(block *tfjson.SchemaBlock, path string) (interface{}, error
 		return querySchemaPath(nestedBlock.Block, remainingPath)
 	}
 
-	return nil, fmt.Errorf("path segment '%s' not found in schema block", segment)
+	return nil, fmt.Errorf("path segment '%s' not found in schema block, available attributes: %v, available blocks: %v", segment, sortedKeys(block.Attributes), sortedKeys(block.NestedBlocks))
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func toCompactJson(data interface{}) (string, error) {
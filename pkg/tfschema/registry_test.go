@@ -7,6 +7,7 @@ import (
 	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // Common provider request for testing - using azurerm as an example
@@ -193,8 +194,8 @@ func TestQuerySchema_FunctionSupport(t *testing.T) {
 	}
 }
 
-func TestQuerySchema_Function_PathNotSupported(t *testing.T) {
-	// Test that path queries are not supported for functions
+func TestQuerySchema_Function_InvalidPath(t *testing.T) {
+	// Test that invalid function paths are rejected before the provider is loaded
 	azapiProviderReq := ProviderRequest{
 		ProviderNamespace: "Azure",
 		ProviderName:      "azapi",
@@ -203,8 +204,8 @@ func TestQuerySchema_Function_PathNotSupported(t *testing.T) {
 
 	_, err := QuerySchema("function", "build_resource_id", "some.path", azapiProviderReq)
 
-	require.Error(t, err, "Should return error for function with path")
-	assert.Equal(t, "path queries are not supported for function schemas", err.Error(), "Error message should match expected")
+	require.Error(t, err, "Should return error for function with invalid path")
+	assert.Contains(t, err.Error(), `invalid function path "some.path"`, "Error message should match expected")
 }
 
 // Tests for ListItems function
@@ -316,3 +317,59 @@ func TestQuerySchema_Provider_PathSupported(t *testing.T) {
 	assert.Contains(t, schema, "Base64 encoded")
 	assert.Contains(t, schema, "Client Certificate")
 }
+
+// testProviderBlock mimics the azurerm provider configuration block
+var testProviderBlock = &tfjson.SchemaBlock{
+	Attributes: map[string]*tfjson.SchemaAttribute{
+		"subscription_id": {AttributeType: cty.String, Optional: true, Description: "The Subscription ID which should be used."},
+		"use_msi":         {AttributeType: cty.Bool, Optional: true},
+	},
+	NestedBlocks: map[string]*tfjson.SchemaBlockType{
+		"features": {
+			NestingMode: tfjson.SchemaNestingModeList,
+			MaxItems:    1,
+			Block: &tfjson.SchemaBlock{
+				NestedBlocks: map[string]*tfjson.SchemaBlockType{
+					"key_vault": {
+						NestingMode: tfjson.SchemaNestingModeList,
+						Block: &tfjson.SchemaBlock{
+							Attributes: map[string]*tfjson.SchemaAttribute{
+								"purge_soft_delete_on_destroy": {AttributeType: cty.Bool, Optional: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestQuerySchemaPath_ProviderBlock(t *testing.T) {
+	result, err := querySchemaPath(testProviderBlock, "features")
+	require.NoError(t, err)
+	features, ok := result.(*tfjson.SchemaBlockType)
+	require.True(t, ok)
+	assert.Contains(t, features.Block.NestedBlocks, "key_vault")
+
+	result, err = querySchemaPath(testProviderBlock, "features.key_vault.purge_soft_delete_on_destroy")
+	require.NoError(t, err)
+	attr, ok := result.(*tfjson.SchemaAttribute)
+	require.True(t, ok)
+	assert.Equal(t, cty.Bool, attr.AttributeType)
+
+	result, err = querySchemaPath(testProviderBlock, "subscription_id")
+	require.NoError(t, err)
+	assert.Equal(t, "The Subscription ID which should be used.", result.(*tfjson.SchemaAttribute).Description)
+}
+
+func TestQuerySchemaPath_NotFoundListsAvailableChildren(t *testing.T) {
+	_, err := querySchemaPath(testProviderBlock, "feature")
+	require.Error(t, err)
+	assert.Equal(t, "path segment 'feature' not found in schema block, available attributes: [subscription_id use_msi], available blocks: [features]", err.Error())
+}
+
+func TestQuerySchemaPath_CannotTraverseAttribute(t *testing.T) {
+	_, err := querySchemaPath(testProviderBlock, "use_msi.value")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot traverse into attribute use_msi")
+}
@@ -0,0 +1,109 @@
+package tfschema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Filters of sensitive attribute reports
+const (
+	SensitiveFilterAll       = ""
+	SensitiveFilterSensitive = "sensitive"
+	SensitiveFilterWriteOnly = "write_only"
+)
+
+// SensitiveItem is an attribute flagged sensitive or write-only. Write-only attributes accept ephemeral values
+// and are never persisted to state, sensitive attributes are persisted but redacted from plan and log output.
+type SensitiveItem struct {
+	Category string `json:"category"`
+	// Type is the resource, data source or ephemeral resource type, empty for the provider configuration
+	Type string `json:"type,omitempty"`
+	// Path is the dot-separated path of the attribute in the schema
+	Path      string `json:"path"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+	WriteOnly bool   `json:"write_only,omitempty"`
+	Required  bool   `json:"required,omitempty"`
+	Computed  bool   `json:"computed,omitempty"`
+}
+
+// SensitiveReport lists sensitive and write-only attributes of one schema or a whole provider
+type SensitiveReport struct {
+	Provider string          `json:"provider"`
+	Version  string          `json:"version"`
+	Count    int             `json:"count"`
+	Items    []SensitiveItem `json:"items"`
+}
+
+// ReportSensitive walks schemas like ReportDeprecated and returns only attributes flagged sensitive or write-only.
+// filter is one of SensitiveFilterAll, SensitiveFilterSensitive or SensitiveFilterWriteOnly.
+func ReportSensitive(category, name, filter string, providerReq ProviderRequest) (*SensitiveReport, error) {
+	if category == "function" {
+		return nil, errors.New("sensitive attribute reports are not supported for function schemas")
+	}
+	if filter != SensitiveFilterAll && filter != SensitiveFilterSensitive && filter != SensitiveFilterWriteOnly {
+		return nil, fmt.Errorf("unknown filter %q, must be one of: %s", filter, strings.Join([]string{SensitiveFilterSensitive, SensitiveFilterWriteOnly}, ", "))
+	}
+	items := []SensitiveItem{}
+	resolved, err := walkSchemas(category, name, providerReq, func(category, typeName string, block *tfjson.SchemaBlock) {
+		items = append(items, sensitiveInBlock(category, typeName, "", block, filter)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SensitiveReport{
+		Provider: fmt.Sprintf("%s/%s", resolved.ProviderNamespace, resolved.ProviderName),
+		Version:  resolved.ProviderVersion,
+		Count:    len(items),
+		Items:    items,
+	}, nil
+}
+
+// sensitiveInBlock collects sensitive and write-only attributes of block, path is the path of block itself
+func sensitiveInBlock(category, typeName, path string, block *tfjson.SchemaBlock, filter string) []SensitiveItem {
+	if block == nil {
+		return nil
+	}
+	items := sensitiveInAttributes(category, typeName, path, block.Attributes, filter)
+	for _, n := range sortedKeys(block.NestedBlocks) {
+		if nested := block.NestedBlocks[n]; nested != nil {
+			items = append(items, sensitiveInBlock(category, typeName, joinPath(path, n), nested.Block, filter)...)
+		}
+	}
+	return items
+}
+
+func sensitiveInAttributes(category, typeName, path string, attributes map[string]*tfjson.SchemaAttribute, filter string) []SensitiveItem {
+	var items []SensitiveItem
+	for _, n := range sortedKeys(attributes) {
+		attr := attributes[n]
+		attrPath := joinPath(path, n)
+		if matchSensitiveFilter(attr, filter) {
+			items = append(items, SensitiveItem{
+				Category:  category,
+				Type:      typeName,
+				Path:      attrPath,
+				Sensitive: attr.Sensitive,
+				WriteOnly: attr.WriteOnly,
+				Required:  attr.Required,
+				Computed:  attr.Computed,
+			})
+		}
+		if attr.AttributeNestedType != nil {
+			items = append(items, sensitiveInAttributes(category, typeName, attrPath, attr.AttributeNestedType.Attributes, filter)...)
+		}
+	}
+	return items
+}
+
+func matchSensitiveFilter(attr *tfjson.SchemaAttribute, filter string) bool {
+	switch filter {
+	case SensitiveFilterSensitive:
+		return attr.Sensitive
+	case SensitiveFilterWriteOnly:
+		return attr.WriteOnly
+	}
+	return attr.Sensitive || attr.WriteOnly
+}
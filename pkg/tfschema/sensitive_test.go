@@ -0,0 +1,88 @@
+package tfschema
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestSensitiveInBlock(t *testing.T) {
+	block := testResourceBlock()
+	block.Attributes["admin_password"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, Sensitive: true}
+	block.Attributes["admin_password_wo"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, Sensitive: true, WriteOnly: true}
+	block.Attributes["settings"].AttributeNestedType.Attributes["key"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Computed: true, Sensitive: true}
+	block.NestedBlocks["identity"].Block.Attributes["secret_wo"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Required: true, WriteOnly: true}
+
+	tests := []struct {
+		name     string
+		filter   string
+		expected []string
+	}{
+		{name: "all", filter: SensitiveFilterAll, expected: []string{"admin_password", "admin_password_wo", "settings.key", "identity.secret_wo"}},
+		{name: "sensitive", filter: SensitiveFilterSensitive, expected: []string{"admin_password", "admin_password_wo", "settings.key"}},
+		{name: "write-only", filter: SensitiveFilterWriteOnly, expected: []string{"admin_password_wo", "identity.secret_wo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := sensitiveInBlock("resource", "azurerm_kubernetes_cluster", "", block, tt.filter)
+			var paths []string
+			for _, item := range items {
+				paths = append(paths, item.Path)
+			}
+			assert.Equal(t, tt.expected, paths)
+		})
+	}
+
+	items := sensitiveInBlock("resource", "azurerm_kubernetes_cluster", "", block, SensitiveFilterWriteOnly)
+	assert.Equal(t, SensitiveItem{Category: "resource", Type: "azurerm_kubernetes_cluster", Path: "identity.secret_wo", WriteOnly: true, Required: true}, items[1])
+}
+
+func TestReportSensitive(t *testing.T) {
+	stubSchemaSource(t)
+	original := fetchProviderSchema
+	fetchProviderSchema = func(req ProviderRequest) (*tfjson.ProviderSchema, error) {
+		schema, err := original(req)
+		require.NoError(t, err)
+		schema.ConfigSchema = &tfjson.Schema{Block: &tfjson.SchemaBlock{
+			Attributes: map[string]*tfjson.SchemaAttribute{
+				"client_secret":   {AttributeType: cty.String, Optional: true, Sensitive: true},
+				"subscription_id": {AttributeType: cty.String, Optional: true},
+			},
+		}}
+		schema.ResourceSchemas["azurerm_resource_group"].Block.Attributes["token_wo"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, WriteOnly: true}
+		return schema, nil
+	}
+	defer func() {
+		fetchProviderSchema = original
+	}()
+
+	report, err := ReportSensitive("", "", SensitiveFilterAll, cacheTestReq)
+	require.NoError(t, err)
+	assert.Equal(t, "hashicorp/azurerm", report.Provider)
+	assert.Equal(t, "4.39.0", report.Version)
+	assert.Equal(t, []SensitiveItem{
+		{Category: "provider", Path: "client_secret", Sensitive: true},
+		{Category: "resource", Type: "azurerm_resource_group", Path: "token_wo", WriteOnly: true},
+	}, report.Items)
+	assert.Equal(t, 2, report.Count)
+
+	report, err = ReportSensitive("resource", "azurerm_resource_group", SensitiveFilterSensitive, cacheTestReq)
+	require.NoError(t, err)
+	assert.Empty(t, report.Items)
+}
+
+func TestReportSensitive_InvalidInput(t *testing.T) {
+	stubSchemaSource(t)
+
+	_, err := ReportSensitive("function", "", SensitiveFilterAll, cacheTestReq)
+	assert.ErrorContains(t, err, "not supported for function")
+
+	_, err = ReportSensitive("resource", "", "secret", cacheTestReq)
+	assert.ErrorContains(t, err, "unknown filter")
+
+	_, err = ReportSensitive("", "azurerm_resource_group", SensitiveFilterAll, cacheTestReq)
+	assert.ErrorContains(t, err, "category is required")
+}
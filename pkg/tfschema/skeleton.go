@@ -0,0 +1,191 @@
+package tfschema
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// skeletonLabel is the label used for generated resource, data and ephemeral blocks
+const skeletonLabel = "this"
+
+// stringPlaceholder is the value used for required string attributes in generated skeletons
+const stringPlaceholder = "REPLACE_ME"
+
+// GenerateSkeleton renders a ready-to-edit HCL block for a resource, data source, ephemeral resource or provider.
+// Required attributes are filled with placeholders, optional attributes and optional nested blocks are commented out,
+// and computed-only or deprecated optional attributes are omitted.
+func GenerateSkeleton(category, name string, providerReq ProviderRequest) (string, error) {
+	if category == "function" {
+		return "", fmt.Errorf("skeletons are not supported for function schemas")
+	}
+	schema, err := getSchema(category, name, providerReq)
+	if err != nil {
+		return "", err
+	}
+	if category == "provider" {
+		name = providerReq.ProviderName
+	}
+	return renderSkeleton(category, name, schema.Block)
+}
+
+// renderSkeleton renders the HCL skeleton of block as a top level block of the given category
+func renderSkeleton(category, name string, block *tfjson.SchemaBlock) (string, error) {
+	var header string
+	switch category {
+	case "resource", "data", "ephemeral":
+		header = fmt.Sprintf("%s %q %q {", category, name, skeletonLabel)
+	case "provider":
+		header = fmt.Sprintf("provider %q {", name)
+	default:
+		return "", fmt.Errorf("unknown schema category %s, must be one of 'resource', 'data', 'ephemeral' or 'provider'", category)
+	}
+	if block == nil {
+		return "", fmt.Errorf("schema of %s %s has no block", category, name)
+	}
+
+	w := &skeletonWriter{}
+	w.line(0, nil, header)
+	w.blockBody(block, 1, nil)
+	w.line(0, nil, "}")
+	return string(hclwrite.Format([]byte(w.String()))), nil
+}
+
+type skeletonWriter struct {
+	strings.Builder
+}
+
+// line writes text at the given depth. commentDepths holds the depths at which an optional element started,
+// a `# ` marker is written at each of them so the indentation inside commented out blocks is preserved,
+// and optional elements nested in commented out blocks stay commented after uncommenting the outer block.
+func (w *skeletonWriter) line(depth int, commentDepths []int, text string) {
+	for i := 0; i <= depth; i++ {
+		if i > 0 {
+			w.WriteString("  ")
+		}
+		if slices.Contains(commentDepths, i) {
+			w.WriteString("# ")
+		}
+	}
+	w.WriteString(text)
+	w.WriteString("\n")
+}
+
+// commentAt returns commentDepths extended with depth when the element at depth is optional
+func commentAt(commentDepths []int, depth int, optional bool) []int {
+	if !optional {
+		return commentDepths
+	}
+	return append(slices.Clone(commentDepths), depth)
+}
+
+func (w *skeletonWriter) blockBody(block *tfjson.SchemaBlock, depth int, commentDepths []int) {
+	for _, name := range settableAttributes(block.Attributes) {
+		w.attribute(name, block.Attributes[name], depth, commentDepths)
+	}
+	for _, name := range settableBlocks(block.NestedBlocks) {
+		nested := block.NestedBlocks[name]
+		blockComments := commentAt(commentDepths, depth, nested.MinItems == 0)
+		header := name + " {"
+		if nested.NestingMode == tfjson.SchemaNestingModeMap {
+			header = fmt.Sprintf("%s %q {", name, "key")
+		}
+		w.line(depth, blockComments, header)
+		w.blockBody(nested.Block, depth+1, blockComments)
+		w.line(depth, blockComments, "}")
+	}
+}
+
+func (w *skeletonWriter) attribute(name string, attr *tfjson.SchemaAttribute, depth int, commentDepths []int) {
+	commented := commentAt(commentDepths, depth, !attr.Required)
+	nested := attr.AttributeNestedType
+	if nested == nil {
+		w.line(depth, commented, fmt.Sprintf("%s = %s", name, placeholder(attr.AttributeType)))
+		return
+	}
+
+	var opening, closing string
+	switch nested.NestingMode {
+	case tfjson.SchemaNestingModeList, tfjson.SchemaNestingModeSet:
+		opening, closing = "[{", "}]"
+	case tfjson.SchemaNestingModeMap:
+		w.line(depth, commented, name+" = {")
+		w.line(depth+1, commented, "key = {")
+		w.nestedAttributes(nested.Attributes, depth+2, commented)
+		w.line(depth+1, commented, "}")
+		w.line(depth, commented, "}")
+		return
+	default:
+		opening, closing = "{", "}"
+	}
+	w.line(depth, commented, fmt.Sprintf("%s = %s", name, opening))
+	w.nestedAttributes(nested.Attributes, depth+1, commented)
+	w.line(depth, commented, closing)
+}
+
+func (w *skeletonWriter) nestedAttributes(attributes map[string]*tfjson.SchemaAttribute, depth int, commentDepths []int) {
+	for _, name := range settableAttributes(attributes) {
+		w.attribute(name, attributes[name], depth, commentDepths)
+	}
+}
+
+// settableAttributes returns names of attributes a user can set, required ones first, each group sorted by name
+func settableAttributes(attributes map[string]*tfjson.SchemaAttribute) []string {
+	var required, optional []string
+	for name, attr := range attributes {
+		switch {
+		case attr.Required:
+			required = append(required, name)
+		case attr.Optional && !attr.Deprecated:
+			optional = append(optional, name)
+		}
+	}
+	sort.Strings(required)
+	sort.Strings(optional)
+	return append(required, optional...)
+}
+
+// settableBlocks returns names of nested blocks containing settable content, required ones first, each group sorted by name
+func settableBlocks(blocks map[string]*tfjson.SchemaBlockType) []string {
+	var required, optional []string
+	for name, block := range blocks {
+		if block.Block == nil || !hasSettableContent(block.Block) {
+			continue
+		}
+		if block.MinItems > 0 {
+			required = append(required, name)
+		} else if !block.Block.Deprecated {
+			optional = append(optional, name)
+		}
+	}
+	sort.Strings(required)
+	sort.Strings(optional)
+	return append(required, optional...)
+}
+
+func hasSettableContent(block *tfjson.SchemaBlock) bool {
+	return len(settableAttributes(block.Attributes)) > 0 || len(settableBlocks(block.NestedBlocks)) > 0
+}
+
+// placeholder returns a placeholder HCL expression matching the attribute type
+func placeholder(t cty.Type) string {
+	switch {
+	case t == cty.String:
+		return fmt.Sprintf("%q", stringPlaceholder)
+	case t == cty.Number:
+		return "0"
+	case t == cty.Bool:
+		return "false"
+	case t.IsListType(), t.IsSetType(), t.IsTupleType():
+		return "[]"
+	case t.IsMapType(), t.IsObjectType():
+		return "{}"
+	default:
+		return "null"
+	}
+}
@@ -0,0 +1,156 @@
+package tfschema
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func testResourceBlock() *tfjson.SchemaBlock {
+	return &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"name":     {AttributeType: cty.String, Required: true},
+			"location": {AttributeType: cty.String, Required: true},
+			"tags":     {AttributeType: cty.Map(cty.String), Optional: true},
+			"id":       {AttributeType: cty.String, Computed: true},
+			"legacy":   {AttributeType: cty.Bool, Optional: true, Deprecated: true},
+			"settings": {
+				Optional: true,
+				AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+					NestingMode: tfjson.SchemaNestingModeSingle,
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"size": {AttributeType: cty.Number, Required: true},
+					},
+				},
+			},
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{
+			"default_node_pool": {
+				NestingMode: tfjson.SchemaNestingModeList,
+				MinItems:    1,
+				MaxItems:    1,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"vm_size":    {AttributeType: cty.String, Required: true},
+						"node_count": {AttributeType: cty.Number, Optional: true},
+					},
+				},
+			},
+			"identity": {
+				NestingMode: tfjson.SchemaNestingModeList,
+				MaxItems:    1,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"type":         {AttributeType: cty.String, Required: true},
+						"identity_ids": {AttributeType: cty.Set(cty.String), Optional: true},
+					},
+				},
+			},
+			"status": {
+				NestingMode: tfjson.SchemaNestingModeList,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"state": {AttributeType: cty.String, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderSkeleton_Resource(t *testing.T) {
+	result, err := renderSkeleton("resource", "azurerm_kubernetes_cluster", testResourceBlock())
+	require.NoError(t, err)
+
+	expected := `resource "azurerm_kubernetes_cluster" "this" {
+  location = "REPLACE_ME"
+  name     = "REPLACE_ME"
+  # settings = {
+  #   size = 0
+  # }
+  # tags = {}
+  default_node_pool {
+    vm_size = "REPLACE_ME"
+    # node_count = 0
+  }
+  # identity {
+  #   type = "REPLACE_ME"
+  #   # identity_ids = []
+  # }
+}
+`
+	assert.Equal(t, expected, result)
+
+	// The skeleton must be valid HCL so it can be edited in place
+	_, diags := hclsyntax.ParseConfig([]byte(result), "main.tf", hcl.InitialPos)
+	assert.False(t, diags.HasErrors(), diags.Error())
+}
+
+func TestRenderSkeleton_Provider(t *testing.T) {
+	result, err := renderSkeleton("provider", "azurerm", testProviderBlock)
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `provider "azurerm" {`)
+	assert.Contains(t, result, "  # features {\n")
+	assert.Contains(t, result, "  #   # key_vault {\n")
+	assert.Contains(t, result, "  #   #   # purge_soft_delete_on_destroy = false\n")
+	assert.Contains(t, result, "  # subscription_id = \"REPLACE_ME\"")
+}
+
+func TestRenderSkeleton_NestedAttributeModes(t *testing.T) {
+	block := &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"rules": {
+				Required: true,
+				AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+					NestingMode: tfjson.SchemaNestingModeList,
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"enabled": {AttributeType: cty.Bool, Required: true},
+					},
+				},
+			},
+			"labels": {
+				Required: true,
+				AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+					NestingMode: tfjson.SchemaNestingModeMap,
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"value": {AttributeType: cty.String, Required: true},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := renderSkeleton("ephemeral", "example_thing", block)
+	require.NoError(t, err)
+
+	expected := `ephemeral "example_thing" "this" {
+  labels = {
+    key = {
+      value = "REPLACE_ME"
+    }
+  }
+  rules = [{
+    enabled = false
+  }]
+}
+`
+	assert.Equal(t, expected, result)
+}
+
+func TestRenderSkeleton_InvalidCategory(t *testing.T) {
+	_, err := renderSkeleton("function", "can", &tfjson.SchemaBlock{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown schema category")
+}
+
+func TestGenerateSkeleton_FunctionNotSupported(t *testing.T) {
+	_, err := GenerateSkeleton("function", "can", testProviderReq)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported for function")
+}
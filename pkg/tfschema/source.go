@@ -0,0 +1,35 @@
+package tfschema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ParseProviderSource parses a provider source address as written in required_providers, like
+// `registry.terraform.io/hashicorp/aws` or `hashicorp/aws`, into its namespace and name. The hostname, when present,
+// must be a public registry or the private registry configured through RegistryHostEnv.
+func ParseProviderSource(source string) (namespace, name string, err error) {
+	parts := strings.Split(strings.TrimSpace(source), "/")
+	switch len(parts) {
+	case 2:
+		namespace, name = parts[0], parts[1]
+	case 3:
+		host := strings.ToLower(parts[0])
+		if !slices.Contains(mirrorHosts(), host) {
+			return "", "", fmt.Errorf("provider source %q uses registry host %s, only %s are supported, set %s to query a private registry", source, host, strings.Join(mirrorHosts(), ", "), RegistryHostEnv)
+		}
+		namespace, name = parts[1], parts[2]
+	default:
+		return "", "", fmt.Errorf("invalid provider source %q, expected [hostname/]namespace/name", source)
+	}
+	if namespace == "" || name == "" {
+		return "", "", fmt.Errorf("invalid provider source %q, expected [hostname/]namespace/name", source)
+	}
+	return namespace, name, nil
+}
+
+// IsProviderSource reports whether s is a provider source address rather than a bare provider name
+func IsProviderSource(s string) bool {
+	return strings.Contains(s, "/")
+}
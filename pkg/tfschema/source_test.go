@@ -0,0 +1,44 @@
+package tfschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProviderSource(t *testing.T) {
+	tests := []struct {
+		source    string
+		namespace string
+		name      string
+	}{
+		{source: "hashicorp/aws", namespace: "hashicorp", name: "aws"},
+		{source: "registry.terraform.io/hashicorp/aws", namespace: "hashicorp", name: "aws"},
+		{source: "Registry.OpenTofu.org/Azure/azapi", namespace: "Azure", name: "azapi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			namespace, name, err := ParseProviderSource(tt.source)
+			require.NoError(t, err)
+			assert.Equal(t, tt.namespace, namespace)
+			assert.Equal(t, tt.name, name)
+		})
+	}
+}
+
+func TestParseProviderSource_Invalid(t *testing.T) {
+	for _, source := range []string{"aws", "hashicorp/", "/aws", "a/b/c/d"} {
+		_, _, err := ParseProviderSource(source)
+		assert.ErrorContains(t, err, "invalid provider source", source)
+	}
+
+	_, _, err := ParseProviderSource("app.terraform.io/example/azurerm")
+	assert.ErrorContains(t, err, "set TFSCHEMA_REGISTRY_HOST")
+
+	t.Setenv(RegistryHostEnv, "app.terraform.io")
+	namespace, name, err := ParseProviderSource("app.terraform.io/example/azurerm")
+	require.NoError(t, err)
+	assert.Equal(t, "example", namespace)
+	assert.Equal(t, "azurerm", name)
+}
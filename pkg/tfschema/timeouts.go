@@ -0,0 +1,44 @@
+package tfschema
+
+import (
+	"slices"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// timeoutsName is the name of the block, or the nested attribute for plugin framework providers, configuring
+// operation timeouts of a resource
+const timeoutsName = "timeouts"
+
+// timeoutOperationOrder is the order timeout operations are reported in, other operations follow alphabetically
+var timeoutOperationOrder = []string{"create", "read", "update", "delete"}
+
+// timeoutOperations returns the operations whose timeout can be configured on block, in the order create, read,
+// update, delete. Provider schemas don't carry the default durations, those are only documented by the provider.
+func timeoutOperations(block *tfjson.SchemaBlock) []string {
+	if block == nil {
+		return nil
+	}
+	var attributes map[string]*tfjson.SchemaAttribute
+	if nested, ok := block.NestedBlocks[timeoutsName]; ok && nested.Block != nil {
+		attributes = nested.Block.Attributes
+	} else if attr, ok := block.Attributes[timeoutsName]; ok && attr.AttributeNestedType != nil {
+		attributes = attr.AttributeNestedType.Attributes
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	operations := sortedKeys(attributes)
+	slices.SortStableFunc(operations, func(a, b string) int {
+		return timeoutOperationRank(a) - timeoutOperationRank(b)
+	})
+	return operations
+}
+
+func timeoutOperationRank(operation string) int {
+	if i := slices.Index(timeoutOperationOrder, operation); i >= 0 {
+		return i
+	}
+	return len(timeoutOperationOrder)
+}
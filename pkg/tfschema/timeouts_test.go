@@ -0,0 +1,78 @@
+package tfschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func testTimeoutsAttributes() map[string]*tfjson.SchemaAttribute {
+	return map[string]*tfjson.SchemaAttribute{
+		"delete": {AttributeType: cty.String, Optional: true},
+		"create": {AttributeType: cty.String, Optional: true},
+		"update": {AttributeType: cty.String, Optional: true},
+		"read":   {AttributeType: cty.String, Optional: true},
+	}
+}
+
+func testBlockWithTimeoutsBlock() *tfjson.SchemaBlock {
+	block := testResourceBlock()
+	block.NestedBlocks[timeoutsName] = &tfjson.SchemaBlockType{
+		NestingMode: tfjson.SchemaNestingModeSingle,
+		Block:       &tfjson.SchemaBlock{Attributes: testTimeoutsAttributes()},
+	}
+	return block
+}
+
+func TestTimeoutOperations(t *testing.T) {
+	assert.Equal(t, []string{"create", "read", "update", "delete"}, timeoutOperations(testBlockWithTimeoutsBlock()))
+
+	// Plugin framework providers expose timeouts as a nested attribute
+	block := testResourceBlock()
+	block.Attributes[timeoutsName] = &tfjson.SchemaAttribute{
+		Optional: true,
+		AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+			NestingMode: tfjson.SchemaNestingModeSingle,
+			Attributes: map[string]*tfjson.SchemaAttribute{
+				"delete": {AttributeType: cty.String, Optional: true},
+				"create": {AttributeType: cty.String, Optional: true},
+			},
+		},
+	}
+	assert.Equal(t, []string{"create", "delete"}, timeoutOperations(block))
+
+	assert.Nil(t, timeoutOperations(testResourceBlock()))
+}
+
+func TestApplyView_NamesAndTypesTimeouts(t *testing.T) {
+	result := applyView(&tfjson.Schema{Block: testBlockWithTimeoutsBlock()}, ViewNamesAndTypes)
+
+	content, err := json.Marshal(result)
+	require.NoError(t, err)
+	var compact map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(content, &compact))
+	assert.JSONEq(t, `["create","read","update","delete"]`, string(compact["timeouts"]))
+	assert.NotContains(t, string(compact["blocks"]), `"timeouts"`)
+}
+
+func TestApplyView_RequiredOnlyKeepsTimeouts(t *testing.T) {
+	result := applyView(&tfjson.Schema{Block: testBlockWithTimeoutsBlock()}, ViewRequiredOnly)
+
+	schema, ok := result.(*tfjson.Schema)
+	require.True(t, ok)
+	require.Contains(t, schema.Block.NestedBlocks, timeoutsName)
+	assert.Len(t, schema.Block.NestedBlocks[timeoutsName].Block.Attributes, 4)
+	assert.NotContains(t, schema.Block.NestedBlocks, "identity")
+
+	schema = applyView(&tfjson.Schema{Block: testResourceBlock()}, ViewRequiredOnly).(*tfjson.Schema)
+	assert.NotContains(t, schema.Block.NestedBlocks, timeoutsName)
+}
+
+func TestRenderMarkdown_Timeouts(t *testing.T) {
+	result := renderMarkdown("`azurerm_kubernetes_cluster`", "", &tfjson.Schema{Block: testBlockWithTimeoutsBlock()})
+	assert.Contains(t, result, "Configurable timeouts: `create`, `read`, `update`, `delete`")
+}
@@ -0,0 +1,283 @@
+package tfschema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Schema views trading detail for size
+const (
+	// ViewFull returns the schema as reported by the provider
+	ViewFull = "full"
+	// ViewRequiredOnly keeps only required attributes and required nested blocks, without descriptions
+	ViewRequiredOnly = "required_only"
+	// ViewNamesAndTypes renders every configurable attribute as `type, required|optional`, without descriptions
+	ViewNamesAndTypes = "names_and_types"
+	// ViewDescriptions renders every attribute and nested block as a nested map of descriptions, without types or flags
+	ViewDescriptions = "descriptions"
+)
+
+// Views lists the supported schema views
+var Views = []string{ViewFull, ViewRequiredOnly, ViewNamesAndTypes, ViewDescriptions}
+
+// QueryOptions tunes the output of QuerySchemaWithOptions
+type QueryOptions struct {
+	// View is one of Views, defaults to ViewFull
+	View string
+	// Format is one of Formats, defaults to FormatJSON
+	Format string
+}
+
+// compactBlock is the names_and_types representation of a schema block
+type compactBlock struct {
+	Nesting          string                   `json:"nesting,omitempty"`
+	Required         bool                     `json:"required,omitempty"`
+	MinItems         uint64                   `json:"min_items,omitempty"`
+	MaxItems         uint64                   `json:"max_items,omitempty"`
+	Attributes       map[string]string        `json:"attributes,omitempty"`
+	NestedAttributes map[string]*compactBlock `json:"nested_attributes,omitempty"`
+	Blocks           map[string]*compactBlock `json:"blocks,omitempty"`
+	// Timeouts lists the operations whose timeout can be configured, it replaces the timeouts block or attribute
+	Timeouts []string `json:"timeouts,omitempty"`
+}
+
+func validateView(view string) error {
+	for _, v := range Views {
+		if v == view {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown view %q, must be one of: %s", view, strings.Join(Views, ", "))
+}
+
+// applyView transforms a schema, block, nested block or attribute returned by a query according to view
+func applyView(value any, view string) any {
+	if view == "" || view == ViewFull {
+		return value
+	}
+	if view == ViewDescriptions {
+		return describeValue(value)
+	}
+	requiredOnly := view == ViewRequiredOnly
+	switch v := value.(type) {
+	case PathMatches:
+		result := make(map[string]any, len(v))
+		for path, match := range v {
+			result[path] = applyView(match, view)
+		}
+		return result
+	case *tfjson.Schema:
+		if view == ViewNamesAndTypes {
+			return compactSchemaBlock(v.Block)
+		}
+		return &tfjson.Schema{Version: v.Version, Block: keepTimeouts(pruneBlock(v.Block, requiredOnly), v.Block)}
+	case *tfjson.SchemaBlock:
+		if view == ViewNamesAndTypes {
+			return compactSchemaBlock(v)
+		}
+		return pruneBlock(v, requiredOnly)
+	case *tfjson.SchemaBlockType:
+		if view == ViewNamesAndTypes {
+			return compactBlockType(v)
+		}
+		return &tfjson.SchemaBlockType{
+			NestingMode: v.NestingMode,
+			MinItems:    v.MinItems,
+			MaxItems:    v.MaxItems,
+			Block:       pruneBlock(v.Block, requiredOnly),
+		}
+	case *tfjson.SchemaAttribute:
+		if view == ViewNamesAndTypes {
+			return attributeSummary(v)
+		}
+		return pruneAttribute(v, requiredOnly)
+	}
+	return value
+}
+
+// keepAttribute drops computed-only attributes, and optional attributes when requiredOnly is set
+func keepAttribute(attr *tfjson.SchemaAttribute, requiredOnly bool) bool {
+	if requiredOnly {
+		return attr.Required
+	}
+	return attr.Required || attr.Optional
+}
+
+// keepBlock drops blocks without configurable content, and optional blocks when requiredOnly is set
+func keepBlock(block *tfjson.SchemaBlockType, requiredOnly bool) bool {
+	if block.Block == nil || (requiredOnly && block.MinItems == 0) {
+		return false
+	}
+	return hasSettableContent(block.Block)
+}
+
+// pruneBlock returns a copy of block without descriptions and computed-only attributes
+func pruneBlock(block *tfjson.SchemaBlock, requiredOnly bool) *tfjson.SchemaBlock {
+	if block == nil {
+		return nil
+	}
+	pruned := &tfjson.SchemaBlock{Deprecated: block.Deprecated}
+	for name, attr := range block.Attributes {
+		if !keepAttribute(attr, requiredOnly) {
+			continue
+		}
+		if pruned.Attributes == nil {
+			pruned.Attributes = make(map[string]*tfjson.SchemaAttribute)
+		}
+		pruned.Attributes[name] = pruneAttribute(attr, requiredOnly)
+	}
+	for name, nested := range block.NestedBlocks {
+		if !keepBlock(nested, requiredOnly) {
+			continue
+		}
+		if pruned.NestedBlocks == nil {
+			pruned.NestedBlocks = make(map[string]*tfjson.SchemaBlockType)
+		}
+		pruned.NestedBlocks[name] = &tfjson.SchemaBlockType{
+			NestingMode: nested.NestingMode,
+			MinItems:    nested.MinItems,
+			MaxItems:    nested.MaxItems,
+			Block:       pruneBlock(nested.Block, requiredOnly),
+		}
+	}
+	return pruned
+}
+
+// keepTimeouts adds the timeouts block or attribute of the source schema block back to a pruned root block,
+// agents need it to advise on timeouts of slow resources even when only required arguments are requested
+func keepTimeouts(pruned, source *tfjson.SchemaBlock) *tfjson.SchemaBlock {
+	if pruned == nil || timeoutOperations(source) == nil {
+		return pruned
+	}
+	if nested, ok := source.NestedBlocks[timeoutsName]; ok {
+		if pruned.NestedBlocks == nil {
+			pruned.NestedBlocks = make(map[string]*tfjson.SchemaBlockType)
+		}
+		if _, ok := pruned.NestedBlocks[timeoutsName]; !ok {
+			pruned.NestedBlocks[timeoutsName] = &tfjson.SchemaBlockType{
+				NestingMode: nested.NestingMode,
+				MinItems:    nested.MinItems,
+				MaxItems:    nested.MaxItems,
+				Block:       pruneBlock(nested.Block, false),
+			}
+		}
+		return pruned
+	}
+	if pruned.Attributes == nil {
+		pruned.Attributes = make(map[string]*tfjson.SchemaAttribute)
+	}
+	if _, ok := pruned.Attributes[timeoutsName]; !ok {
+		pruned.Attributes[timeoutsName] = pruneAttribute(source.Attributes[timeoutsName], false)
+	}
+	return pruned
+}
+
+// pruneAttribute returns a copy of attr without descriptions, nested attributes are pruned recursively
+func pruneAttribute(attr *tfjson.SchemaAttribute, requiredOnly bool) *tfjson.SchemaAttribute {
+	pruned := *attr
+	pruned.Description = ""
+	pruned.DescriptionKind = ""
+	if attr.AttributeNestedType != nil {
+		nested := *attr.AttributeNestedType
+		nested.Attributes = make(map[string]*tfjson.SchemaAttribute)
+		for name, a := range attr.AttributeNestedType.Attributes {
+			if keepAttribute(a, requiredOnly) {
+				nested.Attributes[name] = pruneAttribute(a, requiredOnly)
+			}
+		}
+		pruned.AttributeNestedType = &nested
+	}
+	return &pruned
+}
+
+func compactSchemaBlock(block *tfjson.SchemaBlock) *compactBlock {
+	result := &compactBlock{}
+	if block == nil {
+		return result
+	}
+	result.Timeouts = timeoutOperations(block)
+	for name, attr := range block.Attributes {
+		if !keepAttribute(attr, false) || (name == timeoutsName && result.Timeouts != nil) {
+			continue
+		}
+		if result.Attributes == nil {
+			result.Attributes = make(map[string]string)
+		}
+		result.Attributes[name] = attributeSummary(attr)
+		if attr.AttributeNestedType != nil {
+			if result.NestedAttributes == nil {
+				result.NestedAttributes = make(map[string]*compactBlock)
+			}
+			result.NestedAttributes[name] = compactNestedAttribute(attr.AttributeNestedType)
+		}
+	}
+	for name, nested := range block.NestedBlocks {
+		if !keepBlock(nested, false) || (name == timeoutsName && result.Timeouts != nil) {
+			continue
+		}
+		if result.Blocks == nil {
+			result.Blocks = make(map[string]*compactBlock)
+		}
+		result.Blocks[name] = compactBlockType(nested)
+	}
+	return result
+}
+
+func compactBlockType(block *tfjson.SchemaBlockType) *compactBlock {
+	result := compactSchemaBlock(block.Block)
+	result.Nesting = string(block.NestingMode)
+	result.Required = block.MinItems > 0
+	result.MinItems = block.MinItems
+	result.MaxItems = block.MaxItems
+	return result
+}
+
+func compactNestedAttribute(nested *tfjson.SchemaNestedAttributeType) *compactBlock {
+	result := compactSchemaBlock(&tfjson.SchemaBlock{Attributes: nested.Attributes})
+	result.Nesting = string(nested.NestingMode)
+	return result
+}
+
+// attributeSummary renders an attribute as `<type>, required|optional[, computed][, sensitive][, deprecated]`
+func attributeSummary(attr *tfjson.SchemaAttribute) string {
+	parts := []string{attributeTypeString(attr)}
+	switch {
+	case attr.Required:
+		parts = append(parts, "required")
+	case attr.Optional:
+		parts = append(parts, "optional")
+	}
+	if attr.Computed {
+		parts = append(parts, "computed")
+	}
+	if attr.Sensitive {
+		parts = append(parts, "sensitive")
+	}
+	if attr.Deprecated {
+		parts = append(parts, "deprecated")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func attributeTypeString(attr *tfjson.SchemaAttribute) string {
+	if attr.AttributeNestedType == nil {
+		if attr.AttributeType == cty.NilType {
+			return "any"
+		}
+		return typeexpr.TypeString(attr.AttributeType)
+	}
+	switch attr.AttributeNestedType.NestingMode {
+	case tfjson.SchemaNestingModeList:
+		return "list(object)"
+	case tfjson.SchemaNestingModeSet:
+		return "set(object)"
+	case tfjson.SchemaNestingModeMap:
+		return "map(object)"
+	default:
+		return "object"
+	}
+}
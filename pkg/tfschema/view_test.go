@@ -0,0 +1,107 @@
+package tfschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func testDescribedBlock() *tfjson.SchemaBlock {
+	block := testResourceBlock()
+	block.Attributes["name"].Description = "The name of the cluster."
+	block.Attributes["tags"].Description = "A mapping of tags."
+	block.Attributes["secret"] = &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, Sensitive: true}
+	return block
+}
+
+func TestApplyView_Full(t *testing.T) {
+	block := testDescribedBlock()
+	assert.Same(t, block, applyView(block, ViewFull))
+	assert.Same(t, block, applyView(block, ""))
+}
+
+func TestApplyView_RequiredOnly(t *testing.T) {
+	result := applyView(&tfjson.Schema{Version: 2, Block: testDescribedBlock()}, ViewRequiredOnly)
+
+	schema, ok := result.(*tfjson.Schema)
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), schema.Version)
+	assert.Equal(t, []string{"location", "name"}, sortedKeys(schema.Block.Attributes))
+	assert.Empty(t, schema.Block.Attributes["name"].Description)
+	assert.True(t, schema.Block.Attributes["name"].Required)
+	assert.Equal(t, []string{"default_node_pool"}, sortedKeys(schema.Block.NestedBlocks))
+	assert.Equal(t, []string{"vm_size"}, sortedKeys(schema.Block.NestedBlocks["default_node_pool"].Block.Attributes))
+}
+
+func TestApplyView_RequiredOnlyDoesNotModifySource(t *testing.T) {
+	block := testDescribedBlock()
+	_ = applyView(block, ViewRequiredOnly)
+	assert.Equal(t, "The name of the cluster.", block.Attributes["name"].Description)
+	assert.Contains(t, block.Attributes, "id")
+}
+
+func TestApplyView_NamesAndTypes(t *testing.T) {
+	result := applyView(testDescribedBlock(), ViewNamesAndTypes)
+
+	content, err := json.Marshal(result)
+	require.NoError(t, err)
+	expected := `{
+		"attributes": {
+			"legacy": "bool, optional, deprecated",
+			"location": "string, required",
+			"name": "string, required",
+			"secret": "string, optional, sensitive",
+			"settings": "object, optional",
+			"tags": "map(string), optional"
+		},
+		"nested_attributes": {
+			"settings": {"nesting": "single", "attributes": {"size": "number, required"}}
+		},
+		"blocks": {
+			"default_node_pool": {
+				"nesting": "list",
+				"required": true,
+				"min_items": 1,
+				"max_items": 1,
+				"attributes": {"node_count": "number, optional", "vm_size": "string, required"}
+			},
+			"identity": {
+				"nesting": "list",
+				"max_items": 1,
+				"attributes": {"identity_ids": "set(string), optional", "type": "string, required"}
+			}
+		}
+	}`
+	assert.JSONEq(t, expected, string(content))
+}
+
+func TestApplyView_Attribute(t *testing.T) {
+	attr := &tfjson.SchemaAttribute{AttributeType: cty.List(cty.String), Optional: true, Computed: true, Description: "Zones."}
+
+	assert.Equal(t, "list(string), optional, computed", applyView(attr, ViewNamesAndTypes))
+	pruned, ok := applyView(attr, ViewRequiredOnly).(*tfjson.SchemaAttribute)
+	require.True(t, ok)
+	assert.Empty(t, pruned.Description)
+	assert.Equal(t, "Zones.", attr.Description)
+}
+
+func TestQuerySchemaWithOptions_InvalidView(t *testing.T) {
+	_, err := QuerySchemaWithOptions("resource", "azurerm_resource_group", "", testProviderReq, QueryOptions{View: "tiny"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown view")
+}
+
+func TestQuerySchemaWithOptions_View(t *testing.T) {
+	stubSchemaSource(t)
+
+	result, err := QuerySchemaWithOptions("resource", "azurerm_resource_group", "", cacheTestReq, QueryOptions{View: ViewNamesAndTypes})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"attributes":{"location":"string, required","name":"string, required"}}`, result)
+
+	_, err = QuerySchemaWithOptions("function", "normalise_resource_id", "", cacheTestReq, QueryOptions{View: ViewRequiredOnly})
+	require.Error(t, err)
+}
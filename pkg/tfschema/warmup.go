@@ -0,0 +1,54 @@
+package tfschema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PreloadEnv lists providers to load in the background when the server starts, separated by commas,
+// e.g. `hashicorp/azurerm@latest,Azure/azapi@~> 2.0`. The version is optional and defaults to latest.
+const PreloadEnv = "TFSCHEMA_PRELOAD"
+
+// ParsePreloadList parses a comma-separated list of `namespace/name[@version]` entries, `latest` or an empty
+// version resolves to the latest version, any other version is used as a version constraint
+func ParsePreloadList(list string) ([]ProviderRequest, error) {
+	var reqs []ProviderRequest
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		address, version, _ := strings.Cut(entry, "@")
+		namespace, name, ok := strings.Cut(address, "/")
+		if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid preload entry %q, expected namespace/name[@version]", entry)
+		}
+		version = strings.TrimSpace(version)
+		if version == "latest" {
+			version = ""
+		}
+		reqs = append(reqs, ProviderRequest{
+			ProviderNamespace: namespace,
+			ProviderName:      name,
+			ProviderVersion:   version,
+		})
+	}
+	return reqs, nil
+}
+
+// WarmUp loads the schemas of the providers into the memory and disk caches, so the first query doesn't pay the
+// provider download cost. Providers are loaded one at a time, a failing provider doesn't stop the others.
+func WarmUp(ctx context.Context, reqs []ProviderRequest) error {
+	var errs []error
+	for _, req := range reqs {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if _, err := providerSchema(req); err != nil {
+			errs = append(errs, fmt.Errorf("failed to preload provider %s/%s: %w", req.ProviderNamespace, req.ProviderName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
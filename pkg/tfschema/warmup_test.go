@@ -0,0 +1,95 @@
+package tfschema
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePreloadList(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     string
+		expected []ProviderRequest
+	}{
+		{
+			name: "latest and constraint",
+			list: "hashicorp/azurerm@latest, Azure/azapi@~> 2.0",
+			expected: []ProviderRequest{
+				{ProviderNamespace: "hashicorp", ProviderName: "azurerm"},
+				{ProviderNamespace: "Azure", ProviderName: "azapi", ProviderVersion: "~> 2.0"},
+			},
+		},
+		{
+			name: "version is optional",
+			list: "hashicorp/aws,",
+			expected: []ProviderRequest{
+				{ProviderNamespace: "hashicorp", ProviderName: "aws"},
+			},
+		},
+		{
+			name: "empty list",
+			list: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqs, err := ParsePreloadList(tt.list)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, reqs)
+		})
+	}
+}
+
+func TestParsePreloadList_Invalid(t *testing.T) {
+	for _, list := range []string{"azurerm", "hashicorp/", "/azurerm@latest", "registry.terraform.io/hashicorp/azurerm"} {
+		_, err := ParsePreloadList(list)
+		assert.ErrorContains(t, err, "invalid preload entry", list)
+	}
+}
+
+func TestWarmUp(t *testing.T) {
+	downloads := stubSchemaSource(t, "4.38.1", "4.39.0")
+
+	require.NoError(t, WarmUp(context.Background(), []ProviderRequest{{ProviderNamespace: "hashicorp", ProviderName: "azurerm"}}))
+	assert.Equal(t, 1, *downloads)
+
+	_, err := QuerySchema("resource", "azurerm_resource_group", "", cacheTestReq)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *downloads)
+}
+
+func TestWarmUp_ContinuesAfterFailure(t *testing.T) {
+	stubSchemaSource(t)
+	var loaded []string
+	stubs := gostub.Stub(&fetchProviderSchema, func(req ProviderRequest) (*tfjson.ProviderSchema, error) {
+		if req.ProviderName == "broken" {
+			return nil, errors.New("download failed")
+		}
+		loaded = append(loaded, req.ProviderName)
+		return cacheTestSchema(), nil
+	})
+	defer stubs.Reset()
+
+	err := WarmUp(context.Background(), []ProviderRequest{
+		{ProviderNamespace: "hashicorp", ProviderName: "broken", ProviderVersion: "1.0.0"},
+		{ProviderNamespace: "hashicorp", ProviderName: "azurerm", ProviderVersion: "4.39.0"},
+	})
+	assert.ErrorContains(t, err, "failed to preload provider hashicorp/broken: download failed")
+	assert.Equal(t, []string{"azurerm"}, loaded)
+}
+
+func TestWarmUp_Cancelled(t *testing.T) {
+	downloads := stubSchemaSource(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := WarmUp(ctx, []ProviderRequest{cacheTestReq})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, *downloads)
+}
@@ -0,0 +1,88 @@
+package tfschema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// PathMatches maps the full dot-separated path of every element matched by a wildcard path to the
+// matched attribute (*tfjson.SchemaAttribute) or nested block (*tfjson.SchemaBlockType)
+type PathMatches map[string]any
+
+// hasWildcard reports whether path contains a `*` or `**` segment
+func hasWildcard(path string) bool {
+	return slices.ContainsFunc(strings.Split(path, "."), isWildcard)
+}
+
+func isWildcard(segment string) bool {
+	return segment == "*" || segment == "**"
+}
+
+// queryWildcardPath returns all attributes and nested blocks matching path. `*` matches any single attribute or
+// block, `**` matches zero or more levels of nested blocks, e.g. `*.tags` or `**.identity`.
+func queryWildcardPath(block *tfjson.SchemaBlock, path string) (PathMatches, error) {
+	segments := strings.Split(path, ".")
+	if slices.Contains(segments, "") {
+		return nil, fmt.Errorf("invalid path %s: empty path segment", path)
+	}
+	matches := make(PathMatches)
+	matchSegments(block, segments, "", matches)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no attributes or blocks match path %s", path)
+	}
+	return matches, nil
+}
+
+func matchSegments(block *tfjson.SchemaBlock, segments []string, prefix string, matches PathMatches) {
+	if block == nil || len(segments) == 0 {
+		return
+	}
+	segment, rest := segments[0], segments[1:]
+
+	if segment == "**" {
+		// zero levels
+		if len(rest) == 0 {
+			matchAll(block, prefix, matches)
+			return
+		}
+		matchSegments(block, rest, prefix, matches)
+		// one or more levels
+		for name, nested := range block.NestedBlocks {
+			matchSegments(nested.Block, segments, joinPath(prefix, name), matches)
+		}
+		return
+	}
+
+	for name, attr := range block.Attributes {
+		if (segment == "*" || segment == name) && len(rest) == 0 {
+			matches[joinPath(prefix, name)] = attr
+		}
+	}
+	for name, nested := range block.NestedBlocks {
+		if segment != "*" && segment != name {
+			continue
+		}
+		if len(rest) == 0 {
+			matches[joinPath(prefix, name)] = nested
+			continue
+		}
+		matchSegments(nested.Block, rest, joinPath(prefix, name), matches)
+	}
+}
+
+// matchAll adds every attribute and nested block under block
+func matchAll(block *tfjson.SchemaBlock, prefix string, matches PathMatches) {
+	if block == nil {
+		return
+	}
+	for name, attr := range block.Attributes {
+		matches[joinPath(prefix, name)] = attr
+	}
+	for name, nested := range block.NestedBlocks {
+		matches[joinPath(prefix, name)] = nested
+		matchAll(nested.Block, joinPath(prefix, name), matches)
+	}
+}
@@ -0,0 +1,109 @@
+package tfschema
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func testWildcardBlock() *tfjson.SchemaBlock {
+	tags := func() *tfjson.SchemaAttribute {
+		return &tfjson.SchemaAttribute{AttributeType: cty.Map(cty.String), Optional: true}
+	}
+	identity := func() *tfjson.SchemaBlockType {
+		return &tfjson.SchemaBlockType{
+			NestingMode: tfjson.SchemaNestingModeList,
+			Block: &tfjson.SchemaBlock{Attributes: map[string]*tfjson.SchemaAttribute{
+				"type": {AttributeType: cty.String, Required: true},
+			}},
+		}
+	}
+	return &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"name": {AttributeType: cty.String, Required: true},
+			"tags": tags(),
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{
+			"identity": identity(),
+			"default_node_pool": {
+				NestingMode: tfjson.SchemaNestingModeList,
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"tags": tags(),
+					},
+					NestedBlocks: map[string]*tfjson.SchemaBlockType{
+						"kubelet_config": {
+							NestingMode: tfjson.SchemaNestingModeList,
+							Block: &tfjson.SchemaBlock{NestedBlocks: map[string]*tfjson.SchemaBlockType{
+								"identity": identity(),
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestQuerySchemaPath_Wildcards(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{name: "single level wildcard", path: "*.tags", expected: []string{"default_node_pool.tags"}},
+		{name: "wildcard leaf", path: "identity.*", expected: []string{"identity.type"}},
+		{name: "recursive attribute", path: "**.tags", expected: []string{"default_node_pool.tags", "tags"}},
+		{name: "recursive block", path: "**.identity", expected: []string{"default_node_pool.kubelet_config.identity", "identity"}},
+		{name: "recursive then literal", path: "**.identity.type", expected: []string{"default_node_pool.kubelet_config.identity.type", "identity.type"}},
+		{name: "literal then recursive", path: "default_node_pool.**.type", expected: []string{"default_node_pool.kubelet_config.identity.type"}},
+		{
+			name:     "trailing recursive wildcard matches everything below",
+			path:     "default_node_pool.kubelet_config.**",
+			expected: []string{"default_node_pool.kubelet_config.identity", "default_node_pool.kubelet_config.identity.type"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := querySchemaPath(testWildcardBlock(), tt.path)
+			require.NoError(t, err)
+			matches, ok := result.(PathMatches)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, sortedKeys(matches))
+		})
+	}
+}
+
+func TestQuerySchemaPath_WildcardMatchTypes(t *testing.T) {
+	result, err := querySchemaPath(testWildcardBlock(), "**.identity")
+	require.NoError(t, err)
+	matches := result.(PathMatches)
+	assert.IsType(t, &tfjson.SchemaBlockType{}, matches["identity"])
+
+	result, err = querySchemaPath(testWildcardBlock(), "*.tags")
+	require.NoError(t, err)
+	assert.IsType(t, &tfjson.SchemaAttribute{}, result.(PathMatches)["default_node_pool.tags"])
+}
+
+func TestQuerySchemaPath_WildcardNoMatch(t *testing.T) {
+	_, err := querySchemaPath(testWildcardBlock(), "**.not_exist")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no attributes or blocks match path **.not_exist")
+
+	_, err = querySchemaPath(testWildcardBlock(), "*..tags")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "empty path segment")
+}
+
+func TestApplyView_PathMatches(t *testing.T) {
+	result, err := querySchemaPath(testWildcardBlock(), "**.tags")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"tags":                   "map(string), optional",
+		"default_node_pool.tags": "map(string), optional",
+	}, applyView(result, ViewNamesAndTypes))
+}
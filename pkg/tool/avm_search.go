@@ -0,0 +1,61 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfmodule"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AVMSearchParam struct {
+	Query        string `json:"query,omitempty" jsonschema:"Keywords matched against module names, display names, resource types and descriptions (e.g., 'key vault'). All keywords must match."`
+	ResourceType string `json:"resource_type,omitempty" jsonschema:"ARM resource type or resource provider namespace prefix (e.g., 'Microsoft.KeyVault/vaults', 'Microsoft.KeyVault')."`
+	Category     string `json:"category,omitempty" jsonschema:"Module category: 'resource', 'pattern' or 'utility'. If not set, all categories are searched."`
+	Cursor       string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit        int    `json:"limit,omitempty" jsonschema:"Maximum number of modules to return. Defaults to 10."`
+}
+
+// defaultAVMSearchLimit is the page size when no limit is requested, the latest version is looked up for each module
+const defaultAVMSearchLimit = 10
+
+func SearchAVMModules(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AVMSearchParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	if args.Query == "" && args.ResourceType == "" {
+		return nil, fmt.Errorf("query or resource_type is required")
+	}
+	modules, err := tfmodule.SearchAVMModules(ctx, tfmodule.AVMSearchRequest{
+		Query:        args.Query,
+		ResourceType: args.ResourceType,
+		Category:     args.Category,
+	})
+	if err != nil {
+		return nil, err
+	}
+	limit := args.Limit
+	if limit == 0 {
+		limit = defaultAVMSearchLimit
+	}
+	page, err := paginate(modules, args.Cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	tfmodule.LookupLatestVersions(ctx, page.Items)
+	content, err := json.Marshal(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal AVM search result: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+				Annotations: &mcp.Annotations{
+					Audience: []mcp.Role{
+						"assistant",
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,39 @@
+package tool
+
+import (
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// resolveApiVersion resolves api-version aliases like `latest`, so a result can report the concrete version it was
+// generated from
+func resolveApiVersion(resourceType, apiVersion string) (string, error) {
+	resolved, err := azapi.ResolveApiVersion(resourceType, apiVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve api-version %q of %s: %w", apiVersion, resourceType, err)
+	}
+	return resolved, nil
+}
+
+// withApiVersion records the concrete api-version in the result metadata for clients, and appends a note to the
+// content for agents, when it was resolved from an alias
+func withApiVersion(result *mcp.CallToolResultFor[any], resourceType, requested, resolved string) *mcp.CallToolResultFor[any] {
+	if requested == resolved {
+		return result
+	}
+	if result.Meta == nil {
+		result.Meta = mcp.Meta{}
+	}
+	result.Meta["api_version"] = resolved
+	result.Content = append(result.Content, &mcp.TextContent{
+		Text: fmt.Sprintf("Resolved api-version: %s@%s", resourceType, resolved),
+		Annotations: &mcp.Annotations{
+			Audience: []mcp.Role{
+				"assistant",
+			},
+		},
+	})
+	return result
+}
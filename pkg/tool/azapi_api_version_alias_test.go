@@ -0,0 +1,40 @@
+package tool
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithApiVersion(t *testing.T) {
+	result := withApiVersion(schemaResult("String"), "Microsoft.Compute/virtualMachines", "latest", "2024-11-01")
+
+	assert.Equal(t, "2024-11-01", result.Meta["api_version"])
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "String", result.Content[0].(*mcp.TextContent).Text)
+	assert.Equal(t, "Resolved api-version: Microsoft.Compute/virtualMachines@2024-11-01", result.Content[1].(*mcp.TextContent).Text)
+}
+
+func TestWithApiVersion_Concrete(t *testing.T) {
+	result := withApiVersion(schemaResult("String"), "Microsoft.Compute/virtualMachines", "2024-11-01", "2024-11-01")
+
+	assert.Nil(t, result.Meta)
+	assert.Len(t, result.Content, 1)
+}
+
+func TestQueryAzAPIResourceSchema_LatestAlias(t *testing.T) {
+	result, err := QueryAzAPIResourceSchema(t.Context(), nil, &mcp.CallToolParamsFor[AzAPIResourceSchemaQueryParam]{
+		Arguments: AzAPIResourceSchemaQueryParam{
+			ResourceType: "Microsoft.Compute/virtualMachines",
+			ApiVersion:   "latest-stable",
+			Path:         "body.properties.licenseType",
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "String", result.Content[0].(*mcp.TextContent).Text)
+	assert.NotEqual(t, "latest-stable", result.Meta["api_version"])
+	assert.Contains(t, result.Content[1].(*mcp.TextContent).Text, "Resolved api-version: Microsoft.Compute/virtualMachines@")
+}
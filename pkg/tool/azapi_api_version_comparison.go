@@ -0,0 +1,51 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzAPIVersionComparisonParam struct {
+	ResourceType   string `json:"resource_type" jsonschema:"Azure resource type, for example: Microsoft.Web/sites"`
+	FromApiVersion string `json:"from_api_version,omitempty" jsonschema:"Api-version to compare from, for example: 2024-04-01. Defaults to the stable api-version before to_api_version"`
+	ToApiVersion   string `json:"to_api_version,omitempty" jsonschema:"Api-version to compare to, for example: 2024-11-01, or 'latest' / 'latest-stable'. Defaults to the latest stable api-version"`
+}
+
+func CompareAzAPIVersions(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIVersionComparisonParam]) (*mcp.CallToolResultFor[any], error) {
+	resourceType := params.Arguments.ResourceType
+	if resourceType == "" {
+		return nil, errors.New("`resource_type` is a required parameter")
+	}
+	from, to := params.Arguments.FromApiVersion, params.Arguments.ToApiVersion
+	var err error
+	if from != "" {
+		if from, err = resolveApiVersion(resourceType, from); err != nil {
+			return nil, err
+		}
+	}
+	if to != "" {
+		if to, err = resolveApiVersion(resourceType, to); err != nil {
+			return nil, err
+		}
+	}
+	comparison, err := azapi.CompareApiVersions(resourceType, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare api-versions of %s: %w", resourceType, err)
+	}
+	content, err := json.Marshal(comparison)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal api-version comparison of %s: %w", resourceType, err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,43 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type ARMTemplateConversionParam struct {
+	Template string `json:"template" jsonschema:"ARM template to convert, as a JSON string: a whole template, an array of resources or a single resource"`
+}
+
+func ConvertARMTemplateToAzAPI(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[ARMTemplateConversionParam]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Template == "" {
+		return nil, errors.New("`template` is a required parameter")
+	}
+	conversion, err := azapi.ConvertARMTemplate(params.Arguments.Template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert ARM template: %w", err)
+	}
+	result := &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: conversion.HCL,
+			},
+		},
+	}
+	if len(conversion.Warnings) > 0 {
+		result.Content = append(result.Content, &mcp.TextContent{
+			Text: "Review before use:\n- " + strings.Join(conversion.Warnings, "\n- "),
+			Annotations: &mcp.Annotations{
+				Audience: []mcp.Role{
+					"assistant",
+				},
+			},
+		})
+	}
+	return result, nil
+}
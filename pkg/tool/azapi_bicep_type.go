@@ -0,0 +1,38 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type BicepTypeComparisonParam struct {
+	ResourceType string `json:"resource_type" jsonschema:"Azure resource type of the Bicep resource, for example: Microsoft.KeyVault/vaults"`
+	ApiVersion   string `json:"api_version,omitempty" jsonschema:"Api-version of the Bicep resource, for example: 2023-07-01, or 'latest' / 'latest-stable' for the newest one in the Bicep types. Defaults to the newest stable api-version"`
+}
+
+func CompareBicepAzAPIType(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[BicepTypeComparisonParam]) (*mcp.CallToolResultFor[any], error) {
+	resourceType := params.Arguments.ResourceType
+	if resourceType == "" {
+		return nil, errors.New("`resource_type` is a required parameter")
+	}
+	comparison, err := azapi.CompareBicepType(ctx, resourceType, params.Arguments.ApiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare the Bicep type of %s: %w", resourceType, err)
+	}
+	content, err := json.Marshal(comparison)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Bicep type comparison of %s: %w", resourceType, err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,40 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzAPIBodySkeletonParam struct {
+	ResourceType string `json:"resource_type" jsonschema:"Azure resource type, for example: Microsoft.KeyVault/vaults"`
+	ApiVersion   string `json:"api_version" jsonschema:"Azure resource api-version, for example: 2023-07-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version"`
+	Format       string `json:"format,omitempty" jsonschema:"Output format: 'hcl' (default) renders a body attribute with possible values as comments, 'json' renders the body as JSON."`
+}
+
+func GenerateAzAPIBodySkeleton(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIBodySkeletonParam]) (*mcp.CallToolResultFor[any], error) {
+	resourceType := params.Arguments.ResourceType
+	apiVersion := params.Arguments.ApiVersion
+	if resourceType == "" || apiVersion == "" {
+		return nil, errors.New("`resource_type` and `api_version` are required parameters")
+	}
+	requested := apiVersion
+	apiVersion, err := resolveApiVersion(resourceType, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	skeleton, err := azapi.GenerateBodySkeleton(resourceType, apiVersion, params.Arguments.Format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate body for %s@%s: %w", resourceType, apiVersion, err)
+	}
+	return withApiVersion(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: skeleton,
+			},
+		},
+	}, resourceType, requested, apiVersion), nil
+}
@@ -0,0 +1,45 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzAPIBodyValidationParam struct {
+	ResourceType string `json:"resource_type" jsonschema:"Azure resource type, for example: Microsoft.KeyVault/vaults"`
+	ApiVersion   string `json:"api_version" jsonschema:"Azure resource api-version, for example: 2023-07-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version"`
+	Body         string `json:"body" jsonschema:"The azapi_resource body to validate, as a JSON object string, for example: {\"properties\":{\"tenantId\":\"...\"}}"`
+}
+
+func ValidateAzAPIBody(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIBodyValidationParam]) (*mcp.CallToolResultFor[any], error) {
+	resourceType := params.Arguments.ResourceType
+	apiVersion := params.Arguments.ApiVersion
+	if resourceType == "" || apiVersion == "" || params.Arguments.Body == "" {
+		return nil, errors.New("`resource_type`, `api_version` and `body` are required parameters")
+	}
+	requested := apiVersion
+	apiVersion, err := resolveApiVersion(resourceType, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	validation, err := azapi.ValidateBody(resourceType, apiVersion, params.Arguments.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate body for %s@%s: %w", resourceType, apiVersion, err)
+	}
+	content, err := json.Marshal(validation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal body validation for %s@%s: %w", resourceType, apiVersion, err)
+	}
+	return withApiVersion(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, resourceType, requested, apiVersion), nil
+}
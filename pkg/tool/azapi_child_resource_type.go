@@ -0,0 +1,49 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzAPIChildResourceTypeParam struct {
+	ResourceType string `json:"resource_type" jsonschema:"Parent Azure resource type, for example: Microsoft.Network/virtualNetworks"`
+	Recursive    bool   `json:"recursive,omitempty" jsonschema:"List nested child resource types at any depth, like Microsoft.Storage/storageAccounts/blobServices/containers for Microsoft.Storage/storageAccounts. Defaults to direct children only."`
+	Cursor       string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit        int    `json:"limit,omitempty" jsonschema:"Maximum number of child resource types to return. If not set, all of them are returned."`
+}
+
+// childResourceTypePage is a page of the child resource types of a resource type
+type childResourceTypePage struct {
+	ResourceType string `json:"resource_type"`
+	Page[azapi.ChildResourceType]
+}
+
+func ListAzAPIChildResourceTypes(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIChildResourceTypeParam]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.ResourceType == "" {
+		return nil, errors.New("`resource_type` is required")
+	}
+	children, err := azapi.ListChildResourceTypes(params.Arguments.ResourceType, params.Arguments.Recursive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child resource types of %s: %w", params.Arguments.ResourceType, err)
+	}
+	page, err := paginate(children.ChildResourceTypes, params.Arguments.Cursor, params.Arguments.Limit)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(childResourceTypePage{ResourceType: children.ResourceType, Page: page})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal child resource types: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, nil
+}
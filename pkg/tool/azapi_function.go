@@ -0,0 +1,36 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzAPIFunctionQueryParam struct {
+	Name string `json:"name,omitempty" jsonschema:"Name of the azapi provider function, like build_resource_id or provider::azapi::build_resource_id. When not set, all functions are listed with their summaries"`
+}
+
+func QueryAzAPIFunctions(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIFunctionQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	var result any = azapi.ListFunctions()
+	if params.Arguments.Name != "" {
+		function, err := azapi.GetFunction(params.Arguments.Name)
+		if err != nil {
+			return nil, err
+		}
+		result = function
+	}
+	content, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal azapi functions: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,45 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzAPIPossibleValuesParam struct {
+	ResourceType string `json:"resource_type" jsonschema:"Azure resource type, for example: Microsoft.CognitiveServices/accounts"`
+	ApiVersion   string `json:"api_version" jsonschema:"Azure resource api-version, for example: 2025-06-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version"`
+	Path         string `json:"path" jsonschema:"JSON path of the body property, for example: body.properties.publicNetworkAccess"`
+}
+
+func QueryAzAPIPossibleValues(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIPossibleValuesParam]) (*mcp.CallToolResultFor[any], error) {
+	resourceType := params.Arguments.ResourceType
+	apiVersion := params.Arguments.ApiVersion
+	if resourceType == "" || apiVersion == "" || params.Arguments.Path == "" {
+		return nil, errors.New("`resource_type`, `api_version` and `path` are required parameters")
+	}
+	requested := apiVersion
+	apiVersion, err := resolveApiVersion(resourceType, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	values, err := azapi.GetPossibleValues(resourceType, apiVersion, params.Arguments.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get possible values for %s@%s: %w", resourceType, apiVersion, err)
+	}
+	content, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal possible values for %s@%s: %w", resourceType, apiVersion, err)
+	}
+	return withApiVersion(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, resourceType, requested, apiVersion), nil
+}
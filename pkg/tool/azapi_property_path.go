@@ -0,0 +1,45 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzAPIPropertyPathParam struct {
+	ResourceType string `json:"resource_type" jsonschema:"Azure resource type, for example: Microsoft.KeyVault/vaults"`
+	ApiVersion   string `json:"api_version" jsonschema:"Azure resource api-version, for example: 2023-07-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version"`
+	PropertyName string `json:"property_name" jsonschema:"Name of the property to find, case-insensitive, for example: publicNetworkAccess"`
+}
+
+func FindAzAPIPropertyPaths(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIPropertyPathParam]) (*mcp.CallToolResultFor[any], error) {
+	resourceType := params.Arguments.ResourceType
+	apiVersion := params.Arguments.ApiVersion
+	if resourceType == "" || apiVersion == "" || params.Arguments.PropertyName == "" {
+		return nil, errors.New("`resource_type`, `api_version` and `property_name` are required parameters")
+	}
+	requested := apiVersion
+	apiVersion, err := resolveApiVersion(resourceType, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := azapi.FindPropertyPaths(resourceType, apiVersion, params.Arguments.PropertyName)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal property paths for %s@%s: %w", resourceType, apiVersion, err)
+	}
+	return withApiVersion(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, resourceType, requested, apiVersion), nil
+}
@@ -13,21 +13,54 @@ import (
 
 type AzAPIResourceDescriptionQueryParam struct {
 	ResourceType string `json:"resource_type" jsonschema:"Azure resource type, for example: Microsoft.Compute/virtualMachines, combined with api_version to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01"`
-	ApiVersion   string `json:"api_version" jsonschema:"Azure resource api-version, for example: 2024-11-01, combined with resource_type to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01"`
-	Path         string `json:"path,omitempty" jsonschema:"JSON path to query the resource schema, for example: body.properties.osProfile.secrets.sourceVault.id, if not specified, the whole resource schema will be returned"`
+	ApiVersion   string `json:"api_version" jsonschema:"Azure resource api-version, for example: 2024-11-01, combined with resource_type to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version"`
+	Path         string `json:"path,omitempty" jsonschema:"JSON path to query the resource schema, for example: body.properties.osProfile.secrets.sourceVault.id, if not specified, the whole resource schema will be returned. '*' matches any single property and '**' any depth of nested properties, e.g. body.properties.**.subnetId, wildcard queries return every match keyed by its full path"`
+	BlockType    string `json:"block_type,omitempty" jsonschema:"AzAPI block type to query, defaults to 'azapi_resource'. Data sources and ephemeral resources are prefixed with 'data.' and 'ephemeral.', like 'data.azapi_resource_list'. Only 'azapi_resource', 'azapi_update_resource' and 'azapi_data_plane_resource' require resource_type and api_version."`
+	RequiredOnly bool   `json:"required_only,omitempty" jsonschema:"Only return the properties that must be set (Required or DeployTimeConstant, and not ReadOnly), to get the minimal body of a resource"`
+	Format       string `json:"format,omitempty" jsonschema:"Output format: 'json' (default) returns the descriptions as JSON, 'markdown' renders them as a nested Markdown document with a property table per object, suitable for module docs"`
+	Flag         string `json:"flag,omitempty" jsonschema:"Only return the body properties carrying this flag, as a list of their paths, descriptions and flags: 'ReadOnly', 'WriteOnly', 'Identifier', 'DeployTimeConstant' or 'Required'. For example, 'ReadOnly' lists what may go in response_export_values and must be left out of body"`
 }
 
-func QueryAzAPIDescriptionSchema(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIResourceSchemaQueryParam]) (*mcp.CallToolResultFor[any], error) {
+func QueryAzAPIDescriptionSchema(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIResourceDescriptionQueryParam]) (*mcp.CallToolResultFor[any], error) {
 	resourceType := params.Arguments.ResourceType
 	apiVersion := params.Arguments.ApiVersion
-	if resourceType == "" || apiVersion == "" {
+	blockType := params.Arguments.BlockType
+	if azapi.BlockMergesBody(blockType) && (resourceType == "" || apiVersion == "") {
 		return nil, errors.New("`resource_type` and `api_version` are required parameters")
 	}
+	requested := apiVersion
+	if azapi.BlockMergesBody(blockType) {
+		resolved, err := resolveApiVersion(resourceType, apiVersion)
+		if err != nil {
+			return nil, err
+		}
+		apiVersion = resolved
+	}
 	path := params.Arguments.Path
-	schema, err := azapi.GetResourceSchemaDescription(resourceType, apiVersion, path)
+	if params.Arguments.Flag != "" {
+		return queryAzAPIPropertiesWithFlag(params.Arguments, resourceType, requested, apiVersion)
+	}
+	schema, err := azapi.GetBlockSchemaDescription(blockType, resourceType, apiVersion, path, params.Arguments.RequiredOnly)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resource schema for %s@%s: %w", resourceType, apiVersion, err)
 	}
+	switch params.Arguments.Format {
+	case "", azapi.DescriptionFormatJSON:
+	case azapi.DescriptionFormatMarkdown:
+		title := blockType
+		if azapi.BlockMergesBody(blockType) {
+			title = fmt.Sprintf("%s@%s", resourceType, apiVersion)
+		}
+		return withApiVersion(&mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: azapi.DescriptionMarkdown(title, path, schema),
+				},
+			},
+		}, resourceType, requested, apiVersion), nil
+	default:
+		return nil, fmt.Errorf("invalid format %q, must be %q or %q", params.Arguments.Format, azapi.DescriptionFormatJSON, azapi.DescriptionFormatMarkdown)
+	}
 	payload, err := json.Marshal(schema)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal resource schema for %s@%s: %w", resourceType, apiVersion, err)
@@ -36,11 +69,41 @@ func QueryAzAPIDescriptionSchema(ctx context.Context, cc *mcp.ServerSession, par
 	if err = json.Compact(compressed, payload); err != nil {
 		return nil, fmt.Errorf("failed to compact resource schema for %s@%s: %w", resourceType, apiVersion, err)
 	}
-	return &mcp.CallToolResultFor[any]{
+	return withApiVersion(&mcp.CallToolResultFor[any]{
 		Content: []mcp.Content{
 			&mcp.TextContent{
 				Text: compressed.String(),
 			},
 		},
-	}, nil
+	}, resourceType, requested, apiVersion), nil
+}
+
+func queryAzAPIPropertiesWithFlag(args AzAPIResourceDescriptionQueryParam, resourceType, requested, apiVersion string) (*mcp.CallToolResultFor[any], error) {
+	if !azapi.BlockMergesBody(args.BlockType) {
+		return nil, fmt.Errorf("`flag` only applies to block types with a resource body, got %s", args.BlockType)
+	}
+	properties, err := azapi.GetPropertiesWithFlag(resourceType, apiVersion, args.Flag, args.Path)
+	if err != nil {
+		return nil, err
+	}
+	var text string
+	switch args.Format {
+	case "", azapi.DescriptionFormatJSON:
+		content, err := json.Marshal(properties)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal properties for %s@%s: %w", resourceType, apiVersion, err)
+		}
+		text = string(content)
+	case azapi.DescriptionFormatMarkdown:
+		text = azapi.FlaggedPropertiesMarkdown(fmt.Sprintf("%s@%s %s properties", resourceType, apiVersion, args.Flag), properties)
+	default:
+		return nil, fmt.Errorf("invalid format %q, must be %q or %q", args.Format, azapi.DescriptionFormatJSON, azapi.DescriptionFormatMarkdown)
+	}
+	return withApiVersion(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+	}, resourceType, requested, apiVersion), nil
 }
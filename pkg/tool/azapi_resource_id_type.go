@@ -0,0 +1,36 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzAPIResourceIDTypeParam struct {
+	ID string `json:"id" jsonschema:"Azure resource ID of an existing resource, for example: /subscriptions/{id}/resourceGroups/{rg}/providers/Microsoft.Network/virtualNetworks/{vnet}/subnets/{subnet}"`
+}
+
+func InferAzAPIResourceType(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIResourceIDTypeParam]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.ID == "" {
+		return nil, errors.New("`id` is a required parameter")
+	}
+	inferred, err := azapi.InferResourceType(params.Arguments.ID)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(inferred)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource type of %s: %w", params.Arguments.ID, err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, nil
+}
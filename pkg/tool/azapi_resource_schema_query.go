@@ -11,26 +11,38 @@ import (
 
 type AzAPIResourceSchemaQueryParam struct {
 	ResourceType string `json:"resource_type" jsonschema:"Azure resource type, for example: Microsoft.Compute/virtualMachines, combined with api_version to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01"`
-	ApiVersion   string `json:"api_version" jsonschema:"Azure resource api-version, for example: 2024-11-01, combined with resource_type to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01"`
-	Path         string `json:"path,omitempty" jsonschema:"JSON path to query the resource schema, for example: body.properties.osProfile.secrets.sourceVault.id, if not specified, the whole resource schema will be returned"`
+	ApiVersion   string `json:"api_version" jsonschema:"Azure resource api-version, for example: 2024-11-01, combined with resource_type to identify the resource schema, like: Microsoft.Compute/virtualMachines@2024-11-01, or 'latest' for the newest stable version (the newest preview version when there is no stable one) or 'latest-stable' for the newest stable version"`
+	Path         string `json:"path,omitempty" jsonschema:"JSON path to query the resource schema, for example: body.properties.osProfile.secrets.sourceVault.id, if not specified, the whole resource schema will be returned. '*' matches any single property and '**' any depth of nested properties, e.g. body.properties.**.subnetId, wildcard queries return every match keyed by its full path"`
+	BlockType    string `json:"block_type,omitempty" jsonschema:"AzAPI block type to query, defaults to 'azapi_resource'. Data sources and ephemeral resources are prefixed with 'data.' and 'ephemeral.', like 'data.azapi_resource_list'. Only 'azapi_resource', 'azapi_update_resource' and 'azapi_data_plane_resource' require resource_type and api_version."`
+	RequiredOnly bool   `json:"required_only,omitempty" jsonschema:"Only return the properties that must be set (Required or DeployTimeConstant, and not ReadOnly), to get the minimal body of a resource"`
+	Format       string `json:"format,omitempty" jsonschema:"Type language of the result: 'go' (default), 'hcl', 'typescript' or 'json_schema'"`
 }
 
 func QueryAzAPIResourceSchema(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIResourceSchemaQueryParam]) (*mcp.CallToolResultFor[any], error) {
 	resourceType := params.Arguments.ResourceType
 	apiVersion := params.Arguments.ApiVersion
-	if resourceType == "" || apiVersion == "" {
+	blockType := params.Arguments.BlockType
+	if azapi.BlockMergesBody(blockType) && (resourceType == "" || apiVersion == "") {
 		return nil, errors.New("`resource_type` and `api_version` are required parameters")
 	}
+	requested := apiVersion
+	if azapi.BlockMergesBody(blockType) {
+		resolved, err := resolveApiVersion(resourceType, apiVersion)
+		if err != nil {
+			return nil, err
+		}
+		apiVersion = resolved
+	}
 	path := params.Arguments.Path
-	schema, err := azapi.GetResourceSchema(resourceType, apiVersion, path)
+	schema, err := azapi.GetBlockSchemaInFormat(blockType, resourceType, apiVersion, path, params.Arguments.Format, params.Arguments.RequiredOnly)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resource schema for %s@%s: %w", resourceType, apiVersion, err)
 	}
-	return &mcp.CallToolResultFor[any]{
+	return withApiVersion(&mcp.CallToolResultFor[any]{
 		Content: []mcp.Content{
 			&mcp.TextContent{
 				Text: schema,
 			},
 		},
-	}, nil
+	}, resourceType, requested, apiVersion), nil
 }
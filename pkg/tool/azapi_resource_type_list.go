@@ -0,0 +1,38 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzAPIResourceTypeListParam struct {
+	Filter string `json:"filter,omitempty" jsonschema:"Case-insensitive substring of the resource type (e.g., 'virtualMachines'), or a glob where '*' matches any characters including '/' (e.g., 'Microsoft.Storage/*'). If not set, all resource types are listed."`
+	Cursor string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit  int    `json:"limit,omitempty" jsonschema:"Maximum number of resource types to return. If not set, all matches are returned."`
+}
+
+func ListAzAPIResourceTypes(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzAPIResourceTypeListParam]) (*mcp.CallToolResultFor[any], error) {
+	resourceTypes, err := azapi.ListResourceTypes(params.Arguments.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource types: %w", err)
+	}
+	page, err := paginate(resourceTypes, params.Arguments.Cursor, params.Arguments.Limit)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource types: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, nil
+}
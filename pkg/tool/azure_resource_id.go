@@ -0,0 +1,54 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzureResourceIDParam struct {
+	ID             string `json:"id,omitempty" jsonschema:"Azure resource ID to parse, like /subscriptions/{id}/resourceGroups/{rg}/providers/Microsoft.Network/virtualNetworks/{vnet}. When set, the other parameters are ignored."`
+	SubscriptionID string `json:"subscription_id,omitempty" jsonschema:"Subscription ID of the resource to build the ID of, ignored when parent_id is set"`
+	ResourceGroup  string `json:"resource_group,omitempty" jsonschema:"Resource group name of the resource to build the ID of, ignored when parent_id is set"`
+	ParentID       string `json:"parent_id,omitempty" jsonschema:"ID of the parent resource or scope of the resource to build the ID of"`
+	ResourceType   string `json:"resource_type,omitempty" jsonschema:"Resource type of the resource to build the ID of, like Microsoft.Network/virtualNetworks/subnets"`
+	Name           string `json:"name,omitempty" jsonschema:"Name of the resource to build the ID of, or the names of the resource and its parents separated by '/' like ARM templates use, e.g. vnet1/subnet1"`
+}
+
+func ParseOrBuildAzureResourceID(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[AzureResourceIDParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	var id *azapi.ResourceID
+	var err error
+	switch {
+	case args.ID != "":
+		id, err = azapi.ParseResourceID(args.ID)
+	case args.ResourceType != "" && args.Name != "":
+		id, err = azapi.BuildResourceID(azapi.ResourceIDComponents{
+			SubscriptionID: args.SubscriptionID,
+			ResourceGroup:  args.ResourceGroup,
+			ParentID:       args.ParentID,
+			ResourceType:   args.ResourceType,
+			Name:           args.Name,
+		})
+	default:
+		return nil, errors.New("either `id`, or `resource_type` and `name` are required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource ID: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,72 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type AzureSDKQueryParam struct {
+	Service    string `json:"service,omitempty" jsonschema:"Optional go-azure-sdk resource manager service (e.g. 'storage'), lists the services when not set"`
+	APIVersion string `json:"api_version,omitempty" jsonschema:"Optional API version of the service to list the packages of (e.g. '2023-05-01'), defaults to the latest stable API version"`
+	Filter     string `json:"filter,omitempty" jsonschema:"Optional case-insensitive substring the listed services, or packages when 'service' is set, must contain (e.g. 'storageaccounts')"`
+	Tag        string `json:"tag,omitempty" jsonschema:"Optional go-azure-sdk index tag version (defaults to latest version if not specified)"`
+	Cursor     string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit      int    `json:"limit,omitempty" jsonschema:"Maximum number of services, or packages when 'service' is set, to return. If not set, all of them are returned."`
+}
+
+// azureSDKServicePage is a page of the package namespaces of an API version of a go-azure-sdk service
+type azureSDKServicePage struct {
+	Service     string   `json:"service"`
+	APIVersions []string `json:"api_versions"`
+	APIVersion  string   `json:"api_version"`
+	Namespace   string   `json:"namespace"`
+	Page[string]
+}
+
+// QueryAzureSDK is an MCP tool that lists the services of go-azure-sdk, or the API versions and package namespaces of a service
+func QueryAzureSDK(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[AzureSDKQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	var result any
+	if args.Service == "" {
+		services, err := gophon.ListAzureSDKServices(ctx, args.Filter, args.Tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query go-azure-sdk: %w", err)
+		}
+		page, err := paginate(services, args.Cursor, args.Limit)
+		if err != nil {
+			return nil, err
+		}
+		result = page
+	} else {
+		service, err := gophon.GetAzureSDKService(ctx, args.Service, args.APIVersion, args.Filter, args.Tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query go-azure-sdk: %w", err)
+		}
+		page, err := paginate(service.Packages, args.Cursor, args.Limit)
+		if err != nil {
+			return nil, err
+		}
+		result = azureSDKServicePage{
+			Service:     service.Service,
+			APIVersions: service.APIVersions,
+			APIVersion:  service.APIVersion,
+			Namespace:   service.Namespace,
+			Page:        page,
+		}
+	}
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal go-azure-sdk listing to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
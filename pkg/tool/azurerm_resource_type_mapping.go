@@ -0,0 +1,35 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/azapi"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type ResourceTypeMappingParam struct {
+	ResourceType string `json:"resource_type" jsonschema:"azurerm resource type (e.g., 'azurerm_storage_account') to map to ARM resource types, or ARM resource type (e.g., 'Microsoft.Storage/storageAccounts') to map back to azurerm resource types"`
+}
+
+func MapAzureResourceType(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[ResourceTypeMappingParam]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.ResourceType == "" {
+		return nil, fmt.Errorf("`resource_type` is required")
+	}
+	mappings, err := azapi.MapResourceType(ctx, params.Arguments.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(mappings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource type mappings: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,42 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/cache"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type CacheClearParam struct {
+	Name string `json:"name,omitempty" jsonschema:"The name of the cache to clear (e.g., 'tfschema'). If not set, all caches are cleared."`
+}
+
+type cacheClearResult struct {
+	Cleared []cache.ClearResult `json:"cleared"`
+	Caches  []cache.Info        `json:"caches"`
+}
+
+// ClearCache is an MCP tool that drops the entries of one or all of the caches, from memory and from disk
+func ClearCache(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[CacheClearParam]) (*mcp.CallToolResultFor[any], error) {
+	var names []string
+	if params.Arguments.Name != "" {
+		names = append(names, params.Arguments.Name)
+	}
+	cleared, err := cache.Clear(names...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear cache: %w", err)
+	}
+	content, err := json.Marshal(cacheClearResult{Cleared: cleared, Caches: cache.List()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cache clear result: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,52 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearCache(t *testing.T) {
+	tests := []struct {
+		name        string
+		cache       string
+		expectError string
+	}{
+		{
+			name:  "should clear a memory only cache",
+			cache: "azapi_lookup",
+		},
+		{
+			name:        "should error on unknown cache",
+			cache:       "unknown",
+			expectError: `unknown cache "unknown"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &mcp.CallToolParamsFor[CacheClearParam]{Arguments: CacheClearParam{Name: tt.cache}}
+			result, err := ClearCache(context.Background(), &mcp.ServerSession{}, params)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			var cleared cacheClearResult
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &cleared))
+			require.Len(t, cleared.Cleared, 1)
+			assert.Equal(t, tt.cache, cleared.Cleared[0].Name)
+			var names []string
+			for _, info := range cleared.Caches {
+				names = append(names, info.Name)
+			}
+			assert.Subset(t, names, []string{"azapi_lookup", "conftest_policy", "gophon_block_index", "gophon_github", "tflint_config", "tfschema"})
+		})
+	}
+}
@@ -23,7 +23,7 @@ type ConftestIgnoredPolicy struct {
 	Name      string `json:"name" jsonschema:"Required policy rule name (e.g., 'storage_account_https_only', 'vm_backup_enabled'). Used together with 'namespace' to uniquely identify the policy to ignore."`
 }
 
-func ConftestScan(_ context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[ConftestScanParam]) (*mcp.CallToolResultFor[any], error) {
+func ConftestScan(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[ConftestScanParam]) (*mcp.CallToolResultFor[any], error) {
 	// Convert MCP parameters to conftest scan parameters
 	var ignoredPolicies []conftest.IgnoredPolicy
 	for _, policy := range params.Arguments.IgnoredPolicies {
@@ -49,7 +49,7 @@ func ConftestScan(_ context.Context, _ *mcp.ServerSession, params *mcp.CallToolP
 	}
 
 	// Execute the conftest scan
-	result, err := conftest.Scan(scanParams)
+	result, err := conftest.Scan(ctx, scanParams)
 	if err != nil {
 		return nil, fmt.Errorf("conftest scan failed: %w", err)
 	}
@@ -0,0 +1,72 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type DeprecatedReportParam struct {
+	Category          string `json:"category,omitempty" jsonschema:"Terraform block type to walk, possible values: resource, data, ephemeral, provider. If not set, the entire provider is walked."`
+	Type              string `json:"type,omitempty" jsonschema:"Terraform block type like: azurerm_kubernetes_cluster. If not set, every schema of the category is walked."`
+	ProviderNamespace string `json:"namespace" jsonschema:"Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'."`
+	ProviderName      string `json:"name" jsonschema:"Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). If not provided, will be inferred from the type parameter."`
+	ProviderVersion   string `json:"version,omitempty" jsonschema:"Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used."`
+}
+
+func QueryDeprecatedReport(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[DeprecatedReportParam]) (*mcp.CallToolResultFor[any], error) {
+	if err := params.Arguments.splitProviderSource(); err != nil {
+		return nil, err
+	}
+	category := params.Arguments.Category
+	t := params.Arguments.Type
+	name := params.Arguments.ProviderName
+
+	if category != "" {
+		if _, ok := validCategories[category]; !ok || category == "function" {
+			return nil, fmt.Errorf("invalid category: %s", category)
+		}
+	}
+	if t != "" && category == "" {
+		return nil, fmt.Errorf("category is required when type is set")
+	}
+	if name == "" {
+		name = inferProviderNameFromType(t)
+		if name == "" {
+			return nil, fmt.Errorf("provider name is required when it cannot be inferred from the type parameter")
+		}
+	}
+
+	providerReq := tfschema.ProviderRequest{
+		ProviderNamespace: NewSchemaQueryValidator().NormalizeNamespace(params.Arguments.ProviderNamespace),
+		ProviderName:      name,
+		ProviderVersion:   params.Arguments.ProviderVersion,
+	}
+	providerReq, err := resolveProvider(providerReq)
+	if err != nil {
+		return nil, err
+	}
+	report, err := tfschema.ReportDeprecated(category, t, providerReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to report deprecated schema elements: %w", err)
+	}
+	content, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal deprecation report: %w", err)
+	}
+	return withProviderVersion(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+				Annotations: &mcp.Annotations{
+					Audience: []mcp.Role{
+						"assistant",
+					},
+				},
+			},
+		},
+	}, providerReq), nil
+}
@@ -0,0 +1,60 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type EphemeralReportParam struct {
+	ProviderNamespace string `json:"namespace" jsonschema:"Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'."`
+	ProviderName      string `json:"name" jsonschema:"Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). Required parameter."`
+	ProviderVersion   string `json:"version,omitempty" jsonschema:"Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used."`
+}
+
+func (p *EphemeralReportParam) splitProviderSource() (err error) {
+	p.ProviderNamespace, p.ProviderName, err = splitProviderSource(p.ProviderNamespace, p.ProviderName)
+	return err
+}
+
+func QueryEphemeralReport(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[EphemeralReportParam]) (*mcp.CallToolResultFor[any], error) {
+	if err := params.Arguments.splitProviderSource(); err != nil {
+		return nil, err
+	}
+	if params.Arguments.ProviderName == "" {
+		return nil, fmt.Errorf("provider name is required")
+	}
+
+	providerReq := tfschema.ProviderRequest{
+		ProviderNamespace: NewSchemaQueryValidator().NormalizeNamespace(params.Arguments.ProviderNamespace),
+		ProviderName:      params.Arguments.ProviderName,
+		ProviderVersion:   params.Arguments.ProviderVersion,
+	}
+	providerReq, err := resolveProvider(providerReq)
+	if err != nil {
+		return nil, err
+	}
+	report, err := tfschema.ReportEphemeralCompatibility(providerReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to report ephemeral compatibility: %w", err)
+	}
+	content, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ephemeral compatibility report: %w", err)
+	}
+	return withProviderVersion(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+				Annotations: &mcp.Annotations{
+					Audience: []mcp.Role{
+						"assistant",
+					},
+				},
+			},
+		},
+	}, providerReq), nil
+}
@@ -0,0 +1,51 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type GolangPackageListParam struct {
+	Namespace    string `json:"namespace" jsonschema:"[Required] The golang namespace to list packages of (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal/services')"`
+	IncludeFiles bool   `json:"include_files,omitempty" jsonschema:"Also list the index files of the namespace after its sub-packages, one file per func, method, type or var"`
+	Tag          string `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Cursor       string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit        int    `json:"limit,omitempty" jsonschema:"Maximum number of packages and files to return. If not set, all of them are returned."`
+}
+
+// packagePage is a page of the sub-package namespaces of a namespace, followed by its index files when requested
+type packagePage struct {
+	Namespace string `json:"namespace"`
+	Page[string]
+}
+
+// ListGolangPackages is an MCP tool that lists the sub-packages of an indexed golang namespace
+func ListGolangPackages(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[GolangPackageListParam]) (*mcp.CallToolResultFor[any], error) {
+	namespace := params.Arguments.Namespace
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace parameter is required")
+	}
+	listing, err := gophon.ListPackages(ctx, namespace, params.Arguments.Tag, params.Arguments.IncludeFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages of namespace %q: %w", namespace, err)
+	}
+	page, err := paginate(append(listing.Packages, listing.Files...), params.Arguments.Cursor, params.Arguments.Limit)
+	if err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(packagePage{Namespace: listing.Namespace, Page: page})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal packages to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,45 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type GolangReferenceQueryParam struct {
+	Namespace        string   `json:"namespace" jsonschema:"[Required] The golang namespace the symbol is declared in (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal/services/network')"`
+	Symbol           string   `json:"symbol" jsonschema:"[Required] The symbol to find references to, possible values: 'func', 'method', 'type', 'var'"`
+	Receiver         string   `json:"receiver,omitempty" jsonschema:"The type of method receiver, e.g.: 'ContainerAppResource'. Can only be set when symbol is 'method'."`
+	Name             string   `json:"name" jsonschema:"[Required] The name of the function, method, type or variable to find references to"`
+	SearchNamespaces []string `json:"search_namespaces,omitempty" jsonschema:"Other namespaces to search for references in, the symbol's own namespace is always searched"`
+	Tag              string   `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Cursor           string   `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit            int      `json:"limit,omitempty" jsonschema:"Maximum number of references to return. If not set, all references are returned."`
+}
+
+// QueryGolangReferences is an MCP tool that finds the indexed symbols referencing a golang symbol
+func QueryGolangReferences(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[GolangReferenceQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	result, err := gophon.FindReferences(ctx, args.Namespace, args.Symbol, args.Receiver, args.Name, args.Tag, args.SearchNamespaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find references to %s %s: %w", args.Symbol, args.Name, err)
+	}
+	page, err := paginate(result.References, args.Cursor, args.Limit)
+	if err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(scannedPage[gophon.Reference]{Page: page, Scanned: result.Scanned, Truncated: result.Truncated})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal references to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,39 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type GolangSourceCodeDiffParam struct {
+	Namespace string `json:"namespace" jsonschema:"[Required] The golang namespace to query (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps')"`
+	Symbol    string `json:"symbol" jsonschema:"[Required] The symbol you want to compare, possible values: 'func', 'method', 'type', 'var'"`
+	Receiver  string `json:"receiver,omitempty" jsonschema:"The type of method receiver, e.g.: 'ContainerAppResource'. Can only be set when symbol is 'method'."`
+	Name      string `json:"name" jsonschema:"[Required] The name of the function, method, type or variable you want to compare"`
+	FromTag   string `json:"from_tag" jsonschema:"[Required] The tag to compare from, e.g.: v4.20.0"`
+	ToTag     string `json:"to_tag,omitempty" jsonschema:"The tag to compare to, e.g.: v4.30.0 (defaults to latest version if not specified)"`
+}
+
+// DiffGolangSourceCode is an MCP tool that returns the unified diff of a golang symbol between two tags
+func DiffGolangSourceCode(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[GolangSourceCodeDiffParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	diff, err := gophon.DiffGolangSourceCode(ctx, args.Namespace, args.Symbol, args.Receiver, args.Name, args.FromTag, args.ToTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff golang source code for %s %s: %w", args.Symbol, args.Name, err)
+	}
+	jsonBytes, err := json.Marshal(diff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal diff to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
@@ -2,6 +2,7 @@ package tool
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -9,16 +10,40 @@ import (
 )
 
 type GolangSourceCodeQueryParam struct {
-	Namespace string `json:"namespace" jsonschema:"[Required] The golang namespace to query (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal'). When you are reading golang source code and want to read a specific function, method, type or variable, you need to infer the correct namespace first. To infer the namespace of a given symbol, you must read 'package' declaration in the current golang code, along with all imports, then guess the symbol you'd like to read is in which namespace. The symbol could be placed in a different namespace, it's quite common."`
+	Namespace       string                  `json:"namespace,omitempty" jsonschema:"[Required] The golang namespace to query (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal'). When you are reading golang source code and want to read a specific function, method, type or variable, you need to infer the correct namespace first. To infer the namespace of a given symbol, you must read 'package' declaration in the current golang code, along with all imports, then guess the symbol you'd like to read is in which namespace. The symbol could be placed in a different namespace, it's quite common. Optional when every item of 'symbols' has its own namespace."`
+	Symbol          string                  `json:"symbol,omitempty" jsonschema:"[Required] The symbol you want to read, possible values: 'func', 'method', 'type', 'var'. Not used when 'symbols' is set."`
+	Receiver        string                  `json:"receiver,omitempty" jsonschema:"The type of method receiver, e.g.: 'ContainerAppResource'. Can only be set when symbol is 'method'."`
+	Name            string                  `json:"name,omitempty" jsonschema:"[Required] The name of the function, method, type or variable you want to read. For example: 'NewContainerAppResource', 'ContainerAppResource'. Not used when 'symbols' is set."`
+	Tag             string                  `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Symbols         []GolangSymbolSpecParam `json:"symbols,omitempty" jsonschema:"Optional list of symbols to read in one call, at most 50, instead of 'symbol', 'receiver' and 'name'. Returns a JSON object of the source code keyed by '<namespace>/<symbol>.[<receiver>.]<name>[@<tag>]'."`
+	IncludeLocation bool                    `json:"include_location,omitempty" jsonschema:"Optional, returns a JSON object with the source code and its 'location': the file 'path' in the upstream repository, the 'start_line', 'end_line' and a GitHub 'permalink' pinned to the tag"`
+	Mode            string                  `json:"mode,omitempty" jsonschema:"Optional, 'full' (default) returns the whole source code, 'signature' returns the declaration only: the doc comment and signature of functions and methods, the fields of types, and variables with function bodies and literal elements elided as '{ ... }'"`
+	IncludeMethods  bool                    `json:"include_methods,omitempty" jsonschema:"Optional, when 'symbol' is 'type' returns a JSON object with the type 'source' and the 'name' and 'source' of its indexed 'methods', so a struct and its methods are read in one call. Methods beyond 64KB of source code are listed in 'omitted_methods' to read one by one. Not used when 'symbols' is set."`
+}
+
+type GolangSymbolSpecParam struct {
+	Namespace string `json:"namespace,omitempty" jsonschema:"The golang namespace of the symbol, defaults to the 'namespace' of the query"`
 	Symbol    string `json:"symbol" jsonschema:"[Required] The symbol you want to read, possible values: 'func', 'method', 'type', 'var'"`
 	Receiver  string `json:"receiver,omitempty" jsonschema:"The type of method receiver, e.g.: 'ContainerAppResource'. Can only be set when symbol is 'method'."`
-	Name      string `json:"name" jsonschema:"[Required] The name of the function, method, type or variable you want to read. For example: 'NewContainerAppResource', 'ContainerAppResource'"`
-	Tag       string `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Name      string `json:"name" jsonschema:"[Required] The name of the function, method, type or variable you want to read"`
+	Tag       string `json:"tag,omitempty" jsonschema:"Optional tag version, defaults to the 'tag' of the query"`
 }
 
-func QueryGolangSourceCode(_ context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[GolangSourceCodeQueryParam]) (*mcp.CallToolResultFor[any], error) {
+func QueryGolangSourceCode(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[GolangSourceCodeQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	if mode := params.Arguments.Mode; mode != "" && mode != gophon.SourceModeFull && mode != gophon.SourceModeSignature {
+		return nil, fmt.Errorf("invalid mode: %s, valid modes are `%s` and `%s`", mode, gophon.SourceModeFull, gophon.SourceModeSignature)
+	}
+	if len(params.Arguments.Symbols) > 0 {
+		return queryGolangSourceCodes(ctx, params.Arguments)
+	}
+	if params.Arguments.IncludeMethods {
+		return queryGolangTypeWithMethods(ctx, params.Arguments)
+	}
+	if params.Arguments.IncludeLocation {
+		return queryGolangSourceCodeWithLocation(ctx, params.Arguments)
+	}
 	symbol := params.Arguments.Symbol
-	code, err := gophon.GetGolangSourceCode(params.Arguments.Namespace, symbol, params.Arguments.Receiver, params.Arguments.Name, params.Arguments.Tag)
+	code, err := gophon.GetGolangSourceCode(ctx, params.Arguments.Namespace, symbol, params.Arguments.Receiver, params.Arguments.Name, params.Arguments.Tag)
 	if err != nil && strings.Contains(err.Error(), gophon.NotFoundError.Error()) && symbol == "func" {
 		return nil, fmt.Errorf("cannot find function %s, maybe it's a variable with function type?", symbol)
 	}
@@ -28,7 +53,100 @@ func QueryGolangSourceCode(_ context.Context, _ *mcp.ServerSession, params *mcp.
 	return &mcp.CallToolResultFor[any]{
 		Content: []mcp.Content{
 			&mcp.TextContent{
-				Text: code,
+				Text: applySourceMode(code, params.Arguments.Mode),
+			},
+		},
+	}, nil
+}
+
+// applySourceMode returns the signature of source in signature mode, or source as is
+func applySourceMode(source, mode string) string {
+	if mode == gophon.SourceModeSignature {
+		return gophon.Signature(source)
+	}
+	return source
+}
+
+func queryGolangSourceCodeWithLocation(ctx context.Context, args GolangSourceCodeQueryParam) (*mcp.CallToolResultFor[any], error) {
+	code, err := gophon.GetGolangSourceCodeWithLocation(ctx, args.Namespace, args.Symbol, args.Receiver, args.Name, args.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get golang source code for %s %s: %w", args.Symbol, args.Name, err)
+	}
+	code.Source = applySourceMode(code.Source, args.Mode)
+	jsonBytes, err := json.Marshal(code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal golang source code to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
+
+func queryGolangTypeWithMethods(ctx context.Context, args GolangSourceCodeQueryParam) (*mcp.CallToolResultFor[any], error) {
+	if args.Symbol != "type" {
+		return nil, fmt.Errorf("include_methods is only valid for types")
+	}
+	code, err := gophon.GetTypeWithMethods(ctx, args.Namespace, args.Name, args.Tag, args.IncludeLocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get golang source code for type %s: %w", args.Name, err)
+	}
+	code.Source = applySourceMode(code.Source, args.Mode)
+	for i := range code.Methods {
+		code.Methods[i].Source = applySourceMode(code.Methods[i].Source, args.Mode)
+	}
+	jsonBytes, err := json.Marshal(code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal golang source code to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
+
+func queryGolangSourceCodes(ctx context.Context, args GolangSourceCodeQueryParam) (*mcp.CallToolResultFor[any], error) {
+	queries := make([]gophon.SymbolQuery, 0, len(args.Symbols))
+	for _, spec := range args.Symbols {
+		query := gophon.SymbolQuery{
+			Namespace: spec.Namespace,
+			Symbol:    spec.Symbol,
+			Receiver:  spec.Receiver,
+			Name:      spec.Name,
+			Tag:       spec.Tag,
+		}
+		if query.Namespace == "" {
+			query.Namespace = args.Namespace
+		}
+		if query.Tag == "" {
+			query.Tag = args.Tag
+		}
+		queries = append(queries, query)
+	}
+	sources, err := gophon.GetGolangSourceCodes(ctx, queries, args.IncludeLocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get golang source code: %w", err)
+	}
+	for key, source := range sources {
+		if source.Source != "" {
+			source.Source = applySourceMode(source.Source, args.Mode)
+			sources[key] = source
+		}
+	}
+	jsonBytes, err := json.Marshal(sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal golang source code to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
 			},
 		},
 	}, nil
@@ -0,0 +1,59 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type GolangSourceSearchParam struct {
+	Namespace    string `json:"namespace" jsonschema:"[Required] The golang namespace to search (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal/services/network'), its sub-packages are not searched"`
+	Pattern      string `json:"pattern" jsonschema:"[Required] The Go regular expression to match against every line of source code, e.g.: 'ExpandFeatures\\('"`
+	ContextLines int    `json:"context_lines,omitempty" jsonschema:"Number of lines before and after every match to return, at most 10 (defaults to 0)"`
+	Tag          string `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Cursor       string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit        int    `json:"limit,omitempty" jsonschema:"Maximum number of matches to return. Defaults to 50."`
+}
+
+// defaultSourceSearchLimit is the page size when no limit is requested, matches come with their context lines
+const defaultSourceSearchLimit = 50
+
+// scannedPage is a page of the results of a search reading the source code of indexed symbols
+type scannedPage[T any] struct {
+	Page[T]
+	// Scanned is the number of symbols whose source code was searched
+	Scanned int `json:"scanned"`
+	// Truncated is true when the namespaces have more symbols than could be searched, the others weren't searched
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// SearchGolangSource is an MCP tool that searches the indexed source code of a golang namespace with a regular expression
+func SearchGolangSource(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[GolangSourceSearchParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	result, err := gophon.SearchSource(ctx, args.Namespace, args.Pattern, args.Tag, args.ContextLines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search source code of namespace %s: %w", args.Namespace, err)
+	}
+	limit := args.Limit
+	if limit == 0 {
+		limit = defaultSourceSearchLimit
+	}
+	page, err := paginate(result.Matches, args.Cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(scannedPage[gophon.SourceMatch]{Page: page, Scanned: result.Scanned, Truncated: result.Truncated})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal source search result to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,47 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type GolangSymbolSearchParam struct {
+	Namespace string `json:"namespace" jsonschema:"[Required] The golang namespace to search in (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps')"`
+	Pattern   string `json:"pattern,omitempty" jsonschema:"Case-insensitive substring of the symbol name, or a regular expression when regex is true. Methods also match 'Receiver.Name'. Lists all symbols when empty."`
+	Symbol    string `json:"symbol,omitempty" jsonschema:"Optional symbol kind to filter by, possible values: 'func', 'method', 'type', 'var'"`
+	Regex     bool   `json:"regex,omitempty" jsonschema:"Treat pattern as a regular expression"`
+	Tag       string `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Cursor    string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit     int    `json:"limit,omitempty" jsonschema:"Maximum number of symbols to return. If not set, all matching symbols are returned."`
+}
+
+// SearchGolangSymbols is an MCP tool that lists the indexed symbols of a golang namespace matching a pattern
+func SearchGolangSymbols(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[GolangSymbolSearchParam]) (*mcp.CallToolResultFor[any], error) {
+	namespace := params.Arguments.Namespace
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace parameter is required")
+	}
+	symbols, err := gophon.SearchSymbols(ctx, namespace, params.Arguments.Pattern, params.Arguments.Symbol, params.Arguments.Tag, params.Arguments.Regex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search symbols in namespace %q: %w", namespace, err)
+	}
+	page, err := paginate(symbols, params.Arguments.Cursor, params.Arguments.Limit)
+	if err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal symbols to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
@@ -10,7 +10,12 @@ import (
 )
 
 type GolangTagsQueryParam struct {
-	Namespace string `json:"namespace" jsonschema:"The golang namespace to get tags for (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal')"`
+	Namespace         string `json:"namespace" jsonschema:"The golang namespace to get tags for (e.g. 'github.com/hashicorp/terraform-provider-azurerm/internal')"`
+	ExcludePrerelease bool   `json:"exclude_prerelease,omitempty" jsonschema:"Exclude pre-release tags like 'v5.0.0-beta1'"`
+	Prefix            string `json:"prefix,omitempty" jsonschema:"Only return tags starting with the prefix, e.g.: 'v4.'"`
+	Order             string `json:"order,omitempty" jsonschema:"Order of the tags, 'asc' for oldest first or 'desc' for latest first, defaults to 'asc'"`
+	Cursor            string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit             int    `json:"limit,omitempty" jsonschema:"Maximum number of tags to return, the first ones in 'order', returns all tags when not set"`
 }
 
 // QuerySupportedTags is an MCP tool that returns all supported tags for a specific golang namespace
@@ -21,13 +26,20 @@ func QuerySupportedTags(ctx context.Context, cc *mcp.ServerSession, params *mcp.
 	}
 
 	// Get supported tags using the core business logic
-	tags, err := gophon.ListSupportedTags(namespace)
+	tags, err := gophon.ListSupportedTagsWithOptions(ctx, namespace, gophon.TagOptions{
+		ExcludePrerelease: params.Arguments.ExcludePrerelease,
+		Prefix:            params.Arguments.Prefix,
+		Order:             params.Arguments.Order,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get supported tags for namespace %q: %w", namespace, err)
 	}
+	page, err := paginate(tags, params.Arguments.Cursor, params.Arguments.Limit)
+	if err != nil {
+		return nil, err
+	}
 
-	// Convert to JSON array format for consistent API response
-	jsonBytes, err := json.Marshal(tags)
+	jsonBytes, err := json.Marshal(page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal tags to JSON: %w", err)
 	}
@@ -75,9 +75,10 @@ func TestQuerySupportedTags(t *testing.T) {
 			require.True(t, ok)
 
 			// Parse the JSON response
-			var tags []string
-			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &tags))
-			assert.True(t, tt.name != "valid namespace" || len(tags) > 0, "Expected tags to be present for valid namespace")
+			var page Page[string]
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &page))
+			assert.True(t, tt.name != "valid namespace" || len(page.Items) > 0, "Expected tags to be present for valid namespace")
+			assert.Len(t, page.Items, page.Total)
 		})
 	}
 }
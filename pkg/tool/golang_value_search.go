@@ -0,0 +1,43 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type GolangValueSearchParam struct {
+	Namespace string `json:"namespace" jsonschema:"[Required] The golang namespace to search (e.g. 'github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts'), its sub-packages are not searched"`
+	Value     string `json:"value" jsonschema:"[Required] The value of the constants or variables to find, matched case-insensitively against the whole literal value, e.g.: 'Standard_LRS'"`
+	Regex     bool   `json:"regex,omitempty" jsonschema:"Treat value as a Go regular expression matched against literal values (defaults to false)"`
+	Tag       string `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Cursor    string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit     int    `json:"limit,omitempty" jsonschema:"Maximum number of matches to return. If not set, all matches are returned."`
+}
+
+// SearchGolangValues is an MCP tool that searches the constants and variables of a golang namespace by value
+func SearchGolangValues(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[GolangValueSearchParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	result, err := gophon.SearchValues(ctx, args.Namespace, args.Value, args.Tag, args.Regex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search values of namespace %s: %w", args.Namespace, err)
+	}
+	page, err := paginate(result.Matches, args.Cursor, args.Limit)
+	if err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(scannedPage[gophon.ValueMatch]{Page: page, Scanned: result.Scanned, Truncated: result.Truncated})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value search result to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
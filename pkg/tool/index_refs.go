@@ -0,0 +1,38 @@
+package tool
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// WithIndexRefs wraps a tool reading gophon indexes so its result tells which index versions served it, in the
+// `index_refs` of the result metadata and in a trailing text content like
+// `index: lonegunmanb/terraform-provider-azurerm-index@v4.25.0 (commit 1a2b3c4)`
+func WithIndexRefs[In any](handler mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[any], error) {
+		ctx, refs := gophon.WithIndexRefs(ctx)
+		result, err := handler(ctx, session, params)
+		if err != nil || result == nil {
+			return result, err
+		}
+		list := refs.List()
+		if len(list) == 0 {
+			return result, nil
+		}
+		if result.Meta == nil {
+			result.Meta = mcp.Meta{}
+		}
+		result.Meta["index_refs"] = list
+		descriptions := make([]string, 0, len(list))
+		for _, ref := range list {
+			descriptions = append(descriptions, ref.String())
+		}
+		logging.FromContext(ctx).DebugContext(ctx, "read gophon indexes", "index_refs", descriptions)
+		result.Content = append(result.Content, &mcp.TextContent{Text: "index: " + strings.Join(descriptions, ", ")})
+		return result, nil
+	}
+}
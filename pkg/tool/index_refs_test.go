@@ -0,0 +1,42 @@
+package tool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithIndexRefs(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "terraform-provider-azurerm-index")
+	clients := filepath.Join(root, "index", "internal", "clients")
+	require.NoError(t, os.MkdirAll(clients, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(clients, "type.Client.goindex"), []byte("type Client struct {}"), 0o644))
+	t.Setenv(gophon.IndexDirEnv, dir)
+
+	handler := WithIndexRefs(QueryGolangSourceCode)
+	result, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[GolangSourceCodeQueryParam]{
+		Arguments: GolangSourceCodeQueryParam{
+			Namespace: "github.com/hashicorp/terraform-provider-azurerm/internal/clients",
+			Symbol:    "type",
+			Name:      "Client",
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "type Client struct {}", result.Content[0].(*mcp.TextContent).Text)
+	assert.Equal(t, "index: lonegunmanb/terraform-provider-azurerm-index@HEAD (local "+root+")", result.Content[1].(*mcp.TextContent).Text)
+	assert.Equal(t, []gophon.IndexRef{{Repository: "lonegunmanb/terraform-provider-azurerm-index", LocalPath: root}}, result.Meta["index_refs"])
+
+	// errors are returned as is
+	_, err = handler(context.Background(), nil, &mcp.CallToolParamsFor[GolangSourceCodeQueryParam]{
+		Arguments: GolangSourceCodeQueryParam{Namespace: "github.com/hashicorp/terraform-provider-azurerm/internal/clients", Symbol: "type", Name: "Missing"},
+	})
+	assert.Error(t, err)
+}
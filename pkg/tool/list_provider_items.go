@@ -2,6 +2,7 @@ package tool
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfschema"
@@ -11,11 +12,30 @@ import (
 type ListItemsParam struct {
 	Category          string `json:"category" jsonschema:"Terraform item type to list, possible values: resource, data, ephemeral, function"`
 	ProviderNamespace string `json:"namespace" jsonschema:"Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'."`
-	ProviderName      string `json:"name" jsonschema:"Provider name (e.g., 'aws', 'azurerm', 'azapi'). Required parameter."`
+	ProviderName      string `json:"name" jsonschema:"Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). Required parameter."`
 	ProviderVersion   string `json:"version,omitempty" jsonschema:"Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used."`
+	Prefix            string `json:"prefix,omitempty" jsonschema:"Only return items starting with this prefix (e.g., 'aws_lambda_')"`
+	Contains          string `json:"contains,omitempty" jsonschema:"Only return items containing this substring (e.g., 'bucket')"`
+	Regex             string `json:"regex,omitempty" jsonschema:"Only return items matching this regular expression (e.g., '_(vpc|subnet)$')"`
+	Cursor            string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit             int    `json:"limit,omitempty" jsonschema:"Maximum number of items to return. Defaults to 0, which returns all matching items."`
+}
+
+// providerItemPage is a page of provider items with metadata about the provider
+type providerItemPage struct {
+	Provider string `json:"provider"`
+	// Version is the resolved provider version
+	Version  string `json:"version"`
+	Category string `json:"category"`
+	Page[string]
+	// Counts is the number of items of every category before filtering
+	Counts map[string]int `json:"counts"`
 }
 
 func ListProviderItems(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[ListItemsParam]) (*mcp.CallToolResultFor[any], error) {
+	if err := params.Arguments.splitProviderSource(); err != nil {
+		return nil, err
+	}
 	category := params.Arguments.Category
 	namespace := params.Arguments.ProviderNamespace
 	name := params.Arguments.ProviderName
@@ -36,16 +56,38 @@ func ListProviderItems(ctx context.Context, cc *mcp.ServerSession, params *mcp.C
 		ProviderName:      name,
 		ProviderVersion:   version,
 	}
+	providerReq, err := resolveProvider(providerReq)
+	if err != nil {
+		return nil, err
+	}
 
-	items, err := tfschema.ListItems(category, providerReq)
+	list, err := tfschema.ListItemsWithMetadata(category, providerReq, tfschema.ListItemsOptions{
+		Prefix:   params.Arguments.Prefix,
+		Contains: params.Arguments.Contains,
+		Regex:    params.Arguments.Regex,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list %s items: %w", category, err)
 	}
+	page, err := paginate(list.Items, params.Arguments.Cursor, params.Arguments.Limit)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(providerItemPage{
+		Provider: list.Provider,
+		Version:  list.Version,
+		Category: list.Category,
+		Page:     page,
+		Counts:   list.Counts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s items: %w", category, err)
+	}
 
-	return &mcp.CallToolResultFor[any]{
+	return withProviderVersion(&mcp.CallToolResultFor[any]{
 		Content: []mcp.Content{
 			&mcp.TextContent{
-				Text: fmt.Sprintf("Found %d %s items for provider %s/%s:\n%v", len(items), category, namespace, name, items),
+				Text: string(content),
 				Annotations: &mcp.Annotations{
 					Audience: []mcp.Role{
 						"assistant",
@@ -53,5 +95,5 @@ func ListProviderItems(ctx context.Context, cc *mcp.ServerSession, params *mcp.C
 				},
 			},
 		},
-	}, nil
+	}, providerReq), nil
 }
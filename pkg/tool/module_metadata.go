@@ -0,0 +1,47 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfmodule"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type ModuleMetadataParam struct {
+	Source  string `json:"source" jsonschema:"Registry module source address as written in a module block (e.g., 'Azure/avm-res-keyvault-vault/azurerm', 'registry.terraform.io/Azure/avm-res-keyvault-vault/azurerm'). Required parameter."`
+	Version string `json:"version,omitempty" jsonschema:"Module version (e.g., '0.10.0'). If not specified, the latest version will be used."`
+}
+
+func QueryModuleMetadata(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[ModuleMetadataParam]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Source == "" {
+		return nil, fmt.Errorf("module source is required")
+	}
+	req, err := tfmodule.ParseModuleSource(params.Arguments.Source)
+	if err != nil {
+		return nil, err
+	}
+	req.Version = params.Arguments.Version
+
+	metadata, err := tfmodule.GetModule(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal module metadata: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+				Annotations: &mcp.Annotations{
+					Audience: []mcp.Role{
+						"assistant",
+					},
+				},
+			},
+		},
+	}, nil
+}
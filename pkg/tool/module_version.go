@@ -0,0 +1,47 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfmodule"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type ModuleVersionParam struct {
+	Source            string `json:"source" jsonschema:"Registry module source address as written in a module block (e.g., 'Azure/avm-res-keyvault-vault/azurerm'). Required parameter."`
+	VersionConstraint string `json:"version_constraint,omitempty" jsonschema:"Version constraint as written in the module block (e.g., '~> 0.10', '>= 1.0, < 2.0'). If not specified, the latest release is selected."`
+}
+
+func ResolveModuleVersion(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[ModuleVersionParam]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Source == "" {
+		return nil, fmt.Errorf("module source is required")
+	}
+	req, err := tfmodule.ParseModuleSource(params.Arguments.Source)
+	if err != nil {
+		return nil, err
+	}
+	req.Version = params.Arguments.VersionConstraint
+
+	resolution, err := tfmodule.ResolveModuleVersion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(resolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal module version resolution: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+				Annotations: &mcp.Annotations{
+					Audience: []mcp.Role{
+						"assistant",
+					},
+				},
+			},
+		},
+	}, nil
+}
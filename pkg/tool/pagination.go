@@ -0,0 +1,75 @@
+package tool
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Enumeration tools share one pagination convention: they accept an optional 'cursor' and 'limit' and return a Page.
+// The first page is requested without a cursor, the following ones by passing back the 'next_cursor' of the previous
+// page, which is omitted on the last page. A zero limit returns all remaining items, unless the tool documents a default
+// limit because every item it returns is expensive, like a registry lookup or a source code match.
+
+// cursorPrefix tags the offsets encoded in cursors, so cursors stay opaque and arbitrary strings are rejected
+const cursorPrefix = "offset:"
+
+// Page is a page of the items listed by an enumeration tool
+type Page[T any] struct {
+	Items []T `json:"items"`
+	// Total is the number of items across all pages
+	Total int `json:"total"`
+	// NextCursor is the cursor of the next page, empty when this is the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// paginate returns the page of items starting at cursor with at most limit items
+func paginate[T any](items []T, cursor string, limit int) (Page[T], error) {
+	if limit < 0 {
+		return Page[T]{}, fmt.Errorf("limit must not be negative: %d", limit)
+	}
+	offset, err := decodeCursor(cursor)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	page := Page[T]{
+		Items: []T{},
+		Total: len(items),
+	}
+	if offset >= len(items) {
+		return page, nil
+	}
+	end := len(items)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+		page.NextCursor = encodeCursor(end)
+	}
+	page.Items = items[offset:end]
+	return page, nil
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset encoded in cursor, an empty cursor is the first page
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	invalid := fmt.Errorf("invalid cursor %q, pass the 'next_cursor' of the previous page, or no cursor for the first page", cursor)
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, invalid
+	}
+	offset, found := strings.CutPrefix(string(decoded), cursorPrefix)
+	if !found {
+		return 0, invalid
+	}
+	n, err := strconv.Atoi(offset)
+	if err != nil || n < 0 {
+		return 0, invalid
+	}
+	return n, nil
+}
@@ -0,0 +1,112 @@
+package tool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	tests := []struct {
+		name     string
+		cursor   string
+		limit    int
+		expected Page[string]
+	}{
+		{
+			name:     "all items without limit",
+			expected: Page[string]{Items: items, Total: 5},
+		},
+		{
+			name:     "first page",
+			limit:    2,
+			expected: Page[string]{Items: []string{"a", "b"}, Total: 5, NextCursor: encodeCursor(2)},
+		},
+		{
+			name:     "middle page",
+			cursor:   encodeCursor(2),
+			limit:    2,
+			expected: Page[string]{Items: []string{"c", "d"}, Total: 5, NextCursor: encodeCursor(4)},
+		},
+		{
+			name:     "last page",
+			cursor:   encodeCursor(4),
+			limit:    2,
+			expected: Page[string]{Items: []string{"e"}, Total: 5},
+		},
+		{
+			name:     "limit matching the remaining items",
+			cursor:   encodeCursor(3),
+			limit:    2,
+			expected: Page[string]{Items: []string{"d", "e"}, Total: 5},
+		},
+		{
+			name:     "cursor past the end",
+			cursor:   encodeCursor(10),
+			limit:    2,
+			expected: Page[string]{Items: []string{}, Total: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := paginate(items, tt.cursor, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, page)
+		})
+	}
+}
+
+func TestPaginate_WalkAllPages(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	var walked []int
+	cursor := ""
+	for {
+		page, err := paginate(items, cursor, 3)
+		require.NoError(t, err)
+		walked = append(walked, page.Items...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	assert.Equal(t, items, walked)
+}
+
+func TestPaginate_InvalidArguments(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+		limit  int
+		errMsg string
+	}{
+		{
+			name:   "negative limit",
+			limit:  -1,
+			errMsg: "limit must not be negative",
+		},
+		{
+			name:   "not base64",
+			cursor: "not a cursor!",
+			errMsg: "invalid cursor",
+		},
+		{
+			name:   "plain offset",
+			cursor: "2",
+			errMsg: "invalid cursor",
+		},
+		{
+			name:   "negative offset",
+			cursor: encodeCursor(-1),
+			errMsg: "invalid cursor",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := paginate([]string{"a"}, tt.cursor, tt.limit)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
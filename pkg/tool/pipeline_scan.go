@@ -0,0 +1,82 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/conftest"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/pipeline"
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tflint"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type PipelineScanParam struct {
+	TargetDirectory              string                  `json:"target_directory,omitempty" jsonschema:"Target directory containing Terraform code. When left empty/unset, uses current working directory automatically."`
+	PlanFile                     string                  `json:"plan_file,omitempty" jsonschema:"Path to Terraform plan file in JSON format used by the policy step, relative to target_directory. The policy step is skipped when not set."`
+	BudgetSeconds                int                     `json:"budget_seconds,omitempty" jsonschema:"Total time budget in seconds for the whole pipeline, a step still running when it runs out is cancelled. Defaults to 600."`
+	StepDeadlines                map[string]int          `json:"step_deadlines,omitempty" jsonschema:"Per-step soft deadlines in seconds keyed by step name (fmt, validate, lint, policy). A step is skipped when the remaining budget is less than its soft deadline."`
+	SkipSteps                    []string                `json:"skip_steps,omitempty" jsonschema:"Steps to skip explicitly, possible values: fmt, validate, lint, policy."`
+	TFLintCategory               string                  `json:"tflint_category,omitempty" jsonschema:"Predefined AVM TFLint configuration category: 'reusable' (default) or 'example'."`
+	TFLintRemoteConfigUrl        string                  `json:"tflint_remote_config_url,omitempty" jsonschema:"Optional remote TFLint configuration URL (go-getter syntax). Mutually exclusive with 'tflint_category'."`
+	IgnoredRuleIDs               []string                `json:"ignored_rule_ids,omitempty" jsonschema:"List of TFLint rule IDs to ignore during the lint step."`
+	PreDefinedPolicyLibraryAlias string                  `json:"predefined_policy_library_alias,omitempty" jsonschema:"Predefined policy library alias for the policy step: 'aprl', 'avmsec' or 'all' (default). Mutually exclusive with 'policy_urls'."`
+	PolicyUrls                   []string                `json:"policy_urls,omitempty" jsonschema:"Array of policy URLs in go-getter format for the policy step. Mutually exclusive with 'predefined_policy_library_alias'."`
+	IgnoredPolicies              []ConftestIgnoredPolicy `json:"ignored_policies,omitempty" jsonschema:"Array of policies to ignore during the policy step, each with 'namespace' and 'name'."`
+	Namespaces                   []string                `json:"namespaces,omitempty" jsonschema:"Specific policy namespaces to test during the policy step."`
+	IncludeDefaultAVMExceptions  *bool                   `json:"include_default_avm_exceptions,omitempty" jsonschema:"Whether to include default Azure Verified Modules (AVM) exceptions in the policy step. Defaults to true."`
+}
+
+func PipelineScan(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[PipelineScanParam]) (*mcp.CallToolResultFor[any], error) {
+	var ignoredPolicies []conftest.IgnoredPolicy
+	for _, policy := range params.Arguments.IgnoredPolicies {
+		ignoredPolicies = append(ignoredPolicies, conftest.IgnoredPolicy{
+			Namespace: policy.Namespace,
+			Name:      policy.Name,
+		})
+	}
+
+	includeAVMExceptions := true // Default to true
+	if params.Arguments.IncludeDefaultAVMExceptions != nil {
+		includeAVMExceptions = *params.Arguments.IncludeDefaultAVMExceptions
+	}
+
+	scanParams := pipeline.ScanParam{
+		TargetPath:    params.Arguments.TargetDirectory,
+		PlanFile:      params.Arguments.PlanFile,
+		BudgetSeconds: params.Arguments.BudgetSeconds,
+		StepDeadlines: params.Arguments.StepDeadlines,
+		SkipSteps:     params.Arguments.SkipSteps,
+		TFLint: tflint.ScanParam{
+			Category:        params.Arguments.TFLintCategory,
+			RemoteConfigUrl: params.Arguments.TFLintRemoteConfigUrl,
+			IgnoredRules:    params.Arguments.IgnoredRuleIDs,
+		},
+		Conftest: conftest.ScanParam{
+			PreDefinedPolicyLibraryAlias: params.Arguments.PreDefinedPolicyLibraryAlias,
+			PolicyUrls:                   params.Arguments.PolicyUrls,
+			IgnoredPolicies:              ignoredPolicies,
+			Namespaces:                   params.Arguments.Namespaces,
+			IncludeDefaultAVMExceptions:  includeAVMExceptions,
+		},
+	}
+
+	result, err := pipeline.Scan(ctx, scanParams)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline scan failed: %w", err)
+	}
+
+	// Convert the result to compact JSON for AI agent efficiency
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scan result: %w", err)
+	}
+
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonResult),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,46 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type PlanConformanceParam struct {
+	PlanFile         string            `json:"plan_file" jsonschema:"Required path to Terraform plan file in JSON format. Use relative paths in most cases, relative to the current Terraform workspace (e.g., './plan.json'). Generate using: 'terraform plan -out=plan.tfplan && terraform show -json plan.tfplan > plan.json'."`
+	ProviderVersions map[string]string `json:"provider_versions,omitempty" jsonschema:"Provider versions or version constraints to check against keyed by provider source (e.g., {'hashicorp/azurerm': '5.0.0'}). Providers without an entry are checked against the version constraints declared in the plan configuration, or the latest version."`
+}
+
+func CheckPlanConformance(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[PlanConformanceParam]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.PlanFile == "" {
+		return nil, fmt.Errorf("plan_file is required")
+	}
+	for source := range params.Arguments.ProviderVersions {
+		if _, _, err := tfschema.ParseProviderSource(source); err != nil {
+			return nil, err
+		}
+	}
+	report, err := tfschema.CheckPlanFileConformance(params.Arguments.PlanFile, params.Arguments.ProviderVersions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check plan conformance: %w", err)
+	}
+	content, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plan conformance report: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(content),
+				Annotations: &mcp.Annotations{
+					Audience: []mcp.Role{
+						"assistant",
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,57 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfschema"
+)
+
+// splitProviderSource lets the name parameter carry a provider source address as written in required_providers,
+// e.g. `registry.terraform.io/hashicorp/aws`, and returns the namespace and name parsed from it. Bare names are
+// returned unchanged. An explicit namespace must agree with the namespace of the source address.
+func splitProviderSource(namespace, name string) (string, string, error) {
+	if !tfschema.IsProviderSource(name) {
+		return namespace, name, nil
+	}
+	sourceNamespace, sourceName, err := tfschema.ParseProviderSource(name)
+	if err != nil {
+		return "", "", err
+	}
+	if namespace != "" && !strings.EqualFold(namespace, sourceNamespace) {
+		return "", "", fmt.Errorf("namespace '%s' conflicts with provider source '%s'", namespace, name)
+	}
+	return sourceNamespace, sourceName, nil
+}
+
+func (p *SchemaQueryParam) splitProviderSource() (err error) {
+	// The provider configuration has no type, accept the provider source address there as well
+	if p.Category == "provider" && p.ProviderName == "" && tfschema.IsProviderSource(p.Type) {
+		p.ProviderName, p.Type = p.Type, ""
+	}
+	p.ProviderNamespace, p.ProviderName, err = splitProviderSource(p.ProviderNamespace, p.ProviderName)
+	return err
+}
+
+func (p *SchemaSkeletonParam) splitProviderSource() (err error) {
+	if p.Category == "provider" && p.ProviderName == "" && tfschema.IsProviderSource(p.Type) {
+		p.ProviderName, p.Type = p.Type, ""
+	}
+	p.ProviderNamespace, p.ProviderName, err = splitProviderSource(p.ProviderNamespace, p.ProviderName)
+	return err
+}
+
+func (p *ListItemsParam) splitProviderSource() (err error) {
+	p.ProviderNamespace, p.ProviderName, err = splitProviderSource(p.ProviderNamespace, p.ProviderName)
+	return err
+}
+
+func (p *DeprecatedReportParam) splitProviderSource() (err error) {
+	p.ProviderNamespace, p.ProviderName, err = splitProviderSource(p.ProviderNamespace, p.ProviderName)
+	return err
+}
+
+func (p *SensitiveReportParam) splitProviderSource() (err error) {
+	p.ProviderNamespace, p.ProviderName, err = splitProviderSource(p.ProviderNamespace, p.ProviderName)
+	return err
+}
@@ -0,0 +1,53 @@
+package tool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitProviderSource(t *testing.T) {
+	tests := []struct {
+		name              string
+		namespace         string
+		provider          string
+		expectedNamespace string
+		expectedName      string
+	}{
+		{name: "bare name", provider: "aws", expectedName: "aws"},
+		{name: "bare name with namespace", namespace: "Azure", provider: "azapi", expectedNamespace: "Azure", expectedName: "azapi"},
+		{name: "namespace/name", provider: "Azure/azapi", expectedNamespace: "Azure", expectedName: "azapi"},
+		{name: "fully-qualified address", provider: "registry.terraform.io/hashicorp/aws", expectedNamespace: "hashicorp", expectedName: "aws"},
+		{name: "matching namespace", namespace: "azure", provider: "registry.terraform.io/Azure/azapi", expectedNamespace: "Azure", expectedName: "azapi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, name, err := splitProviderSource(tt.namespace, tt.provider)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNamespace, namespace)
+			assert.Equal(t, tt.expectedName, name)
+		})
+	}
+}
+
+func TestSplitProviderSource_Conflict(t *testing.T) {
+	_, _, err := splitProviderSource("hashicorp", "registry.terraform.io/Azure/azapi")
+	assert.ErrorContains(t, err, "conflicts with provider source")
+
+	_, _, err = splitProviderSource("", "example.com/a/b")
+	assert.Error(t, err)
+}
+
+func TestSchemaQueryParam_ProviderSourceAsType(t *testing.T) {
+	param := &SchemaQueryParam{Category: "provider", Type: "registry.terraform.io/hashicorp/azurerm"}
+	require.NoError(t, param.splitProviderSource())
+	assert.Equal(t, "hashicorp", param.ProviderNamespace)
+	assert.Equal(t, "azurerm", param.ProviderName)
+	assert.Empty(t, param.Type)
+
+	param = &SchemaQueryParam{Category: "resource", Type: "azurerm_resource_group", ProviderName: "hashicorp/azurerm"}
+	require.NoError(t, param.splitProviderSource())
+	assert.Equal(t, "azurerm_resource_group", param.Type)
+	assert.Equal(t, "azurerm", param.ProviderName)
+}
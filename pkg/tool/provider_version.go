@@ -0,0 +1,38 @@
+package tool
+
+import (
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// resolveProvider pins the provider version, so a result can report the concrete version it was generated from
+// even when the caller passed a constraint like `~> 4.0` or no version at all
+func resolveProvider(providerReq tfschema.ProviderRequest) (tfschema.ProviderRequest, error) {
+	resolved, err := tfschema.ResolveVersion(providerReq)
+	if err != nil {
+		return tfschema.ProviderRequest{}, fmt.Errorf("failed to resolve version of provider %s/%s: %w", providerReq.ProviderNamespace, providerReq.ProviderName, err)
+	}
+	return resolved, nil
+}
+
+// withProviderVersion records the resolved provider in the result metadata for clients, and appends a note to the
+// content for agents, so responses are reproducible by querying the same version again
+func withProviderVersion(result *mcp.CallToolResultFor[any], providerReq tfschema.ProviderRequest) *mcp.CallToolResultFor[any] {
+	provider := fmt.Sprintf("%s/%s", providerReq.ProviderNamespace, providerReq.ProviderName)
+	if result.Meta == nil {
+		result.Meta = mcp.Meta{}
+	}
+	result.Meta["provider"] = provider
+	result.Meta["provider_version"] = providerReq.ProviderVersion
+	result.Content = append(result.Content, &mcp.TextContent{
+		Text: fmt.Sprintf("Resolved provider version: %s %s", provider, providerReq.ProviderVersion),
+		Annotations: &mcp.Annotations{
+			Audience: []mcp.Role{
+				"assistant",
+			},
+		},
+	})
+	return result
+}
@@ -0,0 +1,24 @@
+package tool
+
+import (
+	"testing"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithProviderVersion(t *testing.T) {
+	result := withProviderVersion(schemaResult(`{"block":{}}`), tfschema.ProviderRequest{
+		ProviderNamespace: "hashicorp",
+		ProviderName:      "azurerm",
+		ProviderVersion:   "4.39.0",
+	})
+
+	assert.Equal(t, "hashicorp/azurerm", result.Meta["provider"])
+	assert.Equal(t, "4.39.0", result.Meta["provider_version"])
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, `{"block":{}}`, result.Content[0].(*mcp.TextContent).Text)
+	assert.Equal(t, "Resolved provider version: hashicorp/azurerm 4.39.0", result.Content[1].(*mcp.TextContent).Text)
+}
@@ -2,6 +2,7 @@ package tool
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -10,12 +11,15 @@ import (
 )
 
 type SchemaQueryParam struct {
-	Category          string `json:"category" jsonschema:"Terraform block type, possible values: resource, data, ephemeral, function, provider"`
-	Type              string `json:"type" jsonschema:"Terraform block type like: azurerm_resource_group or function name like: can. Not required for provider category."`
-	Path              string `json:"path,omitempty" jsonschema:"JSON path to query the resource schema, for example: default_node_pool.upgrade_settings, if not specified, the whole resource schema will be returned. Note: path queries are not supported for function schemas"`
-	ProviderNamespace string `json:"namespace" jsonschema:"Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'."`
-	ProviderName      string `json:"name" jsonschema:"Provider name (e.g., 'aws', 'azurerm', 'azapi'). Required for provider category. For other categories, if not provided, will be inferred from the type parameter (except for functions)."`
-	ProviderVersion   string `json:"version,omitempty" jsonschema:"Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used."`
+	Category          string                 `json:"category" jsonschema:"Terraform block type, possible values: resource, data, ephemeral, function, provider"`
+	Type              string                 `json:"type" jsonschema:"Terraform block type like: azurerm_resource_group or function name like: can. Not required for provider category."`
+	Path              string                 `json:"path,omitempty" jsonschema:"JSON path to query the resource schema, for example: default_node_pool.upgrade_settings, if not specified, the whole resource schema will be returned. Wildcard segments are supported: '*' matches any single attribute or block, '**' matches any depth of nested blocks, e.g. '*.tags' or '**.identity'; wildcard queries return a map from the full path of every match to its schema. Nested blocks are returned with their nesting_mode, min_items, max_items and a readable cardinality, e.g. 'list of blocks, at most 1, optional'. For function schemas, use 'parameters', 'parameters[<index>]' or 'parameters.<name>' for a single parameter with its description, 'variadic_parameter' or 'return_type'."`
+	ProviderNamespace string                 `json:"namespace" jsonschema:"Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'."`
+	ProviderName      string                 `json:"name" jsonschema:"Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). Required for provider category. For other categories, if not provided, will be inferred from the type parameter (except for functions)."`
+	ProviderVersion   string                 `json:"version,omitempty" jsonschema:"Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used."`
+	View              string                 `json:"view,omitempty" jsonschema:"Schema view, possible values: full (default), required_only, names_and_types, descriptions. Compact views drop descriptions and computed-only attributes to save tokens, the descriptions view keeps only a nested map of descriptions."`
+	Format            string                 `json:"format,omitempty" jsonschema:"Output format, possible values: json (default), markdown, json_schema. Markdown renders attribute tables (name, type, required, description) ready to paste into PR descriptions and docs. json_schema renders a JSON Schema draft 2020-12 document for external validators and form generators."`
+	Queries           []tfschema.SchemaQuery `json:"queries,omitempty" jsonschema:"Bulk queries against one provider, each item has category, type and optional path. When set, top level category, type and path are ignored and a map keyed by 'category:type[:path]' is returned."`
 }
 
 // inferProviderNameFromType extracts the provider name from a resource/data/ephemeral type
@@ -30,6 +34,9 @@ func inferProviderNameFromType(resourceType string) string {
 }
 
 func QuerySchema(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[SchemaQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	if err := params.Arguments.splitProviderSource(); err != nil {
+		return nil, err
+	}
 	category := params.Arguments.Category
 	t := params.Arguments.Type
 	path := params.Arguments.Path
@@ -37,6 +44,10 @@ func QuerySchema(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToo
 	name := params.Arguments.ProviderName
 	version := params.Arguments.ProviderVersion
 
+	if len(params.Arguments.Queries) > 0 {
+		return querySchemas(params.Arguments)
+	}
+
 	validator := NewSchemaQueryValidator()
 
 	// Validate parameters
@@ -59,11 +70,65 @@ func QuerySchema(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToo
 		ProviderName:      name,
 		ProviderVersion:   version,
 	}
+	providerReq, err = resolveProvider(providerReq)
+	if err != nil {
+		return nil, err
+	}
 
-	schema, err := tfschema.QuerySchema(category, t, path, providerReq)
+	schema, err := tfschema.QuerySchemaWithOptions(category, t, path, providerReq, tfschema.QueryOptions{
+		View:   params.Arguments.View,
+		Format: params.Arguments.Format,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query schema for %s %s: %w", category, t, err)
 	}
+	return withProviderVersion(schemaResult(schema), providerReq), nil
+}
+
+// querySchemas runs bulk queries, all queries must target the same provider
+func querySchemas(args SchemaQueryParam) (*mcp.CallToolResultFor[any], error) {
+	validator := NewSchemaQueryValidator()
+	name := args.ProviderName
+	for _, q := range args.Queries {
+		if err := validator.ValidateParams(q.Category, q.Type, q.Path, args.ProviderNamespace, args.ProviderName); err != nil {
+			return nil, fmt.Errorf("invalid query %s: %w", q.Key(), err)
+		}
+		inferred, err := inferProviderName(q.Category, q.Type, args.ProviderName)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			name = inferred
+		}
+		if inferred != name {
+			return nil, fmt.Errorf("bulk queries must target a single provider, got both '%s' and '%s', please provide the 'name' parameter", name, inferred)
+		}
+	}
+
+	providerReq := tfschema.ProviderRequest{
+		ProviderNamespace: validator.NormalizeNamespace(args.ProviderNamespace),
+		ProviderName:      name,
+		ProviderVersion:   args.ProviderVersion,
+	}
+	providerReq, err := resolveProvider(providerReq)
+	if err != nil {
+		return nil, err
+	}
+	results, err := tfschema.QuerySchemas(args.Queries, providerReq, tfschema.QueryOptions{
+		View:   args.View,
+		Format: args.Format,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schemas of provider %s/%s: %w", providerReq.ProviderNamespace, name, err)
+	}
+	content, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	return withProviderVersion(schemaResult(string(content)), providerReq), nil
+}
+
+func schemaResult(schema string) *mcp.CallToolResultFor[any] {
 	return &mcp.CallToolResultFor[any]{
 		Content: []mcp.Content{
 			&mcp.TextContent{
@@ -75,7 +140,7 @@ func QuerySchema(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToo
 				},
 			},
 		},
-	}, nil
+	}
 }
 
 // inferProviderName attempts to infer provider name from resource type if not provided
@@ -3,6 +3,8 @@ package tool
 import (
 	"testing"
 
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfschema"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -161,3 +163,25 @@ func TestSchemaQueryValidator_InferProviderName(t *testing.T) {
 		})
 	}
 }
+
+func TestQuerySchemas_RejectsMultipleProviders(t *testing.T) {
+	_, err := querySchemas(SchemaQueryParam{
+		Queries: []tfschema.SchemaQuery{
+			{Category: "resource", Type: "azurerm_resource_group"},
+			{Category: "resource", Type: "aws_instance"},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bulk queries must target a single provider")
+}
+
+func TestQuerySchemas_InvalidQuery(t *testing.T) {
+	_, err := querySchemas(SchemaQueryParam{
+		Queries: []tfschema.SchemaQuery{
+			{Category: "resource", Type: "azurerm_resource_group"},
+			{Category: "function", Type: "normalise_resource_id"},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid query function:normalise_resource_id")
+}
@@ -29,11 +29,6 @@ func (v *SchemaQueryValidator) ValidateParams(category, resourceType, path, name
 		return fmt.Errorf("provider name is required when category is 'provider'")
 	}
 
-	// For function category, path queries are not supported
-	if category == "function" && path != "" {
-		return fmt.Errorf("path queries are not supported for %s schemas", category)
-	}
-
 	// For non-function and non-provider categories, validate provider name can be inferred if not provided
 	if providerName == "" {
 		inferredName := inferProviderNameFromType(resourceType)
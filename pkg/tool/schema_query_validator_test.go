@@ -87,14 +87,13 @@ func TestSchemaQueryValidator_ValidateParams(t *testing.T) {
 			errorMsg:     "provider name is required when category is 'function'",
 		},
 		{
-			name:         "function category with path not supported",
+			name:         "function category with parameter path",
 			category:     "function",
 			resourceType: "",
-			path:         "some.path",
+			path:         "parameters[0]",
 			namespace:    "hashicorp",
 			providerName: "random",
-			expectError:  true,
-			errorMsg:     "path queries are not supported for function schemas",
+			expectError:  false,
 		},
 		{
 			name:         "resource category with invalid type and no provider name",
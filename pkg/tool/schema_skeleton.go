@@ -0,0 +1,67 @@
+package tool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type SchemaSkeletonParam struct {
+	Category          string `json:"category" jsonschema:"Terraform block type, possible values: resource, data, ephemeral, provider"`
+	Type              string `json:"type" jsonschema:"Terraform block type like: azurerm_resource_group. Not required for provider category."`
+	ProviderNamespace string `json:"namespace" jsonschema:"Provider namespace (e.g., 'hashicorp', 'Azure'). If not set, defaults to 'hashicorp'."`
+	ProviderName      string `json:"name" jsonschema:"Provider name (e.g., 'aws', 'azurerm', 'azapi') or provider source address as written in required_providers (e.g., 'registry.terraform.io/hashicorp/aws', 'Azure/azapi'). Required for provider category. For other categories, if not provided, will be inferred from the type parameter."`
+	ProviderVersion   string `json:"version,omitempty" jsonschema:"Provider version or version constraint (e.g., '5.0.0', '~> 4.0', '>= 3.0, < 5.0'). If not specified, the latest version will be used."`
+}
+
+func GenerateSchemaSkeleton(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[SchemaSkeletonParam]) (*mcp.CallToolResultFor[any], error) {
+	if err := params.Arguments.splitProviderSource(); err != nil {
+		return nil, err
+	}
+	category := params.Arguments.Category
+	t := params.Arguments.Type
+	name := params.Arguments.ProviderName
+
+	if category == "function" {
+		return nil, fmt.Errorf("skeletons are not supported for function schemas")
+	}
+	validator := NewSchemaQueryValidator()
+	if err := validator.ValidateParams(category, t, "", params.Arguments.ProviderNamespace, name); err != nil {
+		return nil, err
+	}
+
+	var err error
+	name, err = inferProviderName(category, t, name)
+	if err != nil {
+		return nil, err
+	}
+
+	providerReq := tfschema.ProviderRequest{
+		ProviderNamespace: validator.NormalizeNamespace(params.Arguments.ProviderNamespace),
+		ProviderName:      name,
+		ProviderVersion:   params.Arguments.ProviderVersion,
+	}
+	providerReq, err = resolveProvider(providerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	skeleton, err := tfschema.GenerateSkeleton(category, t, providerReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate skeleton for %s %s: %w", category, t, err)
+	}
+	return withProviderVersion(&mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: skeleton,
+				Annotations: &mcp.Annotations{
+					Audience: []mcp.Role{
+						"assistant",
+					},
+				},
+			},
+		},
+	}, providerReq), nil
+}
@@ -0,0 +1,44 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type TerraformAcceptanceTestsQueryParam struct {
+	BlockType     string `json:"block_type" jsonschema:"The terraform block type (e.g. 'resource', 'data', 'ephemeral')"`
+	TerraformType string `json:"terraform_type" jsonschema:"The terraform type (e.g. 'azurerm_subnet')"`
+	Test          string `json:"test,omitempty" jsonschema:"Optional test function to read with the functions it calls instead of the whole file (e.g. 'TestAccSubnet_basic')"`
+	Tag           string `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Provider      string `json:"provider,omitempty" jsonschema:"Optional provider to read the source code of, defaults to the prefix of terraform_type"`
+}
+
+// QueryTerraformAcceptanceTests is an MCP tool that returns the acceptance tests of a terraform block from the upstream provider repository
+func QueryTerraformAcceptanceTests(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[TerraformAcceptanceTestsQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	if args.BlockType == "" {
+		return nil, fmt.Errorf("block_type parameter is required")
+	}
+	if args.TerraformType == "" {
+		return nil, fmt.Errorf("terraform_type parameter is required")
+	}
+	result, err := gophon.GetAcceptanceTests(ctx, args.Provider, args.BlockType, args.TerraformType, args.Test, args.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get acceptance tests of %s %s: %w", args.BlockType, args.TerraformType, err)
+	}
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal acceptance tests to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
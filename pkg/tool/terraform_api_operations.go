@@ -0,0 +1,44 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type TerraformAPIOperationsQueryParam struct {
+	BlockType     string   `json:"block_type" jsonschema:"The terraform block type (e.g. 'resource', 'data', 'ephemeral', 'function')"`
+	TerraformType string   `json:"terraform_type" jsonschema:"The terraform type (e.g. 'azurerm_resource_group'), or the provider function for 'function' (e.g. 'provider::azurerm::parse_resource_id')"`
+	Entrypoints   []string `json:"entrypoints,omitempty" jsonschema:"Optional entrypoints to analyze, defaults to 'create', 'read', 'update', 'delete' for 'resource', 'read' for 'data', 'open', 'renew', 'close' for 'ephemeral' and 'run' for 'function'"`
+	Tag           string   `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Provider      string   `json:"provider,omitempty" jsonschema:"Optional provider to read the source code of, defaults to the prefix of terraform_type"`
+}
+
+// QueryTerraformAPIOperations is an MCP tool that returns the SDK client methods called by the entrypoints of a terraform block
+func QueryTerraformAPIOperations(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[TerraformAPIOperationsQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	if args.BlockType == "" {
+		return nil, fmt.Errorf("block_type parameter is required")
+	}
+	if args.TerraformType == "" {
+		return nil, fmt.Errorf("terraform_type parameter is required")
+	}
+	result, err := gophon.GetAPIOperations(ctx, args.Provider, args.BlockType, args.TerraformType, args.Entrypoints, args.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API operations of %s %s: %w", args.BlockType, args.TerraformType, err)
+	}
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal API operations to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,44 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type TerraformAttributeValidatorsQueryParam struct {
+	BlockType     string `json:"block_type" jsonschema:"The terraform block type (e.g. 'resource', 'data', 'ephemeral')"`
+	TerraformType string `json:"terraform_type" jsonschema:"The terraform type (e.g. 'azurerm_subnet')"`
+	Attribute     string `json:"attribute" jsonschema:"The attribute to read the validation of, nested attributes are separated by dots (e.g. 'name' or 'delegation.name')"`
+	Tag           string `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Provider      string `json:"provider,omitempty" jsonschema:"Optional provider to read the source code of, defaults to the prefix of terraform_type"`
+}
+
+// QueryTerraformAttributeValidators is an MCP tool that returns the validation of a terraform block attribute with the source code of its validators
+func QueryTerraformAttributeValidators(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[TerraformAttributeValidatorsQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	if args.BlockType == "" {
+		return nil, fmt.Errorf("block_type parameter is required")
+	}
+	if args.TerraformType == "" {
+		return nil, fmt.Errorf("terraform_type parameter is required")
+	}
+	result, err := gophon.GetAttributeValidators(ctx, args.Provider, args.BlockType, args.TerraformType, args.Attribute, args.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validators of %s %s.%s: %w", args.BlockType, args.TerraformType, args.Attribute, err)
+	}
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal validators to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,43 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type TerraformBlockTimeoutsQueryParam struct {
+	BlockType     string `json:"block_type" jsonschema:"The terraform block type (e.g. 'resource', 'data')"`
+	TerraformType string `json:"terraform_type" jsonschema:"The terraform type (e.g. 'azurerm_subnet')"`
+	Tag           string `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Provider      string `json:"provider,omitempty" jsonschema:"Optional provider to read the source code of, defaults to the prefix of terraform_type"`
+}
+
+// QueryTerraformBlockTimeouts is an MCP tool that returns the default operation timeouts of a terraform block read from its source code
+func QueryTerraformBlockTimeouts(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[TerraformBlockTimeoutsQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	if args.BlockType == "" {
+		return nil, fmt.Errorf("block_type parameter is required")
+	}
+	if args.TerraformType == "" {
+		return nil, fmt.Errorf("terraform_type parameter is required")
+	}
+	result, err := gophon.GetTimeouts(ctx, args.Provider, args.BlockType, args.TerraformType, args.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timeouts of %s %s: %w", args.BlockType, args.TerraformType, err)
+	}
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal timeouts to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
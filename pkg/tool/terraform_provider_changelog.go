@@ -0,0 +1,41 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type TerraformProviderChangelogQueryParam struct {
+	Provider string `json:"provider" jsonschema:"The provider to read the changelog of (e.g. 'azurerm')"`
+	Tag      string `json:"tag,omitempty" jsonschema:"The provider version to read the changelog of, e.g.: v4.30.0. Either 'tag' or 'from_tag' must be set."`
+	FromTag  string `json:"from_tag,omitempty" jsonschema:"Read the changelog of the versions after this one, e.g.: v4.20.0"`
+	ToTag    string `json:"to_tag,omitempty" jsonschema:"The last version to read the changelog of with 'from_tag', e.g.: v4.30.0 (defaults to latest version if not specified)"`
+	Filter   string `json:"filter,omitempty" jsonschema:"Only return the changelog lines containing the filter, case-insensitive, e.g.: 'azurerm_storage_account'"`
+}
+
+// QueryTerraformProviderChangelog is an MCP tool that returns the changelog of provider versions
+func QueryTerraformProviderChangelog(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[TerraformProviderChangelogQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	if args.Provider == "" {
+		return nil, fmt.Errorf("provider parameter is required")
+	}
+	changelog, err := gophon.GetChangelog(ctx, args.Provider, args.Tag, args.FromTag, args.ToTag, args.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changelog of provider %s: %w", args.Provider, err)
+	}
+	jsonBytes, err := json.Marshal(changelog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal changelog to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
@@ -2,6 +2,7 @@ package tool
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
@@ -9,14 +10,15 @@ import (
 )
 
 type TerraformSourceCodeQueryParam struct {
-	BlockType      string `json:"block_type" jsonschema:"The terraform block type (e.g. 'resource', 'data', 'ephemeral')"`
-	TerraformType  string `json:"terraform_type" jsonschema:"The terraform type (e.g. 'azurerm_resource_group')"`
-	EntrypointName string `json:"entrypoint_name" jsonschema:"The function or method name you want to read the source code (for 'resource': 'create', 'read', 'update', 'delete', 'schema', 'attribute'; for 'data': 'read', 'schema', 'attribute'; for 'ephemeral': 'open', 'close', 'renew', 'schema')"`
+	BlockType      string `json:"block_type" jsonschema:"The terraform block type (e.g. 'resource', 'data', 'ephemeral', 'function', 'provider')"`
+	TerraformType  string `json:"terraform_type" jsonschema:"The terraform type (e.g. 'azurerm_resource_group'), or the provider function for 'function' (e.g. 'provider::azurerm::parse_resource_id'), or the provider name for 'provider' (e.g. 'azurerm')"`
+	EntrypointName string `json:"entrypoint_name" jsonschema:"The function or method name you want to read the source code (for 'resource': 'create', 'read', 'update', 'delete', 'schema', 'attribute', 'identity', 'state_upgrade', 'customize_diff'; for 'data': 'read', 'schema', 'attribute'; for 'ephemeral': 'open', 'close', 'renew', 'schema'; for 'function': 'definition', 'run'; for 'provider': 'configure', the provider configuration and API client setup)"`
 	Tag            string `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Provider       string `json:"provider,omitempty" jsonschema:"Optional provider to read the source code of, defaults to the prefix of terraform_type, e.g.: 'google-beta' for a google_* block of the google-beta provider, required for provider functions not called like 'provider::<provider>::<function>'"`
 }
 
 // QueryTerraformSourceCode is an MCP tool that returns terraform source code for a specific block type, terraform type, and entrypoint
-func QueryTerraformSourceCode(_ context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[TerraformSourceCodeQueryParam]) (*mcp.CallToolResultFor[any], error) {
+func QueryTerraformSourceCode(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[TerraformSourceCodeQueryParam]) (*mcp.CallToolResultFor[any], error) {
 	blockType := params.Arguments.BlockType
 	terraformType := params.Arguments.TerraformType
 	entrypointName := params.Arguments.EntrypointName
@@ -33,16 +35,25 @@ func QueryTerraformSourceCode(_ context.Context, _ *mcp.ServerSession, params *m
 	}
 
 	// Get terraform source code using the core business logic
-	sourceCode, err := gophon.GetTerraformSourceCode(blockType, terraformType, entrypointName, tag)
+	sourceCode, err := gophon.GetProviderTerraformSourceCode(ctx, params.Arguments.Provider, blockType, terraformType, entrypointName, tag)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get terraform source code for %s %s.%s: %w", blockType, terraformType, entrypointName, err)
 	}
 
+	// the implementation style changes how the source code should be read, it's a separate content so the source code
+	// is returned as is
+	style, err := json.Marshal(gophon.DetectImplementationStyle(sourceCode))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal implementation style to JSON: %w", err)
+	}
 	return &mcp.CallToolResultFor[any]{
 		Content: []mcp.Content{
 			&mcp.TextContent{
 				Text: sourceCode,
 			},
+			&mcp.TextContent{
+				Text: string(style),
+			},
 		},
 	}, nil
 }
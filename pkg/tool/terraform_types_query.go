@@ -0,0 +1,58 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/gophon"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type TerraformTypesQueryParam struct {
+	Provider  string `json:"provider" jsonschema:"The provider to list the indexed terraform types of (e.g. 'azurerm')"`
+	BlockType string `json:"block_type,omitempty" jsonschema:"Optional terraform block type to list (e.g. 'resource', 'data', 'ephemeral', 'function'), lists all block types when not set"`
+	Filter    string `json:"filter,omitempty" jsonschema:"Optional case-insensitive substring the listed types must contain (e.g. 'subnet')"`
+	Tag       string `json:"tag,omitempty" jsonschema:"Optional tag version, e.g.: v4.0.0 (defaults to latest version if not specified)"`
+	Cursor    string `json:"cursor,omitempty" jsonschema:"The 'next_cursor' of the previous page to get the next page. If not set, returns the first page."`
+	Limit     int    `json:"limit,omitempty" jsonschema:"Maximum number of types to return. If not set, all matching types are returned."`
+}
+
+// terraformType is a terraform type indexed for a provider, like the `resource` `azurerm_subnet`
+type terraformType struct {
+	BlockType string `json:"block_type"`
+	Name      string `json:"name"`
+}
+
+// QueryTerraformTypes is an MCP tool that lists the terraform types indexed for a provider
+func QueryTerraformTypes(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[TerraformTypesQueryParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	if args.Provider == "" {
+		return nil, fmt.Errorf("provider parameter is required")
+	}
+	types, err := gophon.ListTerraformTypes(ctx, args.Provider, args.BlockType, args.Filter, args.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list terraform types of provider %s: %w", args.Provider, err)
+	}
+	var items []terraformType
+	for _, blockType := range gophon.TerraformBlockTypes {
+		for _, name := range types[blockType] {
+			items = append(items, terraformType{BlockType: blockType, Name: name})
+		}
+	}
+	page, err := paginate(items, args.Cursor, args.Limit)
+	if err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal terraform types to JSON: %w", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(jsonBytes),
+			},
+		},
+	}, nil
+}
@@ -17,7 +17,7 @@ type TFLintScanParam struct {
 	IgnoredRuleIDs   []string `json:"ignored_rule_ids,omitempty" jsonschema:"List of TFLint rule IDs to ignore during scanning. These rules will be disabled in the configuration."`
 }
 
-func TFLintScan(_ context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[TFLintScanParam]) (*mcp.CallToolResultFor[any], error) {
+func TFLintScan(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[TFLintScanParam]) (*mcp.CallToolResultFor[any], error) {
 	// Convert the MCP parameters to TFLint scan parameters
 	scanParams := tflint.ScanParam{
 		Category:        params.Arguments.Category,
@@ -28,7 +28,7 @@ func TFLintScan(_ context.Context, _ *mcp.ServerSession, params *mcp.CallToolPar
 	}
 
 	// Execute the TFLint scan
-	result, err := tflint.Scan(scanParams)
+	result, err := tflint.Scan(ctx, scanParams)
 	if err != nil {
 		return nil, fmt.Errorf("TFLint scan failed: %w", err)
 	}
@@ -0,0 +1,55 @@
+package tool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/tfmodule"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type TfvarsStubParam struct {
+	Source          string `json:"source,omitempty" jsonschema:"Registry module source address (e.g., 'Azure/avm-res-keyvault-vault/azurerm'). Mutually exclusive with 'module_directory'."`
+	Version         string `json:"version,omitempty" jsonschema:"Registry module version (e.g., '0.10.0'). If not specified, the latest version will be used. Only used with 'source'."`
+	ModuleDirectory string `json:"module_directory,omitempty" jsonschema:"Local module directory whose variable blocks are read. Mutually exclusive with 'source'."`
+}
+
+func GenerateTfvarsStub(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[TfvarsStubParam]) (*mcp.CallToolResultFor[any], error) {
+	args := params.Arguments
+	if (args.Source == "") == (args.ModuleDirectory == "") {
+		return nil, fmt.Errorf("exactly one of source or module_directory is required")
+	}
+
+	var variables []tfmodule.Variable
+	if args.ModuleDirectory != "" {
+		local, err := tfmodule.LocalVariables(args.ModuleDirectory)
+		if err != nil {
+			return nil, err
+		}
+		variables = local
+	} else {
+		req, err := tfmodule.ParseModuleSource(args.Source)
+		if err != nil {
+			return nil, err
+		}
+		req.Version = args.Version
+		metadata, err := tfmodule.GetModule(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		variables = metadata.Inputs
+	}
+
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: tfmodule.GenerateTfvars(variables),
+				Annotations: &mcp.Annotations{
+					Audience: []mcp.Role{
+						"assistant",
+					},
+				},
+			},
+		},
+	}, nil
+}
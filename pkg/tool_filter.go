@@ -0,0 +1,126 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Environment variables of the tools registered by RegisterMcpServer
+const (
+	// EnabledToolsEnv is a comma-separated list of the tools to register, like `query_azapi_*,query_terraform_schema`,
+	// all tools are registered when it's empty
+	EnabledToolsEnv = "ENABLED_TOOLS"
+	// DisabledToolsEnv is a comma-separated list of the tools not to register, like `tflint_scan,conftest_scan`
+	DisabledToolsEnv = "DISABLED_TOOLS"
+	// ToolsConfigEnv is the path of a JSON file with the `enabled_tools` and `disabled_tools` lists
+	ToolsConfigEnv = "TOOLS_CONFIG_FILE"
+)
+
+// ToolFilter selects the tools registered by RegisterMcpServer. Tools are named, or matched by glob patterns like
+// `azapi_*`. When Enabled isn't empty only the tools it matches are registered, the tools matched by Disabled are never
+// registered.
+type ToolFilter struct {
+	Enabled  []string `json:"enabled_tools"`
+	Disabled []string `json:"disabled_tools"`
+}
+
+// ParseToolFilter returns the filter of the comma-separated enabled and disabled lists, merged with the lists of the
+// JSON config file when configFile isn't empty
+func ParseToolFilter(enabled, disabled, configFile string) (*ToolFilter, error) {
+	filter := &ToolFilter{}
+	if configFile != "" {
+		content, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tools config file %s: %w", configFile, err)
+		}
+		if err = json.Unmarshal(content, filter); err != nil {
+			return nil, fmt.Errorf("failed to parse tools config file %s: %w", configFile, err)
+		}
+	}
+	filter.Enabled = append(filter.Enabled, splitToolList(enabled)...)
+	filter.Disabled = append(filter.Disabled, splitToolList(disabled)...)
+	for _, pattern := range append(filter.Enabled, filter.Disabled...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+	}
+	return filter, nil
+}
+
+func splitToolList(list string) []string {
+	var tools []string
+	for _, tool := range strings.Split(list, ",") {
+		if tool = strings.TrimSpace(tool); tool != "" {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// Allows returns true when the tool name is registered, a nil filter allows every tool
+func (f *ToolFilter) Allows(name string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Enabled) > 0 && !matchesTool(f.Enabled, name) {
+		return false
+	}
+	return !matchesTool(f.Disabled, name)
+}
+
+// validate returns an error naming the patterns matching none of the tools, a misspelled tool would be silently
+// registered, or not, otherwise
+func (f *ToolFilter) validate(tools []string) error {
+	if f == nil {
+		return nil
+	}
+	var unknown []string
+	for _, pattern := range append(f.Enabled, f.Disabled...) {
+		matched := false
+		for _, tool := range tools {
+			if matchesTool([]string{pattern}, tool) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unknown = append(unknown, pattern)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown tools: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func matchesTool(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// toolRegistry adds the tools allowed by its filter to the server, and records the names of all tools
+type toolRegistry struct {
+	server *mcp.Server
+	filter *ToolFilter
+	// skip records the tools added without registering them
+	skip  bool
+	names []string
+}
+
+func addTool[In, Out any](r *toolRegistry, t *mcp.Tool, h mcp.ToolHandlerFor[In, Out]) {
+	r.names = append(r.names, t.Name)
+	if !r.skip && r.filter.Allows(t.Name) {
+		mcp.AddTool(r.server, t, h)
+		telemetry.RegisterTool(t.Name)
+	}
+}
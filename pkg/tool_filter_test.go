@@ -0,0 +1,86 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolFilter_Allows(t *testing.T) {
+	tests := []struct {
+		desc     string
+		filter   *ToolFilter
+		allowed  []string
+		rejected []string
+	}{
+		{
+			desc:    "nil filter",
+			allowed: []string{"tflint_scan", "query_azapi_resource_schema"},
+		},
+		{
+			desc:     "disabled tools",
+			filter:   &ToolFilter{Disabled: []string{"tflint_scan", "conftest_*"}},
+			allowed:  []string{"query_azapi_resource_schema"},
+			rejected: []string{"tflint_scan", "conftest_scan"},
+		},
+		{
+			desc:     "enabled tools",
+			filter:   &ToolFilter{Enabled: []string{"query_azapi_*"}},
+			allowed:  []string{"query_azapi_resource_schema"},
+			rejected: []string{"tflint_scan"},
+		},
+		{
+			desc:     "disabled wins over enabled",
+			filter:   &ToolFilter{Enabled: []string{"query_azapi_*"}, Disabled: []string{"query_azapi_resource_schema"}},
+			allowed:  []string{"query_azapi_resource_document"},
+			rejected: []string{"query_azapi_resource_schema", "tflint_scan"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			for _, name := range tt.allowed {
+				assert.True(t, tt.filter.Allows(name), name)
+			}
+			for _, name := range tt.rejected {
+				assert.False(t, tt.filter.Allows(name), name)
+			}
+		})
+	}
+}
+
+func TestParseToolFilter(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "tools.json")
+	require.NoError(t, os.WriteFile(config, []byte(`{"disabled_tools": ["tflint_scan"]}`), 0o644))
+
+	filter, err := ParseToolFilter("query_azapi_*, ", "conftest_scan,terraform_pipeline_scan", config)
+	require.NoError(t, err)
+	assert.Equal(t, &ToolFilter{
+		Enabled:  []string{"query_azapi_*"},
+		Disabled: []string{"tflint_scan", "conftest_scan", "terraform_pipeline_scan"},
+	}, filter)
+
+	_, err = ParseToolFilter("query_[", "", "")
+	assert.ErrorContains(t, err, `invalid tool pattern "query_["`)
+	_, err = ParseToolFilter("", "", filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read tools config file")
+}
+
+func TestRegisterMcpServer_UnknownTools(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	err := RegisterMcpServer(server, &ToolFilter{Disabled: []string{"tflint_scan", "tflint-scan", "query_azapi_*"}})
+	assert.EqualError(t, err, "unknown tools: tflint-scan")
+
+	server = mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	assert.NoError(t, RegisterMcpServer(server, nil))
+}
+
+func TestCacheNames(t *testing.T) {
+	names := cacheNames()
+	assert.Contains(t, names, "tfschema")
+	assert.Contains(t, names, "tfschema_versions")
+	assert.Contains(t, names, "conftest_policy")
+}
@@ -0,0 +1,435 @@
+package transport
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/telemetry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Environment variables of the authentication of the HTTP transports
+const (
+	// AuthConfigEnv is the path of the JSON file configuring the API keys and the OIDC token introspection
+	AuthConfigEnv = "TRANSPORT_AUTH_CONFIG"
+	// APIKeysEnv is a comma-separated list of API keys allowed to call every tool
+	APIKeysEnv = "TRANSPORT_API_KEYS"
+	// OIDCClientSecretEnv is the client secret of the OIDC token introspection, to keep it out of the config file
+	OIDCClientSecretEnv = "TRANSPORT_OIDC_CLIENT_SECRET"
+)
+
+// apiKeyHeader is the header holding the API key of a request not using the `Authorization` header
+const apiKeyHeader = "X-API-Key"
+
+// introspectionTTL is how long the introspection of an OIDC token is reused, tokens expiring sooner are reused until
+// they expire
+var introspectionTTL = time.Minute
+
+// AuthConfig is the configuration of the authentication of the HTTP transports. Clients send an API key or an OIDC
+// access token as a bearer token in the `Authorization` header, or an API key in the `X-API-Key` header.
+type AuthConfig struct {
+	APIKeys []APIKey    `json:"api_keys"`
+	OIDC    *OIDCConfig `json:"oidc,omitempty"`
+}
+
+// APIKey is a static key and the tools its clients may call
+type APIKey struct {
+	// Name identifies the clients of the key in logs
+	Name string `json:"name"`
+	// Key is the key, or KeySHA256 the hex encoded SHA-256 of the key to keep it out of the config file
+	Key       string `json:"key,omitempty"`
+	KeySHA256 string `json:"key_sha256,omitempty"`
+	// Tools are the tools the key may call, glob patterns like `query_*` are supported, empty allows every tool
+	Tools []string `json:"tools,omitempty"`
+}
+
+// OIDCConfig validates OIDC access tokens with the token introspection endpoint of an identity provider
+type OIDCConfig struct {
+	IntrospectionURL string `json:"introspection_url"`
+	ClientID         string `json:"client_id"`
+	ClientSecret     string `json:"client_secret,omitempty"`
+	// Tools are the tools every active token may call, empty allows every tool unless ScopeTools is set
+	Tools []string `json:"tools,omitempty"`
+	// ScopeTools are the tools a token may call for each of its scopes, on top of Tools
+	ScopeTools map[string][]string `json:"scope_tools,omitempty"`
+}
+
+// LoadAuthConfig returns the configuration of the JSON config file, when configFile isn't empty, with the
+// comma-separated apiKeys allowed to call every tool. It returns nil when no authentication is configured.
+func LoadAuthConfig(configFile, apiKeys, oidcClientSecret string) (*AuthConfig, error) {
+	config := &AuthConfig{}
+	if configFile != "" {
+		content, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read auth config file %s: %w", configFile, err)
+		}
+		if err = json.Unmarshal(content, config); err != nil {
+			return nil, fmt.Errorf("failed to parse auth config file %s: %w", configFile, err)
+		}
+	}
+	for i, key := range strings.Split(apiKeys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			config.APIKeys = append(config.APIKeys, APIKey{Name: fmt.Sprintf("%s[%d]", APIKeysEnv, i), Key: key})
+		}
+	}
+	if config.OIDC != nil && oidcClientSecret != "" {
+		config.OIDC.ClientSecret = oidcClientSecret
+	}
+	if len(config.APIKeys) == 0 && config.OIDC == nil {
+		return nil, nil
+	}
+	return config, config.validate()
+}
+
+func (c *AuthConfig) validate() error {
+	for _, key := range c.APIKeys {
+		if (key.Key == "") == (key.KeySHA256 == "") {
+			return fmt.Errorf("API key %q must have either a key or a key_sha256", key.Name)
+		}
+		if key.KeySHA256 != "" {
+			if digest, err := hex.DecodeString(key.KeySHA256); err != nil || len(digest) != sha256.Size {
+				return fmt.Errorf("API key %q has an invalid key_sha256, expected a hex encoded SHA-256", key.Name)
+			}
+		}
+		if err := validateToolPatterns(key.Tools); err != nil {
+			return fmt.Errorf("API key %q: %w", key.Name, err)
+		}
+	}
+	if c.OIDC == nil {
+		return nil
+	}
+	if u, err := url.Parse(c.OIDC.IntrospectionURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid OIDC introspection_url %q", c.OIDC.IntrospectionURL)
+	}
+	if err := validateToolPatterns(c.OIDC.Tools); err != nil {
+		return fmt.Errorf("OIDC: %w", err)
+	}
+	for scope, tools := range c.OIDC.ScopeTools {
+		if err := validateToolPatterns(tools); err != nil {
+			return fmt.Errorf("OIDC scope %q: %w", scope, err)
+		}
+	}
+	return nil
+}
+
+// restrictsTools returns true when some principals may not call every tool
+func (c *AuthConfig) restrictsTools() bool {
+	for _, key := range c.APIKeys {
+		if len(key.Tools) > 0 {
+			return true
+		}
+	}
+	return c.OIDC != nil && c.OIDC.tools(nil) != nil
+}
+
+func validateToolPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Principal is an authenticated client
+type Principal struct {
+	Name string
+	// Tools are the tools the principal may call, nil allows every tool
+	Tools []string
+}
+
+// Allows returns true when the principal may call the tool
+func (p *Principal) Allows(tool string) bool {
+	if p.Tools == nil {
+		return true
+	}
+	for _, pattern := range p.Tools {
+		if ok, _ := path.Match(pattern, tool); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticator authenticates the requests of the HTTP transports, and restricts the tools of each session to the
+// tools of the principal that opened it
+type authenticator struct {
+	config *AuthConfig
+	client *http.Client
+
+	mu sync.Mutex
+	// owners are the principals of the open streamable HTTP sessions
+	owners map[string]*Principal
+	// introspections are the principals of the OIDC tokens recently introspected, keyed by the SHA-256 of the token, nil
+	// for inactive tokens
+	introspections map[string]introspection
+}
+
+type introspection struct {
+	principal *Principal
+	expires   time.Time
+}
+
+func newAuthenticator(config *AuthConfig) *authenticator {
+	return &authenticator{
+		config:         config,
+		client:         &http.Client{Timeout: 10 * time.Second},
+		owners:         make(map[string]*Principal),
+		introspections: make(map[string]introspection),
+	}
+}
+
+// wrap authenticates the requests to next. A streamable HTTP session belongs to the principal that opened it, requests
+// of other principals are rejected. The owner of a session is saved as soon as its ID is sent, next is still serving
+// the request opening it when the client may send the next one.
+func (a *authenticator) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		principal, err := a.authenticate(req)
+		if err != nil {
+			log.Printf("rejected MCP request from %s: %v", req.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcp"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		id := req.Header.Get(sessionIDHeader)
+		if id != "" {
+			if owner := a.owner(id); owner != nil && owner.Name != principal.Name {
+				http.Error(w, "session belongs to another client", http.StatusForbidden)
+				return
+			}
+		}
+		if id == "" {
+			w = &sessionOwnerWriter{ResponseWriter: w, save: func(created string) {
+				a.mu.Lock()
+				a.owners[created] = principal
+				a.mu.Unlock()
+			}}
+		}
+		next.ServeHTTP(w, req)
+		if req.Method == http.MethodDelete {
+			a.forget(id)
+		}
+	})
+}
+
+// sessionOwnerWriter calls save with the session ID of the response before its header is written
+type sessionOwnerWriter struct {
+	http.ResponseWriter
+	save  func(id string)
+	saved bool
+}
+
+func (w *sessionOwnerWriter) WriteHeader(statusCode int) {
+	w.saveOwner()
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *sessionOwnerWriter) Write(b []byte) (int, error) {
+	w.saveOwner()
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush lets the event streams of the session be flushed through the writer
+func (w *sessionOwnerWriter) Flush() {
+	w.saveOwner()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *sessionOwnerWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *sessionOwnerWriter) saveOwner() {
+	if w.saved {
+		return
+	}
+	w.saved = true
+	if id := w.Header().Get(sessionIDHeader); id != "" {
+		w.save(id)
+	}
+}
+
+func (a *authenticator) owner(id string) *Principal {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.owners[id]
+}
+
+// forget drops the principal of a closed session
+func (a *authenticator) forget(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.owners, id)
+}
+
+// authenticate returns the principal of the API key or OIDC token of req
+func (a *authenticator) authenticate(req *http.Request) (*Principal, error) {
+	token := req.Header.Get(apiKeyHeader)
+	if bearer, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); ok {
+		token = strings.TrimSpace(bearer)
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no API key or bearer token")
+	}
+	digest := sha256.Sum256([]byte(token))
+	for _, key := range a.config.APIKeys {
+		if subtle.ConstantTimeCompare(digest[:], key.digest()) == 1 {
+			return key.principal(), nil
+		}
+	}
+	if a.config.OIDC == nil {
+		return nil, fmt.Errorf("unknown API key")
+	}
+	principal, err := a.introspect(req.Context(), hex.EncodeToString(digest[:]), token)
+	if err != nil {
+		return nil, err
+	}
+	if principal == nil {
+		return nil, fmt.Errorf("inactive token")
+	}
+	return principal, nil
+}
+
+func (k APIKey) principal() *Principal {
+	principal := &Principal{Name: k.Name}
+	if len(k.Tools) > 0 {
+		principal.Tools = k.Tools
+	}
+	return principal
+}
+
+func (k APIKey) digest() []byte {
+	if k.KeySHA256 != "" {
+		digest, _ := hex.DecodeString(k.KeySHA256)
+		return digest
+	}
+	digest := sha256.Sum256([]byte(k.Key))
+	return digest[:]
+}
+
+// introspect returns the principal of an active OIDC token, or nil for inactive tokens, with the token introspection
+// of RFC 7662
+func (a *authenticator) introspect(ctx context.Context, key, token string) (*Principal, error) {
+	now := time.Now()
+	a.mu.Lock()
+	cached, ok := a.introspections[key]
+	for k, entry := range a.introspections {
+		if !now.Before(entry.expires) {
+			delete(a.introspections, k)
+		}
+	}
+	a.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.principal, nil
+	}
+
+	oidc := a.config.OIDC
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oidc.IntrospectionURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create introspection request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(oidc.ClientID), url.QueryEscape(oidc.ClientSecret))
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to introspect token: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token introspection failed with status %d", resp.StatusCode)
+	}
+	var result struct {
+		Active   bool   `json:"active"`
+		Scope    string `json:"scope"`
+		Subject  string `json:"sub"`
+		ClientID string `json:"client_id"`
+		Username string `json:"username"`
+		Expires  int64  `json:"exp"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse token introspection response: %w", err)
+	}
+
+	var principal *Principal
+	expires := now.Add(introspectionTTL)
+	if result.Active {
+		principal = &Principal{Name: "oidc:" + firstNonEmpty(result.Subject, result.Username, result.ClientID), Tools: oidc.tools(strings.Fields(result.Scope))}
+		if result.Expires > 0 && time.Unix(result.Expires, 0).Before(expires) {
+			expires = time.Unix(result.Expires, 0)
+		}
+	}
+	a.mu.Lock()
+	a.introspections[key] = introspection{principal: principal, expires: expires}
+	a.mu.Unlock()
+	return principal, nil
+}
+
+// tools returns the tools of a token with the scopes, nil allows every tool
+func (c *OIDCConfig) tools(scopes []string) []string {
+	if len(c.Tools) == 0 && len(c.ScopeTools) == 0 {
+		return nil
+	}
+	tools := append([]string{}, c.Tools...)
+	for _, scope := range scopes {
+		tools = append(tools, c.ScopeTools[scope]...)
+	}
+	return tools
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// middleware restricts the tools listed and called in a session to the tools of its principal. When some principals
+// may not call every tool, sessions without a known owner may not call any tool.
+func (a *authenticator) middleware(next mcp.MethodHandler[*mcp.ServerSession]) mcp.MethodHandler[*mcp.ServerSession] {
+	return func(ctx context.Context, session *mcp.ServerSession, method string, params mcp.Params) (mcp.Result, error) {
+		var principal *Principal
+		if session != nil {
+			principal = a.owner(session.ID())
+		}
+		if principal == nil {
+			if !a.config.restrictsTools() {
+				return next(ctx, session, method, params)
+			}
+			principal = &Principal{Name: "a session without a known owner", Tools: []string{}}
+		}
+		if tool := telemetry.ToolName(params); tool != "" && !principal.Allows(tool) {
+			return nil, fmt.Errorf("tool %s is not allowed for %s", tool, principal.Name)
+		}
+		result, err := next(ctx, session, method, params)
+		if list, ok := result.(*mcp.ListToolsResult); ok && err == nil {
+			allowed := make([]*mcp.Tool, 0, len(list.Tools))
+			for _, tool := range list.Tools {
+				if principal.Allows(tool.Name) {
+					allowed = append(allowed, tool)
+				}
+			}
+			list.Tools = allowed
+		}
+		return result, err
+	}
+}
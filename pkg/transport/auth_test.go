@@ -0,0 +1,297 @@
+package transport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAuthConfig(t *testing.T) {
+	digest := sha256.Sum256([]byte("hashed"))
+	cases := []struct {
+		name       string
+		config     string
+		apiKeys    string
+		secret     string
+		expected   *AuthConfig
+		errMessage string
+	}{
+		{
+			name: "no authentication",
+		},
+		{
+			name:    "API keys from the environment",
+			apiKeys: "first, second,",
+			expected: &AuthConfig{APIKeys: []APIKey{
+				{Name: "TRANSPORT_API_KEYS[0]", Key: "first"},
+				{Name: "TRANSPORT_API_KEYS[1]", Key: "second"},
+			}},
+		},
+		{
+			name:   "config file with the OIDC secret from the environment",
+			config: `{"api_keys": [{"name": "ci", "key_sha256": "` + hex.EncodeToString(digest[:]) + `", "tools": ["query_*"]}], "oidc": {"introspection_url": "https://idp.example.com/introspect", "client_id": "mcp"}}`,
+			secret: "secret",
+			expected: &AuthConfig{
+				APIKeys: []APIKey{{Name: "ci", KeySHA256: hex.EncodeToString(digest[:]), Tools: []string{"query_*"}}},
+				OIDC:    &OIDCConfig{IntrospectionURL: "https://idp.example.com/introspect", ClientID: "mcp", ClientSecret: "secret"},
+			},
+		},
+		{
+			name:       "key and key_sha256",
+			config:     `{"api_keys": [{"name": "ci", "key": "k", "key_sha256": "` + hex.EncodeToString(digest[:]) + `"}]}`,
+			errMessage: `API key "ci" must have either a key or a key_sha256`,
+		},
+		{
+			name:       "invalid key_sha256",
+			config:     `{"api_keys": [{"name": "ci", "key_sha256": "abc"}]}`,
+			errMessage: `API key "ci" has an invalid key_sha256, expected a hex encoded SHA-256`,
+		},
+		{
+			name:       "invalid tool pattern",
+			config:     `{"api_keys": [{"name": "ci", "key": "k", "tools": ["["]}]}`,
+			errMessage: `API key "ci": invalid tool pattern "[": syntax error in pattern`,
+		},
+		{
+			name:       "invalid introspection URL",
+			config:     `{"oidc": {"introspection_url": "/introspect"}}`,
+			errMessage: `invalid OIDC introspection_url "/introspect"`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			configFile := ""
+			if c.config != "" {
+				configFile = filepath.Join(t.TempDir(), "auth.json")
+				require.NoError(t, os.WriteFile(configFile, []byte(c.config), 0600))
+			}
+			config, err := LoadAuthConfig(configFile, c.apiKeys, c.secret)
+			if c.errMessage != "" {
+				assert.EqualError(t, err, c.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, config)
+		})
+	}
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	introspections := 0
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		introspections++
+		user, password, _ := r.BasicAuth()
+		assert.Equal(t, "mcp", user)
+		assert.Equal(t, "secret", password)
+		response := map[string]any{"active": false}
+		switch r.FormValue("token") {
+		case "reader-token":
+			response = map[string]any{"active": true, "sub": "alice", "scope": "openid mcp.read", "exp": time.Now().Add(time.Hour).Unix()}
+		case "admin-token":
+			response = map[string]any{"active": true, "client_id": "pipeline", "scope": "mcp.read mcp.admin"}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer idp.Close()
+	digest := sha256.Sum256([]byte("hashed-key"))
+	auth := newAuthenticator(&AuthConfig{
+		APIKeys: []APIKey{
+			{Name: "all", Key: "plain-key"},
+			{Name: "queries", KeySHA256: hex.EncodeToString(digest[:]), Tools: []string{"query_*"}},
+		},
+		OIDC: &OIDCConfig{
+			IntrospectionURL: idp.URL,
+			ClientID:         "mcp",
+			ClientSecret:     "secret",
+			Tools:            []string{"list_*"},
+			ScopeTools:       map[string][]string{"mcp.read": {"query_*"}, "mcp.admin": {"*"}},
+		},
+	})
+
+	cases := []struct {
+		name       string
+		header     string
+		value      string
+		expected   *Principal
+		errMessage string
+	}{
+		{
+			name:     "bearer API key",
+			header:   "Authorization",
+			value:    "Bearer plain-key",
+			expected: &Principal{Name: "all"},
+		},
+		{
+			name:     "hashed API key header",
+			header:   apiKeyHeader,
+			value:    "hashed-key",
+			expected: &Principal{Name: "queries", Tools: []string{"query_*"}},
+		},
+		{
+			name:     "OIDC token",
+			header:   "Authorization",
+			value:    "Bearer reader-token",
+			expected: &Principal{Name: "oidc:alice", Tools: []string{"list_*", "query_*"}},
+		},
+		{
+			name:     "OIDC token of a client",
+			header:   "Authorization",
+			value:    "Bearer admin-token",
+			expected: &Principal{Name: "oidc:pipeline", Tools: []string{"list_*", "query_*", "*"}},
+		},
+		{
+			name:       "inactive OIDC token",
+			header:     "Authorization",
+			value:      "Bearer revoked-token",
+			errMessage: "inactive token",
+		},
+		{
+			name:       "no credentials",
+			errMessage: "no API key or bearer token",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if c.header != "" {
+				req.Header.Set(c.header, c.value)
+			}
+			principal, err := auth.authenticate(req)
+			if c.errMessage != "" {
+				assert.EqualError(t, err, c.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, principal)
+		})
+	}
+
+	// introspections are reused
+	introspected := introspections
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Authorization", "Bearer reader-token")
+	_, err := auth.authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, introspected, introspections)
+}
+
+func TestAuthenticator_SavesOwnerWhenSessionIDIsSent(t *testing.T) {
+	auth := newAuthenticator(&AuthConfig{APIKeys: []APIKey{{Name: "queries", Key: "reader", Tools: []string{"query_*"}}}})
+	handler := auth.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(sessionIDHeader, "session")
+		w.WriteHeader(http.StatusOK)
+		// the client may use the session before the request opening it is served
+		owner := auth.owner("session")
+		require.NotNil(t, owner)
+		assert.Equal(t, "queries", owner.Name)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(apiKeyHeader, "reader")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestAuthenticator_MiddlewareRejectsUnknownOwner(t *testing.T) {
+	next := func(context.Context, *mcp.ServerSession, string, mcp.Params) (mcp.Result, error) {
+		return &mcp.CallToolResult{}, nil
+	}
+	params := &mcp.CallToolParamsFor[any]{Name: "query_echo"}
+
+	restricted := newAuthenticator(&AuthConfig{APIKeys: []APIKey{{Name: "queries", Key: "reader", Tools: []string{"query_*"}}}})
+	_, err := restricted.middleware(next)(context.Background(), nil, "tools/call", params)
+	assert.ErrorContains(t, err, "tool query_echo is not allowed")
+
+	unrestricted := newAuthenticator(&AuthConfig{APIKeys: []APIKey{{Name: "all", Key: "admin"}}})
+	_, err = unrestricted.middleware(next)(context.Background(), nil, "tools/call", params)
+	assert.NoError(t, err)
+}
+
+func connectWithKey(url, key string) (*mcp.ClientSession, error) {
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "0.1.0"}, nil)
+	httpClient := &http.Client{Transport: headerTransport{key: key}}
+	return client.Connect(context.Background(), mcp.NewStreamableClientTransport(url, &mcp.StreamableClientTransportOptions{HTTPClient: httpClient}))
+}
+
+// headerTransport sends an API key with every request
+type headerTransport struct {
+	key string
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(apiKeyHeader, t.key)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestServe_Auth(t *testing.T) {
+	server := newTestServer()
+	mcp.AddTool(server, &mcp.Tool{Name: "query_echo", Description: "echo"}, func(_ context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]any]) (*mcp.CallToolResultFor[any], error) {
+		return &mcp.CallToolResultFor[any]{Content: []mcp.Content{&mcp.TextContent{Text: "query"}}}, nil
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = serve(ctx, listener, server, HTTPOptions{Mode: ModeStreamableHTTP, Auth: &AuthConfig{APIKeys: []APIKey{
+			{Name: "all", Key: "admin"},
+			{Name: "queries", Key: "reader", Tools: []string{"query_*"}},
+		}}})
+	}()
+	url := "http://" + listener.Addr().String()
+
+	_, err = connect(url)
+	assert.Error(t, err)
+	_, err = connectWithKey(url, "unknown")
+	assert.Error(t, err)
+
+	admin, err := connectWithKey(url, "admin")
+	require.NoError(t, err)
+	defer admin.Close()
+	tools, err := admin.ListTools(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, tools.Tools, 2)
+
+	reader, err := connectWithKey(url, "reader")
+	require.NoError(t, err)
+	defer reader.Close()
+	tools, err = reader.ListTools(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, tools.Tools, 1)
+	assert.Equal(t, "query_echo", tools.Tools[0].Name)
+	result, err := reader.CallTool(context.Background(), &mcp.CallToolParams{Name: "query_echo", Arguments: map[string]any{}})
+	require.NoError(t, err)
+	assert.Equal(t, "query", result.Content[0].(*mcp.TextContent).Text)
+	_, err = reader.CallTool(context.Background(), &mcp.CallToolParams{Name: "echo", Arguments: map[string]any{}})
+	assert.ErrorContains(t, err, "tool echo is not allowed for queries")
+
+	// a session can't be used with the key of another client
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	require.NoError(t, err)
+	req.Header.Set(apiKeyHeader, "admin")
+	req.Header.Set(sessionIDHeader, reader.ID())
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestServe_AuthSSE(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	err = serve(context.Background(), listener, newTestServer(), HTTPOptions{Mode: ModeSSE, Auth: &AuthConfig{APIKeys: []APIKey{{Name: "queries", Key: "reader", Tools: []string{"query_*"}}}}})
+	assert.EqualError(t, err, "per-key tool permissions require the streamable-http transport")
+}
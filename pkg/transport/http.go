@@ -0,0 +1,284 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lonegunmanb/terraform-mcp-eva/pkg/logging"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Environment variables of the HTTP transports
+const (
+	// SessionIdleTimeoutEnv is how long a streamable HTTP session is kept without requests, like `30m`, `0` keeps
+	// sessions until their client deletes them
+	SessionIdleTimeoutEnv = "TRANSPORT_SESSION_IDLE_TIMEOUT"
+	// MaxSessionsEnv is the maximum number of concurrent streamable HTTP sessions, `0` doesn't limit them
+	MaxSessionsEnv = "TRANSPORT_MAX_SESSIONS"
+)
+
+// Modes of the HTTP transports
+const (
+	ModeStreamableHTTP = "streamable-http"
+	ModeSSE            = "sse"
+)
+
+const (
+	// DefaultSessionIdleTimeout is the default SessionIdleTimeout of HTTPOptions
+	DefaultSessionIdleTimeout = 30 * time.Minute
+	// sessionIDHeader is the header holding the session of a streamable HTTP request
+	sessionIDHeader = "Mcp-Session-Id"
+	// shutdownTimeout is how long in-flight requests get to finish when the server stops
+	shutdownTimeout = 10 * time.Second
+	// readHeaderTimeout is how long a client gets to send the headers of a request
+	readHeaderTimeout = 10 * time.Second
+	// idleConnTimeout is how long a keep-alive connection is kept without requests
+	idleConnTimeout = 2 * time.Minute
+	// minReapInterval is the shortest interval between two checks for idle sessions, however short the timeout is
+	minReapInterval = time.Second
+)
+
+// HTTPOptions configures an HTTP transport
+type HTTPOptions struct {
+	// Mode is ModeStreamableHTTP or ModeSSE for the legacy HTTP+SSE transport
+	Mode string
+	// Addr is the listen address, like `127.0.0.1:8080`
+	Addr string
+	// SessionIdleTimeout closes streamable HTTP sessions without requests for longer, zero keeps them until their
+	// client deletes them
+	SessionIdleTimeout time.Duration
+	// MaxSessions rejects new streamable HTTP sessions once that many are open, zero doesn't limit them
+	MaxSessions int
+	// Auth authenticates the requests, nil accepts every request
+	Auth *AuthConfig
+}
+
+// ServeHTTP serves server over HTTP until ctx is done, every client gets its own session sharing the tools of server.
+// When ctx is done the sessions are closed, letting their in-flight requests finish, before the listener is shut down.
+func ServeHTTP(ctx context.Context, server *mcp.Server, options HTTPOptions) error {
+	listener, err := net.Listen("tcp", options.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", options.Addr, err)
+	}
+	return serve(ctx, listener, server, options)
+}
+
+func serve(ctx context.Context, listener net.Listener, server *mcp.Server, options HTTPOptions) error {
+	logger := logging.FromContext(ctx)
+	var handler http.Handler
+	var sessions *sessionTracker
+	getServer := func(*http.Request) *mcp.Server {
+		return server
+	}
+	switch options.Mode {
+	case ModeStreamableHTTP:
+		sessions = newSessionTracker(mcp.NewStreamableHTTPHandler(getServer, nil), options.MaxSessions)
+		handler = sessions
+	case ModeSSE:
+		handler = mcp.NewSSEHandler(getServer)
+	default:
+		return fmt.Errorf("unknown HTTP transport mode: %s", options.Mode)
+	}
+	if options.Auth != nil {
+		// the tools of a session are restricted by the principal owning its streamable HTTP session ID
+		if options.Mode == ModeSSE && options.Auth.restrictsTools() {
+			return fmt.Errorf("per-key tool permissions require the %s transport", ModeStreamableHTTP)
+		}
+		auth := newAuthenticator(options.Auth)
+		if sessions != nil {
+			sessions.onRemove = auth.forget
+		}
+		server.AddReceivingMiddleware(auth.middleware)
+		handler = auth.wrap(handler)
+	} else if addr, ok := listener.Addr().(*net.TCPAddr); ok && !addr.IP.IsLoopback() {
+		logger.WarnContext(ctx, "MCP server listening without authentication, any client reaching it can call every tool", "addr", addr)
+	}
+	httpServer := &http.Server{Handler: handler, ReadHeaderTimeout: readHeaderTimeout, IdleTimeout: idleConnTimeout}
+	served := make(chan error, 1)
+	go func() {
+		served <- httpServer.Serve(listener)
+	}()
+	logger.InfoContext(ctx, "MCP server serving", "mode", options.Mode, "addr", listener.Addr())
+	if sessions != nil && options.SessionIdleTimeout > 0 {
+		go sessions.reapIdle(ctx, options.SessionIdleTimeout)
+	}
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+	if sessions != nil {
+		sessions.closeAll()
+	}
+	for session := range server.Sessions() {
+		_ = session.Close()
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down the HTTP server: %w", err)
+	}
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// sessionTracker tracks the sessions of a streamable HTTP handler, so idle sessions can be closed, the number of
+// sessions can be limited, and the sessions can be closed when the server stops
+type sessionTracker struct {
+	handler     http.Handler
+	maxSessions int
+	// onRemove is called with the ID of every session removed
+	onRemove func(id string)
+
+	mu       sync.Mutex
+	sessions map[string]*sessionState
+}
+
+// sessionState is the activity of a session
+type sessionState struct {
+	// active is the number of in-flight requests, like the long-lived GET stream of server notifications
+	active   int
+	lastSeen time.Time
+}
+
+func newSessionTracker(handler http.Handler, maxSessions int) *sessionTracker {
+	return &sessionTracker{handler: handler, maxSessions: maxSessions, sessions: make(map[string]*sessionState)}
+}
+
+func (t *sessionTracker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	id := req.Header.Get(sessionIDHeader)
+	if id == "" && req.Method == http.MethodPost && t.full() {
+		http.Error(w, "too many sessions", http.StatusServiceUnavailable)
+		return
+	}
+	if id != "" {
+		t.begin(id)
+		defer t.end(id)
+	}
+	t.handler.ServeHTTP(w, req)
+	switch {
+	case req.Method == http.MethodDelete:
+		t.remove(id)
+	case id == "":
+		// the handler answers the request creating a session with its ID
+		if created := w.Header().Get(sessionIDHeader); created != "" {
+			t.add(created)
+		}
+	}
+}
+
+func (t *sessionTracker) full() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.maxSessions > 0 && len(t.sessions) >= t.maxSessions
+}
+
+func (t *sessionTracker) add(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sessions[id] = &sessionState{lastSeen: time.Now()}
+}
+
+// begin records a request of a session, requests of unknown sessions are answered `404 Not Found` by the handler
+func (t *sessionTracker) begin(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if state, ok := t.sessions[id]; ok {
+		state.active++
+		state.lastSeen = time.Now()
+	}
+}
+
+func (t *sessionTracker) end(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if state, ok := t.sessions[id]; ok {
+		state.active--
+		state.lastSeen = time.Now()
+	}
+}
+
+func (t *sessionTracker) remove(id string) {
+	t.mu.Lock()
+	delete(t.sessions, id)
+	t.mu.Unlock()
+	if t.onRemove != nil {
+		t.onRemove(id)
+	}
+}
+
+// idle returns the sessions without in-flight requests since before deadline
+func (t *sessionTracker) idle(deadline time.Time) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var ids []string
+	for id, state := range t.sessions {
+		if state.active <= 0 && state.lastSeen.Before(deadline) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// reapIdle closes the sessions idle for longer than timeout until ctx is done
+func (t *sessionTracker) reapIdle(ctx context.Context, timeout time.Duration) {
+	logger := logging.FromContext(ctx)
+	ticker := time.NewTicker(max(min(timeout/2, time.Minute), minReapInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, id := range t.idle(now.Add(-timeout)) {
+				logger.InfoContext(ctx, "closing idle MCP session", "session", id, "idle_timeout", timeout)
+				t.close(id)
+			}
+		}
+	}
+}
+
+// closeAll closes every session
+func (t *sessionTracker) closeAll() {
+	t.mu.Lock()
+	ids := make([]string, 0, len(t.sessions))
+	for id := range t.sessions {
+		ids = append(ids, id)
+	}
+	t.mu.Unlock()
+	for _, id := range ids {
+		t.close(id)
+	}
+}
+
+// close deletes a session like its client would, the handler has no other way to forget a session
+func (t *sessionTracker) close(id string) {
+	req, _ := http.NewRequest(http.MethodDelete, "/", nil)
+	req.Header.Set(sessionIDHeader, id)
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	t.handler.ServeHTTP(discardResponseWriter{header: make(http.Header)}, req)
+	t.remove(id)
+}
+
+// discardResponseWriter is the response writer of the requests sent by the tracker itself
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w discardResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w discardResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w discardResponseWriter) WriteHeader(int) {}
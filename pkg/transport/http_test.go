@@ -0,0 +1,114 @@
+package transport
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.1.0"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "echo", Description: "echo"}, func(_ context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]any]) (*mcp.CallToolResultFor[any], error) {
+		return &mcp.CallToolResultFor[any]{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil
+	})
+	return server
+}
+
+func connect(url string) (*mcp.ClientSession, error) {
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "0.1.0"}, nil)
+	return client.Connect(context.Background(), mcp.NewStreamableClientTransport(url, nil))
+}
+
+func TestSessionTracker(t *testing.T) {
+	server := newTestServer()
+	tracker := newSessionTracker(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil), 2)
+	httpServer := httptest.NewServer(tracker)
+	defer httpServer.Close()
+
+	first, err := connect(httpServer.URL)
+	require.NoError(t, err)
+	defer first.Close()
+	second, err := connect(httpServer.URL)
+	require.NoError(t, err)
+	defer second.Close()
+	_, err = first.ListTools(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, tracker.sessions, 2)
+
+	// the sessions are limited
+	_, err = connect(httpServer.URL)
+	assert.Error(t, err)
+
+	// idle sessions are closed, and their ID is forgotten by the handler
+	assert.Empty(t, tracker.idle(time.Now().Add(-time.Minute)))
+	idle := tracker.idle(time.Now().Add(time.Minute))
+	assert.Len(t, idle, 2)
+	tracker.close(first.ID())
+	assert.Len(t, tracker.sessions, 1)
+	_, err = first.ListTools(context.Background(), nil)
+	assert.Error(t, err)
+
+	// a closed session makes room for a new one
+	third, err := connect(httpServer.URL)
+	require.NoError(t, err)
+	defer third.Close()
+	_, err = third.ListTools(context.Background(), nil)
+	require.NoError(t, err)
+}
+
+func TestSessionTracker_ReapIdleTinyTimeout(t *testing.T) {
+	tracker := newSessionTracker(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), 0)
+	tracker.add("idle")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// a timeout shorter than the minimum interval doesn't make the ticker panic
+	go tracker.reapIdle(ctx, time.Nanosecond)
+	assert.Eventually(t, func() bool {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		return len(tracker.sessions) == 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestServe_Shutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(ctx, listener, newTestServer(), HTTPOptions{Mode: ModeStreamableHTTP, SessionIdleTimeout: DefaultSessionIdleTimeout})
+	}()
+
+	session, err := connect("http://" + listener.Addr().String())
+	require.NoError(t, err)
+	defer session.Close()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "echo", Arguments: map[string]any{}})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result.Content[0].(*mcp.TextContent).Text)
+
+	cancel()
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(shutdownTimeout):
+		t.Fatal("the server didn't shut down")
+	}
+	_, err = session.ListTools(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestServe_UnknownMode(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	err = serve(context.Background(), listener, newTestServer(), HTTPOptions{Mode: "websocket"})
+	assert.EqualError(t, err, "unknown HTTP transport mode: websocket")
+}